@@ -0,0 +1,50 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+// Package authorization validates the rules of the authorization policies before they are
+// deployed, so that the enforcer never receives a rule it is not able to evaluate.
+//
+// The rules are written in the APK expression language, a restricted language which uses the
+// syntax of CEL but is not CEL. An expression has
+//   - int, float, string, bool and null literals, lists and maps,
+//   - the variables claims, headers, pathParams, path and method,
+//   - the operators !, -, *, /, %, +, ==, !=, <, <=, >, >=, in, &&, || and ?:,
+//   - the functions has, size, int and string,
+//   - the methods startsWith, endsWith, contains, matches, size, lowerAscii, upperAscii,
+//     exists and all.
+//
+// The regular expressions of matches use the RE2 syntax and are partially matched, as the
+// enforcer evaluates them with the RE2 port of Java. The enforcer parses and checks the
+// expressions with the same rules, and testdata/expressions.json holds the expressions both
+// sides are tested against.
+package authorization
+
+import (
+	"fmt"
+
+	"github.com/wso2/apk/adapter/internal/oasparser/constants"
+)
+
+// ValidateRule checks that the expression of an authorization rule is valid in the given language
+// and only uses the variables and functions supported by the enforcer. An empty language is
+// treated as the APK expression language.
+func ValidateRule(language, expression string) error {
+	switch language {
+	case "", constants.AuthorizationLanguageAPKExpression:
+		return validateExpression(expression)
+	}
+	return fmt.Errorf("unsupported language %q", language)
+}
@@ -0,0 +1,89 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package authorization
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/apk/adapter/internal/oasparser/constants"
+)
+
+// expressionTests are the expressions of testdata/expressions.json, which the enforcer is tested
+// against as well
+type expressionTests struct {
+	Tests []struct {
+		Name       string `json:"name"`
+		Expression string `json:"expression"`
+		Valid      bool   `json:"valid"`
+	} `json:"tests"`
+}
+
+func TestValidateRule(t *testing.T) {
+	content, err := os.ReadFile("testdata/expressions.json")
+	assert.NoError(t, err)
+	var expressions expressionTests
+	assert.NoError(t, json.Unmarshal(content, &expressions))
+	assert.NotEmpty(t, expressions.Tests)
+
+	for _, test := range expressions.Tests {
+		err := ValidateRule(constants.AuthorizationLanguageAPKExpression, test.Expression)
+		if test.Valid {
+			assert.NoError(t, err, test.Name)
+		} else {
+			assert.Error(t, err, test.Name)
+		}
+	}
+}
+
+func TestValidateRuleLanguage(t *testing.T) {
+	tests := []struct {
+		language   string
+		isExpError bool
+		message    string
+	}{
+		{
+			language: "",
+			message:  "APKExpression is the default language",
+		},
+		{
+			language: constants.AuthorizationLanguageAPKExpression,
+			message:  "APKExpression",
+		},
+		{
+			language:   "CEL",
+			isExpError: true,
+			message:    "CEL is not supported",
+		},
+		{
+			language:   "Rego",
+			isExpError: true,
+			message:    "Rego is not supported",
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateRule(test.language, `claims.sub == "alice"`)
+		if test.isExpError {
+			assert.Error(t, err, test.message)
+		} else {
+			assert.NoError(t, err, test.message)
+		}
+	}
+}
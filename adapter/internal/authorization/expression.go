@@ -0,0 +1,149 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package authorization
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// variables are the variables available to the expressions of an authorization rule
+var variables = map[string]bool{"claims": true, "headers": true, "pathParams": true, "path": true, "method": true}
+
+// functions maps the supported global functions to their number of arguments
+var functions = map[string]int{"has": 1, "size": 1, "int": 1, "string": 1}
+
+// methods maps the supported methods to their number of arguments
+var methods = map[string]int{"startsWith": 1, "endsWith": 1, "contains": 1, "matches": 1, "size": 0,
+	"lowerAscii": 0, "upperAscii": 0, "exists": 2, "all": 2}
+
+// validateExpression checks that the expression is one the enforcer is able to evaluate
+func validateExpression(expression string) error {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpression()
+	if err != nil {
+		return err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return unexpectedToken(t, "end of expression")
+	}
+	return check(root, map[string]bool{})
+}
+
+func check(n node, locals map[string]bool) error {
+	switch n := n.(type) {
+	case *literalNode:
+		if n.kind == tokenInt {
+			if _, err := strconv.ParseInt(n.value, 10, 64); err != nil {
+				return fmt.Errorf("integer %s at position %d is out of range", n.value, n.pos)
+			}
+		}
+		return nil
+	case *identNode:
+		if !variables[n.name] && !locals[n.name] {
+			return fmt.Errorf("undeclared reference to %q at position %d", n.name, n.pos)
+		}
+		return nil
+	case *selectNode:
+		return check(n.operand, locals)
+	case *indexNode:
+		if err := check(n.operand, locals); err != nil {
+			return err
+		}
+		return check(n.index, locals)
+	case *callNode:
+		return checkCall(n, locals)
+	case *listNode:
+		return checkNodes(n.elements, locals)
+	case *mapNode:
+		if err := checkNodes(n.keys, locals); err != nil {
+			return err
+		}
+		return checkNodes(n.values, locals)
+	case *unaryNode:
+		return check(n.operand, locals)
+	case *binaryNode:
+		if err := check(n.left, locals); err != nil {
+			return err
+		}
+		return check(n.right, locals)
+	case *conditionalNode:
+		return checkNodes([]node{n.condition, n.ifTrue, n.ifFalse}, locals)
+	}
+	return fmt.Errorf("unsupported expression")
+}
+
+func checkCall(n *callNode, locals map[string]bool) error {
+	if n.target == nil {
+		arity, found := functions[n.function]
+		if !found {
+			return fmt.Errorf("unsupported function %q at position %d", n.function, n.pos)
+		}
+		if len(n.args) != arity {
+			return fmt.Errorf("function %q at position %d expects %d argument(s)", n.function, n.pos, arity)
+		}
+		if n.function == "has" {
+			if _, isSelect := n.args[0].(*selectNode); !isSelect {
+				return fmt.Errorf("argument of has() at position %d must be a field selection", n.pos)
+			}
+		}
+		return checkNodes(n.args, locals)
+	}
+	arity, found := methods[n.function]
+	if !found {
+		return fmt.Errorf("unsupported method %q at position %d", n.function, n.pos)
+	}
+	if len(n.args) != arity {
+		return fmt.Errorf("method %q at position %d expects %d argument(s)", n.function, n.pos, arity)
+	}
+	if err := check(n.target, locals); err != nil {
+		return err
+	}
+	switch n.function {
+	case "exists", "all":
+		variable, isIdent := n.args[0].(*identNode)
+		if !isIdent {
+			return fmt.Errorf("first argument of %q at position %d must be a variable name", n.function, n.pos)
+		}
+		scoped := map[string]bool{variable.name: true}
+		for local := range locals {
+			scoped[local] = true
+		}
+		return check(n.args[1], scoped)
+	case "matches":
+		if pattern, isLiteral := n.args[0].(*literalNode); isLiteral && pattern.kind == tokenString {
+			if _, err := regexp.Compile(pattern.value); err != nil {
+				return fmt.Errorf("invalid regular expression at position %d: %v", n.pos, err)
+			}
+		}
+	}
+	return checkNodes(n.args, locals)
+}
+
+func checkNodes(nodes []node, locals map[string]bool) error {
+	for _, n := range nodes {
+		if err := check(n, locals); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package authorization
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// operators are ordered so that the longer operators are matched first
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "+", "-", "*", "/", "%", "?", ":",
+	".", ",", "(", ")", "[", "]", "{", "}"}
+
+// tokenize splits an expression into tokens
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(input); {
+		c := input[pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			pos++
+		case strings.HasPrefix(input[pos:], "//"):
+			for pos < len(input) && input[pos] != '\n' {
+				pos++
+			}
+		case isLetter(c):
+			start := pos
+			for pos < len(input) && (isLetter(input[pos]) || isDigit(input[pos])) {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: input[start:pos], pos: start})
+		case isDigit(c):
+			start := pos
+			kind := tokenInt
+			for pos < len(input) && isDigit(input[pos]) {
+				pos++
+			}
+			if pos+1 < len(input) && input[pos] == '.' && isDigit(input[pos+1]) {
+				kind = tokenFloat
+				pos++
+				for pos < len(input) && isDigit(input[pos]) {
+					pos++
+				}
+			}
+			tokens = append(tokens, token{kind: kind, value: input[start:pos], pos: start})
+		case c == '"' || c == '\'':
+			value, end, err := readString(input, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value, pos: pos})
+			pos = end
+		default:
+			operator := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(input[pos:], candidate) {
+					operator = candidate
+					break
+				}
+			}
+			if operator == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, pos)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, value: operator, pos: pos})
+			pos += len(operator)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(input)}), nil
+}
+
+// readString reads the quoted string starting at start and returns its value and the position
+// after the closing quote.
+func readString(input string, start int) (string, int, error) {
+	quote := input[start]
+	var value strings.Builder
+	for pos := start + 1; pos < len(input); pos++ {
+		c := input[pos]
+		switch {
+		case c == quote:
+			return value.String(), pos + 1, nil
+		case c == '\n':
+			return "", 0, fmt.Errorf("unterminated string at position %d", start)
+		case c == '\\':
+			pos++
+			if pos == len(input) {
+				return "", 0, fmt.Errorf("unterminated string at position %d", start)
+			}
+			switch input[pos] {
+			case '\\', '"', '\'':
+				value.WriteByte(input[pos])
+			case 'n':
+				value.WriteByte('\n')
+			case 'r':
+				value.WriteByte('\r')
+			case 't':
+				value.WriteByte('\t')
+			default:
+				return "", 0, fmt.Errorf("invalid escape sequence \\%c at position %d", input[pos], pos-1)
+			}
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string at position %d", start)
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
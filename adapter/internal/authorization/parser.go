@@ -0,0 +1,352 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package authorization
+
+import (
+	"fmt"
+)
+
+// node is a node of the syntax tree of an expression
+type node interface{}
+
+type literalNode struct {
+	value string
+	kind  tokenKind
+	pos   int
+}
+
+type identNode struct {
+	name string
+	pos  int
+}
+
+type selectNode struct {
+	operand node
+	field   string
+}
+
+type indexNode struct {
+	operand node
+	index   node
+}
+
+// callNode is a function call, or a method call when the target is set
+type callNode struct {
+	target   node
+	function string
+	args     []node
+	pos      int
+}
+
+type listNode struct {
+	elements []node
+}
+
+type mapNode struct {
+	keys   []node
+	values []node
+}
+
+type unaryNode struct {
+	operator string
+	operand  node
+}
+
+type binaryNode struct {
+	operator string
+	left     node
+	right    node
+}
+
+type conditionalNode struct {
+	condition node
+	ifTrue    node
+	ifFalse   node
+}
+
+// parser is a recursive descent parser of the expressions
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(operator string) bool {
+	t := p.peek()
+	return t.kind == tokenOperator && t.value == operator
+}
+
+func (p *parser) isKeyword(keyword string) bool {
+	t := p.peek()
+	return t.kind == tokenIdent && t.value == keyword
+}
+
+func (p *parser) expectOperator(operator string) error {
+	t := p.next()
+	if t.kind != tokenOperator || t.value != operator {
+		return unexpectedToken(t, fmt.Sprintf("%q", operator))
+	}
+	return nil
+}
+
+func (p *parser) parseExpression() (node, error) {
+	condition, err := p.parseOr()
+	if err != nil || !p.isOperator("?") {
+		return condition, err
+	}
+	p.next()
+	ifTrue, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectOperator(":"); err != nil {
+		return nil, err
+	}
+	ifFalse, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	return &conditionalNode{condition: condition, ifTrue: ifTrue, ifFalse: ifFalse}, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseBinary(p.parseAnd, "||")
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseBinary(p.parseRelation, "&&")
+}
+
+func (p *parser) parseRelation() (node, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	for _, operator := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.isOperator(operator) {
+			p.next()
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			return &binaryNode{operator: operator, left: left, right: right}, nil
+		}
+	}
+	if p.isKeyword("in") {
+		p.next()
+		right, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &binaryNode{operator: "in", left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseAdditive() (node, error) {
+	return p.parseBinary(p.parseMultiplicative, "+", "-")
+}
+
+func (p *parser) parseMultiplicative() (node, error) {
+	return p.parseBinary(p.parseUnary, "*", "/", "%")
+}
+
+func (p *parser) parseBinary(parseOperand func() (node, error), operators ...string) (node, error) {
+	left, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		operator := ""
+		for _, candidate := range operators {
+			if p.isOperator(candidate) {
+				operator = candidate
+			}
+		}
+		if operator == "" {
+			return left, nil
+		}
+		p.next()
+		right, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{operator: operator, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperator("!") || p.isOperator("-") {
+		operator := p.next().value
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{operator: operator, operand: operand}, nil
+	}
+	return p.parseMember()
+}
+
+func (p *parser) parseMember() (node, error) {
+	operand, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.isOperator("."):
+			p.next()
+			field := p.next()
+			if field.kind != tokenIdent {
+				return nil, unexpectedToken(field, "a field name")
+			}
+			if p.isOperator("(") {
+				args, err := p.parseArguments("(", ")")
+				if err != nil {
+					return nil, err
+				}
+				operand = &callNode{target: operand, function: field.value, args: args, pos: field.pos}
+			} else {
+				operand = &selectNode{operand: operand, field: field.value}
+			}
+		case p.isOperator("["):
+			p.next()
+			index, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOperator("]"); err != nil {
+				return nil, err
+			}
+			operand = &indexNode{operand: operand, index: index}
+		default:
+			return operand, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokenInt || t.kind == tokenFloat || t.kind == tokenString:
+		p.next()
+		return &literalNode{value: t.value, kind: t.kind, pos: t.pos}, nil
+	case t.kind == tokenIdent:
+		p.next()
+		switch t.value {
+		case "true", "false", "null":
+			return &literalNode{value: t.value, kind: tokenIdent, pos: t.pos}, nil
+		}
+		if p.isOperator("(") {
+			args, err := p.parseArguments("(", ")")
+			if err != nil {
+				return nil, err
+			}
+			return &callNode{function: t.value, args: args, pos: t.pos}, nil
+		}
+		return &identNode{name: t.value, pos: t.pos}, nil
+	case p.isOperator("("):
+		p.next()
+		expression, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectOperator(")"); err != nil {
+			return nil, err
+		}
+		return expression, nil
+	case p.isOperator("["):
+		elements, err := p.parseArguments("[", "]")
+		if err != nil {
+			return nil, err
+		}
+		return &listNode{elements: elements}, nil
+	case p.isOperator("{"):
+		return p.parseMap()
+	}
+	return nil, unexpectedToken(t, "an expression")
+}
+
+// parseArguments parses a comma separated list of expressions enclosed by open and close
+func (p *parser) parseArguments(open, close string) ([]node, error) {
+	if err := p.expectOperator(open); err != nil {
+		return nil, err
+	}
+	var args []node
+	for !p.isOperator(close) {
+		if len(args) > 0 {
+			if err := p.expectOperator(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	p.next()
+	return args, nil
+}
+
+func (p *parser) parseMap() (node, error) {
+	if err := p.expectOperator("{"); err != nil {
+		return nil, err
+	}
+	mapNode := &mapNode{}
+	for !p.isOperator("}") {
+		if len(mapNode.keys) > 0 {
+			if err := p.expectOperator(","); err != nil {
+				return nil, err
+			}
+		}
+		key, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectOperator(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		mapNode.keys = append(mapNode.keys, key)
+		mapNode.values = append(mapNode.values, value)
+	}
+	p.next()
+	return mapNode, nil
+}
+
+func unexpectedToken(t token, expected string) error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("unexpected end of expression, expected %s", expected)
+	}
+	return fmt.Errorf("unexpected %q at position %d, expected %s", t.value, t.pos, expected)
+}
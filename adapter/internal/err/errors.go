@@ -101,4 +101,35 @@ const (
 	UmsdrCode = 102516
 	// UmsdrMessage upstream request reached max stream duration error message
 	UmsdrMessage = "Upstream request reached max stream duration"
+
+	// PayloadTooLargeCode request body exceeds the payload policy limit error code
+	PayloadTooLargeCode = 102517
+	// PayloadTooLargeMessage request body exceeds the payload policy limit error message
+	PayloadTooLargeMessage = "Payload too large"
+	// PayloadTooLargeDescription request body exceeds the payload policy limit error description
+	PayloadTooLargeDescription = "The request body exceeds the maximum size allowed for the resource."
+
+	// UnsupportedMediaTypeCode request content type is not allowed by the payload policy error code
+	UnsupportedMediaTypeCode = 102518
+	// UnsupportedMediaTypeMessage request content type is not allowed by the payload policy error message
+	UnsupportedMediaTypeMessage = "Unsupported media type"
+	// UnsupportedMediaTypeDescription request content type is not allowed by the payload policy error description
+	UnsupportedMediaTypeDescription = "The content type of the request is not allowed for the resource."
+
+	// ResponseTooLargeCode response body exceeds the payload policy limit error code
+	ResponseTooLargeCode = 102519
+	// ResponseTooLargeMessage response body exceeds the payload policy limit error message
+	ResponseTooLargeMessage = "Response payload too large"
+	// ResponseTooLargeDescription response body exceeds the payload policy limit error description
+	ResponseTooLargeDescription = "The response body exceeds the maximum size allowed for the resource."
+
+	// PromptGuardrailViolationCode prompt violates the AI guardrails error code
+	PromptGuardrailViolationCode = 102520
+	// PromptGuardrailViolationMessage prompt violates the AI guardrails error message
+	PromptGuardrailViolationMessage = "Prompt rejected by guardrails"
+
+	// ResponseGuardrailViolationCode AI provider response violates the AI guardrails error code
+	ResponseGuardrailViolationCode = 102521
+	// ResponseGuardrailViolationMessage AI provider response violates the AI guardrails error message
+	ResponseGuardrailViolationMessage = "Response rejected by guardrails"
 )
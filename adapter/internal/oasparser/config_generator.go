@@ -207,6 +207,17 @@ func GetEnforcerAPI(adapterInternalAPI *model.AdapterInternalAPI, vhost string)
 
 	logger.LoggerOasparser.Debugf("After Conversion AI Provider: %+v", aiProvider)
 
+	var graphqlComplexityInfo []*api.GraphqlComplexity
+	if graphQLProtection := adapterInternalAPI.GetGraphQLProtection(); graphQLProtection != nil {
+		for _, fieldCost := range graphQLProtection.FieldCosts {
+			graphqlComplexityInfo = append(graphqlComplexityInfo, &api.GraphqlComplexity{
+				Type:            fieldCost.Type,
+				Field:           fieldCost.Field,
+				ComplexityValue: fieldCost.Cost,
+			})
+		}
+	}
+
 	return &api.Api{
 		Id:                     adapterInternalAPI.UUID,
 		Title:                  adapterInternalAPI.GetTitle(),
@@ -230,7 +241,7 @@ func GetEnforcerAPI(adapterInternalAPI *model.AdapterInternalAPI, vhost string)
 		ApplicationSecurity: adapterInternalAPI.GetApplicationSecurity(),
 		TransportSecurity:   !adapterInternalAPI.GetDisableMtls(),
 		// GraphQLSchema:         adapterInternalAPI.GraphQLSchema,
-		// GraphqlComplexityInfo: adapterInternalAPI.GraphQLComplexities.Data.List,
+		GraphqlComplexityInfo:  graphqlComplexityInfo,
 		SystemAPI:              adapterInternalAPI.IsSystemAPI,
 		ApiDefinitionFile:      adapterInternalAPI.GetAPIDefinitionFile(),
 		Environment:            adapterInternalAPI.GetEnvironment(),
@@ -267,6 +278,14 @@ func GetEnforcerAPIOperation(operation model.Operation, isMockedAPI bool) *api.O
 		MatchID:           operation.GetMatchID(),
 		// MockedApiConfig: mockedAPIConfig,
 	}
+	for _, rule := range operation.GetAuthorizationRules() {
+		apiOperation.AuthorizationRules = append(apiOperation.AuthorizationRules, &api.AuthorizationRule{
+			Name:       rule.Name,
+			Language:   rule.Language,
+			Expression: rule.Expression,
+			Message:    rule.Message,
+		})
+	}
 	return &apiOperation
 }
 
@@ -302,6 +321,16 @@ func castAPIAuthenticationsToEnforcerAPIAuthentications(authentication *model.Au
 			SendTokenToUpstream: authentication.Oauth2.SendTokenToUpstream,
 		}
 	}
+	if authentication.HMAC != nil {
+		enforcerAuthentication.Hmac = &api.HMAC{
+			Header:              strings.ToLower(authentication.HMAC.Header),
+			Keys:                authentication.HMAC.Keys,
+			Algorithms:          authentication.HMAC.Algorithms,
+			SignedHeaders:       authentication.HMAC.SignedHeaders,
+			ClockSkew:           authentication.HMAC.ClockSkew,
+			SendTokenToUpstream: authentication.HMAC.SendTokenToUpstream,
+		}
+	}
 	return enforcerAuthentication
 }
 
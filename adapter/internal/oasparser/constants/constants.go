@@ -50,6 +50,7 @@ const (
 const (
 	AuthorizationHeader  string = "authorization"
 	TestConsoleKeyHeader string = "internal-key"
+	SignatureHeader      string = "signature"
 )
 
 // sub-property keys mentioned under x-wso2-request-interceptor and x-wso2-response-interceptor
@@ -78,15 +79,22 @@ const (
 	ActionRewritePath        string = "REWRITE_RESOURCE_PATH"
 	ActionRedirectRequest    string = "REDIRECT_REQUEST"
 	ActionMirrorRequest      string = "MIRROR_REQUEST"
+	ActionTransformBody      string = "TRANSFORM_BODY"
+	ActionMediateSOAP        string = "MEDIATE_SOAP"
 
-	PolicyRequestInterceptor  string = "PolicyRequestInterceptor"
-	PolicyResponseInterceptor string = "PolicyResponseInterceptor"
+	PolicyRequestInterceptor         string = "PolicyRequestInterceptor"
+	PolicyResponseInterceptor        string = "PolicyResponseInterceptor"
+	PolicyRequestBodyTransformation  string = "PolicyRequestBodyTransformation"
+	PolicyResponseBodyTransformation string = "PolicyResponseBodyTransformation"
+	PolicySOAPMediation              string = "PolicySOAPMediation"
 
 	RewritePathResourcePath    string = "resourcePath"
 	RewritePathType            string = "rewritePathType"
 	InterceptorServiceURL      string = "interceptorServiceURL"
 	InterceptorEndpoints       string = "interceptorEndpoints"
 	InterceptorServiceIncludes string = "includes"
+	BodyTransformation         string = "bodyTransformation"
+	SOAPMediation              string = "soapMediation"
 	IncludeQueryParams         string = "includeQueryParams"
 	HeaderName                 string = "headerName"
 	HeaderValue                string = "headerValue"
@@ -114,6 +122,11 @@ const (
 	InlineEndpointType    string = "INLINE"
 )
 
+// GraphQL operation type constants
+const (
+	GQLSubscription string = "SUBSCRIPTION"
+)
+
 // Constants used for version identification of API definitions
 const (
 	Swagger      string = "swagger"
@@ -134,12 +147,13 @@ const (
 
 // CRD Kinds
 const (
-	KindAuthentication  = "Authentication"
-	KindAPIPolicy       = "APIPolicy"
-	KindScope           = "Scope"
-	KindRateLimitPolicy = "RateLimitPolicy"
-	KindService         = "Service"
-	KindBackend         = "Backend"
+	KindAuthentication      = "Authentication"
+	KindAPIPolicy           = "APIPolicy"
+	KindScope               = "Scope"
+	KindRateLimitPolicy     = "RateLimitPolicy"
+	KindService             = "Service"
+	KindBackend             = "Backend"
+	KindAuthorizationPolicy = "AuthorizationPolicy"
 )
 
 // API environment types
@@ -152,4 +166,11 @@ const (
 const (
 	OAuth2 string = "OAuth2"
 	APIKey string = "APIKey"
+	HMAC   string = "HMAC"
 )
+
+// DefaultHMACAlgorithm is the signature algorithm accepted when none is configured for HMAC authentication
+const DefaultHMACAlgorithm string = "hmac-sha256"
+
+// AuthorizationLanguageAPKExpression is the expression language of authorization rules when none is configured
+const AuthorizationLanguageAPKExpression string = "APKExpression"
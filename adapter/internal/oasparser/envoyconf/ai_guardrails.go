@@ -0,0 +1,65 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	access_logv3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	envoy_type_matcher_v3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	logger "github.com/wso2/apk/adapter/internal/loggers"
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
+)
+
+// getAIGuardrailsMetadataValue returns the guardrails of the API encoded to be passed to the enforcer in the
+// route metadata. The json is base64 encoded as the enforcer reads the route metadata in the text format.
+func getAIGuardrailsMetadataValue(aiGuardrails *model.AIGuardrails) string {
+	guardrailsJSON, err := json.Marshal(aiGuardrails)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the AI guardrails. %v", err)
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(guardrailsJSON)
+}
+
+// genAIGuardrailFilters returns the filters matching the local replies of the enforcer rejecting a payload
+// of the given direction for violating the AI guardrails.
+func genAIGuardrailFilters(direction string) []*access_logv3.AccessLogFilter {
+	return []*access_logv3.AccessLogFilter{
+		{
+			FilterSpecifier: &access_logv3.AccessLogFilter_MetadataFilter{
+				MetadataFilter: &access_logv3.MetadataFilter{
+					Matcher: &envoy_type_matcher_v3.MetadataMatcher{
+						Filter: HTTPExternalProcessor,
+						Value: &envoy_type_matcher_v3.ValueMatcher{
+							MatchPattern: &envoy_type_matcher_v3.ValueMatcher_StringMatch{
+								StringMatch: &envoy_type_matcher_v3.StringMatcher{
+									MatchPattern: &envoy_type_matcher_v3.StringMatcher_Exact{Exact: direction},
+								},
+							},
+						},
+						Path: []*envoy_type_matcher_v3.MetadataMatcher_PathSegment{
+							{Segment: &envoy_type_matcher_v3.MetadataMatcher_PathSegment_Key{Key: aiGuardrailDirectionMetadataKey}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,37 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	logger "github.com/wso2/apk/adapter/internal/loggers"
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
+)
+
+// getAIResponseCacheMetadataValue returns the AI response cache configurations of the API encoded to be passed
+// to the enforcer in the route metadata.
+func getAIResponseCacheMetadataValue(aiResponseCache *model.AIResponseCache) string {
+	cacheJSON, err := json.Marshal(aiResponseCache)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the AI response cache configurations. %v", err)
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(cacheJSON)
+}
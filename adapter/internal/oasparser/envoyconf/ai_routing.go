@@ -0,0 +1,528 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	aggregatev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/clusters/aggregate/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	upstreamcodecv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/upstream_codec/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	upstreams "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
+	"github.com/golang/protobuf/ptypes/any"
+	logger "github.com/wso2/apk/adapter/internal/loggers"
+	"github.com/wso2/apk/adapter/internal/logging"
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// createAIRoutingClusters creates a cluster per target of the AI routing policy of the API and an aggregate
+// cluster per failover chain, which sends the requests to the target clusters in the order of the chain.
+func createAIRoutingClusters(adapterInternalAPI *model.AdapterInternalAPI, vHost string, organizationID string,
+	timeout time.Duration, processedEndpoints map[string]model.EndpointCluster) ([]*clusterv3.Cluster,
+	[]*corev3.Address, *aiRoutingClusters) {
+	aiRouting := adapterInternalAPI.GetAIRouting()
+	if aiRouting == nil || len(aiRouting.Targets) == 0 {
+		return nil, nil, nil
+	}
+	apiTitle := adapterInternalAPI.GetTitle()
+	apiVersion := adapterInternalAPI.GetVersion()
+	var clusters []*clusterv3.Cluster
+	var addresses []*corev3.Address
+
+	apiModel := adapterInternalAPI.GetAIProvider().Model
+	securityHeaders, securityQueryParams := getEndpointSecurityParams(adapterInternalAPI)
+	targetClusterNames := make([]string, 0, len(aiRouting.Targets))
+	for i, target := range aiRouting.Targets {
+		endpointCluster := target.EndpointCluster
+		if endpointCluster == nil || len(endpointCluster.Endpoints) == 0 {
+			logger.LoggerOasparser.ErrorC(logging.PrintError(logging.Error2239, logging.MAJOR,
+				"No endpoints found in backend %s of the AI routing policy of %s:%v", target.BackendName, apiTitle, apiVersion))
+			return nil, nil, nil
+		}
+		// the targets which rewrite the requests get a cluster of their own, which is not shared with the routes
+		targetScript := getAIRoutingTargetLuaScript(target, apiModel.In, apiModel.Value, securityHeaders,
+			securityQueryParams)
+		clusterName := ""
+		if targetScript == "" {
+			clusterName = getExistingClusterName(*endpointCluster, processedEndpoints)
+		}
+		if clusterName == "" {
+			clusterName = getClusterName(endpointCluster.EndpointPrefix, organizationID, vHost, apiTitle, apiVersion,
+				fmt.Sprintf("%s_target_%d", aiRoutingClusterPrefix, i))
+			basePath := strings.TrimSuffix(endpointCluster.Endpoints[0].Basepath, "/")
+			cluster, address, err := processEndpoints(clusterName, endpointCluster, timeout, basePath)
+			if err == nil && targetScript != "" {
+				err = addUpstreamLuaFilter(cluster, aiRoutingTargetFilterName, targetScript)
+			}
+			if err != nil {
+				logger.LoggerOasparser.ErrorC(logging.PrintError(logging.Error2239, logging.MAJOR,
+					"Error while adding endpoints of backend %s of the AI routing policy of %s:%v. %v", target.BackendName,
+					apiTitle, apiVersion, err.Error()))
+				return nil, nil, nil
+			}
+			clusters = append(clusters, cluster)
+			addresses = append(addresses, address...)
+			if targetScript == "" {
+				processedEndpoints[clusterName] = *endpointCluster
+			}
+		}
+		targetClusterNames = append(targetClusterNames, clusterName)
+	}
+
+	routingClusters := &aiRoutingClusters{
+		modelClusters: make(map[string]string),
+		modelIn:       apiModel.In,
+		modelValue:    apiModel.Value,
+		policy:        aiRouting,
+	}
+	// chains with the same targets share the aggregate cluster
+	chainClusters := make(map[string]string)
+	getChainCluster := func(chain []int) (string, error) {
+		clusterNames := make([]string, 0, len(chain))
+		for _, targetIndex := range chain {
+			clusterNames = append(clusterNames, targetClusterNames[targetIndex])
+		}
+		chainKey := strings.Join(clusterNames, ",")
+		if clusterName, exists := chainClusters[chainKey]; exists {
+			return clusterName, nil
+		}
+		clusterName := getClusterName("", organizationID, vHost, apiTitle, apiVersion,
+			fmt.Sprintf("%s_chain_%d", aiRoutingClusterPrefix, len(chainClusters)))
+		cluster, err := createAggregateCluster(clusterName, clusterNames, timeout)
+		if err != nil {
+			return "", err
+		}
+		clusters = append(clusters, cluster)
+		chainClusters[chainKey] = clusterName
+		if retries := uint32(len(chain) - 1); retries > routingClusters.numRetries {
+			routingClusters.numRetries = retries
+		}
+		return clusterName, nil
+	}
+
+	modelChains, defaultChain := aiRouting.GetFailoverChains()
+	var err error
+	if routingClusters.defaultCluster, err = getChainCluster(defaultChain); err != nil {
+		logger.LoggerOasparser.ErrorC(logging.PrintError(logging.Error2239, logging.MAJOR,
+			"Error while creating the AI routing clusters of %s:%v. %v", apiTitle, apiVersion, err.Error()))
+		return nil, nil, nil
+	}
+	modelNames := make([]string, 0, len(modelChains))
+	for modelName := range modelChains {
+		modelNames = append(modelNames, modelName)
+	}
+	sort.Strings(modelNames)
+	for _, modelName := range modelNames {
+		if routingClusters.modelClusters[modelName], err = getChainCluster(modelChains[modelName]); err != nil {
+			logger.LoggerOasparser.ErrorC(logging.PrintError(logging.Error2239, logging.MAJOR,
+				"Error while creating the AI routing clusters of %s:%v. %v", apiTitle, apiVersion, err.Error()))
+			return nil, nil, nil
+		}
+	}
+	return clusters, addresses, routingClusters
+}
+
+// createAggregateCluster creates a cluster which sends the requests to the first healthy cluster of the given
+// clusters. The route retry policy moves the retries of a request to the next cluster.
+func createAggregateCluster(clusterName string, clusterNames []string, timeout time.Duration) (*clusterv3.Cluster, error) {
+	aggregateConfig, err := anypb.New(&aggregatev3.ClusterConfig{
+		Clusters: clusterNames,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &clusterv3.Cluster{
+		Name:           clusterName,
+		ConnectTimeout: durationpb.New(timeout * time.Second),
+		LbPolicy:       clusterv3.Cluster_CLUSTER_PROVIDED,
+		ClusterDiscoveryType: &clusterv3.Cluster_ClusterType{
+			ClusterType: &clusterv3.Cluster_CustomClusterType{
+				Name:        aggregateClusterTypeName,
+				TypedConfig: aggregateConfig,
+			},
+		},
+	}, nil
+}
+
+// getAIRoutingFilterConfigs returns a copy of the given per route filter configs which enables the AI routing
+// lua filter to select the cluster for the requested model.
+func getAIRoutingFilterConfigs(perRouteFilterConfigs map[string]*any.Any,
+	routingClusters *aiRoutingClusters) map[string]*any.Any {
+	filterConfigs := make(map[string]*any.Any, len(perRouteFilterConfigs)+1)
+	for name, filterConfig := range perRouteFilterConfigs {
+		filterConfigs[name] = filterConfig
+	}
+	luaPerRoute := &lua.LuaPerRoute{
+		Override: &lua.LuaPerRoute_SourceCode{
+			SourceCode: &corev3.DataSource{
+				Specifier: &corev3.DataSource_InlineString{
+					InlineString: getAIRoutingLuaScript(routingClusters),
+				},
+			},
+		},
+	}
+	if filterConfig := enableFilterForRoute(luaPerRoute); filterConfig != nil {
+		filterConfigs[LuaAIRouting] = filterConfig
+	}
+	return filterConfigs
+}
+
+// getAIRoutingLuaScript returns the lua script which reads the requested model from the header or the top level
+// field of the json body and sets the aggregate cluster of the model to the AI routing cluster header. The route is
+// recomputed to pick the header.
+func getAIRoutingLuaScript(routingClusters *aiRoutingClusters) string {
+	modelNames := make([]string, 0, len(routingClusters.modelClusters))
+	for modelName := range routingClusters.modelClusters {
+		modelNames = append(modelNames, modelName)
+	}
+	sort.Strings(modelNames)
+	modelClusters := make([]string, 0, len(modelNames))
+	for _, modelName := range modelNames {
+		modelClusters = append(modelClusters, fmt.Sprintf("[%q] = %q", modelName, routingClusters.modelClusters[modelName]))
+	}
+
+	return fmt.Sprintf(`%s
+local model_clusters = { %s }
+local default_cluster = %q
+function envoy_on_request(request_handle)
+	local model = %s
+	local cluster = default_cluster
+	if model ~= nil and model_clusters[model] ~= nil then
+		cluster = model_clusters[model]
+	end
+	request_handle:headers():replace(%q, cluster)
+	request_handle:clearRouteCache()
+end
+function envoy_on_response(response_handle)
+end
+`, aiRoutingLuaFunctions, strings.Join(modelClusters, ", "), routingClusters.defaultCluster,
+		getLuaModelExpression("request_handle", routingClusters.modelIn, routingClusters.modelValue),
+		aiRoutingClusterHeaderName)
+}
+
+// getAIRoutingTargetLuaScript returns the upstream lua script of the cluster of an AI routing target, which
+// replaces the credentials set for the endpoint security of the API with the credentials of the backend of the
+// target and sets the model in the model location of the provider of the target. An empty script is returned if
+// the requests are sent to the target unchanged.
+func getAIRoutingTargetLuaScript(target model.AIRoutingTarget, apiModelIn, apiModelValue string,
+	securityHeaders, securityQueryParams []string) string {
+	targetModelIn, targetModelValue := apiModelIn, apiModelValue
+	if target.ProviderName != "" {
+		targetModelIn, targetModelValue = target.ModelIn, target.ModelValue
+	}
+	apiModelInHeader, apiModelName := getModelLocation(apiModelIn, apiModelValue)
+	targetModelInHeader, targetModelName := getModelLocation(targetModelIn, targetModelValue)
+	rewriteModel := target.Model != "" || apiModelInHeader != targetModelInHeader || apiModelName != targetModelName
+	if !rewriteModel && target.Security == nil && len(securityHeaders) == 0 && len(securityQueryParams) == 0 {
+		return ""
+	}
+
+	var statements []string
+	for _, header := range securityHeaders {
+		statements = append(statements, fmt.Sprintf("headers:remove(%q)", header))
+	}
+	rewritePath := len(securityQueryParams) > 0
+	for _, queryParam := range securityQueryParams {
+		statements = append(statements, fmt.Sprintf("path = remove_query_param(path, %q)", queryParam))
+	}
+	if target.Security != nil {
+		switch target.Security.Type {
+		case "Basic":
+			credentials := base64.StdEncoding.EncodeToString([]byte(target.Security.Username + ":" + target.Security.Password))
+			statements = append(statements, fmt.Sprintf("headers:replace(%q, %q)", "authorization", "Basic "+credentials))
+		case "APIKey":
+			key := target.Security.CustomParameters["key"]
+			value := target.Security.CustomParameters["value"]
+			if strings.EqualFold(target.Security.CustomParameters["in"], "Query") {
+				rewritePath = true
+				statements = append(statements, fmt.Sprintf("path = add_query_param(path, %q)",
+					url.QueryEscape(key)+"="+url.QueryEscape(value)))
+			} else {
+				statements = append(statements, fmt.Sprintf("headers:replace(%q, %q)", strings.ToLower(key), value))
+			}
+		}
+	}
+	if rewritePath {
+		statements = append([]string{`local path = headers:get(":path")`}, statements...)
+		statements = append(statements, `headers:replace(":path", path)`)
+	}
+
+	if rewriteModel {
+		var modelExpression string
+		// the models read from the json body are already escaped for json
+		escaped := !apiModelInHeader
+		if target.Model != "" {
+			modelExpression = fmt.Sprintf("%q", target.Model)
+			escaped = false
+		} else {
+			modelExpression = getLuaModelExpression("request_handle", apiModelIn, apiModelValue)
+		}
+		statements = append(statements, "local model = "+modelExpression, "if model ~= nil then")
+		switch {
+		case targetModelInHeader:
+			statements = append(statements, fmt.Sprintf("\theaders:replace(%q, model)", targetModelName))
+		case escaped:
+			statements = append(statements, fmt.Sprintf("\tset_body_model(request_handle, %q, model)", targetModelName))
+		default:
+			statements = append(statements, fmt.Sprintf("\tset_body_model(request_handle, %q, escape_json(model))",
+				targetModelName))
+		}
+		statements = append(statements, "end")
+	}
+
+	return fmt.Sprintf(`%s
+function envoy_on_request(request_handle)
+	local headers = request_handle:headers()
+	%s
+end
+function envoy_on_response(response_handle)
+end
+`, aiRoutingLuaFunctions, strings.Join(statements, "\n\t"))
+}
+
+// getModelLocation returns whether the model is sent in a header and the name of the header or the top level
+// field of the json body carrying the model, which is named by the last segment of the path.
+func getModelLocation(modelIn, modelValue string) (bool, string) {
+	if strings.EqualFold(modelIn, "Header") && modelValue != "" {
+		return true, strings.ToLower(modelValue)
+	}
+	if modelValue == "" {
+		return false, "model"
+	}
+	segments := strings.Split(modelValue, ".")
+	return false, segments[len(segments)-1]
+}
+
+// getLuaModelExpression returns the lua expression which reads the model of the request from the given model
+// location.
+func getLuaModelExpression(handle, modelIn, modelValue string) string {
+	inHeader, name := getModelLocation(modelIn, modelValue)
+	if inHeader {
+		return fmt.Sprintf("%s:headers():get(%q)", handle, name)
+	}
+	return fmt.Sprintf("get_body_model(%s, %q)", handle, name)
+}
+
+// getEndpointSecurityParams returns the headers and the query parameters which carry the credentials set by the
+// enforcer for the endpoint security of the API and its resources.
+func getEndpointSecurityParams(adapterInternalAPI *model.AdapterInternalAPI) ([]string, []string) {
+	securityConfigs := append([]*model.EndpointSecurity{}, adapterInternalAPI.EndpointSecurity...)
+	for _, resource := range adapterInternalAPI.GetResources() {
+		securityConfigs = append(securityConfigs, resource.GetEndpointSecurity()...)
+	}
+	headers := make(map[string]bool)
+	queryParams := make(map[string]bool)
+	for _, security := range securityConfigs {
+		if security == nil || !security.Enabled {
+			continue
+		}
+		switch security.Type {
+		case "Basic", "OAuth2":
+			headers["authorization"] = true
+		case "APIKey":
+			if strings.EqualFold(security.CustomParameters["in"], "Query") {
+				queryParams[security.CustomParameters["key"]] = true
+			} else {
+				headers[strings.ToLower(security.CustomParameters["key"])] = true
+			}
+		}
+	}
+	return sortedKeys(headers), sortedKeys(queryParams)
+}
+
+// sortedKeys returns the keys of the given set in the sorted order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// addUpstreamLuaFilter adds a lua filter with the given script to the upstream http filters of the cluster,
+// followed by the upstream codec filter which must be the last upstream filter.
+func addUpstreamLuaFilter(cluster *clusterv3.Cluster, filterName, script string) error {
+	protocolOptions := &upstreams.HttpProtocolOptions{}
+	if err := cluster.TypedExtensionProtocolOptions["envoy.extensions.upstreams.http.v3.HttpProtocolOptions"].
+		UnmarshalTo(protocolOptions); err != nil {
+		return err
+	}
+	luaConfig, err := anypb.New(&lua.Lua{
+		DefaultSourceCode: &corev3.DataSource{
+			Specifier: &corev3.DataSource_InlineString{
+				InlineString: script,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	codecConfig, err := anypb.New(&upstreamcodecv3.UpstreamCodec{})
+	if err != nil {
+		return err
+	}
+	protocolOptions.HttpFilters = append(protocolOptions.HttpFilters,
+		&hcmv3.HttpFilter{
+			Name:       filterName,
+			ConfigType: &hcmv3.HttpFilter_TypedConfig{TypedConfig: luaConfig},
+		},
+		&hcmv3.HttpFilter{
+			Name:       upstreamCodecFilterName,
+			ConfigType: &hcmv3.HttpFilter_TypedConfig{TypedConfig: codecConfig},
+		})
+	protocolOptionsConfig, err := anypb.New(protocolOptions)
+	if err != nil {
+		return err
+	}
+	cluster.TypedExtensionProtocolOptions["envoy.extensions.upstreams.http.v3.HttpProtocolOptions"] = protocolOptionsConfig
+	return nil
+}
+
+// aiRoutingLuaFunctions are the lua functions used by the AI routing scripts to read and set the model of the
+// requests. The model is read from the top level field of the json body, so the fields of the nested objects such
+// as the messages are not mistaken for the model.
+const aiRoutingLuaFunctions = `
+local function skip_json_string(body, position)
+	local index = position + 1
+	while true do
+		local found = string.find(body, '["\\]', index)
+		if found == nil then
+			return nil
+		end
+		if string.sub(body, found, found) == '"' then
+			return found
+		end
+		index = found + 2
+	end
+end
+local function find_top_level_field(body, field)
+	local depth = 0
+	local index = 1
+	while true do
+		index = string.find(body, '["{}%[%]]', index)
+		if index == nil then
+			return nil
+		end
+		local character = string.sub(body, index, index)
+		if character == '"' then
+			local key_end = skip_json_string(body, index)
+			if key_end == nil then
+				return nil
+			end
+			local value_start = string.match(body, '^%s*:%s*()', key_end + 1)
+			if depth == 1 and value_start ~= nil and string.sub(body, index + 1, key_end - 1) == field then
+				if string.sub(body, value_start, value_start) ~= '"' then
+					return nil
+				end
+				local value_end = skip_json_string(body, value_start)
+				if value_end == nil then
+					return nil
+				end
+				return value_start + 1, value_end - 1
+			end
+			index = key_end + 1
+		elseif character == "{" or character == "[" then
+			depth = depth + 1
+			index = index + 1
+		else
+			depth = depth - 1
+			index = index + 1
+		end
+	end
+end
+local function get_body(handle)
+	local body = handle:body()
+	if body == nil or body:length() == 0 then
+		return nil, nil
+	end
+	return body, body:getBytes(0, body:length())
+end
+local function get_body_model(handle, field)
+	local _, bytes = get_body(handle)
+	if bytes == nil then
+		return nil
+	end
+	local value_start, value_end = find_top_level_field(bytes, field)
+	if value_start == nil then
+		return nil
+	end
+	return string.sub(bytes, value_start, value_end)
+end
+local function set_body_model(handle, field, model)
+	local body, bytes = get_body(handle)
+	if bytes == nil then
+		return
+	end
+	local updated
+	local value_start, value_end = find_top_level_field(bytes, field)
+	if value_start ~= nil then
+		updated = string.sub(bytes, 1, value_start - 1) .. model .. string.sub(bytes, value_end + 1)
+	else
+		local object_start = string.find(bytes, "{", 1, true)
+		if object_start == nil then
+			return
+		end
+		local separator = ","
+		if string.match(bytes, "^%s*}", object_start + 1) ~= nil then
+			separator = ""
+		end
+		updated = string.sub(bytes, 1, object_start) .. '"' .. field .. '":"' .. model .. '"' .. separator ..
+			string.sub(bytes, object_start + 1)
+	end
+	body:setBytes(updated)
+	handle:headers():replace("content-length", tostring(#updated))
+end
+local function escape_json(value)
+	return (string.gsub(value, '[%c"\\]', function(character)
+		return string.format("\\u%04x", string.byte(character))
+	end))
+end
+local function remove_query_param(path, name)
+	local resource, query = string.match(path, "^([^?]*)%?(.*)$")
+	if resource == nil then
+		return path
+	end
+	local params = {}
+	for param in string.gmatch(query, "[^&]+") do
+		if string.match(param, "^[^=]*") ~= name then
+			table.insert(params, param)
+		end
+	end
+	if #params == 0 then
+		return resource
+	end
+	return resource .. "?" .. table.concat(params, "&")
+end
+local function add_query_param(path, param)
+	if string.find(path, "?", 1, true) ~= nil then
+		return path .. "&" .. param
+	end
+	return path .. "?" .. param
+end
+`
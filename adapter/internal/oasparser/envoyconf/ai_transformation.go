@@ -0,0 +1,71 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package envoyconf
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	logger "github.com/wso2/apk/adapter/internal/loggers"
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
+)
+
+// getAITransformationMetadataValue returns the transformation of the AI provider of the API encoded to be passed
+// to the enforcer in the route metadata.
+func getAITransformationMetadataValue(aiTransformation *model.AITransformation) string {
+	transformationJSON, err := json.Marshal(aiTransformation)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the AI provider transformation. %v", err)
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(transformationJSON)
+}
+
+// getAITransformation returns the transformation of the AI provider of the API for the routes of the resource. The
+// enforcer rewrites the path of the requests to the path of the provider under the base path of the backend, and
+// signs the requests with the AWS credentials of the backend if it is secured with AWS security.
+func getAITransformation(adapterInternalAPI *model.AdapterInternalAPI, resource *model.Resource,
+	endpointBasePath string) *model.AITransformation {
+	if adapterInternalAPI.GetAIProvider().Transformation == nil {
+		return nil
+	}
+	// the transformation of the provider is shared by the APIs, hence it is copied
+	transformation := *adapterInternalAPI.GetAIProvider().Transformation
+	transformation.BasePath = strings.TrimSuffix(endpointBasePath, "/")
+	securityConfigs := append([]*model.EndpointSecurity{}, adapterInternalAPI.EndpointSecurity...)
+	if resource != nil {
+		securityConfigs = append(securityConfigs, resource.GetEndpointSecurity()...)
+	}
+	for _, security := range securityConfigs {
+		if security == nil || !security.Enabled || security.Type != "AWS" {
+			continue
+		}
+		transformation.AWS = &model.AWSSigning{
+			Region:          security.CustomParameters["region"],
+			Service:         security.CustomParameters["service"],
+			AccessKeyID:     security.CustomParameters["accessKeyId"],
+			SecretAccessKey: security.CustomParameters["secretAccessKey"],
+			SessionToken:    security.CustomParameters["sessionToken"],
+		}
+		// the router rewrites the host of the requests to the host of the endpoint, which is signed
+		if resource != nil && resource.GetEndpoints() != nil && len(resource.GetEndpoints().Endpoints) > 0 {
+			transformation.AWS.Host = resource.GetEndpoints().Endpoints[0].Host
+		}
+	}
+	return &transformation
+}
@@ -0,0 +1,134 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package envoyconf
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcessorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	"github.com/golang/protobuf/ptypes/any"
+	logger "github.com/wso2/apk/adapter/internal/loggers"
+	"github.com/wso2/apk/adapter/internal/oasparser/constants"
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// bodyTransformations holds the body transformations of an operation passed to the enforcer
+type bodyTransformations struct {
+	Request  *model.BodyTransformation `json:"request,omitempty"`
+	Response *model.BodyTransformation `json:"response,omitempty"`
+}
+
+// generateBodyTransformation returns the body transformation of the given parameters of a body transformation policy
+func generateBodyTransformation(policyParams interface{}) (*model.BodyTransformation, error) {
+	paramsToTransformBody, ok := policyParams.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("error while processing policy parameter map. Map: %v", policyParams)
+	}
+	bodyTransformation, ok := paramsToTransformBody[constants.BodyTransformation].(*model.BodyTransformation)
+	if !ok || bodyTransformation == nil {
+		return nil, fmt.Errorf("policy parameter map must include %s", constants.BodyTransformation)
+	}
+	return bodyTransformation, nil
+}
+
+// getBodyTransformationFilterConfigs returns a copy of the given per route filter configs which buffers the
+// payloads to be transformed by the enforcer. The processing mode is merged with the one already enabled for the
+// route, such as by the response cache or the schema validation.
+func getBodyTransformationFilterConfigs(perRouteFilterConfigs map[string]*any.Any, transformRequests bool,
+	transformResponses bool) map[string]*any.Any {
+	filterConfigs := make(map[string]*any.Any, len(perRouteFilterConfigs)+1)
+	for name, filterConfig := range perRouteFilterConfigs {
+		filterConfigs[name] = filterConfig
+	}
+	processingMode := &extProcessorv3.ProcessingMode{
+		RequestHeaderMode:  extProcessorv3.ProcessingMode_SKIP,
+		ResponseHeaderMode: extProcessorv3.ProcessingMode_SKIP,
+	}
+	if filterConfig, found := perRouteFilterConfigs[HTTPExternalProcessor]; found {
+		extProcPerRoute := &extProcessorv3.ExtProcPerRoute{}
+		if err := proto.Unmarshal(filterConfig.GetValue(), extProcPerRoute); err == nil &&
+			extProcPerRoute.GetOverrides().GetProcessingMode() != nil {
+			processingMode = proto.Clone(extProcPerRoute.GetOverrides().GetProcessingMode()).(*extProcessorv3.ProcessingMode)
+		}
+	}
+	if transformRequests {
+		processingMode.RequestBodyMode = extProcessorv3.ProcessingMode_BUFFERED
+	}
+	if transformResponses {
+		// the response headers are sent as only the successful responses are transformed
+		processingMode.ResponseHeaderMode = extProcessorv3.ProcessingMode_SEND
+		processingMode.ResponseBodyMode = extProcessorv3.ProcessingMode_BUFFERED
+	}
+	perFilterConfigExtProc := extProcessorv3.ExtProcPerRoute{
+		Override: &extProcessorv3.ExtProcPerRoute_Overrides{
+			Overrides: &extProcessorv3.ExtProcOverrides{
+				ProcessingMode: processingMode,
+			},
+		},
+	}
+	dataExtProc, err := proto.Marshal(&perFilterConfigExtProc)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the body transformation ext_proc config. %v", err)
+		return perRouteFilterConfigs
+	}
+	filterConfigs[HTTPExternalProcessor] = &any.Any{
+		TypeUrl: extProcPerRouteName,
+		Value:   dataExtProc,
+	}
+	return filterConfigs
+}
+
+// getBodyTransformationMetadata returns a copy of the given route metadata which passes the body transformations
+// of the operation to the enforcer as well.
+func getBodyTransformationMetadata(metadata *corev3.Metadata, requestTransformation *model.BodyTransformation,
+	responseTransformation *model.BodyTransformation) *corev3.Metadata {
+	return getExtProcMetadata(metadata, bodyTransformationMetadataKey, bodyTransformations{
+		Request:  requestTransformation,
+		Response: responseTransformation,
+	})
+}
+
+// getExtProcMetadata returns a copy of the given route metadata which passes the given value to the enforcer in
+// base64 encoded json under the given key of the ext_proc filter metadata.
+func getExtProcMetadata(metadata *corev3.Metadata, key string, value interface{}) *corev3.Metadata {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the %s metadata. %v", key, err)
+		return metadata
+	}
+	routeMetadata := &corev3.Metadata{
+		FilterMetadata: make(map[string]*structpb.Struct, len(metadata.GetFilterMetadata())+1),
+	}
+	for name, filterMetadata := range metadata.GetFilterMetadata() {
+		routeMetadata.FilterMetadata[name] = proto.Clone(filterMetadata).(*structpb.Struct)
+	}
+	extProcMetadata, found := routeMetadata.FilterMetadata[HTTPExternalProcessor]
+	if !found {
+		extProcMetadata = &structpb.Struct{}
+		routeMetadata.FilterMetadata[HTTPExternalProcessor] = extProcMetadata
+	}
+	if extProcMetadata.Fields == nil {
+		extProcMetadata.Fields = make(map[string]*structpb.Value)
+	}
+	extProcMetadata.Fields[key] = structpb.NewStringValue(base64.StdEncoding.EncodeToString(valueJSON))
+	return routeMetadata
+}
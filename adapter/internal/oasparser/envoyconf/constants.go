@@ -31,6 +31,7 @@ const (
 	extProcPerRouteName        string = "type.googleapis.com/envoy.extensions.filters.http.ext_proc.v3.ExtProcPerRoute"
 	ratelimitPerRouteName          string = "type.googleapis.com/envoy.extensions.filters.http.ratelimit.v3.RateLimitPerRoute"
 	luaPerRouteName            string = "type.googleapis.com/envoy.extensions.filters.http.lua.v3.LuaPerRoute"
+	bufferPerRouteName         string = "type.googleapis.com/envoy.extensions.filters.http.buffer.v3.BufferPerRoute"
 	corsFilterName             string = "type.googleapis.com/envoy.extensions.filters.http.cors.v3.Cors"
 	localRateLimitPerRouteName string = "type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit"
 	httpProtocolOptionsName    string = "type.googleapis.com/envoy.extensions.upstreams.http.v3.HttpProtocolOptions"
@@ -52,19 +53,29 @@ const (
 // These values are shared between the adapter and enforcer, hence if it is required to change
 // these values, modifications should be done in the both adapter and enforcer.
 const (
-	pathContextExtension            string = "path"
-	vHostContextExtension           string = "vHost"
-	basePathContextExtension        string = "basePath"
-	methodContextExtension          string = "method"
-	apiVersionContextExtension      string = "version"
-	apiNameContextExtension         string = "name"
-	clusterNameContextExtension     string = "clusterName"
-	retryPolicyRetriableStatusCodes string = "retriable-status-codes"
+	pathContextExtension              string = "path"
+	vHostContextExtension             string = "vHost"
+	basePathContextExtension          string = "basePath"
+	methodContextExtension            string = "method"
+	apiVersionContextExtension        string = "version"
+	apiNameContextExtension           string = "name"
+	clusterNameContextExtension       string = "clusterName"
+	schemaValidationContextExtension  string = "schemaValidation"
+	validateResponsesContextExtension string = "validateResponses"
+	retryPolicyRetriableStatusCodes   string = "retriable-status-codes"
+	// limits of the GraphQL protection policy
+	gqlMaxDepthContextExtension             string = "graphQLMaxDepth"
+	gqlMaxComplexityContextExtension        string = "graphQLMaxComplexity"
+	gqlMaxAliasesContextExtension           string = "graphQLMaxAliases"
+	gqlMaxBatchSizeContextExtension         string = "graphQLMaxBatchSize"
+	gqlDisableIntrospectionContextExtension string = "graphQLDisableIntrospection"
 )
 
 const (
 	// clusterHeaderName denotes the constant used for header based routing decisions.
 	clusterHeaderName string = "x-wso2-cluster-header"
+	// aiRoutingClusterHeaderName denotes the header carrying the cluster selected for the requested AI model.
+	aiRoutingClusterHeaderName string = "x-wso2-ai-cluster"
 	// xWso2requestInterceptor used to provide request interceptor details for api and resource level
 	xWso2requestInterceptor string = "x-wso2-request-interceptor"
 	// xWso2responseInterceptor used to provide response interceptor details for api and resource level
@@ -149,3 +160,54 @@ const LuaGlobal = "envoy.filters.http.lua.global"
 
 // LuaLocal is the lua filter name for local lua filter
 const LuaLocal = "envoy.filters.http.lua.local"
+
+// LuaPayload is the lua filter name for the payload policy filter
+const LuaPayload = "envoy.filters.http.lua.payload"
+
+// LuaAIRouting is the lua filter name for the AI routing filter
+const LuaAIRouting = "envoy.filters.http.lua.ai_routing"
+
+// Constants relevant to the AI routing clusters and retries
+const (
+	aggregateClusterTypeName    string = "envoy.clusters.aggregate"
+	previousPrioritiesRetryName string = "envoy.retry_priorities.previous_priorities"
+	previousHostsRetryPredicate string = "envoy.retry_host_predicates.previous_hosts"
+	aiRoutingClusterPrefix      string = "ai_routing"
+	aiRoutingTargetFilterName   string = "envoy.filters.http.lua.ai_routing_target"
+	upstreamCodecFilterName     string = "envoy.filters.http.upstream_codec"
+)
+
+// Constants relevant to the AI guardrails. These values are shared between the adapter and enforcer.
+const (
+	aiGuardrailsMetadataKey         string = "AIGuardrails"
+	aiGuardrailDirectionMetadataKey string = "aiguardrail:direction"
+	aiGuardrailRequestDirection     string = "request"
+	aiGuardrailResponseDirection    string = "response"
+)
+
+// aiResponseCacheMetadataKey is the route metadata key of the AI response cache configurations. This value is
+// shared between the adapter and enforcer.
+const aiResponseCacheMetadataKey string = "AIResponseCache"
+
+// aiTransformationMetadataKey is the route metadata key of the transformation of the AI provider. This value is
+// shared between the adapter and enforcer.
+const aiTransformationMetadataKey string = "AITransformation"
+
+// responseCacheMetadataKey is the route metadata key of the response cache configurations of an operation. This
+// value is shared between the adapter and enforcer.
+const responseCacheMetadataKey string = "ResponseCache"
+
+// bodyTransformationMetadataKey is the route metadata key of the body transformations of an operation. This value
+// is shared between the adapter and enforcer.
+const bodyTransformationMetadataKey string = "BodyTransformation"
+
+// soapMediationMetadataKey is the route metadata key of the SOAP mediation of an operation. This value is shared
+// between the adapter and enforcer.
+const soapMediationMetadataKey string = "SOAPMediation"
+
+// gqlSubscriptionMetadataKey is the route metadata key of the rate limits of the subscription fields of a GraphQL
+// API. This value is shared between the adapter and enforcer.
+const gqlSubscriptionMetadataKey string = "GraphQLSubscription"
+
+// webSocketExtensionsHeaderName is the header negotiating the extensions of a websocket connection
+const webSocketExtensionsHeaderName string = "sec-websocket-extensions"
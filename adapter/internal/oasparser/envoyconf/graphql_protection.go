@@ -0,0 +1,45 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"strconv"
+
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
+)
+
+// addGraphQLProtectionContextExtensions adds the limits of the GraphQL protection policy of an API to the context
+// extensions of its routes, through which the enforcer applies them to the operations. The field costs are passed to
+// the enforcer in the API.
+func addGraphQLProtectionContextExtensions(contextExtensions map[string]string,
+	graphQLProtection *model.GraphQLProtection) {
+	limits := map[string]*uint32{
+		gqlMaxDepthContextExtension:      graphQLProtection.MaxDepth,
+		gqlMaxComplexityContextExtension: graphQLProtection.MaxComplexity,
+		gqlMaxAliasesContextExtension:    graphQLProtection.MaxAliases,
+		gqlMaxBatchSizeContextExtension:  graphQLProtection.MaxBatchSize,
+	}
+	for contextExtension, limit := range limits {
+		if limit != nil {
+			contextExtensions[contextExtension] = strconv.FormatUint(uint64(*limit), 10)
+		}
+	}
+	if graphQLProtection.DisableIntrospection {
+		contextExtensions[gqlDisableIntrospectionContextExtension] = "true"
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"strings"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extProcessorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	"github.com/golang/protobuf/ptypes/any"
+	logger "github.com/wso2/apk/adapter/internal/loggers"
+	"github.com/wso2/apk/adapter/internal/oasparser/constants"
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
+	"google.golang.org/protobuf/proto"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// gqlSubscription holds the configurations of the subscriptions of a GraphQL API passed to the enforcer, which
+// authorizes the subscribe messages of the websocket connections
+type gqlSubscription struct {
+	// RateLimits maps the subscription fields to the entries of the descriptors of their rate limits
+	RateLimits map[string][]rateLimitDescriptorEntry `json:"rateLimits"`
+}
+
+// rateLimitDescriptorEntry is an entry of a descriptor sent to the rate limit service
+type rateLimitDescriptorEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// createGQLSubscriptionRoutes creates the routes of the subscriptions of a GraphQL API. Subscriptions are served over
+// a websocket connection upgraded from a GET request to the path of the API, which is routed to the GraphQL backend
+// with the same policies as the queries and mutations. The messages of the connection are streamed to the enforcer,
+// which applies the scopes and the rate limits of the subscription fields on the subscribe messages.
+func createGQLSubscriptionRoutes(adapterInternalAPI *model.AdapterInternalAPI, subscriptions []*model.Resource,
+	policies model.OperationPolicies, vHost, endpointBasePath, clusterName, organizationID string) ([]*routev3.Route, error) {
+	operation := model.NewOperationWithPolicies("GET", policies, "")
+	resource := model.CreateMinimalResource(adapterInternalAPI.GetXWso2Basepath(), []*model.Operation{operation}, "",
+		adapterInternalAPI.Endpoints, true, false, gwapiv1.PathMatchExact)
+	subscription := gqlSubscription{
+		RateLimits: generateGQLSubscriptionRateLimits(adapterInternalAPI, subscriptions, organizationID),
+	}
+
+	createDefaultPaths := []bool{false}
+	if adapterInternalAPI.IsDefaultVersion {
+		createDefaultPaths = append(createDefaultPaths, true)
+	}
+	var routes []*routev3.Route
+	for _, createDefaultPath := range createDefaultPaths {
+		params := genRouteCreateParams(adapterInternalAPI, &resource, vHost, endpointBasePath, clusterName, nil, nil,
+			organizationID, false, createDefaultPath, nil)
+		// The websocket upgrade and stream duration of websocket APIs apply to the subscription routes
+		params.apiType = constants.WS
+		subscriptionRoutes, err := createRoutes(params)
+		if err != nil {
+			return nil, err
+		}
+		for _, route := range subscriptionRoutes {
+			if route.GetRoute() == nil {
+				continue
+			}
+			route.TypedPerFilterConfig = getGQLSubscriptionFilterConfigs(route.GetTypedPerFilterConfig())
+			// The enforcer reads the messages of the connection as plain frames, hence the frames are not compressed
+			route.RequestHeadersToRemove = append(route.RequestHeadersToRemove, webSocketExtensionsHeaderName)
+			route.Metadata = getExtProcMetadata(route.GetMetadata(), gqlSubscriptionMetadataKey, subscription)
+		}
+		routes = append(routes, subscriptionRoutes...)
+	}
+	return routes, nil
+}
+
+// getGQLSubscriptionFilterConfigs returns a copy of the given per route filter configs which streams the messages of
+// the websocket connections to the enforcer. The request headers are sent along to pass the route metadata and the
+// metadata of the handshake authorized by the enforcer.
+func getGQLSubscriptionFilterConfigs(perRouteFilterConfigs map[string]*any.Any) map[string]*any.Any {
+	filterConfigs := make(map[string]*any.Any, len(perRouteFilterConfigs)+1)
+	for name, filterConfig := range perRouteFilterConfigs {
+		filterConfigs[name] = filterConfig
+	}
+	perFilterConfigExtProc := extProcessorv3.ExtProcPerRoute{
+		Override: &extProcessorv3.ExtProcPerRoute_Overrides{
+			Overrides: &extProcessorv3.ExtProcOverrides{
+				ProcessingMode: &extProcessorv3.ProcessingMode{
+					RequestHeaderMode:  extProcessorv3.ProcessingMode_SEND,
+					ResponseHeaderMode: extProcessorv3.ProcessingMode_SKIP,
+					RequestBodyMode:    extProcessorv3.ProcessingMode_STREAMED,
+				},
+			},
+		},
+	}
+	dataExtProc, err := proto.Marshal(&perFilterConfigExtProc)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the GraphQL subscription ext_proc config. %v", err)
+		return perRouteFilterConfigs
+	}
+	filterConfigs[HTTPExternalProcessor] = &any.Any{
+		TypeUrl: extProcPerRouteName,
+		Value:   dataExtProc,
+	}
+	return filterConfigs
+}
+
+// generateGQLSubscriptionRateLimits generates the descriptors of the rate limits of the subscription fields of a
+// GraphQL API. The enforcer sends the descriptor of a field to the rate limit service for each subscribe message of
+// the field.
+func generateGQLSubscriptionRateLimits(adapterInternalAPI *model.AdapterInternalAPI, subscriptions []*model.Resource,
+	organizationID string) map[string][]rateLimitDescriptorEntry {
+	basePath := strings.TrimSuffix(adapterInternalAPI.GetXWso2Basepath(), "/")
+	rateLimits := make(map[string][]rateLimitDescriptorEntry)
+	for _, subscription := range subscriptions {
+		for _, operation := range subscription.GetMethod() {
+			if operation.GetMethod() != constants.GQLSubscription || operation.GetRateLimitPolicy() == nil {
+				continue
+			}
+			// Resource level rate limits are registered in the rate limit service under the base path followed by
+			// the path of the resource, which is the subscription field for GraphQL APIs.
+			rateLimit := generateRateLimitPolicy(&ratelimitCriteria{
+				level:                RateLimitPolicyOperationLevel,
+				organizationID:       organizationID,
+				basePathForRLService: basePath + basePath + subscription.GetPath(),
+				environment:          adapterInternalAPI.GetEnvironment(),
+				envType:              adapterInternalAPI.EnvType,
+			})[0]
+			var entries []rateLimitDescriptorEntry
+			for _, action := range rateLimit.GetActions() {
+				if genericKey := action.GetGenericKey(); genericKey != nil {
+					entries = append(entries, rateLimitDescriptorEntry{
+						Key:   genericKey.GetDescriptorKey(),
+						Value: genericKey.GetDescriptorValue(),
+					})
+				}
+			}
+			// The operation of the descriptor is the subscription instead of the method of the request
+			entries = append(entries, rateLimitDescriptorEntry{Key: DescriptorKeyForMethod,
+				Value: constants.GQLSubscription})
+			rateLimits[subscription.GetPath()] = entries
+		}
+	}
+	return rateLimits
+}
@@ -0,0 +1,133 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"regexp"
+	"strings"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	grpc_json_transcoder_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_json_transcoder/v3"
+	envoy_type_matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// createGRPCTranscodingRoutes creates the routes of the REST resources bound to the method of the given resource of a
+// gRPC API. The routes are copies of the routes of the method which match the paths and the HTTP methods of the
+// bindings, and enable the gRPC JSON transcoder. The paths of the bindings are already prefixed with the base path of
+// the API, as are the annotations of the descriptor set of the transcoder. The transcoder rewrites the path of a
+// request to the path of the method, hence the transcoded request is routed with the authentication and the rate
+// limits of the method.
+func createGRPCTranscodingRoutes(grpcTranscoding *model.GRPCTranscoding, resource *model.Resource,
+	methodRoutes []*routev3.Route) ([]*routev3.Route, error) {
+	var transcoderConfig *anypb.Any
+	var routes []*routev3.Route
+	for _, binding := range grpcTranscoding.Bindings {
+		if binding.GRPCPath != resource.GetPath() {
+			continue
+		}
+		if transcoderConfig == nil {
+			var err error
+			if transcoderConfig, err = getGRPCJSONTranscoderConfig(grpcTranscoding); err != nil {
+				return nil, err
+			}
+		}
+		for _, methodRoute := range methodRoutes {
+			route := proto.Clone(methodRoute).(*routev3.Route)
+			route.Match = generateRouteMatch(getGRPCTranscodingPathRegex(binding.PathTemplate))
+			route.Match.Headers = []*routev3.HeaderMatcher{{
+				Name: httpMethodHeader,
+				HeaderMatchSpecifier: &routev3.HeaderMatcher_StringMatch{
+					StringMatch: &envoy_type_matcherv3.StringMatcher{
+						MatchPattern: &envoy_type_matcherv3.StringMatcher_Exact{
+							Exact: binding.HTTPMethod,
+						},
+					},
+				},
+			}}
+			if route.TypedPerFilterConfig == nil {
+				route.TypedPerFilterConfig = make(map[string]*anypb.Any)
+			}
+			route.TypedPerFilterConfig[wellknown.GRPCJSONTranscoder] = transcoderConfig
+			routes = append(routes, route)
+		}
+	}
+	return routes, nil
+}
+
+// getGRPCJSONTranscoderConfig returns the per route config of the gRPC JSON transcoder of a gRPC API. The requests
+// which do not match a binding are rejected, instead of being passed to the gRPC backend.
+func getGRPCJSONTranscoderConfig(grpcTranscoding *model.GRPCTranscoding) (*anypb.Any, error) {
+	return anypb.New(&grpc_json_transcoder_v3.GrpcJsonTranscoder{
+		DescriptorSet: &grpc_json_transcoder_v3.GrpcJsonTranscoder_ProtoDescriptorBin{
+			ProtoDescriptorBin: grpcTranscoding.DescriptorSet,
+		},
+		Services: grpcTranscoding.Services,
+		PrintOptions: &grpc_json_transcoder_v3.GrpcJsonTranscoder_PrintOptions{
+			PreserveProtoFieldNames: grpcTranscoding.PreserveProtoFieldNames,
+		},
+		ConvertGrpcStatus:            true,
+		IgnoreUnknownQueryParameters: grpcTranscoding.IgnoreUnknownQueryParameters,
+		RequestValidationOptions: &grpc_json_transcoder_v3.GrpcJsonTranscoder_RequestValidationOptions{
+			RejectUnknownMethod:          true,
+			RejectUnknownQueryParameters: !grpcTranscoding.IgnoreUnknownQueryParameters,
+		},
+	})
+}
+
+// getGRPCTranscodingPathRegex returns the regex matching the path template of a google.api.http annotation. A
+// variable matches a single segment unless it has a pattern of its own, as in {name=shelves/*/books/**}.
+func getGRPCTranscodingPathRegex(pathTemplate string) string {
+	var regex strings.Builder
+	for pathTemplate != "" {
+		start := strings.Index(pathTemplate, "{")
+		end := strings.Index(pathTemplate, "}")
+		if start < 0 || end < start {
+			regex.WriteString(getPathSegmentsRegex(pathTemplate))
+			break
+		}
+		regex.WriteString(getPathSegmentsRegex(pathTemplate[:start]))
+		variablePattern := "*"
+		if _, pattern, found := strings.Cut(pathTemplate[start+1:end], "="); found {
+			variablePattern = pattern
+		}
+		regex.WriteString(getPathSegmentsRegex(variablePattern))
+		pathTemplate = pathTemplate[end+1:]
+	}
+	return "^" + regex.String() + "$"
+}
+
+// getPathSegmentsRegex returns the regex matching the given segments of a path template, in which * matches a single
+// segment and ** matches any number of segments.
+func getPathSegmentsRegex(segments string) string {
+	parts := strings.Split(segments, "/")
+	for i, part := range parts {
+		switch part {
+		case "**":
+			parts[i] = ".*"
+		case "*":
+			parts[i] = "[^/]+"
+		default:
+			parts[i] = regexp.QuoteMeta(part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
@@ -26,10 +26,13 @@ import (
 
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	envoy_config_ratelimit_v3 "github.com/envoyproxy/go-control-plane/envoy/config/ratelimit/v3"
+	bufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
 	cors_filter_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/cors/v3"
 	ext_authv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
 	ext_process "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	grpc_json_transcoder_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_json_transcoder/v3"
 	grpc_stats_filter_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_stats/v3"
+	grpc_web_v3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/grpc_web/v3"
 	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
 	ratelimit "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ratelimit/v3"
 	routerv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/router/v3"
@@ -67,19 +70,47 @@ function envoy_on_response(response_handle)
 end`)
 	luaGlobal := getLuaFilter(LuaGlobal, globalLuaScript)
 	cors := getCorsHTTPFilter()
+	// payload policy filters are enabled only for the routes with a payload policy
+	luaPayload := getLuaFilter(LuaPayload, `
+function envoy_on_request(request_handle)
+end
+function envoy_on_response(response_handle)
+end`)
+	luaPayload.Disabled = true
+	buffer := getBufferFilter()
+	// AI routing filter is enabled only for the routes of the APIs with an AI routing policy
+	luaAIRouting := getLuaFilter(LuaAIRouting, `
+function envoy_on_request(request_handle)
+end
+function envoy_on_response(response_handle)
+end`)
+	luaAIRouting.Disabled = true
+	// gRPC JSON transcoder is enabled only for the routes of the REST resources of the gRPC APIs with a gRPC
+	// transcoding policy, and is placed before the ext authz filter to authenticate the transcoded requests
+	grpcJSONTranscoder := getGRPCJSONTranscoderFilter()
+	// gRPC-Web filter is enabled only for the routes of the gRPC APIs which accept gRPC-Web requests, and is placed
+	// before the ext authz filter to authenticate the translated requests as gRPC requests
+	grpcWeb := getGRPCWebFilter()
 
 	httpFilters := []*hcmv3.HttpFilter{
 		cors,
+		grpcWeb,
+		grpcJSONTranscoder,
+		luaPayload,
+		buffer,
 		extAuth,
+		luaAIRouting,
 		luaLocal,
 		luaGlobal,
-		extProcessor,
 	}
 	conf := config.ReadConfigs()
+	// the ratelimit filter is placed before the ext proc filter, so that the requests served from the response
+	// caches of the enforcer are counted against the ratelimits
 	if conf.Envoy.RateLimit.Enabled {
 		rateLimit := getRateLimitFilter()
 		httpFilters = append(httpFilters, rateLimit)
 	}
+	httpFilters = append(httpFilters, extProcessor)
 	if conf.Envoy.Filters.Compression.Enabled {
 		compressionFilter, err := getCompressorFilter()
 		if err != nil {
@@ -154,6 +185,43 @@ func getCorsHTTPFilter() *hcmv3.HttpFilter {
 	return &filter
 }
 
+// getGRPCWebFilter gets the gRPC-Web http filter. The filter is disabled by default and is enabled per route.
+func getGRPCWebFilter() *hcmv3.HttpFilter {
+	ext, err := anypb.New(&grpc_web_v3.GrpcWeb{})
+	if err != nil {
+		logger.LoggerOasparser.Error("Error marshaling grpc web filter configs. ", err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: wellknown.GRPCWeb,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: ext,
+		},
+		Disabled: true,
+	}
+}
+
+// getGRPCJSONTranscoderFilter gets the gRPC JSON transcoder http filter. The filter is disabled by default and the
+// descriptor set and the services are set per route.
+func getGRPCJSONTranscoderFilter() *hcmv3.HttpFilter {
+	// The filter is considered disabled when the list of services is empty
+	transcoderConfig := &grpc_json_transcoder_v3.GrpcJsonTranscoder{
+		DescriptorSet: &grpc_json_transcoder_v3.GrpcJsonTranscoder_ProtoDescriptorBin{
+			ProtoDescriptorBin: []byte{},
+		},
+	}
+	ext, err := anypb.New(transcoderConfig)
+	if err != nil {
+		logger.LoggerOasparser.Error("Error marshaling grpc json transcoder filter configs. ", err)
+	}
+	return &hcmv3.HttpFilter{
+		Name: wellknown.GRPCJSONTranscoder,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: ext,
+		},
+		Disabled: true,
+	}
+}
+
 // UpgradeFilters that are applied in websocket upgrade mode
 func getUpgradeFilters() []*hcmv3.HttpFilter {
 
@@ -161,12 +229,14 @@ func getUpgradeFilters() []*hcmv3.HttpFilter {
 	grpcStats := getGRPCStatsHTTPFilter()
 	extAauth := getExtAuthzHTTPFilter()
 	apkWebSocketWASM := getAPKWebSocketWASMFilter()
+	extProcessor := getUpgradeExtProcessHTTPFilter()
 	router := getRouterHTTPFilter()
 	upgradeFilters := []*hcmv3.HttpFilter{
 		cors,
 		grpcStats,
 		extAauth,
 		apkWebSocketWASM,
+		extProcessor,
 		router,
 	}
 	return upgradeFilters
@@ -229,8 +299,25 @@ func getRateLimitFilter() *hcmv3.HttpFilter {
 
 // getExtProcessHTTPFilter gets ExtAauthz http filter.
 func getExtProcessHTTPFilter() *hcmv3.HttpFilter {
+	return marshalExtProcessHTTPFilter(getExternalProcessor())
+}
+
+// getUpgradeExtProcessHTTPFilter gets the ext_proc http filter of the websocket connections. The routes disable the
+// filter, except for the subscriptions of GraphQL APIs whose messages are streamed to the enforcer to authorize the
+// subscribe messages. The messages are not authorized when the enforcer is unreachable, hence the filter fails closed.
+func getUpgradeExtProcessHTTPFilter() *hcmv3.HttpFilter {
+	externalProcessor := getExternalProcessor()
+	externalProcessor.FailureModeAllow = false
+	externalProcessor.ProcessingMode = &ext_process.ProcessingMode{
+		RequestHeaderMode:  ext_process.ProcessingMode_SKIP,
+		ResponseHeaderMode: ext_process.ProcessingMode_SKIP,
+	}
+	return marshalExtProcessHTTPFilter(externalProcessor)
+}
+
+func getExternalProcessor() *ext_process.ExternalProcessor {
 	conf := config.ReadConfigs()
-	externalProcessor := &ext_process.ExternalProcessor{
+	return &ext_process.ExternalProcessor{
 		GrpcService: &corev3.GrpcService{
 			TargetSpecifier: &corev3.GrpcService_EnvoyGrpc_{
 				EnvoyGrpc: &corev3.GrpcService_EnvoyGrpc{
@@ -240,6 +327,8 @@ func getExtProcessHTTPFilter() *hcmv3.HttpFilter {
 			Timeout: durationpb.New(conf.Envoy.EnforcerResponseTimeoutInSeconds * time.Second),
 		},
 		FailureModeAllow: true,
+		// the enforcer switches the response body to the streamed mode for server-sent event responses
+		AllowModeOverride: true,
 		ProcessingMode: &ext_process.ProcessingMode{
 			ResponseBodyMode:   ext_process.ProcessingMode_BUFFERED,
 			RequestHeaderMode:  ext_process.ProcessingMode_SKIP,
@@ -257,6 +346,9 @@ func getExtProcessHTTPFilter() *hcmv3.HttpFilter {
 		ResponseAttributes: []string{"xds.route_metadata"},
 		MessageTimeout: durationpb.New(conf.Envoy.EnforcerResponseTimeoutInSeconds * time.Second),
 	}
+}
+
+func marshalExtProcessHTTPFilter(externalProcessor *ext_process.ExternalProcessor) *hcmv3.HttpFilter {
 	ext, err2 := anypb.New(externalProcessor)
 	if err2 != nil {
 		logger.LoggerOasparser.Error(err2)
@@ -340,6 +432,27 @@ func getLuaFilter(filterName, defaultScript string) *hcmv3.HttpFilter {
 	return &luaFilter
 }
 
+// getBufferFilter gets the buffer http filter which limits the size of the request body.
+// The filter is disabled by default and the limit is set per route.
+func getBufferFilter() *hcmv3.HttpFilter {
+	conf := config.ReadConfigs()
+	bufferConfig := &bufferv3.Buffer{
+		MaxRequestBytes: &wrappers.UInt32Value{Value: conf.Envoy.PayloadPassingToEnforcer.MaxRequestBytes},
+	}
+	ext, err := anypb.New(bufferConfig)
+	if err != nil {
+		logger.LoggerOasparser.Error(err)
+	}
+	bufferFilter := hcmv3.HttpFilter{
+		Name: wellknown.Buffer,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: ext,
+		},
+		Disabled: true,
+	}
+	return &bufferFilter
+}
+
 func getAPKWebSocketWASMFilter() *hcmv3.HttpFilter {
 	config := &wrappers.StringValue{
 		Value: `{
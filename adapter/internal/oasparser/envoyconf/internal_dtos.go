@@ -45,7 +45,31 @@ type routeCreateParams struct {
 	environment                  string
 	envType                      string
 	mirrorClusterNames           map[string][]string
+	weightedClusters             []weightedCluster
 	isAiAPI                      bool
+	aiRouting                    *aiRoutingClusters
+	aiGuardrails                 *model.AIGuardrails
+	aiResponseCache              *model.AIResponseCache
+	aiTransformation             *model.AITransformation
+	graphQLProtection            *model.GraphQLProtection
+	isGRPCWebEnabled             bool
+}
+
+// aiRoutingClusters holds the clusters created for the failover chains of an AI routing policy
+type aiRoutingClusters struct {
+	// modelClusters maps the model names to the aggregate cluster of their failover chain
+	modelClusters  map[string]string
+	defaultCluster string
+	numRetries     uint32
+	modelIn        string
+	modelValue     string
+	policy         *model.AIRouting
+}
+
+// weightedCluster holds the name of an upstream cluster and its share of the traffic of a route
+type weightedCluster struct {
+	clusterName string
+	weight      uint32
 }
 
 // RatelimitCriteria criterias of rate limiting
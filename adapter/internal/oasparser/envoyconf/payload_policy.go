@@ -0,0 +1,151 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	bufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/buffer/v3"
+	lua "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/wso2/apk/adapter/internal/err"
+	logger "github.com/wso2/apk/adapter/internal/loggers"
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// getPayloadPolicyFilterConfigs returns a copy of the given per route filter configs which enables
+// the buffer filter and the payload lua filter according to the payload policy of the operation.
+func getPayloadPolicyFilterConfigs(perRouteFilterConfigs map[string]*any.Any,
+	payloadPolicy *model.PayloadPolicy) map[string]*any.Any {
+	filterConfigs := make(map[string]*any.Any, len(perRouteFilterConfigs)+2)
+	for name, filterConfig := range perRouteFilterConfigs {
+		filterConfigs[name] = filterConfig
+	}
+	if payloadPolicy.MaxRequestBodySize > 0 {
+		bufferPerRoute := &bufferv3.BufferPerRoute{
+			Override: &bufferv3.BufferPerRoute_Buffer{
+				Buffer: &bufferv3.Buffer{
+					MaxRequestBytes: wrapperspb.UInt32(payloadPolicy.MaxRequestBodySize),
+				},
+			},
+		}
+		if filterConfig := enableFilterForRoute(bufferPerRoute); filterConfig != nil {
+			filterConfigs[wellknown.Buffer] = filterConfig
+		}
+	}
+	if len(payloadPolicy.AllowedContentTypes) > 0 || payloadPolicy.MaxResponseBodySize > 0 {
+		luaPerRoute := &lua.LuaPerRoute{
+			Override: &lua.LuaPerRoute_SourceCode{
+				SourceCode: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineString{
+						InlineString: getPayloadPolicyLuaScript(payloadPolicy),
+					},
+				},
+			},
+		}
+		if filterConfig := enableFilterForRoute(luaPerRoute); filterConfig != nil {
+			filterConfigs[LuaPayload] = filterConfig
+		}
+	}
+	return filterConfigs
+}
+
+// enableFilterForRoute wraps the per route config of a filter, which is disabled by default
+// in the http connection manager, to enable it for the route.
+func enableFilterForRoute(perRouteConfig proto.Message) *any.Any {
+	config, marshalErr := anypb.New(perRouteConfig)
+	if marshalErr != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the per route filter config. %v", marshalErr)
+		return nil
+	}
+	filterConfig, marshalErr := anypb.New(&routev3.FilterConfig{
+		Config:   config,
+		Disabled: false,
+	})
+	if marshalErr != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the per route filter config. %v", marshalErr)
+		return nil
+	}
+	return filterConfig
+}
+
+// getPayloadPolicyLuaScript returns the lua script which rejects requests with a content type which is
+// not allowed and replaces responses larger than the allowed size. Responses without a Content-Length,
+// such as chunked responses, are buffered to measure their size.
+func getPayloadPolicyLuaScript(payloadPolicy *model.PayloadPolicy) string {
+	var script strings.Builder
+	if len(payloadPolicy.AllowedContentTypes) > 0 {
+		contentTypes := make([]string, 0, len(payloadPolicy.AllowedContentTypes))
+		for _, contentType := range payloadPolicy.AllowedContentTypes {
+			contentTypes = append(contentTypes, fmt.Sprintf("[%q] = true", strings.ToLower(strings.TrimSpace(contentType))))
+		}
+		sort.Strings(contentTypes)
+		script.WriteString(fmt.Sprintf(`
+local allowed_content_types = { %s }
+function envoy_on_request(request_handle)
+	local headers = request_handle:headers()
+	local content_type = headers:get("content-type")
+	local content_length = headers:get("content-length")
+	local has_body = headers:get("transfer-encoding") ~= nil or (content_length ~= nil and content_length ~= "0")
+	if content_type == nil and not has_body then
+		return
+	end
+	local media_type = string.lower(string.match(content_type or "", "^%%s*([^;%%s]+)") or "")
+	if not allowed_content_types[media_type] then
+		request_handle:respond({[":status"] = "415"}, "")
+	end
+end
+`, strings.Join(contentTypes, ", ")))
+	} else {
+		script.WriteString(`
+function envoy_on_request(request_handle)
+end
+`)
+	}
+	if payloadPolicy.MaxResponseBodySize > 0 {
+		script.WriteString(fmt.Sprintf(`
+function envoy_on_response(response_handle)
+	local content_length = tonumber(response_handle:headers():get("content-length"))
+	if content_length == nil then
+		-- the size of chunked and streamed responses is only known once they are buffered
+		content_length = response_handle:body(true):length()
+	end
+	if content_length > %d then
+		response_handle:headers():replace(":status", "502")
+		response_handle:headers():replace("content-type", "application/json")
+		response_handle:headers():remove("content-length")
+		response_handle:body(true):setBytes('{"code":"%d","message":"%s","description":"%s"}')
+	end
+end
+`, payloadPolicy.MaxResponseBodySize, err.ResponseTooLargeCode, err.ResponseTooLargeMessage, err.ResponseTooLargeDescription))
+	} else {
+		script.WriteString(`
+function envoy_on_response(response_handle)
+end
+`)
+	}
+	return script.String()
+}
@@ -0,0 +1,80 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package envoyconf
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcessorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	"github.com/golang/protobuf/ptypes/any"
+	logger "github.com/wso2/apk/adapter/internal/loggers"
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// getResponseCacheFilterConfigs returns a copy of the given per route filter configs which enables the external
+// processor, so the enforcer serves the requests from the response cache and caches the responses of the backend.
+func getResponseCacheFilterConfigs(perRouteFilterConfigs map[string]*any.Any) map[string]*any.Any {
+	filterConfigs := make(map[string]*any.Any, len(perRouteFilterConfigs)+1)
+	for name, filterConfig := range perRouteFilterConfigs {
+		filterConfigs[name] = filterConfig
+	}
+	// the request headers are sent to look up the cache, and the responses to be stored in the cache
+	perFilterConfigExtProc := extProcessorv3.ExtProcPerRoute{
+		Override: &extProcessorv3.ExtProcPerRoute_Overrides{
+			Overrides: &extProcessorv3.ExtProcOverrides{
+				ProcessingMode: &extProcessorv3.ProcessingMode{
+					RequestHeaderMode:  extProcessorv3.ProcessingMode_SEND,
+					ResponseHeaderMode: extProcessorv3.ProcessingMode_SEND,
+					ResponseBodyMode:   extProcessorv3.ProcessingMode_BUFFERED,
+				},
+			},
+		},
+	}
+	dataExtProc, err := proto.Marshal(&perFilterConfigExtProc)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the response cache ext_proc config. %v", err)
+		return perRouteFilterConfigs
+	}
+	filterConfigs[HTTPExternalProcessor] = &any.Any{
+		TypeUrl: extProcPerRouteName,
+		Value:   dataExtProc,
+	}
+	return filterConfigs
+}
+
+// getResponseCacheMetadata returns the route metadata which passes the response cache configurations of the
+// operation to the enforcer.
+func getResponseCacheMetadata(responseCache *model.ResponseCache) *corev3.Metadata {
+	cacheJSON, err := json.Marshal(responseCache)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the response cache configurations. %v", err)
+		return nil
+	}
+	return &corev3.Metadata{
+		FilterMetadata: map[string]*structpb.Struct{
+			HTTPExternalProcessor: {
+				Fields: map[string]*structpb.Value{
+					responseCacheMetadataKey: structpb.NewStringValue(base64.StdEncoding.EncodeToString(cacheJSON)),
+				},
+			},
+		},
+	}
+}
@@ -42,6 +42,10 @@ type errorResponseDetails struct {
 
 var errorResponseMap map[string]errorResponseDetails
 
+// localReplyErrorResponseMap holds the local replies of the payload policy filters keyed by the status code,
+// as those replies do not set a response flag.
+var localReplyErrorResponseMap map[uint32]errorResponseDetails
+
 func init() {
 	errorResponseMap = map[string]errorResponseDetails{
 		"NR":    {404, err.NotFoundCode, err.NotFoundMessage, err.NotFoundDescription},
@@ -60,6 +64,10 @@ func init() {
 		"UPE":   {500, err.UpeCode, err.UpeMessage, "%LOCAL_REPLY_BODY%"},
 		"UMSDR": {500, err.UmsdrCode, err.UmsdrMessage, "%LOCAL_REPLY_BODY%"},
 	}
+	localReplyErrorResponseMap = map[uint32]errorResponseDetails{
+		413: {413, err.PayloadTooLargeCode, err.PayloadTooLargeMessage, err.PayloadTooLargeDescription},
+		415: {415, err.UnsupportedMediaTypeCode, err.UnsupportedMediaTypeMessage, err.UnsupportedMediaTypeDescription},
+	}
 }
 
 func getErrorResponseMappers() []*hcmv3.ResponseMapper {
@@ -80,6 +88,13 @@ func getErrorResponseMappers() []*hcmv3.ResponseMapper {
 		responseMappers = append(responseMappers,
 			genSoap11ErrorResponseMapperForExtAuthz(500, err.UaexCode, err.UaexMessage, err.UaexDecription),
 		)
+
+		for statusCode, details := range localReplyErrorResponseMap {
+			responseMappers = append(responseMappers,
+				genSoap12ErrorResponseMapperForStatusCode(statusCode, int32(details.errorCode), details.message, details.description),
+				genSoap11ErrorResponseMapperForStatusCode(statusCode, int32(details.errorCode), details.message, details.description),
+			)
+		}
 	}
 
 	for flag, details := range errorResponseMap {
@@ -88,10 +103,24 @@ func getErrorResponseMappers() []*hcmv3.ResponseMapper {
 		)
 	}
 
+	for statusCode, details := range localReplyErrorResponseMap {
+		responseMappers = append(responseMappers,
+			genErrorResponseMapperJSONForStatusCode(statusCode, int32(details.errorCode), details.message, details.description),
+		)
+	}
+
 	responseMappers = append(responseMappers,
 		genExtAuthResponseMapper(genExtAuthFilters(), uint32(500), int32(err.UaexCode), err.UaexMessage, err.UaexDecription),
 	)
 
+	// the enforcer sets the violated guardrail as the local reply body
+	responseMappers = append(responseMappers,
+		genExtAuthResponseMapper(genAIGuardrailFilters(aiGuardrailRequestDirection), uint32(400),
+			int32(err.PromptGuardrailViolationCode), err.PromptGuardrailViolationMessage, "%LOCAL_REPLY_BODY%"),
+		genExtAuthResponseMapper(genAIGuardrailFilters(aiGuardrailResponseDirection), uint32(502),
+			int32(err.ResponseGuardrailViolationCode), err.ResponseGuardrailViolationMessage, "%LOCAL_REPLY_BODY%"),
+	)
+
 	return responseMappers
 }
 
@@ -117,6 +146,28 @@ func genErrorResponseMapperJSON(flag string, statusCode uint32, errorCode int32,
 	return mapper
 }
 
+func genErrorResponseMapperJSONForStatusCode(statusCode uint32, errorCode int32, message string, description string) *hcmv3.ResponseMapper {
+	errorMsgMap := make(map[string]*structpb.Value)
+	errorMsgMap["code"] = structpb.NewStringValue(strconv.FormatInt(int64(errorCode), 10))
+	errorMsgMap["message"] = structpb.NewStringValue(message)
+	errorMsgMap["description"] = structpb.NewStringValue(description)
+
+	mapper := &hcmv3.ResponseMapper{
+		Filter: &access_logv3.AccessLogFilter{
+			FilterSpecifier: genStatusCodeFilter(statusCode),
+		},
+		StatusCode: wrapperspb.UInt32(statusCode),
+		BodyFormatOverride: &corev3.SubstitutionFormatString{
+			Format: &corev3.SubstitutionFormatString_JsonFormat{
+				JsonFormat: &structpb.Struct{
+					Fields: errorMsgMap,
+				},
+			},
+		},
+	}
+	return mapper
+}
+
 func genSoap12ErrorResponseMapper(flag string, statusCode uint32, errorCode int32, message string, description string) *hcmv3.ResponseMapper {
 	msg, _ := soaputils.GenerateSoapFaultMessage(soap12ProtocolVersion, message, description, strconv.Itoa(int(errorCode)))
 	filters := []*access_logv3.AccessLogFilter{
@@ -139,6 +190,18 @@ func genSoap12ErrorResponseMapperForExtAuthz(statusCode uint32, errorCode int32,
 	return genSoapErrorResponseMapper(filters, statusCode, msg, contentTypeHeaderSoap)
 }
 
+func genSoap12ErrorResponseMapperForStatusCode(statusCode uint32, errorCode int32, message string, description string) *hcmv3.ResponseMapper {
+	msg, _ := soaputils.GenerateSoapFaultMessage(soap12ProtocolVersion, message, description, strconv.Itoa(int(errorCode)))
+	filters := []*access_logv3.AccessLogFilter{
+		{
+			FilterSpecifier: genStatusCodeFilter(statusCode),
+		},
+	}
+
+	filters = append(filters, genSoap12Filters()...)
+	return genSoapErrorResponseMapper(filters, statusCode, msg, contentTypeHeaderSoap)
+}
+
 func genSoap11ErrorResponseMapper(flag string, statusCode uint32, errorCode int32, message string, description string) *hcmv3.ResponseMapper {
 	msg, _ := soaputils.GenerateSoapFaultMessage(soap11ProtocolVersion, message, description, strconv.Itoa(int(errorCode)))
 	filters := []*access_logv3.AccessLogFilter{
@@ -161,6 +224,18 @@ func genSoap11ErrorResponseMapperForExtAuthz(statusCode uint32, errorCode int32,
 	return genSoapErrorResponseMapper(filters, statusCode, msg, contentTypeHeaderXML)
 }
 
+func genSoap11ErrorResponseMapperForStatusCode(statusCode uint32, errorCode int32, message string, description string) *hcmv3.ResponseMapper {
+	msg, _ := soaputils.GenerateSoapFaultMessage(soap11ProtocolVersion, message, description, strconv.Itoa(int(errorCode)))
+	filters := []*access_logv3.AccessLogFilter{
+		{
+			FilterSpecifier: genStatusCodeFilter(statusCode),
+		},
+	}
+
+	filters = append(filters, genSoap11Filters()...)
+	return genSoapErrorResponseMapper(filters, statusCode, msg, contentTypeHeaderXML)
+}
+
 func genSoapErrorResponseMapper(filters []*access_logv3.AccessLogFilter,
 	statusCode uint32, msg, contentTypeHeader string) *hcmv3.ResponseMapper {
 
@@ -196,6 +271,21 @@ func genResponseFlagFilter(flag string) *access_logv3.AccessLogFilter_ResponseFl
 	}
 }
 
+// genStatusCodeFilter returns a filter, which can be used to filter responses using the status code.
+func genStatusCodeFilter(statusCode uint32) *access_logv3.AccessLogFilter_StatusCodeFilter {
+	return &access_logv3.AccessLogFilter_StatusCodeFilter{
+		StatusCodeFilter: &access_logv3.StatusCodeFilter{
+			Comparison: &access_logv3.ComparisonFilter{
+				Op: access_logv3.ComparisonFilter_EQ,
+				Value: &corev3.RuntimeUInt32{
+					DefaultValue: statusCode,
+					RuntimeKey:   "apk.local_reply.status_code_" + strconv.Itoa(int(statusCode)),
+				},
+			},
+		},
+	}
+}
+
 // genPresentMatchHeaderFilter returns a header filter specifier, which can be used to check whether the header is present or not.
 func genPresentMatchHeaderFilter(headerName string) *access_logv3.AccessLogFilter_HeaderFilter {
 	return &access_logv3.AccessLogFilter_HeaderFilter{
@@ -28,6 +28,8 @@ import (
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
 	extProcessorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	previoushosts "github.com/envoyproxy/go-control-plane/envoy/extensions/retry/host/previous_hosts/v3"
+	previouspriorities "github.com/envoyproxy/go-control-plane/envoy/extensions/retry/priority/previous_priorities/v3"
 	envoy_type_matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/golang/protobuf/ptypes/any"
@@ -35,6 +37,8 @@ import (
 	"github.com/wso2/apk/adapter/internal/oasparser/constants"
 	"github.com/wso2/apk/adapter/internal/oasparser/model"
 	opConstants "github.com/wso2/apk/adapter/internal/operator/constants"
+	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -116,7 +120,7 @@ func generateRouteMatch(routeRegex string) *routev3.RouteMatch {
 	return match
 }
 
-func generateRouteAction(apiType string, routeConfig *model.EndpointConfig, ratelimitCriteria *ratelimitCriteria, mirrorClusterNames []string, isBackendBasedAIRatelimitEnabled bool, descriptorValueForBackendBasedAIRatelimit string) (action *routev3.Route_Route) {
+func generateRouteAction(apiType string, routeConfig *model.EndpointConfig, ratelimitCriteria *ratelimitCriteria, mirrorClusterNames []string, weightedClusters []weightedCluster, loadBalancing *model.LoadBalancing, isBackendBasedAIRatelimitEnabled bool, descriptorValueForBackendBasedAIRatelimit string) (action *routev3.Route_Route) {
 	action = &routev3.Route_Route{
 		Route: &routev3.RouteAction{
 			HostRewriteSpecifier: &routev3.RouteAction_AutoHostRewrite{
@@ -131,6 +135,11 @@ func generateRouteAction(apiType string, routeConfig *model.EndpointConfig, rate
 			},
 		},
 	}
+	// Split the traffic among the upstream clusters by weight, instead of routing to the cluster selected by the enforcer
+	if len(weightedClusters) > 1 {
+		action.Route.ClusterSpecifier = generateWeightedClusterSpecifier(weightedClusters)
+	}
+	action.Route.HashPolicy = generateHashPolicies(loadBalancing)
 	if routeConfig != nil {
 		action.Route.IdleTimeout = durationpb.New(time.Duration(routeConfig.IdleTimeoutInSeconds) * time.Second)
 	}
@@ -166,6 +175,79 @@ func generateRouteAction(apiType string, routeConfig *model.EndpointConfig, rate
 	return action
 }
 
+// generateHashPolicies creates the hash policies of a route, which are used by the consistent hashing load balancers.
+func generateHashPolicies(loadBalancing *model.LoadBalancing) []*routev3.RouteAction_HashPolicy {
+	if loadBalancing == nil {
+		return nil
+	}
+	policy := dpv1alpha2.LoadBalancingPolicyType(loadBalancing.Policy)
+	if policy != dpv1alpha2.RingHashLoadBalancing && policy != dpv1alpha2.MaglevLoadBalancing {
+		return nil
+	}
+	var hashPolicies []*routev3.RouteAction_HashPolicy
+	for _, hashKey := range loadBalancing.HashKeys {
+		switch dpv1alpha2.HashKeyType(hashKey.Type) {
+		case dpv1alpha2.HeaderHashKey:
+			if hashKey.Name == "" {
+				continue
+			}
+			hashPolicies = append(hashPolicies, &routev3.RouteAction_HashPolicy{
+				PolicySpecifier: &routev3.RouteAction_HashPolicy_Header_{
+					Header: &routev3.RouteAction_HashPolicy_Header{
+						HeaderName: hashKey.Name,
+					},
+				},
+			})
+		case dpv1alpha2.CookieHashKey:
+			if hashKey.Name == "" {
+				continue
+			}
+			cookie := &routev3.RouteAction_HashPolicy_Cookie{
+				Name: hashKey.Name,
+			}
+			// envoy generates the cookie when the ttl is set and the client has not sent the cookie
+			if hashKey.CookieTTL > 0 {
+				cookie.Ttl = durationpb.New(time.Duration(hashKey.CookieTTL) * time.Second)
+				cookie.Path = "/"
+			}
+			hashPolicies = append(hashPolicies, &routev3.RouteAction_HashPolicy{
+				PolicySpecifier: &routev3.RouteAction_HashPolicy_Cookie_{
+					Cookie: cookie,
+				},
+			})
+		case dpv1alpha2.SourceIPHashKey:
+			hashPolicies = append(hashPolicies, &routev3.RouteAction_HashPolicy{
+				PolicySpecifier: &routev3.RouteAction_HashPolicy_ConnectionProperties_{
+					ConnectionProperties: &routev3.RouteAction_HashPolicy_ConnectionProperties{
+						SourceIp: true,
+					},
+				},
+			})
+		}
+	}
+	// Envoy combines the hashes of all the available keys unless a policy is terminal. Hence every policy except
+	// the last one is made terminal, so that the hash is computed from the first available key.
+	for i := 0; i < len(hashPolicies)-1; i++ {
+		hashPolicies[i].Terminal = true
+	}
+	return hashPolicies
+}
+
+func generateWeightedClusterSpecifier(weightedClusters []weightedCluster) *routev3.RouteAction_WeightedClusters {
+	clusterWeights := make([]*routev3.WeightedCluster_ClusterWeight, 0, len(weightedClusters))
+	for _, weightedCluster := range weightedClusters {
+		clusterWeights = append(clusterWeights, &routev3.WeightedCluster_ClusterWeight{
+			Name:   weightedCluster.clusterName,
+			Weight: wrapperspb.UInt32(weightedCluster.weight),
+		})
+	}
+	return &routev3.RouteAction_WeightedClusters{
+		WeightedClusters: &routev3.WeightedCluster{
+			Clusters: clusterWeights,
+		},
+	}
+}
+
 func generateRequestRedirectRoute(route string, policyParams interface{}) (*routev3.Route_Redirect, error) {
 	policyParameters, _ := policyParams.(map[string]interface{})
 	scheme, _ := policyParameters[constants.RedirectScheme].(string)
@@ -257,6 +339,74 @@ func generateBackendBasedAIRatelimit(descValue string) []*routev3.RateLimit {
 	return []*routev3.RateLimit{&rateLimitForRequestTokenCount, &rateLimitForResponseTokenCount, &rateLimitForRequestCount, &rateLimitForTotalTokenCount}
 }
 
+// setAIRoutingRouteAction routes the request to the aggregate cluster selected for the requested model by the
+// AI routing lua filter and retries the failed requests on the next clusters of the aggregate cluster.
+func setAIRoutingRouteAction(action *routev3.Route_Route, routingClusters *aiRoutingClusters) {
+	if action == nil || routingClusters == nil {
+		return
+	}
+	action.Route.ClusterSpecifier = &routev3.RouteAction_ClusterHeader{
+		ClusterHeader: aiRoutingClusterHeaderName,
+	}
+	action.Route.RetryPolicy = generateAIRoutingRetryPolicy(routingClusters)
+}
+
+// generateAIRoutingRetryPolicy creates the retry policy which moves each retry of a request to the next
+// priority, i.e. the next target cluster, of the aggregate cluster on the failover conditions.
+func generateAIRoutingRetryPolicy(routingClusters *aiRoutingClusters) *routev3.RetryPolicy {
+	var retryOn []string
+	var retriableStatusCodes []uint32
+	for _, condition := range routingClusters.policy.FailoverOn {
+		switch dpv1alpha3.AIFailoverCondition(condition) {
+		case dpv1alpha3.AIFailoverOnServerError:
+			retryOn = append(retryOn, "5xx")
+		case dpv1alpha3.AIFailoverOnRateLimited:
+			retryOn = append(retryOn, retryPolicyRetriableStatusCodes)
+			retriableStatusCodes = append(retriableStatusCodes, 429)
+		case dpv1alpha3.AIFailoverOnTimeout:
+			retryOn = append(retryOn, "reset")
+		case dpv1alpha3.AIFailoverOnConnectFailure:
+			retryOn = append(retryOn, "connect-failure")
+		}
+	}
+	retryPolicy := &routev3.RetryPolicy{
+		RetryOn:                       strings.Join(retryOn, ","),
+		NumRetries:                    wrapperspb.UInt32(routingClusters.numRetries),
+		RetriableStatusCodes:          retriableStatusCodes,
+		HostSelectionRetryMaxAttempts: 3,
+	}
+	if routingClusters.policy.PerTryTimeoutInMillis > 0 {
+		retryPolicy.PerTryTimeout = durationpb.New(time.Duration(routingClusters.policy.PerTryTimeoutInMillis) * time.Millisecond)
+	}
+	previousPriorities, err := anypb.New(&previouspriorities.PreviousPrioritiesConfig{
+		UpdateFrequency: 1,
+	})
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the retry priority config. %v", err)
+		return retryPolicy
+	}
+	retryPolicy.RetryPriority = &routev3.RetryPolicy_RetryPriority{
+		Name: previousPrioritiesRetryName,
+		ConfigType: &routev3.RetryPolicy_RetryPriority_TypedConfig{
+			TypedConfig: previousPriorities,
+		},
+	}
+	previousHosts, err := anypb.New(&previoushosts.PreviousHostsPredicate{})
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the retry host predicate config. %v", err)
+		return retryPolicy
+	}
+	retryPolicy.RetryHostPredicate = []*routev3.RetryPolicy_RetryHostPredicate{
+		{
+			Name: previousHostsRetryPredicate,
+			ConfigType: &routev3.RetryPolicy_RetryHostPredicate_TypedConfig{
+				TypedConfig: previousHosts,
+			},
+		},
+	}
+	return retryPolicy
+}
+
 
 
 
@@ -326,6 +476,28 @@ func generateHTTPMethodMatcher(methodRegex string, sandClusterName string) []*ro
 	return headerMatcherArray
 }
 
+// generateRequestHeaderMatchers creates the header matchers for the header matches of a HTTPRoute rule.
+func generateRequestHeaderMatchers(headerMatches []gwapiv1.HTTPHeaderMatch) []*routev3.HeaderMatcher {
+	headerMatchers := make([]*routev3.HeaderMatcher, 0, len(headerMatches))
+	for _, headerMatch := range headerMatches {
+		if headerMatch.Type != nil && *headerMatch.Type == gwapiv1.HeaderMatchRegularExpression {
+			headerMatchers = append(headerMatchers, generateHeaderMatcher(string(headerMatch.Name), headerMatch.Value))
+			continue
+		}
+		headerMatchers = append(headerMatchers, &routev3.HeaderMatcher{
+			Name: string(headerMatch.Name),
+			HeaderMatchSpecifier: &routev3.HeaderMatcher_StringMatch{
+				StringMatch: &envoy_type_matcherv3.StringMatcher{
+					MatchPattern: &envoy_type_matcherv3.StringMatcher_Exact{
+						Exact: headerMatch.Value,
+					},
+				},
+			},
+		})
+	}
+	return headerMatchers
+}
+
 func generateQueryParamMatcher(queryParamName, value string) []*routev3.QueryParameterMatcher {
 	queryParamMatcher := &routev3.QueryParameterMatcher{
 		Name: queryParamName,
@@ -24,6 +24,7 @@ import (
 	"net"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -54,6 +55,7 @@ import (
 	logging "github.com/wso2/apk/adapter/internal/logging"
 	"github.com/wso2/apk/adapter/internal/oasparser/constants"
 	"github.com/wso2/apk/adapter/internal/oasparser/model"
+	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
 	"github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
 	"google.golang.org/protobuf/proto"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -198,6 +200,17 @@ func CreateRoutesWithClusters(adapterInternalAPI *model.AdapterInternalAPI, inte
 			}
 			routes = append(routes, defaultRoutes...)
 		}
+		if subscriptions := adapterInternalAPI.GetGQLSubscriptions(); len(subscriptions) > 0 {
+			subscriptionRoutes, err := createGQLSubscriptionRoutes(adapterInternalAPI, subscriptions, policies, vHost,
+				basePath, clusterName, organizationID)
+			if err != nil {
+				logger.LoggerXds.ErrorC(logging.PrintError(logging.Error2231, logging.MAJOR,
+					"Error while creating subscription routes for GQL API %s %s Error: %s", adapterInternalAPI.GetTitle(),
+					adapterInternalAPI.GetVersion(), err.Error()))
+				return nil, nil, nil, fmt.Errorf("error while creating routes. %v", err)
+			}
+			routes = append(routes, subscriptionRoutes...)
+		}
 		return routes, clusters, endpoints, nil
 	}
 
@@ -225,7 +238,32 @@ func CreateRoutesWithClusters(adapterInternalAPI *model.AdapterInternalAPI, inte
 			basePath := strings.TrimSuffix(endpoint.Endpoints[0].Basepath, "/")
 			existingClusterName := getExistingClusterName(*endpoint, processedEndpoints)
 
-			if existingClusterName == "" {
+			// When the traffic of the method is split among backends, a cluster is created per backend
+			var weightedClusters []weightedCluster
+			for i, weightedEndpoint := range resource.GetWeightedEndpoints() {
+				weightedEndpoint.EndpointCluster.HTTP2BackendEnabled = true
+				weightedClusterName := getExistingClusterName(*weightedEndpoint.EndpointCluster, processedEndpoints)
+				if weightedClusterName == "" {
+					weightedClusterName = getClusterName(weightedEndpoint.EndpointCluster.EndpointPrefix, organizationID, vHost,
+						adapterInternalAPI.GetTitle(), apiVersion, resource.GetID()+"_"+strconv.Itoa(i))
+					cluster, address, err := processEndpoints(weightedClusterName, weightedEndpoint.EndpointCluster, timeout, basePath)
+					if err != nil {
+						logger.LoggerOasparser.ErrorC(logging.PrintError(logging.Error2239, logging.MAJOR, "Error while adding weighted endpoints of backend %s for %s:%v-%v. %v", weightedEndpoint.BackendName, apiTitle, apiVersion, resourcePath, err.Error()))
+						continue
+					}
+					clusters = append(clusters, cluster)
+					endpoints = append(endpoints, address...)
+					processedEndpoints[weightedClusterName] = *weightedEndpoint.EndpointCluster
+				}
+				weightedClusters = append(weightedClusters, weightedCluster{
+					clusterName: weightedClusterName,
+					weight:      weightedEndpoint.Weight,
+				})
+			}
+
+			if len(weightedClusters) > 0 {
+				clusterName = weightedClusters[0].clusterName
+			} else if existingClusterName == "" {
 				clusterName = getClusterName(endpoint.EndpointPrefix, organizationID, vHost, adapterInternalAPI.GetTitle(), apiVersion, resource.GetID())
 				cluster, address, err := processEndpoints(clusterName, endpoint, timeout, basePath)
 				if err != nil {
@@ -246,6 +284,7 @@ func CreateRoutesWithClusters(adapterInternalAPI *model.AdapterInternalAPI, inte
 			endpoints = append(endpoints, endpointsI...)
 			routeParams := genRouteCreateParams(adapterInternalAPI, resource, vHost, basePath, clusterName, *operationalReqInterceptors, *operationalRespInterceptorVal, organizationID,
 				false, false, nil)
+			routeParams.weightedClusters = weightedClusters
 
 			routeP, err := createRoutes(routeParams)
 			if err != nil {
@@ -255,10 +294,22 @@ func CreateRoutesWithClusters(adapterInternalAPI *model.AdapterInternalAPI, inte
 				return nil, nil, nil, fmt.Errorf("error while creating routes. %v", err)
 			}
 			routes = append(routes, routeP...)
+			if grpcTranscoding := adapterInternalAPI.GetGRPCTranscoding(); grpcTranscoding != nil {
+				transcodingRoutes, err := createGRPCTranscodingRoutes(grpcTranscoding, resource, routeP)
+				if err != nil {
+					logger.LoggerXds.ErrorC(logging.PrintError(logging.Error2231, logging.MAJOR,
+						"Error while creating transcoding routes for GRPC API %s %s for path: %s Error: %s",
+						adapterInternalAPI.GetTitle(), adapterInternalAPI.GetVersion(), resource.GetPath(), err.Error()))
+					return nil, nil, nil, fmt.Errorf("error while creating routes. %v", err)
+				}
+				routes = append(routes, transcodingRoutes...)
+			}
 			if adapterInternalAPI.IsDefaultVersion {
-				defaultRoutes, errDefaultPath := createRoutes(genRouteCreateParams(adapterInternalAPI, resource, vHost, basePath, clusterName,
+				defaultRouteParams := genRouteCreateParams(adapterInternalAPI, resource, vHost, basePath, clusterName,
 					*operationalReqInterceptors, *operationalRespInterceptorVal, organizationID,
-					false, true, nil))
+					false, true, nil)
+				defaultRouteParams.weightedClusters = weightedClusters
+				defaultRoutes, errDefaultPath := createRoutes(defaultRouteParams)
 				if errDefaultPath != nil {
 					logger.LoggerXds.ErrorC(logging.PrintError(logging.Error2231, logging.MAJOR, "Error while creating routes for GRPC API %s %s for path: %s Error: %s",
 						adapterInternalAPI.GetTitle(), adapterInternalAPI.GetVersion(), removeFirstOccurrence(resource.GetPath(), adapterInternalAPI.GetVersion()), errDefaultPath.Error()))
@@ -271,6 +322,12 @@ func CreateRoutesWithClusters(adapterInternalAPI *model.AdapterInternalAPI, inte
 		return routes, clusters, endpoints, nil
 	}
 
+	// Create the clusters of the targets and the failover chains of the AI routing policy
+	clustersAI, endpointsAI, routingClusters := createAIRoutingClusters(adapterInternalAPI, vHost, organizationID,
+		timeout, processedEndpoints)
+	clusters = append(clusters, clustersAI...)
+	endpoints = append(endpoints, endpointsAI...)
+
 	for _, resource := range adapterInternalAPI.GetResources() {
 		var clusterName string
 		mirrorClusterNames := map[string][]string{}
@@ -282,7 +339,31 @@ func CreateRoutesWithClusters(adapterInternalAPI *model.AdapterInternalAPI, inte
 		}
 		existingClusterName := getExistingClusterName(*endpoint, processedEndpoints)
 
-		if existingClusterName == "" {
+		// When the traffic of the resource is split among backends, a cluster is created per backend
+		var weightedClusters []weightedCluster
+		for i, weightedEndpoint := range resource.GetWeightedEndpoints() {
+			weightedClusterName := getExistingClusterName(*weightedEndpoint.EndpointCluster, processedEndpoints)
+			if weightedClusterName == "" {
+				weightedClusterName = getClusterName(weightedEndpoint.EndpointCluster.EndpointPrefix, organizationID, vHost,
+					adapterInternalAPI.GetTitle(), apiVersion, resource.GetID()+"_"+strconv.Itoa(i))
+				cluster, address, err := processEndpoints(weightedClusterName, weightedEndpoint.EndpointCluster, timeout, basePath)
+				if err != nil {
+					logger.LoggerOasparser.ErrorC(logging.PrintError(logging.Error2239, logging.MAJOR, "Error while adding weighted endpoints of backend %s for %s:%v-%v. %v", weightedEndpoint.BackendName, apiTitle, apiVersion, resourcePath, err.Error()))
+					continue
+				}
+				clusters = append(clusters, cluster)
+				endpoints = append(endpoints, address...)
+				processedEndpoints[weightedClusterName] = *weightedEndpoint.EndpointCluster
+			}
+			weightedClusters = append(weightedClusters, weightedCluster{
+				clusterName: weightedClusterName,
+				weight:      weightedEndpoint.Weight,
+			})
+		}
+
+		if len(weightedClusters) > 0 {
+			clusterName = weightedClusters[0].clusterName
+		} else if existingClusterName == "" {
 			clusterName = getClusterName(endpoint.EndpointPrefix, organizationID, vHost, adapterInternalAPI.GetTitle(), apiVersion, resource.GetID())
 			cluster, address, err := processEndpoints(clusterName, endpoint, timeout, basePath)
 			if err != nil {
@@ -334,6 +415,8 @@ func CreateRoutesWithClusters(adapterInternalAPI *model.AdapterInternalAPI, inte
 		endpoints = append(endpoints, endpointsI...)
 		routeParams := genRouteCreateParams(adapterInternalAPI, resource, vHost, basePath, clusterName, *operationalReqInterceptors, *operationalRespInterceptorVal, organizationID,
 			false, false, mirrorClusterNames)
+		routeParams.weightedClusters = weightedClusters
+		routeParams.aiRouting = routingClusters
 
 		routeP, err := createRoutes(routeParams)
 		if err != nil {
@@ -344,8 +427,11 @@ func CreateRoutesWithClusters(adapterInternalAPI *model.AdapterInternalAPI, inte
 		}
 		routes = append(routes, routeP...)
 		if adapterInternalAPI.IsDefaultVersion {
-			defaultRoutes, errDefaultPath := createRoutes(genRouteCreateParams(adapterInternalAPI, resource, vHost, basePath, clusterName, *operationalReqInterceptors, *operationalRespInterceptorVal, organizationID,
-				false, true, mirrorClusterNames))
+			defaultRouteParams := genRouteCreateParams(adapterInternalAPI, resource, vHost, basePath, clusterName, *operationalReqInterceptors, *operationalRespInterceptorVal, organizationID,
+				false, true, mirrorClusterNames)
+			defaultRouteParams.weightedClusters = weightedClusters
+			defaultRouteParams.aiRouting = routingClusters
+			defaultRoutes, errDefaultPath := createRoutes(defaultRouteParams)
 			if errDefaultPath != nil {
 				logger.LoggerXds.ErrorC(logging.PrintError(logging.Error2231, logging.MAJOR, "Error while creating routes for API %s %s for path: %s Error: %s", adapterInternalAPI.GetTitle(), adapterInternalAPI.GetVersion(), removeFirstOccurrence(resource.GetPath(), adapterInternalAPI.GetVersion()), errDefaultPath.Error()))
 				return nil, nil, nil, fmt.Errorf("error while creating routes. %v", errDefaultPath)
@@ -541,6 +627,8 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 	epType := clusterDetails.EndpointType
 
 	addresses := []*corev3.Address{}
+	// priorities provided for the endpoints take precedence over the failover endpoint type
+	endpointPriorities := getEndpointPriorities(clusterDetails.Endpoints)
 
 	for i, ep := range clusterDetails.Endpoints {
 		// validating the basepath to be same for all upstreams of an api
@@ -564,10 +652,17 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 				},
 			},
 		}
+		if endpointPriorities != nil {
+			localityLbEndpoints.Priority = endpointPriorities[ep.Priority]
+		}
+		if ep.Weight > 0 {
+			localityLbEndpoints.LbEndpoints[0].LoadBalancingWeight = wrapperspb.UInt32(ep.Weight)
+		}
 
 		// create tls configs
 		if strings.HasPrefix(ep.URLType, httpsURLType) || strings.HasPrefix(ep.URLType, wssURLType) {
-			upstreamtlsContext := createUpstreamTLSContext(ep.Certificate, ep.AllowedSANs, address, clusterDetails.HTTP2BackendEnabled)
+			upstreamtlsContext := createUpstreamTLSContext(ep.Certificate, ep.AllowedSANs, ep.ClientCertificate, ep.ClientPrivateKey,
+				address, clusterDetails.HTTP2BackendEnabled)
 			marshalledTLSContext, err := anypb.New(upstreamtlsContext)
 			if err != nil {
 				return nil, nil, errors.New("internal Error while marshalling the upstream TLS Context")
@@ -665,6 +760,14 @@ func processEndpoints(clusterName string, clusterDetails *model.EndpointCluster,
 		cluster.HealthChecks = createHealthCheck(clusterDetails.HealthCheck)
 	}
 
+	if clusterDetails.OutlierDetection != nil {
+		cluster.OutlierDetection = createOutlierDetection(clusterDetails.OutlierDetection)
+	}
+
+	if clusterDetails.LoadBalancing != nil {
+		cluster.LbPolicy = getClusterLbPolicy(clusterDetails.LoadBalancing.Policy)
+	}
+
 	if clusterDetails.Config != nil && clusterDetails.Config.CircuitBreakers != nil {
 		circuitBreaker := clusterDetails.Config.CircuitBreakers
 		threshold := &clusterv3.CircuitBreakers_Thresholds{
@@ -700,9 +803,83 @@ func createHealthCheck(healthCheck *model.HealthCheck) []*corev3.HealthCheck {
 	}
 }
 
-func createUpstreamTLSContext(upstreamCerts []byte, allowedSANs []string, address *corev3.Address, hTTP2BackendEnabled bool) *tlsv3.UpstreamTlsContext {
+// getEndpointPriorities maps the priorities provided for the endpoints to contiguous envoy priorities
+// starting from 0, as envoy does not allow skipping priority levels. Returns nil if no priority is provided.
+func getEndpointPriorities(endpoints []model.Endpoint) map[uint32]uint32 {
+	var priorities []uint32
+	hasPriority := false
+	for _, ep := range endpoints {
+		if ep.Priority > 0 {
+			hasPriority = true
+		}
+		if !slices.Contains(priorities, ep.Priority) {
+			priorities = append(priorities, ep.Priority)
+		}
+	}
+	if !hasPriority {
+		return nil
+	}
+	slices.Sort(priorities)
+	endpointPriorities := make(map[uint32]uint32, len(priorities))
+	for i, priority := range priorities {
+		endpointPriorities[priority] = uint32(i)
+	}
+	return endpointPriorities
+}
+
+func getClusterLbPolicy(policy string) clusterv3.Cluster_LbPolicy {
+	switch dpv1alpha2.LoadBalancingPolicyType(policy) {
+	case dpv1alpha2.LeastRequestLoadBalancing:
+		return clusterv3.Cluster_LEAST_REQUEST
+	case dpv1alpha2.RandomLoadBalancing:
+		return clusterv3.Cluster_RANDOM
+	case dpv1alpha2.RingHashLoadBalancing:
+		return clusterv3.Cluster_RING_HASH
+	case dpv1alpha2.MaglevLoadBalancing:
+		return clusterv3.Cluster_MAGLEV
+	default:
+		return clusterv3.Cluster_ROUND_ROBIN
+	}
+}
+
+func createOutlierDetection(outlierDetection *model.OutlierDetection) *clusterv3.OutlierDetection {
+	od := &clusterv3.OutlierDetection{
+		Consecutive_5Xx:            wrapperspb.UInt32(outlierDetection.Consecutive5xxErrors),
+		EnforcingConsecutive_5Xx:   wrapperspb.UInt32(100),
+		EnforcingSuccessRate:       wrapperspb.UInt32(0),
+		EnforcingFailurePercentage: wrapperspb.UInt32(0),
+	}
+	// a zero threshold disables ejection, instead of ejecting on every failure
+	if outlierDetection.Consecutive5xxErrors == 0 {
+		od.EnforcingConsecutive_5Xx = wrapperspb.UInt32(0)
+	}
+	// envoy defaults are used for the values which are not set
+	if outlierDetection.Interval > 0 {
+		od.Interval = durationpb.New(time.Duration(outlierDetection.Interval) * time.Second)
+	}
+	if outlierDetection.BaseEjectionTime > 0 {
+		od.BaseEjectionTime = durationpb.New(time.Duration(outlierDetection.BaseEjectionTime) * time.Second)
+	}
+	if outlierDetection.MaxEjectionPercent > 0 {
+		od.MaxEjectionPercent = wrapperspb.UInt32(outlierDetection.MaxEjectionPercent)
+	}
+	if outlierDetection.ConsecutiveGatewayErrors > 0 {
+		od.ConsecutiveGatewayFailure = wrapperspb.UInt32(outlierDetection.ConsecutiveGatewayErrors)
+		od.EnforcingConsecutiveGatewayFailure = wrapperspb.UInt32(100)
+	}
+	return od
+}
+
+func createUpstreamTLSContext(upstreamCerts []byte, allowedSANs []string, clientCert []byte, clientKey []byte,
+	address *corev3.Address, hTTP2BackendEnabled bool) *tlsv3.UpstreamTlsContext {
 	conf := config.ReadConfigs()
-	tlsCert := generateTLSCert(conf.Envoy.KeyStore.KeyPath, conf.Envoy.KeyStore.CertPath)
+	var tlsCert *tlsv3.TlsCertificate
+	// the client certificate of the backend is presented for mutual TLS instead of the gateway certificate
+	if len(clientCert) > 0 && len(clientKey) > 0 {
+		tlsCert = generateTLSCertWithStr(string(clientKey), string(clientCert))
+	} else {
+		tlsCert = generateTLSCert(conf.Envoy.KeyStore.KeyPath, conf.Envoy.KeyStore.CertPath)
+	}
 	// Convert the cipher string to a string array
 	ciphersArray := strings.Split(conf.Envoy.Upstream.TLS.Ciphers, ",")
 	for i := range ciphersArray {
@@ -817,6 +994,7 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 	corsPolicy := getCorsPolicy(params.corsPolicy)
 	resource := params.resource
 	clusterName := params.clusterName
+	weightedClusters := params.weightedClusters
 	endpointBasepath := params.endpointBasePath
 	requestInterceptor := params.requestInterceptor
 	responseInterceptor := params.responseInterceptor
@@ -829,6 +1007,7 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 	resourcePath := resource.GetPath()
 	resourceMethods := resource.GetMethodList()
 	pathMatchType := resource.GetPathMatchType()
+	headerMatchers := generateRequestHeaderMatchers(resource.GetHeaderMatches())
 
 	contextExtensions := make(map[string]string)
 	contextExtensions[pathContextExtension] = resourcePath
@@ -846,6 +1025,9 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 	// Even if the routing is based on direct cluster, these properties needs to be populated
 	// to validate the key type component in the token.
 	contextExtensions[clusterNameContextExtension] = clusterName
+	if params.graphQLProtection != nil {
+		addGraphQLProtectionContextExtensions(contextExtensions, params.graphQLProtection)
+	}
 
 	extAuthPerFilterConfig := extAuthService.ExtAuthzPerRoute{
 		Override: &extAuthService.ExtAuthzPerRoute_CheckSettings{
@@ -938,6 +1120,14 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 		LuaLocal:                            luaFilter,
 		wellknown.CORS:                      corsFilter,
 	}
+	if params.isGRPCWebEnabled {
+		// The filter has no per route config, hence only the disabled flag of the filter is overridden
+		if grpcWebFilter, err := anypb.New(&routev3.FilterConfig{Disabled: false}); err == nil {
+			perRouteFilterConfigs[wellknown.GRPCWeb] = grpcWebFilter
+		} else {
+			logger.LoggerOasparser.Errorf("Error while marshalling the per route config of the grpc web filter. %v", err)
+		}
+	}
 	if !params.isAiAPI {
 		perFilterConfigExtProc := extProcessorv3.ExtProcPerRoute{
 			Override: &extProcessorv3.ExtProcPerRoute_Disabled{
@@ -951,25 +1141,53 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 		}
 		perRouteFilterConfigs[HTTPExternalProcessor] = filterExtProc
 	} else {
+		// The response headers are sent to the enforcer to detect server-sent event streams. The enforcer
+		// overrides the body mode to streamed for them, so the tokens are counted without buffering the body.
+		processingMode := &extProcessorv3.ProcessingMode{
+			RequestHeaderMode:  extProcessorv3.ProcessingMode_SKIP,
+			ResponseHeaderMode: extProcessorv3.ProcessingMode_SEND,
+			ResponseBodyMode:   extProcessorv3.ProcessingMode_BUFFERED,
+		}
 		if strings.ToUpper(resource.GetExtractTokenFromValue()) == "HEADER" {
-			perFilterConfigExtProc := extProcessorv3.ExtProcPerRoute{
-				Override: &extProcessorv3.ExtProcPerRoute_Overrides{
-					Overrides: &extProcessorv3.ExtProcOverrides{
-						ProcessingMode: &extProcessorv3.ProcessingMode{
-							RequestHeaderMode:  extProcessorv3.ProcessingMode_SKIP,
-							ResponseHeaderMode: extProcessorv3.ProcessingMode_SEND,
-							ResponseBodyMode:   extProcessorv3.ProcessingMode_NONE,
-						},
-					},
-				},
-			}
-			dataExtProc, _ := proto.Marshal(&perFilterConfigExtProc)
-			filterExtProc := &any.Any{
-				TypeUrl: extProcPerRouteName,
-				Value:   dataExtProc,
+			processingMode.ResponseBodyMode = extProcessorv3.ProcessingMode_NONE
+		} else {
+			// the prompts are sent to estimate the prompt tokens of the event streams which do not report the usage
+			processingMode.RequestBodyMode = extProcessorv3.ProcessingMode_BUFFERED
+		}
+		if params.aiGuardrails != nil {
+			// the prompts and the responses are buffered for the enforcer to apply the guardrails on them
+			processingMode.RequestBodyMode = extProcessorv3.ProcessingMode_BUFFERED
+			if params.aiGuardrails.HasResponseChecks() {
+				processingMode.ResponseBodyMode = extProcessorv3.ProcessingMode_BUFFERED
 			}
-			perRouteFilterConfigs[HTTPExternalProcessor] = filterExtProc
 		}
+		if params.aiResponseCache != nil {
+			// the request headers are sent to detect the cache bypass header, the prompts to look up the cache and
+			// the responses to be stored in the cache
+			processingMode.RequestHeaderMode = extProcessorv3.ProcessingMode_SEND
+			processingMode.RequestBodyMode = extProcessorv3.ProcessingMode_BUFFERED
+			processingMode.ResponseBodyMode = extProcessorv3.ProcessingMode_BUFFERED
+		}
+		if params.aiTransformation != nil {
+			// the requests are converted to the format of the provider and the responses back to the OpenAI format.
+			// The request headers are sent for the path and the credentials to be rewritten for the provider.
+			processingMode.RequestHeaderMode = extProcessorv3.ProcessingMode_SEND
+			processingMode.RequestBodyMode = extProcessorv3.ProcessingMode_BUFFERED
+			processingMode.ResponseBodyMode = extProcessorv3.ProcessingMode_BUFFERED
+		}
+		perFilterConfigExtProc := extProcessorv3.ExtProcPerRoute{
+			Override: &extProcessorv3.ExtProcPerRoute_Overrides{
+				Overrides: &extProcessorv3.ExtProcOverrides{
+					ProcessingMode: processingMode,
+				},
+			},
+		}
+		dataExtProc, _ := proto.Marshal(&perFilterConfigExtProc)
+		filterExtProc := &any.Any{
+			TypeUrl: extProcPerRouteName,
+			Value:   dataExtProc,
+		}
+		perRouteFilterConfigs[HTTPExternalProcessor] = filterExtProc
 	}
 	perFilterConfigRL := ratelimitv3.RateLimitPerRoute{
 		VhRateLimits: ratelimitv3.RateLimitPerRoute_INCLUDE,
@@ -980,6 +1198,9 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 		Value:   ratelimitPerRoute,
 	}
 	perRouteFilterConfigs[RatelimitFilterName] = filterrl
+	if params.aiRouting != nil {
+		perRouteFilterConfigs = getAIRoutingFilterConfigs(perRouteFilterConfigs, params.aiRouting)
+	}
 
 	logger.LoggerOasparser.Debugf("adding route : %s for API : %s", resourcePath, title)
 
@@ -1037,6 +1258,7 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 		}
 	}
 	routeConfig := resource.GetEndpoints().Config
+	loadBalancing := resource.GetEndpoints().LoadBalancing
 	metaData := &corev3.Metadata{}
 	if params.isAiAPI {
 		metaData = &corev3.Metadata{
@@ -1057,6 +1279,18 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 				},
 			},
 		}
+		if params.aiGuardrails != nil {
+			metaData.FilterMetadata["envoy.filters.http.ext_proc"].Fields[aiGuardrailsMetadataKey] =
+				structpb.NewStringValue(getAIGuardrailsMetadataValue(params.aiGuardrails))
+		}
+		if params.aiResponseCache != nil {
+			metaData.FilterMetadata["envoy.filters.http.ext_proc"].Fields[aiResponseCacheMetadataKey] =
+				structpb.NewStringValue(getAIResponseCacheMetadataValue(params.aiResponseCache))
+		}
+		if params.aiTransformation != nil {
+			metaData.FilterMetadata["envoy.filters.http.ext_proc"].Fields[aiTransformationMetadataKey] =
+				structpb.NewStringValue(getAITransformationMetadataValue(params.aiTransformation))
+		}
 	} else {
 		metaData = nil
 	}
@@ -1088,6 +1322,23 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 			var requestRedirectAction *routev3.Route_Redirect
 			hasMethodRewritePolicy := false
 			var newMethod string
+			var requestBodyTransformation *model.BodyTransformation
+			var responseBodyTransformation *model.BodyTransformation
+			var soapMediation *model.SOAPMediation
+			routeFilterConfigs := perRouteFilterConfigs
+			if operation.GetPayloadPolicy() != nil {
+				routeFilterConfigs = getPayloadPolicyFilterConfigs(perRouteFilterConfigs, operation.GetPayloadPolicy())
+			}
+			if operation.GetSchemaValidation() != nil && !params.isAiAPI {
+				// applied before the response cache, as the response cache also sends the responses to the enforcer
+				routeFilterConfigs = getSchemaValidationFilterConfigs(routeFilterConfigs, contextExtensions,
+					operation.GetSchemaValidation())
+			}
+			routeMetaData := metaData
+			if operation.GetResponseCache() != nil && operation.GetMethod() == "GET" && !params.isAiAPI {
+				routeFilterConfigs = getResponseCacheFilterConfigs(routeFilterConfigs)
+				routeMetaData = getResponseCacheMetadata(operation.GetResponseCache())
+			}
 
 			// Policies - for request flow
 			for _, requestPolicy := range operation.GetPolicies().Request {
@@ -1148,6 +1399,22 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 					if err != nil {
 						return nil, err
 					}
+				case constants.ActionTransformBody:
+					logger.LoggerOasparser.Debugf("Adding %s policy to request flow for %s %s",
+						constants.ActionTransformBody, resourcePath, operation.GetMethod())
+					requestBodyTransformation, err = generateBodyTransformation(requestPolicy.Parameters)
+					if err != nil {
+						return nil, fmt.Errorf("error adding request policy %s to operation %s of resource %s."+
+							" %v", requestPolicy.Action, operation.GetMethod(), resourcePath, err)
+					}
+				case constants.ActionMediateSOAP:
+					logger.LoggerOasparser.Debugf("Adding %s policy to request flow for %s %s",
+						constants.ActionMediateSOAP, resourcePath, operation.GetMethod())
+					soapMediation, err = generateSOAPMediation(requestPolicy.Parameters)
+					if err != nil {
+						return nil, fmt.Errorf("error adding request policy %s to operation %s of resource %s."+
+							" %v", requestPolicy.Action, operation.GetMethod(), resourcePath, err)
+					}
 				}
 			}
 
@@ -1174,9 +1441,29 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 							" %v", responsePolicy.Action, operation.GetMethod(), resourcePath, err)
 					}
 					responseHeadersToRemove = append(responseHeadersToRemove, responseHeaderToRemove)
+				case constants.ActionTransformBody:
+					logger.LoggerOasparser.Debugf("Adding %s policy to response flow for %s %s",
+						constants.ActionTransformBody, resourcePath, operation.GetMethod())
+					responseBodyTransformation, err = generateBodyTransformation(responsePolicy.Parameters)
+					if err != nil {
+						return nil, fmt.Errorf("error adding response policy %s to operation %s of resource %s."+
+							" %v", responsePolicy.Action, operation.GetMethod(), resourcePath, err)
+					}
 				}
 			}
 
+			if (requestBodyTransformation != nil || responseBodyTransformation != nil) && !params.isAiAPI {
+				// applied after the response cache and the schema validation, as their processing modes are merged
+				routeFilterConfigs = getBodyTransformationFilterConfigs(routeFilterConfigs,
+					requestBodyTransformation != nil, responseBodyTransformation != nil)
+				routeMetaData = getBodyTransformationMetadata(routeMetaData, requestBodyTransformation,
+					responseBodyTransformation)
+			}
+			if soapMediation != nil && !params.isAiAPI {
+				routeFilterConfigs = getSOAPMediationFilterConfigs(routeFilterConfigs)
+				routeMetaData = getSOAPMediationMetadata(routeMetaData, soapMediation)
+			}
+
 			var mirrorClusterNameList []string
 			if mirrorClusterNames != nil && mirrorClusterNames[operation.GetID()] != nil {
 				mirrorClusterNameList = mirrorClusterNames[operation.GetID()]
@@ -1187,9 +1474,9 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 				logger.LoggerOasparser.Debugf("Creating two routes to support method rewrite for %s %s. New method: %s",
 					resourcePath, operation.GetMethod(), newMethod)
 				match1 := generateRouteMatch(routePath)
-				match1.Headers = generateHTTPMethodMatcher(operation.GetMethod(), clusterName)
+				match1.Headers = append(generateHTTPMethodMatcher(operation.GetMethod(), clusterName), headerMatchers...)
 				match2 := generateRouteMatch(routePath)
-				match2.Headers = generateHTTPMethodMatcher(newMethod, clusterName)
+				match2.Headers = append(generateHTTPMethodMatcher(newMethod, clusterName), headerMatchers...)
 
 				//- external routes only accept requests if metadata "method-rewrite" is null
 				//- external routes adds the metadata "method-rewrite"
@@ -1199,13 +1486,15 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 				metadataValue := operation.GetMethod() + "_to_" + newMethod
 				match2.DynamicMetadata = generateMetadataMatcherForInternalRoutes(metadataValue)
 
-				action1 := generateRouteAction(apiType, routeConfig, rateLimitPolicyCriteria, mirrorClusterNameList, resource.GetEnableBackendBasedAIRatelimit() && params.isAiAPI, resource.GetBackendBasedAIRatelimitDescriptorValue())
-				action2 := generateRouteAction(apiType, routeConfig, rateLimitPolicyCriteria, mirrorClusterNameList, resource.GetEnableBackendBasedAIRatelimit() && params.isAiAPI, resource.GetBackendBasedAIRatelimitDescriptorValue())
+				action1 := generateRouteAction(apiType, routeConfig, rateLimitPolicyCriteria, mirrorClusterNameList, weightedClusters, loadBalancing, resource.GetEnableBackendBasedAIRatelimit() && params.isAiAPI, resource.GetBackendBasedAIRatelimitDescriptorValue())
+				setAIRoutingRouteAction(action1, params.aiRouting)
+				action2 := generateRouteAction(apiType, routeConfig, rateLimitPolicyCriteria, mirrorClusterNameList, weightedClusters, loadBalancing, resource.GetEnableBackendBasedAIRatelimit() && params.isAiAPI, resource.GetBackendBasedAIRatelimitDescriptorValue())
+				setAIRoutingRouteAction(action2, params.aiRouting)
 
 				requestHeadersToRemove := make([]string, 0)
 				// Create route1 for current method.
 				// Do not add policies to route config. Send via enforcer
-				route1 := generateRouteConfig(xWso2Basepath+operation.GetMethod(), match1, action1, requestRedirectAction, metaData, decorator, perRouteFilterConfigs,
+				route1 := generateRouteConfig(xWso2Basepath+operation.GetMethod(), match1, action1, requestRedirectAction, routeMetaData, decorator, routeFilterConfigs,
 					nil, requestHeadersToRemove, nil, nil)
 
 				// Create route2 for new method.
@@ -1224,19 +1513,20 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 			} else {
 				var action *routev3.Route_Route
 				if requestRedirectAction == nil {
-					action = generateRouteAction(apiType, routeConfig, rateLimitPolicyCriteria, mirrorClusterNameList, resource.GetEnableBackendBasedAIRatelimit() && params.isAiAPI, resource.GetBackendBasedAIRatelimitDescriptorValue())
+					action = generateRouteAction(apiType, routeConfig, rateLimitPolicyCriteria, mirrorClusterNameList, weightedClusters, loadBalancing, resource.GetEnableBackendBasedAIRatelimit() && params.isAiAPI, resource.GetBackendBasedAIRatelimitDescriptorValue())
+					setAIRoutingRouteAction(action, params.aiRouting)
 				}
 				logger.LoggerOasparser.Debug("Creating routes for resource with policies", resourcePath, operation.GetMethod())
 				// create route for current method. Add policies to route config. Send via enforcer
 				match := generateRouteMatch(routePath)
-				match.Headers = generateHTTPMethodMatcher(operation.GetMethod(), clusterName)
+				match.Headers = append(generateHTTPMethodMatcher(operation.GetMethod(), clusterName), headerMatchers...)
 				match.DynamicMetadata = generateMetadataMatcherForExternalRoutes()
 				if pathRewriteConfig != nil && requestRedirectAction == nil {
 					action.Route.RegexRewrite = pathRewriteConfig
 				} else if requestRedirectAction == nil {
 					action.Route.RegexRewrite = generateRegexMatchAndSubstitute(routePath, resourcePath, pathMatchType)
 				}
-				route := generateRouteConfig(xWso2Basepath, match, action, requestRedirectAction, metaData, decorator, perRouteFilterConfigs,
+				route := generateRouteConfig(xWso2Basepath, match, action, requestRedirectAction, routeMetaData, decorator, routeFilterConfigs,
 					requestHeadersToAdd, requestHeadersToRemove, responseHeadersToAdd, responseHeadersToRemove)
 				routes = append(routes, route)
 			}
@@ -1249,8 +1539,9 @@ func createRoutes(params *routeCreateParams) (routes []*routev3.Route, err error
 			methodRegex = methodRegex + "|OPTIONS"
 		}
 		match := generateRouteMatch(routePath)
-		match.Headers = generateHTTPMethodMatcher(methodRegex, clusterName)
-		action := generateRouteAction(apiType, routeConfig, rateLimitPolicyCriteria, nil, resource.GetEnableBackendBasedAIRatelimit() && params.isAiAPI, resource.GetBackendBasedAIRatelimitDescriptorValue())
+		match.Headers = append(generateHTTPMethodMatcher(methodRegex, clusterName), headerMatchers...)
+		action := generateRouteAction(apiType, routeConfig, rateLimitPolicyCriteria, nil, weightedClusters, loadBalancing, resource.GetEnableBackendBasedAIRatelimit() && params.isAiAPI, resource.GetBackendBasedAIRatelimitDescriptorValue())
+		setAIRoutingRouteAction(action, params.aiRouting)
 		rewritePath := generateRoutePathForReWrite(basePath, resourcePath, pathMatchType)
 		action.Route.RegexRewrite = generateRegexMatchAndSubstitute(rewritePath, resourcePath, pathMatchType)
 		requestHeadersToRemove := make([]string, 0)
@@ -1815,6 +2106,11 @@ func genRouteCreateParams(swagger *model.AdapterInternalAPI, resource *model.Res
 		envType:                      swagger.EnvType,
 		mirrorClusterNames:           mirrorClusterNames,
 		isAiAPI:                      swagger.AIProvider.Enabled,
+		aiGuardrails:                 swagger.GetAIGuardrails(),
+		aiResponseCache:              swagger.GetAIResponseCache(),
+		aiTransformation:             getAITransformation(swagger, resource, endpointBasePath),
+		graphQLProtection:            swagger.GetGraphQLProtection(),
+		isGRPCWebEnabled:             swagger.IsGRPCWebEnabled(),
 	}
 	return params
 }
@@ -20,12 +20,15 @@ import (
 	"strings"
 	"testing"
 
+	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/wso2/apk/adapter/internal/dataholder"
 	"github.com/wso2/apk/adapter/internal/discovery/xds"
 	"github.com/wso2/apk/adapter/internal/loggers"
 	envoy "github.com/wso2/apk/adapter/internal/oasparser/envoyconf"
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
 	"github.com/wso2/apk/adapter/internal/operator/constants"
 	"github.com/wso2/apk/adapter/internal/operator/synchronizer"
 	operatorutils "github.com/wso2/apk/adapter/internal/operator/utils"
@@ -738,3 +741,248 @@ func TestCreateRoutesWithClustersSameBackendRefs(t *testing.T) {
 	_, clusters, _, _ := envoy.CreateRoutesWithClusters(adapterInternalAPI, nil, "prod.gw.wso2.com", "carbon.super")
 	assert.Equal(t, 2, len(clusters), "Number of production clusters created is incorrect.")
 }
+
+func TestCreateRoutesWithClustersWeightedBackendRefs(t *testing.T) {
+	apiState := synchronizer.APIState{}
+	apiDefinition := v1alpha3.API{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-api-weighted-backendrefs",
+		},
+		Spec: v1alpha3.APISpec{
+			APIName:    "test-api-weighted-backendrefs",
+			APIVersion: "1.0.0",
+			BasePath:   "/test-api-weighted-backendrefs/1.0.0",
+			Production: []v1alpha3.EnvConfig{
+				{
+					RouteRefs: []string{
+						"test-api-weighted-backendrefs-prod-http-route",
+					},
+				},
+			},
+		},
+	}
+	apiState.APIDefinition = &apiDefinition
+	httpRouteState := synchronizer.HTTPRouteState{}
+	methodTypeGet := gwapiv1.HTTPMethodGet
+
+	stableWeight, canaryWeight := int32(95), int32(5)
+	stableBackendRef := createDefaultBackendRef("test-backend-stable")
+	stableBackendRef.Weight = &stableWeight
+	canaryBackendRef := createDefaultBackendRef("test-backend-canary")
+	canaryBackendRef.Weight = &canaryWeight
+
+	apiState.AIProvider = new(v1alpha3.AIProvider)
+	httpRouteState.RuleIdxToAiRatelimitPolicyMapping = make(map[int]*v1alpha3.AIRateLimitPolicy)
+	httpRoute := gwapiv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-api-weighted-backendrefs-prod-http-route",
+		},
+		Spec: gwapiv1.HTTPRouteSpec{
+			Hostnames:       []gwapiv1.Hostname{"prod.gw.wso2.com"},
+			CommonRouteSpec: createDefaultCommonRouteSpec(),
+			Rules: []gwapiv1.HTTPRouteRule{
+				{
+					Matches: []gwapiv1.HTTPRouteMatch{
+						{
+							Path: &gwapiv1.HTTPPathMatch{
+								Type:  operatorutils.PathMatchTypePtr(gwapiv1.PathMatchExact),
+								Value: operatorutils.StringPtr("/orders"),
+							},
+							Method: &methodTypeGet,
+						},
+					},
+					BackendRefs: []gwapiv1.HTTPBackendRef{stableBackendRef, canaryBackendRef},
+				},
+				{
+					Matches: []gwapiv1.HTTPRouteMatch{
+						{
+							Path: &gwapiv1.HTTPPathMatch{
+								Type:  operatorutils.PathMatchTypePtr(gwapiv1.PathMatchExact),
+								Value: operatorutils.StringPtr("/orders"),
+							},
+							Headers: []gwapiv1.HTTPHeaderMatch{
+								{
+									Name:  "x-canary",
+									Value: "true",
+								},
+							},
+							Method: &methodTypeGet,
+						},
+					},
+					BackendRefs: []gwapiv1.HTTPBackendRef{
+						createDefaultBackendRef("test-backend-canary"),
+					},
+				},
+			},
+		},
+	}
+
+	httpRouteState.HTTPRouteCombined = &httpRoute
+
+	backendMapping := make(map[string]*v1alpha2.ResolvedBackend)
+	backendTimeout := &v1alpha2.Timeout{UpstreamResponseTimeout: 10, DownstreamRequestIdleTimeout: 30}
+	backendMapping[k8types.NamespacedName{Namespace: "default", Name: "test-backend-stable"}.String()] =
+		&v1alpha2.ResolvedBackend{Services: []v1alpha2.Service{{Host: "order-service-v1.default", Port: 80}},
+			Protocol: v1alpha2.HTTPProtocol, Timeout: backendTimeout,
+			CircuitBreaker: &v1alpha2.CircuitBreaker{MaxRequests: 100}}
+	backendMapping[k8types.NamespacedName{Namespace: "default", Name: "test-backend-canary"}.String()] =
+		&v1alpha2.ResolvedBackend{Services: []v1alpha2.Service{{Host: "order-service-v2.default", Port: 80}},
+			Protocol: v1alpha2.HTTPProtocol, Timeout: backendTimeout,
+			CircuitBreaker: &v1alpha2.CircuitBreaker{MaxRequests: 5}}
+	httpRouteState.BackendMapping = backendMapping
+
+	apiState.ProdHTTPRoute = &httpRouteState
+	xds.SanitizeGateway("default-gateway", true)
+
+	adapterInternalAPI, labels, err := synchronizer.UpdateInternalMapsFromHTTPRoute(apiState, &httpRouteState, constants.Production)
+	assert.Equal(t, map[string]struct{}{"default-gateway": {}}, labels, "Labels are incorrect.")
+	assert.Nil(t, err, "Error should not be present when apiState is converted to a AdapterInternalAPI object")
+	routes, clusters, _, _ := envoy.CreateRoutesWithClusters(adapterInternalAPI, nil, "prod.gw.wso2.com", "carbon.super")
+	// The canary backend cluster is shared by the header based rule and the weighted rule.
+	assert.Equal(t, 3, len(clusters), "Number of production clusters created is incorrect.")
+
+	var canaryRouteIndex, weightedRouteIndex = -1, -1
+	for i, route := range routes {
+		if route.GetRoute() == nil || route.GetMatch().GetSafeRegex() == nil ||
+			!strings.Contains(route.GetMatch().GetSafeRegex().Regex, "orders") {
+			continue
+		}
+		for _, header := range route.GetMatch().GetHeaders() {
+			if header.GetName() == "x-canary" && canaryRouteIndex == -1 {
+				canaryRouteIndex = i
+				assert.Equal(t, "true", header.GetStringMatch().GetExact(), "Canary header value is incorrect.")
+			}
+		}
+		if route.GetRoute().GetWeightedClusters() != nil && weightedRouteIndex == -1 {
+			weightedRouteIndex = i
+		}
+	}
+	assert.NotEqual(t, -1, canaryRouteIndex, "Header based canary route should be created.")
+	assert.NotEqual(t, -1, weightedRouteIndex, "Weighted route should be created.")
+	assert.Less(t, canaryRouteIndex, weightedRouteIndex, "Header based canary route should be placed before the weighted route.")
+
+	weightedClusters := routes[weightedRouteIndex].GetRoute().GetWeightedClusters().GetClusters()
+	assert.Equal(t, 2, len(weightedClusters), "Number of weighted clusters is incorrect.")
+	assert.Equal(t, uint32(95), weightedClusters[0].GetWeight().GetValue(), "Weight of the stable cluster is incorrect.")
+	assert.Equal(t, uint32(5), weightedClusters[1].GetWeight().GetValue(), "Weight of the canary cluster is incorrect.")
+	assert.Equal(t, routes[canaryRouteIndex].GetRoute().GetClusterHeader(), "x-wso2-cluster-header",
+		"Header based canary route should route to the cluster selected by the enforcer.")
+
+	// Each weighted cluster is created with the circuit breakers of its own backend.
+	maxRequests := map[string]uint32{}
+	for _, cluster := range clusters {
+		if thresholds := cluster.GetCircuitBreakers().GetThresholds(); len(thresholds) > 0 {
+			maxRequests[cluster.GetName()] = thresholds[0].GetMaxRequests().GetValue()
+		}
+	}
+	assert.Equal(t, uint32(100), maxRequests[weightedClusters[0].GetName()], "Circuit breaker of the stable cluster is incorrect.")
+	assert.Equal(t, uint32(5), maxRequests[weightedClusters[1].GetName()], "Circuit breaker of the canary cluster is incorrect.")
+	assert.Equal(t, int64(30), routes[weightedRouteIndex].GetRoute().GetIdleTimeout().GetSeconds(),
+		"Idle timeout shared by the backends of the rule is incorrect.")
+
+	// The cluster passed to the enforcer is one of the weighted clusters, so the cluster header set by the enforcer
+	// does not change the cluster selected by weight.
+	extAuthPerRouteConfig := &extAuthService.ExtAuthzPerRoute{}
+	err = routes[weightedRouteIndex].GetTypedPerFilterConfig()[wellknown.HTTPExternalAuthorization].
+		UnmarshalTo(extAuthPerRouteConfig)
+	assert.Nil(t, err, "Error while parsing ExtAuthzPerRouteConfig.")
+	assert.Equal(t, weightedClusters[0].GetName(), extAuthPerRouteConfig.GetCheckSettings().GetContextExtensions()["clusterName"],
+		"Cluster of the weighted route passed to the enforcer is incorrect.")
+}
+
+func TestCreateRoutesWithClustersGRPCRouteRules(t *testing.T) {
+	orderService, getOrder, createOrder := "OrderService", "GetOrder", "CreateOrder"
+	createGRPCBackendRef := func(backendName string, weight int32) gwapiv1.GRPCBackendRef {
+		return gwapiv1.GRPCBackendRef{
+			BackendRef: gwapiv1.BackendRef{
+				BackendObjectReference: gwapiv1.BackendObjectReference{
+					Group: (*gwapiv1.Group)(&v1alpha1.GroupVersion.Group),
+					Kind:  operatorutils.KindPtr("Backend"),
+					Name:  gwapiv1.ObjectName(backendName),
+				},
+				Weight: &weight,
+			},
+		}
+	}
+	grpcRoute := &gwapiv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-api-grpc-route",
+		},
+		Spec: gwapiv1.GRPCRouteSpec{
+			Hostnames:       []gwapiv1.Hostname{"prod.gw.wso2.com"},
+			CommonRouteSpec: createDefaultCommonRouteSpec(),
+			Rules: []gwapiv1.GRPCRouteRule{
+				{
+					Matches: []gwapiv1.GRPCRouteMatch{
+						{Method: &gwapiv1.GRPCMethodMatch{Service: &orderService, Method: &getOrder}},
+					},
+					BackendRefs: []gwapiv1.GRPCBackendRef{createGRPCBackendRef("order-query-backend", 1)},
+				},
+				{
+					Matches: []gwapiv1.GRPCRouteMatch{
+						{Method: &gwapiv1.GRPCMethodMatch{Service: &orderService, Method: &createOrder}},
+					},
+					BackendRefs: []gwapiv1.GRPCBackendRef{
+						createGRPCBackendRef("order-command-backend-v1", 80),
+						createGRPCBackendRef("order-command-backend-v2", 20),
+					},
+				},
+			},
+		},
+	}
+
+	backendMapping := make(map[string]*v1alpha2.ResolvedBackend)
+	backendMapping[k8types.NamespacedName{Namespace: "default", Name: "order-query-backend"}.String()] =
+		&v1alpha2.ResolvedBackend{Services: []v1alpha2.Service{{Host: "order-query.default", Port: 50051}},
+			Protocol: v1alpha2.HTTPProtocol, Timeout: &v1alpha2.Timeout{DownstreamRequestIdleTimeout: 30}}
+	backendMapping[k8types.NamespacedName{Namespace: "default", Name: "order-command-backend-v1"}.String()] =
+		&v1alpha2.ResolvedBackend{Services: []v1alpha2.Service{{Host: "order-command-v1.default", Port: 50051}},
+			Protocol: v1alpha2.HTTPProtocol, CircuitBreaker: &v1alpha2.CircuitBreaker{MaxRequests: 100}}
+	backendMapping[k8types.NamespacedName{Namespace: "default", Name: "order-command-backend-v2"}.String()] =
+		&v1alpha2.ResolvedBackend{Services: []v1alpha2.Service{{Host: "order-command-v2.default", Port: 50051}},
+			Protocol: v1alpha2.HTTPProtocol}
+
+	var adapterInternalAPI model.AdapterInternalAPI
+	adapterInternalAPI.SetInfoAPICR(v1alpha3.API{Spec: v1alpha3.APISpec{APIName: "orders", APIVersion: "v1",
+		APIType: "GRPC", BasePath: "/org.example.orders.v1", Organization: "carbon.super"}})
+	err := adapterInternalAPI.SetInfoGRPCRouteCR(grpcRoute, model.ResourceParams{BackendMapping: backendMapping})
+	assert.Nil(t, err, "Setting the GRPCRoute should not fail.")
+	assert.Equal(t, "order-query.default", adapterInternalAPI.Endpoints.Endpoints[0].Host,
+		"Backend of the API should be the backend of the first rule.")
+
+	routes, clusters, _, err := envoy.CreateRoutesWithClusters(&adapterInternalAPI, nil, "prod.gw.wso2.com", "carbon.super")
+	assert.Nil(t, err, "Creating the routes should not fail.")
+	// The API definition cluster, the API level cluster, the cluster of the GetOrder method and a cluster per backend
+	// of the CreateOrder method
+	assert.Equal(t, 5, len(clusters), "Number of clusters created is incorrect.")
+	for _, cluster := range clusters[1:] {
+		assert.NotNil(t, cluster.GetTypedExtensionProtocolOptions()["envoy.extensions.upstreams.http.v3.HttpProtocolOptions"],
+			"HTTP2 should be enabled for the cluster %s.", cluster.GetName())
+	}
+
+	var getOrderRouteFound, createOrderRouteFound bool
+	for _, route := range routes {
+		if route.GetRoute() == nil {
+			continue
+		}
+		path := route.GetMatch().GetSafeRegex().GetRegex()
+		if strings.Contains(path, getOrder) {
+			getOrderRouteFound = true
+			assert.Equal(t, "x-wso2-cluster-header", route.GetRoute().GetClusterHeader(),
+				"GetOrder route should route to the cluster selected by the enforcer.")
+			assert.Equal(t, int64(30), route.GetRoute().GetIdleTimeout().GetSeconds(),
+				"Idle timeout of the GetOrder route should be the timeout of its backend.")
+		} else if strings.Contains(path, createOrder) {
+			createOrderRouteFound = true
+			weightedClusters := route.GetRoute().GetWeightedClusters().GetClusters()
+			assert.Equal(t, 2, len(weightedClusters), "Number of weighted clusters is incorrect.")
+			assert.Equal(t, uint32(80), weightedClusters[0].GetWeight().GetValue(), "Weight of the v1 cluster is incorrect.")
+			assert.Equal(t, uint32(20), weightedClusters[1].GetWeight().GetValue(), "Weight of the v2 cluster is incorrect.")
+		}
+	}
+	assert.True(t, getOrderRouteFound, "GetOrder route should be created.")
+	assert.True(t, createOrderRouteFound, "CreateOrder route should be created.")
+}
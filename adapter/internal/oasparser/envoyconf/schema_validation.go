@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package envoyconf
+
+import (
+	"strconv"
+
+	extAuthService "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	extProcessorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/wso2/apk/adapter/config"
+	logger "github.com/wso2/apk/adapter/internal/loggers"
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
+	"google.golang.org/protobuf/proto"
+)
+
+// getSchemaValidationFilterConfigs returns a copy of the given per route filter configs which passes the whole request
+// payload to the enforcer and asks it to validate the request against the OpenAPI definition of the API. When the
+// responses are validated as well, the external processor is enabled for the responses of the route.
+func getSchemaValidationFilterConfigs(perRouteFilterConfigs map[string]*any.Any, contextExtensions map[string]string,
+	schemaValidation *model.SchemaValidation) map[string]*any.Any {
+	filterConfigs := make(map[string]*any.Any, len(perRouteFilterConfigs)+1)
+	for name, filterConfig := range perRouteFilterConfigs {
+		filterConfigs[name] = filterConfig
+	}
+	validationContextExtensions := make(map[string]string, len(contextExtensions)+2)
+	for key, value := range contextExtensions {
+		validationContextExtensions[key] = value
+	}
+	validationContextExtensions[schemaValidationContextExtension] = "true"
+	validationContextExtensions[validateResponsesContextExtension] = strconv.FormatBool(schemaValidation.ValidateResponses)
+	conf := config.ReadConfigs()
+	extAuthPerFilterConfig := extAuthService.ExtAuthzPerRoute{
+		Override: &extAuthService.ExtAuthzPerRoute_CheckSettings{
+			CheckSettings: &extAuthService.CheckSettings{
+				ContextExtensions: validationContextExtensions,
+				// the request body is validated by the enforcer, hence the requests with bodies larger than the
+				// buffer are rejected instead of sending a truncated body to be validated
+				DisableRequestBodyBuffering: false,
+				WithRequestBody: &extAuthService.BufferSettings{
+					MaxRequestBytes:     conf.Envoy.PayloadPassingToEnforcer.MaxRequestBytes,
+					AllowPartialMessage: false,
+					PackAsBytes:         conf.Envoy.PayloadPassingToEnforcer.PackAsBytes,
+				},
+			},
+		},
+	}
+	dataExtAuth, err := proto.Marshal(&extAuthPerFilterConfig)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the schema validation ext_authz config. %v", err)
+		return perRouteFilterConfigs
+	}
+	filterConfigs[wellknown.HTTPExternalAuthorization] = &any.Any{
+		TypeUrl: extAuthzPerRouteName,
+		Value:   dataExtAuth,
+	}
+	if !schemaValidation.ValidateResponses {
+		return filterConfigs
+	}
+	// the responses are buffered for the enforcer to validate them
+	perFilterConfigExtProc := extProcessorv3.ExtProcPerRoute{
+		Override: &extProcessorv3.ExtProcPerRoute_Overrides{
+			Overrides: &extProcessorv3.ExtProcOverrides{
+				ProcessingMode: &extProcessorv3.ProcessingMode{
+					RequestHeaderMode:  extProcessorv3.ProcessingMode_SKIP,
+					ResponseHeaderMode: extProcessorv3.ProcessingMode_SEND,
+					ResponseBodyMode:   extProcessorv3.ProcessingMode_BUFFERED,
+				},
+			},
+		},
+	}
+	dataExtProc, err := proto.Marshal(&perFilterConfigExtProc)
+	if err != nil {
+		logger.LoggerOasparser.Errorf("Error while marshalling the schema validation ext_proc config. %v", err)
+		return perRouteFilterConfigs
+	}
+	filterConfigs[HTTPExternalProcessor] = &any.Any{
+		TypeUrl: extProcPerRouteName,
+		Value:   dataExtProc,
+	}
+	return filterConfigs
+}
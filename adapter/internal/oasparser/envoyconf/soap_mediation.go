@@ -0,0 +1,53 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package envoyconf
+
+import (
+	"fmt"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/wso2/apk/adapter/internal/oasparser/constants"
+	"github.com/wso2/apk/adapter/internal/oasparser/model"
+)
+
+// generateSOAPMediation returns the SOAP mediation of the given parameters of a SOAP mediation policy
+func generateSOAPMediation(policyParams interface{}) (*model.SOAPMediation, error) {
+	paramsToMediateSOAP, ok := policyParams.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("error while processing policy parameter map. Map: %v", policyParams)
+	}
+	soapMediation, ok := paramsToMediateSOAP[constants.SOAPMediation].(*model.SOAPMediation)
+	if !ok || soapMediation == nil {
+		return nil, fmt.Errorf("policy parameter map must include %s", constants.SOAPMediation)
+	}
+	return soapMediation, nil
+}
+
+// getSOAPMediationFilterConfigs returns a copy of the given per route filter configs which buffers the requests and
+// the responses to be mediated by the enforcer. Unlike the body transformations, all the responses are mediated, as
+// the SOAP faults are returned with error status codes.
+func getSOAPMediationFilterConfigs(perRouteFilterConfigs map[string]*any.Any) map[string]*any.Any {
+	return getBodyTransformationFilterConfigs(perRouteFilterConfigs, true, true)
+}
+
+// getSOAPMediationMetadata returns a copy of the given route metadata which passes the SOAP mediation of the
+// operation to the enforcer as well.
+func getSOAPMediationMetadata(metadata *corev3.Metadata, soapMediation *model.SOAPMediation) *corev3.Metadata {
+	return getExtProcMetadata(metadata, soapMediationMetadataKey, soapMediation)
+}
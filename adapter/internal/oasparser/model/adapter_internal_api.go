@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -74,7 +76,6 @@ type AdapterInternalAPI struct {
 	subscriptionValidation   bool
 	APIProperties            []dpv1alpha3.Property
 	// GraphQLSchema              string
-	// GraphQLComplexities        GraphQLComplexityYaml
 	IsSystemAPI      bool
 	RateLimitPolicy  *RateLimitPolicy
 	environment      string
@@ -82,6 +83,15 @@ type AdapterInternalAPI struct {
 	EndpointSecurity []*EndpointSecurity
 	AIProvider       InternalAIProvider
 	HTTPRouteIDs     []string
+	aiRouting        *AIRouting
+	aiGuardrails     *AIGuardrails
+	aiResponseCache  *AIResponseCache
+	// graphQLProtection holds the limits of the operations of a GraphQL API
+	graphQLProtection *GraphQLProtection
+	// grpcTranscoding holds the HTTP/JSON to gRPC transcoding of a gRPC API
+	grpcTranscoding *GRPCTranscoding
+	// grpcWeb denotes whether the methods of a gRPC API accept gRPC-Web requests
+	grpcWeb bool
 }
 
 // BackendJWTTokenInfo represents the object structure holding the information related to the JWT Generator
@@ -104,6 +114,129 @@ type InternalAIProvider struct {
 	PromptTokens       ValueDetails
 	CompletionToken    ValueDetails
 	TotalToken         ValueDetails
+	Transformation     *AITransformation
+}
+
+// AITransformation holds the format of an AI provider to which the OpenAI chat completions requests are
+// converted, and from which the responses are converted back. It is passed to the enforcer in json through the
+// route metadata.
+type AITransformation struct {
+	Format           string `json:"format"`
+	DefaultMaxTokens uint32 `json:"defaultMaxTokens"`
+	APIVersion       string `json:"apiVersion,omitempty"`
+	// BasePath of the backend, to which the path of the provider is appended
+	BasePath string `json:"basePath,omitempty"`
+	// AWS credentials with which the requests converted to the Bedrock format are signed
+	AWS *AWSSigning `json:"aws,omitempty"`
+}
+
+// AWSSigning holds the credentials with which the requests to an AWS service are signed with signature version 4,
+// and the host of the service to which the requests are sent.
+type AWSSigning struct {
+	Region          string `json:"region"`
+	Service         string `json:"service"`
+	Host            string `json:"host"`
+	AccessKeyID     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+	SessionToken    string `json:"sessionToken,omitempty"`
+}
+
+// AIRouting holds the ordered AI provider targets among which the requests of an API are routed
+type AIRouting struct {
+	Targets               []AIRoutingTarget
+	FailoverOn            []string
+	PerTryTimeoutInMillis uint32
+}
+
+// AIRoutingTarget represents an AI provider backend of the AI routing policy
+type AIRoutingTarget struct {
+	ProviderName string
+	BackendName  string
+	Models       []string
+	// Model is the model name set in the requests sent to the target in the model location of the provider
+	Model           string
+	ModelIn         string
+	ModelValue      string
+	EndpointCluster *EndpointCluster
+	// Security is the endpoint security of the backend of the target, which replaces the endpoint security of
+	// the API in the requests sent to the target
+	Security *EndpointSecurity
+}
+
+// AIGuardrails holds the content safety checks applied to the prompts and the responses of an AI API.
+// It is passed to the enforcer in json through the route metadata.
+type AIGuardrails struct {
+	DenyPatterns        []string `json:"denyPatterns,omitempty"`
+	DenyKeywords        []string `json:"denyKeywords,omitempty"`
+	PIIEntities         []string `json:"piiEntities,omitempty"`
+	PIIAction           string   `json:"piiAction,omitempty"`
+	MaxPromptLength     uint32   `json:"maxPromptLength,omitempty"`
+	ResponseSchema      string   `json:"responseSchema,omitempty"`
+	ResponseContentPath string   `json:"responseContentPath,omitempty"`
+}
+
+// AIResponseCache holds the configurations of the AI response cache of an API. It is passed to the enforcer
+// in json through the route metadata. The cached responses are scoped by the organization and the API.
+type AIResponseCache struct {
+	Organization string `json:"organization"`
+	APIUUID      string `json:"apiUUID"`
+	TTLSeconds   uint32 `json:"ttlSeconds"`
+	MaxEntrySize uint32 `json:"maxEntrySize"`
+	BypassHeader string `json:"bypassHeader"`
+}
+
+// ResponseCache holds the configurations of the response cache of an operation. It is passed to the enforcer
+// in json through the route metadata. The cached responses are scoped by the organization and the API.
+type ResponseCache struct {
+	Organization        string   `json:"organization"`
+	APIUUID             string   `json:"apiUUID"`
+	TTLSeconds          uint32   `json:"ttlSeconds"`
+	VaryByHeaders       []string `json:"varyByHeaders,omitempty"`
+	VaryByQueryParams   []string `json:"varyByQueryParams,omitempty"`
+	VaryByConsumer      bool     `json:"varyByConsumer"`
+	RespectCacheControl bool     `json:"respectCacheControl"`
+	Store               string   `json:"store"`
+	MaxEntrySize        uint32   `json:"maxEntrySize"`
+}
+
+// SchemaValidation holds the configurations of the validation of the traffic of an operation against the
+// OpenAPI definition of the API, which is done by the enforcer.
+type SchemaValidation struct {
+	ValidateResponses bool
+}
+
+// BodyTransformation holds the transformation of the payloads of the requests or the responses of an operation.
+// It is passed to the enforcer in json through the route metadata.
+type BodyTransformation struct {
+	Conversion     string            `json:"conversion,omitempty"`
+	XMLRootElement string            `json:"xmlRootElement,omitempty"`
+	AddFields      []BodyField       `json:"addFields,omitempty"`
+	RemoveFields   []string          `json:"removeFields,omitempty"`
+	RenameFields   []BodyFieldRename `json:"renameFields,omitempty"`
+	Template       string            `json:"template,omitempty"`
+	ContentType    string            `json:"contentType,omitempty"`
+}
+
+// SOAPMediation holds the SOAP operation of the backend invoked for an operation of a REST API. It is passed to the
+// enforcer in json through the route metadata.
+type SOAPMediation struct {
+	SOAPVersion      string `json:"soapVersion"`
+	SOAPAction       string `json:"soapAction,omitempty"`
+	Namespace        string `json:"namespace,omitempty"`
+	InputElement     string `json:"inputElement"`
+	EnvelopeTemplate string `json:"envelopeTemplate,omitempty"`
+}
+
+// BodyField holds a value to be set at a JSONPath of a payload
+type BodyField struct {
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+// BodyFieldRename holds a field of a payload to be renamed
+type BodyFieldRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
 }
 
 // ValueDetails defines the value details
@@ -130,18 +263,36 @@ type RateLimitPolicy struct {
 	SpanUnit string
 }
 
+// PayloadPolicy holds the payload size limits and the allowed request content types of an operation
+type PayloadPolicy struct {
+	MaxRequestBodySize  uint32
+	MaxResponseBodySize uint32
+	AllowedContentTypes []string
+}
+
 // EndpointCluster represent an upstream cluster
 type EndpointCluster struct {
 	EndpointPrefix string
 	Endpoints      []Endpoint
 	// EndpointType enum {failover, loadbalance}. if any other value provided, consider as the default value; which is loadbalance
-	EndpointType string
-	Config       *EndpointConfig
-	HealthCheck  *HealthCheck
+	EndpointType     string
+	Config           *EndpointConfig
+	HealthCheck      *HealthCheck
+	OutlierDetection *OutlierDetection
+	LoadBalancing    *LoadBalancing
 	// Is http2 protocol enabled
 	HTTP2BackendEnabled bool
 }
 
+// WeightedEndpointCluster represents the upstream cluster of a single backendRef of a route rule
+// which receives a share of the traffic proportional to its weight.
+type WeightedEndpointCluster struct {
+	// BackendName is the namespaced name of the Backend the cluster is created for
+	BackendName     string
+	EndpointCluster *EndpointCluster
+	Weight          uint32
+}
+
 // Endpoint represents the structure of an endpoint.
 type Endpoint struct {
 	// Host name
@@ -164,6 +315,13 @@ type Endpoint struct {
 	Certificate []byte
 	// Subject Alternative Names to verify in the public certificate
 	AllowedSANs []string
+	// Client certificate and private key presented to the endpoint for mutual TLS
+	ClientCertificate []byte
+	ClientPrivateKey  []byte
+	// Load balancing weight of the endpoint among the endpoints of the same priority
+	Weight uint32
+	// Priority of the endpoint, 0 being the highest
+	Priority uint32
 }
 
 // EndpointSecurity contains parameters of endpoint security at api.json
@@ -191,6 +349,31 @@ type HealthCheck struct {
 	HealthyThreshold   uint32
 }
 
+// OutlierDetection holds the parameters for passive health checking done by apk to the EndpointCluster
+type OutlierDetection struct {
+	Consecutive5xxErrors     uint32
+	ConsecutiveGatewayErrors uint32
+	Interval                 uint32
+	BaseEjectionTime         uint32
+	MaxEjectionPercent       uint32
+}
+
+// LoadBalancing holds the load balancing policy of the EndpointCluster and the request attributes
+// used for consistent hashing
+type LoadBalancing struct {
+	// Policy enum {RoundRobin, LeastRequest, Random, RingHash, Maglev}
+	Policy   string
+	HashKeys []HashKey
+}
+
+// HashKey holds a request attribute used to compute the consistent hash of a request
+type HashKey struct {
+	// Type enum {Header, Cookie, SourceIP}
+	Type      string
+	Name      string
+	CookieTTL uint32
+}
+
 // RetryConfig holds the parameters for retries done by apk to the EndpointCluster
 type RetryConfig struct {
 	Count                int32
@@ -309,6 +492,20 @@ func (adapterInternalAPI *AdapterInternalAPI) GetResources() []*Resource {
 	return adapterInternalAPI.resources
 }
 
+// GetGQLSubscriptions returns the resources of the subscription fields of a GraphQL API
+func (adapterInternalAPI *AdapterInternalAPI) GetGQLSubscriptions() []*Resource {
+	var subscriptions []*Resource
+	for _, resource := range adapterInternalAPI.resources {
+		for _, operation := range resource.GetMethod() {
+			if operation.GetMethod() == constants.GQLSubscription {
+				subscriptions = append(subscriptions, resource)
+				break
+			}
+		}
+	}
+	return subscriptions
+}
+
 // GetDescription returns the description of the openapi
 func (adapterInternalAPI *AdapterInternalAPI) GetDescription() string {
 	return adapterInternalAPI.description
@@ -473,14 +670,101 @@ func (adapterInternalAPI *AdapterInternalAPI) SetAIProvider(aiProvider dpv1alpha
 			In:    aiProvider.Spec.RateLimitFields.TotalToken.In,
 			Value: aiProvider.Spec.RateLimitFields.TotalToken.Value,
 		},
+		Transformation: parseAITransformationToInternal(aiProvider.Spec.Transformation),
 	}
 }
 
+// parseAITransformationToInternal returns the transformation of the AI provider, or nil if the provider already
+// uses the OpenAI format.
+func parseAITransformationToInternal(transformation *dpv1alpha3.AIProviderTransformation) *AITransformation {
+	if transformation == nil || transformation.Format == "OpenAI" {
+		return nil
+	}
+	aiTransformation := &AITransformation{
+		Format:           transformation.Format,
+		DefaultMaxTokens: transformation.DefaultMaxTokens,
+		APIVersion:       transformation.APIVersion,
+	}
+	if aiTransformation.DefaultMaxTokens == 0 {
+		aiTransformation.DefaultMaxTokens = 1024
+	}
+	return aiTransformation
+}
+
 // GetAIProvider returns the AIProvider of the API
 func (adapterInternalAPI *AdapterInternalAPI) GetAIProvider() InternalAIProvider {
 	return adapterInternalAPI.AIProvider
 }
 
+// GetAIRouting returns the AI routing policy of the API
+func (adapterInternalAPI *AdapterInternalAPI) GetAIRouting() *AIRouting {
+	return adapterInternalAPI.aiRouting
+}
+
+// GetGraphQLProtection returns the limits of the operations of a GraphQL API
+func (adapterInternalAPI *AdapterInternalAPI) GetGraphQLProtection() *GraphQLProtection {
+	return adapterInternalAPI.graphQLProtection
+}
+
+// GetGRPCTranscoding returns the HTTP/JSON to gRPC transcoding of a gRPC API
+func (adapterInternalAPI *AdapterInternalAPI) GetGRPCTranscoding() *GRPCTranscoding {
+	return adapterInternalAPI.grpcTranscoding
+}
+
+// IsGRPCWebEnabled returns whether the methods of a gRPC API accept gRPC-Web requests
+func (adapterInternalAPI *AdapterInternalAPI) IsGRPCWebEnabled() bool {
+	return adapterInternalAPI.grpcWeb
+}
+
+// GetAIGuardrails returns the guardrails applied to the prompts and the responses of the API
+func (adapterInternalAPI *AdapterInternalAPI) GetAIGuardrails() *AIGuardrails {
+	return adapterInternalAPI.aiGuardrails
+}
+
+// GetAIResponseCache returns the AI response cache configurations of the API
+func (adapterInternalAPI *AdapterInternalAPI) GetAIResponseCache() *AIResponseCache {
+	return adapterInternalAPI.aiResponseCache
+}
+
+// HasResponseChecks returns whether any of the guardrails inspect the responses
+func (aiGuardrails *AIGuardrails) HasResponseChecks() bool {
+	return len(aiGuardrails.DenyPatterns) > 0 || len(aiGuardrails.DenyKeywords) > 0 ||
+		len(aiGuardrails.PIIEntities) > 0 || aiGuardrails.ResponseSchema != ""
+}
+
+// GetFailoverChains returns the indexes of the targets to be tried, in order, for each model
+// served by the targets and for the requests of any other model. Targets without models serve
+// any model, hence they are appended to every chain as fallbacks.
+func (aiRouting *AIRouting) GetFailoverChains() (map[string][]int, []int) {
+	modelChains := make(map[string][]int)
+	var defaultChain []int
+	for i, target := range aiRouting.Targets {
+		if len(target.Models) == 0 {
+			defaultChain = append(defaultChain, i)
+		}
+	}
+	for _, target := range aiRouting.Targets {
+		for _, modelName := range target.Models {
+			if _, exists := modelChains[modelName]; exists {
+				continue
+			}
+			var chain []int
+			for j := range aiRouting.Targets {
+				if len(aiRouting.Targets[j].Models) == 0 || slices.Contains(aiRouting.Targets[j].Models, modelName) {
+					chain = append(chain, j)
+				}
+			}
+			modelChains[modelName] = chain
+		}
+	}
+	if len(defaultChain) == 0 {
+		for i := range aiRouting.Targets {
+			defaultChain = append(defaultChain, i)
+		}
+	}
+	return modelChains, defaultChain
+}
+
 // Validate method confirms that the adapterInternalAPI has all required fields in the required format.
 // This needs to be checked prior to generate router/enforcer related resources.
 func (adapterInternalAPI *AdapterInternalAPI) Validate() error {
@@ -507,6 +791,7 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoHTTPRouteCR(httpRoute *gwap
 	outputAuthScheme := utils.TieBreaker(utils.GetPtrSlice(maps.Values(resourceParams.AuthSchemes)))
 	outputAPIPolicy := utils.TieBreaker(utils.GetPtrSlice(maps.Values(resourceParams.APIPolicies)))
 	outputRatelimitPolicy := utils.TieBreaker(utils.GetPtrSlice(maps.Values(resourceParams.RateLimitPolicies)))
+	outputAuthorizationPolicy := utils.TieBreaker(utils.GetPtrSlice(maps.Values(resourceParams.AuthorizationPolicies)))
 
 	disableScopes := true
 	config := config.ReadConfigs()
@@ -523,28 +808,25 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoHTTPRouteCR(httpRoute *gwap
 	if outputRatelimitPolicy != nil {
 		ratelimitPolicy = *outputRatelimitPolicy
 	}
+	var authorizationPolicy *dpv1alpha3.AuthorizationPolicy
+	if outputAuthorizationPolicy != nil {
+		authorizationPolicy = *outputAuthorizationPolicy
+	}
 
 	for ruleID, rule := range httpRoute.Spec.Rules {
 		var endPoints []Endpoint
 		var policies = OperationPolicies{}
-		var circuitBreaker *dpv1alpha2.CircuitBreaker
-		var healthCheck *dpv1alpha2.HealthCheck
+		ruleEndpointCluster := &EndpointCluster{}
 		resourceAuthScheme := authScheme
 		resourceAPIPolicy := apiPolicy
 		resourceRatelimitPolicy := ratelimitPolicy
+		resourceAuthorizationPolicy := authorizationPolicy
 		var scopes []string
-		var timeoutInMillis uint32
-		var idleTimeoutInSeconds uint32
-		isRetryConfig := false
-		isRouteTimeout := false
-		var backendRetryCount uint32
-		var statusCodes []uint32
-		statusCodes = append(statusCodes, config.Envoy.Upstream.Retry.StatusCodes...)
-		var baseIntervalInMillis uint32
 		hasURLRewritePolicy := false
 		hasRequestRedirectPolicy := false
 		var securityConfig []EndpointSecurity
 		var mirrorEndpointClusters []*EndpointCluster
+		var weightedEndpoints []*WeightedEndpointCluster
 
 		enableBackendBasedAIRatelimit := false
 		descriptorValue := ""
@@ -564,39 +846,20 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoHTTPRouteCR(httpRoute *gwap
 			}
 			resolvedBackend, ok := resourceParams.BackendMapping[backendName.String()]
 			if ok {
-				if resolvedBackend.CircuitBreaker != nil {
-					circuitBreaker = &dpv1alpha2.CircuitBreaker{
-						MaxConnections:     resolvedBackend.CircuitBreaker.MaxConnections,
-						MaxPendingRequests: resolvedBackend.CircuitBreaker.MaxPendingRequests,
-						MaxRequests:        resolvedBackend.CircuitBreaker.MaxRequests,
-						MaxRetries:         resolvedBackend.CircuitBreaker.MaxRetries,
-						MaxConnectionPools: resolvedBackend.CircuitBreaker.MaxConnectionPools,
+				basePath := GetBackendBasePath(backendName, resourceParams.BackendMapping)
+				firstBackendName := ""
+				if len(weightedEndpoints) > 0 {
+					firstBackendName = weightedEndpoints[0].BackendName
+					if basePath != backendBasePath {
+						return fmt.Errorf("backend: %s should have the same base path as backend: %s to share the traffic of a rule",
+							backendName, firstBackendName)
 					}
 				}
-				if resolvedBackend.Timeout != nil {
-					isRouteTimeout = true
-					timeoutInMillis = resolvedBackend.Timeout.UpstreamResponseTimeout * 1000
-					idleTimeoutInSeconds = resolvedBackend.Timeout.DownstreamRequestIdleTimeout
-				}
-
-				if resolvedBackend.Retry != nil {
-					isRetryConfig = true
-					backendRetryCount = resolvedBackend.Retry.Count
-					baseIntervalInMillis = resolvedBackend.Retry.BaseIntervalMillis
-					if len(resolvedBackend.Retry.StatusCodes) > 0 {
-						statusCodes = resolvedBackend.Retry.StatusCodes
-					}
-				}
-				if resolvedBackend.HealthCheck != nil {
-					healthCheck = &dpv1alpha2.HealthCheck{
-						Interval:           resolvedBackend.HealthCheck.Interval,
-						Timeout:            resolvedBackend.HealthCheck.Timeout,
-						UnhealthyThreshold: resolvedBackend.HealthCheck.UnhealthyThreshold,
-						HealthyThreshold:   resolvedBackend.HealthCheck.HealthyThreshold,
-					}
-				}
-				endPoints = append(endPoints, GetEndpoints(backendName, resourceParams.BackendMapping)...)
-				backendBasePath = GetBackendBasePath(backendName, resourceParams.BackendMapping)
+				backendBasePath = basePath
+				weightedEndpoint := getWeightedEndpointCluster(backendName, backend.Weight, resourceParams.BackendMapping)
+				addRuleEndpointCluster(ruleEndpointCluster, weightedEndpoint.EndpointCluster, backendName.String(),
+					firstBackendName)
+				weightedEndpoints = append(weightedEndpoints, weightedEndpoint)
 				switch resolvedBackend.Security.Type {
 				case "Basic":
 					securityConfig = append(securityConfig, EndpointSecurity{
@@ -615,11 +878,16 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoHTTPRouteCR(httpRoute *gwap
 							"value": string(resolvedBackend.Security.APIKey.Value),
 						},
 					})
+				case "OAuth2":
+					securityConfig = append(securityConfig, getOAuth2EndpointSecurity(resolvedBackend.Security.OAuth2))
+				case "AWS":
+					securityConfig = append(securityConfig, getAWSEndpointSecurity(resolvedBackend.Security.AWS))
 				}
 			} else {
 				return fmt.Errorf("backend: %s has not been resolved", backendName)
 			}
 		}
+		endPoints = append(endPoints, ruleEndpointCluster.Endpoints...)
 		for _, filter := range rule.Filters {
 			switch filter.Type {
 			case gwapiv1.HTTPRouteFilterURLRewrite:
@@ -685,6 +953,17 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoHTTPRouteCR(httpRoute *gwap
 						 'Resource' in resource level RateLimitPolicies`, filter.ExtensionRef.Name)
 					}
 				}
+				if filter.ExtensionRef.Kind == constants.KindAuthorizationPolicy {
+					if ref, found := resourceParams.ResourceAuthorizationPolicies[types.NamespacedName{
+						Name:      string(filter.ExtensionRef.Name),
+						Namespace: httpRoute.Namespace,
+					}.String()]; found {
+						resourceAuthorizationPolicy = concatAuthorizationPolicies(authorizationPolicy, &ref)
+					} else {
+						return fmt.Errorf(`authorizationpolicy: %s has not been resolved, spec.targetRef.kind should be 
+						 'Resource' in resource level AuthorizationPolicies`, filter.ExtensionRef.Name)
+					}
+				}
 			case gwapiv1.HTTPRouteFilterRequestHeaderModifier:
 				for _, header := range filter.RequestHeaderModifier.Add {
 					policyParameters := make(map[string]interface{})
@@ -796,99 +1075,18 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoHTTPRouteCR(httpRoute *gwap
 				endPoints = append(endPoints, requestRedirectEndpoint)
 
 			case gwapiv1.HTTPRouteFilterRequestMirror:
-				var mirrorTimeoutInMillis uint32
-				var mirrorIdleTimeoutInSeconds uint32
-				var mirrorCircuitBreaker *dpv1alpha1.CircuitBreaker
-				var mirrorHealthCheck *dpv1alpha1.HealthCheck
-				isMirrorRetryConfig := false
-				isMirrorRouteTimeout := false
-				var mirrorBackendRetryCount uint32
-				var mirrorStatusCodes []uint32
-				mirrorStatusCodes = append(mirrorStatusCodes, config.Envoy.Upstream.Retry.StatusCodes...)
-				var mirrorBaseIntervalInMillis uint32
 				policyParameters := make(map[string]interface{})
 				mirrorBackend := &filter.RequestMirror.BackendRef
 				mirrorBackendName := types.NamespacedName{
 					Name:      string(mirrorBackend.Name),
 					Namespace: utils.GetNamespace(mirrorBackend.Namespace, httpRoute.Namespace),
 				}
-				resolvedMirrorBackend, ok := resourceParams.BackendMapping[mirrorBackendName.String()]
-
-				if ok {
-					if resolvedMirrorBackend.CircuitBreaker != nil {
-						mirrorCircuitBreaker = &dpv1alpha1.CircuitBreaker{
-							MaxConnections:     resolvedMirrorBackend.CircuitBreaker.MaxConnections,
-							MaxPendingRequests: resolvedMirrorBackend.CircuitBreaker.MaxPendingRequests,
-							MaxRequests:        resolvedMirrorBackend.CircuitBreaker.MaxRequests,
-							MaxRetries:         resolvedMirrorBackend.CircuitBreaker.MaxRetries,
-							MaxConnectionPools: resolvedMirrorBackend.CircuitBreaker.MaxConnectionPools,
-						}
-					}
-
-					if resolvedMirrorBackend.Timeout != nil {
-						isMirrorRouteTimeout = true
-						mirrorTimeoutInMillis = resolvedMirrorBackend.Timeout.UpstreamResponseTimeout * 1000
-						mirrorIdleTimeoutInSeconds = resolvedMirrorBackend.Timeout.DownstreamRequestIdleTimeout
-					}
-
-					if resolvedMirrorBackend.Retry != nil {
-						isMirrorRetryConfig = true
-						mirrorBackendRetryCount = resolvedMirrorBackend.Retry.Count
-						mirrorBaseIntervalInMillis = resolvedMirrorBackend.Retry.BaseIntervalMillis
-						if len(resolvedMirrorBackend.Retry.StatusCodes) > 0 {
-							mirrorStatusCodes = resolvedMirrorBackend.Retry.StatusCodes
-						}
-					}
-
-					if resolvedMirrorBackend.HealthCheck != nil {
-						mirrorHealthCheck = &dpv1alpha1.HealthCheck{
-							Interval:           resolvedMirrorBackend.HealthCheck.Interval,
-							Timeout:            resolvedMirrorBackend.HealthCheck.Timeout,
-							UnhealthyThreshold: resolvedMirrorBackend.HealthCheck.UnhealthyThreshold,
-							HealthyThreshold:   resolvedMirrorBackend.HealthCheck.HealthyThreshold,
-						}
-					}
-				} else {
+				if _, ok := resourceParams.BackendMapping[mirrorBackendName.String()]; !ok {
 					return fmt.Errorf("backend: %s has not been resolved", mirrorBackendName)
 				}
-
-				mirrorEndpoints := GetEndpoints(mirrorBackendName, resourceParams.BackendMapping)
-				if len(mirrorEndpoints) > 0 {
-					mirrorEndpointCluster := &EndpointCluster{
-						Endpoints: mirrorEndpoints,
-					}
-					mirrorEndpointConfig := &EndpointConfig{}
-					if isMirrorRouteTimeout {
-						mirrorEndpointConfig.TimeoutInMillis = mirrorTimeoutInMillis
-						mirrorEndpointConfig.IdleTimeoutInSeconds = mirrorIdleTimeoutInSeconds
-					}
-					if mirrorCircuitBreaker != nil {
-						mirrorEndpointConfig.CircuitBreakers = &CircuitBreakers{
-							MaxConnections:     int32(mirrorCircuitBreaker.MaxConnections),
-							MaxRequests:        int32(mirrorCircuitBreaker.MaxRequests),
-							MaxPendingRequests: int32(mirrorCircuitBreaker.MaxPendingRequests),
-							MaxRetries:         int32(mirrorCircuitBreaker.MaxRetries),
-							MaxConnectionPools: int32(mirrorCircuitBreaker.MaxConnectionPools),
-						}
-					}
-					if isMirrorRetryConfig {
-						mirrorEndpointConfig.RetryConfig = &RetryConfig{
-							Count:                int32(mirrorBackendRetryCount),
-							StatusCodes:          mirrorStatusCodes,
-							BaseIntervalInMillis: int32(mirrorBaseIntervalInMillis),
-						}
-					}
-					if mirrorHealthCheck != nil {
-						mirrorEndpointCluster.HealthCheck = &HealthCheck{
-							Interval:           mirrorHealthCheck.Interval,
-							Timeout:            mirrorHealthCheck.Timeout,
-							UnhealthyThreshold: mirrorHealthCheck.UnhealthyThreshold,
-							HealthyThreshold:   mirrorHealthCheck.HealthyThreshold,
-						}
-					}
-					if isMirrorRouteTimeout || mirrorCircuitBreaker != nil || mirrorHealthCheck != nil || isMirrorRetryConfig {
-						mirrorEndpointCluster.Config = mirrorEndpointConfig
-					}
+				mirrorEndpointCluster := getEndpointCluster(mirrorBackendName, resourceParams.BackendMapping,
+					config.Envoy.Upstream.Retry.StatusCodes)
+				if len(mirrorEndpointCluster.Endpoints) > 0 {
 					mirrorEndpointClusters = append(mirrorEndpointClusters, mirrorEndpointCluster)
 				}
 				policies.Request = append(policies.Request, Policy{
@@ -901,15 +1099,30 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoHTTPRouteCR(httpRoute *gwap
 		resourceAPIPolicy = concatAPIPolicies(resourceAPIPolicy, nil)
 		resourceAuthScheme = concatAuthSchemes(resourceAuthScheme, nil)
 		resourceRatelimitPolicy = concatRateLimitPolicies(resourceRatelimitPolicy, nil)
+		resourceAuthorizationPolicy = concatAuthorizationPolicies(resourceAuthorizationPolicy, nil)
 		addOperationLevelInterceptors(&policies, resourceAPIPolicy, resourceParams.InterceptorServiceMapping, resourceParams.BackendMapping, httpRoute.Namespace)
+		addOperationLevelBodyTransformations(&policies, resourceAPIPolicy)
 
 		loggers.LoggerOasparser.Debugf("Calculating auths for API ..., API_UUID = %v", adapterInternalAPI.UUID)
-		apiAuth := getSecurity(resourceAuthScheme)
+		apiAuth := getSecurity(resourceAuthScheme, resourceParams.HMACKeys)
 
 		if !hasRequestRedirectPolicy && len(rule.BackendRefs) < 1 {
 			return fmt.Errorf("no backendref were provided")
 		}
 
+		// Traffic is split among the backends by weight only when the rule has more than one backendRef.
+		if len(weightedEndpoints) > 1 {
+			var totalWeight uint32
+			for _, weightedEndpoint := range weightedEndpoints {
+				totalWeight += weightedEndpoint.Weight
+			}
+			if totalWeight == 0 {
+				return fmt.Errorf("at least one backendRef of the rule should have a non zero weight")
+			}
+		} else {
+			weightedEndpoints = nil
+		}
+
 		for matchID, match := range rule.Matches {
 			if hasURLRewritePolicy && hasRequestRedirectPolicy {
 				return fmt.Errorf("cannot have URL Rewrite and Request Redirect under the same rule")
@@ -931,8 +1144,21 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoHTTPRouteCR(httpRoute *gwap
 			}
 			resourcePath := adapterInternalAPI.xWso2Basepath + *match.Path.Value
 			matchID := getMatchID(httpRoute.Namespace, httpRoute.Name, ruleID, matchID)
-			operations := getAllowedOperations(matchID, match.Method, policies, apiAuth,
-				parseRateLimitPolicyToInternal(resourceRatelimitPolicy), scopes, mirrorEndpointClusters)
+			matchPolicies, err := addOperationLevelSOAPMediation(policies, resourceAPIPolicy, *match.Path.Value,
+				httpRoute.Namespace, resourceParams.WSDLs)
+			if err != nil {
+				return err
+			}
+			schemaValidation, err := parseSchemaValidationToInternal(resourceAPIPolicy, adapterInternalAPI.apiDefinitionFile)
+			if err != nil {
+				return err
+			}
+			operations := getAllowedOperations(matchID, match.Method, matchPolicies, apiAuth,
+				parseRateLimitPolicyToInternal(resourceRatelimitPolicy), parseAuthorizationPolicyToInternal(resourceAuthorizationPolicy),
+				parsePayloadPolicyToInternal(resourceAPIPolicy),
+				parseCachingPolicyToInternal(resourceAPIPolicy, adapterInternalAPI.OrganizationID, adapterInternalAPI.UUID,
+					apiAuth),
+				schemaValidation, scopes, mirrorEndpointClusters)
 
 			resource := &Resource{
 				path:                                   resourcePath,
@@ -947,47 +1173,25 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoHTTPRouteCR(httpRoute *gwap
 			}
 
 			resource.endpoints = &EndpointCluster{
-				Endpoints: endPoints,
-			}
-
-			endpointConfig := &EndpointConfig{}
-
-			if isRouteTimeout {
-				endpointConfig.TimeoutInMillis = timeoutInMillis
-				endpointConfig.IdleTimeoutInSeconds = idleTimeoutInSeconds
-			}
-			if circuitBreaker != nil {
-				endpointConfig.CircuitBreakers = &CircuitBreakers{
-					MaxConnections:     int32(circuitBreaker.MaxConnections),
-					MaxRequests:        int32(circuitBreaker.MaxRequests),
-					MaxPendingRequests: int32(circuitBreaker.MaxPendingRequests),
-					MaxRetries:         int32(circuitBreaker.MaxRetries),
-					MaxConnectionPools: int32(circuitBreaker.MaxConnectionPools),
-				}
-			}
-			if isRetryConfig {
-				endpointConfig.RetryConfig = &RetryConfig{
-					Count:                int32(backendRetryCount),
-					StatusCodes:          statusCodes,
-					BaseIntervalInMillis: int32(baseIntervalInMillis),
-				}
-			}
-			if healthCheck != nil {
-				resource.endpoints.HealthCheck = &HealthCheck{
-					Interval:           healthCheck.Interval,
-					Timeout:            healthCheck.Timeout,
-					UnhealthyThreshold: healthCheck.UnhealthyThreshold,
-					HealthyThreshold:   healthCheck.HealthyThreshold,
-				}
-			}
-			if isRouteTimeout || circuitBreaker != nil || healthCheck != nil || isRetryConfig {
-				resource.endpoints.Config = endpointConfig
+				Endpoints:        endPoints,
+				Config:           ruleEndpointCluster.Config,
+				HealthCheck:      ruleEndpointCluster.HealthCheck,
+				OutlierDetection: ruleEndpointCluster.OutlierDetection,
+				LoadBalancing:    ruleEndpointCluster.LoadBalancing,
 			}
+			resource.weightedEndpoints = weightedEndpoints
+			resource.headerMatches = match.Headers
 			resource.endpointSecurity = utils.GetPtrSlice(securityConfig)
 			resources = append(resources, resource)
 		}
 	}
 
+	// Resources which match on request headers are placed first, so that a header based canary rule is
+	// evaluated before a rule with the same path and no header match.
+	sort.SliceStable(resources, func(i, j int) bool {
+		return len(resources[i].headerMatches) > 0 && len(resources[j].headerMatches) == 0
+	})
+
 	ratelimitPolicy = concatRateLimitPolicies(ratelimitPolicy, nil)
 	apiPolicy = concatAPIPolicies(apiPolicy, nil)
 	authScheme = concatAuthSchemes(authScheme, nil)
@@ -1010,6 +1214,9 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoHTTPRouteCR(httpRoute *gwap
 		if authSpec.AuthTypes.APIKey != nil {
 			adapterInternalAPI.SetApplicationSecurity(constants.APIKey, authSpec.AuthTypes.APIKey.Required == "mandatory")
 		}
+		if authSpec.AuthTypes.HMAC != nil {
+			adapterInternalAPI.SetApplicationSecurity(constants.HMAC, authSpec.AuthTypes.HMAC.Required == "mandatory")
+		}
 	} else {
 		adapterInternalAPI.SetApplicationSecurity(constants.OAuth2, true)
 	}
@@ -1025,6 +1232,21 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoHTTPRouteCR(httpRoute *gwap
 		adapterInternalAPI.backendJWTTokenInfo = parseBackendJWTTokenToInternal(backendJWTPolicy)
 	}
 
+	aiRouting, err := parseAIRoutingToInternal(apiPolicy, httpRoute.Namespace, resourceParams.BackendMapping,
+		resourceParams.AIProviders)
+	if err != nil {
+		return err
+	}
+	adapterInternalAPI.aiRouting = aiRouting
+
+	aiGuardrails, err := parseAIGuardrailsToInternal(apiPolicy)
+	if err != nil {
+		return err
+	}
+	adapterInternalAPI.aiGuardrails = aiGuardrails
+	adapterInternalAPI.aiResponseCache = parseAIResponseCacheToInternal(apiPolicy, adapterInternalAPI.OrganizationID,
+		adapterInternalAPI.UUID)
+
 	return nil
 }
 
@@ -1086,8 +1308,10 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoGQLRouteCR(gqlRoute *dpv1al
 			}
 		}
 		adapterInternalAPI.Endpoints = &EndpointCluster{
-			Endpoints: GetEndpoints(backendName, resourceParams.BackendMapping),
-			Config:    endpointConfig,
+			Endpoints:        GetEndpoints(backendName, resourceParams.BackendMapping),
+			Config:           endpointConfig,
+			OutlierDetection: getOutlierDetection(resolvedBackend.OutlierDetection),
+			LoadBalancing:    getLoadBalancing(resolvedBackend.LoadBalancing),
 		}
 		if resolvedBackend.HealthCheck != nil {
 			adapterInternalAPI.Endpoints.HealthCheck = &HealthCheck{
@@ -1107,6 +1331,8 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoGQLRouteCR(gqlRoute *dpv1al
 				Type:     string(resolvedBackend.Security.Type),
 				Enabled:  true,
 			})
+		case "OAuth2":
+			securityConfig = append(securityConfig, getOAuth2EndpointSecurity(resolvedBackend.Security.OAuth2))
 		}
 		adapterInternalAPI.EndpointSecurity = utils.GetPtrSlice(securityConfig)
 	} else {
@@ -1157,7 +1383,7 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoGQLRouteCR(gqlRoute *dpv1al
 		resourceAuthScheme = concatAuthSchemes(resourceAuthScheme, nil)
 		resourceRatelimitPolicy = concatRateLimitPolicies(resourceRatelimitPolicy, nil)
 
-		apiAuth := getSecurity(resourceAuthScheme)
+		apiAuth := getSecurity(resourceAuthScheme, resourceParams.HMACKeys)
 
 		for _, match := range rule.Matches {
 			resourcePath := *match.Path
@@ -1181,6 +1407,11 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoGQLRouteCR(gqlRoute *dpv1al
 		adapterInternalAPI.disableAuthentications = *authScheme.Spec.Override.Disabled
 	}
 	adapterInternalAPI.disableScopes = disableScopes
+	graphQLProtection, err := parseGraphQLProtectionToInternal(apiPolicy, adapterInternalAPI.apiDefinitionFile)
+	if err != nil {
+		return err
+	}
+	adapterInternalAPI.graphQLProtection = graphQLProtection
 	return nil
 }
 
@@ -1193,7 +1424,6 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoGRPCRouteCR(grpcRoute *gwap
 	outputRatelimitPolicy := utils.TieBreaker(utils.GetPtrSlice(maps.Values(resourceParams.RateLimitPolicies)))
 
 	disableScopes := true
-	config := config.ReadConfigs()
 
 	var authScheme *dpv1alpha2.Authentication
 	if outputAuthScheme != nil {
@@ -1208,70 +1438,19 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoGRPCRouteCR(grpcRoute *gwap
 		ratelimitPolicy = *outputRatelimitPolicy
 	}
 
-	//We are only supporting one backend for now
-	backend := grpcRoute.Spec.Rules[0].BackendRefs[0]
-	backendName := types.NamespacedName{
-		Name:      string(backend.Name),
-		Namespace: utils.GetNamespace(backend.Namespace, grpcRoute.Namespace),
-	}
-	resolvedBackend, ok := resourceParams.BackendMapping[backendName.String()]
-	if ok {
-		endpointConfig := &EndpointConfig{}
-		if resolvedBackend.CircuitBreaker != nil {
-			endpointConfig.CircuitBreakers = &CircuitBreakers{
-				MaxConnections:     int32(resolvedBackend.CircuitBreaker.MaxConnections),
-				MaxRequests:        int32(resolvedBackend.CircuitBreaker.MaxRequests),
-				MaxPendingRequests: int32(resolvedBackend.CircuitBreaker.MaxPendingRequests),
-				MaxRetries:         int32(resolvedBackend.CircuitBreaker.MaxRetries),
-				MaxConnectionPools: int32(resolvedBackend.CircuitBreaker.MaxConnectionPools),
-			}
-		}
-		if resolvedBackend.Timeout != nil {
-			endpointConfig.TimeoutInMillis = resolvedBackend.Timeout.UpstreamResponseTimeout * 1000
-			endpointConfig.IdleTimeoutInSeconds = resolvedBackend.Timeout.DownstreamRequestIdleTimeout
-		}
-		if resolvedBackend.Retry != nil {
-			statusCodes := config.Envoy.Upstream.Retry.StatusCodes
-			if len(resolvedBackend.Retry.StatusCodes) > 0 {
-				statusCodes = resolvedBackend.Retry.StatusCodes
-			}
-			endpointConfig.RetryConfig = &RetryConfig{
-				Count:                int32(resolvedBackend.Retry.Count),
-				StatusCodes:          statusCodes,
-				BaseIntervalInMillis: int32(resolvedBackend.Retry.BaseIntervalMillis),
-			}
-		}
-		adapterInternalAPI.Endpoints = &EndpointCluster{
-			Endpoints: GetEndpoints(backendName, resourceParams.BackendMapping),
-			Config:    endpointConfig,
-		}
-		if resolvedBackend.HealthCheck != nil {
-			adapterInternalAPI.Endpoints.HealthCheck = &HealthCheck{
-				Interval:           resolvedBackend.HealthCheck.Interval,
-				Timeout:            resolvedBackend.HealthCheck.Timeout,
-				UnhealthyThreshold: resolvedBackend.HealthCheck.UnhealthyThreshold,
-				HealthyThreshold:   resolvedBackend.HealthCheck.HealthyThreshold,
-			}
+	for ruleID, rule := range grpcRoute.Spec.Rules {
+		var policies = OperationPolicies{}
+		// Each rule is routed to its own backends, so that the methods of a service can be served by different
+		// deployments with their own timeouts, retries and circuit breakers.
+		ruleEndpoints, err := getGRPCRuleEndpoints(rule.BackendRefs, grpcRoute.Namespace, resourceParams.BackendMapping)
+		if err != nil {
+			return err
 		}
-
-		var securityConfig []EndpointSecurity
-		switch resolvedBackend.Security.Type {
-		case "Basic":
-			securityConfig = append(securityConfig, EndpointSecurity{
-				Password: string(resolvedBackend.Security.Basic.Password),
-				Username: string(resolvedBackend.Security.Basic.Username),
-				Type:     string(resolvedBackend.Security.Type),
-				Enabled:  true,
-			})
+		// The backends of the first rule are used as the backends of the API
+		if ruleID == 0 {
+			adapterInternalAPI.Endpoints = ruleEndpoints.endpoints
+			adapterInternalAPI.EndpointSecurity = utils.GetPtrSlice(ruleEndpoints.security)
 		}
-		adapterInternalAPI.EndpointSecurity = utils.GetPtrSlice(securityConfig)
-	} else {
-		return fmt.Errorf("backend: %s has not been resolved", backendName)
-	}
-
-	for _, rule := range grpcRoute.Spec.Rules {
-		var policies = OperationPolicies{}
-		var endPoints []Endpoint
 		resourceAuthScheme := authScheme
 		resourceAPIPolicy := apiPolicy
 		resourceRatelimitPolicy := ratelimitPolicy
@@ -1332,20 +1511,18 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoGRPCRouteCR(grpcRoute *gwap
 		addOperationLevelInterceptors(&policies, resourceAPIPolicy, resourceParams.InterceptorServiceMapping, resourceParams.BackendMapping, grpcRoute.Namespace)
 
 		loggers.LoggerOasparser.Debugf("Calculating auths for API ..., API_UUID = %v", adapterInternalAPI.UUID)
-		apiAuth := getSecurity(resourceAuthScheme)
+		apiAuth := getSecurity(resourceAuthScheme, resourceParams.HMACKeys)
 
 		for _, match := range rule.Matches {
 			resourcePath := adapterInternalAPI.GetXWso2Basepath() + "." + *match.Method.Service + "/" + *match.Method.Method
-			endPoints = append(endPoints, GetEndpoints(backendName, resourceParams.BackendMapping)...)
 			resource := &Resource{path: resourcePath, pathMatchType: "Exact",
 				methods: []*Operation{{iD: uuid.New().String(), method: "POST", policies: policies,
 					auth: apiAuth, rateLimitPolicy: parseRateLimitPolicyToInternal(resourceRatelimitPolicy), scopes: scopes}},
 				iD: uuid.New().String(),
 			}
-			endpoints := GetEndpoints(backendName, resourceParams.BackendMapping)
-			resource.endpoints = &EndpointCluster{
-				Endpoints: endpoints,
-			}
+			resource.endpoints = ruleEndpoints.endpoints
+			resource.weightedEndpoints = ruleEndpoints.weightedEndpoints
+			resource.endpointSecurity = utils.GetPtrSlice(ruleEndpoints.security)
 			resources = append(resources, resource)
 		}
 	}
@@ -1371,6 +1548,9 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoGRPCRouteCR(grpcRoute *gwap
 		if authSpec.AuthTypes.APIKey != nil {
 			adapterInternalAPI.SetApplicationSecurity(constants.APIKey, authSpec.AuthTypes.APIKey.Required == "mandatory")
 		}
+		if authSpec.AuthTypes.HMAC != nil {
+			adapterInternalAPI.SetApplicationSecurity(constants.HMAC, authSpec.AuthTypes.HMAC.Required == "mandatory")
+		}
 	} else {
 		adapterInternalAPI.SetApplicationSecurity(constants.OAuth2, true)
 	}
@@ -1383,6 +1563,16 @@ func (adapterInternalAPI *AdapterInternalAPI) SetInfoGRPCRouteCR(grpcRoute *gwap
 		}.String()].Spec
 		adapterInternalAPI.backendJWTTokenInfo = parseBackendJWTTokenToInternal(backendJWTPolicy)
 	}
+	grpcTranscoding, err := parseGRPCTranscodingToInternal(apiPolicy, grpcRoute.Namespace,
+		adapterInternalAPI.GetXWso2Basepath(), resourceParams.ProtoDescriptorSets, resources)
+	if err != nil {
+		return err
+	}
+	adapterInternalAPI.grpcTranscoding = grpcTranscoding
+	if isGRPCWebEnabled(apiPolicy) {
+		adapterInternalAPI.grpcWeb = true
+		adapterInternalAPI.xWso2Cors = addGRPCWebCorsHeaders(adapterInternalAPI.xWso2Cors)
+	}
 	return nil
 }
 
@@ -43,6 +43,10 @@ type Operation struct {
 	policies               OperationPolicies
 	mockedAPIConfig        *api.MockedApiConfig
 	rateLimitPolicy        *RateLimitPolicy
+	authorizationRules     []AuthorizationRule
+	payloadPolicy          *PayloadPolicy
+	responseCache          *ResponseCache
+	schemaValidation       *SchemaValidation
 	mirrorEndpointClusters []*EndpointCluster
 	matchID                string
 }
@@ -53,6 +57,7 @@ type Authentication struct {
 	JWT      *JWT
 	APIKey   []APIKey
 	Oauth2   *Oauth2
+	HMAC     *HMAC
 }
 
 // JWT holds JWT related configurations
@@ -75,6 +80,24 @@ type APIKey struct {
 	SendTokenToUpstream bool
 }
 
+// HMAC holds HMAC request signature related configurations
+type HMAC struct {
+	Header              string
+	Keys                map[string]string
+	Algorithms          []string
+	SignedHeaders       []string
+	ClockSkew           uint32
+	SendTokenToUpstream bool
+}
+
+// AuthorizationRule holds an authorization expression evaluated by the enforcer
+type AuthorizationRule struct {
+	Name       string
+	Language   string
+	Expression string
+	Message    string
+}
+
 // SetAuthentication set authentication configurations
 func (operation *Operation) SetAuthentication(authentication *Authentication) {
 	operation.auth = authentication
@@ -100,6 +123,26 @@ func (operation *Operation) GetRateLimitPolicy() *RateLimitPolicy {
 	return operation.rateLimitPolicy
 }
 
+// GetAuthorizationRules returns the operation level authorization rules
+func (operation *Operation) GetAuthorizationRules() []AuthorizationRule {
+	return operation.authorizationRules
+}
+
+// GetPayloadPolicy returns the operation level payload size and content type limits
+func (operation *Operation) GetPayloadPolicy() *PayloadPolicy {
+	return operation.payloadPolicy
+}
+
+// GetResponseCache returns the operation level response cache configurations
+func (operation *Operation) GetResponseCache() *ResponseCache {
+	return operation.responseCache
+}
+
+// GetSchemaValidation returns the operation level schema validation configurations
+func (operation *Operation) GetSchemaValidation() *SchemaValidation {
+	return operation.schemaValidation
+}
+
 // GetScopes returns the security schemas defined for the http opeartion
 func (operation *Operation) GetScopes() []string {
 	return operation.scopes
@@ -185,7 +228,7 @@ func NewOperation(method string, security []string, extensions map[string]interf
 	tier := ResolveThrottlingTier(extensions)
 	disableSecurity := ResolveDisableSecurity(extensions)
 	id := uuid.New().String()
-	return &Operation{id, method, security, nil, tier, disableSecurity, extensions, OperationPolicies{}, &api.MockedApiConfig{}, nil, nil, matchID}
+	return &Operation{id, method, security, nil, tier, disableSecurity, extensions, OperationPolicies{}, &api.MockedApiConfig{}, nil, nil, nil, nil, nil, nil, matchID}
 }
 
 // NewOperationWithPolicies Creates and returns operation with given method and policies
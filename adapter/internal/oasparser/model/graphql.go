@@ -0,0 +1,136 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+)
+
+// gqlCostDirective is the schema directive setting the cost of a field, as in @cost(weight: 10)
+const gqlCostDirective = "cost"
+
+// GraphQLProtection holds the limits of the operations of a GraphQL API, which are enforced by the enforcer. The
+// limits which are nil are not enforced.
+type GraphQLProtection struct {
+	MaxDepth             *uint32
+	MaxComplexity        *uint32
+	MaxAliases           *uint32
+	MaxBatchSize         *uint32
+	DisableIntrospection bool
+	// FieldCosts holds the costs of the fields of the schema which do not cost 1. It is passed to the enforcer
+	// through the graphql complexity info of the API.
+	FieldCosts []GraphQLFieldCost
+}
+
+// GraphQLFieldCost holds the cost of a field of the schema of a GraphQL API
+type GraphQLFieldCost struct {
+	Type  string
+	Field string
+	Cost  uint32
+}
+
+// parseGraphQLProtectionToInternal returns the GraphQL protection of the given API policy. make sure the policy only
+// has the override section. The costs of the fields are taken from the @cost directives of the schema, and are
+// overridden by the field costs of the policy.
+func parseGraphQLProtectionToInternal(apiPolicy *dpv1alpha3.APIPolicy, definition []byte) (*GraphQLProtection, error) {
+	if apiPolicy == nil || apiPolicy.Spec.Override == nil || apiPolicy.Spec.Override.GraphQLProtection == nil {
+		return nil, nil
+	}
+	protectionPolicy := apiPolicy.Spec.Override.GraphQLProtection
+	graphQLProtection := &GraphQLProtection{
+		MaxDepth:             protectionPolicy.MaxDepth,
+		MaxComplexity:        protectionPolicy.MaxComplexity,
+		MaxAliases:           protectionPolicy.MaxAliases,
+		MaxBatchSize:         protectionPolicy.MaxBatchSize,
+		DisableIntrospection: protectionPolicy.DisableIntrospection,
+	}
+	if protectionPolicy.MaxComplexity == nil {
+		return graphQLProtection, nil
+	}
+	fieldCosts, err := parseGraphQLFieldCosts(definition)
+	if err != nil {
+		return nil, err
+	}
+	fieldCostsByName := make(map[string]*GraphQLFieldCost, len(fieldCosts))
+	for _, fieldCost := range fieldCosts {
+		fieldCostsByName[fieldCost.Type+"."+fieldCost.Field] = fieldCost
+	}
+	for _, policyFieldCost := range protectionPolicy.FieldCosts {
+		fieldCost, found := fieldCostsByName[policyFieldCost.Type+"."+policyFieldCost.Field]
+		if !found {
+			return nil, fmt.Errorf("field %s of type %s is not found in the graphql schema", policyFieldCost.Field,
+				policyFieldCost.Type)
+		}
+		fieldCost.Cost = policyFieldCost.Cost
+	}
+	for _, fieldCost := range fieldCosts {
+		if fieldCost.Cost != 1 {
+			graphQLProtection.FieldCosts = append(graphQLProtection.FieldCosts, *fieldCost)
+		}
+	}
+	return graphQLProtection, nil
+}
+
+// parseGraphQLFieldCosts returns the costs of the fields of the object and interface types of the given schema, in
+// the order they are declared. A field costs 1 unless it has a @cost directive.
+func parseGraphQLFieldCosts(definition []byte) ([]*GraphQLFieldCost, error) {
+	schemaDocument, err := parseGraphQLSchema(definition)
+	if err != nil {
+		return nil, err
+	}
+	var fieldCosts []*GraphQLFieldCost
+	for _, typeDefinition := range append(schemaDocument.Definitions, schemaDocument.Extensions...) {
+		if typeDefinition.Kind != ast.Object && typeDefinition.Kind != ast.Interface {
+			continue
+		}
+		for _, fieldDefinition := range typeDefinition.Fields {
+			fieldCost := &GraphQLFieldCost{Type: typeDefinition.Name, Field: fieldDefinition.Name, Cost: 1}
+			if directive := fieldDefinition.Directives.ForName(gqlCostDirective); directive != nil {
+				if weight := directive.Arguments.ForName("weight"); weight != nil && weight.Value != nil {
+					cost, err := strconv.ParseUint(weight.Value.Raw, 10, 32)
+					if err != nil {
+						return nil, fmt.Errorf("invalid cost of field %s of type %s in the graphql schema: %v",
+							fieldDefinition.Name, typeDefinition.Name, err)
+					}
+					fieldCost.Cost = uint32(cost)
+				}
+			}
+			fieldCosts = append(fieldCosts, fieldCost)
+		}
+	}
+	return fieldCosts, nil
+}
+
+// parseGraphQLSchema parses the schema of a GraphQL API, which is either given as text or as gzipped data. The
+// schema is not validated, hence the directives such as @cost need not be declared in it.
+func parseGraphQLSchema(definition []byte) (*ast.SchemaDocument, error) {
+	definition, err := decompressAPIDefinition(definition)
+	if err != nil {
+		return nil, fmt.Errorf("invalid graphql schema: %v", err)
+	}
+	schemaDocument, err := parser.ParseSchema(&ast.Source{Input: string(definition)})
+	if err != nil {
+		return nil, fmt.Errorf("invalid graphql schema: %v", err)
+	}
+	return schemaDocument, nil
+}
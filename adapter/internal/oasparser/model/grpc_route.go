@@ -0,0 +1,97 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/wso2/apk/adapter/internal/operator/utils"
+	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
+	"k8s.io/apimachinery/pkg/types"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// grpcRuleEndpoints holds the upstream configurations resolved from the backendRefs of a GRPCRoute rule.
+type grpcRuleEndpoints struct {
+	endpoints         *EndpointCluster
+	weightedEndpoints []*WeightedEndpointCluster
+	security          []EndpointSecurity
+}
+
+// getGRPCRuleEndpoints resolves the backendRefs of a GRPCRoute rule. The timeouts and retries of the backends apply
+// to the methods of the rule, and the traffic of the rule is split among the backends by weight when the rule has
+// more than one backendRef.
+func getGRPCRuleEndpoints(backendRefs []gwapiv1.GRPCBackendRef, namespace string,
+	backendMapping map[string]*dpv1alpha2.ResolvedBackend) (*grpcRuleEndpoints, error) {
+	if len(backendRefs) < 1 {
+		return nil, fmt.Errorf("no backendref were provided")
+	}
+	ruleEndpoints := &grpcRuleEndpoints{
+		endpoints: &EndpointCluster{},
+	}
+	backendBasePath := ""
+	for _, backend := range backendRefs {
+		backendName := types.NamespacedName{
+			Name:      string(backend.Name),
+			Namespace: utils.GetNamespace(backend.Namespace, namespace),
+		}
+		resolvedBackend, ok := backendMapping[backendName.String()]
+		if !ok {
+			return nil, fmt.Errorf("backend: %s has not been resolved", backendName)
+		}
+		basePath := GetBackendBasePath(backendName, backendMapping)
+		firstBackendName := ""
+		if len(ruleEndpoints.weightedEndpoints) > 0 {
+			firstBackendName = ruleEndpoints.weightedEndpoints[0].BackendName
+			if basePath != backendBasePath {
+				return nil, fmt.Errorf("backend: %s should have the same base path as backend: %s to share the traffic of a rule",
+					backendName, firstBackendName)
+			}
+		}
+		backendBasePath = basePath
+		weightedEndpoint := getWeightedEndpointCluster(backendName, backend.Weight, backendMapping)
+		addRuleEndpointCluster(ruleEndpoints.endpoints, weightedEndpoint.EndpointCluster, backendName.String(),
+			firstBackendName)
+		ruleEndpoints.weightedEndpoints = append(ruleEndpoints.weightedEndpoints, weightedEndpoint)
+
+		switch resolvedBackend.Security.Type {
+		case "Basic":
+			ruleEndpoints.security = append(ruleEndpoints.security, EndpointSecurity{
+				Password: string(resolvedBackend.Security.Basic.Password),
+				Username: string(resolvedBackend.Security.Basic.Username),
+				Type:     string(resolvedBackend.Security.Type),
+				Enabled:  true,
+			})
+		case "OAuth2":
+			ruleEndpoints.security = append(ruleEndpoints.security, getOAuth2EndpointSecurity(resolvedBackend.Security.OAuth2))
+		}
+	}
+	// Traffic is split among the backends by weight only when the rule has more than one backendRef.
+	if len(ruleEndpoints.weightedEndpoints) > 1 {
+		var totalWeight uint32
+		for _, weightedEndpoint := range ruleEndpoints.weightedEndpoints {
+			totalWeight += weightedEndpoint.Weight
+		}
+		if totalWeight == 0 {
+			return nil, fmt.Errorf("at least one backendRef of the rule should have a non zero weight")
+		}
+	} else {
+		ruleEndpoints.weightedEndpoints = nil
+	}
+	return ruleEndpoints, nil
+}
@@ -0,0 +1,177 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GRPCTranscoding holds the HTTP/JSON to gRPC transcoding of a gRPC API
+type GRPCTranscoding struct {
+	// DescriptorSet is the serialized protobuf descriptor set of the services of the API
+	DescriptorSet []byte
+	// Services holds the fully qualified names of the services which have methods exposed over HTTP/JSON
+	Services                     []string
+	Bindings                     []GRPCHTTPBinding
+	PreserveProtoFieldNames      bool
+	IgnoreUnknownQueryParameters bool
+}
+
+// GRPCHTTPBinding maps an HTTP method and a path template of the google.api.http annotation of a gRPC method to the
+// path of the gRPC method, as in /package.Service/Method
+type GRPCHTTPBinding struct {
+	HTTPMethod   string
+	PathTemplate string
+	GRPCPath     string
+}
+
+// parseGRPCTranscodingToInternal returns the gRPC transcoding of the given API policy. make sure the policy only has
+// the override section. The bindings are taken from the google.api.http annotations of the methods of the given
+// resources, and the methods without an annotation are only exposed over gRPC. The paths of the bindings are scoped
+// to the given base path of the API, hence the annotations of the descriptor set are prefixed with it as well, as
+// the transcoder matches the paths of the requests against the annotations.
+func parseGRPCTranscodingToInternal(apiPolicy *dpv1alpha3.APIPolicy, namespace string, basePath string,
+	descriptorSets map[string][]byte, resources []*Resource) (*GRPCTranscoding, error) {
+	if apiPolicy == nil || apiPolicy.Spec.Override == nil || apiPolicy.Spec.Override.GRPCTranscoding == nil {
+		return nil, nil
+	}
+	transcodingPolicy := apiPolicy.Spec.Override.GRPCTranscoding
+	descriptorSetName := types.NamespacedName{Namespace: namespace, Name: transcodingPolicy.DescriptorSetRef}.String()
+	descriptorSet, found := descriptorSets[descriptorSetName]
+	if !found {
+		return nil, fmt.Errorf("descriptor set: %s has not been resolved", descriptorSetName)
+	}
+	if !strings.HasPrefix(basePath, "/") {
+		return nil, fmt.Errorf("base path %s of the api should start with / to expose its methods over HTTP/JSON",
+			basePath)
+	}
+	basePath = strings.TrimSuffix(basePath, "/")
+	fileDescriptorSet, methods, err := parseGRPCMethodDescriptors(descriptorSet)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcTranscoding := &GRPCTranscoding{
+		PreserveProtoFieldNames:      transcodingPolicy.PreserveProtoFieldNames,
+		IgnoreUnknownQueryParameters: transcodingPolicy.IgnoreUnknownQueryParameters,
+	}
+	services := make(map[string]bool)
+	for _, resource := range resources {
+		// The path of a resource of a gRPC API is the path of its method, as in /package.Service/Method
+		grpcPath := resource.GetPath()
+		method, found := methods[grpcPath]
+		if !found {
+			return nil, fmt.Errorf("method %s is not found in the descriptor set: %s", grpcPath, descriptorSetName)
+		}
+		if !proto.HasExtension(method.GetOptions(), annotations.E_Http) {
+			continue
+		}
+		httpRule, ok := proto.GetExtension(method.GetOptions(), annotations.E_Http).(*annotations.HttpRule)
+		if !ok || httpRule == nil {
+			continue
+		}
+		bindings, err := getGRPCHTTPBindings(httpRule, grpcPath, basePath)
+		if err != nil {
+			return nil, err
+		}
+		proto.SetExtension(method.GetOptions(), annotations.E_Http, httpRule)
+		grpcTranscoding.Bindings = append(grpcTranscoding.Bindings, bindings...)
+		service := strings.TrimPrefix(grpcPath[:strings.LastIndex(grpcPath, "/")], "/")
+		if !services[service] {
+			services[service] = true
+			grpcTranscoding.Services = append(grpcTranscoding.Services, service)
+		}
+	}
+	if len(grpcTranscoding.Bindings) == 0 {
+		return nil, fmt.Errorf("none of the methods of the api has a google.api.http annotation in the descriptor set: %s",
+			descriptorSetName)
+	}
+	marshalOptions := proto.MarshalOptions{Deterministic: true}
+	if grpcTranscoding.DescriptorSet, err = marshalOptions.Marshal(fileDescriptorSet); err != nil {
+		return nil, fmt.Errorf("error while prefixing the google.api.http annotations of the descriptor set: %s. %v",
+			descriptorSetName, err)
+	}
+	return grpcTranscoding, nil
+}
+
+// parseGRPCMethodDescriptors returns the given serialized descriptor set, and the methods of its services keyed by
+// their paths.
+func parseGRPCMethodDescriptors(descriptorSet []byte) (*descriptorpb.FileDescriptorSet,
+	map[string]*descriptorpb.MethodDescriptorProto, error) {
+	fileDescriptorSet := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(descriptorSet, fileDescriptorSet); err != nil {
+		return nil, nil, fmt.Errorf("invalid descriptor set: %v", err)
+	}
+	methods := make(map[string]*descriptorpb.MethodDescriptorProto)
+	for _, file := range fileDescriptorSet.GetFile() {
+		for _, service := range file.GetService() {
+			serviceName := service.GetName()
+			if file.GetPackage() != "" {
+				serviceName = file.GetPackage() + "." + serviceName
+			}
+			for _, method := range service.GetMethod() {
+				methods["/"+serviceName+"/"+method.GetName()] = method
+			}
+		}
+	}
+	return fileDescriptorSet, methods, nil
+}
+
+// getGRPCHTTPBindings returns the bindings of the given google.api.http annotation and its additional bindings. The
+// paths of the annotation are prefixed with the given base path.
+func getGRPCHTTPBindings(httpRule *annotations.HttpRule, grpcPath string, basePath string) ([]GRPCHTTPBinding, error) {
+	var bindings []GRPCHTTPBinding
+	for _, rule := range append([]*annotations.HttpRule{httpRule}, httpRule.GetAdditionalBindings()...) {
+		binding := GRPCHTTPBinding{GRPCPath: grpcPath}
+		var path *string
+		switch pattern := rule.GetPattern().(type) {
+		case *annotations.HttpRule_Get:
+			binding.HTTPMethod, path = "GET", &pattern.Get
+		case *annotations.HttpRule_Put:
+			binding.HTTPMethod, path = "PUT", &pattern.Put
+		case *annotations.HttpRule_Post:
+			binding.HTTPMethod, path = "POST", &pattern.Post
+		case *annotations.HttpRule_Delete:
+			binding.HTTPMethod, path = "DELETE", &pattern.Delete
+		case *annotations.HttpRule_Patch:
+			binding.HTTPMethod, path = "PATCH", &pattern.Patch
+		case *annotations.HttpRule_Custom:
+			if pattern.Custom == nil {
+				continue
+			}
+			binding.HTTPMethod, path = strings.ToUpper(pattern.Custom.GetKind()), &pattern.Custom.Path
+		default:
+			continue
+		}
+		if !strings.HasPrefix(*path, "/") {
+			return nil, fmt.Errorf("path %s of the google.api.http annotation of method %s should start with /",
+				*path, grpcPath)
+		}
+		*path = basePath + *path
+		binding.PathTemplate = *path
+		bindings = append(bindings, binding)
+	}
+	return bindings, nil
+}
@@ -0,0 +1,70 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"strings"
+
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+)
+
+// grpcWebAllowHeaders are the request headers sent by the gRPC-Web clients
+var grpcWebAllowHeaders = []string{"content-type", "x-grpc-web", "x-user-agent", "grpc-timeout"}
+
+// grpcWebExposeHeaders are the response headers read by the gRPC-Web clients
+var grpcWebExposeHeaders = []string{"grpc-status", "grpc-message", "grpc-status-details-bin"}
+
+// isGRPCWebEnabled returns whether the given API policy accepts gRPC-Web requests. make sure the policy only has
+// the override section.
+func isGRPCWebEnabled(apiPolicy *dpv1alpha3.APIPolicy) bool {
+	return apiPolicy != nil && apiPolicy.Spec.Override != nil && apiPolicy.Spec.Override.GRPCWeb
+}
+
+// addGRPCWebCorsHeaders returns a copy of the given CORS configuration which allows the request headers and exposes
+// the response headers of the gRPC-Web protocol, so that browser clients on other origins can call the API.
+func addGRPCWebCorsHeaders(corsConfig *CorsConfig) *CorsConfig {
+	if corsConfig == nil {
+		return nil
+	}
+	grpcWebCorsConfig := *corsConfig
+	grpcWebCorsConfig.AccessControlAllowHeaders = appendMissingHeaders(corsConfig.AccessControlAllowHeaders,
+		grpcWebAllowHeaders)
+	grpcWebCorsConfig.AccessControlExposeHeaders = appendMissingHeaders(corsConfig.AccessControlExposeHeaders,
+		grpcWebExposeHeaders)
+	return &grpcWebCorsConfig
+}
+
+// appendMissingHeaders returns a new list of the given headers followed by the additional headers which are not in
+// it. Header names are compared case insensitively.
+func appendMissingHeaders(headers []string, additionalHeaders []string) []string {
+	mergedHeaders := make([]string, 0, len(headers)+len(additionalHeaders))
+	mergedHeaders = append(mergedHeaders, headers...)
+	for _, additionalHeader := range additionalHeaders {
+		found := false
+		for _, header := range headers {
+			if strings.EqualFold(header, additionalHeader) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			mergedHeaders = append(mergedHeaders, additionalHeader)
+		}
+	}
+	return mergedHeaders
+}
@@ -18,8 +18,19 @@
 package model
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/wso2/apk/adapter/config"
+	"github.com/wso2/apk/adapter/internal/loggers"
 	"github.com/wso2/apk/adapter/internal/oasparser/constants"
 	"github.com/wso2/apk/adapter/internal/operator/utils"
 	dpv1alpha1 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha1"
@@ -27,20 +38,27 @@ import (
 	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
 	"k8s.io/apimachinery/pkg/types"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/yaml"
 )
 
 // ResourceParams contains httproute related parameters
 type ResourceParams struct {
-	AuthSchemes               map[string]dpv1alpha2.Authentication
-	ResourceAuthSchemes       map[string]dpv1alpha2.Authentication
-	APIPolicies               map[string]dpv1alpha3.APIPolicy
-	ResourceAPIPolicies       map[string]dpv1alpha3.APIPolicy
-	InterceptorServiceMapping map[string]dpv1alpha1.InterceptorService
-	BackendJWTMapping         map[string]dpv1alpha1.BackendJWT
-	BackendMapping            map[string]*dpv1alpha2.ResolvedBackend
-	ResourceScopes            map[string]dpv1alpha1.Scope
-	RateLimitPolicies         map[string]dpv1alpha3.RateLimitPolicy
-	ResourceRateLimitPolicies map[string]dpv1alpha3.RateLimitPolicy
+	AuthSchemes                   map[string]dpv1alpha2.Authentication
+	ResourceAuthSchemes           map[string]dpv1alpha2.Authentication
+	APIPolicies                   map[string]dpv1alpha3.APIPolicy
+	ResourceAPIPolicies           map[string]dpv1alpha3.APIPolicy
+	InterceptorServiceMapping     map[string]dpv1alpha1.InterceptorService
+	BackendJWTMapping             map[string]dpv1alpha1.BackendJWT
+	BackendMapping                map[string]*dpv1alpha2.ResolvedBackend
+	ResourceScopes                map[string]dpv1alpha1.Scope
+	RateLimitPolicies             map[string]dpv1alpha3.RateLimitPolicy
+	ResourceRateLimitPolicies     map[string]dpv1alpha3.RateLimitPolicy
+	HMACKeys                      map[string]map[string]string
+	WSDLs                         map[string][]byte
+	ProtoDescriptorSets           map[string][]byte
+	AuthorizationPolicies         map[string]dpv1alpha3.AuthorizationPolicy
+	ResourceAuthorizationPolicies map[string]dpv1alpha3.AuthorizationPolicy
+	AIProviders                   map[string]*dpv1alpha3.AIProvider
 }
 
 func parseBackendJWTTokenToInternal(backendJWTToken dpv1alpha1.BackendJWTSpec) *BackendJWTTokenInfo {
@@ -110,6 +128,286 @@ func parseRateLimitPolicyToInternal(ratelimitPolicy *dpv1alpha3.RateLimitPolicy)
 	return rateLimitPolicyInternal
 }
 
+// parsePayloadPolicyToInternal returns the payload policy of the given APIPolicy.
+// make sure the policy only has override values. (i.e. use concatAPIPolicies)
+func parsePayloadPolicyToInternal(apiPolicy *dpv1alpha3.APIPolicy) *PayloadPolicy {
+	if apiPolicy == nil || apiPolicy.Spec.Override == nil || apiPolicy.Spec.Override.PayloadPolicy == nil {
+		return nil
+	}
+	payloadPolicy := apiPolicy.Spec.Override.PayloadPolicy
+	payloadPolicyInternal := &PayloadPolicy{AllowedContentTypes: payloadPolicy.AllowedContentTypes}
+	if payloadPolicy.MaxRequestBodySize != nil {
+		payloadPolicyInternal.MaxRequestBodySize = *payloadPolicy.MaxRequestBodySize
+	}
+	if payloadPolicy.MaxResponseBodySize != nil {
+		payloadPolicyInternal.MaxResponseBodySize = *payloadPolicy.MaxResponseBodySize
+	}
+	return payloadPolicyInternal
+}
+
+// parseAIRoutingToInternal returns the AI routing policy of the given API policy with the endpoint clusters
+// of the targets. make sure the policy only has override values. (i.e. use concatAPIPolicies)
+func parseAIRoutingToInternal(apiPolicy *dpv1alpha3.APIPolicy, namespace string,
+	backendMapping map[string]*dpv1alpha2.ResolvedBackend, aiProviders map[string]*dpv1alpha3.AIProvider) (*AIRouting, error) {
+	if apiPolicy == nil || apiPolicy.Spec.Override == nil || apiPolicy.Spec.Override.AIRouting == nil {
+		return nil, nil
+	}
+	aiRoutingPolicy := apiPolicy.Spec.Override.AIRouting
+	aiRouting := &AIRouting{}
+	for _, target := range aiRoutingPolicy.Targets {
+		backendName := types.NamespacedName{
+			Name:      target.BackendRef.Name,
+			Namespace: namespace,
+		}
+		resolvedBackend, ok := backendMapping[backendName.String()]
+		if !ok {
+			return nil, fmt.Errorf("backend: %s of the AI routing policy is not resolved", backendName)
+		}
+		aiRoutingTarget := AIRoutingTarget{
+			BackendName:     backendName.String(),
+			Models:          target.Models,
+			Model:           target.Model,
+			EndpointCluster: getEndpointCluster(backendName, backendMapping, nil),
+		}
+		if target.Provider != nil {
+			providerName := types.NamespacedName{
+				Name:      target.Provider.Name,
+				Namespace: namespace,
+			}
+			aiProvider, ok := aiProviders[providerName.String()]
+			if !ok || aiProvider == nil {
+				return nil, fmt.Errorf("AI provider: %s of the AI routing policy is not resolved", providerName)
+			}
+			aiRoutingTarget.ProviderName = providerName.String()
+			aiRoutingTarget.ModelIn = aiProvider.Spec.Model.In
+			aiRoutingTarget.ModelValue = aiProvider.Spec.Model.Value
+		}
+		switch resolvedBackend.Security.Type {
+		case "Basic":
+			aiRoutingTarget.Security = &EndpointSecurity{
+				Password: resolvedBackend.Security.Basic.Password,
+				Username: resolvedBackend.Security.Basic.Username,
+				Type:     resolvedBackend.Security.Type,
+				Enabled:  true,
+			}
+		case "APIKey":
+			aiRoutingTarget.Security = &EndpointSecurity{
+				Type:    resolvedBackend.Security.Type,
+				Enabled: true,
+				CustomParameters: map[string]string{
+					"in":    resolvedBackend.Security.APIKey.In,
+					"key":   resolvedBackend.Security.APIKey.Name,
+					"value": resolvedBackend.Security.APIKey.Value,
+				},
+			}
+		case "OAuth2", "AWS":
+			return nil, fmt.Errorf("%s security of backend: %s is not supported for the targets of the AI routing policy",
+				resolvedBackend.Security.Type, backendName)
+		}
+		aiRouting.Targets = append(aiRouting.Targets, aiRoutingTarget)
+	}
+	failoverOn := aiRoutingPolicy.FailoverOn
+	if len(failoverOn) == 0 {
+		failoverOn = []dpv1alpha3.AIFailoverCondition{dpv1alpha3.AIFailoverOnServerError, dpv1alpha3.AIFailoverOnRateLimited,
+			dpv1alpha3.AIFailoverOnTimeout, dpv1alpha3.AIFailoverOnConnectFailure}
+	}
+	for _, condition := range failoverOn {
+		aiRouting.FailoverOn = append(aiRouting.FailoverOn, string(condition))
+	}
+	if aiRoutingPolicy.PerTryTimeoutMillis != nil {
+		aiRouting.PerTryTimeoutInMillis = *aiRoutingPolicy.PerTryTimeoutMillis
+	}
+	return aiRouting, nil
+}
+
+// parseAIGuardrailsToInternal validates the AI guardrails policy of the API and converts it to the internal
+// representation passed to the enforcer.
+func parseAIGuardrailsToInternal(apiPolicy *dpv1alpha3.APIPolicy) (*AIGuardrails, error) {
+	if apiPolicy == nil || apiPolicy.Spec.Override == nil || apiPolicy.Spec.Override.AIGuardrails == nil {
+		return nil, nil
+	}
+	aiGuardrailsPolicy := apiPolicy.Spec.Override.AIGuardrails
+	for _, pattern := range aiGuardrailsPolicy.DenyPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("deny pattern: %s of the AI guardrails policy is invalid. %v", pattern, err)
+		}
+	}
+	aiGuardrails := &AIGuardrails{
+		DenyPatterns: aiGuardrailsPolicy.DenyPatterns,
+		DenyKeywords: aiGuardrailsPolicy.DenyKeywords,
+	}
+	if aiGuardrailsPolicy.PIIDetection != nil {
+		for _, entity := range aiGuardrailsPolicy.PIIDetection.Entities {
+			aiGuardrails.PIIEntities = append(aiGuardrails.PIIEntities, string(entity))
+		}
+		aiGuardrails.PIIAction = string(dpv1alpha3.AIGuardrailRedact)
+		if aiGuardrailsPolicy.PIIDetection.Action != "" {
+			aiGuardrails.PIIAction = string(aiGuardrailsPolicy.PIIDetection.Action)
+		}
+	}
+	if aiGuardrailsPolicy.MaxPromptLength != nil {
+		aiGuardrails.MaxPromptLength = *aiGuardrailsPolicy.MaxPromptLength
+	}
+	if aiGuardrailsPolicy.ResponseSchema != nil {
+		var schema map[string]interface{}
+		if err := json.Unmarshal([]byte(aiGuardrailsPolicy.ResponseSchema.Schema), &schema); err != nil {
+			return nil, fmt.Errorf("response schema of the AI guardrails policy is not a valid json object. %v", err)
+		}
+		if ref := getExternalSchemaRef(schema); ref != "" {
+			return nil, fmt.Errorf("response schema of the AI guardrails policy refers to the external schema %s", ref)
+		}
+		aiGuardrails.ResponseSchema = aiGuardrailsPolicy.ResponseSchema.Schema
+		aiGuardrails.ResponseContentPath = aiGuardrailsPolicy.ResponseSchema.ContentPath
+		if aiGuardrails.ResponseContentPath == "" {
+			aiGuardrails.ResponseContentPath = "$.choices[0].message.content"
+		}
+	}
+	return aiGuardrails, nil
+}
+
+// getExternalSchemaRef returns the first $ref of the given json schema which does not refer to the schema itself.
+// The enforcer does not fetch the external schemas.
+func getExternalSchemaRef(schema interface{}) string {
+	switch value := schema.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok && !strings.HasPrefix(ref, "#") {
+			return ref
+		}
+		for _, item := range value {
+			if ref := getExternalSchemaRef(item); ref != "" {
+				return ref
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if ref := getExternalSchemaRef(item); ref != "" {
+				return ref
+			}
+		}
+	}
+	return ""
+}
+
+// parseAIResponseCacheToInternal returns the AI response cache configurations of the API scoped by the given
+// organization and API, or nil if the cache is not enabled.
+func parseAIResponseCacheToInternal(apiPolicy *dpv1alpha3.APIPolicy, organizationID string, apiUUID string) *AIResponseCache {
+	if apiPolicy == nil || apiPolicy.Spec.Override == nil || apiPolicy.Spec.Override.AIResponseCache == nil ||
+		!apiPolicy.Spec.Override.AIResponseCache.Enabled {
+		return nil
+	}
+	aiResponseCachePolicy := apiPolicy.Spec.Override.AIResponseCache
+	aiResponseCache := &AIResponseCache{
+		Organization: organizationID,
+		APIUUID:      apiUUID,
+		TTLSeconds:   aiResponseCachePolicy.TTLSeconds,
+		MaxEntrySize: aiResponseCachePolicy.MaxEntrySize,
+		BypassHeader: strings.ToLower(aiResponseCachePolicy.BypassHeader),
+	}
+	if aiResponseCache.TTLSeconds == 0 {
+		aiResponseCache.TTLSeconds = 300
+	}
+	if aiResponseCache.MaxEntrySize == 0 {
+		aiResponseCache.MaxEntrySize = 1048576
+	}
+	if aiResponseCache.BypassHeader == "" {
+		aiResponseCache.BypassHeader = "x-wso2-cache-bypass"
+	}
+	return aiResponseCache
+}
+
+// parseCachingPolicyToInternal returns the response cache of the given API policy scoped by the organization
+// and the API. The responses of secured operations are always cached per consumer, so they are not served to
+// other consumers. make sure the policy only has override values. (i.e. use concatAPIPolicies)
+func parseCachingPolicyToInternal(apiPolicy *dpv1alpha3.APIPolicy, organizationID string, apiUUID string,
+	auth *Authentication) *ResponseCache {
+	if apiPolicy == nil || apiPolicy.Spec.Override == nil || apiPolicy.Spec.Override.Caching == nil ||
+		!apiPolicy.Spec.Override.Caching.Enabled {
+		return nil
+	}
+	cachingPolicy := apiPolicy.Spec.Override.Caching
+	responseCache := &ResponseCache{
+		Organization:        organizationID,
+		APIUUID:             apiUUID,
+		TTLSeconds:          cachingPolicy.TTLSeconds,
+		VaryByQueryParams:   cachingPolicy.VaryByQueryParams,
+		VaryByConsumer:      cachingPolicy.VaryByConsumer || auth == nil || !auth.Disabled,
+		RespectCacheControl: cachingPolicy.RespectCacheControl,
+		Store:               cachingPolicy.Store,
+		MaxEntrySize:        cachingPolicy.MaxEntrySize,
+	}
+	for _, header := range cachingPolicy.VaryByHeaders {
+		responseCache.VaryByHeaders = append(responseCache.VaryByHeaders, strings.ToLower(header))
+	}
+	if responseCache.TTLSeconds == 0 {
+		responseCache.TTLSeconds = 300
+	}
+	if responseCache.Store == "" {
+		responseCache.Store = "InMemory"
+	}
+	if responseCache.MaxEntrySize == 0 {
+		responseCache.MaxEntrySize = 1048576
+	}
+	return responseCache
+}
+
+// parseSchemaValidationToInternal returns the schema validation of the given API policy. The requests are validated
+// against the given API definition, which should be an OpenAPI 3 definition. make sure the policy only has override
+// values. (i.e. use concatAPIPolicies)
+func parseSchemaValidationToInternal(apiPolicy *dpv1alpha3.APIPolicy, definition []byte) (*SchemaValidation, error) {
+	if apiPolicy == nil || apiPolicy.Spec.Override == nil || apiPolicy.Spec.Override.SchemaValidation == nil ||
+		!apiPolicy.Spec.Override.SchemaValidation.Enabled {
+		return nil, nil
+	}
+	if err := validateOpenAPI3Definition(definition); err != nil {
+		return nil, fmt.Errorf("schema validation requires an OpenAPI 3 definition of the API: %v", err)
+	}
+	return &SchemaValidation{
+		ValidateResponses: apiPolicy.Spec.Override.SchemaValidation.ValidateResponses,
+	}, nil
+}
+
+// validateOpenAPI3Definition checks whether the given JSON or YAML definition, which may be gzip compressed, is an
+// OpenAPI 3 definition with paths.
+func validateOpenAPI3Definition(definition []byte) error {
+	if len(definition) == 0 {
+		return fmt.Errorf("the API does not have a definition")
+	}
+	definition, err := decompressAPIDefinition(definition)
+	if err != nil {
+		return err
+	}
+	var openAPI struct {
+		OpenAPI string                 `json:"openapi"`
+		Paths   map[string]interface{} `json:"paths"`
+	}
+	if err := yaml.Unmarshal(definition, &openAPI); err != nil {
+		return fmt.Errorf("invalid API definition: %v", err)
+	}
+	if !strings.HasPrefix(openAPI.OpenAPI, "3.") {
+		return fmt.Errorf("unsupported API definition version: %q", openAPI.OpenAPI)
+	}
+	if len(openAPI.Paths) == 0 {
+		return fmt.Errorf("the API definition does not have any paths")
+	}
+	return nil
+}
+
+// decompressAPIDefinition returns the given API definition, decompressing it if it is gzip compressed
+func decompressAPIDefinition(definition []byte) ([]byte, error) {
+	if len(definition) < 2 || definition[0] != 0x1f || definition[1] != 0x8b {
+		return definition, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(definition))
+	if err != nil {
+		return nil, fmt.Errorf("invalid compressed API definition: %v", err)
+	}
+	defer r.Close()
+	if definition, err = io.ReadAll(r); err != nil {
+		return nil, fmt.Errorf("invalid compressed API definition: %v", err)
+	}
+	return definition, nil
+}
+
 // addOperationLevelInterceptors add the operation level interceptor policy to the policies
 func addOperationLevelInterceptors(policies *OperationPolicies, apiPolicy *dpv1alpha3.APIPolicy,
 	interceptorServicesMapping map[string]dpv1alpha1.InterceptorService,
@@ -160,6 +458,116 @@ func addOperationLevelInterceptors(policies *OperationPolicies, apiPolicy *dpv1a
 	}
 }
 
+// addOperationLevelBodyTransformations adds the body transformation policies to the policies. make sure the policy
+// only has override values. (i.e. use concatAPIPolicies)
+func addOperationLevelBodyTransformations(policies *OperationPolicies, apiPolicy *dpv1alpha3.APIPolicy) {
+	if apiPolicy == nil || apiPolicy.Spec.Override == nil || apiPolicy.Spec.Override.BodyTransformation == nil {
+		return
+	}
+	if bodyTransformation := parseBodyTransformationToInternal(
+		apiPolicy.Spec.Override.BodyTransformation.Request); bodyTransformation != nil {
+		policies.Request = append(policies.Request, Policy{
+			PolicyName: constants.PolicyRequestBodyTransformation,
+			Action:     constants.ActionTransformBody,
+			Parameters: map[string]interface{}{constants.BodyTransformation: bodyTransformation},
+		})
+	}
+	if bodyTransformation := parseBodyTransformationToInternal(
+		apiPolicy.Spec.Override.BodyTransformation.Response); bodyTransformation != nil {
+		policies.Response = append(policies.Response, Policy{
+			PolicyName: constants.PolicyResponseBodyTransformation,
+			Action:     constants.ActionTransformBody,
+			Parameters: map[string]interface{}{constants.BodyTransformation: bodyTransformation},
+		})
+	}
+}
+
+// addOperationLevelSOAPMediation returns the policies of the resource with the given path along with the SOAP
+// mediation of the resource, if the API policy maps the resource to an operation of a SOAP backend.
+func addOperationLevelSOAPMediation(policies OperationPolicies, apiPolicy *dpv1alpha3.APIPolicy, path, namespace string,
+	wsdls map[string][]byte) (OperationPolicies, error) {
+	if apiPolicy == nil || apiPolicy.Spec.Override == nil || apiPolicy.Spec.Override.SOAPMediation == nil {
+		return policies, nil
+	}
+	soapMediationPolicy := apiPolicy.Spec.Override.SOAPMediation
+	var operation *dpv1alpha3.SOAPOperationMapping
+	for i := range soapMediationPolicy.Operations {
+		if soapMediationPolicy.Operations[i].Path == path {
+			operation = &soapMediationPolicy.Operations[i]
+			break
+		}
+		if soapMediationPolicy.Operations[i].Path == "" {
+			operation = &soapMediationPolicy.Operations[i]
+		}
+	}
+	if operation == nil {
+		return policies, nil
+	}
+	wsdlRef := types.NamespacedName{Namespace: namespace, Name: soapMediationPolicy.WSDLRef}.String()
+	wsdl, found := wsdls[wsdlRef]
+	if !found {
+		return policies, fmt.Errorf("wsdl %s of the soap mediation has not been resolved", wsdlRef)
+	}
+	soapMediation, err := parseWSDLOperation(wsdl, operation.Operation, soapMediationPolicy.SOAPVersion)
+	if err != nil {
+		return policies, fmt.Errorf("error while parsing wsdl %s, %v", wsdlRef, err)
+	}
+	if operation.SOAPAction != "" {
+		soapMediation.SOAPAction = operation.SOAPAction
+	}
+	soapMediation.EnvelopeTemplate = operation.EnvelopeTemplate
+
+	// The policies are shared by the matches of the rule, hence the request policies are copied.
+	mediatedPolicies := policies
+	mediatedPolicies.Request = append(append(PolicyList{}, policies.Request...), Policy{
+		PolicyName: constants.PolicySOAPMediation,
+		Action:     constants.ActionMediateSOAP,
+		Parameters: map[string]interface{}{constants.SOAPMediation: soapMediation},
+	})
+	return mediatedPolicies, nil
+}
+
+// parseBodyTransformationToInternal returns the body transformation of the given transformation of the API policy,
+// or nil if it does not transform the payload.
+func parseBodyTransformationToInternal(transformation *dpv1alpha3.BodyTransformation) *BodyTransformation {
+	if transformation == nil || (transformation.Conversion == "" && len(transformation.AddFields) == 0 &&
+		len(transformation.RemoveFields) == 0 && len(transformation.RenameFields) == 0 &&
+		transformation.Template == "") {
+		return nil
+	}
+	bodyTransformation := &BodyTransformation{
+		Conversion:     transformation.Conversion,
+		XMLRootElement: transformation.XMLRootElement,
+		RemoveFields:   transformation.RemoveFields,
+		Template:       transformation.Template,
+		ContentType:    transformation.ContentType,
+	}
+	for _, addField := range transformation.AddFields {
+		bodyTransformation.AddFields = append(bodyTransformation.AddFields, BodyField{
+			Path:  addField.Path,
+			Value: addField.Value,
+		})
+	}
+	for _, renameField := range transformation.RenameFields {
+		bodyTransformation.RenameFields = append(bodyTransformation.RenameFields, BodyFieldRename{
+			From: renameField.From,
+			To:   renameField.To,
+		})
+	}
+	if bodyTransformation.ContentType == "" {
+		switch bodyTransformation.Conversion {
+		case "JSONToXML":
+			bodyTransformation.ContentType = "application/xml"
+		case "XMLToJSON":
+			bodyTransformation.ContentType = "application/json"
+		}
+	}
+	if bodyTransformation.Conversion == "JSONToXML" && bodyTransformation.XMLRootElement == "" {
+		bodyTransformation.XMLRootElement = "root"
+	}
+	return bodyTransformation
+}
+
 // GetEndpoints creates endpoints using resolved backends in backendMapping
 func GetEndpoints(backendName types.NamespacedName, backendMapping map[string]*dpv1alpha2.ResolvedBackend) []Endpoint {
 	endpoints := []Endpoint{}
@@ -168,12 +576,16 @@ func GetEndpoints(backendName types.NamespacedName, backendMapping map[string]*d
 		if len(backend.Services) > 0 {
 			for _, service := range backend.Services {
 				endpoints = append(endpoints, Endpoint{
-					Host:        service.Host,
-					Port:        service.Port,
-					Basepath:    backend.BasePath,
-					URLType:     string(backend.Protocol),
-					Certificate: []byte(backend.TLS.ResolvedCertificate),
-					AllowedSANs: backend.TLS.AllowedSANs,
+					Host:              service.Host,
+					Port:              service.Port,
+					Basepath:          backend.BasePath,
+					URLType:           string(backend.Protocol),
+					Certificate:       []byte(backend.TLS.ResolvedCertificate),
+					AllowedSANs:       backend.TLS.AllowedSANs,
+					ClientCertificate: []byte(backend.TLS.ClientCertificate),
+					ClientPrivateKey:  []byte(backend.TLS.ClientPrivateKey),
+					Weight:            service.Weight,
+					Priority:          service.Priority,
 				})
 			}
 		}
@@ -192,6 +604,188 @@ func GetBackendBasePath(backendName types.NamespacedName, backendMapping map[str
 	return ""
 }
 
+// getOutlierDetection converts the outlier detection configuration of a resolved backend to the internal representation.
+func getOutlierDetection(outlierDetection *dpv1alpha2.OutlierDetection) *OutlierDetection {
+	if outlierDetection == nil {
+		return nil
+	}
+	return &OutlierDetection{
+		Consecutive5xxErrors:     outlierDetection.Consecutive5xxErrors,
+		ConsecutiveGatewayErrors: outlierDetection.ConsecutiveGatewayErrors,
+		Interval:                 outlierDetection.Interval,
+		BaseEjectionTime:         outlierDetection.BaseEjectionTime,
+		MaxEjectionPercent:       outlierDetection.MaxEjectionPercent,
+	}
+}
+
+// getOAuth2EndpointSecurity creates the endpoint security for an OAuth2 client credentials backend. The
+// enforcer obtains the access token from the token endpoint, caches it and sends it to the backend.
+func getOAuth2EndpointSecurity(oauth2 dpv1alpha2.ResolvedOAuth2SecurityConfig) EndpointSecurity {
+	return EndpointSecurity{
+		Type:    "OAuth2",
+		Enabled: true,
+		CustomParameters: map[string]string{
+			"tokenEndpoint": oauth2.TokenEndpoint,
+			"clientId":      oauth2.ClientID,
+			"clientSecret":  oauth2.ClientSecret,
+			"scopes":        strings.Join(oauth2.Scopes, " "),
+			"audience":      oauth2.Audience,
+			"tokenCacheTTL": strconv.FormatUint(uint64(oauth2.TokenCacheTTL), 10),
+			"refreshSkew":   strconv.FormatUint(uint64(oauth2.RefreshSkew), 10),
+		},
+	}
+}
+
+// getAWSEndpointSecurity creates the endpoint security for a backend of an AWS service. The enforcer signs the
+// requests converted to the Bedrock format with the credentials.
+func getAWSEndpointSecurity(aws dpv1alpha2.ResolvedAWSSecurityConfig) EndpointSecurity {
+	return EndpointSecurity{
+		Type:    "AWS",
+		Enabled: true,
+		CustomParameters: map[string]string{
+			"region":          aws.Region,
+			"service":         aws.Service,
+			"accessKeyId":     aws.AccessKeyID,
+			"secretAccessKey": aws.SecretAccessKey,
+			"sessionToken":    aws.SessionToken,
+		},
+	}
+}
+
+// getLoadBalancing converts the load balancing configuration of a resolved backend to the internal representation.
+func getLoadBalancing(loadBalancing *dpv1alpha2.LoadBalancing) *LoadBalancing {
+	if loadBalancing == nil {
+		return nil
+	}
+	internalLoadBalancing := &LoadBalancing{
+		Policy: string(loadBalancing.Policy),
+	}
+	for _, hashKey := range loadBalancing.HashKeys {
+		internalLoadBalancing.HashKeys = append(internalLoadBalancing.HashKeys, HashKey{
+			Type:      string(hashKey.Type),
+			Name:      hashKey.Name,
+			CookieTTL: hashKey.CookieTTL,
+		})
+	}
+	return internalLoadBalancing
+}
+
+// getEndpointCluster creates the endpoint cluster of a resolved backend in backendMapping with the circuit breaker,
+// timeout, retry, health check, outlier detection and load balancing configurations of the backend. The given retry
+// status codes are used when the retry configuration of the backend does not have its own status codes.
+func getEndpointCluster(backendName types.NamespacedName, backendMapping map[string]*dpv1alpha2.ResolvedBackend,
+	retryStatusCodes []uint32) *EndpointCluster {
+	endpointCluster := &EndpointCluster{
+		Endpoints: GetEndpoints(backendName, backendMapping),
+	}
+	backend, ok := backendMapping[backendName.String()]
+	if !ok || backend == nil {
+		return endpointCluster
+	}
+	endpointConfig := &EndpointConfig{}
+	hasEndpointConfig := false
+	if backend.CircuitBreaker != nil {
+		hasEndpointConfig = true
+		endpointConfig.CircuitBreakers = &CircuitBreakers{
+			MaxConnections:     int32(backend.CircuitBreaker.MaxConnections),
+			MaxRequests:        int32(backend.CircuitBreaker.MaxRequests),
+			MaxPendingRequests: int32(backend.CircuitBreaker.MaxPendingRequests),
+			MaxRetries:         int32(backend.CircuitBreaker.MaxRetries),
+			MaxConnectionPools: int32(backend.CircuitBreaker.MaxConnectionPools),
+		}
+	}
+	if backend.Timeout != nil {
+		hasEndpointConfig = true
+		endpointConfig.TimeoutInMillis = backend.Timeout.UpstreamResponseTimeout * 1000
+		endpointConfig.IdleTimeoutInSeconds = backend.Timeout.DownstreamRequestIdleTimeout
+	}
+	if backend.Retry != nil {
+		hasEndpointConfig = true
+		statusCodes := retryStatusCodes
+		if len(backend.Retry.StatusCodes) > 0 {
+			statusCodes = backend.Retry.StatusCodes
+		}
+		endpointConfig.RetryConfig = &RetryConfig{
+			Count:                int32(backend.Retry.Count),
+			StatusCodes:          statusCodes,
+			BaseIntervalInMillis: int32(backend.Retry.BaseIntervalMillis),
+		}
+	}
+	if backend.HealthCheck != nil {
+		hasEndpointConfig = true
+		endpointCluster.HealthCheck = &HealthCheck{
+			Interval:           backend.HealthCheck.Interval,
+			Timeout:            backend.HealthCheck.Timeout,
+			UnhealthyThreshold: backend.HealthCheck.UnhealthyThreshold,
+			HealthyThreshold:   backend.HealthCheck.HealthyThreshold,
+		}
+	}
+	if hasEndpointConfig {
+		endpointCluster.Config = endpointConfig
+	}
+	endpointCluster.OutlierDetection = getOutlierDetection(backend.OutlierDetection)
+	endpointCluster.LoadBalancing = getLoadBalancing(backend.LoadBalancing)
+	return endpointCluster
+}
+
+// getWeightedEndpointCluster creates the endpoint cluster of a single backendRef using the resolved backend
+// in backendMapping. The weight of the backendRef defaults to 1 when it is not provided.
+func getWeightedEndpointCluster(backendName types.NamespacedName, weight *int32,
+	backendMapping map[string]*dpv1alpha2.ResolvedBackend) *WeightedEndpointCluster {
+	backendWeight := uint32(1)
+	if weight != nil && *weight >= 0 {
+		backendWeight = uint32(*weight)
+	}
+	retryStatusCodes := config.ReadConfigs().Envoy.Upstream.Retry.StatusCodes
+	return &WeightedEndpointCluster{
+		BackendName:     backendName.String(),
+		EndpointCluster: getEndpointCluster(backendName, backendMapping, retryStatusCodes),
+		Weight:          backendWeight,
+	}
+}
+
+// addRuleEndpointCluster adds the endpoint cluster of a backendRef to the endpoint cluster of its rule, where
+// firstBackendName is empty for the first backendRef of the rule. The circuit breaker, health check, outlier
+// detection and load balancing configurations are applied on the upstream clusters, hence each backend keeps its
+// own in its weighted endpoint cluster. The timeout, retry and hash key configurations are applied on the route of
+// the rule, which envoy does not allow to vary per weighted cluster, hence the ones of the first backend of the rule
+// apply to all of its backends.
+func addRuleEndpointCluster(ruleEndpointCluster *EndpointCluster, endpointCluster *EndpointCluster,
+	backendName string, firstBackendName string) {
+	if firstBackendName == "" {
+		ruleEndpointCluster.Config = endpointCluster.Config
+		ruleEndpointCluster.HealthCheck = endpointCluster.HealthCheck
+		ruleEndpointCluster.OutlierDetection = endpointCluster.OutlierDetection
+		ruleEndpointCluster.LoadBalancing = endpointCluster.LoadBalancing
+	} else if !reflect.DeepEqual(getRouteLevelConfig(ruleEndpointCluster), getRouteLevelConfig(endpointCluster)) {
+		loggers.LoggerOasparser.Warnf("The timeout, retry and hash key configurations of backend: %s are not applied "+
+			"as it shares the traffic of a rule with backend: %s, whose configurations apply to the rule",
+			backendName, firstBackendName)
+	}
+	ruleEndpointCluster.Endpoints = append(ruleEndpointCluster.Endpoints, endpointCluster.Endpoints...)
+}
+
+// routeLevelConfig holds the configurations of an endpoint cluster which are applied on the route.
+type routeLevelConfig struct {
+	timeoutInMillis      uint32
+	idleTimeoutInSeconds uint32
+	retryConfig          *RetryConfig
+	hashKeys             []HashKey
+}
+
+func getRouteLevelConfig(endpointCluster *EndpointCluster) routeLevelConfig {
+	config := routeLevelConfig{}
+	if endpointCluster.Config != nil {
+		config.timeoutInMillis = endpointCluster.Config.TimeoutInMillis
+		config.idleTimeoutInSeconds = endpointCluster.Config.IdleTimeoutInSeconds
+		config.retryConfig = endpointCluster.Config.RetryConfig
+	}
+	if endpointCluster.LoadBalancing != nil {
+		config.hashKeys = endpointCluster.LoadBalancing.HashKeys
+	}
+	return config
+}
+
 func concatRateLimitPolicies(schemeUp *dpv1alpha3.RateLimitPolicy, schemeDown *dpv1alpha3.RateLimitPolicy) *dpv1alpha3.RateLimitPolicy {
 	finalRateLimit := dpv1alpha3.RateLimitPolicy{}
 	if schemeUp != nil && schemeDown != nil {
@@ -216,6 +810,40 @@ func concatAPIPolicies(schemeUp *dpv1alpha3.APIPolicy, schemeDown *dpv1alpha3.AP
 	return &apiPolicy
 }
 
+func concatAuthorizationPolicies(schemeUp *dpv1alpha3.AuthorizationPolicy, schemeDown *dpv1alpha3.AuthorizationPolicy) *dpv1alpha3.AuthorizationPolicy {
+	authorizationPolicy := dpv1alpha3.AuthorizationPolicy{}
+	if schemeUp != nil && schemeDown != nil {
+		authorizationPolicy.Spec.Override = utils.SelectPolicy(&schemeUp.Spec.Override, &schemeUp.Spec.Default, &schemeDown.Spec.Override, &schemeDown.Spec.Default)
+	} else if schemeUp != nil {
+		authorizationPolicy.Spec.Override = utils.SelectPolicy(&schemeUp.Spec.Override, &schemeUp.Spec.Default, nil, nil)
+	} else if schemeDown != nil {
+		authorizationPolicy.Spec.Override = utils.SelectPolicy(nil, nil, &schemeDown.Spec.Override, &schemeDown.Spec.Default)
+	}
+	return &authorizationPolicy
+}
+
+// parseAuthorizationPolicyToInternal returns the authorization rules of the given policy.
+// make sure the policy only has override values. (i.e. use concatAuthorizationPolicies)
+func parseAuthorizationPolicyToInternal(authorizationPolicy *dpv1alpha3.AuthorizationPolicy) []AuthorizationRule {
+	if authorizationPolicy == nil || authorizationPolicy.Spec.Override == nil {
+		return nil
+	}
+	var rules []AuthorizationRule
+	for _, rule := range authorizationPolicy.Spec.Override.Rules {
+		language := rule.Language
+		if language == "" {
+			language = constants.AuthorizationLanguageAPKExpression
+		}
+		rules = append(rules, AuthorizationRule{
+			Name:       rule.Name,
+			Language:   language,
+			Expression: rule.Expression,
+			Message:    rule.Message,
+		})
+	}
+	return rules
+}
+
 func concatAuthSchemes(schemeUp *dpv1alpha2.Authentication, schemeDown *dpv1alpha2.Authentication) *dpv1alpha2.Authentication {
 	finalAuth := dpv1alpha2.Authentication{
 		Spec: dpv1alpha2.AuthenticationSpec{},
@@ -233,7 +861,7 @@ func concatAuthSchemes(schemeUp *dpv1alpha2.Authentication, schemeDown *dpv1alph
 // getSecurity returns security schemes and it's definitions with flag to indicate if security is disabled
 // make sure authscheme only has external service override values. (i.e. empty default values)
 // tip: use concatScheme method
-func getSecurity(authScheme *dpv1alpha2.Authentication) *Authentication {
+func getSecurity(authScheme *dpv1alpha2.Authentication, hmacKeys map[string]map[string]string) *Authentication {
 	authHeader := constants.AuthorizationHeader
 	if authScheme != nil && authScheme.Spec.Override != nil && authScheme.Spec.Override.AuthTypes != nil && len(authScheme.Spec.Override.AuthTypes.OAuth2.Header) > 0 {
 		authHeader = authScheme.Spec.Override.AuthTypes.OAuth2.Header
@@ -279,6 +907,11 @@ func getSecurity(authScheme *dpv1alpha2.Authentication) *Authentication {
 			}
 			auth.APIKey = apiKeys
 		}
+		if authScheme.Spec.Override.AuthTypes != nil && authScheme.Spec.Override.AuthTypes.HMAC != nil {
+			auth.HMAC = getHMAC(authScheme.Spec.Override.AuthTypes.HMAC,
+				hmacKeys[authScheme.Spec.Override.AuthTypes.HMAC.SecretRef.Name])
+			authFound = true
+		}
 		if !authFound {
 			return &Authentication{Disabled: true}
 		}
@@ -286,27 +919,52 @@ func getSecurity(authScheme *dpv1alpha2.Authentication) *Authentication {
 	return auth
 }
 
+// getHMAC returns the HMAC authentication configuration with the resolved shared keys
+func getHMAC(hmacAuth *dpv1alpha2.HMACAuth, keys map[string]string) *HMAC {
+	header := hmacAuth.Header
+	if header == "" {
+		header = constants.SignatureHeader
+	}
+	algorithms := make([]string, 0, len(hmacAuth.Algorithms))
+	for _, algorithm := range hmacAuth.Algorithms {
+		algorithms = append(algorithms, string(algorithm))
+	}
+	if len(algorithms) == 0 {
+		algorithms = append(algorithms, constants.DefaultHMACAlgorithm)
+	}
+	return &HMAC{
+		Header:              header,
+		Keys:                keys,
+		Algorithms:          algorithms,
+		SignedHeaders:       hmacAuth.SignedHeaders,
+		ClockSkew:           hmacAuth.ClockSkew,
+		SendTokenToUpstream: hmacAuth.SendTokenToUpstream,
+	}
+}
+
 // getAllowedOperations retuns a list of allowed operatons, if httpMethod is not specified then all methods are allowed.
 func getAllowedOperations(matchID string, httpMethod *gwapiv1.HTTPMethod, policies OperationPolicies, auth *Authentication,
-	ratelimitPolicy *RateLimitPolicy, scopes []string, mirrorEndpointClusters []*EndpointCluster) []*Operation {
+	ratelimitPolicy *RateLimitPolicy, authorizationRules []AuthorizationRule,
+	payloadPolicy *PayloadPolicy, responseCache *ResponseCache, schemaValidation *SchemaValidation, scopes []string,
+	mirrorEndpointClusters []*EndpointCluster) []*Operation {
 	if httpMethod != nil {
 		return []*Operation{{iD: uuid.New().String(), method: string(*httpMethod), policies: policies,
-			auth: auth, rateLimitPolicy: ratelimitPolicy, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID}}
+			auth: auth, rateLimitPolicy: ratelimitPolicy, authorizationRules: authorizationRules, payloadPolicy: payloadPolicy, responseCache: responseCache, schemaValidation: schemaValidation, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID}}
 	}
 	return []*Operation{{iD: uuid.New().String(), method: string(gwapiv1.HTTPMethodGet), policies: policies,
-		auth: auth, rateLimitPolicy: ratelimitPolicy, scopes: scopes, matchID: matchID},
+		auth: auth, rateLimitPolicy: ratelimitPolicy, authorizationRules: authorizationRules, payloadPolicy: payloadPolicy, responseCache: responseCache, schemaValidation: schemaValidation, scopes: scopes, matchID: matchID},
 		{iD: uuid.New().String(), method: string(gwapiv1.HTTPMethodPost), policies: policies,
-			auth: auth, rateLimitPolicy: ratelimitPolicy, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID},
+			auth: auth, rateLimitPolicy: ratelimitPolicy, authorizationRules: authorizationRules, payloadPolicy: payloadPolicy, responseCache: responseCache, schemaValidation: schemaValidation, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID},
 		{iD: uuid.New().String(), method: string(gwapiv1.HTTPMethodDelete), policies: policies,
-			auth: auth, rateLimitPolicy: ratelimitPolicy, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID},
+			auth: auth, rateLimitPolicy: ratelimitPolicy, authorizationRules: authorizationRules, payloadPolicy: payloadPolicy, responseCache: responseCache, schemaValidation: schemaValidation, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID},
 		{iD: uuid.New().String(), method: string(gwapiv1.HTTPMethodPatch), policies: policies,
-			auth: auth, rateLimitPolicy: ratelimitPolicy, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID},
+			auth: auth, rateLimitPolicy: ratelimitPolicy, authorizationRules: authorizationRules, payloadPolicy: payloadPolicy, responseCache: responseCache, schemaValidation: schemaValidation, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID},
 		{iD: uuid.New().String(), method: string(gwapiv1.HTTPMethodPut), policies: policies,
-			auth: auth, rateLimitPolicy: ratelimitPolicy, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID},
+			auth: auth, rateLimitPolicy: ratelimitPolicy, authorizationRules: authorizationRules, payloadPolicy: payloadPolicy, responseCache: responseCache, schemaValidation: schemaValidation, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID},
 		{iD: uuid.New().String(), method: string(gwapiv1.HTTPMethodHead), policies: policies,
-			auth: auth, rateLimitPolicy: ratelimitPolicy, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID},
+			auth: auth, rateLimitPolicy: ratelimitPolicy, authorizationRules: authorizationRules, payloadPolicy: payloadPolicy, responseCache: responseCache, schemaValidation: schemaValidation, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID},
 		{iD: uuid.New().String(), method: string(gwapiv1.HTTPMethodOptions), policies: policies,
-			auth: auth, rateLimitPolicy: ratelimitPolicy, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID}}
+			auth: auth, rateLimitPolicy: ratelimitPolicy, authorizationRules: authorizationRules, payloadPolicy: payloadPolicy, responseCache: responseCache, schemaValidation: schemaValidation, scopes: scopes, mirrorEndpointClusters: mirrorEndpointClusters, matchID: matchID}}
 }
 
 // SetInfoAPICR populates ID, ApiType, Version and XWso2BasePath of adapterInternalAPI.
@@ -18,10 +18,20 @@
 package model
 
 import (
+	"bytes"
+	"compress/gzip"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/wso2/apk/adapter/config"
+	"github.com/wso2/apk/adapter/internal/oasparser/constants"
+	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
 	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"k8s.io/apimachinery/pkg/types"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 func TestConcatRateLimitPolicies(t *testing.T) {
@@ -366,3 +376,727 @@ func TestConcatAPIPolicies(t *testing.T) {
 		assert.Equal(t, resultScheme, actualResult, item.message)
 	}
 }
+
+func TestGetOAuth2EndpointSecurity(t *testing.T) {
+	endpointSecurity := getOAuth2EndpointSecurity(dpv1alpha2.ResolvedOAuth2SecurityConfig{
+		TokenEndpoint: "https://idp.example.com/oauth2/token",
+		ClientID:      "client",
+		ClientSecret:  "secret",
+		Scopes:        []string{"read", "write"},
+		RefreshSkew:   30,
+	})
+	assert.Equal(t, "OAuth2", endpointSecurity.Type, "Endpoint security type mismatch.")
+	assert.True(t, endpointSecurity.Enabled, "Endpoint security should be enabled.")
+	assert.Equal(t, "https://idp.example.com/oauth2/token", endpointSecurity.CustomParameters["tokenEndpoint"],
+		"Token endpoint mismatch.")
+	assert.Equal(t, "client", endpointSecurity.CustomParameters["clientId"], "Client ID mismatch.")
+	assert.Equal(t, "secret", endpointSecurity.CustomParameters["clientSecret"], "Client secret mismatch.")
+	assert.Equal(t, "read write", endpointSecurity.CustomParameters["scopes"], "Scopes mismatch.")
+	assert.Equal(t, "0", endpointSecurity.CustomParameters["tokenCacheTTL"], "Token cache TTL mismatch.")
+	assert.Equal(t, "30", endpointSecurity.CustomParameters["refreshSkew"], "Refresh skew mismatch.")
+}
+
+func TestGetAWSEndpointSecurity(t *testing.T) {
+	endpointSecurity := getAWSEndpointSecurity(dpv1alpha2.ResolvedAWSSecurityConfig{
+		Region:          "us-east-1",
+		Service:         "bedrock",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+	})
+	assert.Equal(t, "AWS", endpointSecurity.Type, "Endpoint security type mismatch.")
+	assert.True(t, endpointSecurity.Enabled, "Endpoint security should be enabled.")
+	assert.Equal(t, "us-east-1", endpointSecurity.CustomParameters["region"], "Region mismatch.")
+	assert.Equal(t, "bedrock", endpointSecurity.CustomParameters["service"], "Service mismatch.")
+	assert.Equal(t, "AKIDEXAMPLE", endpointSecurity.CustomParameters["accessKeyId"], "Access key ID mismatch.")
+	assert.Equal(t, "secret", endpointSecurity.CustomParameters["secretAccessKey"], "Secret access key mismatch.")
+	assert.Empty(t, endpointSecurity.CustomParameters["sessionToken"], "Session token should not be set.")
+}
+
+func TestGetSecurityWithHMAC(t *testing.T) {
+	authScheme := &dpv1alpha2.Authentication{
+		Spec: dpv1alpha2.AuthenticationSpec{
+			Override: &dpv1alpha2.AuthSpec{
+				AuthTypes: &dpv1alpha2.APIAuth{
+					OAuth2: dpv1alpha2.OAuth2Auth{Disabled: true},
+					HMAC: &dpv1alpha2.HMACAuth{
+						SecretRef:     dpv1alpha2.HMACSecretRef{Name: "device-keys"},
+						SignedHeaders: []string{"(request-target)", "date", "digest"},
+						ClockSkew:     120,
+					},
+				},
+			},
+		},
+	}
+	hmacKeys := map[string]map[string]string{"device-keys": {"device-1": "key-1"}}
+	auth := getSecurity(authScheme, hmacKeys)
+	assert.False(t, auth.Disabled, "Authentication should be enabled.")
+	assert.Nil(t, auth.Oauth2, "OAuth2 should be disabled.")
+	assert.NotNil(t, auth.HMAC, "HMAC should be enabled.")
+	assert.Equal(t, "signature", auth.HMAC.Header, "Signature header mismatch.")
+	assert.Equal(t, map[string]string{"device-1": "key-1"}, auth.HMAC.Keys, "HMAC keys mismatch.")
+	assert.Equal(t, []string{"hmac-sha256"}, auth.HMAC.Algorithms, "Algorithms mismatch.")
+	assert.Equal(t, []string{"(request-target)", "date", "digest"}, auth.HMAC.SignedHeaders, "Signed headers mismatch.")
+	assert.Equal(t, uint32(120), auth.HMAC.ClockSkew, "Clock skew mismatch.")
+}
+
+func TestConcatAuthorizationPolicies(t *testing.T) {
+	apiLevel := &dpv1alpha3.AuthorizationPolicy{
+		Spec: dpv1alpha3.AuthorizationPolicySpec{
+			Default: &dpv1alpha3.AuthorizationRules{
+				Rules: []dpv1alpha3.AuthorizationRule{
+					{Name: "tenant", Expression: "claims.tenant == headers['x-tenant']"},
+				},
+			},
+		},
+	}
+	resourceLevel := &dpv1alpha3.AuthorizationPolicy{
+		Spec: dpv1alpha3.AuthorizationPolicySpec{
+			Default: &dpv1alpha3.AuthorizationRules{
+				Rules: []dpv1alpha3.AuthorizationRule{
+					{Name: "admin", Language: "APKExpression", Expression: "claims.role == \"admin\"",
+						Message: "admin role required"},
+				},
+			},
+		},
+	}
+
+	rules := parseAuthorizationPolicyToInternal(concatAuthorizationPolicies(apiLevel, nil))
+	assert.Equal(t, []AuthorizationRule{{Name: "tenant", Language: "APKExpression",
+		Expression: "claims.tenant == headers['x-tenant']"}}, rules,
+		"API level rules should be applied with the APK expression language as the default language.")
+
+	rules = parseAuthorizationPolicyToInternal(concatAuthorizationPolicies(apiLevel, resourceLevel))
+	assert.Equal(t, []AuthorizationRule{{Name: "admin", Language: "APKExpression",
+		Expression: "claims.role == \"admin\"", Message: "admin role required"}}, rules,
+		"Resource level default rules should take precedence over API level default rules.")
+
+	apiLevel.Spec.Override = apiLevel.Spec.Default
+	apiLevel.Spec.Default = nil
+	rules = parseAuthorizationPolicyToInternal(concatAuthorizationPolicies(apiLevel, resourceLevel))
+	assert.Equal(t, "tenant", rules[0].Name, "API level override rules should take precedence over resource level rules.")
+
+	assert.Nil(t, parseAuthorizationPolicyToInternal(concatAuthorizationPolicies(nil, nil)), "No rules should be applied.")
+}
+
+func TestParseAIRoutingToInternal(t *testing.T) {
+	apiPolicy := &dpv1alpha3.APIPolicy{
+		Spec: dpv1alpha3.APIPolicySpec{
+			Default: &dpv1alpha3.PolicySpec{
+				AIRouting: &dpv1alpha3.AIRoutingPolicy{
+					Targets: []dpv1alpha3.AIRoutingTarget{
+						{Provider: &dpv1alpha3.AIProviderReference{Name: "azure-ai"},
+							BackendRef: dpv1alpha3.AIRoutingBackendReference{Name: "azure-backend"}, Models: []string{"gpt-4o"},
+							Model: "gpt-4o-deployment"},
+						{BackendRef: dpv1alpha3.AIRoutingBackendReference{Name: "openai-backend"}, Models: []string{"gpt-4o", "gpt-4o-mini"}},
+						{BackendRef: dpv1alpha3.AIRoutingBackendReference{Name: "local-backend"}},
+					},
+				},
+			},
+		},
+	}
+	backendMapping := map[string]*dpv1alpha2.ResolvedBackend{
+		"default/azure-backend": {Services: []dpv1alpha2.Service{{Host: "azure.example.com", Port: 443}}, Protocol: dpv1alpha2.HTTPSProtocol,
+			Security: dpv1alpha2.ResolvedSecurityConfig{Type: "APIKey",
+				APIKey: dpv1alpha2.ResolvedAPIKeySecurityConfig{In: "Header", Name: "api-key", Value: "azure-key"}}},
+		"default/openai-backend": {Services: []dpv1alpha2.Service{{Host: "openai.example.com", Port: 443}}, Protocol: dpv1alpha2.HTTPSProtocol},
+		"default/local-backend":  {Services: []dpv1alpha2.Service{{Host: "llm.default.svc", Port: 8080}}, Protocol: dpv1alpha2.HTTPProtocol},
+	}
+
+	aiProviders := map[string]*dpv1alpha3.AIProvider{
+		"default/azure-ai": {Spec: dpv1alpha3.AIProviderSpec{ProviderName: "AzureAI",
+			Model: dpv1alpha3.ValueDetails{In: "Body", Value: "model"}}},
+	}
+
+	aiRouting, err := parseAIRoutingToInternal(concatAPIPolicies(apiPolicy, nil), "default", backendMapping, aiProviders)
+	assert.Nil(t, err, "Error while parsing the AI routing policy")
+	assert.Equal(t, 3, len(aiRouting.Targets), "Target count mismatch.")
+	assert.Equal(t, "default/azure-ai", aiRouting.Targets[0].ProviderName, "Provider name mismatch.")
+	assert.Equal(t, "gpt-4o-deployment", aiRouting.Targets[0].Model, "Target model mismatch.")
+	assert.Equal(t, "Body", aiRouting.Targets[0].ModelIn, "Model location of the target provider mismatch.")
+	assert.Equal(t, "model", aiRouting.Targets[0].ModelValue, "Model field of the target provider mismatch.")
+	assert.Equal(t, "api-key", aiRouting.Targets[0].Security.CustomParameters["key"], "Target security mismatch.")
+	assert.Nil(t, aiRouting.Targets[1].Security, "Targets without backend security should not have security.")
+	assert.Equal(t, "default/local-backend", aiRouting.Targets[2].BackendName, "Backend name mismatch.")
+	assert.Equal(t, "llm.default.svc", aiRouting.Targets[2].EndpointCluster.Endpoints[0].Host, "Endpoint host mismatch.")
+	assert.Equal(t, []string{"ServerError", "RateLimited", "Timeout", "ConnectFailure"}, aiRouting.FailoverOn,
+		"All the failover conditions should be used by default.")
+
+	modelChains, defaultChain := aiRouting.GetFailoverChains()
+	assert.Equal(t, []int{0, 1, 2}, modelChains["gpt-4o"], "Failover chain of gpt-4o mismatch.")
+	assert.Equal(t, []int{1, 2}, modelChains["gpt-4o-mini"], "Failover chain of gpt-4o-mini mismatch.")
+	assert.Equal(t, []int{2}, defaultChain, "Targets without models should serve the other models.")
+
+	_, err = parseAIRoutingToInternal(concatAPIPolicies(apiPolicy, nil), "default", backendMapping, nil)
+	assert.NotNil(t, err, "Unresolved AI providers of the AI routing policy should not be accepted.")
+
+	backendMapping["default/local-backend"].Security = dpv1alpha2.ResolvedSecurityConfig{Type: "OAuth2"}
+	_, err = parseAIRoutingToInternal(concatAPIPolicies(apiPolicy, nil), "default", backendMapping, aiProviders)
+	assert.NotNil(t, err, "OAuth2 security of the AI routing targets should not be accepted.")
+
+	delete(backendMapping, "default/openai-backend")
+	_, err = parseAIRoutingToInternal(concatAPIPolicies(apiPolicy, nil), "default", backendMapping, aiProviders)
+	assert.NotNil(t, err, "Unresolved backends of the AI routing policy should not be accepted.")
+}
+
+func TestParseAIGuardrailsToInternal(t *testing.T) {
+	maxPromptLength := uint32(4000)
+	apiPolicy := &dpv1alpha3.APIPolicy{
+		Spec: dpv1alpha3.APIPolicySpec{
+			Default: &dpv1alpha3.PolicySpec{
+				AIGuardrails: &dpv1alpha3.AIGuardrailsPolicy{
+					DenyPatterns: []string{`(?i)ignore (all )?previous instructions`},
+					DenyKeywords: []string{"password"},
+					PIIDetection: &dpv1alpha3.AIPIIDetection{
+						Entities: []dpv1alpha3.AIPIIEntity{dpv1alpha3.AIPIIEmail, dpv1alpha3.AIPIICardNumber},
+					},
+					MaxPromptLength: &maxPromptLength,
+				},
+			},
+		},
+	}
+
+	aiGuardrails, err := parseAIGuardrailsToInternal(concatAPIPolicies(apiPolicy, nil))
+	assert.Nil(t, err, "Error while parsing the AI guardrails policy")
+	assert.Equal(t, []string{"Email", "CardNumber"}, aiGuardrails.PIIEntities, "PII entities mismatch.")
+	assert.Equal(t, "Redact", aiGuardrails.PIIAction, "PII should be redacted by default.")
+	assert.Equal(t, uint32(4000), aiGuardrails.MaxPromptLength, "Max prompt length mismatch.")
+	assert.True(t, aiGuardrails.HasResponseChecks(), "Deny lists should be applied to the responses.")
+
+	apiPolicy.Spec.Default.AIGuardrails = &dpv1alpha3.AIGuardrailsPolicy{
+		MaxPromptLength: &maxPromptLength,
+		ResponseSchema:  &dpv1alpha3.AIResponseSchema{Schema: `{"type": "object", "required": ["answer"]}`},
+	}
+	aiGuardrails, err = parseAIGuardrailsToInternal(concatAPIPolicies(apiPolicy, nil))
+	assert.Nil(t, err, "Error while parsing the AI guardrails policy")
+	assert.Equal(t, "$.choices[0].message.content", aiGuardrails.ResponseContentPath,
+		"The chat completion content should be validated by default.")
+
+	apiPolicy.Spec.Default.AIGuardrails.ResponseSchema = nil
+	aiGuardrails, err = parseAIGuardrailsToInternal(concatAPIPolicies(apiPolicy, nil))
+	assert.Nil(t, err, "Error while parsing the AI guardrails policy")
+	assert.False(t, aiGuardrails.HasResponseChecks(), "The prompt length should not be checked on the responses.")
+
+	apiPolicy.Spec.Default.AIGuardrails.DenyPatterns = []string{"(unclosed"}
+	_, err = parseAIGuardrailsToInternal(concatAPIPolicies(apiPolicy, nil))
+	assert.NotNil(t, err, "Invalid deny patterns should not be accepted.")
+
+	apiPolicy.Spec.Default.AIGuardrails = &dpv1alpha3.AIGuardrailsPolicy{
+		ResponseSchema: &dpv1alpha3.AIResponseSchema{Schema: "not a schema"},
+	}
+	_, err = parseAIGuardrailsToInternal(concatAPIPolicies(apiPolicy, nil))
+	assert.NotNil(t, err, "Invalid response schemas should not be accepted.")
+
+	apiPolicy.Spec.Default.AIGuardrails.ResponseSchema.Schema = `{"properties": {"answer": {"$ref": "#/definitions/answer"}},
+		"definitions": {"answer": {"type": "string"}}}`
+	_, err = parseAIGuardrailsToInternal(concatAPIPolicies(apiPolicy, nil))
+	assert.Nil(t, err, "Response schemas referring to their own definitions should be accepted.")
+	apiPolicy.Spec.Default.AIGuardrails.ResponseSchema.Schema = `{"allOf": [{"$ref": "http://example.com/schema.json"}]}`
+	_, err = parseAIGuardrailsToInternal(concatAPIPolicies(apiPolicy, nil))
+	assert.NotNil(t, err, "Response schemas referring to external schemas should not be accepted.")
+}
+
+func TestParseAIResponseCacheToInternal(t *testing.T) {
+	apiPolicy := &dpv1alpha3.APIPolicy{
+		Spec: dpv1alpha3.APIPolicySpec{
+			Default: &dpv1alpha3.PolicySpec{
+				AIResponseCache: &dpv1alpha3.AIResponseCachePolicy{
+					Enabled:      true,
+					TTLSeconds:   60,
+					BypassHeader: "X-No-Cache",
+				},
+			},
+		},
+	}
+
+	aiResponseCache := parseAIResponseCacheToInternal(concatAPIPolicies(apiPolicy, nil), "org1", "api-uuid")
+	assert.Equal(t, "org1", aiResponseCache.Organization, "Organization mismatch.")
+	assert.Equal(t, "api-uuid", aiResponseCache.APIUUID, "API UUID mismatch.")
+	assert.Equal(t, uint32(60), aiResponseCache.TTLSeconds, "TTL mismatch.")
+	assert.Equal(t, uint32(1048576), aiResponseCache.MaxEntrySize, "Default max entry size mismatch.")
+	assert.Equal(t, "x-no-cache", aiResponseCache.BypassHeader, "Bypass header should be lower cased.")
+
+	apiPolicy.Spec.Default.AIResponseCache.Enabled = false
+	assert.Nil(t, parseAIResponseCacheToInternal(concatAPIPolicies(apiPolicy, nil), "org1", "api-uuid"),
+		"Disabled cache should not be applied.")
+}
+
+func TestParseCachingPolicyToInternal(t *testing.T) {
+	apiPolicy := &dpv1alpha3.APIPolicy{
+		Spec: dpv1alpha3.APIPolicySpec{
+			Default: &dpv1alpha3.PolicySpec{
+				Caching: &dpv1alpha3.CachingPolicy{
+					Enabled:             true,
+					VaryByHeaders:       []string{"Accept-Language"},
+					VaryByQueryParams:   []string{"page"},
+					VaryByConsumer:      true,
+					RespectCacheControl: true,
+				},
+			},
+		},
+	}
+
+	unsecured := &Authentication{Disabled: true}
+	responseCache := parseCachingPolicyToInternal(concatAPIPolicies(apiPolicy, nil), "org1", "api-uuid", unsecured)
+	assert.Equal(t, "org1", responseCache.Organization, "Organization mismatch.")
+	assert.Equal(t, "api-uuid", responseCache.APIUUID, "API UUID mismatch.")
+	assert.Equal(t, uint32(300), responseCache.TTLSeconds, "Default TTL mismatch.")
+	assert.Equal(t, "InMemory", responseCache.Store, "Default store mismatch.")
+	assert.Equal(t, uint32(1048576), responseCache.MaxEntrySize, "Default max entry size mismatch.")
+	assert.Equal(t, []string{"accept-language"}, responseCache.VaryByHeaders, "Vary by headers should be lower cased.")
+	assert.Equal(t, []string{"page"}, responseCache.VaryByQueryParams, "Vary by query params mismatch.")
+	assert.True(t, responseCache.VaryByConsumer, "Vary by consumer mismatch.")
+
+	apiPolicy.Spec.Default.Caching.VaryByConsumer = false
+	responseCache = parseCachingPolicyToInternal(concatAPIPolicies(apiPolicy, nil), "org1", "api-uuid", unsecured)
+	assert.False(t, responseCache.VaryByConsumer, "Vary by consumer mismatch.")
+	responseCache = parseCachingPolicyToInternal(concatAPIPolicies(apiPolicy, nil), "org1", "api-uuid",
+		getSecurity(nil, nil))
+	assert.True(t, responseCache.VaryByConsumer, "The responses of secured operations should vary by consumer.")
+
+	apiPolicy.Spec.Default.Caching.Enabled = false
+	assert.Nil(t, parseCachingPolicyToInternal(concatAPIPolicies(apiPolicy, nil), "org1", "api-uuid", unsecured),
+		"Disabled cache should not be applied.")
+}
+
+func TestParseSchemaValidationToInternal(t *testing.T) {
+	apiPolicy := &dpv1alpha3.APIPolicy{
+		Spec: dpv1alpha3.APIPolicySpec{
+			Default: &dpv1alpha3.PolicySpec{
+				SchemaValidation: &dpv1alpha3.SchemaValidationPolicy{
+					Enabled: true,
+				},
+			},
+		},
+	}
+	definition := []byte("openapi: 3.0.1\ninfo:\n  title: Pets\npaths:\n  /pets:\n    get: {}\n")
+
+	schemaValidation, err := parseSchemaValidationToInternal(concatAPIPolicies(apiPolicy, nil), definition)
+	assert.Nil(t, err)
+	assert.NotNil(t, schemaValidation, "Schema validation should be applied.")
+	assert.False(t, schemaValidation.ValidateResponses, "Responses should not be validated by default.")
+
+	apiPolicy.Spec.Default.SchemaValidation.ValidateResponses = true
+	var compressedDefinition bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressedDefinition)
+	_, err = gzipWriter.Write([]byte(`{"openapi":"3.1.0","paths":{"/pets":{"get":{}}}}`))
+	assert.Nil(t, err)
+	assert.Nil(t, gzipWriter.Close())
+	schemaValidation, err = parseSchemaValidationToInternal(concatAPIPolicies(apiPolicy, nil),
+		compressedDefinition.Bytes())
+	assert.Nil(t, err, "Compressed JSON definitions should be accepted.")
+	assert.True(t, schemaValidation.ValidateResponses, "Validate responses mismatch.")
+
+	for name, invalidDefinition := range map[string][]byte{
+		"missing":  nil,
+		"swagger":  []byte("swagger: \"2.0\"\npaths:\n  /pets:\n    get: {}\n"),
+		"no paths": []byte(`{"openapi":"3.0.0","paths":{}}`),
+		"invalid":  []byte("openapi: [3"),
+	} {
+		_, err = parseSchemaValidationToInternal(concatAPIPolicies(apiPolicy, nil), invalidDefinition)
+		assert.NotNil(t, err, "The %s definition should be rejected.", name)
+	}
+
+	apiPolicy.Spec.Default.SchemaValidation.Enabled = false
+	schemaValidation, err = parseSchemaValidationToInternal(concatAPIPolicies(apiPolicy, nil), nil)
+	assert.Nil(t, err)
+	assert.Nil(t, schemaValidation, "Disabled schema validation should not be applied.")
+	schemaValidation, err = parseSchemaValidationToInternal(nil, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, schemaValidation, "APIs without a policy should not be validated.")
+}
+
+func TestAddOperationLevelBodyTransformations(t *testing.T) {
+	apiPolicy := &dpv1alpha3.APIPolicy{
+		Spec: dpv1alpha3.APIPolicySpec{
+			Default: &dpv1alpha3.PolicySpec{
+				BodyTransformation: &dpv1alpha3.BodyTransformationPolicy{
+					Request: &dpv1alpha3.BodyTransformation{
+						Conversion:   "JSONToXML",
+						RemoveFields: []string{"$.internal"},
+						RenameFields: []dpv1alpha3.BodyFieldRename{{From: "$.items[*].id", To: "itemId"}},
+					},
+					Response: &dpv1alpha3.BodyTransformation{
+						ContentType: "application/xml",
+					},
+				},
+			},
+		},
+	}
+
+	policies := OperationPolicies{}
+	addOperationLevelBodyTransformations(&policies, concatAPIPolicies(apiPolicy, nil))
+	assert.Len(t, policies.Request, 1, "Request body transformation should be added.")
+	assert.Empty(t, policies.Response, "Response body transformation without steps should not be added.")
+	assert.Equal(t, constants.ActionTransformBody, policies.Request[0].Action, "Action mismatch.")
+	bodyTransformation := policies.Request[0].Parameters.(map[string]interface{})[constants.BodyTransformation].(*BodyTransformation)
+	assert.Equal(t, "JSONToXML", bodyTransformation.Conversion, "Conversion mismatch.")
+	assert.Equal(t, "root", bodyTransformation.XMLRootElement, "XML root element should default to root.")
+	assert.Equal(t, "application/xml", bodyTransformation.ContentType, "Content type should default to XML.")
+	assert.Equal(t, []string{"$.internal"}, bodyTransformation.RemoveFields, "Remove fields mismatch.")
+	assert.Equal(t, []BodyFieldRename{{From: "$.items[*].id", To: "itemId"}}, bodyTransformation.RenameFields,
+		"Rename fields mismatch.")
+
+	apiPolicy.Spec.Default.BodyTransformation.Response = &dpv1alpha3.BodyTransformation{
+		Conversion: "XMLToJSON",
+		AddFields:  []dpv1alpha3.BodyField{{Path: "$.source", Value: `"gateway"`}},
+	}
+	policies = OperationPolicies{}
+	addOperationLevelBodyTransformations(&policies, concatAPIPolicies(apiPolicy, nil))
+	assert.Len(t, policies.Response, 1, "Response body transformation should be added.")
+	bodyTransformation = policies.Response[0].Parameters.(map[string]interface{})[constants.BodyTransformation].(*BodyTransformation)
+	assert.Equal(t, "application/json", bodyTransformation.ContentType, "Content type should default to JSON.")
+	assert.Empty(t, bodyTransformation.XMLRootElement, "XML root element should only be set for XML payloads.")
+	assert.Equal(t, []BodyField{{Path: "$.source", Value: `"gateway"`}}, bodyTransformation.AddFields,
+		"Add fields mismatch.")
+
+	policies = OperationPolicies{}
+	addOperationLevelBodyTransformations(&policies, nil)
+	assert.Empty(t, policies.Request, "APIs without a policy should not be transformed.")
+}
+
+func TestAddOperationLevelSOAPMediation(t *testing.T) {
+	wsdl := `<?xml version="1.0" encoding="utf-8"?>
+<wsdl:definitions xmlns:wsdl="http://schemas.xmlsoap.org/wsdl/" xmlns:soap="http://schemas.xmlsoap.org/wsdl/soap/"
+	xmlns:soap12="http://schemas.xmlsoap.org/wsdl/soap12/" xmlns:tns="http://tempuri.org/"
+	xmlns:s0="http://tempuri.org/types" targetNamespace="http://tempuri.org/">
+	<wsdl:message name="AddSoapIn">
+		<wsdl:part name="parameters" element="s0:Add"/>
+	</wsdl:message>
+	<wsdl:portType name="CalculatorSoap">
+		<wsdl:operation name="Add">
+			<wsdl:input message="tns:AddSoapIn"/>
+		</wsdl:operation>
+	</wsdl:portType>
+	<wsdl:binding name="CalculatorSoap12" type="tns:CalculatorSoap">
+		<soap12:binding transport="http://schemas.xmlsoap.org/soap/http"/>
+		<wsdl:operation name="Add">
+			<soap12:operation soapAction="http://tempuri.org/Add12" style="document"/>
+		</wsdl:operation>
+	</wsdl:binding>
+	<wsdl:binding name="CalculatorSoap" type="tns:CalculatorSoap">
+		<soap:binding transport="http://schemas.xmlsoap.org/soap/http"/>
+		<wsdl:operation name="Add">
+			<soap:operation soapAction="http://tempuri.org/Add" style="document"/>
+		</wsdl:operation>
+	</wsdl:binding>
+</wsdl:definitions>`
+	wsdls := map[string][]byte{"default/calculator-wsdl": []byte(wsdl)}
+	apiPolicy := &dpv1alpha3.APIPolicy{
+		Spec: dpv1alpha3.APIPolicySpec{
+			Default: &dpv1alpha3.PolicySpec{
+				SOAPMediation: &dpv1alpha3.SOAPMediationPolicy{
+					WSDLRef: "calculator-wsdl",
+					Operations: []dpv1alpha3.SOAPOperationMapping{
+						{Path: "/add", Operation: "Add"},
+						{Path: "/sum", Operation: "Add", SOAPAction: "urn:Sum", EnvelopeTemplate: "<a>{{.a}}</a>"},
+					},
+				},
+			},
+		},
+	}
+	policies := OperationPolicies{Request: PolicyList{{Action: constants.ActionHeaderAdd}}}
+
+	mediatedPolicies, err := addOperationLevelSOAPMediation(policies, concatAPIPolicies(apiPolicy, nil), "/add",
+		"default", wsdls)
+	assert.Nil(t, err, "Error while adding the SOAP mediation")
+	assert.Len(t, mediatedPolicies.Request, 2, "SOAP mediation should be added.")
+	assert.Len(t, policies.Request, 1, "Shared policies of the rule should not be modified.")
+	assert.Equal(t, constants.ActionMediateSOAP, mediatedPolicies.Request[1].Action, "Action mismatch.")
+	soapMediation := mediatedPolicies.Request[1].Parameters.(map[string]interface{})[constants.SOAPMediation].(*SOAPMediation)
+	assert.Equal(t, &SOAPMediation{
+		SOAPVersion:  SOAP11,
+		SOAPAction:   "http://tempuri.org/Add",
+		Namespace:    "http://tempuri.org/types",
+		InputElement: "Add",
+	}, soapMediation, "SOAP 1.1 binding should be preferred when the version is not given.")
+
+	mediatedPolicies, err = addOperationLevelSOAPMediation(policies, concatAPIPolicies(apiPolicy, nil), "/sum",
+		"default", wsdls)
+	assert.Nil(t, err, "Error while adding the SOAP mediation")
+	soapMediation = mediatedPolicies.Request[1].Parameters.(map[string]interface{})[constants.SOAPMediation].(*SOAPMediation)
+	assert.Equal(t, "urn:Sum", soapMediation.SOAPAction, "SOAP action should be overridden.")
+	assert.Equal(t, "<a>{{.a}}</a>", soapMediation.EnvelopeTemplate, "Envelope template mismatch.")
+
+	mediatedPolicies, err = addOperationLevelSOAPMediation(policies, concatAPIPolicies(apiPolicy, nil), "/divide",
+		"default", wsdls)
+	assert.Nil(t, err, "Resources without a mapping should not fail.")
+	assert.Len(t, mediatedPolicies.Request, 1, "Resources without a mapping should not be mediated.")
+
+	apiPolicy.Spec.Default.SOAPMediation.SOAPVersion = SOAP12
+	apiPolicy.Spec.Default.SOAPMediation.Operations = []dpv1alpha3.SOAPOperationMapping{{Operation: "Add"}}
+	mediatedPolicies, err = addOperationLevelSOAPMediation(policies, concatAPIPolicies(apiPolicy, nil), "/divide",
+		"default", wsdls)
+	assert.Nil(t, err, "Error while adding the SOAP mediation")
+	soapMediation = mediatedPolicies.Request[1].Parameters.(map[string]interface{})[constants.SOAPMediation].(*SOAPMediation)
+	assert.Equal(t, SOAP12, soapMediation.SOAPVersion, "SOAP version mismatch.")
+	assert.Equal(t, "http://tempuri.org/Add12", soapMediation.SOAPAction, "SOAP 1.2 binding should be used.")
+
+	apiPolicy.Spec.Default.SOAPMediation.Operations = []dpv1alpha3.SOAPOperationMapping{{Operation: "Multiply"}}
+	_, err = addOperationLevelSOAPMediation(policies, concatAPIPolicies(apiPolicy, nil), "/add", "default", wsdls)
+	assert.NotNil(t, err, "Operations missing in the WSDL should be rejected.")
+	_, err = addOperationLevelSOAPMediation(policies, concatAPIPolicies(apiPolicy, nil), "/add", "default", nil)
+	assert.NotNil(t, err, "Unresolved WSDLs should be rejected.")
+}
+
+func TestParseAITransformationToInternal(t *testing.T) {
+	transformation := parseAITransformationToInternal(&dpv1alpha3.AIProviderTransformation{Format: "Anthropic"})
+	assert.Equal(t, "Anthropic", transformation.Format, "Format mismatch.")
+	assert.Equal(t, uint32(1024), transformation.DefaultMaxTokens, "Default max tokens mismatch.")
+
+	transformation = parseAITransformationToInternal(&dpv1alpha3.AIProviderTransformation{Format: "Bedrock",
+		DefaultMaxTokens: 512, APIVersion: "v2"})
+	assert.Equal(t, uint32(512), transformation.DefaultMaxTokens, "Max tokens mismatch.")
+	assert.Equal(t, "v2", transformation.APIVersion, "API version mismatch.")
+
+	assert.Nil(t, parseAITransformationToInternal(&dpv1alpha3.AIProviderTransformation{Format: "OpenAI"}),
+		"OpenAI providers should not be transformed.")
+	assert.Nil(t, parseAITransformationToInternal(nil), "Providers without a transformation should not be transformed.")
+}
+
+func TestGetGQLSubscriptions(t *testing.T) {
+	rateLimitPolicy := &RateLimitPolicy{Count: 5, SpanUnit: "Minute"}
+	query := &Resource{path: "books", methods: []*Operation{{method: "QUERY"}}}
+	subscription := &Resource{path: "bookAdded",
+		methods: []*Operation{{method: constants.GQLSubscription, rateLimitPolicy: rateLimitPolicy}}}
+	adapterInternalAPI := AdapterInternalAPI{resources: []*Resource{query, subscription}}
+
+	subscriptions := adapterInternalAPI.GetGQLSubscriptions()
+	assert.Equal(t, []*Resource{subscription}, subscriptions, "Only the subscription fields should be returned.")
+	assert.Equal(t, rateLimitPolicy, subscriptions[0].GetMethod()[0].GetRateLimitPolicy(),
+		"Subscription field rate limit mismatch.")
+
+	adapterInternalAPI.resources = []*Resource{query}
+	assert.Empty(t, adapterInternalAPI.GetGQLSubscriptions(), "An API without subscriptions should have none.")
+}
+
+func TestParseGraphQLProtectionToInternal(t *testing.T) {
+	schema := []byte(`directive @cost(weight: Int!) on FIELD_DEFINITION
+type Query {
+	books(first: Int): [Book] @cost(weight: 5)
+	authors: [Author]
+}
+type Book {
+	title: String
+	reviews: [String] @cost(weight: 3)
+}
+type Author {
+	name: String
+}`)
+	maxDepth := uint32(4)
+	maxComplexity := uint32(100)
+	apiPolicy := &dpv1alpha3.APIPolicy{
+		Spec: dpv1alpha3.APIPolicySpec{
+			Default: &dpv1alpha3.PolicySpec{
+				GraphQLProtection: &dpv1alpha3.GraphQLProtectionPolicy{
+					MaxDepth:             &maxDepth,
+					DisableIntrospection: true,
+				},
+			},
+		},
+	}
+
+	graphQLProtection, err := parseGraphQLProtectionToInternal(concatAPIPolicies(apiPolicy, nil), schema)
+	assert.Nil(t, err, "Parsing the GraphQL protection should not fail.")
+	assert.Equal(t, maxDepth, *graphQLProtection.MaxDepth, "Max depth mismatch.")
+	assert.Nil(t, graphQLProtection.MaxAliases, "Max aliases should not be enforced when it is not set.")
+	assert.True(t, graphQLProtection.DisableIntrospection, "Introspection should be disabled.")
+	assert.Empty(t, graphQLProtection.FieldCosts, "Field costs should not be parsed without a complexity limit.")
+
+	apiPolicy.Spec.Default.GraphQLProtection.MaxComplexity = &maxComplexity
+	apiPolicy.Spec.Default.GraphQLProtection.FieldCosts = []dpv1alpha3.GraphQLFieldCost{
+		{Type: "Query", Field: "authors", Cost: 2},
+		{Type: "Book", Field: "reviews", Cost: 1},
+	}
+	graphQLProtection, err = parseGraphQLProtectionToInternal(concatAPIPolicies(apiPolicy, nil), schema)
+	assert.Nil(t, err, "Parsing the GraphQL protection should not fail.")
+	assert.Equal(t, []GraphQLFieldCost{{Type: "Query", Field: "books", Cost: 5},
+		{Type: "Query", Field: "authors", Cost: 2}}, graphQLProtection.FieldCosts,
+		"Field costs should be taken from the schema and overridden by the policy.")
+
+	apiPolicy.Spec.Default.GraphQLProtection.FieldCosts = []dpv1alpha3.GraphQLFieldCost{
+		{Type: "Query", Field: "publishers", Cost: 2},
+	}
+	_, err = parseGraphQLProtectionToInternal(concatAPIPolicies(apiPolicy, nil), schema)
+	assert.NotNil(t, err, "Costs of the fields which are not in the schema should be rejected.")
+
+	graphQLProtection, err = parseGraphQLProtectionToInternal(nil, schema)
+	assert.Nil(t, err, "Parsing the GraphQL protection should not fail.")
+	assert.Nil(t, graphQLProtection, "APIs without a policy should not be protected.")
+}
+
+func TestGetEndpointCluster(t *testing.T) {
+	backendName := types.NamespacedName{Namespace: "default", Name: "orders"}
+	backendMapping := map[string]*dpv1alpha2.ResolvedBackend{
+		"default/orders": {Services: []dpv1alpha2.Service{{Host: "orders.default", Port: 80}},
+			CircuitBreaker:   &dpv1alpha2.CircuitBreaker{MaxConnections: 10},
+			Timeout:          &dpv1alpha2.Timeout{UpstreamResponseTimeout: 2, DownstreamRequestIdleTimeout: 60},
+			Retry:            &dpv1alpha2.RetryConfig{Count: 2, BaseIntervalMillis: 100, StatusCodes: []uint32{502}},
+			HealthCheck:      &dpv1alpha2.HealthCheck{Interval: 5, Timeout: 1},
+			OutlierDetection: &dpv1alpha2.OutlierDetection{Consecutive5xxErrors: 3},
+			LoadBalancing:    &dpv1alpha2.LoadBalancing{Policy: dpv1alpha2.LeastRequestLoadBalancing}},
+		"default/inventory": {Services: []dpv1alpha2.Service{{Host: "inventory.default", Port: 80}}},
+	}
+
+	endpointCluster := getEndpointCluster(backendName, backendMapping, []uint32{503})
+	assert.Equal(t, 1, len(endpointCluster.Endpoints))
+	assert.Equal(t, int32(10), endpointCluster.Config.CircuitBreakers.MaxConnections)
+	assert.Equal(t, uint32(2000), endpointCluster.Config.TimeoutInMillis)
+	assert.Equal(t, uint32(60), endpointCluster.Config.IdleTimeoutInSeconds)
+	assert.Equal(t, &RetryConfig{Count: 2, BaseIntervalInMillis: 100, StatusCodes: []uint32{502}},
+		endpointCluster.Config.RetryConfig)
+	assert.Equal(t, uint32(5), endpointCluster.HealthCheck.Interval)
+	assert.Equal(t, uint32(3), endpointCluster.OutlierDetection.Consecutive5xxErrors)
+	assert.Equal(t, string(dpv1alpha2.LeastRequestLoadBalancing), endpointCluster.LoadBalancing.Policy)
+
+	endpointCluster = getEndpointCluster(types.NamespacedName{Namespace: "default", Name: "inventory"}, backendMapping,
+		[]uint32{503})
+	assert.Nil(t, endpointCluster.Config, "A backend without configurations should not have an endpoint config.")
+	assert.Nil(t, endpointCluster.LoadBalancing)
+}
+
+func TestAddRuleEndpointCluster(t *testing.T) {
+	stable := &EndpointCluster{Endpoints: []Endpoint{{Host: "orders-v1.default"}},
+		Config: &EndpointConfig{TimeoutInMillis: 5000, CircuitBreakers: &CircuitBreakers{MaxRequests: 100}}}
+	canary := &EndpointCluster{Endpoints: []Endpoint{{Host: "orders-v2.default"}},
+		Config: &EndpointConfig{TimeoutInMillis: 30000, CircuitBreakers: &CircuitBreakers{MaxRequests: 5}}}
+
+	ruleEndpointCluster := &EndpointCluster{}
+	addRuleEndpointCluster(ruleEndpointCluster, stable, "default/orders-v1", "")
+	addRuleEndpointCluster(ruleEndpointCluster, canary, "default/orders-v2", "default/orders-v1")
+	assert.Equal(t, 2, len(ruleEndpointCluster.Endpoints))
+	assert.Equal(t, uint32(5000), ruleEndpointCluster.Config.TimeoutInMillis,
+		"Backends with different timeouts should share the traffic of a rule with the timeout of the first backend.")
+	assert.Equal(t, int32(5), canary.Config.CircuitBreakers.MaxRequests,
+		"Each backend should keep its own circuit breakers.")
+}
+
+func TestGetGRPCRuleEndpoints(t *testing.T) {
+	backendRef := func(name string, weight int32) gwapiv1.GRPCBackendRef {
+		return gwapiv1.GRPCBackendRef{BackendRef: gwapiv1.BackendRef{
+			BackendObjectReference: gwapiv1.BackendObjectReference{Name: gwapiv1.ObjectName(name)}, Weight: &weight}}
+	}
+	backendMapping := map[string]*dpv1alpha2.ResolvedBackend{
+		"default/orders-v1": {Services: []dpv1alpha2.Service{{Host: "orders-v1.default", Port: 50051}},
+			Timeout: &dpv1alpha2.Timeout{UpstreamResponseTimeout: 5},
+			Retry:   &dpv1alpha2.RetryConfig{Count: 3}},
+		"default/orders-v2": {Services: []dpv1alpha2.Service{{Host: "orders-v2.default", Port: 50051}},
+			Timeout:        &dpv1alpha2.Timeout{UpstreamResponseTimeout: 30},
+			CircuitBreaker: &dpv1alpha2.CircuitBreaker{MaxRequests: 10}},
+		"default/payments": {Services: []dpv1alpha2.Service{{Host: "payments.default", Port: 50051}}, BasePath: "/payments"},
+	}
+
+	ruleEndpoints, err := getGRPCRuleEndpoints([]gwapiv1.GRPCBackendRef{backendRef("orders-v1", 1)}, "default",
+		backendMapping)
+	assert.Nil(t, err)
+	assert.Nil(t, ruleEndpoints.weightedEndpoints, "Traffic should not be split for a single backend.")
+	assert.Equal(t, uint32(5000), ruleEndpoints.endpoints.Config.TimeoutInMillis)
+	assert.Equal(t, config.ReadConfigs().Envoy.Upstream.Retry.StatusCodes,
+		ruleEndpoints.endpoints.Config.RetryConfig.StatusCodes)
+
+	ruleEndpoints, err = getGRPCRuleEndpoints([]gwapiv1.GRPCBackendRef{backendRef("orders-v1", 90),
+		backendRef("orders-v2", 10)}, "default", backendMapping)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(ruleEndpoints.weightedEndpoints))
+	assert.Equal(t, uint32(90), ruleEndpoints.weightedEndpoints[0].Weight)
+	assert.Nil(t, ruleEndpoints.weightedEndpoints[0].EndpointCluster.Config.CircuitBreakers,
+		"Each backend should keep its own circuit breakers.")
+	assert.Equal(t, int32(10), ruleEndpoints.weightedEndpoints[1].EndpointCluster.Config.CircuitBreakers.MaxRequests)
+	assert.Equal(t, 2, len(ruleEndpoints.endpoints.Endpoints))
+	assert.Equal(t, uint32(5000), ruleEndpoints.endpoints.Config.TimeoutInMillis,
+		"The timeout of the first backend should apply to the rule.")
+
+	_, err = getGRPCRuleEndpoints([]gwapiv1.GRPCBackendRef{backendRef("orders-v1", 0), backendRef("orders-v2", 0)},
+		"default", backendMapping)
+	assert.NotNil(t, err, "A rule should not be accepted when all its backends have a zero weight.")
+	_, err = getGRPCRuleEndpoints([]gwapiv1.GRPCBackendRef{backendRef("orders-v1", 1), backendRef("payments", 1)},
+		"default", backendMapping)
+	assert.NotNil(t, err, "Backends with different base paths should not share the traffic of a rule.")
+	_, err = getGRPCRuleEndpoints([]gwapiv1.GRPCBackendRef{backendRef("inventory", 1)}, "default", backendMapping)
+	assert.NotNil(t, err, "A rule should not be accepted when its backend is not resolved.")
+	_, err = getGRPCRuleEndpoints(nil, "default", backendMapping)
+	assert.NotNil(t, err, "A rule should not be accepted without backends.")
+}
+
+func TestParseGRPCTranscodingToInternal(t *testing.T) {
+	getStudentOptions := &descriptorpb.MethodOptions{}
+	proto.SetExtension(getStudentOptions, annotations.E_Http, &annotations.HttpRule{
+		Pattern: &annotations.HttpRule_Get{Get: "/v1/students/{id}"},
+		AdditionalBindings: []*annotations.HttpRule{{
+			Pattern: &annotations.HttpRule_Custom{Custom: &annotations.CustomHttpPattern{Kind: "head", Path: "/v1/students/{id}"}},
+		}},
+	})
+	descriptorSet, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:    proto.String("student.proto"),
+		Package: proto.String("org.apk.v1.student_service"),
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("StudentService"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{Name: proto.String("GetStudent"), Options: getStudentOptions},
+				{Name: proto.String("GetStudentStream")},
+			},
+		}},
+	}}})
+	assert.Nil(t, err)
+	descriptorSets := map[string][]byte{"default/student-descriptor": descriptorSet}
+	apiPolicy := &dpv1alpha3.APIPolicy{Spec: dpv1alpha3.APIPolicySpec{Override: &dpv1alpha3.PolicySpec{
+		GRPCTranscoding: &dpv1alpha3.GRPCTranscodingPolicy{DescriptorSetRef: "student-descriptor",
+			PreserveProtoFieldNames: true},
+	}}}
+	resources := []*Resource{
+		{path: "/org.apk.v1.student_service.StudentService/GetStudent"},
+		{path: "/org.apk.v1.student_service.StudentService/GetStudentStream"},
+	}
+
+	grpcTranscoding, err := parseGRPCTranscodingToInternal(apiPolicy, "default", "/org.apk.v1/", descriptorSets,
+		resources)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"org.apk.v1.student_service.StudentService"}, grpcTranscoding.Services)
+	assert.True(t, grpcTranscoding.PreserveProtoFieldNames)
+	assert.Equal(t, []GRPCHTTPBinding{
+		{HTTPMethod: "GET", PathTemplate: "/org.apk.v1/v1/students/{id}", GRPCPath: resources[0].path},
+		{HTTPMethod: "HEAD", PathTemplate: "/org.apk.v1/v1/students/{id}", GRPCPath: resources[0].path},
+	}, grpcTranscoding.Bindings, "Methods without an annotation should not be bound to REST resources.")
+
+	// The transcoder matches the requests against the annotations, which should have the base path of the API
+	_, methods, err := parseGRPCMethodDescriptors(grpcTranscoding.DescriptorSet)
+	assert.Nil(t, err)
+	httpRule := proto.GetExtension(methods[resources[0].path].GetOptions(), annotations.E_Http).(*annotations.HttpRule)
+	assert.Equal(t, "/org.apk.v1/v1/students/{id}", httpRule.GetGet())
+	assert.Equal(t, "/org.apk.v1/v1/students/{id}", httpRule.GetAdditionalBindings()[0].GetCustom().GetPath())
+
+	grpcTranscoding, err = parseGRPCTranscodingToInternal(nil, "default", "/org.apk.v1", descriptorSets, resources)
+	assert.Nil(t, err)
+	assert.Nil(t, grpcTranscoding)
+	_, err = parseGRPCTranscodingToInternal(apiPolicy, "default", "/org.apk.v1", descriptorSets,
+		append(resources, &Resource{path: "/org.apk.v1.student_service.StudentService/DeleteStudent"}))
+	assert.NotNil(t, err, "Methods which are not in the descriptor set should not be accepted.")
+	_, err = parseGRPCTranscodingToInternal(apiPolicy, "default", "/org.apk.v1", descriptorSets, resources[1:])
+	assert.NotNil(t, err, "A transcoding policy should not be accepted when no method has an annotation.")
+	_, err = parseGRPCTranscodingToInternal(apiPolicy, "apk", "/org.apk.v1", descriptorSets, resources)
+	assert.NotNil(t, err, "A descriptor set which is not resolved should not be accepted.")
+	_, err = parseGRPCTranscodingToInternal(apiPolicy, "default", "org.apk.v1", descriptorSets, resources)
+	assert.NotNil(t, err, "A base path which does not start with / should not be accepted.")
+}
+
+func TestAddGRPCWebCorsHeaders(t *testing.T) {
+	allowHeaders := []string{"authorization", "Content-Type"}
+	corsConfig := &CorsConfig{
+		Enabled:                    true,
+		AccessControlAllowHeaders:  allowHeaders,
+		AccessControlExposeHeaders: []string{"*"},
+	}
+	grpcWebCorsConfig := addGRPCWebCorsHeaders(corsConfig)
+	assert.True(t, grpcWebCorsConfig.Enabled)
+	assert.Equal(t, []string{"authorization", "Content-Type", "x-grpc-web", "x-user-agent", "grpc-timeout"},
+		grpcWebCorsConfig.AccessControlAllowHeaders, "Content-Type should not be added twice.")
+	assert.Equal(t, []string{"*", "grpc-status", "grpc-message", "grpc-status-details-bin"},
+		grpcWebCorsConfig.AccessControlExposeHeaders)
+	assert.Equal(t, []string{"authorization", "Content-Type"}, corsConfig.AccessControlAllowHeaders,
+		"CORS configuration of the API policy should not be modified.")
+	assert.Equal(t, []string{"authorization", "Content-Type"}, allowHeaders)
+	assert.Nil(t, addGRPCWebCorsHeaders(nil))
+
+	assert.False(t, isGRPCWebEnabled(nil))
+	assert.True(t, isGRPCWebEnabled(&dpv1alpha3.APIPolicy{Spec: dpv1alpha3.APIPolicySpec{
+		Override: &dpv1alpha3.PolicySpec{GRPCWeb: true}}}))
+}
@@ -41,6 +41,8 @@ type Resource struct {
 	methods                                []*Operation
 	iD                                     string
 	endpoints                              *EndpointCluster
+	weightedEndpoints                      []*WeightedEndpointCluster
+	headerMatches                          []gwapiv1.HTTPHeaderMatch
 	endpointSecurity                       []*EndpointSecurity
 	vendorExtensions                       map[string]interface{}
 	hasPolicies                            bool
@@ -60,6 +62,17 @@ func (resource *Resource) GetEndpoints() *EndpointCluster {
 	return resource.endpoints
 }
 
+// GetWeightedEndpoints returns the per backend endpoint clusters of a given resource. These are
+// populated only when the traffic of the resource is split among more than one backend.
+func (resource *Resource) GetWeightedEndpoints() []*WeightedEndpointCluster {
+	return resource.weightedEndpoints
+}
+
+// GetHeaderMatches returns the request header matches which should be satisfied to route to the resource.
+func (resource *Resource) GetHeaderMatches() []gwapiv1.HTTPHeaderMatch {
+	return resource.headerMatches
+}
+
 // GetPath returns the pathItem name (of openAPI definition) corresponding to a given resource
 func (resource *Resource) GetPath() string {
 	return resource.path
@@ -0,0 +1,181 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package model
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+const (
+	// SOAP11 is the SOAP 1.1 version of a SOAP mediation
+	SOAP11 = "SOAP11"
+	// SOAP12 is the SOAP 1.2 version of a SOAP mediation
+	SOAP12 = "SOAP12"
+)
+
+// wsdlDefinitions holds the parts of a WSDL 1.1 document used to invoke its operations.
+type wsdlDefinitions struct {
+	TargetNamespace string         `xml:"targetNamespace,attr"`
+	Attrs           []xml.Attr     `xml:",any,attr"`
+	Messages        []wsdlMessage  `xml:"http://schemas.xmlsoap.org/wsdl/ message"`
+	PortTypes       []wsdlPortType `xml:"http://schemas.xmlsoap.org/wsdl/ portType"`
+	Bindings        []wsdlBinding  `xml:"http://schemas.xmlsoap.org/wsdl/ binding"`
+}
+
+type wsdlMessage struct {
+	Name  string     `xml:"name,attr"`
+	Parts []wsdlPart `xml:"http://schemas.xmlsoap.org/wsdl/ part"`
+}
+
+type wsdlPart struct {
+	Name    string `xml:"name,attr"`
+	Element string `xml:"element,attr"`
+}
+
+type wsdlPortType struct {
+	Name       string                  `xml:"name,attr"`
+	Operations []wsdlPortTypeOperation `xml:"http://schemas.xmlsoap.org/wsdl/ operation"`
+}
+
+type wsdlPortTypeOperation struct {
+	Name  string `xml:"name,attr"`
+	Input struct {
+		Message string `xml:"message,attr"`
+	} `xml:"http://schemas.xmlsoap.org/wsdl/ input"`
+}
+
+type wsdlBinding struct {
+	Name       string                 `xml:"name,attr"`
+	Type       string                 `xml:"type,attr"`
+	SOAP11     *struct{}              `xml:"http://schemas.xmlsoap.org/wsdl/soap/ binding"`
+	SOAP12     *struct{}              `xml:"http://schemas.xmlsoap.org/wsdl/soap12/ binding"`
+	Operations []wsdlBindingOperation `xml:"http://schemas.xmlsoap.org/wsdl/ operation"`
+}
+
+type wsdlBindingOperation struct {
+	Name   string             `xml:"name,attr"`
+	SOAP11 *wsdlSOAPOperation `xml:"http://schemas.xmlsoap.org/wsdl/soap/ operation"`
+	SOAP12 *wsdlSOAPOperation `xml:"http://schemas.xmlsoap.org/wsdl/soap12/ operation"`
+}
+
+type wsdlSOAPOperation struct {
+	SOAPAction string `xml:"soapAction,attr"`
+}
+
+// parseWSDLOperation returns the SOAP mediation of the given operation of the WSDL. The SOAP binding of the given
+// version is used, or the SOAP 1.1 binding, if any, when the version is empty.
+func parseWSDLOperation(wsdl []byte, operationName, soapVersion string) (*SOAPMediation, error) {
+	var definitions wsdlDefinitions
+	if err := xml.Unmarshal(wsdl, &definitions); err != nil {
+		return nil, fmt.Errorf("invalid wsdl: %v", err)
+	}
+	var bindingOperation *wsdlBindingOperation
+	var binding *wsdlBinding
+	for i := range definitions.Bindings {
+		candidate := &definitions.Bindings[i]
+		version := SOAP11
+		if candidate.SOAP12 != nil {
+			version = SOAP12
+		} else if candidate.SOAP11 == nil {
+			continue
+		}
+		if soapVersion != "" && soapVersion != version {
+			continue
+		}
+		for j := range candidate.Operations {
+			if candidate.Operations[j].Name != operationName {
+				continue
+			}
+			// A SOAP 1.1 binding is preferred over a SOAP 1.2 binding when the version is not given.
+			if binding == nil || (binding.SOAP12 != nil && version == SOAP11) {
+				binding = candidate
+				bindingOperation = &candidate.Operations[j]
+			}
+			break
+		}
+	}
+	if binding == nil {
+		return nil, fmt.Errorf("operation %s is not found in the SOAP bindings of the wsdl", operationName)
+	}
+
+	soapMediation := &SOAPMediation{
+		SOAPVersion:  SOAP11,
+		Namespace:    definitions.TargetNamespace,
+		InputElement: operationName,
+	}
+	if binding.SOAP12 != nil {
+		soapMediation.SOAPVersion = SOAP12
+		if bindingOperation.SOAP12 != nil {
+			soapMediation.SOAPAction = bindingOperation.SOAP12.SOAPAction
+		}
+	} else if bindingOperation.SOAP11 != nil {
+		soapMediation.SOAPAction = bindingOperation.SOAP11.SOAPAction
+	}
+
+	// Document style operations wrap the payload in the element of the input message part, while RPC style
+	// operations wrap it in an element named after the operation.
+	inputMessage := definitions.findInputMessage(localName(binding.Type), operationName)
+	for _, message := range definitions.Messages {
+		if message.Name != inputMessage || len(message.Parts) == 0 || message.Parts[0].Element == "" {
+			continue
+		}
+		element := message.Parts[0].Element
+		soapMediation.InputElement = localName(element)
+		if prefix, _, found := strings.Cut(element, ":"); found {
+			if namespace := definitions.lookupNamespace(prefix); namespace != "" {
+				soapMediation.Namespace = namespace
+			}
+		}
+	}
+	return soapMediation, nil
+}
+
+// findInputMessage returns the local name of the input message of the given operation of the port type.
+func (definitions *wsdlDefinitions) findInputMessage(portTypeName, operationName string) string {
+	for _, portType := range definitions.PortTypes {
+		if portType.Name != portTypeName {
+			continue
+		}
+		for _, operation := range portType.Operations {
+			if operation.Name == operationName {
+				return localName(operation.Input.Message)
+			}
+		}
+	}
+	return ""
+}
+
+// lookupNamespace returns the namespace bound to the given prefix in the root element of the WSDL.
+func (definitions *wsdlDefinitions) lookupNamespace(prefix string) string {
+	for _, attr := range definitions.Attrs {
+		if attr.Name.Space == "xmlns" && attr.Name.Local == prefix {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// localName returns the local part of the given qualified name.
+func localName(qualifiedName string) string {
+	if _, local, found := strings.Cut(qualifiedName, ":"); found {
+		return local
+	}
+	return qualifiedName
+}
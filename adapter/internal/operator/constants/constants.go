@@ -53,6 +53,12 @@ const (
 	KindBackend      = "Backend"
 	KindGateway      = "Gateway"
 	KindSubscription = "Subscription"
+	KindAPIPolicy    = "APIPolicy"
+	KindAIProvider   = "AIProvider"
+	// KindRateLimitPolicy is the kind of RateLimitPolicy CRs
+	KindRateLimitPolicy = "RateLimitPolicy"
+	// KindAuthorizationPolicy is the kind of AuthorizationPolicy CRs
+	KindAuthorizationPolicy = "AuthorizationPolicy"
 )
 
 // Env types
@@ -34,6 +34,7 @@ import (
 	"sync"
 
 	"github.com/wso2/apk/adapter/config"
+	"github.com/wso2/apk/adapter/internal/authorization"
 	"github.com/wso2/apk/adapter/internal/controlplane"
 	"github.com/wso2/apk/adapter/internal/discovery/xds"
 	"github.com/wso2/apk/adapter/internal/discovery/xds/common"
@@ -82,6 +83,10 @@ const (
 	gatewayGRPCRouteIndex = "gatewayToGRPCRouteIndex"
 	// apiAPIPolicyIndex Index for API level apipolicies
 	apiAPIPolicyIndex = "apiToAPIPolicyIndex"
+	// apiAuthorizationPolicyIndex Index for API level authorizationpolicies
+	apiAuthorizationPolicyIndex = "apiToAuthorizationPolicyIndex"
+	// apiAuthorizationPolicyResourceIndex Index for resource level authorizationpolicies
+	apiAuthorizationPolicyResourceIndex = "apiToAuthorizationPolicyResourceIndex"
 	// apiAPIPolicyResourceIndex Index for resource level apipolicies
 	apiAPIPolicyResourceIndex        = "apiToAPIPolicyResourceIndex"
 	serviceHTTPRouteIndex            = "serviceToHTTPRouteIndex"
@@ -103,6 +108,8 @@ const (
 	subscriptionToAPIIndex           = "subscriptionToAPIIndex"
 	apiToSubscriptionIndex           = "apiToSubscriptionIndex"
 	aiProviderAPIPolicyIndex         = "aiProviderToAPIPolicyIndex"
+	backendAPIPolicyIndex            = "backendToAPIPolicyIndex"
+	configMapAPIPolicyIndex          = "configMapToAPIPolicyIndex"
 )
 
 var (
@@ -118,6 +125,9 @@ type APIReconciler struct {
 	statusUpdater         *status.UpdateHandler
 	mgr                   manager.Manager
 	apiPropagationEnabled bool
+	// attachedPolicyRefs holds the CRs whose status records them as attached to an API, keyed by the API
+	attachedPolicyRefs     map[string]map[string]*policyStatusRef
+	attachedPolicyRefsLock sync.Mutex
 }
 
 // NewAPIController creates a new API controller instance. API Controllers watches for dpv1alpha3.API and gwapiv1.HTTPRoute.
@@ -221,6 +231,13 @@ func NewAPIController(mgr manager.Manager, operatorDataStore *synchronizer.Opera
 		return err
 	}
 
+	predicateAuthorizationPolicy := []predicate.TypedPredicate[*dpv1alpha3.AuthorizationPolicy]{predicate.NewTypedPredicateFuncs[*dpv1alpha3.AuthorizationPolicy](utils.FilterAuthorizationPolicyByNamespaces(conf.Adapter.Operator.Namespaces))}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &dpv1alpha3.AuthorizationPolicy{}, handler.TypedEnqueueRequestsFromMapFunc(apiReconciler.populateAPIReconcileRequestsForAuthorizationPolicy),
+		predicateAuthorizationPolicy...)); err != nil {
+		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2671, logging.BLOCKER, "Error watching AuthorizationPolicy resources: %v", err))
+		return err
+	}
+
 	predicateScope := []predicate.TypedPredicate[*dpv1alpha1.Scope]{predicate.NewTypedPredicateFuncs[*dpv1alpha1.Scope](utils.FilterScopeByNamespaces(conf.Adapter.Operator.Namespaces))}
 	if err := c.Watch(source.Kind(mgr.GetCache(), &dpv1alpha1.Scope{}, handler.TypedEnqueueRequestsFromMapFunc(apiReconciler.populateAPIReconcileRequestsForScope),
 		predicateScope...)); err != nil {
@@ -286,9 +303,14 @@ func NewAPIController(mgr manager.Manager, operatorDataStore *synchronizer.Opera
 // +kubebuilder:rbac:groups=dp.wso2.com,resources=ratelimitpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=dp.wso2.com,resources=ratelimitpolicies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=dp.wso2.com,resources=ratelimitpolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=dp.wso2.com,resources=authorizationpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dp.wso2.com,resources=authorizationpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dp.wso2.com,resources=authorizationpolicies/finalizers,verbs=update
 // +kubebuilder:rbac:groups=dp.wso2.com,resources=airatelimitpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=dp.wso2.com,resources=airatelimitpolicies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=dp.wso2.com,resources=airatelimitpolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=dp.wso2.com,resources=backends/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dp.wso2.com,resources=aiproviders/status,verbs=get;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -312,6 +334,7 @@ func (apiReconciler *APIReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			}
 			// The api doesn't exist in the api Cache, remove it
 			apiReconciler.ods.DeleteCachedAPI(req.NamespacedName)
+			apiReconciler.detachPoliciesFromAPI(apiState)
 			loggers.LoggerAPKOperator.Infof("Delete event received for API : %s with API UUID : %v, hence deleted from API cache",
 				req.NamespacedName.String(), string(apiCR.ObjectMeta.UID))
 			*apiReconciler.ch <- &synchronizer.APIEvent{EventType: constants.Delete, Events: []synchronizer.APIState{apiState}}
@@ -325,6 +348,7 @@ func (apiReconciler *APIReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	if apiState, err := apiReconciler.resolveAPIRefs(ctx, apiCR); err != nil {
 		loggers.LoggerAPKOperator.Warnf("Error retrieving ref CRs for API in namespace : %s with API UUID : %v, %v",
 			req.NamespacedName.String(), string(apiCR.ObjectMeta.UID), err)
+		apiReconciler.updatePolicyStatusForFailedAPI(ctx, apiCR, err)
 		return ctrl.Result{}, nil
 	} else if apiState != nil {
 		loggers.LoggerAPKOperator.Infof("Ready to deploy CRs for API in namespace : %s with API UUID : %v, %v",
@@ -350,6 +374,7 @@ func (apiReconciler *APIReconciler) applyStartupAPIs() {
 		if apiState, err := apiReconciler.resolveAPIRefs(ctx, api); err != nil {
 			loggers.LoggerAPKOperator.Warnf("Error retrieving ref CRs for API : %s in namespace : %s with API UUID : %v, %v",
 				api.Name, api.Namespace, string(api.ObjectMeta.UID), err)
+			apiReconciler.updatePolicyStatusForFailedAPI(ctx, api, err)
 		} else if apiState != nil {
 			combinedapiEvent.Events = append(combinedapiEvent.Events, apiState.Events...)
 		}
@@ -393,6 +418,10 @@ func (apiReconciler *APIReconciler) resolveAPIRefs(ctx context.Context, api dpv1
 		return nil, fmt.Errorf("error while getting API level apipolicy for API : %s in namespace : %s with API UUID : %v, %s",
 			apiRef.String(), namespace, string(api.ObjectMeta.UID), err.Error())
 	}
+	if apiState.AuthorizationPolicies, err = apiReconciler.getAuthorizationPoliciesForAPI(ctx, api); err != nil {
+		return nil, fmt.Errorf("error while getting API level authorizationpolicy for API : %s in namespace : %s with API UUID : %v, %s",
+			apiRef.String(), namespace, string(api.ObjectMeta.UID), err.Error())
+	}
 
 	if apiState.ResourceAuthentications, err = apiReconciler.getAuthenticationsForResources(ctx, api); err != nil {
 		return nil, fmt.Errorf("error while getting httproute resource auth : %s in namespace : %s with API UUID : %v, %s",
@@ -406,6 +435,14 @@ func (apiReconciler *APIReconciler) resolveAPIRefs(ctx context.Context, api dpv1
 		return nil, fmt.Errorf("error while getting httproute resource apipolicy %s in namespace : %s with API UUID : %v, %s",
 			apiRef.String(), namespace, string(api.ObjectMeta.UID), err.Error())
 	}
+	if apiState.ResourceAuthorizationPolicies, err = apiReconciler.getAuthorizationPoliciesForResources(ctx, api); err != nil {
+		return nil, fmt.Errorf("error while getting httproute resource authorizationpolicy %s in namespace : %s with API UUID : %v, %s",
+			apiRef.String(), namespace, string(api.ObjectMeta.UID), err.Error())
+	}
+	if err = apiReconciler.validateAuthorizationPolicies(ctx, apiState); err != nil {
+		return nil, fmt.Errorf("invalid authorizationpolicy for API : %s in namespace : %s with API UUID : %v, %s",
+			apiRef.String(), namespace, string(api.ObjectMeta.UID), err.Error())
+	}
 	if apiState.InterceptorServiceMapping, apiState.BackendJWTMapping, apiState.SubscriptionValidation, apiState.AIProvider, err =
 		apiReconciler.getAPIPolicyChildrenRefs(ctx, apiState.APIPolicies, apiState.ResourceAPIPolicies, api); err != nil {
 		return nil, fmt.Errorf("error while getting referenced policies in apipolicy %s in namespace : %s with API UUID : %v, %s",
@@ -426,6 +463,26 @@ func (apiReconciler *APIReconciler) resolveAPIRefs(ctx context.Context, api dpv1
 				apiState.Authentications, namespace, err.Error())
 		}
 	}
+	if apiState.HMACKeys, err = apiReconciler.resolveHMACKeys(ctx, apiState.Authentications,
+		apiState.ResourceAuthentications); err != nil {
+		return nil, fmt.Errorf("error while resolving HMAC keys of authentications in namespace: %s. %s",
+			namespace, err.Error())
+	}
+	if apiState.WSDLs, err = apiReconciler.resolveWSDLs(ctx, apiState.APIPolicies,
+		apiState.ResourceAPIPolicies, api); err != nil {
+		return nil, fmt.Errorf("error while resolving WSDLs of apipolicies in namespace: %s. %s",
+			namespace, err.Error())
+	}
+	if apiState.ProtoDescriptorSets, err = apiReconciler.resolveProtoDescriptorSets(ctx, apiState.APIPolicies,
+		api); err != nil {
+		return nil, fmt.Errorf("error while resolving descriptor sets of apipolicies in namespace: %s. %s",
+			namespace, err.Error())
+	}
+	if apiState.AIRoutingProviders, err = apiReconciler.resolveAIRoutingProviders(ctx, apiState.APIPolicies,
+		api); err != nil {
+		return nil, fmt.Errorf("error while resolving AI routing providers of apipolicies in namespace: %s. %s",
+			namespace, err.Error())
+	}
 	var prodAirl *dpv1alpha3.AIRateLimitPolicy
 	if len(prodRouteRefs) > 0 && apiState.APIDefinition.Spec.APIType == "REST" {
 		apiState.ProdHTTPRoute = &synchronizer.HTTPRouteState{}
@@ -434,6 +491,11 @@ func (apiReconciler *APIReconciler) resolveAPIRefs(ctx context.Context, api dpv1
 			return nil, fmt.Errorf("error while resolving production httpRouteref %s in namespace :%s has not found. %s",
 				prodRouteRefs, namespace, err.Error())
 		}
+		if err = apiReconciler.resolveAIRoutingBackends(ctx, apiState.ProdHTTPRoute.BackendMapping,
+			apiState.APIPolicies, api); err != nil {
+			return nil, fmt.Errorf("error while resolving AI routing backends of production httpRouteref %s in namespace :%s. %s",
+				prodRouteRefs, namespace, err.Error())
+		}
 		if !apiReconciler.ods.IsGatewayAvailable(types.NamespacedName{
 			Name: string(apiState.ProdHTTPRoute.HTTPRouteCombined.Spec.ParentRefs[0].Name),
 			Namespace: utils.GetNamespace(apiState.ProdHTTPRoute.HTTPRouteCombined.Spec.ParentRefs[0].Namespace,
@@ -452,6 +514,11 @@ func (apiReconciler *APIReconciler) resolveAPIRefs(ctx context.Context, api dpv1
 			return nil, fmt.Errorf("error while resolving sandbox httpRouteref %s in namespace :%s has not found. %s",
 				sandRouteRefs, namespace, err.Error())
 		}
+		if err = apiReconciler.resolveAIRoutingBackends(ctx, apiState.SandHTTPRoute.BackendMapping,
+			apiState.APIPolicies, api); err != nil {
+			return nil, fmt.Errorf("error while resolving AI routing backends of sandbox httpRouteref %s in namespace :%s. %s",
+				sandRouteRefs, namespace, err.Error())
+		}
 		if !apiReconciler.ods.IsGatewayAvailable(types.NamespacedName{
 			Name: string(apiState.SandHTTPRoute.HTTPRouteCombined.Spec.ParentRefs[0].Name),
 			Namespace: utils.GetNamespace(apiState.SandHTTPRoute.HTTPRouteCombined.Spec.ParentRefs[0].Namespace,
@@ -539,6 +606,7 @@ func (apiReconciler *APIReconciler) resolveAPIRefs(ctx context.Context, api dpv1
 		}
 		apiReconciler.ods.AddAPIState(apiRef, apiState)
 		apiReconciler.traverseAPIStateAndUpdateOwnerReferences(ctx, *apiState)
+		apiReconciler.updatePolicyStatusForAPI(*apiState)
 		return &synchronizer.APIEvent{EventType: constants.Create, Events: []synchronizer.APIState{*apiState}, UpdatedEvents: []string{}}, nil
 	} else if cachedAPI, events, updated :=
 		apiReconciler.ods.UpdateAPIState(apiRef, apiState); updated {
@@ -560,6 +628,7 @@ func (apiReconciler *APIReconciler) resolveAPIRefs(ctx context.Context, api dpv1
 			}
 		}
 		apiReconciler.traverseAPIStateAndUpdateOwnerReferences(ctx, *apiState)
+		apiReconciler.updatePolicyStatusForAPI(*apiState)
 		loggers.LoggerAPKOperator.Infof("API CR %s with API UUID : %v is updated on %v", apiRef.String(),
 			string(api.ObjectMeta.UID), events)
 		return &synchronizer.APIEvent{EventType: constants.Update, Events: []synchronizer.APIState{cachedAPI}, UpdatedEvents: events}, nil
@@ -616,6 +685,66 @@ func (apiReconciler *APIReconciler) resolveHTTPRouteRefs(ctx context.Context, ht
 	return httpRouteState, airl, err
 }
 
+// resolveAIRoutingBackends resolves the backends of the AI routing targets in the API level
+// APIPolicies and adds them to the backend mapping of the route
+func (apiReconciler *APIReconciler) resolveAIRoutingBackends(ctx context.Context,
+	backendMapping map[string]*dpv1alpha2.ResolvedBackend, apiPolicies map[string]dpv1alpha3.APIPolicy,
+	api dpv1alpha3.API) error {
+	for _, apiPolicy := range apiPolicies {
+		for _, policySpec := range []*dpv1alpha3.PolicySpec{apiPolicy.Spec.Default, apiPolicy.Spec.Override} {
+			if policySpec == nil || policySpec.AIRouting == nil {
+				continue
+			}
+			for _, target := range policySpec.AIRouting.Targets {
+				backendNamespacedName := types.NamespacedName{
+					Name:      target.BackendRef.Name,
+					Namespace: api.Namespace,
+				}
+				if _, exists := backendMapping[backendNamespacedName.String()]; exists {
+					continue
+				}
+				resolvedBackend := apiReconciler.resolveBackend(ctx, backendNamespacedName, api)
+				if resolvedBackend == nil {
+					return fmt.Errorf("unable to find backend %s", backendNamespacedName.String())
+				}
+				backendMapping[backendNamespacedName.String()] = resolvedBackend
+			}
+		}
+	}
+	return nil
+}
+
+// resolveAIRoutingProviders resolves the AIProviders of the AI routing targets in the API level APIPolicies
+func (apiReconciler *APIReconciler) resolveAIRoutingProviders(ctx context.Context,
+	apiPolicies map[string]dpv1alpha3.APIPolicy, api dpv1alpha3.API) (map[string]*dpv1alpha3.AIProvider, error) {
+	aiProviders := make(map[string]*dpv1alpha3.AIProvider)
+	for _, apiPolicy := range apiPolicies {
+		for _, policySpec := range []*dpv1alpha3.PolicySpec{apiPolicy.Spec.Default, apiPolicy.Spec.Override} {
+			if policySpec == nil || policySpec.AIRouting == nil {
+				continue
+			}
+			for _, target := range policySpec.AIRouting.Targets {
+				if target.Provider == nil {
+					continue
+				}
+				aiProviderNamespacedName := types.NamespacedName{
+					Name:      target.Provider.Name,
+					Namespace: apiPolicy.Namespace,
+				}
+				if _, exists := aiProviders[aiProviderNamespacedName.String()]; exists {
+					continue
+				}
+				aiProvider := utils.GetAIProvider(ctx, apiReconciler.client, apiPolicy.Namespace, target.Provider.Name, &api)
+				if aiProvider == nil || aiProvider.Name == "" {
+					return nil, fmt.Errorf("unable to find AI provider %s", aiProviderNamespacedName.String())
+				}
+				aiProviders[aiProviderNamespacedName.String()] = aiProvider
+			}
+		}
+	}
+	return aiProviders, nil
+}
+
 func (apiReconciler *APIReconciler) resolveGRPCRouteRefs(ctx context.Context, grpcRouteRefs []string,
 	namespace string, interceptorServiceMapping map[string]dpv1alpha1.InterceptorService, api dpv1alpha3.API) (*synchronizer.GRPCRouteState, error) {
 	grpcRouteState, err := apiReconciler.concatGRPCRoutes(ctx, grpcRouteRefs, namespace, api)
@@ -650,18 +779,8 @@ func (apiReconciler *APIReconciler) concatGRPCRoutes(ctx context.Context, grpcRo
 		}
 	}
 	grpcRouteState.GRPCRoutePartitions = grpcRoutePartitions
-	backendNamespacedName := types.NamespacedName{
-		Name:      string(grpcRouteState.GRPCRouteCombined.Spec.Rules[0].BackendRefs[0].BackendRef.Name),
-		Namespace: namespace,
-	}
-	resolvedBackend := utils.GetResolvedBackend(ctx, apiReconciler.client, backendNamespacedName, &api)
-	if resolvedBackend != nil {
-		grpcRouteState.BackendMapping = map[string]*dpv1alpha2.ResolvedBackend{
-			backendNamespacedName.String(): resolvedBackend,
-		}
-		return grpcRouteState, nil
-	}
-	return grpcRouteState, errors.New("error while resolving backend for grpcroute")
+	// The backends of all the rules are resolved in getResolvedBackendsMappingForGRPC
+	return grpcRouteState, nil
 }
 
 func (apiReconciler *APIReconciler) concatGQLRoutes(ctx context.Context, gqlRouteRefs []string,
@@ -688,7 +807,7 @@ func (apiReconciler *APIReconciler) concatGQLRoutes(ctx context.Context, gqlRout
 		Name:      string(gqlRouteState.GQLRouteCombined.Spec.BackendRefs[0].Name),
 		Namespace: namespace,
 	}
-	resolvedBackend := utils.GetResolvedBackend(ctx, apiReconciler.client, backendNamespacedName, &api)
+	resolvedBackend := apiReconciler.resolveBackend(ctx, backendNamespacedName, api)
 	if resolvedBackend != nil {
 		gqlRouteState.BackendMapping = map[string]*dpv1alpha2.ResolvedBackend{
 			backendNamespacedName.String(): resolvedBackend,
@@ -866,6 +985,67 @@ func (apiReconciler *APIReconciler) getAPIPoliciesForAPI(ctx context.Context, ap
 	return apiPolicies, nil
 }
 
+func (apiReconciler *APIReconciler) getAuthorizationPoliciesForAPI(ctx context.Context,
+	api dpv1alpha3.API) (map[string]dpv1alpha3.AuthorizationPolicy, error) {
+	nameSpacedName := utils.NamespacedName(&api).String()
+	authorizationPolicies := make(map[string]dpv1alpha3.AuthorizationPolicy)
+	authorizationPolicyList := &dpv1alpha3.AuthorizationPolicyList{}
+	if err := apiReconciler.client.List(ctx, authorizationPolicyList, &k8client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(apiAuthorizationPolicyIndex, nameSpacedName),
+	}); err != nil {
+		return nil, err
+	}
+	for item := range authorizationPolicyList.Items {
+		authorizationPolicy := authorizationPolicyList.Items[item]
+		authorizationPolicies[utils.NamespacedName(&authorizationPolicy).String()] = authorizationPolicy
+	}
+	return authorizationPolicies, nil
+}
+
+func (apiReconciler *APIReconciler) getAuthorizationPoliciesForResources(ctx context.Context,
+	api dpv1alpha3.API) (map[string]dpv1alpha3.AuthorizationPolicy, error) {
+	nameSpacedName := utils.NamespacedName(&api).String()
+	authorizationPolicies := make(map[string]dpv1alpha3.AuthorizationPolicy)
+	authorizationPolicyList := &dpv1alpha3.AuthorizationPolicyList{}
+	if err := apiReconciler.client.List(ctx, authorizationPolicyList, &k8client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(apiAuthorizationPolicyResourceIndex, nameSpacedName),
+	}); err != nil {
+		return nil, err
+	}
+	for item := range authorizationPolicyList.Items {
+		authorizationPolicy := authorizationPolicyList.Items[item]
+		authorizationPolicies[utils.NamespacedName(&authorizationPolicy).String()] = authorizationPolicy
+	}
+	return authorizationPolicies, nil
+}
+
+// validateAuthorizationPolicies rejects the authorization policies of the API which the enforcer is
+// not able to evaluate, so that the API is not deployed without its authorization rules.
+func (apiReconciler *APIReconciler) validateAuthorizationPolicies(ctx context.Context, apiState *synchronizer.APIState) error {
+	policies := append(maps.Values(apiState.AuthorizationPolicies), maps.Values(apiState.ResourceAuthorizationPolicies)...)
+	for i := range policies {
+		policy := &policies[i]
+		if apiState.APIDefinition.Spec.APIType != "REST" {
+			message := fmt.Sprintf("Authorization rules are not supported for %s APIs", apiState.APIDefinition.Spec.APIType)
+			apiReconciler.updatePolicyInvalidStatus(ctx, policy, message)
+			return fmt.Errorf("authorizationpolicy %s: %s", utils.NamespacedName(policy).String(), message)
+		}
+		for _, rules := range []*dpv1alpha3.AuthorizationRules{policy.Spec.Default, policy.Spec.Override} {
+			if rules == nil {
+				continue
+			}
+			for _, rule := range rules.Rules {
+				if err := authorization.ValidateRule(rule.Language, rule.Expression); err != nil {
+					message := fmt.Sprintf("Invalid expression in authorization rule %s: %v", rule.Name, err)
+					apiReconciler.updatePolicyInvalidStatus(ctx, policy, message)
+					return fmt.Errorf("authorizationpolicy %s: %s", utils.NamespacedName(policy).String(), message)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func (apiReconciler *APIReconciler) getAPIDefinitionForAPI(ctx context.Context,
 	apiDefinitionFile, namespace string, api dpv1alpha3.API) ([]byte, error) {
 	configMap := &corev1.ConfigMap{}
@@ -999,6 +1179,102 @@ func (apiReconciler *APIReconciler) resolveAuthentications(ctx context.Context,
 	return &resolvedMutualSSL, nil
 }
 
+// resolveHMACKeys reads the shared keys of the HMAC authentications of the API and its resources
+func (apiReconciler *APIReconciler) resolveHMACKeys(ctx context.Context, authentications,
+	resourceAuthentications map[string]dpv1alpha2.Authentication) (map[string]map[string]string, error) {
+	hmacKeys := make(map[string]map[string]string)
+	for _, authentications := range []map[string]dpv1alpha2.Authentication{authentications, resourceAuthentications} {
+		for _, authentication := range authentications {
+			if err := utils.GetResolvedHMACKeys(ctx, apiReconciler.client, authentication, hmacKeys); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return hmacKeys, nil
+}
+
+// resolveWSDLs reads the WSDLs referred by the SOAP mediation policies of the API and its resources
+func (apiReconciler *APIReconciler) resolveWSDLs(ctx context.Context, apiPolicies,
+	resourceAPIPolicies map[string]dpv1alpha3.APIPolicy, api dpv1alpha3.API) (map[string][]byte, error) {
+	wsdls := make(map[string][]byte)
+	for _, apiPolicies := range []map[string]dpv1alpha3.APIPolicy{apiPolicies, resourceAPIPolicies} {
+		for _, apiPolicy := range apiPolicies {
+			for _, policySpec := range []*dpv1alpha3.PolicySpec{apiPolicy.Spec.Default, apiPolicy.Spec.Override} {
+				if policySpec == nil || policySpec.SOAPMediation == nil {
+					continue
+				}
+				namespacedName := types.NamespacedName{Namespace: apiPolicy.Namespace,
+					Name: policySpec.SOAPMediation.WSDLRef}
+				if _, found := wsdls[namespacedName.String()]; found {
+					continue
+				}
+				configMap := &corev1.ConfigMap{}
+				if err := utils.ResolveRef(ctx, apiReconciler.client, &api, namespacedName, true, configMap); err != nil {
+					return nil, fmt.Errorf("error while getting wsdl %s, %s", namespacedName.String(), err.Error())
+				}
+				wsdl, err := getFileFromConfigMap(configMap, "wsdl")
+				if err != nil {
+					return nil, fmt.Errorf("error while reading wsdl %s, %s", namespacedName.String(), err.Error())
+				}
+				wsdls[namespacedName.String()] = wsdl
+			}
+		}
+	}
+	return wsdls, nil
+}
+
+// resolveProtoDescriptorSets reads the protobuf descriptor sets referred by the gRPC transcoding policies of the API
+func (apiReconciler *APIReconciler) resolveProtoDescriptorSets(ctx context.Context,
+	apiPolicies map[string]dpv1alpha3.APIPolicy, api dpv1alpha3.API) (map[string][]byte, error) {
+	descriptorSets := make(map[string][]byte)
+	for _, apiPolicy := range apiPolicies {
+		for _, policySpec := range []*dpv1alpha3.PolicySpec{apiPolicy.Spec.Default, apiPolicy.Spec.Override} {
+			if policySpec == nil || policySpec.GRPCTranscoding == nil {
+				continue
+			}
+			namespacedName := types.NamespacedName{Namespace: apiPolicy.Namespace,
+				Name: policySpec.GRPCTranscoding.DescriptorSetRef}
+			if _, found := descriptorSets[namespacedName.String()]; found {
+				continue
+			}
+			configMap := &corev1.ConfigMap{}
+			if err := utils.ResolveRef(ctx, apiReconciler.client, &api, namespacedName, true, configMap); err != nil {
+				return nil, fmt.Errorf("error while getting descriptor set %s, %s", namespacedName.String(), err.Error())
+			}
+			descriptorSet, err := getFileFromConfigMap(configMap, "descriptor set")
+			if err != nil {
+				return nil, fmt.Errorf("error while reading descriptor set %s, %s", namespacedName.String(), err.Error())
+			}
+			descriptorSets[namespacedName.String()] = descriptorSet
+		}
+	}
+	return descriptorSets, nil
+}
+
+// getFileFromConfigMap returns the file held by the configmap, which is either given as text or as gzipped binary
+// data.
+func getFileFromConfigMap(configMap *corev1.ConfigMap, fileType string) ([]byte, error) {
+	var file []byte
+	for _, val := range configMap.Data {
+		file = []byte(val)
+	}
+	for _, val := range configMap.BinaryData {
+		file = val
+	}
+	if len(file) == 0 {
+		return nil, fmt.Errorf("configmap does not have a %s", fileType)
+	}
+	if len(file) > 1 && file[0] == 0x1f && file[1] == 0x8b {
+		r, err := gzip.NewReader(bytes.NewReader(file))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	}
+	return file, nil
+}
+
 func (apiReconciler *APIReconciler) getResolvedBackendsMapping(ctx context.Context,
 	httpRouteState *synchronizer.HTTPRouteState, interceptorServiceMapping map[string]dpv1alpha1.InterceptorService,
 	api dpv1alpha3.API) (map[string]*dpv1alpha2.ResolvedBackend, *dpv1alpha3.AIRateLimitPolicy, error) {
@@ -1030,7 +1306,7 @@ func (apiReconciler *APIReconciler) getResolvedBackendsMapping(ctx context.Conte
 				}
 			}
 			if _, exists := backendMapping[backendNamespacedName.String()]; !exists {
-				resolvedBackend := utils.GetResolvedBackend(ctx, apiReconciler.client, backendNamespacedName, &api)
+				resolvedBackend := apiReconciler.resolveBackend(ctx, backendNamespacedName, api)
 				if resolvedBackend != nil {
 					backendMapping[backendNamespacedName.String()] = resolvedBackend
 				} else {
@@ -1049,7 +1325,7 @@ func (apiReconciler *APIReconciler) getResolvedBackendsMapping(ctx context.Conte
 				}
 				if string(*mirrorBackend.Kind) == constants.KindBackend {
 					if _, exists := backendMapping[mirrorBackendNamespacedName.String()]; !exists {
-						resolvedMirrorBackend := utils.GetResolvedBackend(ctx, apiReconciler.client, mirrorBackendNamespacedName, &api)
+						resolvedMirrorBackend := apiReconciler.resolveBackend(ctx, mirrorBackendNamespacedName, api)
 						if resolvedMirrorBackend != nil {
 							backendMapping[mirrorBackendNamespacedName.String()] = resolvedMirrorBackend
 						} else {
@@ -1095,7 +1371,7 @@ func (apiReconciler *APIReconciler) getResolvedBackendsMappingForGRPC(ctx contex
 				Namespace: utils.GetNamespace(backend.Namespace, grpcRoute.Namespace),
 			}
 			if _, exists := backendMapping[backendNamespacedName.String()]; !exists {
-				resolvedBackend := utils.GetResolvedBackend(ctx, apiReconciler.client, backendNamespacedName, &api)
+				resolvedBackend := apiReconciler.resolveBackend(ctx, backendNamespacedName, api)
 				if resolvedBackend != nil {
 					backendMapping[backendNamespacedName.String()] = resolvedBackend
 				} else {
@@ -1205,6 +1481,14 @@ func (apiReconciler *APIReconciler) populateAPIReconcileRequestsForRateLimitPoli
 	return requests
 }
 
+func (apiReconciler *APIReconciler) populateAPIReconcileRequestsForAuthorizationPolicy(ctx context.Context, obj *dpv1alpha3.AuthorizationPolicy) []reconcile.Request {
+	requests := apiReconciler.getAPIsForAuthorizationPolicy(ctx, obj)
+	if len(requests) > 0 {
+		apiReconciler.handleOwnerReference(ctx, obj, &requests)
+	}
+	return requests
+}
+
 func (apiReconciler *APIReconciler) populateAPIReconcileRequestsForScope(ctx context.Context, obj *dpv1alpha1.Scope) []reconcile.Request {
 	requests := apiReconciler.getAPIsForScope(ctx, obj)
 	if len(requests) > 0 {
@@ -1281,6 +1565,12 @@ func (apiReconciler *APIReconciler) traverseAPIStateAndUpdateOwnerReferences(ctx
 	for _, apiPolicy := range apiState.ResourceAPIPolicies {
 		apiReconciler.retrieveParentAPIsAndUpdateOwnerReference(ctx, &apiPolicy)
 	}
+	for _, authorizationPolicy := range apiState.AuthorizationPolicies {
+		apiReconciler.retrieveParentAPIsAndUpdateOwnerReference(ctx, &authorizationPolicy)
+	}
+	for _, authorizationPolicy := range apiState.ResourceAuthorizationPolicies {
+		apiReconciler.retrieveParentAPIsAndUpdateOwnerReference(ctx, &authorizationPolicy)
+	}
 	for _, interceptorService := range apiState.InterceptorServiceMapping {
 		apiReconciler.retrieveParentAPIsAndUpdateOwnerReference(ctx, &interceptorService)
 	}
@@ -1372,6 +1662,18 @@ func (apiReconciler *APIReconciler) retrieveParentAPIsAndUpdateOwnerReference(ct
 		}
 		requests = apiReconciler.getAPIsForRateLimitPolicy(ctx, &rl)
 		apiReconciler.handleOwnerReference(ctx, &rl, &requests)
+	case *dpv1alpha3.AuthorizationPolicy:
+		var authorizationPolicy dpv1alpha3.AuthorizationPolicy
+		namesapcedName := types.NamespacedName{
+			Name:      string(obj.GetName()),
+			Namespace: string(obj.GetNamespace()),
+		}
+		if err := apiReconciler.client.Get(ctx, namesapcedName, &authorizationPolicy); err != nil {
+			loggers.LoggerAPKOperator.Errorf("Unexpected error occured while loading the cr object from cluster %+v", err)
+			return
+		}
+		requests = apiReconciler.getAPIsForAuthorizationPolicy(ctx, &authorizationPolicy)
+		apiReconciler.handleOwnerReference(ctx, &authorizationPolicy, &requests)
 	case *dpv1alpha1.BackendJWT:
 		var backendJWT dpv1alpha1.BackendJWT
 		namesapcedName := types.NamespacedName{
@@ -1629,6 +1931,21 @@ func (apiReconciler *APIReconciler) getAPIsForConfigMap(ctx context.Context, obj
 		return requests
 	}
 
+	// Create API reconcile events when ConfigMap reffered as the WSDL of the SOAP mediation or the descriptor set of
+	// the gRPC transcoding of APIPolicy
+	apiPolicyList := &dpv1alpha3.APIPolicyList{}
+	err = apiReconciler.client.List(ctx, apiPolicyList, &k8client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(configMapAPIPolicyIndex, utils.NamespacedName(configMap).String()),
+	})
+	if err == nil && len(apiPolicyList.Items) > 0 {
+		requests := []reconcile.Request{}
+		for item := range apiPolicyList.Items {
+			apiPolicy := apiPolicyList.Items[item]
+			requests = append(requests, apiReconciler.getAPIsForAPIPolicy(ctx, &apiPolicy)...)
+		}
+		return requests
+	}
+
 	apiList := &dpv1alpha3.APIList{}
 	err = apiReconciler.client.List(ctx, apiList, &k8client.ListOptions{
 		FieldSelector: fields.OneTermEqualSelector(configMapAPIDefinition, utils.NamespacedName(configMap).String()),
@@ -1675,6 +1992,18 @@ func (apiReconciler *APIReconciler) getAPIsForSecret(ctx context.Context, obj k8
 		backend := backendList.Items[item]
 		requests = append(requests, apiReconciler.getAPIsForBackend(ctx, &backend)...)
 	}
+
+	authenticationList := &dpv1alpha2.AuthenticationList{}
+	if err := apiReconciler.client.List(ctx, authenticationList, &k8client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(secretAuthentication, utils.NamespacedName(secret).String()),
+	}); err != nil {
+		loggers.LoggerAPKOperator.Debugf("Unable to find associated Authentications for Secret: %s", utils.NamespacedName(secret).String())
+		return requests
+	}
+	for item := range authenticationList.Items {
+		authentication := authenticationList.Items[item]
+		requests = append(requests, apiReconciler.getAPIsForAuthentication(ctx, &authentication)...)
+	}
 	return requests
 }
 
@@ -1720,6 +2049,7 @@ func (apiReconciler *APIReconciler) getAPIsForAuthentication(ctx context.Context
 	if err != nil {
 		loggers.LoggerAPKOperator.Errorf("Namespace mismatch. TargetRef %s needs to be in the same namespace as the Athentication %s. Expected: %s, Actual: %s",
 			string(authentication.Spec.TargetRef.Name), authentication.Name, authentication.Namespace, string(*authentication.Spec.TargetRef.Namespace))
+		apiReconciler.updatePolicyInvalidStatus(ctx, authentication, "TargetRef needs to be in the same namespace as the Authentication")
 		return requests
 	}
 
@@ -1732,6 +2062,7 @@ func (apiReconciler *APIReconciler) getAPIsForAuthentication(ctx context.Context
 	requests = append(requests, req)
 	loggers.LoggerAPKOperator.Infof("Adding reconcile request for API: %s/%s due to Authentication change: %v",
 		string(authentication.Spec.TargetRef.Name), namespace, utils.NamespacedName(authentication).String())
+	apiReconciler.updatePolicyTargetStatus(ctx, authentication, req.NamespacedName)
 
 	return requests
 }
@@ -1756,6 +2087,7 @@ func (apiReconciler *APIReconciler) getAPIsForAPIPolicy(ctx context.Context, obj
 	if err != nil {
 		loggers.LoggerAPKOperator.Errorf("Namespace mismatch. TargetRef %s needs to be in the same namespace as the ApiPolicy %s. Expected: %s, Actual: %s",
 			string(apiPolicy.Spec.TargetRef.Name), apiPolicy.Name, apiPolicy.Namespace, string(*apiPolicy.Spec.TargetRef.Namespace))
+		apiReconciler.updatePolicyInvalidStatus(ctx, apiPolicy, "TargetRef needs to be in the same namespace as the APIPolicy")
 		return requests
 	}
 
@@ -1767,6 +2099,7 @@ func (apiReconciler *APIReconciler) getAPIsForAPIPolicy(ctx context.Context, obj
 	requests = append(requests, req)
 	loggers.LoggerAPKOperator.Infof("Adding reconcile request for API: %s/%s due to APIPolicy change: %v",
 		string(apiPolicy.Spec.TargetRef.Name), namespace, utils.NamespacedName(apiPolicy).String())
+	apiReconciler.updatePolicyTargetStatus(ctx, apiPolicy, req.NamespacedName)
 
 	return requests
 }
@@ -1878,6 +2211,43 @@ func (apiReconciler *APIReconciler) getAPIsForRateLimitPolicy(ctx context.Contex
 	requests = append(requests, req)
 	loggers.LoggerAPKOperator.Infof("Adding reconcile request for API: %s/%s due to RateLimitPolicy change: %v",
 		string(ratelimitPolicy.Spec.TargetRef.Name), namespace, utils.NamespacedName(ratelimitPolicy).String())
+	apiReconciler.updatePolicyTargetStatus(ctx, ratelimitPolicy, req.NamespacedName)
+
+	return requests
+}
+
+// getAPIsForAuthorizationPolicy triggers the API controller reconcile method based on the changes detected
+// from AuthorizationPolicy objects. If the changes are done for an API stored in the Operator Data store,
+// a new reconcile event will be created and added to the reconcile event queue.
+func (apiReconciler *APIReconciler) getAPIsForAuthorizationPolicy(ctx context.Context, obj k8client.Object) []reconcile.Request {
+	authorizationPolicy, ok := obj.(*dpv1alpha3.AuthorizationPolicy)
+	requests := []reconcile.Request{}
+	if !ok {
+		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2622, logging.TRIVIAL, "Unexpected object type, bypassing reconciliation: %v", authorizationPolicy))
+		return requests
+	}
+
+	if !(authorizationPolicy.Spec.TargetRef.Kind == constants.KindAPI || authorizationPolicy.Spec.TargetRef.Kind == constants.KindResource) {
+		return requests
+	}
+
+	namespace, err := utils.ValidateAndRetrieveNamespace((*gwapiv1.Namespace)(authorizationPolicy.Spec.TargetRef.Namespace), authorizationPolicy.Namespace)
+
+	if err != nil {
+		loggers.LoggerAPKOperator.Errorf("Namespace mismatch. TargetRef %s needs to be in the same namespace as the AuthorizationPolicy %s. Expected: %s, Actual: %s",
+			string(authorizationPolicy.Spec.TargetRef.Name), authorizationPolicy.Name, authorizationPolicy.Namespace, string(*authorizationPolicy.Spec.TargetRef.Namespace))
+		return requests
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      string(authorizationPolicy.Spec.TargetRef.Name),
+			Namespace: namespace},
+	}
+	requests = append(requests, req)
+	loggers.LoggerAPKOperator.Infof("Adding reconcile request for API: %s/%s due to AuthorizationPolicy change: %v",
+		string(authorizationPolicy.Spec.TargetRef.Name), namespace, utils.NamespacedName(authorizationPolicy).String())
+	apiReconciler.updatePolicyTargetStatus(ctx, authorizationPolicy, req.NamespacedName)
 
 	return requests
 }
@@ -2024,6 +2394,20 @@ func (apiReconciler *APIReconciler) getAPIsForBackend(ctx context.Context, obj k
 		requests = append(requests, apiReconciler.getAPIsForInterceptorService(ctx, &interceptorService)...)
 	}
 
+	// Create API reconcile events when Backend reffered from the AI routing of APIPolicy
+	apiPolicyList := &dpv1alpha3.APIPolicyList{}
+	if err := apiReconciler.client.List(ctx, apiPolicyList, &k8client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(backendAPIPolicyIndex, utils.NamespacedName(backend).String()),
+	}); err != nil {
+		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2649, logging.CRITICAL, "Unable to find associated APIPolicies: %s", utils.NamespacedName(backend).String()))
+		return []reconcile.Request{}
+	}
+
+	for item := range apiPolicyList.Items {
+		apiPolicy := apiPolicyList.Items[item]
+		requests = append(requests, apiReconciler.getAPIsForAPIPolicy(ctx, &apiPolicy)...)
+	}
+
 	return requests
 }
 
@@ -2348,6 +2732,13 @@ func addIndexes(ctx context.Context, mgr manager.Manager) error {
 						Namespace: backend.Namespace,
 					}.String())
 			}
+			if backend.Spec.TLS != nil && backend.Spec.TLS.ClientCertificateRef != nil {
+				secrets = append(secrets,
+					types.NamespacedName{
+						Name:      backend.Spec.TLS.ClientCertificateRef.Name,
+						Namespace: backend.Namespace,
+					}.String())
+			}
 			if backend.Spec.Security != nil {
 				if backend.Spec.Security.Basic != nil {
 					secrets = append(secrets,
@@ -2363,6 +2754,20 @@ func addIndexes(ctx context.Context, mgr manager.Manager) error {
 							Namespace: backend.Namespace,
 						}.String())
 				}
+				if backend.Spec.Security.OAuth2 != nil {
+					secrets = append(secrets,
+						types.NamespacedName{
+							Name:      backend.Spec.Security.OAuth2.ClientSecretRef.Name,
+							Namespace: backend.Namespace,
+						}.String())
+				}
+				if backend.Spec.Security.AWS != nil {
+					secrets = append(secrets,
+						types.NamespacedName{
+							Name:      backend.Spec.Security.AWS.SecretRef.Name,
+							Namespace: backend.Namespace,
+						}.String())
+				}
 			}
 			return secrets
 		}); err != nil {
@@ -2449,6 +2854,15 @@ func addIndexes(ctx context.Context, mgr manager.Manager) error {
 				}
 
 			}
+			for _, authSpec := range []*dpv1alpha2.AuthSpec{authentication.Spec.Default, authentication.Spec.Override} {
+				if authSpec != nil && authSpec.AuthTypes != nil && authSpec.AuthTypes.HMAC != nil && len(authSpec.AuthTypes.HMAC.SecretRef.Name) > 0 {
+					secrets = append(secrets,
+						types.NamespacedName{
+							Name:      authSpec.AuthTypes.HMAC.SecretRef.Name,
+							Namespace: authentication.Namespace,
+						}.String())
+				}
+			}
 			return secrets
 		}); err != nil {
 		return err
@@ -2562,6 +2976,21 @@ func addIndexes(ctx context.Context, mgr manager.Manager) error {
 						Name:      string(apiPolicy.Spec.Override.AIProvider.Name),
 					}.String())
 			}
+			for _, policySpec := range []*dpv1alpha3.PolicySpec{apiPolicy.Spec.Default, apiPolicy.Spec.Override} {
+				if policySpec == nil || policySpec.AIRouting == nil {
+					continue
+				}
+				for _, target := range policySpec.AIRouting.Targets {
+					if target.Provider == nil {
+						continue
+					}
+					aiProviders = append(aiProviders,
+						types.NamespacedName{
+							Namespace: apiPolicy.Namespace,
+							Name:      target.Provider.Name,
+						}.String())
+				}
+			}
 			return aiProviders
 		}); err != nil {
 		return err
@@ -2596,6 +3025,58 @@ func addIndexes(ctx context.Context, mgr manager.Manager) error {
 		return err
 	}
 
+	// authorization policy to API indexer
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &dpv1alpha3.AuthorizationPolicy{}, apiAuthorizationPolicyIndex,
+		func(rawObj k8client.Object) []string {
+			authorizationPolicy := rawObj.(*dpv1alpha3.AuthorizationPolicy)
+			var apis []string
+			if authorizationPolicy.Spec.TargetRef.Kind == constants.KindAPI {
+
+				namespace, err := utils.ValidateAndRetrieveNamespace((*gwapiv1.Namespace)(authorizationPolicy.Spec.TargetRef.Namespace), authorizationPolicy.Namespace)
+
+				if err != nil {
+					loggers.LoggerAPKOperator.Errorf("Namespace mismatch. TargetRef %s needs to be in the same namespace as the AuthorizationPolicy %s. Expected: %s, Given: %s",
+						string(authorizationPolicy.Spec.TargetRef.Name), authorizationPolicy.Name, authorizationPolicy.Namespace, string(*authorizationPolicy.Spec.TargetRef.Namespace))
+					return apis
+				}
+
+				apis = append(apis,
+					types.NamespacedName{
+						Namespace: namespace,
+						Name:      string(authorizationPolicy.Spec.TargetRef.Name),
+					}.String())
+			}
+			return apis
+		}); err != nil {
+		return err
+	}
+
+	// resource level authorization policy to API indexer
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &dpv1alpha3.AuthorizationPolicy{}, apiAuthorizationPolicyResourceIndex,
+		func(rawObj k8client.Object) []string {
+			authorizationPolicy := rawObj.(*dpv1alpha3.AuthorizationPolicy)
+			var apis []string
+			if authorizationPolicy.Spec.TargetRef.Kind == constants.KindResource {
+
+				namespace, err := utils.ValidateAndRetrieveNamespace((*gwapiv1.Namespace)(authorizationPolicy.Spec.TargetRef.Namespace), authorizationPolicy.Namespace)
+
+				if err != nil {
+					loggers.LoggerAPKOperator.Errorf("Namespace mismatch. TargetRef %s needs to be in the same namespace as the AuthorizationPolicy %s. Expected: %s, Given: %s",
+						string(authorizationPolicy.Spec.TargetRef.Name), authorizationPolicy.Name, authorizationPolicy.Namespace, string(*authorizationPolicy.Spec.TargetRef.Namespace))
+					return apis
+				}
+
+				apis = append(apis,
+					types.NamespacedName{
+						Namespace: namespace,
+						Name:      string(authorizationPolicy.Spec.TargetRef.Name),
+					}.String())
+			}
+			return apis
+		}); err != nil {
+		return err
+	}
+
 	// backend to InterceptorService indexer
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &dpv1alpha1.InterceptorService{}, backendInterceptorServiceIndex,
 		func(rawObj k8client.Object) []string {
@@ -2611,6 +3092,57 @@ func addIndexes(ctx context.Context, mgr manager.Manager) error {
 		return err
 	}
 
+	// configMap to APIPolicy indexer
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &dpv1alpha3.APIPolicy{}, configMapAPIPolicyIndex,
+		func(rawObj k8client.Object) []string {
+			apiPolicy := rawObj.(*dpv1alpha3.APIPolicy)
+			var configMaps []string
+			for _, policySpec := range []*dpv1alpha3.PolicySpec{apiPolicy.Spec.Default, apiPolicy.Spec.Override} {
+				if policySpec == nil {
+					continue
+				}
+				if policySpec.SOAPMediation != nil {
+					configMaps = append(configMaps,
+						types.NamespacedName{
+							Namespace: apiPolicy.Namespace,
+							Name:      policySpec.SOAPMediation.WSDLRef,
+						}.String())
+				}
+				if policySpec.GRPCTranscoding != nil {
+					configMaps = append(configMaps,
+						types.NamespacedName{
+							Namespace: apiPolicy.Namespace,
+							Name:      policySpec.GRPCTranscoding.DescriptorSetRef,
+						}.String())
+				}
+			}
+			return configMaps
+		}); err != nil {
+		return err
+	}
+
+	// backend to APIPolicy indexer
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &dpv1alpha3.APIPolicy{}, backendAPIPolicyIndex,
+		func(rawObj k8client.Object) []string {
+			apiPolicy := rawObj.(*dpv1alpha3.APIPolicy)
+			var backends []string
+			for _, policySpec := range []*dpv1alpha3.PolicySpec{apiPolicy.Spec.Default, apiPolicy.Spec.Override} {
+				if policySpec == nil || policySpec.AIRouting == nil {
+					continue
+				}
+				for _, target := range policySpec.AIRouting.Targets {
+					backends = append(backends,
+						types.NamespacedName{
+							Namespace: apiPolicy.Namespace,
+							Name:      target.BackendRef.Name,
+						}.String())
+				}
+			}
+			return backends
+		}); err != nil {
+		return err
+	}
+
 	// interceptorService to APIPolicy indexer
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &dpv1alpha3.APIPolicy{}, interceptorServiceAPIPolicyIndex,
 		func(rawObj k8client.Object) []string {
@@ -3092,6 +3624,12 @@ func (apiReconciler *APIReconciler) validateRouteExtRefs(apiState *synchronizer.
 					return fmt.Errorf("ratelimitPolicy not added to the ResourceRateLimitPolicies map yet. Key: %s", key)
 				}
 			}
+			if extKind == "AuthorizationPolicy" {
+				_, found := apiState.ResourceAuthorizationPolicies[key]
+				if !found {
+					return fmt.Errorf("authorizationPolicy not added to the ResourceAuthorizationPolicies map yet. Key: %s", key)
+				}
+			}
 			if extKind == "Authentication" {
 				_, found := apiState.ResourceAuthentications[key]
 				if !found {
@@ -3159,6 +3697,12 @@ func (apiReconciler *APIReconciler) getAPIHash(apiState *synchronizer.APIState)
 	for _, ap := range apiState.APIPolicies {
 		uniqueIDs = append(uniqueIDs, getUniqueID(ap, "ObjectMeta.Name", "ObjectMeta.Namespace", "ObjectMeta.Generation"))
 	}
+	for _, azp := range apiState.AuthorizationPolicies {
+		uniqueIDs = append(uniqueIDs, getUniqueID(azp, "ObjectMeta.Name", "ObjectMeta.Namespace", "ObjectMeta.Generation"))
+	}
+	for _, razp := range apiState.ResourceAuthorizationPolicies {
+		uniqueIDs = append(uniqueIDs, getUniqueID(razp, "ObjectMeta.Name", "ObjectMeta.Namespace", "ObjectMeta.Generation"))
+	}
 	for _, ism := range apiState.InterceptorServiceMapping {
 		uniqueIDs = append(uniqueIDs, getUniqueID(ism, "ObjectMeta.Name", "ObjectMeta.Namespace", "ObjectMeta.Generation"))
 	}
@@ -3235,7 +3779,7 @@ func findProdSandEndpoints(apiState *synchronizer.APIState) (string, string, str
 	if apiState.ProdHTTPRoute != nil {
 		for _, backend := range apiState.ProdHTTPRoute.BackendMapping {
 			if len(backend.Backend.Spec.Services) > 0 {
-				prodEndpoint = fmt.Sprintf("%s:%d", backend.Backend.Spec.Services[0].Host, backend.Backend.Spec.Services[0].Port)
+				prodEndpoint = getPrimaryServiceEndpoint(backend.Backend.Spec.Services)
 				endpointProtocol = string(backend.Backend.Spec.Protocol)
 			}
 			if backend.Security.Basic.Username != "" && backend.Security.Basic.Password != "" {
@@ -3256,7 +3800,7 @@ func findProdSandEndpoints(apiState *synchronizer.APIState) (string, string, str
 	if apiState.SandHTTPRoute != nil {
 		for _, backend := range apiState.SandHTTPRoute.BackendMapping {
 			if len(backend.Backend.Spec.Services) > 0 {
-				sandEndpoint = fmt.Sprintf("%s:%d", backend.Backend.Spec.Services[0].Host, backend.Backend.Spec.Services[0].Port)
+				sandEndpoint = getPrimaryServiceEndpoint(backend.Backend.Spec.Services)
 				endpointProtocol = string(backend.Backend.Spec.Protocol)
 			}
 			if backend.Security.Basic.Username != "" && backend.Security.Basic.Password != "" {
@@ -3277,7 +3821,7 @@ func findProdSandEndpoints(apiState *synchronizer.APIState) (string, string, str
 	if apiState.ProdGQLRoute != nil {
 		for _, backend := range apiState.ProdGQLRoute.BackendMapping {
 			if len(backend.Backend.Spec.Services) > 0 {
-				prodEndpoint = fmt.Sprintf("%s:%d", backend.Backend.Spec.Services[0].Host, backend.Backend.Spec.Services[0].Port)
+				prodEndpoint = getPrimaryServiceEndpoint(backend.Backend.Spec.Services)
 				endpointProtocol = string(backend.Backend.Spec.Protocol)
 			}
 			if backend.Security.Basic.Username != "" && backend.Security.Basic.Password != "" {
@@ -3298,7 +3842,7 @@ func findProdSandEndpoints(apiState *synchronizer.APIState) (string, string, str
 	if apiState.SandGQLRoute != nil {
 		for _, backend := range apiState.SandGQLRoute.BackendMapping {
 			if len(backend.Backend.Spec.Services) > 0 {
-				sandEndpoint = fmt.Sprintf("%s:%d", backend.Backend.Spec.Services[0].Host, backend.Backend.Spec.Services[0].Port)
+				sandEndpoint = getPrimaryServiceEndpoint(backend.Backend.Spec.Services)
 				endpointProtocol = string(backend.Backend.Spec.Protocol)
 			}
 			if backend.Security.Basic.Username != "" && backend.Security.Basic.Password != "" {
@@ -3319,6 +3863,23 @@ func findProdSandEndpoints(apiState *synchronizer.APIState) (string, string, str
 	return prodEndpoint, sandEndpoint, endpointProtocol, prodAPIKeyName, prodAPIKeyIn, prodAPIKeyValue, prodBasicUsername, prodBasicPassword, sandAPIKeyName, sandAPIKeyIn, sandAPIKeyValue, sandBasicUsername, sandBasicPassword, prodEndpointSecurityType, sandEndpointSecurityType, prodEndpointSecurityEnabled, sandEndpointSecurityEnabled
 }
 
+// getPrimaryServiceEndpoint returns the endpoint of the service of a Backend which receives the traffic while the
+// services are healthy, that is the one with the highest weight among the services of the lowest priority. The
+// control plane holds a single endpoint per environment, hence the services which share the traffic with it or take
+// it over on failure are not reported.
+func getPrimaryServiceEndpoint(services []dpv1alpha2.Service) string {
+	// the weight of a service defaults to 1
+	weight := func(service dpv1alpha2.Service) uint32 { return max(service.Weight, 1) }
+	primary := services[0]
+	for _, service := range services[1:] {
+		if service.Priority < primary.Priority ||
+			(service.Priority == primary.Priority && weight(service) > weight(primary)) {
+			primary = service
+		}
+	}
+	return fmt.Sprintf("%s:%d", primary.Host, primary.Port)
+}
+
 func pickOneCorsForCP(apiState *synchronizer.APIState) *controlplane.CORSPolicy {
 	apiPolicies := []dpv1alpha3.APIPolicy{}
 	for _, apiPolicy := range apiState.APIPolicies {
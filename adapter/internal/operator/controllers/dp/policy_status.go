@@ -0,0 +1,398 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package dp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wso2/apk/adapter/internal/loggers"
+	"github.com/wso2/apk/adapter/internal/operator/constants"
+	"github.com/wso2/apk/adapter/internal/operator/status"
+	"github.com/wso2/apk/adapter/internal/operator/synchronizer"
+	"github.com/wso2/apk/adapter/internal/operator/utils"
+	"github.com/wso2/apk/adapter/pkg/logging"
+	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	commonconstants "github.com/wso2/apk/common-go-libs/constants"
+	"golang.org/x/exp/maps"
+	k8error "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8client "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// policyStatusRef refers to a CR which carries policy status, together with the
+// conflict detected for it within a single API.
+type policyStatusRef struct {
+	namespacedName types.NamespacedName
+	newObject      func() k8client.Object
+	// ownsAcceptance is false for kinds whose Accepted and ResolvedRefs conditions are
+	// maintained by the common controller.
+	ownsAcceptance bool
+	// targetsAPI is true for the policies which target the API through their targetRef, and hence
+	// are attached to that API only.
+	targetsAPI     bool
+	conflict       string
+	conflictReason string
+}
+
+// collectPolicyStatusRefs returns the Backends, APIPolicies, Authentications, RateLimitPolicies,
+// AuthorizationPolicies and AIProvider referenced by the given API state keyed by kind and namespaced name.
+func collectPolicyStatusRefs(apiState synchronizer.APIState) map[string]*policyStatusRef {
+	refs := make(map[string]*policyStatusRef)
+	add := func(kind string, obj metav1.Object, newObject func() k8client.Object, ownsAcceptance bool) *policyStatusRef {
+		namespacedName := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		key := kind + "/" + namespacedName.String()
+		if ref, found := refs[key]; found {
+			return ref
+		}
+		refs[key] = &policyStatusRef{namespacedName: namespacedName, newObject: newObject, ownsAcceptance: ownsAcceptance}
+		return refs[key]
+	}
+	addTargeting := func(kind string, obj k8client.Object, newObject func() k8client.Object, ownsAcceptance bool,
+		winner k8client.Object) {
+		ref := add(kind, obj, newObject, ownsAcceptance)
+		ref.targetsAPI = true
+		if winner != nil && k8client.ObjectKeyFromObject(winner) != k8client.ObjectKeyFromObject(obj) {
+			ref.conflict = fmt.Sprintf("%s %s takes precedence for API %s", kind,
+				k8client.ObjectKeyFromObject(winner).String(), utils.NamespacedName(apiState.APIDefinition).String())
+			ref.conflictReason = commonconstants.ReasonOverridden
+		}
+	}
+	newBackend := func() k8client.Object { return new(dpv1alpha2.Backend) }
+	newAuthentication := func() k8client.Object { return new(dpv1alpha2.Authentication) }
+	newAPIPolicy := func() k8client.Object { return new(dpv1alpha3.APIPolicy) }
+	newRateLimitPolicy := func() k8client.Object { return new(dpv1alpha3.RateLimitPolicy) }
+	newAuthorizationPolicy := func() k8client.Object { return new(dpv1alpha3.AuthorizationPolicy) }
+
+	winningAuth := utils.TieBreaker(utils.GetPtrSlice(maps.Values(apiState.Authentications)))
+	for _, auth := range apiState.Authentications {
+		var winner k8client.Object
+		if winningAuth != nil {
+			winner = *winningAuth
+		}
+		addTargeting(constants.KindAuthentication, &auth, newAuthentication, true, winner)
+	}
+	for _, auth := range apiState.ResourceAuthentications {
+		add(constants.KindAuthentication, &auth, newAuthentication, true)
+	}
+	winningAPIPolicy := utils.TieBreaker(utils.GetPtrSlice(maps.Values(apiState.APIPolicies)))
+	for _, apiPolicy := range apiState.APIPolicies {
+		var winner k8client.Object
+		if winningAPIPolicy != nil {
+			winner = *winningAPIPolicy
+		}
+		addTargeting(constants.KindAPIPolicy, &apiPolicy, newAPIPolicy, true, winner)
+	}
+	for _, apiPolicy := range apiState.ResourceAPIPolicies {
+		add(constants.KindAPIPolicy, &apiPolicy, newAPIPolicy, true)
+	}
+	winningRateLimitPolicy := utils.TieBreaker(utils.GetPtrSlice(maps.Values(apiState.RateLimitPolicies)))
+	for _, ratelimitPolicy := range apiState.RateLimitPolicies {
+		var winner k8client.Object
+		if winningRateLimitPolicy != nil {
+			winner = *winningRateLimitPolicy
+		}
+		addTargeting(constants.KindRateLimitPolicy, &ratelimitPolicy, newRateLimitPolicy, false, winner)
+	}
+	for _, ratelimitPolicy := range apiState.ResourceRateLimitPolicies {
+		add(constants.KindRateLimitPolicy, &ratelimitPolicy, newRateLimitPolicy, false)
+	}
+	winningAuthorizationPolicy := utils.TieBreaker(utils.GetPtrSlice(maps.Values(apiState.AuthorizationPolicies)))
+	for _, authorizationPolicy := range apiState.AuthorizationPolicies {
+		var winner k8client.Object
+		if winningAuthorizationPolicy != nil {
+			winner = *winningAuthorizationPolicy
+		}
+		addTargeting(constants.KindAuthorizationPolicy, &authorizationPolicy, newAuthorizationPolicy, true, winner)
+	}
+	for _, authorizationPolicy := range apiState.ResourceAuthorizationPolicies {
+		add(constants.KindAuthorizationPolicy, &authorizationPolicy, newAuthorizationPolicy, true)
+	}
+	if apiState.AIProvider != nil && apiState.AIProvider.Name != "" {
+		ref := add(constants.KindAIProvider, apiState.AIProvider, func() k8client.Object { return new(dpv1alpha3.AIProvider) }, true)
+		if providers := referencedAIProviders(apiState); len(providers) > 1 {
+			ref.conflict = fmt.Sprintf("APIPolicies of API %s refer to multiple AIProviders %v, only %s is applied",
+				utils.NamespacedName(apiState.APIDefinition).String(), providers, apiState.AIProvider.Name)
+			ref.conflictReason = commonconstants.ReasonMultiplePolicies
+		}
+	}
+	for _, backendMapping := range []map[string]*dpv1alpha2.ResolvedBackend{
+		backendMappingOfHTTPRoute(apiState.ProdHTTPRoute), backendMappingOfHTTPRoute(apiState.SandHTTPRoute),
+		backendMappingOfGQLRoute(apiState.ProdGQLRoute), backendMappingOfGQLRoute(apiState.SandGQLRoute),
+		backendMappingOfGRPCRoute(apiState.ProdGRPCRoute), backendMappingOfGRPCRoute(apiState.SandGRPCRoute)} {
+		for _, backend := range backendMapping {
+			// Backends resolved from Services are not Backend CRs
+			if backend != nil && backend.Backend.Name != "" {
+				add(constants.KindBackend, &backend.Backend, newBackend, true)
+			}
+		}
+	}
+	return refs
+}
+
+// updatePolicyStatusForAPI marks the CRs referenced by the API state as accepted and
+// attached to the API, and detaches the API from the CRs it no longer refers to.
+func (apiReconciler *APIReconciler) updatePolicyStatusForAPI(apiState synchronizer.APIState) {
+	apiName := utils.NamespacedName(apiState.APIDefinition).String()
+	refs := collectPolicyStatusRefs(apiState)
+	apiReconciler.detachPolicyRefs(apiName, apiReconciler.swapAttachedPolicyRefs(apiName, refs))
+	for _, ref := range refs {
+		apiReconciler.sendPolicyStatus(ref.namespacedName, ref.newObject(), func(obj k8client.Object) {
+			if ref.targetsAPI {
+				// The policy may have been attached to the API it targeted before its targetRef changed
+				status.RetainAttachedAPIs(obj, func(attachedAPI string) bool { return attachedAPI == apiName })
+			}
+			if ref.ownsAcceptance {
+				status.SetPolicyCondition(obj, commonconstants.ConditionAccepted, metav1.ConditionTrue,
+					commonconstants.ReasonAccepted, "Resource is accepted")
+				status.SetPolicyCondition(obj, commonconstants.ConditionResolvedRefs, metav1.ConditionTrue,
+					commonconstants.ReasonResolvedRefs, "All references are resolved")
+			}
+			status.AddAttachedAPI(obj, apiName)
+			status.SetPolicyCondition(obj, commonconstants.ConditionAttached, metav1.ConditionTrue,
+				commonconstants.ReasonAttached, "Resource is attached to the referring APIs")
+			if ref.conflict != "" {
+				status.SetPolicyCondition(obj, commonconstants.ConditionConflicted, metav1.ConditionTrue,
+					ref.conflictReason, ref.conflict)
+			} else {
+				status.SetPolicyCondition(obj, commonconstants.ConditionConflicted, metav1.ConditionFalse,
+					commonconstants.ReasonNoConflicts, "No conflicting resources found")
+			}
+		})
+	}
+}
+
+// detachPoliciesFromAPI removes the API from the attached API list of the CRs referenced
+// by the API state. Used when the API is deleted.
+func (apiReconciler *APIReconciler) detachPoliciesFromAPI(apiState synchronizer.APIState) {
+	apiName := utils.NamespacedName(apiState.APIDefinition).String()
+	refs := collectPolicyStatusRefs(apiState)
+	for key, ref := range apiReconciler.swapAttachedPolicyRefs(apiName, nil) {
+		refs[key] = ref
+	}
+	apiReconciler.detachPolicyRefs(apiName, refs)
+}
+
+// detachPolicyRefs removes the API from the attached API list of the given CRs.
+func (apiReconciler *APIReconciler) detachPolicyRefs(apiName string, refs map[string]*policyStatusRef) {
+	for _, ref := range refs {
+		apiReconciler.sendPolicyStatus(ref.namespacedName, ref.newObject(), func(obj k8client.Object) {
+			if status.RemoveAttachedAPI(obj, apiName) == 0 {
+				status.SetPolicyCondition(obj, commonconstants.ConditionAttached, metav1.ConditionFalse,
+					commonconstants.ReasonNotAttached, "Resource is not attached to any API")
+			}
+		})
+	}
+}
+
+// swapAttachedPolicyRefs records the CRs currently referenced by the API and returns the CRs the
+// API referred to earlier but no longer does, such as a Backend removed from a route or a policy
+// whose targetRef moved to another API.
+func (apiReconciler *APIReconciler) swapAttachedPolicyRefs(apiName string,
+	refs map[string]*policyStatusRef) map[string]*policyStatusRef {
+	apiReconciler.attachedPolicyRefsLock.Lock()
+	defer apiReconciler.attachedPolicyRefsLock.Unlock()
+	if apiReconciler.attachedPolicyRefs == nil {
+		apiReconciler.attachedPolicyRefs = make(map[string]map[string]*policyStatusRef)
+	}
+	staleRefs := getStalePolicyRefs(apiReconciler.attachedPolicyRefs[apiName], refs)
+	if refs == nil {
+		delete(apiReconciler.attachedPolicyRefs, apiName)
+	} else {
+		apiReconciler.attachedPolicyRefs[apiName] = refs
+	}
+	return staleRefs
+}
+
+// getStalePolicyRefs returns the refs in previousRefs which are not in currentRefs.
+func getStalePolicyRefs(previousRefs, currentRefs map[string]*policyStatusRef) map[string]*policyStatusRef {
+	staleRefs := make(map[string]*policyStatusRef)
+	for key, ref := range previousRefs {
+		if _, found := currentRefs[key]; !found {
+			staleRefs[key] = ref
+		}
+	}
+	return staleRefs
+}
+
+// updatePolicyStatusForFailedAPI marks the policies targeting the API as not attached when
+// the references of the API could not be resolved.
+func (apiReconciler *APIReconciler) updatePolicyStatusForFailedAPI(ctx context.Context, api dpv1alpha3.API, resolveErr error) {
+	apiName := utils.NamespacedName(&api).String()
+	message := fmt.Sprintf("API %s could not be deployed: %s", apiName, status.Error2ConditionMsg(resolveErr))
+	refs := make(map[string]*policyStatusRef)
+	if authentications, err := apiReconciler.getAuthenticationsForAPI(ctx, api); err == nil {
+		for _, auth := range authentications {
+			refs[constants.KindAuthentication+"/"+utils.NamespacedName(&auth).String()] = &policyStatusRef{
+				namespacedName: utils.NamespacedName(&auth), newObject: func() k8client.Object { return new(dpv1alpha2.Authentication) }}
+		}
+	}
+	if apiPolicies, err := apiReconciler.getAPIPoliciesForAPI(ctx, api); err == nil {
+		for _, apiPolicy := range apiPolicies {
+			refs[constants.KindAPIPolicy+"/"+utils.NamespacedName(&apiPolicy).String()] = &policyStatusRef{
+				namespacedName: utils.NamespacedName(&apiPolicy), newObject: func() k8client.Object { return new(dpv1alpha3.APIPolicy) }}
+		}
+	}
+	if ratelimitPolicies, err := apiReconciler.getRatelimitPoliciesForAPI(ctx, api); err == nil {
+		for _, ratelimitPolicy := range ratelimitPolicies {
+			refs[constants.KindRateLimitPolicy+"/"+utils.NamespacedName(&ratelimitPolicy).String()] = &policyStatusRef{
+				namespacedName: utils.NamespacedName(&ratelimitPolicy), newObject: func() k8client.Object { return new(dpv1alpha3.RateLimitPolicy) }}
+		}
+	}
+	if authorizationPolicies, err := apiReconciler.getAuthorizationPoliciesForAPI(ctx, api); err == nil {
+		for _, authorizationPolicy := range authorizationPolicies {
+			refs[constants.KindAuthorizationPolicy+"/"+utils.NamespacedName(&authorizationPolicy).String()] = &policyStatusRef{
+				namespacedName: utils.NamespacedName(&authorizationPolicy), newObject: func() k8client.Object { return new(dpv1alpha3.AuthorizationPolicy) }}
+		}
+	}
+	for _, ref := range refs {
+		apiReconciler.sendPolicyStatus(ref.namespacedName, ref.newObject(), func(obj k8client.Object) {
+			if status.RemoveAttachedAPI(obj, apiName) == 0 {
+				status.SetPolicyCondition(obj, commonconstants.ConditionAttached, metav1.ConditionFalse,
+					commonconstants.ReasonNotAttached, message)
+			}
+		})
+	}
+}
+
+// resolveBackend resolves the given Backend of the API. The Backend is marked as unresolved with
+// the cause when it exists but its references could not be resolved.
+func (apiReconciler *APIReconciler) resolveBackend(ctx context.Context, backendNamespacedName types.NamespacedName,
+	api dpv1alpha3.API) *dpv1alpha2.ResolvedBackend {
+	resolvedBackend, err := utils.ResolveBackend(ctx, apiReconciler.client, backendNamespacedName, &api)
+	if err != nil {
+		apiReconciler.updateBackendUnresolvedStatus(ctx, backendNamespacedName, api, err)
+		return nil
+	}
+	return resolvedBackend
+}
+
+// updateBackendUnresolvedStatus marks a Backend whose references could not be resolved with the
+// cause of the failure.
+func (apiReconciler *APIReconciler) updateBackendUnresolvedStatus(ctx context.Context, backendNamespacedName types.NamespacedName,
+	api dpv1alpha3.API, resolveErr error) {
+	var backend dpv1alpha2.Backend
+	if err := apiReconciler.client.Get(ctx, backendNamespacedName, &backend); err != nil {
+		if !k8error.IsNotFound(err) {
+			loggers.LoggerAPKOperator.Debugf("Unable to get Backend %s for status update: %v", backendNamespacedName.String(), err)
+		}
+		return
+	}
+	message := fmt.Sprintf("Unable to resolve the Backend for API %s: %s", utils.NamespacedName(&api).String(),
+		status.Error2ConditionMsg(resolveErr))
+	apiReconciler.sendPolicyStatus(backendNamespacedName, new(dpv1alpha2.Backend), func(obj k8client.Object) {
+		status.SetPolicyCondition(obj, commonconstants.ConditionResolvedRefs, metav1.ConditionFalse,
+			commonconstants.ReasonRefNotFound, message)
+	})
+}
+
+// updatePolicyTargetStatus sets the Attached condition of a policy to false when its
+// target API does not exist.
+func (apiReconciler *APIReconciler) updatePolicyTargetStatus(ctx context.Context, policy k8client.Object, target types.NamespacedName) {
+	var api dpv1alpha3.API
+	if err := apiReconciler.client.Get(ctx, target, &api); err == nil || !k8error.IsNotFound(err) {
+		return
+	}
+	if !apiReconciler.policyExists(ctx, policy) {
+		return
+	}
+	message := fmt.Sprintf("Target API %s is not found", target.String())
+	apiReconciler.sendPolicyStatus(utils.NamespacedName(policy), policy.DeepCopyObject().(k8client.Object), func(obj k8client.Object) {
+		status.RemoveAttachedAPI(obj, target.String())
+		status.SetPolicyCondition(obj, commonconstants.ConditionAttached, metav1.ConditionFalse,
+			commonconstants.ReasonTargetNotFound, message)
+	})
+}
+
+// updatePolicyInvalidStatus marks a policy as not accepted.
+func (apiReconciler *APIReconciler) updatePolicyInvalidStatus(ctx context.Context, policy k8client.Object, message string) {
+	if !apiReconciler.policyExists(ctx, policy) {
+		return
+	}
+	apiReconciler.sendPolicyStatus(utils.NamespacedName(policy), policy.DeepCopyObject().(k8client.Object), func(obj k8client.Object) {
+		status.SetPolicyCondition(obj, commonconstants.ConditionAccepted, metav1.ConditionFalse,
+			commonconstants.ReasonInvalid, message)
+	})
+}
+
+// policyExists checks whether the policy is still available, as the watch handlers are
+// triggered for deleted policies as well.
+func (apiReconciler *APIReconciler) policyExists(ctx context.Context, policy k8client.Object) bool {
+	return apiReconciler.client.Get(ctx, utils.NamespacedName(policy), policy.DeepCopyObject().(k8client.Object)) == nil
+}
+
+// sendPolicyStatus sends a status update for a policy CR to the status update handler.
+func (apiReconciler *APIReconciler) sendPolicyStatus(namespacedName types.NamespacedName, resource k8client.Object,
+	updatePolicyStatus func(k8client.Object)) {
+	if apiReconciler.statusUpdater == nil {
+		return
+	}
+	apiReconciler.statusUpdater.Send(status.Update{
+		NamespacedName: namespacedName,
+		Resource:       resource,
+		UpdateStatus: func(obj k8client.Object) k8client.Object {
+			objCopy, ok := obj.DeepCopyObject().(k8client.Object)
+			if !ok {
+				loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2626, logging.BLOCKER, "Unsupported object type %T", obj))
+				return obj
+			}
+			updatePolicyStatus(objCopy)
+			return objCopy
+		},
+	})
+}
+
+// referencedAIProviders returns the distinct AIProviders referred by the APIPolicies of the API.
+func referencedAIProviders(apiState synchronizer.APIState) []string {
+	providers := []string{}
+	seen := make(map[string]bool)
+	for _, apiPolicies := range []map[string]dpv1alpha3.APIPolicy{apiState.APIPolicies, apiState.ResourceAPIPolicies} {
+		for _, apiPolicy := range apiPolicies {
+			for _, policySpec := range []*dpv1alpha3.PolicySpec{apiPolicy.Spec.Default, apiPolicy.Spec.Override} {
+				if policySpec != nil && policySpec.AIProvider != nil && !seen[policySpec.AIProvider.Name] {
+					seen[policySpec.AIProvider.Name] = true
+					providers = append(providers, policySpec.AIProvider.Name)
+				}
+			}
+		}
+	}
+	return providers
+}
+
+func backendMappingOfHTTPRoute(routeState *synchronizer.HTTPRouteState) map[string]*dpv1alpha2.ResolvedBackend {
+	if routeState == nil {
+		return nil
+	}
+	return routeState.BackendMapping
+}
+
+func backendMappingOfGQLRoute(routeState *synchronizer.GQLRouteState) map[string]*dpv1alpha2.ResolvedBackend {
+	if routeState == nil {
+		return nil
+	}
+	return routeState.BackendMapping
+}
+
+func backendMappingOfGRPCRoute(routeState *synchronizer.GRPCRouteState) map[string]*dpv1alpha2.ResolvedBackend {
+	if routeState == nil {
+		return nil
+	}
+	return routeState.BackendMapping
+}
@@ -0,0 +1,238 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package dp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/apk/adapter/internal/operator/status"
+	"github.com/wso2/apk/adapter/internal/operator/synchronizer"
+	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	commonconstants "github.com/wso2/apk/common-go-libs/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8client "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newPolicyStatusTestReconciler returns an API reconciler backed by a fake client holding the given
+// objects, with a running status update handler.
+func newPolicyStatusTestReconciler(t *testing.T, objects ...k8client.Object) (*APIReconciler, k8client.Client) {
+	scheme := runtime.NewScheme()
+	assert.Nil(t, clientgoscheme.AddToScheme(scheme))
+	assert.Nil(t, dpv1alpha2.AddToScheme(scheme))
+	assert.Nil(t, dpv1alpha3.AddToScheme(scheme))
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+		WithStatusSubresource(objects...).Build()
+	statusUpdater := status.NewUpdateHandler(client)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go statusUpdater.Start(ctx)
+	return &APIReconciler{client: client, statusUpdater: statusUpdater}, client
+}
+
+// waitForPolicyStatus waits until the status of the given CR satisfies the given condition.
+func waitForPolicyStatus(t *testing.T, client k8client.Client, obj k8client.Object, condition func() bool) {
+	assert.Eventually(t, func() bool {
+		return client.Get(context.Background(), types.NamespacedName{Namespace: obj.GetNamespace(),
+			Name: obj.GetName()}, obj) == nil && condition()
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func findCondition(conditions []metav1.Condition, conditionType string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func TestCollectPolicyStatusRefs(t *testing.T) {
+	api := &dpv1alpha3.API{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}}
+	older := metav1.NewTime(time.Now().Add(-time.Hour))
+	oldPolicy := dpv1alpha3.APIPolicy{ObjectMeta: metav1.ObjectMeta{Name: "old-policy", Namespace: "default",
+		CreationTimestamp: older}}
+	oldPolicy.Spec.Override = &dpv1alpha3.PolicySpec{AIProvider: &dpv1alpha3.AIProviderReference{Name: "provider-a"}}
+	resourcePolicy := dpv1alpha3.APIPolicy{ObjectMeta: metav1.ObjectMeta{Name: "resource-policy", Namespace: "default"}}
+	resourcePolicy.Spec.Override = &dpv1alpha3.PolicySpec{AIProvider: &dpv1alpha3.AIProviderReference{Name: "provider-b"}}
+	apiState := synchronizer.APIState{
+		APIDefinition: api,
+		APIPolicies: map[string]dpv1alpha3.APIPolicy{
+			"default/old-policy": oldPolicy,
+			"default/new-policy": {ObjectMeta: metav1.ObjectMeta{Name: "new-policy", Namespace: "default",
+				CreationTimestamp: metav1.Now()}},
+		},
+		ResourceAPIPolicies: map[string]dpv1alpha3.APIPolicy{"default/resource-policy": resourcePolicy},
+		AIProvider:          &dpv1alpha3.AIProvider{ObjectMeta: metav1.ObjectMeta{Name: "provider-a", Namespace: "default"}},
+		ProdHTTPRoute: &synchronizer.HTTPRouteState{BackendMapping: map[string]*dpv1alpha2.ResolvedBackend{
+			"default/backend": {Backend: dpv1alpha2.Backend{ObjectMeta: metav1.ObjectMeta{Name: "backend",
+				Namespace: "default"}}},
+			// backends resolved from services are not Backend CRs
+			"default/service": {},
+		}},
+	}
+
+	refs := collectPolicyStatusRefs(apiState)
+	assert.Len(t, refs, 5)
+
+	oldPolicyRef := refs["APIPolicy/default/old-policy"]
+	assert.True(t, oldPolicyRef.targetsAPI)
+	assert.Empty(t, oldPolicyRef.conflict, "The oldest APIPolicy should take precedence.")
+	newPolicyRef := refs["APIPolicy/default/new-policy"]
+	assert.Equal(t, commonconstants.ReasonOverridden, newPolicyRef.conflictReason)
+	assert.Contains(t, newPolicyRef.conflict, "default/old-policy")
+	assert.False(t, refs["APIPolicy/default/resource-policy"].targetsAPI,
+		"Resource level policies are attached through the routes of the APIs.")
+
+	aiProvider := refs["AIProvider/default/provider-a"]
+	assert.Equal(t, commonconstants.ReasonMultiplePolicies, aiProvider.conflictReason)
+	assert.False(t, aiProvider.targetsAPI)
+	assert.NotNil(t, refs["Backend/default/backend"])
+}
+
+func TestCollectPolicyStatusRefsComparesNamespaces(t *testing.T) {
+	older := metav1.NewTime(time.Now().Add(-time.Hour))
+	apiState := synchronizer.APIState{
+		APIDefinition: &dpv1alpha3.API{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}},
+		RateLimitPolicies: map[string]dpv1alpha3.RateLimitPolicy{
+			"default/ratelimit": {ObjectMeta: metav1.ObjectMeta{Name: "ratelimit", Namespace: "default",
+				CreationTimestamp: older}},
+			"apk/ratelimit": {ObjectMeta: metav1.ObjectMeta{Name: "ratelimit", Namespace: "apk",
+				CreationTimestamp: metav1.Now()}},
+		},
+	}
+
+	refs := collectPolicyStatusRefs(apiState)
+	assert.Empty(t, refs["RateLimitPolicy/default/ratelimit"].conflict, "The oldest RateLimitPolicy should take precedence.")
+	assert.Equal(t, commonconstants.ReasonOverridden, refs["RateLimitPolicy/apk/ratelimit"].conflictReason,
+		"A policy with the same name in another namespace should be reported as overridden.")
+	assert.Contains(t, refs["RateLimitPolicy/apk/ratelimit"].conflict, "default/ratelimit")
+}
+
+func TestGetStalePolicyRefs(t *testing.T) {
+	previousRefs := map[string]*policyStatusRef{"Backend/default/a": {}, "Backend/default/b": {}}
+	currentRefs := map[string]*policyStatusRef{"Backend/default/b": {}, "Backend/default/c": {}}
+	staleRefs := getStalePolicyRefs(previousRefs, currentRefs)
+	assert.Len(t, staleRefs, 1)
+	assert.Contains(t, staleRefs, "Backend/default/a")
+	assert.Len(t, getStalePolicyRefs(previousRefs, nil), 2)
+	assert.Empty(t, getStalePolicyRefs(nil, currentRefs))
+}
+
+func TestUpdatePolicyStatusForAPIPrunesStaleAttachments(t *testing.T) {
+	apiPolicy := &dpv1alpha3.APIPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"},
+		Status: dpv1alpha3.APIPolicyStatus{AttachedAPIs: []string{"default/old-api"}}}
+	backend := &dpv1alpha2.Backend{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"}}
+	apiReconciler, client := newPolicyStatusTestReconciler(t, apiPolicy, backend)
+
+	apiState := synchronizer.APIState{
+		APIDefinition: &dpv1alpha3.API{ObjectMeta: metav1.ObjectMeta{Name: "new-api", Namespace: "default"}},
+		APIPolicies:   map[string]dpv1alpha3.APIPolicy{"default/policy": *apiPolicy},
+		ProdHTTPRoute: &synchronizer.HTTPRouteState{BackendMapping: map[string]*dpv1alpha2.ResolvedBackend{
+			"default/backend": {Backend: *backend}}},
+	}
+	apiReconciler.updatePolicyStatusForAPI(apiState)
+	waitForPolicyStatus(t, client, apiPolicy, func() bool {
+		return len(apiPolicy.Status.AttachedAPIs) == 1 && apiPolicy.Status.AttachedAPIs[0] == "default/new-api"
+	})
+	accepted := findCondition(apiPolicy.Status.Conditions, commonconstants.ConditionAccepted)
+	assert.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionTrue, accepted.Status)
+	waitForPolicyStatus(t, client, backend, func() bool {
+		return len(backend.Status.AttachedAPIs) == 1
+	})
+
+	// The backend is removed from the route of the API
+	apiState.ProdHTTPRoute = &synchronizer.HTTPRouteState{}
+	apiReconciler.updatePolicyStatusForAPI(apiState)
+	waitForPolicyStatus(t, client, backend, func() bool {
+		attached := findCondition(backend.Status.Conditions, commonconstants.ConditionAttached)
+		return len(backend.Status.AttachedAPIs) == 0 && attached != nil && attached.Status == metav1.ConditionFalse
+	})
+
+	// The API is deleted
+	apiReconciler.detachPoliciesFromAPI(apiState)
+	waitForPolicyStatus(t, client, apiPolicy, func() bool {
+		return len(apiPolicy.Status.AttachedAPIs) == 0
+	})
+	assert.Empty(t, apiReconciler.attachedPolicyRefs)
+}
+
+func TestResolveBackendReportsCause(t *testing.T) {
+	invalidCertificate := "invalid certificate"
+	backend := &dpv1alpha2.Backend{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"},
+		Spec: dpv1alpha2.BackendSpec{
+			Services: []dpv1alpha2.Service{{Host: "backend.default", Port: 443}},
+			TLS:      &dpv1alpha2.TLSConfig{CertificateInline: &invalidCertificate},
+		}}
+	apiReconciler, client := newPolicyStatusTestReconciler(t, backend)
+	api := dpv1alpha3.API{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}}
+
+	assert.Nil(t, apiReconciler.resolveBackend(context.Background(),
+		types.NamespacedName{Namespace: "default", Name: "backend"}, api))
+	waitForPolicyStatus(t, client, backend, func() bool {
+		return findCondition(backend.Status.Conditions, commonconstants.ConditionResolvedRefs) != nil
+	})
+	resolvedRefs := findCondition(backend.Status.Conditions, commonconstants.ConditionResolvedRefs)
+	assert.Equal(t, metav1.ConditionFalse, resolvedRefs.Status)
+	assert.Contains(t, resolvedRefs.Message, "TLS certificate")
+	assert.Contains(t, resolvedRefs.Message, "failed to decode certificate PEM")
+
+	// A missing Backend has no status to update
+	assert.Nil(t, apiReconciler.resolveBackend(context.Background(),
+		types.NamespacedName{Namespace: "default", Name: "missing-backend"}, api))
+}
+
+func TestValidateAuthorizationPoliciesRejectsInvalidRules(t *testing.T) {
+	invalidPolicy := &dpv1alpha3.AuthorizationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "default"},
+		Spec: dpv1alpha3.AuthorizationPolicySpec{Override: &dpv1alpha3.AuthorizationRules{Rules: []dpv1alpha3.AuthorizationRule{
+			{Name: "admins", Language: "APKExpression", Expression: `"admin" in claims.groups`},
+			{Name: "tenant", Language: "APKExpression", Expression: `request.tenant == "a"`},
+		}}}}
+	apiReconciler, client := newPolicyStatusTestReconciler(t, invalidPolicy)
+	apiState := &synchronizer.APIState{
+		APIDefinition: &dpv1alpha3.API{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec: dpv1alpha3.APISpec{APIType: "REST"}},
+		ResourceAuthorizationPolicies: map[string]dpv1alpha3.AuthorizationPolicy{"default/invalid": *invalidPolicy},
+	}
+
+	err := apiReconciler.validateAuthorizationPolicies(context.Background(), apiState)
+	assert.ErrorContains(t, err, "tenant")
+	waitForPolicyStatus(t, client, invalidPolicy, func() bool {
+		accepted := findCondition(invalidPolicy.Status.Conditions, commonconstants.ConditionAccepted)
+		return accepted != nil && accepted.Status == metav1.ConditionFalse
+	})
+
+	// Rules are only enforced for REST APIs
+	apiState.ResourceAuthorizationPolicies = nil
+	apiState.AuthorizationPolicies = map[string]dpv1alpha3.AuthorizationPolicy{"default/valid": {
+		ObjectMeta: metav1.ObjectMeta{Name: "valid", Namespace: "default"},
+		Spec: dpv1alpha3.AuthorizationPolicySpec{Default: &dpv1alpha3.AuthorizationRules{Rules: []dpv1alpha3.AuthorizationRule{
+			{Name: "admins", Expression: `"admin" in claims.groups`},
+		}}}}}
+	assert.Nil(t, apiReconciler.validateAuthorizationPolicies(context.Background(), apiState))
+	apiState.APIDefinition.Spec.APIType = "GraphQL"
+	assert.ErrorContains(t, apiReconciler.validateAuthorizationPolicies(context.Background(), apiState), "GraphQL")
+}
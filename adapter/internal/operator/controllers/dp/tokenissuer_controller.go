@@ -20,17 +20,21 @@ package dp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"github.com/wso2/apk/adapter/config"
 	"github.com/wso2/apk/adapter/internal/discovery/xds"
 	"github.com/wso2/apk/adapter/internal/loggers"
 	"github.com/wso2/apk/adapter/internal/operator/constants"
+	"github.com/wso2/apk/adapter/internal/operator/status"
 	"github.com/wso2/apk/adapter/internal/operator/utils"
 	"github.com/wso2/apk/adapter/pkg/discovery/api/wso2/discovery/subscription"
 	"github.com/wso2/apk/adapter/pkg/logging"
 	dpv1alpha1 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha1"
 	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
+	commonconstants "github.com/wso2/apk/common-go-libs/constants"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -53,8 +57,9 @@ const (
 
 // TokenssuerReconciler reconciles a TokenIssuer object
 type TokenssuerReconciler struct {
-	client k8client.Client
-	Scheme *runtime.Scheme
+	client        k8client.Client
+	Scheme        *runtime.Scheme
+	statusUpdater *status.UpdateHandler
 }
 
 //+kubebuilder:rbac:groups=dp.wso2.com,resources=jwtissuers,verbs=get;list;watch;create;update;patch;delete
@@ -74,20 +79,56 @@ func (r *TokenssuerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	var err error
 	loggers.LoggerAPKOperator.Debugf("Reconciling jwtIssuer: %v", req.NamespacedName.String())
 	jwtKey := req.NamespacedName
-	jwtIssuerMapping, err := getJWTIssuers(ctx, r.client, jwtKey)
+	jwtIssuerMapping, unresolvedJWTIssuers, err := getJWTIssuers(ctx, r.client, jwtKey)
 	if err != nil {
 		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2660, logging.CRITICAL,
 			"Unable to resolve JWTIssuers after updating %s : %s", req.NamespacedName.String(), err.Error()))
 		return ctrl.Result{}, nil
 	}
 	UpdateEnforcerJWTIssuers(jwtIssuerMapping)
+	if _, found := jwtIssuerMapping[jwtKey]; found {
+		r.updateTokenIssuerStatus(jwtKey, nil)
+	} else if resolveErr, found := unresolvedJWTIssuers[jwtKey]; found {
+		r.updateTokenIssuerStatus(jwtKey, resolveErr)
+	}
 	return ctrl.Result{}, nil
 }
 
+// updateTokenIssuerStatus sets the Accepted and ResolvedRefs conditions of the TokenIssuer
+// based on whether its certificates were resolved.
+func (r *TokenssuerReconciler) updateTokenIssuerStatus(jwtKey types.NamespacedName, resolveErr error) {
+	if r.statusUpdater == nil {
+		return
+	}
+	r.statusUpdater.Send(status.Update{
+		NamespacedName: jwtKey,
+		Resource:       new(dpv1alpha2.TokenIssuer),
+		UpdateStatus: func(obj k8client.Object) k8client.Object {
+			tokenIssuer, ok := obj.(*dpv1alpha2.TokenIssuer)
+			if !ok {
+				loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2626, logging.BLOCKER, "Unsupported object type %T", obj))
+				return obj
+			}
+			tokenIssuerCopy := tokenIssuer.DeepCopy()
+			status.SetPolicyCondition(tokenIssuerCopy, commonconstants.ConditionAccepted, metav1.ConditionTrue,
+				commonconstants.ReasonAccepted, "Resource is accepted")
+			if resolveErr != nil {
+				status.SetPolicyCondition(tokenIssuerCopy, commonconstants.ConditionResolvedRefs, metav1.ConditionFalse,
+					commonconstants.ReasonRefNotFound, status.Error2ConditionMsg(resolveErr))
+			} else {
+				status.SetPolicyCondition(tokenIssuerCopy, commonconstants.ConditionResolvedRefs, metav1.ConditionTrue,
+					commonconstants.ReasonResolvedRefs, "All references are resolved")
+			}
+			return tokenIssuerCopy
+		},
+	})
+}
+
 // NewTokenIssuerReconciler creates a new Application controller instance.
-func NewTokenIssuerReconciler(mgr manager.Manager) error {
+func NewTokenIssuerReconciler(mgr manager.Manager, statusUpdater *status.UpdateHandler) error {
 	r := &TokenssuerReconciler{
-		client: mgr.GetClient(),
+		client:        mgr.GetClient(),
+		statusUpdater: statusUpdater,
 	}
 	ctx := context.Background()
 
@@ -130,7 +171,7 @@ func NewTokenIssuerReconciler(mgr manager.Manager) error {
 
 func (r *TokenssuerReconciler) populateTokenReconcileRequestsForConfigMap(ctx context.Context, obj *corev1.ConfigMap) []reconcile.Request {
 	configMap := obj
-	tokenIssuerList := &dpv1alpha1.TokenIssuerList{}
+	tokenIssuerList := &dpv1alpha2.TokenIssuerList{}
 	err := r.client.List(ctx, tokenIssuerList, &k8client.ListOptions{
 		FieldSelector: fields.OneTermEqualSelector(configmapIssuerIndex, utils.NamespacedName(configMap).String()),
 	})
@@ -154,7 +195,7 @@ func (r *TokenssuerReconciler) populateTokenReconcileRequestsForConfigMap(ctx co
 
 func (r *TokenssuerReconciler) populateTokenReconcileRequestsForSecret(ctx context.Context, obj *corev1.Secret) []reconcile.Request {
 	secret := obj
-	tokenIssuerList := &dpv1alpha1.TokenIssuerList{}
+	tokenIssuerList := &dpv1alpha2.TokenIssuerList{}
 	err := r.client.List(ctx, tokenIssuerList, &k8client.ListOptions{
 		FieldSelector: fields.OneTermEqualSelector(secretTokenIssuerIndex, utils.NamespacedName(secret).String()),
 	})
@@ -180,10 +221,27 @@ func (r *TokenssuerReconciler) populateTokenReconcileRequestsForSecret(ctx conte
 func addTokenIssuerIndexes(ctx context.Context, mgr manager.Manager) error {
 
 	// Secret to TokenIssuer indexer
-	if err := mgr.GetFieldIndexer().IndexField(ctx, &dpv1alpha1.TokenIssuer{}, secretTokenIssuerIndex,
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &dpv1alpha2.TokenIssuer{}, secretTokenIssuerIndex,
 		func(rawObj k8client.Object) []string {
-			jwtIssuer := rawObj.(*dpv1alpha1.TokenIssuer)
+			jwtIssuer := rawObj.(*dpv1alpha2.TokenIssuer)
 			var secrets []string
+			if jwtIssuer.Spec.Introspection != nil {
+				secrets = append(secrets,
+					types.NamespacedName{
+						Name:      jwtIssuer.Spec.Introspection.ClientSecretRef.Name,
+						Namespace: jwtIssuer.Namespace,
+					}.String())
+				if jwtIssuer.Spec.Introspection.TLS != nil && jwtIssuer.Spec.Introspection.TLS.SecretRef != nil && len(jwtIssuer.Spec.Introspection.TLS.SecretRef.Name) > 0 {
+					secrets = append(secrets,
+						types.NamespacedName{
+							Name:      string(jwtIssuer.Spec.Introspection.TLS.SecretRef.Name),
+							Namespace: jwtIssuer.Namespace,
+						}.String())
+				}
+			}
+			if jwtIssuer.Spec.SignatureValidation == nil {
+				return secrets
+			}
 			if jwtIssuer.Spec.SignatureValidation.Certificate != nil && jwtIssuer.Spec.SignatureValidation.Certificate.SecretRef != nil && len(jwtIssuer.Spec.SignatureValidation.Certificate.SecretRef.Name) > 0 {
 				secrets = append(secrets,
 					types.NamespacedName{
@@ -203,10 +261,20 @@ func addTokenIssuerIndexes(ctx context.Context, mgr manager.Manager) error {
 		return err
 	}
 	// Configmap to TokenIssuer indexer
-	err := mgr.GetFieldIndexer().IndexField(ctx, &dpv1alpha1.TokenIssuer{}, configmapIssuerIndex,
+	err := mgr.GetFieldIndexer().IndexField(ctx, &dpv1alpha2.TokenIssuer{}, configmapIssuerIndex,
 		func(rawObj k8client.Object) []string {
-			tokenIssuer := rawObj.(*dpv1alpha1.TokenIssuer)
+			tokenIssuer := rawObj.(*dpv1alpha2.TokenIssuer)
 			var configMaps []string
+			if tokenIssuer.Spec.Introspection != nil && tokenIssuer.Spec.Introspection.TLS != nil && tokenIssuer.Spec.Introspection.TLS.ConfigMapRef != nil && len(tokenIssuer.Spec.Introspection.TLS.ConfigMapRef.Name) > 0 {
+				configMaps = append(configMaps,
+					types.NamespacedName{
+						Name:      string(tokenIssuer.Spec.Introspection.TLS.ConfigMapRef.Name),
+						Namespace: tokenIssuer.Namespace,
+					}.String())
+			}
+			if tokenIssuer.Spec.SignatureValidation == nil {
+				return configMaps
+			}
 			if tokenIssuer.Spec.SignatureValidation.Certificate != nil && tokenIssuer.Spec.SignatureValidation.Certificate.ConfigMapRef != nil && len(tokenIssuer.Spec.SignatureValidation.Certificate.ConfigMapRef.Name) > 0 {
 				configMaps = append(configMaps,
 					types.NamespacedName{
@@ -253,6 +321,19 @@ func marshalJWTIssuerList(jwtIssuerMapping dpv1alpha1.JWTIssuerMapping) *subscri
 			}
 			certificate.Jwks = jwks
 		}
+		if internalJWTIssuer.Introspection != nil {
+			introspection := &subscription.Introspection{
+				Url:              internalJWTIssuer.Introspection.URL,
+				ClientId:         internalJWTIssuer.Introspection.ClientID,
+				ClientSecret:     internalJWTIssuer.Introspection.ClientSecret,
+				PositiveCacheTTL: internalJWTIssuer.Introspection.PositiveCacheTTL,
+				NegativeCacheTTL: internalJWTIssuer.Introspection.NegativeCacheTTL,
+			}
+			if internalJWTIssuer.Introspection.TLS != nil {
+				introspection.Tls = internalJWTIssuer.Introspection.TLS.ResolvedCertificate
+			}
+			jwtIssuer.Introspection = introspection
+		}
 		jwtIssuer.ClaimMapping = internalJWTIssuer.ClaimMappings
 		jwtIssuer.Certificate = certificate
 		jwtIssuer.Environments = internalJWTIssuer.Environments
@@ -264,12 +345,15 @@ func marshalJWTIssuerList(jwtIssuerMapping dpv1alpha1.JWTIssuerMapping) *subscri
 	return &subscription.JWTIssuerList{List: jwtIssuers}
 }
 
-// getJWTIssuers returns the JWTIssuers for the given JWTIssuerMapping
-func getJWTIssuers(ctx context.Context, client k8client.Client, namespace types.NamespacedName) (dpv1alpha1.JWTIssuerMapping, error) {
+// getJWTIssuers returns the JWTIssuers for the given JWTIssuerMapping along with the
+// JWTIssuers which were skipped as their certificates could not be resolved
+func getJWTIssuers(ctx context.Context, client k8client.Client, namespace types.NamespacedName) (dpv1alpha1.JWTIssuerMapping,
+	map[types.NamespacedName]error, error) {
 	jwtIssuerMapping := make(dpv1alpha1.JWTIssuerMapping)
+	unresolvedJWTIssuers := make(map[types.NamespacedName]error)
 	jwtIssuerList := &dpv1alpha2.TokenIssuerList{}
 	if err := client.List(ctx, jwtIssuerList); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	for _, jwtIssuer := range jwtIssuerList.Items {
 		resolvedJwtIssuer := dpv1alpha1.ResolvedJWTIssuer{}
@@ -280,7 +364,7 @@ func getJWTIssuers(ctx context.Context, client k8client.Client, namespace types.
 		resolvedJwtIssuer.Environments = getTokenIssuerEnvironments(jwtIssuer.Spec.Environments)
 
 		signatureValidation := dpv1alpha1.ResolvedSignatureValidation{}
-		if jwtIssuer.Spec.SignatureValidation.JWKS != nil && len(jwtIssuer.Spec.SignatureValidation.JWKS.URL) > 0 {
+		if jwtIssuer.Spec.SignatureValidation != nil && jwtIssuer.Spec.SignatureValidation.JWKS != nil && len(jwtIssuer.Spec.SignatureValidation.JWKS.URL) > 0 {
 			jwks := &dpv1alpha1.ResolvedJWKS{}
 			jwks.URL = jwtIssuer.Spec.SignatureValidation.JWKS.URL
 			if jwtIssuer.Spec.SignatureValidation.JWKS.TLS != nil {
@@ -290,23 +374,42 @@ func getJWTIssuers(ctx context.Context, client k8client.Client, namespace types.
 				if err != nil {
 					loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2659, logging.MAJOR,
 						"Error resolving certificate for JWKS for issuer %s in CR %s, %v", resolvedJwtIssuer.Issuer, utils.NamespacedName(&jwtIssuer).String(), err.Error()))
+					unresolvedJWTIssuers[utils.NamespacedName(&jwtIssuer)] = err
 					continue
 				}
 				jwks.TLS = &dpv1alpha1.ResolvedTLSConfig{ResolvedCertificate: tlsCertificate}
 			}
 			signatureValidation.JWKS = jwks
 		}
-		if jwtIssuer.Spec.SignatureValidation.Certificate != nil {
+		if jwtIssuer.Spec.SignatureValidation != nil && jwtIssuer.Spec.SignatureValidation.Certificate != nil {
 			tlsCertificate, err := utils.ResolveCertificate(ctx, client, jwtIssuer.ObjectMeta.Namespace,
 				jwtIssuer.Spec.SignatureValidation.Certificate.CertificateInline,
 				jwtIssuer.Spec.SignatureValidation.Certificate.ConfigMapRef, jwtIssuer.Spec.SignatureValidation.Certificate.SecretRef)
 			if err != nil {
 				loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2659, logging.MAJOR,
 					"Error resolving certificate for JWKS for issuer %s in CR %s, %v", resolvedJwtIssuer.Issuer, utils.NamespacedName(&jwtIssuer).String(), err.Error()))
+				unresolvedJWTIssuers[utils.NamespacedName(&jwtIssuer)] = err
 				continue
 			}
 			signatureValidation.Certificate = &dpv1alpha1.ResolvedTLSConfig{ResolvedCertificate: tlsCertificate}
 		}
+		if jwtIssuer.Spec.Introspection != nil {
+			introspection, err := getResolvedIntrospection(ctx, client, jwtIssuer.Namespace, jwtIssuer.Spec.Introspection)
+			if err != nil {
+				loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2659, logging.MAJOR,
+					"Error resolving introspection for issuer %s in CR %s, %v", resolvedJwtIssuer.Issuer, utils.NamespacedName(&jwtIssuer).String(), err.Error()))
+				unresolvedJWTIssuers[utils.NamespacedName(&jwtIssuer)] = err
+				continue
+			}
+			resolvedJwtIssuer.Introspection = introspection
+		}
+		if signatureValidation.JWKS == nil && signatureValidation.Certificate == nil && resolvedJwtIssuer.Introspection == nil {
+			err := errors.New("signatureValidation with a JWKS or a certificate is required unless introspection is configured")
+			loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2659, logging.MAJOR,
+				"Error resolving issuer %s in CR %s, %v", resolvedJwtIssuer.Issuer, utils.NamespacedName(&jwtIssuer).String(), err.Error()))
+			unresolvedJWTIssuers[utils.NamespacedName(&jwtIssuer)] = err
+			continue
+		}
 		resolvedJwtIssuer.SignatureValidation = signatureValidation
 		jwtIssuerMappingName := types.NamespacedName{
 			Name:      jwtIssuer.Name,
@@ -319,8 +422,34 @@ func getJWTIssuers(ctx context.Context, client k8client.Client, namespace types.
 		}
 		jwtIssuerMapping[jwtIssuerMappingName] = &resolvedJwtIssuer
 	}
-	return jwtIssuerMapping, nil
+	return jwtIssuerMapping, unresolvedJWTIssuers, nil
 }
+
+// getResolvedIntrospection resolves the TLS certificate and the client credentials of the introspection endpoint
+func getResolvedIntrospection(ctx context.Context, client k8client.Client, namespace string,
+	introspection *dpv1alpha2.Introspection) (*dpv1alpha1.ResolvedIntrospection, error) {
+	resolvedIntrospection := &dpv1alpha1.ResolvedIntrospection{
+		URL:              introspection.URL,
+		PositiveCacheTTL: introspection.PositiveCacheTTL,
+		NegativeCacheTTL: introspection.NegativeCacheTTL,
+	}
+	if introspection.TLS != nil {
+		tlsCertificate, err := utils.ResolveCertificate(ctx, client, namespace,
+			introspection.TLS.CertificateInline, introspection.TLS.ConfigMapRef, introspection.TLS.SecretRef)
+		if err != nil {
+			return nil, err
+		}
+		resolvedIntrospection.TLS = &dpv1alpha1.ResolvedTLSConfig{ResolvedCertificate: tlsCertificate}
+	}
+	clientID, clientSecret, err := utils.GetClientCredentials(ctx, client, namespace, introspection.ClientSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	resolvedIntrospection.ClientID = clientID
+	resolvedIntrospection.ClientSecret = clientSecret
+	return resolvedIntrospection, nil
+}
+
 func getResolvedClaimMapping(claimMappings []dpv1alpha2.ClaimMapping) map[string]string {
 	resolvedClaimMappings := make(map[string]string)
 	for _, claimMapping := range claimMappings {
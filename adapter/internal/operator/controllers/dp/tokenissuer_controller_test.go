@@ -0,0 +1,64 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package dp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetJWTIssuersRequiresSignatureValidationOrIntrospection(t *testing.T) {
+	newIssuer := func(name string) *dpv1alpha2.TokenIssuer {
+		return &dpv1alpha2.TokenIssuer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: dpv1alpha2.TokenIssuerSpec{Name: name, Organization: "default", Issuer: "https://" + name,
+				ConsumerKeyClaim: "azp", ScopesClaim: "scope"},
+		}
+	}
+	withoutValidation := newIssuer("no-validation")
+	withJWKS := newIssuer("jwks")
+	withJWKS.Spec.SignatureValidation = &dpv1alpha2.SignatureValidation{
+		JWKS: &dpv1alpha2.JWKS{URL: "https://idp/jwks"}}
+	introspectionOnly := newIssuer("introspection")
+	introspectionOnly.Spec.Introspection = &dpv1alpha2.Introspection{URL: "https://idp/introspect",
+		ClientSecretRef: dpv1alpha2.ClientSecretRef{Name: "introspection-client"}}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "introspection-client", Namespace: "default"},
+		Data: map[string][]byte{"clientId": []byte("id"), "clientSecret": []byte("secret")}}
+
+	scheme := runtime.NewScheme()
+	assert.Nil(t, clientgoscheme.AddToScheme(scheme))
+	assert.Nil(t, dpv1alpha2.AddToScheme(scheme))
+	client := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(withoutValidation, withJWKS, introspectionOnly, secret).Build()
+
+	mapping, unresolved, err := getJWTIssuers(context.Background(), client, types.NamespacedName{})
+	assert.Nil(t, err)
+	assert.Contains(t, unresolved, types.NamespacedName{Namespace: "default", Name: "no-validation"})
+	assert.NotContains(t, mapping, types.NamespacedName{Namespace: "default", Name: "no-validation"})
+	assert.Contains(t, mapping, types.NamespacedName{Namespace: "default", Name: "jwks"})
+	assert.Contains(t, mapping, types.NamespacedName{Namespace: "default", Name: "introspection"})
+}
@@ -870,6 +870,11 @@ type DestinationEndpoint struct {
 	Host string `json:"host" yaml:"host"`
 	// Port on the service to forward the request to.
 	Port uint32 `json:"port" yaml:"port"`
+	// Weight of the endpoint when load balancing among the endpoints of the same priority. The weight defaults to 1.
+	Weight uint32 `json:"weight,omitempty" yaml:"weight,omitempty"`
+	// Priority of the endpoint, where the endpoints with priority 0 are used first and the traffic fails over to the
+	// endpoints with the next priority when they become unhealthy.
+	Priority uint32 `json:"priority,omitempty" yaml:"priority,omitempty"`
 }
 
 // Validate the fields within the DestinationEndpoint structure
@@ -131,7 +131,7 @@ func New(cfg *rest.Config, resources *message.ProviderResources) (*Provider, err
 		loggers.LoggerAPKOperator.Errorf("Error creating API controller: %v", err)
 	}
 
-	if err := dpcontrollers.NewTokenIssuerReconciler(mgr); err != nil {
+	if err := dpcontrollers.NewTokenIssuerReconciler(mgr, updateHandler); err != nil {
 		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error3114, logging.BLOCKER, "Error creating JWT Issuer controller: %v", err))
 	}
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -1147,10 +1147,12 @@ func (t *Translator) processDestination(backendRefContext BackendRefContext,
 		if backend == nil {
 			return nil, weight
 		}
-		ep := ir.NewDestEndpoint(
-			backend.Spec.Services[0].Host,
-			uint32(backend.Spec.Services[0].Port))
-		endpoints = append(endpoints, ep)
+		for _, service := range backend.Spec.Services {
+			ep := ir.NewDestEndpoint(service.Host, service.Port)
+			ep.Weight = service.Weight
+			ep.Priority = service.Priority
+			endpoints = append(endpoints, ep)
+		}
 	}
 
 	// TODO: support mixed endpointslice address type for the same backendRef
@@ -375,8 +375,6 @@ func buildXdsClusterLoadAssignment(clusterName string, destSettings []*ir.Destin
 	localities := make([]*endpointv3.LocalityLbEndpoints, 0, len(destSettings))
 	for i, ds := range destSettings {
 
-		endpoints := make([]*endpointv3.LbEndpoint, 0, len(ds.Endpoints))
-
 		var metadata *corev3.Metadata
 		if ds.TLS != nil {
 			metadata = &corev3.Metadata{
@@ -390,6 +388,10 @@ func buildXdsClusterLoadAssignment(clusterName string, destSettings []*ir.Destin
 			}
 		}
 
+		// Envoy requires the priorities of the localities to be contiguous, hence the priorities of the endpoints
+		// are mapped to contiguous priority levels.
+		priorityLevels := getEndpointPriorityLevels(ds.Endpoints)
+		endpoints := make([][]*endpointv3.LbEndpoint, max(len(priorityLevels), 1))
 		for _, irEp := range ds.Endpoints {
 			lbEndpoint := &endpointv3.LbEndpoint{
 				Metadata: metadata,
@@ -409,20 +411,14 @@ func buildXdsClusterLoadAssignment(clusterName string, destSettings []*ir.Destin
 					},
 				},
 			}
-			// Set default weight of 1 for all endpoints.
-			lbEndpoint.LoadBalancingWeight = &wrapperspb.UInt32Value{Value: 1}
-			endpoints = append(endpoints, lbEndpoint)
-		}
-
-		// Envoy requires a distinct region to be set for each LocalityLbEndpoints.
-		// If we don't do this, Envoy will merge all LocalityLbEndpoints into one.
-		// We use the name of the backendRef as a pseudo region name.
-		locality := &endpointv3.LocalityLbEndpoints{
-			Locality: &corev3.Locality{
-				Region: fmt.Sprintf("%s/backend/%d", clusterName, i),
-			},
-			LbEndpoints: endpoints,
-			Priority:    0,
+			// Set default weight of 1 for the endpoints without a weight.
+			endpointWeight := irEp.Weight
+			if endpointWeight == 0 {
+				endpointWeight = 1
+			}
+			lbEndpoint.LoadBalancingWeight = &wrapperspb.UInt32Value{Value: endpointWeight}
+			level := priorityLevels[irEp.Priority]
+			endpoints[level] = append(endpoints[level], lbEndpoint)
 		}
 
 		// Set locality weight
@@ -432,13 +428,47 @@ func buildXdsClusterLoadAssignment(clusterName string, destSettings []*ir.Destin
 		} else {
 			weight = 1
 		}
-		locality.LoadBalancingWeight = &wrapperspb.UInt32Value{Value: weight}
 
-		localities = append(localities, locality)
+		for level, levelEndpoints := range endpoints {
+			// Envoy requires a distinct region to be set for each LocalityLbEndpoints.
+			// If we don't do this, Envoy will merge all LocalityLbEndpoints into one.
+			// We use the name of the backendRef as a pseudo region name.
+			region := fmt.Sprintf("%s/backend/%d", clusterName, i)
+			if level > 0 {
+				region = fmt.Sprintf("%s/priority/%d", region, level)
+			}
+			locality := &endpointv3.LocalityLbEndpoints{
+				Locality: &corev3.Locality{
+					Region: region,
+				},
+				LbEndpoints: levelEndpoints,
+				Priority:    uint32(level),
+			}
+			locality.LoadBalancingWeight = &wrapperspb.UInt32Value{Value: weight}
+			localities = append(localities, locality)
+		}
 	}
 	return &endpointv3.ClusterLoadAssignment{ClusterName: clusterName, Endpoints: localities}
 }
 
+// getEndpointPriorityLevels maps the distinct priorities of the given endpoints, in ascending order, to contiguous
+// priority levels starting from 0.
+func getEndpointPriorityLevels(endpoints []*ir.DestinationEndpoint) map[uint32]int {
+	var priorities []uint32
+	levels := make(map[uint32]int)
+	for _, ep := range endpoints {
+		if _, found := levels[ep.Priority]; !found {
+			levels[ep.Priority] = 0
+			priorities = append(priorities, ep.Priority)
+		}
+	}
+	sort.Slice(priorities, func(i, j int) bool { return priorities[i] < priorities[j] })
+	for level, priority := range priorities {
+		levels[priority] = level
+	}
+	return levels
+}
+
 func buildTypedExtensionProtocolOptions(args *xdsClusterArgs) map[string]*anypb.Any {
 	requiresHTTP2Options := false
 	for _, ds := range args.settings {
@@ -541,6 +541,27 @@ func requireTestDataOutFile(t *testing.T, name ...string) string {
 	return string(content)
 }
 
+func TestBuildXdsClusterLoadAssignmentWithPriorities(t *testing.T) {
+	primary := ir.NewDestEndpoint("primary.default", 8080)
+	primary.Weight = 3
+	secondary := ir.NewDestEndpoint("secondary.default", 8080)
+	failover := ir.NewDestEndpoint("failover.default", 8080)
+	failover.Priority = 5
+	loadAssignment := buildXdsClusterLoadAssignment("backend",
+		[]*ir.DestinationSetting{{Endpoints: []*ir.DestinationEndpoint{failover, primary, secondary}}})
+
+	localities := loadAssignment.GetEndpoints()
+	require.Len(t, localities, 2)
+	require.Equal(t, uint32(0), localities[0].GetPriority())
+	require.Len(t, localities[0].GetLbEndpoints(), 2)
+	require.Equal(t, uint32(3), localities[0].GetLbEndpoints()[0].GetLoadBalancingWeight().GetValue())
+	require.Equal(t, uint32(1), localities[0].GetLbEndpoints()[1].GetLoadBalancingWeight().GetValue())
+	require.Equal(t, uint32(1), localities[1].GetPriority(), "Priorities should be mapped to contiguous levels.")
+	require.Equal(t, "failover.default",
+		localities[1].GetLbEndpoints()[0].GetEndpoint().GetAddress().GetSocketAddress().GetAddress())
+	require.NotEqual(t, localities[0].GetLocality().GetRegion(), localities[1].GetLocality().GetRegion())
+}
+
 // func requireYamlRootToYAMLString(t *testing.T, pbRoot *ratelimitv3.RateLimitConfig) string {
 // 	str, err := GetRateLimitServiceConfigStr(pbRoot)
 // 	require.NoError(t, err)
@@ -0,0 +1,110 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package status
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// policyStatusOf returns the conditions and attached API list of the CRs which
+// carry policy status. ok is false for unsupported kinds.
+func policyStatusOf(obj client.Object) (conditions *[]metav1.Condition, attachedAPIs *[]string, ok bool) {
+	switch o := obj.(type) {
+	case *dpv1alpha2.Backend:
+		return &o.Status.Conditions, &o.Status.AttachedAPIs, true
+	case *dpv1alpha2.Authentication:
+		return &o.Status.Conditions, &o.Status.AttachedAPIs, true
+	case *dpv1alpha2.TokenIssuer:
+		return &o.Status.Conditions, &o.Status.AttachedAPIs, true
+	case *dpv1alpha3.APIPolicy:
+		return &o.Status.Conditions, &o.Status.AttachedAPIs, true
+	case *dpv1alpha3.RateLimitPolicy:
+		return &o.Status.Conditions, &o.Status.AttachedAPIs, true
+	case *dpv1alpha3.AIProvider:
+		return &o.Status.Conditions, &o.Status.AttachedAPIs, true
+	case *dpv1alpha3.AuthorizationPolicy:
+		return &o.Status.Conditions, &o.Status.AttachedAPIs, true
+	}
+	return nil, nil, false
+}
+
+// SetPolicyCondition adds or updates a status condition of a policy CR. The
+// condition observes the current generation of the CR.
+func SetPolicyCondition(obj client.Object, conditionType string, status metav1.ConditionStatus, reason, msg string) {
+	conditions, _, ok := policyStatusOf(obj)
+	if !ok {
+		return
+	}
+	*conditions = MergeConditions(*conditions,
+		newCondition(conditionType, status, reason, msg, time.Now(), obj.GetGeneration()))
+}
+
+// AddAttachedAPI records the given API in the attached API list of a policy CR.
+func AddAttachedAPI(obj client.Object, api string) {
+	_, attachedAPIs, ok := policyStatusOf(obj)
+	if !ok {
+		return
+	}
+	for _, attachedAPI := range *attachedAPIs {
+		if attachedAPI == api {
+			return
+		}
+	}
+	*attachedAPIs = append(*attachedAPIs, api)
+	sort.Strings(*attachedAPIs)
+}
+
+// RemoveAttachedAPI removes the given API from the attached API list of a policy CR
+// and returns the number of APIs still attached.
+func RemoveAttachedAPI(obj client.Object, api string) int {
+	return RetainAttachedAPIs(obj, func(attachedAPI string) bool { return attachedAPI != api })
+}
+
+// RetainAttachedAPIs keeps only the APIs accepted by the given function in the attached API
+// list of a policy CR and returns the number of APIs still attached.
+func RetainAttachedAPIs(obj client.Object, keep func(attachedAPI string) bool) int {
+	_, attachedAPIs, ok := policyStatusOf(obj)
+	if !ok {
+		return 0
+	}
+	var remaining []string
+	for _, attachedAPI := range *attachedAPIs {
+		if keep(attachedAPI) {
+			remaining = append(remaining, attachedAPI)
+		}
+	}
+	*attachedAPIs = remaining
+	return len(remaining)
+}
+
+// comparePolicyStatus compares the status maintained on policy CRs.
+func comparePolicyStatus(objA, objB client.Object) bool {
+	conditionsA, attachedAPIsA, okA := policyStatusOf(objA)
+	conditionsB, attachedAPIsB, okB := policyStatusOf(objB)
+	if !okA || !okB {
+		return false
+	}
+	return reflect.DeepEqual(*conditionsA, *conditionsB) && reflect.DeepEqual(*attachedAPIsA, *attachedAPIsB)
+}
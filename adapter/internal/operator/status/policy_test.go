@@ -0,0 +1,83 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	dpv1alpha1 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha1"
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetPolicyCondition(t *testing.T) {
+	apiPolicy := &dpv1alpha3.APIPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Generation: 2}}
+	SetPolicyCondition(apiPolicy, "Accepted", metav1.ConditionTrue, "Accepted", "Resource is accepted")
+	SetPolicyCondition(apiPolicy, "Attached", metav1.ConditionTrue, "Attached", "Resource is attached")
+	SetPolicyCondition(apiPolicy, "Accepted", metav1.ConditionFalse, "Invalid", "Resource is invalid")
+
+	assert.Len(t, apiPolicy.Status.Conditions, 2, "A condition type should be recorded only once.")
+	for _, condition := range apiPolicy.Status.Conditions {
+		assert.Equal(t, int64(2), condition.ObservedGeneration)
+		if condition.Type == "Accepted" {
+			assert.Equal(t, metav1.ConditionFalse, condition.Status)
+			assert.Equal(t, "Invalid", condition.Reason)
+		}
+	}
+
+	// Kinds which do not carry policy status are left untouched
+	api := &dpv1alpha1.API{}
+	SetPolicyCondition(api, "Accepted", metav1.ConditionTrue, "Accepted", "Resource is accepted")
+	AddAttachedAPI(api, "default/api")
+	assert.Equal(t, dpv1alpha1.APIStatus{}, api.Status)
+}
+
+func TestAttachedAPIs(t *testing.T) {
+	apiPolicy := &dpv1alpha3.APIPolicy{}
+	AddAttachedAPI(apiPolicy, "default/b-api")
+	AddAttachedAPI(apiPolicy, "default/a-api")
+	AddAttachedAPI(apiPolicy, "default/b-api")
+	assert.Equal(t, []string{"default/a-api", "default/b-api"}, apiPolicy.Status.AttachedAPIs,
+		"Attached APIs should be sorted and distinct.")
+
+	assert.Equal(t, 1, RemoveAttachedAPI(apiPolicy, "default/a-api"))
+	assert.Equal(t, []string{"default/b-api"}, apiPolicy.Status.AttachedAPIs)
+	assert.Equal(t, 1, RemoveAttachedAPI(apiPolicy, "default/unknown-api"))
+
+	AddAttachedAPI(apiPolicy, "default/c-api")
+	assert.Equal(t, 1, RetainAttachedAPIs(apiPolicy, func(api string) bool { return api == "default/c-api" }))
+	assert.Equal(t, []string{"default/c-api"}, apiPolicy.Status.AttachedAPIs)
+	assert.Equal(t, 0, RemoveAttachedAPI(apiPolicy, "default/c-api"))
+	assert.Empty(t, apiPolicy.Status.AttachedAPIs)
+}
+
+func TestComparePolicyStatus(t *testing.T) {
+	apiPolicy := &dpv1alpha3.APIPolicy{}
+	SetPolicyCondition(apiPolicy, "Accepted", metav1.ConditionTrue, "Accepted", "Resource is accepted")
+	AddAttachedAPI(apiPolicy, "default/api")
+
+	apiPolicyCopy := apiPolicy.DeepCopy()
+	assert.True(t, isStatusEqual(apiPolicy, apiPolicyCopy))
+	AddAttachedAPI(apiPolicyCopy, "default/other-api")
+	assert.False(t, isStatusEqual(apiPolicy, apiPolicyCopy), "A change of the attached APIs should be detected.")
+
+	apiPolicyCopy = apiPolicy.DeepCopy()
+	SetPolicyCondition(apiPolicyCopy, "Accepted", metav1.ConditionFalse, "Invalid", "Resource is invalid")
+	assert.False(t, isStatusEqual(apiPolicy, apiPolicyCopy), "A change of the conditions should be detected.")
+}
@@ -94,12 +94,17 @@ func (updateHandler *UpdateHandler) Send(update Update) {
 // isStatusEqual checks if two objects have equivalent status.
 // Supported:
 //   - API
+//   - Backend, Authentication, TokenIssuer, APIPolicy, RateLimitPolicy, AIProvider and AuthorizationPolicy
 func isStatusEqual(objA, objB interface{}) bool {
 	switch a := objA.(type) {
 	case *dpv1alpha1.API:
 		if b, ok := objB.(*dpv1alpha1.API); ok {
 			return compareAPIs(a, b)
 		}
+	case client.Object:
+		if b, ok := objB.(client.Object); ok {
+			return comparePolicyStatus(a, b)
+		}
 	}
 	return false
 }
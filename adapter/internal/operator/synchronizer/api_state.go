@@ -28,27 +28,33 @@ import (
 // the state of the Kubernetes controller cache to detect updates.
 // +k8s:deepcopy-gen=true
 type APIState struct {
-	APIDefinition             *v1alpha3.API
-	ProdHTTPRoute             *HTTPRouteState
-	SandHTTPRoute             *HTTPRouteState
-	ProdGQLRoute              *GQLRouteState
-	SandGQLRoute              *GQLRouteState
-	ProdGRPCRoute             *GRPCRouteState
-	SandGRPCRoute             *GRPCRouteState
-	Authentications           map[string]v1alpha2.Authentication
-	RateLimitPolicies         map[string]v1alpha3.RateLimitPolicy
-	ResourceAuthentications   map[string]v1alpha2.Authentication
-	ResourceRateLimitPolicies map[string]v1alpha3.RateLimitPolicy
-	ResourceAPIPolicies       map[string]v1alpha3.APIPolicy
-	APIPolicies               map[string]v1alpha3.APIPolicy
-	AIProvider                *v1alpha3.AIProvider
-	InterceptorServiceMapping map[string]v1alpha1.InterceptorService
-	BackendJWTMapping         map[string]v1alpha1.BackendJWT
-	APIDefinitionFile         []byte
-	SubscriptionValidation    bool
-	MutualSSL                 *v1alpha2.MutualSSL
-	ProdAIRL                  *v1alpha3.AIRateLimitPolicy
-	SandAIRL                  *v1alpha3.AIRateLimitPolicy
+	APIDefinition                 *v1alpha3.API
+	ProdHTTPRoute                 *HTTPRouteState
+	SandHTTPRoute                 *HTTPRouteState
+	ProdGQLRoute                  *GQLRouteState
+	SandGQLRoute                  *GQLRouteState
+	ProdGRPCRoute                 *GRPCRouteState
+	SandGRPCRoute                 *GRPCRouteState
+	Authentications               map[string]v1alpha2.Authentication
+	RateLimitPolicies             map[string]v1alpha3.RateLimitPolicy
+	ResourceAuthentications       map[string]v1alpha2.Authentication
+	ResourceRateLimitPolicies     map[string]v1alpha3.RateLimitPolicy
+	ResourceAPIPolicies           map[string]v1alpha3.APIPolicy
+	APIPolicies                   map[string]v1alpha3.APIPolicy
+	AuthorizationPolicies         map[string]v1alpha3.AuthorizationPolicy
+	ResourceAuthorizationPolicies map[string]v1alpha3.AuthorizationPolicy
+	AIProvider                    *v1alpha3.AIProvider
+	AIRoutingProviders            map[string]*v1alpha3.AIProvider
+	InterceptorServiceMapping     map[string]v1alpha1.InterceptorService
+	BackendJWTMapping             map[string]v1alpha1.BackendJWT
+	APIDefinitionFile             []byte
+	SubscriptionValidation        bool
+	MutualSSL                     *v1alpha2.MutualSSL
+	HMACKeys                      map[string]map[string]string
+	WSDLs                         map[string][]byte
+	ProtoDescriptorSets           map[string][]byte
+	ProdAIRL                      *v1alpha3.AIRateLimitPolicy
+	SandAIRL                      *v1alpha3.AIRateLimitPolicy
 }
 
 // HTTPRouteState holds the state of the deployed httpRoutes. This state is compared with
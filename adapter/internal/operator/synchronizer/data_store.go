@@ -18,6 +18,7 @@
 package synchronizer
 
 import (
+	"reflect"
 	"sync"
 
 	"github.com/wso2/apk/adapter/internal/loggers"
@@ -280,6 +281,40 @@ func (ods *OperatorDataStore) processAPIState(apiNamespacedName types.Namespaced
 		}
 	}
 
+	if !reflect.DeepEqual(apiState.HMACKeys, cachedAPI.HMACKeys) {
+		cachedAPI.HMACKeys = apiState.HMACKeys
+		updated = true
+		events = append(events, "HMAC Keys")
+	}
+
+	if !reflect.DeepEqual(apiState.WSDLs, cachedAPI.WSDLs) {
+		cachedAPI.WSDLs = apiState.WSDLs
+		updated = true
+		events = append(events, "WSDLs")
+	}
+
+	if !reflect.DeepEqual(apiState.ProtoDescriptorSets, cachedAPI.ProtoDescriptorSets) {
+		cachedAPI.ProtoDescriptorSets = apiState.ProtoDescriptorSets
+		updated = true
+		events = append(events, "Proto Descriptor Sets")
+	}
+
+	if len(apiState.AIRoutingProviders) != len(cachedAPI.AIRoutingProviders) {
+		cachedAPI.AIRoutingProviders = apiState.AIRoutingProviders
+		updated = true
+		events = append(events, "AI Routing Providers")
+	} else {
+		for key, aiProvider := range apiState.AIRoutingProviders {
+			if existingAIProvider, found := cachedAPI.AIRoutingProviders[key]; !found ||
+				aiProvider.UID != existingAIProvider.UID || aiProvider.Generation > existingAIProvider.Generation {
+				cachedAPI.AIRoutingProviders = apiState.AIRoutingProviders
+				updated = true
+				events = append(events, "AI Routing Providers")
+				break
+			}
+		}
+	}
+
 	if cachedAPI.SubscriptionValidation != apiState.SubscriptionValidation {
 		cachedAPI.SubscriptionValidation = apiState.SubscriptionValidation
 	}
@@ -326,6 +361,48 @@ func (ods *OperatorDataStore) processAPIState(apiNamespacedName types.Namespaced
 			}
 		}
 	}
+	if len(apiState.AuthorizationPolicies) != len(cachedAPI.AuthorizationPolicies) {
+		cachedAPI.AuthorizationPolicies = apiState.AuthorizationPolicies
+		updated = true
+		events = append(events, "AuthorizationPolicies")
+	} else {
+		for key, policy := range apiState.AuthorizationPolicies {
+			if existingPolicy, found := cachedAPI.AuthorizationPolicies[key]; found {
+				if policy.UID != existingPolicy.UID || policy.Generation > existingPolicy.Generation {
+					cachedAPI.AuthorizationPolicies = apiState.AuthorizationPolicies
+					updated = true
+					events = append(events, "AuthorizationPolicies")
+					break
+				}
+			} else {
+				cachedAPI.AuthorizationPolicies = apiState.AuthorizationPolicies
+				updated = true
+				events = append(events, "AuthorizationPolicies")
+				break
+			}
+		}
+	}
+	if len(apiState.ResourceAuthorizationPolicies) != len(cachedAPI.ResourceAuthorizationPolicies) {
+		cachedAPI.ResourceAuthorizationPolicies = apiState.ResourceAuthorizationPolicies
+		updated = true
+		events = append(events, "Resource AuthorizationPolicies")
+	} else {
+		for key, policy := range apiState.ResourceAuthorizationPolicies {
+			if existingPolicy, found := cachedAPI.ResourceAuthorizationPolicies[key]; found {
+				if policy.UID != existingPolicy.UID || policy.Generation > existingPolicy.Generation {
+					cachedAPI.ResourceAuthorizationPolicies = apiState.ResourceAuthorizationPolicies
+					updated = true
+					events = append(events, "Resource AuthorizationPolicies")
+					break
+				}
+			} else {
+				cachedAPI.ResourceAuthorizationPolicies = apiState.ResourceAuthorizationPolicies
+				updated = true
+				events = append(events, "Resource AuthorizationPolicies")
+				break
+			}
+		}
+	}
 
 	if len(apiState.RateLimitPolicies) != len(cachedAPI.RateLimitPolicies) {
 		cachedAPI.RateLimitPolicies = apiState.RateLimitPolicies
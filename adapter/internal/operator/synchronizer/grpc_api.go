@@ -89,6 +89,8 @@ func generateGRPCAdapterInternalAPI(apiState APIState, grpcRoute *GRPCRouteState
 		BackendJWTMapping:         apiState.BackendJWTMapping,
 		RateLimitPolicies:         apiState.RateLimitPolicies,
 		ResourceRateLimitPolicies: apiState.ResourceRateLimitPolicies,
+		HMACKeys:                  apiState.HMACKeys,
+		ProtoDescriptorSets:       apiState.ProtoDescriptorSets,
 	}
 	if err := adapterInternalAPI.SetInfoGRPCRouteCR(grpcRoute.GRPCRouteCombined, resourceParams); err != nil {
 		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2631, logging.MAJOR, "Error setting GRPCRoute CR info to adapterInternalAPI. %v", err))
@@ -96,16 +96,21 @@ func generateAdapterInternalAPI(apiState APIState, httpRouteState *HTTPRouteStat
 	adapterInternalAPI.SetEnvironment(environment)
 
 	resourceParams := model.ResourceParams{
-		AuthSchemes:               apiState.Authentications,
-		ResourceAuthSchemes:       apiState.ResourceAuthentications,
-		BackendMapping:            httpRouteState.BackendMapping,
-		APIPolicies:               apiState.APIPolicies,
-		ResourceAPIPolicies:       apiState.ResourceAPIPolicies,
-		ResourceScopes:            httpRouteState.Scopes,
-		InterceptorServiceMapping: apiState.InterceptorServiceMapping,
-		BackendJWTMapping:         apiState.BackendJWTMapping,
-		RateLimitPolicies:         apiState.RateLimitPolicies,
-		ResourceRateLimitPolicies: apiState.ResourceRateLimitPolicies,
+		AuthSchemes:                   apiState.Authentications,
+		ResourceAuthSchemes:           apiState.ResourceAuthentications,
+		BackendMapping:                httpRouteState.BackendMapping,
+		APIPolicies:                   apiState.APIPolicies,
+		ResourceAPIPolicies:           apiState.ResourceAPIPolicies,
+		ResourceScopes:                httpRouteState.Scopes,
+		InterceptorServiceMapping:     apiState.InterceptorServiceMapping,
+		BackendJWTMapping:             apiState.BackendJWTMapping,
+		RateLimitPolicies:             apiState.RateLimitPolicies,
+		ResourceRateLimitPolicies:     apiState.ResourceRateLimitPolicies,
+		HMACKeys:                      apiState.HMACKeys,
+		WSDLs:                         apiState.WSDLs,
+		AuthorizationPolicies:         apiState.AuthorizationPolicies,
+		ResourceAuthorizationPolicies: apiState.ResourceAuthorizationPolicies,
+		AIProviders:                   apiState.AIRoutingProviders,
 	}
 	if err := adapterInternalAPI.SetInfoHTTPRouteCR(httpRouteState.HTTPRouteCombined, resourceParams, httpRouteState.RuleIdxToAiRatelimitPolicyMapping, apiState.AIProvider.Spec.RateLimitFields.PromptTokens.In); err != nil {
 		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2631, logging.MAJOR, "Error setting HttpRoute CR info to adapterInternalAPI. %v", err))
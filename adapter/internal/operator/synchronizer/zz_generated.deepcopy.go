@@ -108,11 +108,40 @@ func (in *APIState) DeepCopyInto(out *APIState) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.AuthorizationPolicies != nil {
+		in, out := &in.AuthorizationPolicies, &out.AuthorizationPolicies
+		*out = make(map[string]v1alpha3.AuthorizationPolicy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ResourceAuthorizationPolicies != nil {
+		in, out := &in.ResourceAuthorizationPolicies, &out.ResourceAuthorizationPolicies
+		*out = make(map[string]v1alpha3.AuthorizationPolicy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.AIProvider != nil {
 		in, out := &in.AIProvider, &out.AIProvider
 		*out = new(v1alpha3.AIProvider)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AIRoutingProviders != nil {
+		in, out := &in.AIRoutingProviders, &out.AIRoutingProviders
+		*out = make(map[string]*v1alpha3.AIProvider, len(*in))
+		for key, val := range *in {
+			var outVal *v1alpha3.AIProvider
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(v1alpha3.AIProvider)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.InterceptorServiceMapping != nil {
 		in, out := &in.InterceptorServiceMapping, &out.InterceptorServiceMapping
 		*out = make(map[string]v1alpha1.InterceptorService, len(*in))
@@ -137,6 +166,53 @@ func (in *APIState) DeepCopyInto(out *APIState) {
 		*out = new(v1alpha2.MutualSSL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HMACKeys != nil {
+		in, out := &in.HMACKeys, &out.HMACKeys
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.WSDLs != nil {
+		in, out := &in.WSDLs, &out.WSDLs
+		*out = make(map[string][]byte, len(*in))
+		for key, val := range *in {
+			var outVal []byte
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]byte, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ProtoDescriptorSets != nil {
+		in, out := &in.ProtoDescriptorSets, &out.ProtoDescriptorSets
+		*out = make(map[string][]byte, len(*in))
+		for key, val := range *in {
+			var outVal []byte
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]byte, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIState.
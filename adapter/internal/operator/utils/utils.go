@@ -19,6 +19,7 @@ package utils
 
 import (
 	"context"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
@@ -197,6 +198,18 @@ func FilterRateLimitPolicyByNamespaces(namespaces []string) func(object *dpv1alp
 	}
 }
 
+// FilterAuthorizationPolicyByNamespaces takes a list of namespaces and returns a filter function
+// which return true if the input object is in the given namespaces list,
+// and returns false otherwise
+func FilterAuthorizationPolicyByNamespaces(namespaces []string) func(object *dpv1alpha3.AuthorizationPolicy) bool {
+	return func(object *dpv1alpha3.AuthorizationPolicy) bool {
+		if namespaces == nil {
+			return true
+		}
+		return stringutils.StringInSlice(object.GetNamespace(), namespaces)
+	}
+}
+
 // FilterScopeByNamespaces takes a list of namespaces and returns a filter function
 // which return true if the input object is in the given namespaces list,
 // and returns false otherwise
@@ -498,6 +511,90 @@ func getSecretValue(ctx context.Context, client k8client.Client,
 	return string(secret.Data[key]), nil
 }
 
+// GetClientCredentials reads the OAuth2 client ID and client secret from the referred Secret.
+func GetClientCredentials(ctx context.Context, client k8client.Client, namespace string,
+	secretRef dpv1alpha2.ClientSecretRef) (string, string, error) {
+	clientIDKey := secretRef.ClientIDKey
+	if clientIDKey == "" {
+		clientIDKey = "clientId"
+	}
+	clientSecretKey := secretRef.ClientSecretKey
+	if clientSecretKey == "" {
+		clientSecretKey = "clientSecret"
+	}
+	clientID, err := getSecretValue(ctx, client, namespace, secretRef.Name, clientIDKey)
+	if err != nil {
+		return "", "", err
+	}
+	clientSecret, err := getSecretValue(ctx, client, namespace, secretRef.Name, clientSecretKey)
+	if err != nil {
+		return "", "", err
+	}
+	if clientID == "" || clientSecret == "" {
+		return "", "", fmt.Errorf("secret %s/%s does not contain both %s and %s", namespace, secretRef.Name,
+			clientIDKey, clientSecretKey)
+	}
+	return clientID, clientSecret, nil
+}
+
+// getAWSCredentials reads the AWS access key ID, secret access key and the optional session token from the
+// referred Secret.
+func getAWSCredentials(ctx context.Context, client k8client.Client, namespace string,
+	secretRef dpv1alpha2.AWSSecretRef) (string, string, string, error) {
+	accessKeyIDKey := secretRef.AccessKeyIDKey
+	if accessKeyIDKey == "" {
+		accessKeyIDKey = "accessKeyId"
+	}
+	secretAccessKeyKey := secretRef.SecretAccessKeyKey
+	if secretAccessKeyKey == "" {
+		secretAccessKeyKey = "secretAccessKey"
+	}
+	accessKeyID, err := getSecretValue(ctx, client, namespace, secretRef.Name, accessKeyIDKey)
+	if err != nil {
+		return "", "", "", err
+	}
+	secretAccessKey, err := getSecretValue(ctx, client, namespace, secretRef.Name, secretAccessKeyKey)
+	if err != nil {
+		return "", "", "", err
+	}
+	if accessKeyID == "" || secretAccessKey == "" {
+		return "", "", "", fmt.Errorf("secret %s/%s does not contain both %s and %s", namespace, secretRef.Name,
+			accessKeyIDKey, secretAccessKeyKey)
+	}
+	sessionToken := ""
+	if secretRef.SessionTokenKey != "" {
+		sessionToken, err = getSecretValue(ctx, client, namespace, secretRef.Name, secretRef.SessionTokenKey)
+		if err != nil {
+			return "", "", "", err
+		}
+	}
+	return accessKeyID, secretAccessKey, sessionToken, nil
+}
+
+// getClientCertificate reads the certificate and the private key from a kubernetes.io/tls Secret.
+func getClientCertificate(ctx context.Context, client k8client.Client,
+	namespace, secretName string) (string, string, error) {
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, types.NamespacedName{
+		Name:      secretName,
+		Namespace: namespace}, secret); err != nil {
+		return "", "", err
+	}
+	if secret.Type != corev1.SecretTypeTLS {
+		return "", "", fmt.Errorf("secret %s/%s is not of type %s", namespace, secretName, corev1.SecretTypeTLS)
+	}
+	certificate := string(secret.Data[corev1.TLSCertKey])
+	privateKey := string(secret.Data[corev1.TLSPrivateKeyKey])
+	if certificate == "" || privateKey == "" {
+		return "", "", fmt.Errorf("secret %s/%s does not contain both %s and %s", namespace, secretName,
+			corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	if _, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey)); err != nil {
+		return "", "", fmt.Errorf("invalid key pair in secret %s/%s: %s", namespace, secretName, err.Error())
+	}
+	return certificate, privateKey, nil
+}
+
 // GetService retrieves the Service object and returns its details.
 func GetService(ctx context.Context, client k8client.Client, namespace, serviceName string) (*corev1.Service, error) {
 	service := &corev1.Service{}
@@ -574,12 +671,20 @@ func ResolveRef(ctx context.Context, client k8client.Client, api *dpv1alpha3.API
 // GetResolvedBackend resolves backend TLS configurations.
 func GetResolvedBackend(ctx context.Context, client k8client.Client,
 	backendNamespacedName types.NamespacedName, api *dpv1alpha3.API) *dpv1alpha2.ResolvedBackend {
+	resolvedBackend, _ := ResolveBackend(ctx, client, backendNamespacedName, api)
+	return resolvedBackend
+}
+
+// ResolveBackend resolves backend TLS configurations and returns the cause when the backend
+// could not be resolved.
+func ResolveBackend(ctx context.Context, client k8client.Client,
+	backendNamespacedName types.NamespacedName, api *dpv1alpha3.API) (*dpv1alpha2.ResolvedBackend, error) {
 	resolvedBackend := dpv1alpha2.ResolvedBackend{}
 	resolvedTLSConfig := dpv1alpha2.ResolvedTLSConfig{}
 	var backend dpv1alpha2.Backend
 	if err := ResolveRef(ctx, client, api, backendNamespacedName, false, &backend); err != nil {
 		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2646, logging.CRITICAL, "Error while getting backend: %v, error: %v", backendNamespacedName, err.Error()))
-		return nil
+		return nil, err
 	}
 	resolvedBackend.Backend = backend
 	resolvedBackend.Services = backend.Spec.Services
@@ -615,22 +720,44 @@ func GetResolvedBackend(ctx context.Context, client k8client.Client,
 			HealthyThreshold:   backend.Spec.HealthCheck.HealthyThreshold,
 		}
 	}
+	if backend.Spec.OutlierDetection != nil {
+		resolvedBackend.OutlierDetection = &dpv1alpha2.OutlierDetection{
+			Consecutive5xxErrors:     backend.Spec.OutlierDetection.Consecutive5xxErrors,
+			ConsecutiveGatewayErrors: backend.Spec.OutlierDetection.ConsecutiveGatewayErrors,
+			Interval:                 backend.Spec.OutlierDetection.Interval,
+			BaseEjectionTime:         backend.Spec.OutlierDetection.BaseEjectionTime,
+			MaxEjectionPercent:       backend.Spec.OutlierDetection.MaxEjectionPercent,
+		}
+	}
+	if backend.Spec.LoadBalancing != nil {
+		resolvedBackend.LoadBalancing = backend.Spec.LoadBalancing.DeepCopy()
+	}
 	var err error
 	if backend.Spec.TLS != nil {
 		resolvedTLSConfig.ResolvedCertificate, err = ResolveCertificate(ctx, client,
 			backend.Namespace, backend.Spec.TLS.CertificateInline, ConvertRefConfigsV1ToV2(backend.Spec.TLS.ConfigMapRef), ConvertRefConfigsV1ToV2(backend.Spec.TLS.SecretRef))
 		if err != nil {
 			loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2654, logging.CRITICAL, "Error resolving certificate for Backend %v", err.Error()))
-			return nil
+			return nil, fmt.Errorf("unable to resolve the TLS certificate: %w", err)
 		}
 		resolvedTLSConfig.AllowedSANs = backend.Spec.TLS.AllowedSANs
+		if backend.Spec.TLS.ClientCertificateRef != nil {
+			resolvedTLSConfig.ClientCertificate, resolvedTLSConfig.ClientPrivateKey, err = getClientCertificate(ctx, client,
+				backend.Namespace, backend.Spec.TLS.ClientCertificateRef.Name)
+			if err != nil {
+				loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2669, logging.CRITICAL,
+					"Error resolving client certificate for Backend %v, error: %v", backendNamespacedName, err.Error()))
+				return nil, fmt.Errorf("unable to resolve the client certificate %s: %w",
+					backend.Spec.TLS.ClientCertificateRef.Name, err)
+			}
+		}
 		resolvedBackend.TLS = resolvedTLSConfig
 	}
 	if backend.Spec.Security != nil {
 		resolvedBackend.Security = getResolvedBackendSecurity(ctx, client,
 			backend.Namespace, *backend.Spec.Security)
 	}
-	return &resolvedBackend
+	return &resolvedBackend, nil
 }
 
 // UpdateCR updates the given CR.
@@ -697,6 +824,43 @@ func getResolvedBackendSecurity(ctx context.Context, client k8client.Client,
 				Value: keyValue,
 			},
 		}
+	} else if security.OAuth2 != nil {
+		clientID, clientSecret, err := GetClientCredentials(ctx, client, namespace, security.OAuth2.ClientSecretRef)
+		if err != nil {
+			loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2648, logging.CRITICAL, "Error while reading key from secretRef: %s", security.OAuth2.ClientSecretRef))
+		}
+		resolvedSecurity = dpv1alpha2.ResolvedSecurityConfig{
+			Type: "OAuth2",
+			OAuth2: dpv1alpha2.ResolvedOAuth2SecurityConfig{
+				TokenEndpoint: security.OAuth2.TokenEndpoint,
+				ClientID:      clientID,
+				ClientSecret:  clientSecret,
+				Scopes:        security.OAuth2.Scopes,
+				Audience:      security.OAuth2.Audience,
+				TokenCacheTTL: security.OAuth2.TokenCacheTTL,
+				RefreshSkew:   security.OAuth2.RefreshSkew,
+			},
+		}
+	} else if security.AWS != nil {
+		accessKeyID, secretAccessKey, sessionToken, err := getAWSCredentials(ctx, client, namespace,
+			security.AWS.SecretRef)
+		if err != nil {
+			loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2648, logging.CRITICAL, "Error while reading key from secretRef: %s", security.AWS.SecretRef))
+		}
+		service := security.AWS.Service
+		if service == "" {
+			service = "bedrock"
+		}
+		resolvedSecurity = dpv1alpha2.ResolvedSecurityConfig{
+			Type: "AWS",
+			AWS: dpv1alpha2.ResolvedAWSSecurityConfig{
+				Region:          security.AWS.Region,
+				Service:         service,
+				AccessKeyID:     accessKeyID,
+				SecretAccessKey: secretAccessKey,
+				SessionToken:    sessionToken,
+			},
+		}
 	}
 	loggers.LoggerAPKOperator.Debugf("Resolved Security %v", resolvedSecurity)
 	return resolvedSecurity
@@ -724,6 +888,39 @@ func GetResolvedMutualSSL(ctx context.Context, client k8client.Client, authentic
 	return nil
 }
 
+// GetResolvedHMACKeys reads the shared keys of the HMAC authentication of the given authentication
+// into the hmacKeys map which is keyed by the name of the referred secret. The secret is looked up in the
+// namespace of the authentication, which is the namespace of the API.
+func GetResolvedHMACKeys(ctx context.Context, client k8client.Client, authentication dpv1alpha2.Authentication,
+	hmacKeys map[string]map[string]string) error {
+	authSpec := SelectPolicy(&authentication.Spec.Override, &authentication.Spec.Default, nil, nil)
+	if authSpec == nil || authSpec.AuthTypes == nil || authSpec.AuthTypes.HMAC == nil {
+		return nil
+	}
+	secretRef := types.NamespacedName{
+		Name:      authSpec.AuthTypes.HMAC.SecretRef.Name,
+		Namespace: authentication.Namespace,
+	}
+	if _, found := hmacKeys[secretRef.Name]; found {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	if err := client.Get(ctx, secretRef, secret); err != nil {
+		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2670, logging.MAJOR,
+			"Error while reading HMAC keys from secret %s: %v", secretRef.String(), err))
+		return err
+	}
+	if len(secret.Data) == 0 {
+		return fmt.Errorf("secret %s does not contain any HMAC key", secretRef.String())
+	}
+	keys := make(map[string]string, len(secret.Data))
+	for keyID, key := range secret.Data {
+		keys[keyID] = string(key)
+	}
+	hmacKeys[secretRef.Name] = keys
+	return nil
+}
+
 // ResolveAllmTLSCertificates resolves all mTLS certificates
 func ResolveAllmTLSCertificates(ctx context.Context, mutualSSL *dpv1alpha2.MutualSSLConfig, client k8client.Client, namespace string) ([]string, error) {
 	var resolvedCertificates []string
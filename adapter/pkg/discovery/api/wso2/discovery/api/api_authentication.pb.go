@@ -218,6 +218,94 @@ func (x *Oauth2) GetSendTokenToUpstream() bool {
 	return false
 }
 
+// HMAC request signature related configurations
+type HMAC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header              string            `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`                                                                                     // name of the header containing the signature
+	Keys                map[string]string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // shared keys by key ID
+	Algorithms          []string          `protobuf:"bytes,3,rep,name=algorithms,proto3" json:"algorithms,omitempty"`                                                                             // accepted signature algorithms
+	SignedHeaders       []string          `protobuf:"bytes,4,rep,name=signedHeaders,proto3" json:"signedHeaders,omitempty"`                                                                       // headers which must be covered by the signature
+	ClockSkew           uint32            `protobuf:"varint,5,opt,name=clockSkew,proto3" json:"clockSkew,omitempty"`                                                                              // tolerated clock skew in seconds
+	SendTokenToUpstream bool              `protobuf:"varint,6,opt,name=sendTokenToUpstream,proto3" json:"sendTokenToUpstream,omitempty"`                                                          // send the signature to upstream
+}
+
+func (x *HMAC) Reset() {
+	*x = HMAC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_wso2_discovery_api_api_authentication_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HMAC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HMAC) ProtoMessage() {}
+
+func (x *HMAC) ProtoReflect() protoreflect.Message {
+	mi := &file_wso2_discovery_api_api_authentication_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HMAC.ProtoReflect.Descriptor instead.
+func (*HMAC) Descriptor() ([]byte, []int) {
+	return file_wso2_discovery_api_api_authentication_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HMAC) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *HMAC) GetKeys() map[string]string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *HMAC) GetAlgorithms() []string {
+	if x != nil {
+		return x.Algorithms
+	}
+	return nil
+}
+
+func (x *HMAC) GetSignedHeaders() []string {
+	if x != nil {
+		return x.SignedHeaders
+	}
+	return nil
+}
+
+func (x *HMAC) GetClockSkew() uint32 {
+	if x != nil {
+		return x.ClockSkew
+	}
+	return 0
+}
+
+func (x *HMAC) GetSendTokenToUpstream() bool {
+	if x != nil {
+		return x.SendTokenToUpstream
+	}
+	return false
+}
+
 type APIAuthentication struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -227,12 +315,13 @@ type APIAuthentication struct {
 	Jwt      *JWT      `protobuf:"bytes,2,opt,name=jwt,proto3" json:"jwt,omitempty"`
 	Apikey   []*APIKey `protobuf:"bytes,3,rep,name=apikey,proto3" json:"apikey,omitempty"`
 	Oauth2   *Oauth2   `protobuf:"bytes,4,opt,name=Oauth2,proto3" json:"Oauth2,omitempty"`
+	Hmac     *HMAC     `protobuf:"bytes,5,opt,name=hmac,proto3" json:"hmac,omitempty"`
 }
 
 func (x *APIAuthentication) Reset() {
 	*x = APIAuthentication{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_wso2_discovery_api_api_authentication_proto_msgTypes[3]
+		mi := &file_wso2_discovery_api_api_authentication_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -245,7 +334,7 @@ func (x *APIAuthentication) String() string {
 func (*APIAuthentication) ProtoMessage() {}
 
 func (x *APIAuthentication) ProtoReflect() protoreflect.Message {
-	mi := &file_wso2_discovery_api_api_authentication_proto_msgTypes[3]
+	mi := &file_wso2_discovery_api_api_authentication_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -258,7 +347,7 @@ func (x *APIAuthentication) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use APIAuthentication.ProtoReflect.Descriptor instead.
 func (*APIAuthentication) Descriptor() ([]byte, []int) {
-	return file_wso2_discovery_api_api_authentication_proto_rawDescGZIP(), []int{3}
+	return file_wso2_discovery_api_api_authentication_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *APIAuthentication) GetDisabled() bool {
@@ -289,6 +378,13 @@ func (x *APIAuthentication) GetOauth2() *Oauth2 {
 	return nil
 }
 
+func (x *APIAuthentication) GetHmac() *HMAC {
+	if x != nil {
+		return x.Hmac
+	}
+	return nil
+}
+
 var File_wso2_discovery_api_api_authentication_proto protoreflect.FileDescriptor
 
 var file_wso2_discovery_api_api_authentication_proto_rawDesc = []byte{
@@ -314,27 +410,49 @@ var file_wso2_discovery_api_api_authentication_proto_rawDesc = []byte{
 	0x12, 0x30, 0x0a, 0x13, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x6f, 0x55,
 	0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x73,
 	0x65, 0x6e, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x6f, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65,
-	0x61, 0x6d, 0x22, 0xc2, 0x01, 0x0a, 0x11, 0x41, 0x50, 0x49, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e,
-	0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x03, 0x6a, 0x77, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x77, 0x73, 0x6f, 0x32, 0x2e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
-	0x72, 0x79, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4a, 0x57, 0x54, 0x52, 0x03, 0x6a, 0x77, 0x74, 0x12,
-	0x32, 0x0a, 0x06, 0x61, 0x70, 0x69, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x77, 0x73, 0x6f, 0x32, 0x2e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x50, 0x49, 0x4b, 0x65, 0x79, 0x52, 0x06, 0x61, 0x70, 0x69,
-	0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x06, 0x4f, 0x61, 0x75, 0x74, 0x68, 0x32, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x73, 0x6f, 0x32, 0x2e, 0x64, 0x69, 0x73, 0x63, 0x6f,
-	0x76, 0x65, 0x72, 0x79, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4f, 0x61, 0x75, 0x74, 0x68, 0x32, 0x52,
-	0x06, 0x4f, 0x61, 0x75, 0x74, 0x68, 0x32, 0x42, 0x7e, 0x0a, 0x23, 0x6f, 0x72, 0x67, 0x2e, 0x77,
-	0x73, 0x6f, 0x32, 0x2e, 0x61, 0x70, 0x6b, 0x2e, 0x65, 0x6e, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x72,
-	0x2e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x61, 0x70, 0x69, 0x42, 0x16,
-	0x41, 0x50, 0x49, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x6e, 0x76, 0x6f, 0x79, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2f,
-	0x67, 0x6f, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x2d, 0x70, 0x6c, 0x61, 0x6e, 0x65,
-	0x2f, 0x77, 0x73, 0x6f, 0x32, 0x2f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2f,
-	0x61, 0x70, 0x69, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x6d, 0x22, 0xa5, 0x02, 0x0a, 0x04, 0x48, 0x4d, 0x41, 0x43, 0x12, 0x16, 0x0a, 0x06, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x77, 0x73, 0x6f, 0x32, 0x2e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x79, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x48, 0x4d, 0x41, 0x43, 0x2e, 0x4b, 0x65, 0x79, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x61,
+	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0a, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77, 0x12,
+	0x30, 0x0a, 0x13, 0x73, 0x65, 0x6e, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x6f, 0x55, 0x70,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x73, 0x65,
+	0x6e, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x6f, 0x55, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf0, 0x01, 0x0a, 0x11, 0x41,
+	0x50, 0x49, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x03,
+	0x6a, 0x77, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x77, 0x73, 0x6f, 0x32,
+	0x2e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4a,
+	0x57, 0x54, 0x52, 0x03, 0x6a, 0x77, 0x74, 0x12, 0x32, 0x0a, 0x06, 0x61, 0x70, 0x69, 0x6b, 0x65,
+	0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x73, 0x6f, 0x32, 0x2e, 0x64,
+	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x50, 0x49,
+	0x4b, 0x65, 0x79, 0x52, 0x06, 0x61, 0x70, 0x69, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x06, 0x4f,
+	0x61, 0x75, 0x74, 0x68, 0x32, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x77, 0x73,
+	0x6f, 0x32, 0x2e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4f, 0x61, 0x75, 0x74, 0x68, 0x32, 0x52, 0x06, 0x4f, 0x61, 0x75, 0x74, 0x68, 0x32, 0x12,
+	0x2c, 0x0a, 0x04, 0x68, 0x6d, 0x61, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x77, 0x73, 0x6f, 0x32, 0x2e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x48, 0x4d, 0x41, 0x43, 0x52, 0x04, 0x68, 0x6d, 0x61, 0x63, 0x42, 0x7e, 0x0a,
+	0x23, 0x6f, 0x72, 0x67, 0x2e, 0x77, 0x73, 0x6f, 0x32, 0x2e, 0x61, 0x70, 0x6b, 0x2e, 0x65, 0x6e,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x72, 0x2e, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x2e, 0x61, 0x70, 0x69, 0x42, 0x16, 0x41, 0x50, 0x49, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x3d,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x6e, 0x76, 0x6f, 0x79,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x2f, 0x67, 0x6f, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x2d, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x2f, 0x77, 0x73, 0x6f, 0x32, 0x2f, 0x64, 0x69, 0x73, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -349,22 +467,26 @@ func file_wso2_discovery_api_api_authentication_proto_rawDescGZIP() []byte {
 	return file_wso2_discovery_api_api_authentication_proto_rawDescData
 }
 
-var file_wso2_discovery_api_api_authentication_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_wso2_discovery_api_api_authentication_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_wso2_discovery_api_api_authentication_proto_goTypes = []interface{}{
 	(*APIKey)(nil),            // 0: wso2.discovery.api.APIKey
 	(*JWT)(nil),               // 1: wso2.discovery.api.JWT
 	(*Oauth2)(nil),            // 2: wso2.discovery.api.Oauth2
-	(*APIAuthentication)(nil), // 3: wso2.discovery.api.APIAuthentication
+	(*HMAC)(nil),              // 3: wso2.discovery.api.HMAC
+	(*APIAuthentication)(nil), // 4: wso2.discovery.api.APIAuthentication
+	nil,                       // 5: wso2.discovery.api.HMAC.KeysEntry
 }
 var file_wso2_discovery_api_api_authentication_proto_depIdxs = []int32{
-	1, // 0: wso2.discovery.api.APIAuthentication.jwt:type_name -> wso2.discovery.api.JWT
-	0, // 1: wso2.discovery.api.APIAuthentication.apikey:type_name -> wso2.discovery.api.APIKey
-	2, // 2: wso2.discovery.api.APIAuthentication.Oauth2:type_name -> wso2.discovery.api.Oauth2
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	5, // 0: wso2.discovery.api.HMAC.keys:type_name -> wso2.discovery.api.HMAC.KeysEntry
+	1, // 1: wso2.discovery.api.APIAuthentication.jwt:type_name -> wso2.discovery.api.JWT
+	0, // 2: wso2.discovery.api.APIAuthentication.apikey:type_name -> wso2.discovery.api.APIKey
+	2, // 3: wso2.discovery.api.APIAuthentication.Oauth2:type_name -> wso2.discovery.api.Oauth2
+	3, // 4: wso2.discovery.api.APIAuthentication.hmac:type_name -> wso2.discovery.api.HMAC
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_wso2_discovery_api_api_authentication_proto_init() }
@@ -410,6 +532,18 @@ func file_wso2_discovery_api_api_authentication_proto_init() {
 			}
 		}
 		file_wso2_discovery_api_api_authentication_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HMAC); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_wso2_discovery_api_api_authentication_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*APIAuthentication); i {
 			case 0:
 				return &v.state
@@ -428,7 +562,7 @@ func file_wso2_discovery_api_api_authentication_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_wso2_discovery_api_api_authentication_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -26,7 +26,10 @@ type SecurityInfo struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Password         string            `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	Password string `protobuf:"bytes,1,opt,name=password,proto3" json:"password,omitempty"`
+	// Type specific parameters. APIKey uses in, key and value. OAuth2 uses tokenEndpoint, clientId,
+	// clientSecret, scopes, audience, tokenCacheTTL and refreshSkew. AWS uses region, service, accessKeyId,
+	// secretAccessKey and sessionToken.
 	CustomParameters map[string]string `protobuf:"bytes,2,rep,name=customParameters,proto3" json:"customParameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	SecurityType     string            `protobuf:"bytes,3,opt,name=securityType,proto3" json:"securityType,omitempty"`
 	Enabled          bool              `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
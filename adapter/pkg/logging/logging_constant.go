@@ -165,6 +165,9 @@ const (
 	Error2666 = 2666
 	Error2667 = 2667
 	Error2668 = 2668
+	Error2669 = 2669
+	Error2670 = 2670
+	Error2671 = 2671
 )
 
 // Error Log Pkg auth(3001-3099) Config Constants
@@ -213,4 +216,7 @@ const (
 	Error3204 = 3204
 	Error3205 = 3205
 	Error3206 = 3206
+	Error3207 = 3207
+	Error3208 = 3208
+	Error3209 = 3209
 )
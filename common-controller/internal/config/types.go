@@ -85,15 +85,16 @@ type operator struct {
 }
 
 type redis struct {
-	Host                string
-	Port                string
-	Username            string
-	Password            string
-	UserCertPath        string
-	UserKeyPath         string
-	CACertPath          string
-	TLSEnabled          bool
-	RevokedTokenChannel string
+	Host                             string
+	Port                             string
+	Username                         string
+	Password                         string
+	UserCertPath                     string
+	UserKeyPath                      string
+	CACertPath                       string
+	TLSEnabled                       bool
+	RevokedTokenChannel              string
+	ResponseCacheInvalidationChannel string
 }
 
 type sts struct {
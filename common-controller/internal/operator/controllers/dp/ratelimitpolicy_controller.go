@@ -23,6 +23,7 @@ import (
 
 	logger "github.com/sirupsen/logrus"
 	k8error "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -43,18 +44,21 @@ import (
 	cache "github.com/wso2/apk/common-controller/internal/cache"
 	"github.com/wso2/apk/common-controller/internal/config"
 	loggers "github.com/wso2/apk/common-controller/internal/loggers"
+	"github.com/wso2/apk/common-controller/internal/operator/status"
 	"github.com/wso2/apk/common-controller/internal/utils"
 	xds "github.com/wso2/apk/common-controller/internal/xds"
 	dpv1alpha1 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha1"
+	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
 	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
 	"github.com/wso2/apk/common-go-libs/constants"
 )
 
 // RateLimitPolicyReconciler reconciles a RateLimitPolicy object
 type RateLimitPolicyReconciler struct {
-	client client.Client
-	ods    *cache.RatelimitDataStore
-	Scheme *runtime.Scheme
+	client        client.Client
+	ods           *cache.RatelimitDataStore
+	Scheme        *runtime.Scheme
+	statusUpdater *status.UpdateHandler
 }
 
 const (
@@ -62,13 +66,16 @@ const (
 	apiRateLimitIndex = "apiRateLimitIndex"
 	// apiRateLimitResourceIndex Index for resource level ratelimits
 	httprouteRateLimitIndex = "httprouteRateLimitIndex"
+	// gqlSubscription is the GQLRoute match type of the subscription fields
+	gqlSubscription dpv1alpha2.GQLType = "SUBSCRIPTION"
 )
 
 // NewratelimitController creates a new ratelimitcontroller instance.
-func NewratelimitController(mgr manager.Manager, ratelimitStore *cache.RatelimitDataStore) error {
+func NewratelimitController(mgr manager.Manager, ratelimitStore *cache.RatelimitDataStore, statusUpdater *status.UpdateHandler) error {
 	ratelimitReconciler := &RateLimitPolicyReconciler{
-		client: mgr.GetClient(),
-		ods:    ratelimitStore,
+		client:        mgr.GetClient(),
+		ods:           ratelimitStore,
+		statusUpdater: statusUpdater,
 	}
 
 	ctx := context.Background()
@@ -102,6 +109,14 @@ func NewratelimitController(mgr manager.Manager, ratelimitStore *cache.Ratelimit
 		return err
 	}
 
+	predicateGQLRoute := []predicate.TypedPredicate[*dpv1alpha2.GQLRoute]{predicate.NewTypedPredicateFuncs(utils.FilterGQLRouteByNamespaces(conf.CommonController.Operator.Namespaces))}
+	if err := c.Watch(source.Kind(mgr.GetCache(), &dpv1alpha2.GQLRoute{},
+		handler.TypedEnqueueRequestsFromMapFunc(ratelimitReconciler.getRatelimitForGQLRoute), predicateGQLRoute...)); err != nil {
+		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2667, logging.BLOCKER,
+			"Error watching GQLRoute resources: %v", err))
+		return err
+	}
+
 	predicateRateLimitPolicy := []predicate.TypedPredicate[*dpv1alpha3.RateLimitPolicy]{predicate.NewTypedPredicateFuncs[*dpv1alpha3.RateLimitPolicy](utils.FilterRateLimitPolicyByNamespaces(conf.CommonController.Operator.Namespaces))}
 	if err := c.Watch(source.Kind(mgr.GetCache(), &dpv1alpha3.RateLimitPolicy{}, &handler.TypedEnqueueRequestForObject[*dpv1alpha3.RateLimitPolicy]{}, predicateRateLimitPolicy...)); err != nil {
 		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2639, logging.BLOCKER,
@@ -179,6 +194,7 @@ func (ratelimitReconciler *RateLimitPolicyReconciler) Reconcile(ctx context.Cont
 		xds.UpdateRateLimiterPolicies(conf.CommonController.Server.Label)
 	} else {
 		if resolveRatelimitPolicyList, err := ratelimitReconciler.marshelRateLimit(ctx, ratelimitKey, ratelimitPolicy); err != nil {
+			ratelimitReconciler.updateRatelimitPolicyStatus(&ratelimitPolicy, err)
 			return ctrl.Result{}, err
 		} else if len(resolveRatelimitPolicyList) > 0 {
 			ratelimitReconciler.ods.AddorUpdateResolveRatelimitToStore(ratelimitKey, resolveRatelimitPolicyList)
@@ -186,10 +202,46 @@ func (ratelimitReconciler *RateLimitPolicyReconciler) Reconcile(ctx context.Cont
 			xds.UpdateRateLimiterPolicies(conf.CommonController.Server.Label)
 		}
 	}
+	ratelimitReconciler.updateRatelimitPolicyStatus(&ratelimitPolicy, nil)
 
 	return ctrl.Result{}, nil
 }
 
+// updateRatelimitPolicyStatus sets the Accepted and ResolvedRefs conditions of the RateLimitPolicy.
+// The Attached and Conflicted conditions are maintained by the adapter. The status of a policy
+// which is being deleted is left as it is.
+func (ratelimitReconciler *RateLimitPolicyReconciler) updateRatelimitPolicyStatus(ratelimitPolicy *dpv1alpha3.RateLimitPolicy,
+	resolveErr error) {
+	if ratelimitReconciler.statusUpdater == nil || isBeingDeleted(ratelimitPolicy) {
+		return
+	}
+	ratelimitReconciler.statusUpdater.Send(status.Update{
+		NamespacedName: utils.NamespacedName(ratelimitPolicy),
+		Resource:       new(dpv1alpha3.RateLimitPolicy),
+		UpdateStatus: func(obj k8client.Object) k8client.Object {
+			ratelimitPolicy, ok := obj.(*dpv1alpha3.RateLimitPolicy)
+			if !ok {
+				loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2626, logging.BLOCKER, "Unsupported object type %T", obj))
+				return obj
+			}
+			if isBeingDeleted(ratelimitPolicy) {
+				return obj
+			}
+			ratelimitPolicyCopy := ratelimitPolicy.DeepCopy()
+			status.SetRateLimitPolicyCondition(ratelimitPolicyCopy, constants.ConditionAccepted, metav1.ConditionTrue,
+				constants.ReasonAccepted, "Resource is accepted")
+			if resolveErr != nil {
+				status.SetRateLimitPolicyCondition(ratelimitPolicyCopy, constants.ConditionResolvedRefs, metav1.ConditionFalse,
+					constants.ReasonTargetNotFound, resolveErr.Error())
+			} else {
+				status.SetRateLimitPolicyCondition(ratelimitPolicyCopy, constants.ConditionResolvedRefs, metav1.ConditionTrue,
+					constants.ReasonResolvedRefs, "All references are resolved")
+			}
+			return ratelimitPolicyCopy
+		},
+	})
+}
+
 func (ratelimitReconciler *RateLimitPolicyReconciler) getRatelimitForAPI(ctx context.Context, obj *dpv1alpha3.API) []reconcile.Request {
 	api := obj
 
@@ -246,6 +298,26 @@ func (ratelimitReconciler *RateLimitPolicyReconciler) getRatelimitForHTTPRoute(c
 	return requests
 }
 
+func (ratelimitReconciler *RateLimitPolicyReconciler) getRatelimitForGQLRoute(ctx context.Context, obj *dpv1alpha2.GQLRoute) []reconcile.Request {
+	gqlRoute := obj
+
+	requests := []reconcile.Request{}
+	for _, rule := range gqlRoute.Spec.Rules {
+		for _, filter := range rule.Filters {
+			if filter.ExtensionRef != nil && filter.ExtensionRef.Kind == constants.KindRateLimitPolicy {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      string(filter.ExtensionRef.Name),
+						Namespace: gqlRoute.Namespace,
+					},
+				})
+			}
+		}
+	}
+
+	return requests
+}
+
 func (ratelimitReconciler *RateLimitPolicyReconciler) marshelSubscriptionRateLimit(
 	ratelimitPolicy dpv1alpha3.RateLimitPolicy) dpv1alpha3.ResolveSubscriptionRatelimitPolicy {
 
@@ -331,6 +403,31 @@ func (ratelimitReconciler *RateLimitPolicyReconciler) marshelRateLimit(ctx conte
 				policyList = append(policyList, resolveRatelimit)
 			}
 		}
+
+		if len(api.Spec.Production) > 0 && api.Spec.APIType == "GraphQL" {
+			resolveResourceList, err := ratelimitReconciler.getGQLRouteResourceList(ctx, ratelimitKey, ratelimitPolicy,
+				api.Spec.Production[0].RouteRefs)
+			if err != nil {
+				return nil, err
+			}
+			if len(resolveResourceList) > 0 {
+				resolveRatelimit.Resources = resolveResourceList
+				policyList = append(policyList, resolveRatelimit)
+			}
+		}
+
+		if len(api.Spec.Sandbox) > 0 && api.Spec.APIType == "GraphQL" {
+			resolveResourceList, err := ratelimitReconciler.getGQLRouteResourceList(ctx, ratelimitKey, ratelimitPolicy,
+				api.Spec.Sandbox[0].RouteRefs)
+			if err != nil {
+				return nil, err
+			}
+			if len(resolveResourceList) > 0 {
+				resolveRatelimit.Resources = resolveResourceList
+				resolveRatelimit.Environment += "_sandbox"
+				policyList = append(policyList, resolveRatelimit)
+			}
+		}
 	}
 
 	return policyList, nil
@@ -382,6 +479,53 @@ func (ratelimitReconciler *RateLimitPolicyReconciler) getHTTPRouteResourceList(c
 	return resolveResourceList, nil
 }
 
+// getGQLRouteResourceList returns the subscription fields of the GQLRoutes which refer the ratelimit policy. The
+// enforcer applies the resource level ratelimits of a GraphQL API to the subscribe messages of its subscriptions.
+func (ratelimitReconciler *RateLimitPolicyReconciler) getGQLRouteResourceList(ctx context.Context, ratelimitKey types.NamespacedName,
+	ratelimitPolicy dpv1alpha3.RateLimitPolicy, gqlRefs []string) ([]dpv1alpha1.ResolveResource, error) {
+
+	var resolveResourceList []dpv1alpha1.ResolveResource
+	var gqlRoute dpv1alpha2.GQLRoute
+
+	for _, ref := range gqlRefs {
+		if ref != "" {
+			if err := ratelimitReconciler.client.Get(ctx, types.NamespacedName{
+				Namespace: ratelimitKey.Namespace,
+				Name:      ref},
+				&gqlRoute); err != nil {
+				return nil, fmt.Errorf("error while getting GQLRoute : %v for API : %v, %s", string(ref),
+					string(ratelimitPolicy.Spec.TargetRef.Name), err.Error())
+			}
+			for _, rule := range gqlRoute.Spec.Rules {
+				for _, filter := range rule.Filters {
+					if filter.ExtensionRef == nil || filter.ExtensionRef.Kind != constants.KindRateLimitPolicy ||
+						string(filter.ExtensionRef.Name) != ratelimitPolicy.Name {
+						continue
+					}
+					for _, match := range rule.Matches {
+						if match.Type == nil || *match.Type != gqlSubscription || match.Path == nil {
+							continue
+						}
+						var resolveResource dpv1alpha1.ResolveResource
+						resolveResource.Path = *match.Path
+						resolveResource.Method = string(*match.Type)
+						if ratelimitPolicy.Spec.Override != nil {
+							resolveResource.ResourceRatelimit.RequestsPerUnit = ratelimitPolicy.Spec.Override.API.RequestsPerUnit
+							resolveResource.ResourceRatelimit.Unit = ratelimitPolicy.Spec.Override.API.Unit
+						} else {
+							resolveResource.ResourceRatelimit.RequestsPerUnit = ratelimitPolicy.Spec.Default.API.RequestsPerUnit
+							resolveResource.ResourceRatelimit.Unit = ratelimitPolicy.Spec.Default.API.Unit
+						}
+						resolveResourceList = append(resolveResourceList, resolveResource)
+					}
+				}
+			}
+		}
+	}
+
+	return resolveResourceList, nil
+}
+
 func (ratelimitReconciler *RateLimitPolicyReconciler) marshelCustomRateLimit(ctx context.Context, ratelimitKey types.NamespacedName,
 	ratelimitPolicy dpv1alpha3.RateLimitPolicy) dpv1alpha1.CustomRateLimitPolicyDef {
 	var customRateLimitPolicy dpv1alpha1.CustomRateLimitPolicyDef
@@ -460,3 +604,8 @@ func (ratelimitReconciler *RateLimitPolicyReconciler) SetupWithManager(mgr ctrl.
 		For(&dpv1alpha3.RateLimitPolicy{}).
 		Complete(ratelimitReconciler)
 }
+
+// isBeingDeleted checks whether the deletion of the given CR has been requested.
+func isBeingDeleted(obj k8client.Object) bool {
+	return !obj.GetDeletionTimestamp().IsZero()
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wso2/apk/common-controller/internal/operator/status"
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8client "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newRatelimitStatusTestReconciler(t *testing.T, objects ...k8client.Object) (*RateLimitPolicyReconciler, k8client.Client) {
+	scheme := runtime.NewScheme()
+	assert.Nil(t, dpv1alpha3.AddToScheme(scheme))
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+		WithStatusSubresource(objects...).Build()
+	statusUpdater := status.NewUpdateHandler(client)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go statusUpdater.Start(ctx)
+	return &RateLimitPolicyReconciler{client: client, statusUpdater: statusUpdater}, client
+}
+
+func TestUpdateRatelimitPolicyStatus(t *testing.T) {
+	ratelimitPolicy := &dpv1alpha3.RateLimitPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default"}}
+	ratelimitReconciler, client := newRatelimitStatusTestReconciler(t, ratelimitPolicy)
+
+	ratelimitReconciler.updateRatelimitPolicyStatus(ratelimitPolicy, nil)
+	assert.Eventually(t, func() bool {
+		return client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "policy"},
+			ratelimitPolicy) == nil && len(ratelimitPolicy.Status.Conditions) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	for _, condition := range ratelimitPolicy.Status.Conditions {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status, condition.Type)
+	}
+}
+
+func TestUpdateRatelimitPolicyStatusSkipsDeletedPolicy(t *testing.T) {
+	ratelimitPolicy := &dpv1alpha3.RateLimitPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: "default",
+		Finalizers: []string{"test/finalizer"}}}
+	ratelimitReconciler, client := newRatelimitStatusTestReconciler(t, ratelimitPolicy)
+	assert.Nil(t, client.Delete(context.Background(), ratelimitPolicy))
+	assert.Nil(t, client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "policy"},
+		ratelimitPolicy))
+	assert.True(t, isBeingDeleted(ratelimitPolicy))
+
+	ratelimitReconciler.updateRatelimitPolicyStatus(ratelimitPolicy, nil)
+	// A status update of a live policy is sent after the skipped one to know when the queue is drained
+	livePolicy := &dpv1alpha3.RateLimitPolicy{ObjectMeta: metav1.ObjectMeta{Name: "live-policy", Namespace: "default"}}
+	assert.Nil(t, client.Create(context.Background(), livePolicy))
+	ratelimitReconciler.updateRatelimitPolicyStatus(livePolicy, nil)
+	assert.Eventually(t, func() bool {
+		return client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "live-policy"},
+			livePolicy) == nil && len(livePolicy.Status.Conditions) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Nil(t, client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "policy"},
+		ratelimitPolicy))
+	assert.Empty(t, ratelimitPolicy.Status.Conditions)
+}
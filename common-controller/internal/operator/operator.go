@@ -171,7 +171,11 @@ func InitOperator(metricsConfig config.Metrics) {
 		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error2655, logging.MAJOR,
 			"Unable to create webhook for Backend, error: %v", err))
 	}
-	if err := dpcontrollers.NewratelimitController(mgr, ratelimitStore); err != nil {
+	updateHandler := status.NewUpdateHandler(mgr.GetClient())
+	if err := mgr.Add(updateHandler); err != nil {
+		loggers.LoggerAPKOperator.Errorf("Failed to add status update handler %v", err)
+	}
+	if err := dpcontrollers.NewratelimitController(mgr, ratelimitStore, updateHandler); err != nil {
 		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error3114, logging.MAJOR,
 			"Error creating JWT Issuer controller, error: %v", err))
 	}
@@ -196,10 +200,6 @@ func InitOperator(metricsConfig config.Metrics) {
 		}
 	}
 
-	updateHandler := status.NewUpdateHandler(mgr.GetClient())
-	if err := mgr.Add(updateHandler); err != nil {
-		loggers.LoggerAPKOperator.Errorf("Failed to add status update handler %v", err)
-	}
 	if err := dpcontrollers.NewGatewayClassController(mgr, updateHandler); err != nil {
 		loggers.LoggerAPKOperator.ErrorC(logging.PrintError(logging.Error3114, logging.MAJOR,
 			"Error creating GatewayClass controller, error: %v", err))
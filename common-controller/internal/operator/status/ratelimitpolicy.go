@@ -0,0 +1,55 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package status
+
+import (
+	"reflect"
+	"time"
+
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetRateLimitPolicyCondition adds or updates a status condition of a RateLimitPolicy. The
+// transition time is only changed when the details of the condition change.
+func SetRateLimitPolicyCondition(ratelimitPolicy *dpv1alpha3.RateLimitPolicy, conditionType string,
+	status metav1.ConditionStatus, reason, msg string) {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: ratelimitPolicy.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+	}
+	for i, existing := range ratelimitPolicy.Status.Conditions {
+		if existing.Type == conditionType {
+			if existing.Status != status || existing.Reason != reason || existing.Message != msg ||
+				existing.ObservedGeneration != ratelimitPolicy.Generation {
+				ratelimitPolicy.Status.Conditions[i] = condition
+			}
+			return
+		}
+	}
+	ratelimitPolicy.Status.Conditions = append(ratelimitPolicy.Status.Conditions, condition)
+}
+
+// compareRateLimitPolicies compares status in RateLimitPolicy CRs.
+func compareRateLimitPolicies(ratelimitPolicy1 *dpv1alpha3.RateLimitPolicy, ratelimitPolicy2 *dpv1alpha3.RateLimitPolicy) bool {
+	return reflect.DeepEqual(ratelimitPolicy1.Status, ratelimitPolicy2.Status)
+}
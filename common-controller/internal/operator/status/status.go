@@ -22,6 +22,7 @@ import (
 
 	"github.com/wso2/apk/common-controller/internal/loggers"
 	dpv1alpha1 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha1"
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -94,12 +95,17 @@ func (updateHandler *UpdateHandler) Send(update Update) {
 // isStatusEqual checks if two objects have equivalent status.
 // Supported:
 //   - API
+//   - RateLimitPolicy
 func isStatusEqual(objA, objB interface{}) bool {
 	switch a := objA.(type) {
 	case *dpv1alpha1.API:
 		if b, ok := objB.(*dpv1alpha1.API); ok {
 			return compareAPIs(a, b)
 		}
+	case *dpv1alpha3.RateLimitPolicy:
+		if b, ok := objB.(*dpv1alpha3.RateLimitPolicy); ok {
+			return compareRateLimitPolicies(a, b)
+		}
 	}
 	return false
 }
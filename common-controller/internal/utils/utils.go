@@ -27,6 +27,7 @@ import (
 	"github.com/wso2/apk/common-controller/internal/config"
 	cpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/cp/v1alpha2"
 	cpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/cp/v1alpha3"
+	dpv1alpha2 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha2"
 	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
 	"github.com/wso2/apk/common-go-libs/constants"
 	"k8s.io/apimachinery/pkg/types"
@@ -176,6 +177,18 @@ func FilterHTTPRouteByNamespaces(namespaces []string) func(object *gwapiv1.HTTPR
 	}
 }
 
+// FilterGQLRouteByNamespaces takes a list of namespaces and returns a filter function
+// which return true if the input object is in the given namespaces list,
+// and returns false otherwise
+func FilterGQLRouteByNamespaces(namespaces []string) func(object *dpv1alpha2.GQLRoute) bool {
+	return func(object *dpv1alpha2.GQLRoute) bool {
+		if namespaces == nil {
+			return true
+		}
+		return stringutils.StringInSlice(object.GetNamespace(), namespaces)
+	}
+}
+
 // FilterAppMappingByNamespaces takes a list of namespaces and returns a filter function
 // which return true if the input object is in the given namespaces list,
 // and returns false otherwise
@@ -0,0 +1,96 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wso2/apk/adapter/pkg/logging"
+	loggers "github.com/wso2/apk/common-controller/internal/loggers"
+)
+
+const cacheInvalidationType = "CACHE_INVALIDATION"
+
+// These values are shared between the common controller and the enforcer, hence if it is required to change
+// these values, modifications should be done in the both common controller and enforcer.
+const (
+	responseCacheKeyPrefix                  = "wso2:apk:response_cache"
+	defaultResponseCacheInvalidationChannel = "wso2-apk-response-cache-invalidation-channel"
+)
+
+type cacheInvalidationRequest struct {
+	Organization string `json:"organization"`
+	APIUUID      string `json:"apiUUID"`
+}
+
+// invalidateResponseCache removes the cached responses of an API, or of all the APIs of an organization when the
+// API is not given, from redis and notifies the enforcers to remove them from their in-memory stores.
+func invalidateResponseCache(c *gin.Context) {
+	if !authenticateRequest(c) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized request"})
+		return
+	}
+	var request cacheInvalidationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		loggers.LoggerAPI.ErrorC(logging.PrintError(logging.Error3200, logging.MAJOR, "Error while parsing body: %v", err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Error while parsing json payload"})
+		return
+	}
+	if request.Organization == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "organization is required"})
+		return
+	}
+	keyPrefix := fmt.Sprintf("%s:%s:", responseCacheKeyPrefix, request.Organization)
+	if request.APIUUID != "" {
+		keyPrefix += request.APIUUID + ":"
+	}
+
+	ctx := context.Background()
+	removedEntries := 0
+	iter := rdb.Scan(ctx, 0, keyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		if err := rdb.Del(ctx, iter.Val()).Err(); err != nil {
+			loggers.LoggerAPI.ErrorC(logging.PrintError(logging.Error3209, logging.MAJOR,
+				"Error while removing the cached response %s from redis: %v", iter.Val(), err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to invalidate the response cache"})
+			return
+		}
+		removedEntries++
+	}
+	if err := iter.Err(); err != nil {
+		loggers.LoggerAPI.ErrorC(logging.PrintError(logging.Error3209, logging.MAJOR,
+			"Error while reading the cached responses from redis: %v", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to invalidate the response cache"})
+		return
+	}
+
+	channel := redisResponseCacheInvalidationChannel
+	if channel == "" {
+		channel = defaultResponseCacheInvalidationChannel
+	}
+	if err := rdb.Publish(ctx, channel, keyPrefix).Err(); err != nil {
+		loggers.LoggerAPI.ErrorC(logging.PrintError(logging.Error3209, logging.MAJOR,
+			"Error while publishing the response cache invalidation: %v", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to invalidate the response cache"})
+		return
+	}
+	loggers.LoggerAPI.Infof("Invalidated the response cache of %s, removed %d entries from redis", keyPrefix, removedEntries)
+	c.JSON(http.StatusOK, gin.H{"message": "Response cache invalidated successfully", "removedEntries": removedEntries})
+}
@@ -59,6 +59,7 @@ var (
 	redisCACertPath string
 	isTLSEnabled    bool
 	redisRevokedTokenChannel string
+	redisResponseCacheInvalidationChannel string
 	tokenExpiryDivider = "_##_"
 	authKeyPath string
 	authKeyHeader string
@@ -77,6 +78,7 @@ func init() {
 	redisCACertPath = conf.CommonController.Redis.CACertPath
 	isTLSEnabled = conf.CommonController.Redis.TLSEnabled
 	redisRevokedTokenChannel = conf.CommonController.Redis.RevokedTokenChannel
+	redisResponseCacheInvalidationChannel = conf.CommonController.Redis.ResponseCacheInvalidationChannel
 	authKeyPath = conf.CommonController.Sts.AuthKeyPath
 	authKeyHeader = conf.CommonController.Sts.AuthKeyHeader
 	utilruntime.Must(initRedisClient())
@@ -131,12 +133,16 @@ func NotifyHandler(c *gin.Context) {
 		revokeToken(c)
 		return
 	}
+	if _type == cacheInvalidationType {
+		invalidateResponseCache(c)
+		return
+	}
 	c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid type"})
 	return
 }
 
 func revokeToken(c *gin.Context) {
-	if !authenticateTokenRevocationRequest(c) {
+	if !authenticateRequest(c) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized request"})
 		return
 	}
@@ -203,7 +209,7 @@ func storeTokenInRedis(token string, expiry int64) error {
 	return nil
 }
 
-func authenticateTokenRevocationRequest(c *gin.Context) bool {
+func authenticateRequest(c *gin.Context) bool {
 	fileContent, err := ioutil.ReadFile(authKeyPath)
 	if err != nil {
 		loggers.LoggerAPI.ErrorC(logging.PrintError(logging.Error3204, logging.MAJOR, "Error reading shared key file: %v", err))
@@ -0,0 +1,231 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/wso2/apk/adapter/pkg/logging"
+	loggers "github.com/wso2/apk/common-controller/internal/loggers"
+	xds "github.com/wso2/apk/common-controller/internal/xds"
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// These values are shared between the common controller and the enforcer, hence if it is required to change
+// these values, modifications should be done in the both common controller and enforcer.
+const (
+	tokenBudgetPoliciesKey       = "wso2:apk:token_budget:policies"
+	tokenBudgetUsageKeyPrefix    = "wso2:apk:token_budget:usage"
+	tokenBudgetPeriodQuarter     = "Quarter"
+	tokenBudgetScopeSubscription = "Subscription"
+)
+
+// tokenBudget is the token budget of an AI ratelimit policy, shared with the enforcer through redis
+type tokenBudget struct {
+	Period          string   `json:"period"`
+	Scope           string   `json:"scope"`
+	TotalTokenCount uint64   `json:"totalTokenCount"`
+	AlertThresholds []uint32 `json:"alertThresholds"`
+}
+
+// tokenBudgetStatus is the status of a token budget of an application for the current period
+type tokenBudgetStatus struct {
+	Policy              string    `json:"policy"`
+	SubscriptionID      string    `json:"subscriptionId,omitempty"`
+	Period              string    `json:"period"`
+	PeriodStart         time.Time `json:"periodStart"`
+	PeriodEnd           time.Time `json:"periodEnd"`
+	TotalTokenCount     uint64    `json:"totalTokenCount"`
+	ConsumedTokenCount  uint64    `json:"consumedTokenCount"`
+	RemainingTokenCount uint64    `json:"remainingTokenCount"`
+}
+
+var (
+	// organization -> AI ratelimit policy name -> token budget
+	tokenBudgets   = make(map[string]map[string]*tokenBudget)
+	tokenBudgetsMu sync.RWMutex
+)
+
+// watchTokenBudgetUpdates shares the token budgets with the enforcers whenever the subscription based AI ratelimit
+// policies change.
+func watchTokenBudgetUpdates() {
+	for aiRatelimitPolicySpecs := range xds.TokenBudgetUpdates() {
+		updateTokenBudgets(aiRatelimitPolicySpecs)
+	}
+}
+
+// updateTokenBudgets updates the token budgets of the subscription based AI ratelimit policies and shares them
+// with the enforcers through redis.
+func updateTokenBudgets(aiRatelimitPolicySpecs map[types.NamespacedName]*dpv1alpha3.AIRateLimitPolicySpec) {
+	budgets := make(map[string]map[string]*tokenBudget)
+	fields := make(map[string]interface{})
+	for namespacedName, spec := range aiRatelimitPolicySpecs {
+		if spec.Override == nil || spec.Override.TokenBudget == nil {
+			continue
+		}
+		budget := &tokenBudget{
+			Period:          spec.Override.TokenBudget.Period,
+			Scope:           spec.Override.TokenBudget.Scope,
+			TotalTokenCount: spec.Override.TokenBudget.TotalTokenCount,
+			AlertThresholds: spec.Override.TokenBudget.AlertThresholds,
+		}
+		organization := spec.Override.Organization
+		if _, ok := budgets[organization]; !ok {
+			budgets[organization] = make(map[string]*tokenBudget)
+		}
+		budgets[organization][namespacedName.Name] = budget
+		budgetJSON, err := json.Marshal(budget)
+		if err != nil {
+			loggers.LoggerAPI.ErrorC(logging.PrintError(logging.Error3207, logging.MAJOR,
+				"Error while marshalling the token budget of the AI ratelimit policy %s: %v", namespacedName.String(), err))
+			continue
+		}
+		fields[getTokenBudgetPolicyKey(organization, namespacedName.Name)] = string(budgetJSON)
+	}
+	tokenBudgetsMu.Lock()
+	tokenBudgets = budgets
+	tokenBudgetsMu.Unlock()
+
+	ctx := context.Background()
+	_, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, tokenBudgetPoliciesKey)
+		if len(fields) > 0 {
+			pipe.HSet(ctx, tokenBudgetPoliciesKey, fields)
+		}
+		return nil
+	})
+	if err != nil {
+		loggers.LoggerAPI.ErrorC(logging.PrintError(logging.Error3207, logging.MAJOR,
+			"Error while storing the token budgets in redis: %v", err))
+	}
+}
+
+// TokenBudgetHandler returns the token budgets of an application for the current periods
+func TokenBudgetHandler(c *gin.Context) {
+	if !authenticateRequest(c) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized request"})
+		return
+	}
+	organization := c.Query("organization")
+	application := c.Query("application")
+	if organization == "" || application == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "organization and application are required"})
+		return
+	}
+
+	tokenBudgetsMu.RLock()
+	orgBudgets := tokenBudgets[organization]
+	tokenBudgetsMu.RUnlock()
+	policyNames := make([]string, 0, len(orgBudgets))
+	for policyName := range orgBudgets {
+		policyNames = append(policyNames, policyName)
+	}
+	sort.Strings(policyNames)
+
+	ctx := context.Background()
+	now := time.Now()
+	statuses := make([]tokenBudgetStatus, 0)
+	for _, policyName := range policyNames {
+		budget := orgBudgets[policyName]
+		periodID, periodStart, periodEnd := getTokenBudgetPeriod(budget.Period, now)
+		status := tokenBudgetStatus{
+			Policy:          policyName,
+			Period:          periodID,
+			PeriodStart:     periodStart,
+			PeriodEnd:       periodEnd,
+			TotalTokenCount: budget.TotalTokenCount,
+		}
+		usageKeyPrefix := fmt.Sprintf("%s:%s:%s:", tokenBudgetUsageKeyPrefix,
+			getTokenBudgetPolicyKey(organization, policyName), application)
+		if budget.Scope != tokenBudgetScopeSubscription {
+			consumed, err := getConsumedTokenCount(ctx, usageKeyPrefix+periodID)
+			if err != nil {
+				loggers.LoggerAPI.ErrorC(logging.PrintError(logging.Error3208, logging.MAJOR,
+					"Error while reading the token budget usage from redis: %v", err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read the token budgets"})
+				return
+			}
+			statuses = append(statuses, withConsumedTokenCount(status, consumed))
+			continue
+		}
+		// each subscription of the application has its own usage under the policy
+		iter := rdb.Scan(ctx, 0, usageKeyPrefix+"*:"+periodID, 100).Iterator()
+		for iter.Next(ctx) {
+			usageKey := iter.Val()
+			consumed, err := getConsumedTokenCount(ctx, usageKey)
+			if err != nil {
+				loggers.LoggerAPI.ErrorC(logging.PrintError(logging.Error3208, logging.MAJOR,
+					"Error while reading the token budget usage from redis: %v", err))
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read the token budgets"})
+				return
+			}
+			subscriptionStatus := status
+			subscriptionStatus.SubscriptionID = strings.TrimSuffix(strings.TrimPrefix(usageKey, usageKeyPrefix), ":"+periodID)
+			statuses = append(statuses, withConsumedTokenCount(subscriptionStatus, consumed))
+		}
+		if err := iter.Err(); err != nil {
+			loggers.LoggerAPI.ErrorC(logging.PrintError(logging.Error3208, logging.MAJOR,
+				"Error while reading the token budget usage from redis: %v", err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read the token budgets"})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"organization": organization, "application": application, "budgets": statuses})
+}
+
+func getTokenBudgetPolicyKey(organization string, policyName string) string {
+	return fmt.Sprintf("%s-%s", organization, policyName)
+}
+
+// getTokenBudgetPeriod returns the identifier, the start and the end of the budget period containing the given time
+func getTokenBudgetPeriod(period string, now time.Time) (string, time.Time, time.Time) {
+	now = now.UTC()
+	if period == tokenBudgetPeriodQuarter {
+		quarter := (int(now.Month()) - 1) / 3
+		start := time.Date(now.Year(), time.Month(quarter*3+1), 1, 0, 0, 0, 0, time.UTC)
+		return fmt.Sprintf("%d-Q%d", now.Year(), quarter+1), start, start.AddDate(0, 3, 0)
+	}
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start.Format("2006-01"), start, start.AddDate(0, 1, 0)
+}
+
+func getConsumedTokenCount(ctx context.Context, usageKey string) (uint64, error) {
+	consumed, err := rdb.Get(ctx, usageKey).Uint64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return consumed, err
+}
+
+func withConsumedTokenCount(status tokenBudgetStatus, consumed uint64) tokenBudgetStatus {
+	status.ConsumedTokenCount = consumed
+	if consumed < status.TotalTokenCount {
+		status.RemainingTokenCount = status.TotalTokenCount - consumed
+	}
+	return status
+}
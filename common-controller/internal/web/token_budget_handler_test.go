@@ -0,0 +1,268 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	dpv1alpha3 "github.com/wso2/apk/common-go-libs/apis/dp/v1alpha3"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const testAuthKey = "test-auth-key"
+
+// fakeRedis serves the redis commands used by the token budgets from memory, so the handlers are tested
+// without a redis server.
+type fakeRedis struct {
+	values map[string]string
+	hashes map[string]map[string]string
+	err    error
+}
+
+func (f *fakeRedis) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (f *fakeRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		return f.process(cmd)
+	}
+}
+
+func (f *fakeRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if err := f.process(cmd); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func (f *fakeRedis) process(cmd redis.Cmder) error {
+	if f.err != nil {
+		cmd.SetErr(f.err)
+		return f.err
+	}
+	args := make([]string, 0, len(cmd.Args()))
+	for _, arg := range cmd.Args() {
+		args = append(args, fmt.Sprint(arg))
+	}
+	switch strings.ToLower(cmd.Name()) {
+	case "get":
+		value, found := f.values[args[1]]
+		if !found {
+			cmd.SetErr(redis.Nil)
+			return redis.Nil
+		}
+		cmd.(*redis.StringCmd).SetVal(value)
+	case "scan":
+		keys := make([]string, 0)
+		for key := range f.values {
+			if matched, _ := path.Match(args[3], key); matched {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		cmd.(*redis.ScanCmd).SetVal(keys, 0)
+	case "del":
+		delete(f.values, args[1])
+		delete(f.hashes, args[1])
+	case "hset":
+		hash := make(map[string]string)
+		for i := 2; i+1 < len(args); i += 2 {
+			hash[args[i]] = args[i+1]
+		}
+		f.hashes[args[1]] = hash
+	}
+	return nil
+}
+
+func setUpTokenBudgetTest(t *testing.T) *fakeRedis {
+	fake := &fakeRedis{values: make(map[string]string), hashes: make(map[string]map[string]string)}
+	previousRdb := rdb
+	rdb = redis.NewClient(&redis.Options{Addr: "localhost:0"})
+	rdb.AddHook(fake)
+
+	previousAuthKeyPath := authKeyPath
+	previousAuthKeyHeader := authKeyHeader
+	authKeyPath = filepath.Join(t.TempDir(), "auth-key")
+	authKeyHeader = "api-key"
+	assert.Nil(t, os.WriteFile(authKeyPath, []byte(testAuthKey), 0600))
+
+	t.Cleanup(func() {
+		rdb = previousRdb
+		authKeyPath = previousAuthKeyPath
+		authKeyHeader = previousAuthKeyHeader
+		tokenBudgetsMu.Lock()
+		tokenBudgets = make(map[string]map[string]*tokenBudget)
+		tokenBudgetsMu.Unlock()
+	})
+	return fake
+}
+
+func getTokenBudgets(query string, authKey string) (int, map[string]interface{}) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/token-budgets?"+query, nil)
+	if authKey != "" {
+		c.Request.Header.Set(authKeyHeader, authKey)
+	}
+	TokenBudgetHandler(c)
+	var body map[string]interface{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &body)
+	return recorder.Code, body
+}
+
+func TestUpdateTokenBudgets(t *testing.T) {
+	fake := setUpTokenBudgetTest(t)
+	fake.hashes[tokenBudgetPoliciesKey] = map[string]string{"org1-removed": "{}"}
+
+	updateTokenBudgets(map[types.NamespacedName]*dpv1alpha3.AIRateLimitPolicySpec{
+		{Namespace: "default", Name: "gold"}: {
+			Override: &dpv1alpha3.AIRateLimit{
+				Organization: "org1",
+				TokenBudget: &dpv1alpha3.TokenBudget{
+					Period:          "Quarter",
+					Scope:           "Subscription",
+					TotalTokenCount: 1000,
+					AlertThresholds: []uint32{80, 100},
+				},
+			},
+		},
+		{Namespace: "default", Name: "silver"}: {
+			Override: &dpv1alpha3.AIRateLimit{Organization: "org1"},
+		},
+	})
+
+	assert.Equal(t, []string{"org1-gold"}, sortedMapKeys(fake.hashes[tokenBudgetPoliciesKey]),
+		"Only the policies with a token budget should be shared, replacing the removed ones.")
+	var budget tokenBudget
+	assert.Nil(t, json.Unmarshal([]byte(fake.hashes[tokenBudgetPoliciesKey]["org1-gold"]), &budget))
+	assert.Equal(t, tokenBudget{Period: "Quarter", Scope: "Subscription", TotalTokenCount: 1000,
+		AlertThresholds: []uint32{80, 100}}, budget)
+	assert.Contains(t, tokenBudgets["org1"], "gold")
+	assert.NotContains(t, tokenBudgets["org1"], "silver")
+
+	updateTokenBudgets(map[types.NamespacedName]*dpv1alpha3.AIRateLimitPolicySpec{})
+	assert.NotContains(t, fake.hashes, tokenBudgetPoliciesKey, "The budgets of the deleted policies should be removed.")
+	assert.Empty(t, tokenBudgets)
+}
+
+func TestTokenBudgetHandler(t *testing.T) {
+	fake := setUpTokenBudgetTest(t)
+	tokenBudgets = map[string]map[string]*tokenBudget{
+		"org1": {
+			"gold":   {Period: "Month", Scope: "Application", TotalTokenCount: 1000},
+			"silver": {Period: "Quarter", Scope: "Subscription", TotalTokenCount: 500},
+		},
+	}
+	monthID, _, _ := getTokenBudgetPeriod("Month", time.Now())
+	quarterID, _, _ := getTokenBudgetPeriod("Quarter", time.Now())
+	fake.values[tokenBudgetUsageKeyPrefix+":org1-gold:app1:"+monthID] = "1200"
+	fake.values[tokenBudgetUsageKeyPrefix+":org1-silver:app1:sub1:"+quarterID] = "100"
+	fake.values[tokenBudgetUsageKeyPrefix+":org1-silver:app1:sub2:"+quarterID] = "50"
+	fake.values[tokenBudgetUsageKeyPrefix+":org1-silver:app2:sub3:"+quarterID] = "10"
+
+	code, body := getTokenBudgets("organization=org1&application=app1", testAuthKey)
+	assert.Equal(t, http.StatusOK, code)
+	budgets := body["budgets"].([]interface{})
+	assert.Len(t, budgets, 3, "The application budget and a budget per subscription should be returned.")
+
+	gold := budgets[0].(map[string]interface{})
+	assert.Equal(t, "gold", gold["policy"])
+	assert.Equal(t, monthID, gold["period"])
+	assert.NotContains(t, gold, "subscriptionId")
+	assert.Equal(t, float64(1200), gold["consumedTokenCount"])
+	assert.Equal(t, float64(0), gold["remainingTokenCount"], "The remaining tokens should not go below zero.")
+
+	sub1 := budgets[1].(map[string]interface{})
+	assert.Equal(t, "silver", sub1["policy"])
+	assert.Equal(t, "sub1", sub1["subscriptionId"])
+	assert.Equal(t, quarterID, sub1["period"])
+	assert.Equal(t, float64(400), sub1["remainingTokenCount"])
+	sub2 := budgets[2].(map[string]interface{})
+	assert.Equal(t, "sub2", sub2["subscriptionId"])
+	assert.Equal(t, float64(450), sub2["remainingTokenCount"])
+
+	code, body = getTokenBudgets("organization=org1&application=app3", testAuthKey)
+	assert.Equal(t, http.StatusOK, code)
+	budgets = body["budgets"].([]interface{})
+	assert.Len(t, budgets, 1, "Only the application scoped budget should be returned for an unused application.")
+	assert.Equal(t, float64(1000), budgets[0].(map[string]interface{})["remainingTokenCount"])
+}
+
+func TestTokenBudgetHandlerErrors(t *testing.T) {
+	fake := setUpTokenBudgetTest(t)
+	tokenBudgets = map[string]map[string]*tokenBudget{
+		"org1": {"gold": {Period: "Month", Scope: "Application", TotalTokenCount: 1000}},
+	}
+
+	code, _ := getTokenBudgets("organization=org1&application=app1", "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = getTokenBudgets("organization=org1&application=app1", "invalid-key")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = getTokenBudgets("organization=org1", testAuthKey)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	fake.err = errors.New("connection refused")
+	code, _ = getTokenBudgets("organization=org1&application=app1", testAuthKey)
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
+
+func TestGetTokenBudgetPeriod(t *testing.T) {
+	periodID, start, end := getTokenBudgetPeriod("Month", time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC))
+	assert.Equal(t, "2024-12", periodID)
+	assert.Equal(t, time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), end)
+
+	periodID, start, end = getTokenBudgetPeriod("Quarter", time.Date(2024, 11, 15, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, "2024-Q4", periodID)
+	assert.Equal(t, time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), end)
+
+	// the periods are in UTC, regardless of the time zone of the given time
+	periodID, _, _ = getTokenBudgetPeriod("Month", time.Date(2024, 4, 1, 1, 0, 0, 0, time.FixedZone("IST", 5*60*60+30*60)))
+	assert.Equal(t, "2024-03", periodID)
+}
+
+func sortedMapKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
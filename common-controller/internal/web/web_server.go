@@ -30,6 +30,8 @@ func StartWebServer() {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
 	router.POST("/notify", NotifyHandler)
+	router.GET("/token-budgets", TokenBudgetHandler)
+	go watchTokenBudgetUpdates()
 	conf := config.ReadConfigs()
 	certPath := conf.CommonController.Keystore.CertPath
 	keyPath := conf.CommonController.Keystore.KeyPath
@@ -93,6 +93,9 @@ var (
 	// TODO(amali) This doesn't have a usage yet. It will be used to handle multiple enforcer labels in future.
 	enforcerLabelMap map[string]*EnforcerInternalAPI // Enforcer Label -> EnforcerInternalAPI struct map
 
+	// Latest subscription based AI ratelimit policies, pending to be shared with the enforcers as token budgets
+	tokenBudgetUpdates = make(chan map[apimachiner_types.NamespacedName]*dpv1alpha3.AIRateLimitPolicySpec, 1)
+
 	// KeyManagerList to store data
 	KeyManagerList = make([]eventhubTypes.KeyManager, 0)
 	isReady        = false
@@ -172,6 +175,27 @@ func UpdateRateLimitXDSCacheForAIRatelimitPolicies(aiRatelimitPolicySpecs map[ap
 // UpdateRateLimitXDSCacheForSubscriptionBasedAIRatelimitPolicies updates the xDS cache of the RateLimiter for AI ratelimit policies.
 func UpdateRateLimitXDSCacheForSubscriptionBasedAIRatelimitPolicies(aiRatelimitPolicySpecs map[apimachiner_types.NamespacedName]*dpv1alpha3.AIRateLimitPolicySpec) {
 	rlsPolicyCache.ProcessSubscriptionBasedAIRatelimitPolicySpecsAndUpdateCache(aiRatelimitPolicySpecs)
+	notifyTokenBudgetUpdate(aiRatelimitPolicySpecs)
+}
+
+// TokenBudgetUpdates returns the channel which receives the latest subscription based AI ratelimit policies
+// whenever they change, so that their token budgets can be shared with the enforcers
+func TokenBudgetUpdates() <-chan map[apimachiner_types.NamespacedName]*dpv1alpha3.AIRateLimitPolicySpec {
+	return tokenBudgetUpdates
+}
+
+// notifyTokenBudgetUpdate replaces any pending update with the given policies, so a slow receiver only sees the
+// latest state
+func notifyTokenBudgetUpdate(aiRatelimitPolicySpecs map[apimachiner_types.NamespacedName]*dpv1alpha3.AIRateLimitPolicySpec) {
+	specs := make(map[apimachiner_types.NamespacedName]*dpv1alpha3.AIRateLimitPolicySpec, len(aiRatelimitPolicySpecs))
+	for key, spec := range aiRatelimitPolicySpecs {
+		specs[key] = spec
+	}
+	select {
+	case <-tokenBudgetUpdates:
+	default:
+	}
+	tokenBudgetUpdates <- specs
 }
 
 // DeleteAPILevelRateLimitPolicies delete the ratelimit xds cache
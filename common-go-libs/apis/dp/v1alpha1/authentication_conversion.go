@@ -115,8 +115,7 @@ func (src *Authentication) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Override = &overrideAuthenticationSpec
 	}
 
-	// Status
-	dst.Status = v1alpha2.AuthenticationStatus(src.Status)
+	// Status is only tracked in the hub version
 	return nil
 }
 
@@ -190,8 +189,7 @@ func (src *Authentication) ConvertFrom(srcRaw conversion.Hub) error {
 		}
 		src.Spec.Override = &overrideAuthenticationSpec
 	}
-	// Status
-	src.Status = AuthenticationStatus(dst.Status)
+	// Status is only tracked in the hub version
 
 	return nil
 }
@@ -32,6 +32,7 @@ type ResolvedJWTIssuer struct {
 	SignatureValidation ResolvedSignatureValidation
 	ClaimMappings       map[string]string
 	Environments        []string
+	Introspection       *ResolvedIntrospection
 }
 
 // ResolvedSignatureValidation holds the resolved properties of SignatureValidation
@@ -45,3 +46,13 @@ type ResolvedJWKS struct {
 	URL string
 	TLS *ResolvedTLSConfig
 }
+
+// ResolvedIntrospection holds the resolved properties of Introspection
+type ResolvedIntrospection struct {
+	URL              string
+	TLS              *ResolvedTLSConfig
+	ClientID         string
+	ClientSecret     string
+	PositiveCacheTTL uint32
+	NegativeCacheTTL uint32
+}
@@ -1355,6 +1355,26 @@ func (in *ResolvedBasicSecurityConfig) DeepCopy() *ResolvedBasicSecurityConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedIntrospection) DeepCopyInto(out *ResolvedIntrospection) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ResolvedTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedIntrospection.
+func (in *ResolvedIntrospection) DeepCopy() *ResolvedIntrospection {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedIntrospection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedJWKS) DeepCopyInto(out *ResolvedJWKS) {
 	*out = *in
@@ -1391,6 +1411,11 @@ func (in *ResolvedJWTIssuer) DeepCopyInto(out *ResolvedJWTIssuer) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Introspection != nil {
+		in, out := &in.Introspection, &out.Introspection
+		*out = new(ResolvedIntrospection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedJWTIssuer.
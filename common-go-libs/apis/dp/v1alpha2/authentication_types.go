@@ -64,6 +64,12 @@ type APIAuth struct {
 	//
 	// +optional
 	MutualSSL *MutualSSLConfig `json:"mtls,omitempty"`
+
+	// HMAC is to specify the HMAC request signature authentication scheme details
+	//
+	// +optional
+	// +nullable
+	HMAC *HMACAuth `json:"hmac,omitempty"`
 }
 
 // MutualSSLConfig scheme type and details
@@ -175,10 +181,85 @@ type APIKey struct {
 	SendTokenToUpstream bool `json:"sendTokenToUpstream,omitempty"`
 }
 
+// HMACAuth HMAC request signature authentication scheme details. Requests are
+// signed with a shared key and the signature is passed in the Signature header
+// as defined in draft-cavage-http-signatures and RFC 9421.
+type HMACAuth struct {
+	// Required indicates if this authentication is optional or mandatory
+	//
+	// +kubebuilder:validation:Enum=mandatory;optional
+	// +kubebuilder:default:=optional
+	// +optional
+	Required string `json:"required,omitempty"`
+
+	// Header is the header name used to pass the signature
+	//
+	// +kubebuilder:default:=signature
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Header string `json:"header,omitempty"`
+
+	// SecretRef denotes the reference to the Secret that contains the shared
+	// keys. Each entry of the Secret maps a key ID to its key.
+	SecretRef HMACSecretRef `json:"secretRef"`
+
+	// Algorithms lists the signature algorithms accepted for the API
+	//
+	// +kubebuilder:default:={"hmac-sha256"}
+	// +optional
+	Algorithms []HMACAlgorithm `json:"algorithms,omitempty"`
+
+	// SignedHeaders lists the headers which must be covered by the signature.
+	// A signed digest header is validated against the body of the request,
+	// which has to be buffered for the API.
+	//
+	// +kubebuilder:default:={"(request-target)","date"}
+	// +optional
+	SignedHeaders []string `json:"signedHeaders,omitempty"`
+
+	// ClockSkew is the tolerated difference in seconds between the signature
+	// creation time and the gateway time
+	//
+	// +kubebuilder:default=300
+	// +optional
+	ClockSkew uint32 `json:"clockSkew,omitempty"`
+
+	// SendTokenToUpstream is to specify whether the signature should be sent to the upstream
+	//
+	// +optional
+	SendTokenToUpstream bool `json:"sendTokenToUpstream,omitempty"`
+}
+
+// HMACSecretRef refers to the Secret that contains the shared keys of HMAC authentication
+type HMACSecretRef struct {
+	// Name of the secret
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// HMACAlgorithm is the signature algorithm of HMAC authentication
+//
+// +kubebuilder:validation:Enum=hmac-sha256;hmac-sha384;hmac-sha512
+type HMACAlgorithm string
+
 // AuthenticationStatus defines the observed state of Authentication
 type AuthenticationStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Conditions describe the current state of the resource as observed by
+	// the data plane. Known condition types are Accepted, ResolvedRefs,
+	// Attached and Conflicted.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// AttachedAPIs lists the APIs (namespace/name) this resource is
+	// currently applied to.
+	//
+	// +optional
+	AttachedAPIs []string `json:"attachedAPIs,omitempty"`
 }
 
 // +genclient
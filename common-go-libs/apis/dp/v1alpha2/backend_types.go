@@ -43,7 +43,7 @@ type BackendSpec struct {
 	// Services holds hosts and ports
 	//
 	// +kubebuilder:validation:MinItems=1
-	// +kubebuilder:validation:MaxItems=1
+	// +kubebuilder:validation:MaxItems=8
 	Services []Service `json:"services,omitempty"`
 
 	// Protocol defines the backend protocol
@@ -74,6 +74,122 @@ type BackendSpec struct {
 
 	// HealthCheck configuration for the backend tcp health check
 	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+
+	// OutlierDetection configuration for the backend passive health check
+	//
+	// +optional
+	OutlierDetection *OutlierDetection `json:"outlierDetection,omitempty"`
+
+	// LoadBalancing configuration for distributing requests among the services of the backend
+	//
+	// +optional
+	LoadBalancing *LoadBalancing `json:"loadBalancing,omitempty"`
+}
+
+// LoadBalancingPolicyType defines the load balancing policy type.
+type LoadBalancingPolicyType string
+
+const (
+	// RoundRobinLoadBalancing selects the services in round robin order
+	RoundRobinLoadBalancing LoadBalancingPolicyType = "RoundRobin"
+	// LeastRequestLoadBalancing selects the service with the fewest active requests
+	LeastRequestLoadBalancing LoadBalancingPolicyType = "LeastRequest"
+	// RandomLoadBalancing selects a random service
+	RandomLoadBalancing LoadBalancingPolicyType = "Random"
+	// RingHashLoadBalancing selects the service using consistent hashing on a ring
+	RingHashLoadBalancing LoadBalancingPolicyType = "RingHash"
+	// MaglevLoadBalancing selects the service using maglev consistent hashing
+	MaglevLoadBalancing LoadBalancingPolicyType = "Maglev"
+)
+
+// HashKeyType defines the request attribute used for consistent hashing.
+type HashKeyType string
+
+const (
+	// HeaderHashKey hashes on the value of a request header
+	HeaderHashKey HashKeyType = "Header"
+	// CookieHashKey hashes on the value of a cookie
+	CookieHashKey HashKeyType = "Cookie"
+	// SourceIPHashKey hashes on the source IP address of the client
+	SourceIPHashKey HashKeyType = "SourceIP"
+)
+
+// LoadBalancing defines the load balancing configurations
+type LoadBalancing struct {
+
+	// Policy defines the algorithm used to select a service of the backend.
+	//
+	// +kubebuilder:validation:Enum=RoundRobin;LeastRequest;Random;RingHash;Maglev
+	// +kubebuilder:default=RoundRobin
+	// +optional
+	Policy LoadBalancingPolicyType `json:"policy,omitempty"`
+
+	// HashKeys defines the request attributes used to compute the hash when the policy is
+	// RingHash or Maglev. Keys are evaluated in order and the first available key is used.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=8
+	HashKeys []HashKey `json:"hashKeys,omitempty"`
+}
+
+// HashKey defines a request attribute used to compute the consistent hash
+type HashKey struct {
+
+	// Type of the request attribute.
+	//
+	// +kubebuilder:validation:Enum=Header;Cookie;SourceIP
+	Type HashKeyType `json:"type"`
+
+	// Name of the header or the cookie. Not required for the SourceIP type.
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// CookieTTL is the lifetime of the cookie in seconds. When set, the cookie is generated for clients
+	// which do not send it, so that subsequent requests stick to the same service (session affinity).
+	//
+	// +optional
+	CookieTTL uint32 `json:"cookieTTL,omitempty"`
+}
+
+// OutlierDetection defines the configurations to passively eject misbehaving hosts of the backend
+// based on the responses received from them
+type OutlierDetection struct {
+
+	// Consecutive5xxErrors is the number of consecutive 5xx responses, including connection failures,
+	// required before a host is ejected. A value of 0 disables ejection on 5xx responses.
+	//
+	// +kubebuilder:default=5
+	// +optional
+	Consecutive5xxErrors uint32 `json:"consecutive5xxErrors"`
+
+	// ConsecutiveGatewayErrors is the number of consecutive gateway errors (502, 503 and 504 responses),
+	// required before a host is ejected. A value of 0 disables ejection on gateway errors.
+	//
+	// +optional
+	ConsecutiveGatewayErrors uint32 `json:"consecutiveGatewayErrors,omitempty"`
+
+	// Interval is the time between ejection analysis sweeps in seconds.
+	//
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Interval uint32 `json:"interval,omitempty"`
+
+	// BaseEjectionTime is the base time that a host is ejected for in seconds. The real time is equal to
+	// the base time multiplied by the number of times the host has been ejected.
+	//
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BaseEjectionTime uint32 `json:"baseEjectionTime,omitempty"`
+
+	// MaxEjectionPercent is the maximum percentage of hosts of the backend that can be ejected.
+	//
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxEjectionPercent uint32 `json:"maxEjectionPercent,omitempty"`
 }
 
 // HealthCheck defines the health check configurations
@@ -191,6 +307,19 @@ type Service struct {
 
 	// Port of the service
 	Port uint32 `json:"port"`
+
+	// Weight of the service when load balancing among the services of the same priority.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=128
+	// +optional
+	Weight uint32 `json:"weight,omitempty"`
+
+	// Priority of the service. Services with priority 0 are used first and the traffic
+	// fails over to the services with the next priority when they become unhealthy.
+	//
+	// +optional
+	Priority uint32 `json:"priority,omitempty"`
 }
 
 // TLSConfig defines enpoint TLS configurations
@@ -208,6 +337,21 @@ type TLSConfig struct {
 	//
 	// +optional
 	AllowedSANs []string `json:"allowedSANs,omitempty"`
+
+	// ClientCertificateRef denotes the reference to the kubernetes.io/tls Secret that contains
+	// the client certificate and the private key presented to the backend for mutual TLS
+	//
+	// +optional
+	ClientCertificateRef *ClientCertificateRef `json:"clientCertificateRef,omitempty"`
+}
+
+// ClientCertificateRef holds the reference to a kubernetes.io/tls Secret. The certificate and the
+// private key are read from the tls.crt and tls.key entries of the Secret.
+type ClientCertificateRef struct {
+	// Name of the Secret
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
 }
 
 // SecurityConfig defines enpoint security configurations
@@ -216,6 +360,110 @@ type SecurityConfig struct {
 	Basic *BasicSecurityConfig `json:"basic,omitempty"`
 	// APIKey security configuration
 	APIKey *APIKeySecurityConfig `json:"apiKey,omitempty"`
+	// OAuth2 client credentials security configuration
+	OAuth2 *OAuth2SecurityConfig `json:"oauth2,omitempty"`
+	// AWS signature version 4 security configuration
+	AWS *AWSSecurityConfig `json:"aws,omitempty"`
+}
+
+// AWSSecurityConfig defines the AWS credentials with which the requests are
+// signed with signature version 4. The requests of the AI APIs converted to
+// the Bedrock format are signed after they are converted.
+type AWSSecurityConfig struct {
+	// Region of the AWS service
+	//
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+
+	// Service is the signing name of the AWS service
+	//
+	// +kubebuilder:default=bedrock
+	// +optional
+	Service string `json:"service,omitempty"`
+
+	// SecretRef to the AWS credentials
+	SecretRef AWSSecretRef `json:"secretRef"`
+}
+
+// AWSSecretRef to AWS credentials
+type AWSSecretRef struct {
+	// Name of the secret
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// AccessKeyIDKey of the secret
+	//
+	// +kubebuilder:default=accessKeyId
+	// +optional
+	AccessKeyIDKey string `json:"accessKeyIdKey,omitempty"`
+
+	// SecretAccessKeyKey of the secret
+	//
+	// +kubebuilder:default=secretAccessKey
+	// +optional
+	SecretAccessKeyKey string `json:"secretAccessKeyKey,omitempty"`
+
+	// SessionTokenKey of the secret, for temporary credentials
+	//
+	// +optional
+	SessionTokenKey string `json:"sessionTokenKey,omitempty"`
+}
+
+// OAuth2SecurityConfig defines the OAuth2 client credentials grant used to obtain
+// an access token which is sent to the backend as a bearer token
+type OAuth2SecurityConfig struct {
+	// TokenEndpoint is the URL of the token endpoint of the authorization server
+	//
+	// +kubebuilder:validation:MinLength=1
+	TokenEndpoint string `json:"tokenEndpoint"`
+
+	// ClientSecretRef to the client credentials
+	ClientSecretRef ClientSecretRef `json:"clientSecretRef"`
+
+	// Scopes to request in the token request
+	//
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Audience to request in the token request
+	//
+	// +optional
+	Audience string `json:"audience,omitempty"`
+
+	// TokenCacheTTL is the maximum time in seconds a token is cached. The expiry
+	// returned by the token endpoint is used when not provided.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	TokenCacheTTL uint32 `json:"tokenCacheTTL,omitempty"`
+
+	// RefreshSkew is the time in seconds before the expiry of a token at which
+	// the token is refreshed. The skew is limited to half of the lifetime of the token.
+	//
+	// +kubebuilder:default=30
+	// +optional
+	RefreshSkew uint32 `json:"refreshSkew,omitempty"`
+}
+
+// ClientSecretRef to OAuth2 client credentials
+type ClientSecretRef struct {
+	// Name of the secret
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// ClientIDKey of the secret
+	//
+	// +kubebuilder:default=clientId
+	// +optional
+	ClientIDKey string `json:"clientIdKey,omitempty"`
+
+	// ClientSecretKey of the secret
+	//
+	// +kubebuilder:default=clientSecret
+	// +optional
+	ClientSecretKey string `json:"clientSecretKey,omitempty"`
 }
 
 // APIKeySecurityConfig defines APIKey security configurations
@@ -272,7 +520,23 @@ type SecretRef struct {
 }
 
 // BackendStatus defines the observed state of Backend
-type BackendStatus struct{}
+type BackendStatus struct {
+	// Conditions describe the current state of the resource as observed by
+	// the data plane. Known condition types are Accepted, ResolvedRefs,
+	// Attached and Conflicted.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// AttachedAPIs lists the APIs (namespace/name) this resource is
+	// currently applied to.
+	//
+	// +optional
+	AttachedAPIs []string `json:"attachedAPIs,omitempty"`
+}
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
@@ -103,7 +103,9 @@ type GQLRouteMatch struct {
 // Accepted Condition for the Route to `status: False`, with a
 // Reason of `UnsupportedValue`.
 //
-// +kubebuilder:validation:Enum=QUERY;MUTATION
+// SUBSCRIPTION operations are served over a WebSocket connection upgraded from a GET request to the API.
+//
+// +kubebuilder:validation:Enum=QUERY;MUTATION;SUBSCRIPTION
 type GQLType string
 
 // GQLRouteStatus defines the observed state of GQLRoute
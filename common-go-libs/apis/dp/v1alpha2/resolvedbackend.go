@@ -29,12 +29,19 @@ type ResolvedBackend struct {
 	Retry          *RetryConfig
 	BasePath       string `json:"basePath"`
 	HealthCheck    *HealthCheck
+	// OutlierDetection of the backend
+	OutlierDetection *OutlierDetection
+	// LoadBalancing of the backend
+	LoadBalancing *LoadBalancing
 }
 
 // ResolvedTLSConfig defines enpoint TLS configurations
 type ResolvedTLSConfig struct {
 	ResolvedCertificate string
 	AllowedSANs         []string
+	// ClientCertificate and ClientPrivateKey are presented to the backend for mutual TLS
+	ClientCertificate string
+	ClientPrivateKey  string
 }
 
 // ResolvedSecurityConfig defines enpoint resolved security configurations
@@ -42,6 +49,8 @@ type ResolvedSecurityConfig struct {
 	Type   string
 	Basic  ResolvedBasicSecurityConfig
 	APIKey ResolvedAPIKeySecurityConfig
+	OAuth2 ResolvedOAuth2SecurityConfig
+	AWS    ResolvedAWSSecurityConfig
 }
 
 // ResolvedBasicSecurityConfig defines resolved basic security configuration
@@ -56,3 +65,23 @@ type ResolvedAPIKeySecurityConfig struct {
 	Name  string
 	Value string
 }
+
+// ResolvedOAuth2SecurityConfig defines resolved OAuth2 client credentials security configuration
+type ResolvedOAuth2SecurityConfig struct {
+	TokenEndpoint string
+	ClientID      string
+	ClientSecret  string
+	Scopes        []string
+	Audience      string
+	TokenCacheTTL uint32
+	RefreshSkew   uint32
+}
+
+// ResolvedAWSSecurityConfig defines resolved AWS signature version 4 security configuration
+type ResolvedAWSSecurityConfig struct {
+	Region          string
+	Service         string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
@@ -26,6 +26,8 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // TokenIssuerSpec defines the desired state of TokenIssuer
+//
+// +kubebuilder:validation:XValidation:rule="has(self.signatureValidation) || has(self.introspection)",message="signatureValidation is required unless introspection is configured"
 type TokenIssuerSpec struct {
 	// Name is the unique name of the Token Issuer in
 	// the Organization defined . "Organization/Name" can
@@ -54,8 +56,17 @@ type TokenIssuerSpec struct {
 	// +kubebuilder:validation:MinLength=1
 	ScopesClaim string `json:"scopesClaim"`
 
-	// SignatureValidation denotes the signature validation method of jwt
-	SignatureValidation *SignatureValidation `json:"signatureValidation"`
+	// SignatureValidation denotes the signature validation method of jwt. It
+	// can only be omitted when Introspection is configured.
+	//
+	// +optional
+	SignatureValidation *SignatureValidation `json:"signatureValidation,omitempty"`
+
+	// Introspection denotes the RFC 7662 introspection endpoint used to
+	// validate opaque tokens of the issuer.
+	//
+	// +optional
+	Introspection *Introspection `json:"introspection,omitempty"`
 
 	// ClaimMappings denotes the claim mappings of the jwt
 	ClaimMappings *[]ClaimMapping `json:"claimMappings,omitempty"`
@@ -94,6 +105,38 @@ type JWKS struct {
 	TLS *CERTConfig `json:"tls,omitempty"`
 }
 
+// Introspection defines the token introspection endpoint. The claims of the
+// introspection response are mapped with the ClaimMappings, ConsumerKeyClaim
+// and ScopesClaim of the TokenIssuer.
+type Introspection struct {
+	// URL is the URL of the introspection endpoint
+	//
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// TLS denotes the TLS configuration of the introspection endpoint
+	//
+	// +optional
+	TLS *CERTConfig `json:"tls,omitempty"`
+
+	// ClientSecretRef denotes the reference to the Secret that contains the
+	// client credentials used to call the introspection endpoint
+	ClientSecretRef ClientSecretRef `json:"clientSecretRef"`
+
+	// PositiveCacheTTL is the time in seconds an active token is cached. A token
+	// is not cached beyond its expiry.
+	//
+	// +kubebuilder:default=300
+	// +optional
+	PositiveCacheTTL uint32 `json:"positiveCacheTTL,omitempty"`
+
+	// NegativeCacheTTL is the time in seconds an inactive token is cached.
+	//
+	// +kubebuilder:default=30
+	// +optional
+	NegativeCacheTTL uint32 `json:"negativeCacheTTL,omitempty"`
+}
+
 // CERTConfig defines the certificate configuration
 type CERTConfig struct {
 	// CertificateInline is the Inline Certificate entry
@@ -119,8 +162,21 @@ type RefConfig struct {
 
 // TokenIssuerStatus defines the observed state of TokenIssuer
 type TokenIssuerStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Conditions describe the current state of the resource as observed by
+	// the data plane. Known condition types are Accepted, ResolvedRefs,
+	// Attached and Conflicted.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// AttachedAPIs lists the APIs (namespace/name) this resource is
+	// currently applied to.
+	//
+	// +optional
+	AttachedAPIs []string `json:"attachedAPIs,omitempty"`
 }
 
 // +genclient
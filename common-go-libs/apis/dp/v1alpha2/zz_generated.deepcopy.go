@@ -23,8 +23,9 @@
 package v1alpha2
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/gateway-api/apis/v1"
+	apisv1 "sigs.k8s.io/gateway-api/apis/v1"
 	apisv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
@@ -70,6 +71,11 @@ func (in *APIAuth) DeepCopyInto(out *APIAuth) {
 		*out = new(MutualSSLConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HMAC != nil {
+		in, out := &in.HMAC, &out.HMAC
+		*out = new(HMACAuth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIAuth.
@@ -315,6 +321,37 @@ func (in *APIStatus) DeepCopy() *APIStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSecretRef) DeepCopyInto(out *AWSSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSecretRef.
+func (in *AWSSecretRef) DeepCopy() *AWSSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSecurityConfig) DeepCopyInto(out *AWSSecurityConfig) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSecurityConfig.
+func (in *AWSSecurityConfig) DeepCopy() *AWSSecurityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSecurityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthSpec) DeepCopyInto(out *AuthSpec) {
 	*out = *in
@@ -346,7 +383,7 @@ func (in *Authentication) DeepCopyInto(out *Authentication) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Authentication.
@@ -428,6 +465,18 @@ func (in *AuthenticationSpec) DeepCopy() *AuthenticationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthenticationStatus) DeepCopyInto(out *AuthenticationStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedAPIs != nil {
+		in, out := &in.AttachedAPIs, &out.AttachedAPIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationStatus.
@@ -446,7 +495,7 @@ func (in *Backend) DeepCopyInto(out *Backend) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backend.
@@ -552,6 +601,16 @@ func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
 		*out = new(HealthCheck)
 		**out = **in
 	}
+	if in.OutlierDetection != nil {
+		in, out := &in.OutlierDetection, &out.OutlierDetection
+		*out = new(OutlierDetection)
+		**out = **in
+	}
+	if in.LoadBalancing != nil {
+		in, out := &in.LoadBalancing, &out.LoadBalancing
+		*out = new(LoadBalancing)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendSpec.
@@ -567,6 +626,18 @@ func (in *BackendSpec) DeepCopy() *BackendSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendStatus) DeepCopyInto(out *BackendStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedAPIs != nil {
+		in, out := &in.AttachedAPIs, &out.AttachedAPIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendStatus.
@@ -695,6 +766,36 @@ func (in *ClaimMapping) DeepCopy() *ClaimMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificateRef) DeepCopyInto(out *ClientCertificateRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificateRef.
+func (in *ClientCertificateRef) DeepCopy() *ClientCertificateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientSecretRef) DeepCopyInto(out *ClientSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientSecretRef.
+func (in *ClientSecretRef) DeepCopy() *ClientSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
 	*out = *in
@@ -771,7 +872,7 @@ func (in *GQLRouteFilter) DeepCopyInto(out *GQLRouteFilter) {
 	*out = *in
 	if in.ExtensionRef != nil {
 		in, out := &in.ExtensionRef, &out.ExtensionRef
-		*out = new(v1.LocalObjectReference)
+		*out = new(apisv1.LocalObjectReference)
 		**out = **in
 	}
 }
@@ -878,12 +979,12 @@ func (in *GQLRouteSpec) DeepCopyInto(out *GQLRouteSpec) {
 	in.CommonRouteSpec.DeepCopyInto(&out.CommonRouteSpec)
 	if in.Hostnames != nil {
 		in, out := &in.Hostnames, &out.Hostnames
-		*out = make([]v1.Hostname, len(*in))
+		*out = make([]apisv1.Hostname, len(*in))
 		copy(*out, *in)
 	}
 	if in.BackendRefs != nil {
 		in, out := &in.BackendRefs, &out.BackendRefs
-		*out = make([]v1.HTTPBackendRef, len(*in))
+		*out = make([]apisv1.HTTPBackendRef, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -922,6 +1023,62 @@ func (in *GQLRouteStatus) DeepCopy() *GQLRouteStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HMACAuth) DeepCopyInto(out *HMACAuth) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.Algorithms != nil {
+		in, out := &in.Algorithms, &out.Algorithms
+		*out = make([]HMACAlgorithm, len(*in))
+		copy(*out, *in)
+	}
+	if in.SignedHeaders != nil {
+		in, out := &in.SignedHeaders, &out.SignedHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMACAuth.
+func (in *HMACAuth) DeepCopy() *HMACAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(HMACAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HMACSecretRef) DeepCopyInto(out *HMACSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMACSecretRef.
+func (in *HMACSecretRef) DeepCopy() *HMACSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(HMACSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HashKey) DeepCopyInto(out *HashKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HashKey.
+func (in *HashKey) DeepCopy() *HashKey {
+	if in == nil {
+		return nil
+	}
+	out := new(HashKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
 	*out = *in
@@ -952,6 +1109,27 @@ func (in *InterceptorReference) DeepCopy() *InterceptorReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Introspection) DeepCopyInto(out *Introspection) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(CERTConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ClientSecretRef = in.ClientSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Introspection.
+func (in *Introspection) DeepCopy() *Introspection {
+	if in == nil {
+		return nil
+	}
+	out := new(Introspection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JWKS) DeepCopyInto(out *JWKS) {
 	*out = *in
@@ -997,6 +1175,26 @@ func (in *JWT) DeepCopy() *JWT {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancing) DeepCopyInto(out *LoadBalancing) {
+	*out = *in
+	if in.HashKeys != nil {
+		in, out := &in.HashKeys, &out.HashKeys
+		*out = make([]HashKey, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancing.
+func (in *LoadBalancing) DeepCopy() *LoadBalancing {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MutualSSL) DeepCopyInto(out *MutualSSL) {
 	*out = *in
@@ -1080,6 +1278,42 @@ func (in *OAuth2Auth) DeepCopy() *OAuth2Auth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2SecurityConfig) DeepCopyInto(out *OAuth2SecurityConfig) {
+	*out = *in
+	out.ClientSecretRef = in.ClientSecretRef
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2SecurityConfig.
+func (in *OAuth2SecurityConfig) DeepCopy() *OAuth2SecurityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2SecurityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutlierDetection) DeepCopyInto(out *OutlierDetection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetection.
+func (in *OutlierDetection) DeepCopy() *OutlierDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(OutlierDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
 	*out = *in
@@ -1160,6 +1394,21 @@ func (in *ResolvedAPIKeySecurityConfig) DeepCopy() *ResolvedAPIKeySecurityConfig
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedAWSSecurityConfig) DeepCopyInto(out *ResolvedAWSSecurityConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedAWSSecurityConfig.
+func (in *ResolvedAWSSecurityConfig) DeepCopy() *ResolvedAWSSecurityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedAWSSecurityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedBackend) DeepCopyInto(out *ResolvedBackend) {
 	*out = *in
@@ -1170,7 +1419,7 @@ func (in *ResolvedBackend) DeepCopyInto(out *ResolvedBackend) {
 		copy(*out, *in)
 	}
 	in.TLS.DeepCopyInto(&out.TLS)
-	out.Security = in.Security
+	in.Security.DeepCopyInto(&out.Security)
 	if in.CircuitBreaker != nil {
 		in, out := &in.CircuitBreaker, &out.CircuitBreaker
 		*out = new(CircuitBreaker)
@@ -1191,6 +1440,16 @@ func (in *ResolvedBackend) DeepCopyInto(out *ResolvedBackend) {
 		*out = new(HealthCheck)
 		**out = **in
 	}
+	if in.OutlierDetection != nil {
+		in, out := &in.OutlierDetection, &out.OutlierDetection
+		*out = new(OutlierDetection)
+		**out = **in
+	}
+	if in.LoadBalancing != nil {
+		in, out := &in.LoadBalancing, &out.LoadBalancing
+		*out = new(LoadBalancing)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedBackend.
@@ -1218,11 +1477,33 @@ func (in *ResolvedBasicSecurityConfig) DeepCopy() *ResolvedBasicSecurityConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedOAuth2SecurityConfig) DeepCopyInto(out *ResolvedOAuth2SecurityConfig) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedOAuth2SecurityConfig.
+func (in *ResolvedOAuth2SecurityConfig) DeepCopy() *ResolvedOAuth2SecurityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedOAuth2SecurityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedSecurityConfig) DeepCopyInto(out *ResolvedSecurityConfig) {
 	*out = *in
 	out.Basic = in.Basic
 	out.APIKey = in.APIKey
+	in.OAuth2.DeepCopyInto(&out.OAuth2)
+	out.AWS = in.AWS
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedSecurityConfig.
@@ -1303,6 +1584,16 @@ func (in *SecurityConfig) DeepCopyInto(out *SecurityConfig) {
 		*out = new(APIKeySecurityConfig)
 		**out = **in
 	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2SecurityConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSSecurityConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityConfig.
@@ -1378,6 +1669,11 @@ func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClientCertificateRef != nil {
+		in, out := &in.ClientCertificateRef, &out.ClientCertificateRef
+		*out = new(ClientCertificateRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
@@ -1411,7 +1707,7 @@ func (in *TokenIssuer) DeepCopyInto(out *TokenIssuer) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenIssuer.
@@ -1472,6 +1768,11 @@ func (in *TokenIssuerSpec) DeepCopyInto(out *TokenIssuerSpec) {
 		*out = new(SignatureValidation)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Introspection != nil {
+		in, out := &in.Introspection, &out.Introspection
+		*out = new(Introspection)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ClaimMappings != nil {
 		in, out := &in.ClaimMappings, &out.ClaimMappings
 		*out = new([]ClaimMapping)
@@ -1506,6 +1807,18 @@ func (in *TokenIssuerSpec) DeepCopy() *TokenIssuerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TokenIssuerStatus) DeepCopyInto(out *TokenIssuerStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedAPIs != nil {
+		in, out := &in.AttachedAPIs, &out.AttachedAPIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenIssuerStatus.
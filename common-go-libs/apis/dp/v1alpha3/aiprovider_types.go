@@ -34,6 +34,37 @@ type AIProviderSpec struct {
 	Organization       string          `json:"organization"`
 	Model              ValueDetails    `json:"model"`
 	RateLimitFields    RateLimitFields `json:"rateLimitFields"`
+
+	// Transformation converts the OpenAI chat completions requests of the clients to the format of the
+	// provider, and the responses of the provider back to the OpenAI format, so the provider of an API can be
+	// switched without changing the clients.
+	//
+	// +optional
+	Transformation *AIProviderTransformation `json:"transformation,omitempty"`
+}
+
+// AIProviderTransformation defines the conversion between the OpenAI chat completions format and the format of
+// the provider. The messages, system prompts, tool calls, usage fields and streaming chunks are converted.
+// The rate limit fields of the provider describe the responses of the provider before they are converted.
+type AIProviderTransformation struct {
+	// Format is the request and response format of the provider. Bedrock refers to the Converse API.
+	//
+	// +kubebuilder:validation:Enum=OpenAI;Anthropic;Bedrock
+	Format string `json:"format"`
+
+	// DefaultMaxTokens is set as the maximum tokens of the converted requests which do not specify it, as
+	// Anthropic requires it.
+	//
+	// +optional
+	// +kubebuilder:default=1024
+	// +kubebuilder:validation:Minimum=1
+	DefaultMaxTokens uint32 `json:"defaultMaxTokens,omitempty"`
+
+	// APIVersion is sent to the provider in its version header, such as the anthropic-version header.
+	// The latest version supported by the gateway is sent if it is not set.
+	//
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
 }
 
 // RateLimitFields defines the Rate Limit fields
@@ -51,8 +82,21 @@ type ValueDetails struct {
 
 // AIProviderStatus defines the observed state of AIProvider
 type AIProviderStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Conditions describe the current state of the resource as observed by
+	// the data plane. Known condition types are Accepted, ResolvedRefs,
+	// Attached and Conflicted.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// AttachedAPIs lists the APIs (namespace/name) this resource is
+	// currently applied to.
+	//
+	// +optional
+	AttachedAPIs []string `json:"attachedAPIs,omitempty"`
 }
 
 //+kubebuilder:object:root=true
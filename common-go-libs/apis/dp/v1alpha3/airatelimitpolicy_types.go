@@ -34,6 +34,48 @@ type AIRateLimit struct {
 	Organization string        `json:"organization,omitempty"`
 	TokenCount   *TokenCount   `json:"tokenCount,omitempty"`
 	RequestCount *RequestCount `json:"requestCount,omitempty"`
+
+	// TokenBudget is a hard token quota for a calendar period. It applies
+	// only to the policies targeting subscriptions.
+	//
+	// +optional
+	TokenBudget *TokenBudget `json:"tokenBudget,omitempty"`
+}
+
+// TokenBudget defines a hard quota on the total tokens consumed within a
+// calendar period. The consumed tokens are kept in the redis server of the
+// gateway, hence the budget is not reset when the gateway restarts. The budget
+// is reset at the beginning of each period, in UTC. While the redis server
+// cannot be reached, the budget is not enforced, unless the gateway is
+// configured to reject the requests in that case.
+type TokenBudget struct {
+	// Period is the calendar period of the budget.
+	//
+	// +kubebuilder:validation:Enum=Month;Quarter
+	// +kubebuilder:default=Month
+	// +optional
+	Period string `json:"period,omitempty"`
+
+	// Scope denotes whether the budget is shared by all the subscriptions of
+	// an application with this policy, or applies to each subscription.
+	//
+	// +kubebuilder:validation:Enum=Application;Subscription
+	// +kubebuilder:default=Application
+	// +optional
+	Scope string `json:"scope,omitempty"`
+
+	// TotalTokenCount is the maximum total tokens allowed within a period.
+	//
+	// +kubebuilder:validation:Minimum=1
+	TotalTokenCount uint64 `json:"totalTokenCount"`
+
+	// AlertThresholds are the percentages of the budget at which soft limit
+	// alerts are raised as the tokens are consumed.
+	//
+	// +kubebuilder:default={80,100}
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	AlertThresholds []uint32 `json:"alertThresholds,omitempty"`
 }
 
 // TokenCount defines the Token based ratelimit configuration
@@ -65,6 +65,673 @@ type PolicySpec struct {
 	// AIProvider referenced to AIProvider resource to be applied
 	// to the API.
 	AIProvider *AIProviderReference `json:"aiProvider,omitempty"`
+
+	// PayloadPolicy limits the size and the content type of the payloads
+	// of the API or the resource.
+	//
+	// +optional
+	PayloadPolicy *PayloadPolicy `json:"payloadPolicy,omitempty"`
+
+	// AIRouting routes the requests of an AI API among an ordered list of
+	// AI provider backends, selected by the requested model, and fails over
+	// to the next backend of the list on the configured conditions.
+	//
+	// +optional
+	AIRouting *AIRoutingPolicy `json:"aiRouting,omitempty"`
+
+	// AIGuardrails inspects the prompts sent to the AI provider and the
+	// responses returned by it, and rejects or redacts the unsafe content.
+	//
+	// +optional
+	AIGuardrails *AIGuardrailsPolicy `json:"aiGuardrails,omitempty"`
+
+	// AIResponseCache caches the responses of the AI provider and serves
+	// the repeated prompts from the cache.
+	//
+	// +optional
+	AIResponseCache *AIResponseCachePolicy `json:"aiResponseCache,omitempty"`
+
+	// Caching caches the responses of the GET requests of the API or the
+	// resource and serves the repeated requests from the cache.
+	//
+	// +optional
+	Caching *CachingPolicy `json:"caching,omitempty"`
+
+	// SchemaValidation validates the requests, and optionally the responses,
+	// of the API or the resource against the OpenAPI definition of the API.
+	//
+	// +optional
+	SchemaValidation *SchemaValidationPolicy `json:"schemaValidation,omitempty"`
+
+	// BodyTransformation transforms the payloads of the requests and the
+	// responses of the API or the resource in the gateway, without an
+	// interceptor service.
+	//
+	// +optional
+	BodyTransformation *BodyTransformationPolicy `json:"bodyTransformation,omitempty"`
+
+	// SOAPMediation exposes the operations of a SOAP backend as the REST
+	// resources of the API, by wrapping the JSON requests in SOAP envelopes
+	// and converting the SOAP responses and faults back to JSON. The mediated
+	// resources are expected to accept the POST method.
+	//
+	// +optional
+	SOAPMediation *SOAPMediationPolicy `json:"soapMediation,omitempty"`
+
+	// GraphQLProtection limits the depth, the complexity, the aliases and
+	// the batch size of the operations of a GraphQL API, and can disable the
+	// introspection of its schema. The operations exceeding a limit are
+	// rejected by the gateway before they reach the backend.
+	//
+	// +optional
+	GraphQLProtection *GraphQLProtectionPolicy `json:"graphQLProtection,omitempty"`
+
+	// GRPCTranscoding exposes the methods of a gRPC API as REST resources,
+	// by transcoding the HTTP/JSON requests to gRPC as given by the
+	// google.api.http annotations of the methods. The transcoded requests
+	// go through the same authentication and rate limits as the gRPC
+	// requests.
+	//
+	// +optional
+	GRPCTranscoding *GRPCTranscodingPolicy `json:"grpcTranscoding,omitempty"`
+
+	// GRPCWeb denotes whether the methods of a gRPC API accept gRPC-Web
+	// requests from browser clients. The gRPC-Web requests are translated
+	// to gRPC in the gateway, and the gRPC-Web headers are added to the
+	// CORS policy of the API.
+	//
+	// +optional
+	GRPCWeb bool `json:"grpcWeb,omitempty"`
+}
+
+// CachingPolicy holds the configurations of the response cache of a REST API.
+// The responses are keyed on the path and the query of the request, and the
+// configured request headers and consumer. The cached responses of an API
+// can be invalidated through the notify endpoint of the common controller.
+type CachingPolicy struct {
+	// Enabled denotes whether the response cache is enabled.
+	//
+	// +kubebuilder:default=true
+	// +optional
+	Enabled bool `json:"enabled"`
+
+	// TTLSeconds is the time a response is kept in the cache, unless the
+	// Cache-Control header of the response sets a shorter time.
+	//
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TTLSeconds uint32 `json:"ttlSeconds,omitempty"`
+
+	// VaryByHeaders lists the request headers whose values are added to the
+	// cache key.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	VaryByHeaders []string `json:"varyByHeaders,omitempty"`
+
+	// VaryByQueryParams lists the query parameters added to the cache key.
+	// The whole query string is added to the cache key when it is empty.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	VaryByQueryParams []string `json:"varyByQueryParams,omitempty"`
+
+	// VaryByConsumer denotes whether the responses of unsecured operations are
+	// cached separately for each consumer. The responses of secured operations
+	// are always cached separately for each end user, or for each application
+	// when the token is not issued to a user. Requests of unsecured operations
+	// with an Authorization header are not cached unless this is enabled.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	VaryByConsumer bool `json:"varyByConsumer,omitempty"`
+
+	// RespectCacheControl denotes whether the Cache-Control headers of the
+	// requests and the responses are honored. The no-cache and no-store
+	// directives of a request skip the cache, and the no-store and private
+	// directives of a response prevent it from being cached. The max-age and
+	// s-maxage directives of a response shorten its time in the cache.
+	//
+	// +kubebuilder:default=true
+	// +optional
+	RespectCacheControl bool `json:"respectCacheControl"`
+
+	// Store is where the responses are cached. InMemory keeps the responses
+	// in each gateway replica and Redis shares them among the replicas
+	// through the redis server of the gateway.
+	//
+	// +kubebuilder:validation:Enum=InMemory;Redis
+	// +kubebuilder:default=InMemory
+	// +optional
+	Store string `json:"store,omitempty"`
+
+	// MaxEntrySize is the maximum size of a cached response in bytes. Larger
+	// responses are not cached.
+	//
+	// +kubebuilder:default=1048576
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxEntrySize uint32 `json:"maxEntrySize,omitempty"`
+}
+
+// SchemaValidationPolicy holds the configurations of the validation of the
+// traffic of a REST API against the OpenAPI 3 definition referred by the
+// API. The API is not deployed if it does not refer to an OpenAPI 3
+// definition. The bodies, the path and query parameters and the headers of
+// the requests are validated, and the invalid requests are rejected with a
+// 400 response listing the JSON pointers of the failing values. The requests
+// which do not match an operation of the definition are rejected as invalid,
+// and the requests whose bodies are larger than the payload buffer of the
+// router are rejected with a 413 response instead of being validated
+// partially.
+type SchemaValidationPolicy struct {
+	// Enabled denotes whether the schema validation is enabled.
+	//
+	// +kubebuilder:default=true
+	// +optional
+	Enabled bool `json:"enabled"`
+
+	// ValidateResponses denotes whether the responses of the backend are
+	// validated as well. The invalid responses are replaced with a 502
+	// response.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	ValidateResponses bool `json:"validateResponses,omitempty"`
+}
+
+// BodyTransformationPolicy holds the transformations of the payloads of the
+// requests and the responses of a REST API, which are applied by the
+// enforcer.
+type BodyTransformationPolicy struct {
+	// Request is the transformation of the payloads of the requests, applied
+	// before the requests are sent to the backend.
+	//
+	// +optional
+	Request *BodyTransformation `json:"request,omitempty"`
+
+	// Response is the transformation of the payloads of the successful
+	// responses of the backend, applied before the responses are sent to the
+	// client.
+	//
+	// +optional
+	Response *BodyTransformation `json:"response,omitempty"`
+}
+
+// BodyTransformation holds the steps of the transformation of a payload. The
+// steps are applied in the order of, the conversion from XML, the removal,
+// the renaming and the addition of the fields, the template and the
+// conversion to XML. The field steps and the template work on JSON payloads.
+type BodyTransformation struct {
+	// Conversion converts the payload between JSON and XML. An XMLToJSON
+	// conversion is applied before the other steps and a JSONToXML
+	// conversion after them.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=JSONToXML;XMLToJSON
+	Conversion string `json:"conversion,omitempty"`
+
+	// XMLRootElement is the name of the root element of the XML payloads
+	// converted from JSON.
+	//
+	// +kubebuilder:default=root
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_.-]*$`
+	XMLRootElement string `json:"xmlRootElement,omitempty"`
+
+	// AddFields sets the given values at the given JSONPaths of the
+	// payload, creating the missing objects on the way.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	AddFields []BodyField `json:"addFields,omitempty"`
+
+	// RemoveFields removes the fields at the given JSONPaths of the payload.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	RemoveFields []string `json:"removeFields,omitempty"`
+
+	// RenameFields renames the fields at the given JSONPaths of the payload.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	RenameFields []BodyFieldRename `json:"renameFields,omitempty"`
+
+	// Template is a Go template which rewrites the payload. The template is
+	// executed on the JSON payload, and supports the field and the dot
+	// actions, the if, with and range actions and the json function.
+	//
+	// +optional
+	Template string `json:"template,omitempty"`
+
+	// ContentType is the content type of the transformed payload. It
+	// defaults to the content type of the converted format, if any.
+	//
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// BodyField holds a value to be set at a JSONPath of a payload.
+type BodyField struct {
+	// Path is the JSONPath of the field, such as $.order.id or
+	// $.items[*].currency.
+	//
+	// +kubebuilder:validation:MinLength=2
+	Path string `json:"path"`
+
+	// Value is the JSON value of the field. Values which are not valid JSON
+	// are set as strings.
+	Value string `json:"value"`
+}
+
+// BodyFieldRename holds a field of a payload to be renamed.
+type BodyFieldRename struct {
+	// From is the JSONPath of the field to be renamed.
+	//
+	// +kubebuilder:validation:MinLength=2
+	From string `json:"from"`
+
+	// To is the new name of the field, which stays in the same object.
+	//
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
+}
+
+// GraphQLProtectionPolicy holds the limits of the operations of a GraphQL
+// API. The limits which are not set are not enforced, and the rejected
+// operations are answered with a 400 response carrying a GraphQL errors
+// object.
+type GraphQLProtectionPolicy struct {
+	// MaxDepth is the maximum depth of the selection sets of an operation.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxDepth *uint32 `json:"maxDepth,omitempty"`
+
+	// MaxComplexity is the maximum complexity of an operation, which is the
+	// sum of the costs of the selected fields. A field costs 1, unless a cost
+	// is set for it in FieldCosts or with the @cost(weight: n) directive in
+	// the schema of the API, and the cost of a list field is multiplied by
+	// its first, last or limit argument.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxComplexity *uint32 `json:"maxComplexity,omitempty"`
+
+	// FieldCosts overrides the costs of the fields of the schema of the
+	// API in the complexity of the operations.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=256
+	FieldCosts []GraphQLFieldCost `json:"fieldCosts,omitempty"`
+
+	// MaxAliases is the maximum number of aliased fields in an operation.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxAliases *uint32 `json:"maxAliases,omitempty"`
+
+	// MaxBatchSize is the maximum number of operations in a batched request,
+	// which carries a JSON array of operations. Batched requests are
+	// rejected when it is not set.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxBatchSize *uint32 `json:"maxBatchSize,omitempty"`
+
+	// DisableIntrospection rejects the operations selecting the __schema or
+	// the __type fields.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	DisableIntrospection bool `json:"disableIntrospection,omitempty"`
+}
+
+// GraphQLFieldCost holds the cost of a field of the schema of a GraphQL API.
+type GraphQLFieldCost struct {
+	// Type is the name of the type declaring the field, such as Query.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+
+	// Field is the name of the field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Field string `json:"field"`
+
+	// Cost is the cost of the field.
+	//
+	// +kubebuilder:validation:Minimum=0
+	Cost uint32 `json:"cost"`
+}
+
+// SOAPMediationPolicy holds the mapping of the resources of a REST API to the
+// operations of the SOAP backend described by a WSDL. The JSON payload of a
+// request is placed in the SOAP body, either through the envelope template
+// of the operation or by converting it to XML under the input element of the
+// operation. The SOAP body of the response is converted back to JSON, and a
+// SOAP fault is returned as a JSON error with a 400 status code for client
+// faults and a 500 status code for the others. The envelopes are sent as the
+// payloads of the requests, hence the mediated resources are expected to
+// accept the POST method, and to be rewritten to the SOAP endpoint of the
+// backend with a URLRewrite filter where the paths differ.
+type SOAPMediationPolicy struct {
+	// WSDLRef is the name of the ConfigMap holding the WSDL of the SOAP
+	// backend, in the namespace of the policy.
+	//
+	// +kubebuilder:validation:MinLength=1
+	WSDLRef string `json:"wsdlRef"`
+
+	// SOAPVersion is the SOAP version of the envelopes sent to the backend.
+	// It defaults to the version of the SOAP binding of the operation in the
+	// WSDL.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=SOAP11;SOAP12
+	SOAPVersion string `json:"soapVersion,omitempty"`
+
+	// Operations maps the resources of the API to the operations of the
+	// WSDL.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	Operations []SOAPOperationMapping `json:"operations"`
+}
+
+// SOAPOperationMapping maps a resource of a REST API to an operation of a
+// SOAP backend.
+type SOAPOperationMapping struct {
+	// Path is the path of the resource, as given in the HTTPRoute match. A
+	// mapping without a path applies to the resources which have no mapping
+	// of their own.
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Operation is the name of the operation in the WSDL.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Operation string `json:"operation"`
+
+	// SOAPAction overrides the SOAP action of the operation given in the
+	// WSDL.
+	//
+	// +optional
+	SOAPAction string `json:"soapAction,omitempty"`
+
+	// EnvelopeTemplate is a Go template which renders the content of the
+	// SOAP body from the JSON payload of the request. It supports the same
+	// actions as the template of a body transformation. The payload is
+	// converted to XML under the input element of the operation when it is
+	// empty.
+	//
+	// +optional
+	EnvelopeTemplate string `json:"envelopeTemplate,omitempty"`
+}
+
+// GRPCTranscodingPolicy holds the configurations of the HTTP/JSON to gRPC
+// transcoding of a gRPC API. The REST resources are taken from the
+// google.api.http annotations of the methods exposed by the GRPCRoutes of
+// the API, and are served on the hostnames of the routes at the paths given
+// in the annotations, prefixed with the base path of the API, as in
+// /org.apk.v1/v1/shelves for the base path /org.apk.v1.
+type GRPCTranscodingPolicy struct {
+	// DescriptorSetRef is the name of the ConfigMap holding the protobuf
+	// descriptor set of the services of the API, in the namespace of the
+	// policy. The descriptor set is generated with the --include_imports and
+	// --descriptor_set_out options of protoc, and can be gzipped.
+	//
+	// +kubebuilder:validation:MinLength=1
+	DescriptorSetRef string `json:"descriptorSetRef"`
+
+	// PreserveProtoFieldNames keeps the field names of the proto messages
+	// in the JSON responses, instead of converting them to lower camel case.
+	//
+	// +optional
+	PreserveProtoFieldNames bool `json:"preserveProtoFieldNames,omitempty"`
+
+	// IgnoreUnknownQueryParameters accepts the requests with query
+	// parameters which do not map to the fields of the request message.
+	// These requests are rejected by default.
+	//
+	// +optional
+	IgnoreUnknownQueryParameters bool `json:"ignoreUnknownQueryParameters,omitempty"`
+}
+
+// AIResponseCachePolicy holds the configurations of the AI response cache. The
+// responses are cached per organization in the redis server of the gateway,
+// keyed on the model, the messages and the temperature of the request.
+type AIResponseCachePolicy struct {
+	// Enabled denotes whether the AI response cache is enabled.
+	//
+	// +kubebuilder:default=true
+	// +optional
+	Enabled bool `json:"enabled"`
+
+	// TTLSeconds is the time a response is kept in the cache.
+	//
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TTLSeconds uint32 `json:"ttlSeconds,omitempty"`
+
+	// MaxEntrySize is the maximum size of a cached response in bytes. Larger
+	// responses are not cached.
+	//
+	// +kubebuilder:default=1048576
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxEntrySize uint32 `json:"maxEntrySize,omitempty"`
+
+	// BypassHeader is the name of the request header which skips the cache
+	// when present in a request.
+	//
+	// +kubebuilder:default="x-wso2-cache-bypass"
+	// +optional
+	BypassHeader string `json:"bypassHeader,omitempty"`
+}
+
+// AIGuardrailsPolicy holds the content safety checks of an AI API. The deny
+// lists and the PII detection apply to both the prompts and the responses.
+// Violating requests are rejected with 400 Bad Request and violating
+// responses are replaced with a 502 Bad Gateway error.
+type AIGuardrailsPolicy struct {
+	// DenyPatterns lists regular expressions which must not match the
+	// payload.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	DenyPatterns []string `json:"denyPatterns,omitempty"`
+
+	// DenyKeywords lists words which must not appear in the payload. The
+	// keywords are matched as whole words, ignoring the case.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	DenyKeywords []string `json:"denyKeywords,omitempty"`
+
+	// PIIDetection detects personally identifiable information in the
+	// payload.
+	//
+	// +optional
+	PIIDetection *AIPIIDetection `json:"piiDetection,omitempty"`
+
+	// MaxPromptLength is the maximum number of characters of the prompt,
+	// counted over the message contents of the request.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxPromptLength *uint32 `json:"maxPromptLength,omitempty"`
+
+	// ResponseSchema validates the model output against a JSON schema.
+	//
+	// +optional
+	ResponseSchema *AIResponseSchema `json:"responseSchema,omitempty"`
+}
+
+// AIPIIDetection holds the PII entities to detect and the action to take on them
+type AIPIIDetection struct {
+	// Entities lists the kinds of PII to detect.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=3
+	Entities []AIPIIEntity `json:"entities"`
+
+	// Action is the action taken when PII is detected. Redact replaces the
+	// detected values with a placeholder and Reject fails the request.
+	//
+	// +optional
+	// +kubebuilder:default=Redact
+	Action AIGuardrailAction `json:"action,omitempty"`
+}
+
+// AIPIIEntity is a kind of personally identifiable information
+// +kubebuilder:validation:Enum=Email;CardNumber;NationalID
+type AIPIIEntity string
+
+const (
+	// AIPIIEmail detects email addresses
+	AIPIIEmail AIPIIEntity = "Email"
+	// AIPIICardNumber detects payment card numbers passing the Luhn check
+	AIPIICardNumber AIPIIEntity = "CardNumber"
+	// AIPIINationalID detects national identification numbers in the NNN-NN-NNNN format
+	AIPIINationalID AIPIIEntity = "NationalID"
+)
+
+// AIGuardrailAction is the action taken on the content detected by a guardrail
+// +kubebuilder:validation:Enum=Redact;Reject
+type AIGuardrailAction string
+
+const (
+	// AIGuardrailRedact replaces the detected content with a placeholder
+	AIGuardrailRedact AIGuardrailAction = "Redact"
+	// AIGuardrailReject rejects the payload containing the detected content
+	AIGuardrailReject AIGuardrailAction = "Reject"
+)
+
+// AIResponseSchema holds the JSON schema which the model output must conform to
+type AIResponseSchema struct {
+	// Schema is the JSON schema (draft 4) document. References to external
+	// schemas are not supported.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Schema string `json:"schema"`
+
+	// ContentPath is the JSON path of the model output in the response
+	// body. The output is parsed as JSON when it is a string.
+	//
+	// +optional
+	// +kubebuilder:default="$.choices[0].message.content"
+	ContentPath string `json:"contentPath,omitempty"`
+}
+
+// AIRoutingPolicy holds the AI provider backends of an API in the order of preference
+type AIRoutingPolicy struct {
+	// Targets lists the provider and backend pairs in the order in which
+	// they are tried. A request is sent to the first target serving the
+	// requested model and falls back to the next ones on failure.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	Targets []AIRoutingTarget `json:"targets"`
+
+	// FailoverOn lists the conditions on which a request is retried on the
+	// next target. All the conditions are used when not specified.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=4
+	FailoverOn []AIFailoverCondition `json:"failoverOn,omitempty"`
+
+	// PerTryTimeoutMillis is the time allowed for a single target to respond
+	// before the request is retried on the next target.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PerTryTimeoutMillis *uint32 `json:"perTryTimeoutMillis,omitempty"`
+}
+
+// AIRoutingTarget holds an AI provider and the backend serving it
+type AIRoutingTarget struct {
+	// Provider refers to the AIProvider resource describing the target. The
+	// model of the requests sent to the target is set in the model location
+	// of the provider. The AIProvider of the API is used if it is not set.
+	//
+	// +optional
+	Provider *AIProviderReference `json:"provider,omitempty"`
+
+	// BackendRef refers to the Backend resource of the target in the
+	// namespace of the API.
+	BackendRef AIRoutingBackendReference `json:"backendRef"`
+
+	// Models lists the model names and aliases served by the target. A
+	// target without models serves any model and is used as a fallback
+	// for all the requests.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	Models []string `json:"models,omitempty"`
+
+	// Model is the model name sent to the target in place of the requested
+	// model. It is set in the model location of the AIProvider of the
+	// target. The requested model is sent unchanged if it is not set.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	Model string `json:"model,omitempty"`
+}
+
+// AIRoutingBackendReference holds the reference to a Backend resource
+type AIRoutingBackendReference struct {
+	// Name is the referred Backend resource's name.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// AIFailoverCondition is a condition on which an AI request fails over to the next target
+// +kubebuilder:validation:Enum=ServerError;RateLimited;Timeout;ConnectFailure
+type AIFailoverCondition string
+
+const (
+	// AIFailoverOnServerError fails over when the target responds with a 5xx status code
+	AIFailoverOnServerError AIFailoverCondition = "ServerError"
+	// AIFailoverOnRateLimited fails over when the target responds with a 429 status code
+	AIFailoverOnRateLimited AIFailoverCondition = "RateLimited"
+	// AIFailoverOnTimeout fails over when the target does not respond in time
+	AIFailoverOnTimeout AIFailoverCondition = "Timeout"
+	// AIFailoverOnConnectFailure fails over when the connection to the target fails
+	AIFailoverOnConnectFailure AIFailoverCondition = "ConnectFailure"
+)
+
+// PayloadPolicy holds the payload size limits and the allowed content types
+type PayloadPolicy struct {
+	// MaxRequestBodySize is the maximum size of the request body in bytes.
+	// Larger requests are rejected with 413 Payload Too Large.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxRequestBodySize *uint32 `json:"maxRequestBodySize,omitempty"`
+
+	// MaxResponseBodySize is the maximum size of the response body in bytes.
+	// Larger responses are replaced with a 502 Bad Gateway error. Responses
+	// without a Content-Length header, such as chunked or streamed responses,
+	// are buffered to measure their size, hence they are not streamed to the
+	// client.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxResponseBodySize *uint32 `json:"maxResponseBodySize,omitempty"`
+
+	// AllowedContentTypes lists the media types accepted in the Content-Type
+	// header of requests with a body. Requests with other content types are
+	// rejected with 415 Unsupported Media Type. Parameters such as charset
+	// are ignored when matching.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	AllowedContentTypes []string `json:"allowedContentTypes,omitempty"`
 }
 
 // BackendJWTToken holds backend JWT token information
@@ -133,8 +800,21 @@ type AIProviderReference struct {
 
 // APIPolicyStatus defines the observed state of APIPolicy
 type APIPolicyStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Conditions describe the current state of the resource as observed by
+	// the data plane. Known condition types are Accepted, ResolvedRefs,
+	// Attached and Conflicted.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// AttachedAPIs lists the APIs (namespace/name) this resource is
+	// currently applied to.
+	//
+	// +optional
+	AttachedAPIs []string `json:"attachedAPIs,omitempty"`
 }
 
 // +genclient
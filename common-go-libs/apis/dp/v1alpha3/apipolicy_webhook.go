@@ -18,6 +18,11 @@
 package v1alpha3
 
 import (
+	"fmt"
+	"regexp"
+	"text/template"
+	"text/template/parse"
+
 	constants "github.com/wso2/apk/common-go-libs/constants"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -76,6 +81,30 @@ func (r *APIPolicy) ValidatePolicy() error {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("targetRef").Child("namespace"), r.Spec.TargetRef.Namespace,
 			"namespace cross reference is not allowed"))
 	}
+	if r.Spec.Default != nil && r.Spec.Default.BodyTransformation != nil {
+		allErrs = append(allErrs, validateBodyTransformationPolicy(r.Spec.Default.BodyTransformation,
+			field.NewPath("spec").Child("default").Child("bodyTransformation"))...)
+	}
+	if r.Spec.Override != nil && r.Spec.Override.BodyTransformation != nil {
+		allErrs = append(allErrs, validateBodyTransformationPolicy(r.Spec.Override.BodyTransformation,
+			field.NewPath("spec").Child("override").Child("bodyTransformation"))...)
+	}
+	if r.Spec.Default != nil && r.Spec.Default.SOAPMediation != nil {
+		allErrs = append(allErrs, validateSOAPMediationPolicy(r.Spec.Default.SOAPMediation,
+			field.NewPath("spec").Child("default").Child("soapMediation"))...)
+	}
+	if r.Spec.Override != nil && r.Spec.Override.SOAPMediation != nil {
+		allErrs = append(allErrs, validateSOAPMediationPolicy(r.Spec.Override.SOAPMediation,
+			field.NewPath("spec").Child("override").Child("soapMediation"))...)
+	}
+	if r.Spec.Default != nil && r.Spec.Default.GraphQLProtection != nil {
+		allErrs = append(allErrs, validateGraphQLProtectionPolicy(r.Spec.Default.GraphQLProtection,
+			field.NewPath("spec").Child("default").Child("graphQLProtection"))...)
+	}
+	if r.Spec.Override != nil && r.Spec.Override.GraphQLProtection != nil {
+		allErrs = append(allErrs, validateGraphQLProtectionPolicy(r.Spec.Override.GraphQLProtection,
+			field.NewPath("spec").Child("override").Child("graphQLProtection"))...)
+	}
 	if len(allErrs) > 0 {
 		return apierrors.NewInvalid(
 			schema.GroupKind{Group: "dp.wso2.com", Kind: "APIPolicy"},
@@ -84,6 +113,156 @@ func (r *APIPolicy) ValidatePolicy() error {
 	return nil
 }
 
+// bodyFieldPathRegex matches the JSONPaths supported by the body transformation, which are made of the child
+// fields, the array indices and the wildcards of the arrays.
+var bodyFieldPathRegex = regexp.MustCompile(`^\$(\.[A-Za-z_][A-Za-z0-9_-]*|\['[^']+'\]|\[[0-9]+\]|\[\*\])+$`)
+
+// validateBodyTransformationPolicy validates the JSONPaths and the templates of the body transformation policy
+func validateBodyTransformationPolicy(policy *BodyTransformationPolicy, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, flow := range []string{"request", "response"} {
+		transformation := policy.Request
+		if flow == "response" {
+			transformation = policy.Response
+		}
+		if transformation == nil {
+			continue
+		}
+		transformationPath := path.Child(flow)
+		for i, addField := range transformation.AddFields {
+			if !bodyFieldPathRegex.MatchString(addField.Path) {
+				allErrs = append(allErrs, field.Invalid(transformationPath.Child("addFields").Index(i).Child("path"),
+					addField.Path, "invalid JSONPath"))
+			}
+		}
+		for i, removeField := range transformation.RemoveFields {
+			if !bodyFieldPathRegex.MatchString(removeField) {
+				allErrs = append(allErrs, field.Invalid(transformationPath.Child("removeFields").Index(i),
+					removeField, "invalid JSONPath"))
+			}
+		}
+		for i, renameField := range transformation.RenameFields {
+			if !bodyFieldPathRegex.MatchString(renameField.From) {
+				allErrs = append(allErrs, field.Invalid(transformationPath.Child("renameFields").Index(i).Child("from"),
+					renameField.From, "invalid JSONPath"))
+			}
+		}
+		if transformation.Template != "" {
+			if err := validateBodyTemplate(transformation.Template); err != nil {
+				allErrs = append(allErrs, field.Invalid(transformationPath.Child("template"), transformation.Template,
+					err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateSOAPMediationPolicy validates the paths and the envelope templates of the operation mappings of the SOAP
+// mediation policy
+func validateSOAPMediationPolicy(policy *SOAPMediationPolicy, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	paths := make(map[string]bool)
+	for i, operation := range policy.Operations {
+		operationPath := path.Child("operations").Index(i)
+		if paths[operation.Path] {
+			allErrs = append(allErrs, field.Duplicate(operationPath.Child("path"), operation.Path))
+		}
+		paths[operation.Path] = true
+		if operation.EnvelopeTemplate != "" {
+			if err := validateBodyTemplate(operation.EnvelopeTemplate); err != nil {
+				allErrs = append(allErrs, field.Invalid(operationPath.Child("envelopeTemplate"),
+					operation.EnvelopeTemplate, err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateGraphQLProtectionPolicy makes sure the GraphQL protection policy sets a single cost for a field
+func validateGraphQLProtectionPolicy(policy *GraphQLProtectionPolicy, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	fields := make(map[string]bool)
+	for i, fieldCost := range policy.FieldCosts {
+		key := fieldCost.Type + "." + fieldCost.Field
+		if fields[key] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("fieldCosts").Index(i), key))
+		}
+		fields[key] = true
+	}
+	return allErrs
+}
+
+// validateBodyTemplate parses the Go template of a body transformation and makes sure it only uses the actions
+// supported by the enforcer.
+func validateBodyTemplate(text string) error {
+	tmpl, err := template.New("body").Funcs(template.FuncMap{"json": func(interface{}) string { return "" }}).Parse(text)
+	if err != nil {
+		return err
+	}
+	return validateBodyTemplateNode(tmpl.Tree.Root)
+}
+
+func validateBodyTemplateNode(node parse.Node) error {
+	switch n := node.(type) {
+	case nil, *parse.TextNode, *parse.CommentNode:
+		return nil
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := validateBodyTemplateNode(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *parse.ActionNode:
+		return validateBodyTemplatePipe(n.Pipe)
+	case *parse.IfNode:
+		return validateBodyTemplateBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return validateBodyTemplateBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		return validateBodyTemplateBranch(&n.BranchNode)
+	default:
+		return fmt.Errorf("unsupported template action %s", node)
+	}
+}
+
+func validateBodyTemplateBranch(branch *parse.BranchNode) error {
+	if err := validateBodyTemplatePipe(branch.Pipe); err != nil {
+		return err
+	}
+	if err := validateBodyTemplateNode(branch.List); err != nil {
+		return err
+	}
+	return validateBodyTemplateNode(branch.ElseList)
+}
+
+func validateBodyTemplatePipe(pipe *parse.PipeNode) error {
+	if len(pipe.Decl) > 0 || len(pipe.Cmds) != 1 {
+		return fmt.Errorf("unsupported template pipeline %s", pipe)
+	}
+	args := pipe.Cmds[0].Args
+	if len(args) == 2 {
+		if identifier, ok := args[0].(*parse.IdentifierNode); ok && identifier.Ident == "json" {
+			args = args[1:]
+		}
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("unsupported template pipeline %s", pipe)
+	}
+	switch arg := args[0].(type) {
+	case *parse.FieldNode, *parse.DotNode:
+		return nil
+	case *parse.VariableNode:
+		if arg.Ident[0] == "$" {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported template pipeline %s", pipe)
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *APIPolicy) ValidateDelete() (admission.Warnings, error) {
 	// TODO(user): fill in your validation logic upon object deletion.
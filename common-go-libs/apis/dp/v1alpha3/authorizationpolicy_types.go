@@ -0,0 +1,119 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// AuthorizationPolicySpec defines the desired state of AuthorizationPolicy
+type AuthorizationPolicySpec struct {
+	Default   *AuthorizationRules                       `json:"default,omitempty"`
+	Override  *AuthorizationRules                       `json:"override,omitempty"`
+	TargetRef gwapiv1b1.NamespacedPolicyTargetReference `json:"targetRef,omitempty"`
+}
+
+// AuthorizationRules holds the authorization rules of an API or a resource
+type AuthorizationRules struct {
+	// Rules lists the authorization rules. A request is allowed only if all
+	// the rules evaluate to true.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Rules []AuthorizationRule `json:"rules,omitempty"`
+}
+
+// AuthorizationRule defines an expression evaluated by the enforcer after the
+// request is authenticated. The expression can refer to the claims of the
+// token, the request headers, the path parameters, the path and the method of
+// the request as claims, headers, pathParams, path and method.
+type AuthorizationRule struct {
+	// Name is the name of the rule
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Language is the language of the expression
+	//
+	// +kubebuilder:validation:Enum=APKExpression
+	// +kubebuilder:default=APKExpression
+	// +optional
+	Language string `json:"language,omitempty"`
+
+	// Expression is the expression of the rule in the APK expression language,
+	// which uses the syntax of CEL but only supports a subset of it. The
+	// expression should evaluate to true to allow the request, and may use the
+	// has, size, int and string functions and the startsWith, endsWith,
+	// contains, matches, size, lowerAscii, upperAscii, exists and all methods.
+	// The regular expressions of matches use the RE2 syntax. An expression
+	// which fails to evaluate, such as one referring to a missing claim,
+	// denies the request.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Expression string `json:"expression"`
+
+	// Message is the message returned to the client when the rule denies the request
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AuthorizationPolicyStatus defines the observed state of AuthorizationPolicy
+type AuthorizationPolicyStatus struct {
+	// Conditions describe the current state of the resource as observed by
+	// the data plane. Known condition types are Accepted, ResolvedRefs,
+	// Attached and Conflicted.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// AttachedAPIs lists the APIs (namespace/name) this resource is
+	// currently applied to.
+	//
+	// +optional
+	AttachedAPIs []string `json:"attachedAPIs,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// AuthorizationPolicy is the Schema for the authorizationpolicies API
+type AuthorizationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AuthorizationPolicySpec   `json:"spec,omitempty"`
+	Status AuthorizationPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AuthorizationPolicyList contains a list of AuthorizationPolicy
+type AuthorizationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AuthorizationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AuthorizationPolicy{}, &AuthorizationPolicyList{})
+}
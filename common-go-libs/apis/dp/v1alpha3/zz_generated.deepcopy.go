@@ -23,16 +23,77 @@
 package v1alpha3
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIGuardrailsPolicy) DeepCopyInto(out *AIGuardrailsPolicy) {
+	*out = *in
+	if in.DenyPatterns != nil {
+		in, out := &in.DenyPatterns, &out.DenyPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DenyKeywords != nil {
+		in, out := &in.DenyKeywords, &out.DenyKeywords
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PIIDetection != nil {
+		in, out := &in.PIIDetection, &out.PIIDetection
+		*out = new(AIPIIDetection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxPromptLength != nil {
+		in, out := &in.MaxPromptLength, &out.MaxPromptLength
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.ResponseSchema != nil {
+		in, out := &in.ResponseSchema, &out.ResponseSchema
+		*out = new(AIResponseSchema)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIGuardrailsPolicy.
+func (in *AIGuardrailsPolicy) DeepCopy() *AIGuardrailsPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AIGuardrailsPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIPIIDetection) DeepCopyInto(out *AIPIIDetection) {
+	*out = *in
+	if in.Entities != nil {
+		in, out := &in.Entities, &out.Entities
+		*out = make([]AIPIIEntity, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIPIIDetection.
+func (in *AIPIIDetection) DeepCopy() *AIPIIDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(AIPIIDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AIProvider) DeepCopyInto(out *AIProvider) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIProvider.
@@ -105,6 +166,11 @@ func (in *AIProviderSpec) DeepCopyInto(out *AIProviderSpec) {
 	*out = *in
 	out.Model = in.Model
 	out.RateLimitFields = in.RateLimitFields
+	if in.Transformation != nil {
+		in, out := &in.Transformation, &out.Transformation
+		*out = new(AIProviderTransformation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIProviderSpec.
@@ -120,6 +186,18 @@ func (in *AIProviderSpec) DeepCopy() *AIProviderSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AIProviderStatus) DeepCopyInto(out *AIProviderStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedAPIs != nil {
+		in, out := &in.AttachedAPIs, &out.AttachedAPIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIProviderStatus.
@@ -132,6 +210,21 @@ func (in *AIProviderStatus) DeepCopy() *AIProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIProviderTransformation) DeepCopyInto(out *AIProviderTransformation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIProviderTransformation.
+func (in *AIProviderTransformation) DeepCopy() *AIProviderTransformation {
+	if in == nil {
+		return nil
+	}
+	out := new(AIProviderTransformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AIRateLimit) DeepCopyInto(out *AIRateLimit) {
 	*out = *in
@@ -145,6 +238,11 @@ func (in *AIRateLimit) DeepCopyInto(out *AIRateLimit) {
 		*out = new(RequestCount)
 		**out = **in
 	}
+	if in.TokenBudget != nil {
+		in, out := &in.TokenBudget, &out.TokenBudget
+		*out = new(TokenBudget)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIRateLimit.
@@ -257,6 +355,109 @@ func (in *AIRateLimitPolicyStatus) DeepCopy() *AIRateLimitPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIResponseCachePolicy) DeepCopyInto(out *AIResponseCachePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIResponseCachePolicy.
+func (in *AIResponseCachePolicy) DeepCopy() *AIResponseCachePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AIResponseCachePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIResponseSchema) DeepCopyInto(out *AIResponseSchema) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIResponseSchema.
+func (in *AIResponseSchema) DeepCopy() *AIResponseSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(AIResponseSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIRoutingBackendReference) DeepCopyInto(out *AIRoutingBackendReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIRoutingBackendReference.
+func (in *AIRoutingBackendReference) DeepCopy() *AIRoutingBackendReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AIRoutingBackendReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIRoutingPolicy) DeepCopyInto(out *AIRoutingPolicy) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]AIRoutingTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailoverOn != nil {
+		in, out := &in.FailoverOn, &out.FailoverOn
+		*out = make([]AIFailoverCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.PerTryTimeoutMillis != nil {
+		in, out := &in.PerTryTimeoutMillis, &out.PerTryTimeoutMillis
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIRoutingPolicy.
+func (in *AIRoutingPolicy) DeepCopy() *AIRoutingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AIRoutingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AIRoutingTarget) DeepCopyInto(out *AIRoutingTarget) {
+	*out = *in
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(AIProviderReference)
+		**out = **in
+	}
+	out.BackendRef = in.BackendRef
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AIRoutingTarget.
+func (in *AIRoutingTarget) DeepCopy() *AIRoutingTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(AIRoutingTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *API) DeepCopyInto(out *API) {
 	*out = *in
@@ -322,7 +523,7 @@ func (in *APIPolicy) DeepCopyInto(out *APIPolicy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIPolicy.
@@ -404,6 +605,18 @@ func (in *APIPolicySpec) DeepCopy() *APIPolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIPolicyStatus) DeepCopyInto(out *APIPolicyStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedAPIs != nil {
+		in, out := &in.AttachedAPIs, &out.AttachedAPIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIPolicyStatus.
@@ -481,6 +694,153 @@ func (in *APIStatus) DeepCopy() *APIStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationPolicy) DeepCopyInto(out *AuthorizationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationPolicy.
+func (in *AuthorizationPolicy) DeepCopy() *AuthorizationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AuthorizationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationPolicyList) DeepCopyInto(out *AuthorizationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AuthorizationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationPolicyList.
+func (in *AuthorizationPolicyList) DeepCopy() *AuthorizationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AuthorizationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationPolicySpec) DeepCopyInto(out *AuthorizationPolicySpec) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(AuthorizationRules)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Override != nil {
+		in, out := &in.Override, &out.Override
+		*out = new(AuthorizationRules)
+		(*in).DeepCopyInto(*out)
+	}
+	in.TargetRef.DeepCopyInto(&out.TargetRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationPolicySpec.
+func (in *AuthorizationPolicySpec) DeepCopy() *AuthorizationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationPolicyStatus) DeepCopyInto(out *AuthorizationPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedAPIs != nil {
+		in, out := &in.AttachedAPIs, &out.AttachedAPIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationPolicyStatus.
+func (in *AuthorizationPolicyStatus) DeepCopy() *AuthorizationPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationRule) DeepCopyInto(out *AuthorizationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationRule.
+func (in *AuthorizationRule) DeepCopy() *AuthorizationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationRules) DeepCopyInto(out *AuthorizationRules) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]AuthorizationRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationRules.
+func (in *AuthorizationRules) DeepCopy() *AuthorizationRules {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackendJWTToken) DeepCopyInto(out *BackendJWTToken) {
 	*out = *in
@@ -496,6 +856,91 @@ func (in *BackendJWTToken) DeepCopy() *BackendJWTToken {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BodyField) DeepCopyInto(out *BodyField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodyField.
+func (in *BodyField) DeepCopy() *BodyField {
+	if in == nil {
+		return nil
+	}
+	out := new(BodyField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BodyFieldRename) DeepCopyInto(out *BodyFieldRename) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodyFieldRename.
+func (in *BodyFieldRename) DeepCopy() *BodyFieldRename {
+	if in == nil {
+		return nil
+	}
+	out := new(BodyFieldRename)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BodyTransformation) DeepCopyInto(out *BodyTransformation) {
+	*out = *in
+	if in.AddFields != nil {
+		in, out := &in.AddFields, &out.AddFields
+		*out = make([]BodyField, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoveFields != nil {
+		in, out := &in.RemoveFields, &out.RemoveFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RenameFields != nil {
+		in, out := &in.RenameFields, &out.RenameFields
+		*out = make([]BodyFieldRename, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodyTransformation.
+func (in *BodyTransformation) DeepCopy() *BodyTransformation {
+	if in == nil {
+		return nil
+	}
+	out := new(BodyTransformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BodyTransformationPolicy) DeepCopyInto(out *BodyTransformationPolicy) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(BodyTransformation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(BodyTransformation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BodyTransformationPolicy.
+func (in *BodyTransformationPolicy) DeepCopy() *BodyTransformationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BodyTransformationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BurstControl) DeepCopyInto(out *BurstControl) {
 	*out = *in
@@ -551,6 +996,31 @@ func (in *CORSPolicy) DeepCopy() *CORSPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CachingPolicy) DeepCopyInto(out *CachingPolicy) {
+	*out = *in
+	if in.VaryByHeaders != nil {
+		in, out := &in.VaryByHeaders, &out.VaryByHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VaryByQueryParams != nil {
+		in, out := &in.VaryByQueryParams, &out.VaryByQueryParams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CachingPolicy.
+func (in *CachingPolicy) DeepCopy() *CachingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CachingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomRateLimitPolicy) DeepCopyInto(out *CustomRateLimitPolicy) {
 	*out = *in
@@ -610,6 +1080,76 @@ func (in *EnvConfig) DeepCopy() *EnvConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCTranscodingPolicy) DeepCopyInto(out *GRPCTranscodingPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCTranscodingPolicy.
+func (in *GRPCTranscodingPolicy) DeepCopy() *GRPCTranscodingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCTranscodingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GraphQLFieldCost) DeepCopyInto(out *GraphQLFieldCost) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GraphQLFieldCost.
+func (in *GraphQLFieldCost) DeepCopy() *GraphQLFieldCost {
+	if in == nil {
+		return nil
+	}
+	out := new(GraphQLFieldCost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GraphQLProtectionPolicy) DeepCopyInto(out *GraphQLProtectionPolicy) {
+	*out = *in
+	if in.MaxDepth != nil {
+		in, out := &in.MaxDepth, &out.MaxDepth
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxComplexity != nil {
+		in, out := &in.MaxComplexity, &out.MaxComplexity
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.FieldCosts != nil {
+		in, out := &in.FieldCosts, &out.FieldCosts
+		*out = make([]GraphQLFieldCost, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAliases != nil {
+		in, out := &in.MaxAliases, &out.MaxAliases
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxBatchSize != nil {
+		in, out := &in.MaxBatchSize, &out.MaxBatchSize
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GraphQLProtectionPolicy.
+func (in *GraphQLProtectionPolicy) DeepCopy() *GraphQLProtectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GraphQLProtectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterceptorReference) DeepCopyInto(out *InterceptorReference) {
 	*out = *in
@@ -625,6 +1165,36 @@ func (in *InterceptorReference) DeepCopy() *InterceptorReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PayloadPolicy) DeepCopyInto(out *PayloadPolicy) {
+	*out = *in
+	if in.MaxRequestBodySize != nil {
+		in, out := &in.MaxRequestBodySize, &out.MaxRequestBodySize
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxResponseBodySize != nil {
+		in, out := &in.MaxResponseBodySize, &out.MaxResponseBodySize
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.AllowedContentTypes != nil {
+		in, out := &in.AllowedContentTypes, &out.AllowedContentTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PayloadPolicy.
+func (in *PayloadPolicy) DeepCopy() *PayloadPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PayloadPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
 	*out = *in
@@ -653,6 +1223,56 @@ func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
 		*out = new(AIProviderReference)
 		**out = **in
 	}
+	if in.PayloadPolicy != nil {
+		in, out := &in.PayloadPolicy, &out.PayloadPolicy
+		*out = new(PayloadPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AIRouting != nil {
+		in, out := &in.AIRouting, &out.AIRouting
+		*out = new(AIRoutingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AIGuardrails != nil {
+		in, out := &in.AIGuardrails, &out.AIGuardrails
+		*out = new(AIGuardrailsPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AIResponseCache != nil {
+		in, out := &in.AIResponseCache, &out.AIResponseCache
+		*out = new(AIResponseCachePolicy)
+		**out = **in
+	}
+	if in.Caching != nil {
+		in, out := &in.Caching, &out.Caching
+		*out = new(CachingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchemaValidation != nil {
+		in, out := &in.SchemaValidation, &out.SchemaValidation
+		*out = new(SchemaValidationPolicy)
+		**out = **in
+	}
+	if in.BodyTransformation != nil {
+		in, out := &in.BodyTransformation, &out.BodyTransformation
+		*out = new(BodyTransformationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SOAPMediation != nil {
+		in, out := &in.SOAPMediation, &out.SOAPMediation
+		*out = new(SOAPMediationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GraphQLProtection != nil {
+		in, out := &in.GraphQLProtection, &out.GraphQLProtection
+		*out = new(GraphQLProtectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPCTranscoding != nil {
+		in, out := &in.GRPCTranscoding, &out.GRPCTranscoding
+		*out = new(GRPCTranscodingPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySpec.
@@ -734,7 +1354,7 @@ func (in *RateLimitPolicy) DeepCopyInto(out *RateLimitPolicy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitPolicy.
@@ -816,6 +1436,18 @@ func (in *RateLimitPolicySpec) DeepCopy() *RateLimitPolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimitPolicyStatus) DeepCopyInto(out *RateLimitPolicyStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AttachedAPIs != nil {
+		in, out := &in.AttachedAPIs, &out.AttachedAPIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitPolicyStatus.
@@ -890,6 +1522,56 @@ func (in *ResolveSubscriptionRatelimitPolicy) DeepCopy() *ResolveSubscriptionRat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SOAPMediationPolicy) DeepCopyInto(out *SOAPMediationPolicy) {
+	*out = *in
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]SOAPOperationMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SOAPMediationPolicy.
+func (in *SOAPMediationPolicy) DeepCopy() *SOAPMediationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SOAPMediationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SOAPOperationMapping) DeepCopyInto(out *SOAPOperationMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SOAPOperationMapping.
+func (in *SOAPOperationMapping) DeepCopy() *SOAPOperationMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(SOAPOperationMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaValidationPolicy) DeepCopyInto(out *SchemaValidationPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaValidationPolicy.
+func (in *SchemaValidationPolicy) DeepCopy() *SchemaValidationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaValidationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubscriptionRateLimitPolicy) DeepCopyInto(out *SubscriptionRateLimitPolicy) {
 	*out = *in
@@ -915,6 +1597,26 @@ func (in *SubscriptionRateLimitPolicy) DeepCopy() *SubscriptionRateLimitPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenBudget) DeepCopyInto(out *TokenBudget) {
+	*out = *in
+	if in.AlertThresholds != nil {
+		in, out := &in.AlertThresholds, &out.AlertThresholds
+		*out = make([]uint32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenBudget.
+func (in *TokenBudget) DeepCopy() *TokenBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TokenCount) DeepCopyInto(out *TokenCount) {
 	*out = *in
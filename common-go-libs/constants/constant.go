@@ -90,3 +90,25 @@ const (
 const (
 	OAuth2 = "OAuth2"
 )
+
+// Status condition types set on policy and backend CRs
+const (
+	ConditionAccepted     = "Accepted"
+	ConditionResolvedRefs = "ResolvedRefs"
+	ConditionAttached     = "Attached"
+	ConditionConflicted   = "Conflicted"
+)
+
+// Status condition reasons set on policy and backend CRs
+const (
+	ReasonAccepted         = "Accepted"
+	ReasonInvalid          = "Invalid"
+	ReasonResolvedRefs     = "ResolvedRefs"
+	ReasonRefNotFound      = "RefNotFound"
+	ReasonAttached         = "Attached"
+	ReasonNotAttached      = "NotAttached"
+	ReasonTargetNotFound   = "TargetNotFound"
+	ReasonOverridden       = "Overridden"
+	ReasonNoConflicts      = "NoConflicts"
+	ReasonMultiplePolicies = "MultiplePolicies"
+)
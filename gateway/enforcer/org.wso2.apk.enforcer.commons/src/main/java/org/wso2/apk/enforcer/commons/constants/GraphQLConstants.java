@@ -39,6 +39,15 @@ public class GraphQLConstants {
     public static final int GRAPHQL_QUERY_TOO_COMPLEX = 900821;
     public static final String GRAPHQL_QUERY_TOO_COMPLEX_MESSAGE = "QUERY TOO COMPLEX";
 
+    public static final int GRAPHQL_TOO_MANY_ALIASES = 900822;
+    public static final String GRAPHQL_TOO_MANY_ALIASES_MESSAGE = "TOO MANY ALIASES";
+
+    public static final int GRAPHQL_BATCH_TOO_LARGE = 900823;
+    public static final String GRAPHQL_BATCH_TOO_LARGE_MESSAGE = "BATCH TOO LARGE";
+
+    public static final int GRAPHQL_INTROSPECTION_DISABLED = 900824;
+    public static final String GRAPHQL_INTROSPECTION_DISABLED_MESSAGE = "INTROSPECTION DISABLED";
+
     public static final int GRAPHQL_INVALID_QUERY = 900422;
     public static final String GRAPHQL_API_FAILURE_HANDLER = "_graphql_failure_handler";
     public static final String GRAPHQL_INVALID_QUERY_MESSAGE = "INVALID QUERY";
@@ -47,6 +56,17 @@ public class GraphQLConstants {
     public static final String MAXIMUM_QUERY_DEPTH = "max_query_depth";
     public static final String GRAPHQL_MAX_DEPTH = "graphQLMaxDepth";
     public static final String GRAPHQL_MAX_COMPLEXITY = "graphQLMaxComplexity";
+    public static final String GRAPHQL_MAX_ALIASES = "graphQLMaxAliases";
+    public static final String GRAPHQL_MAX_BATCH_SIZE = "graphQLMaxBatchSize";
+    public static final String GRAPHQL_DISABLE_INTROSPECTION = "graphQLDisableIntrospection";
+    // Holds the queries of a batched request, which carries a JSON array of queries
+    public static final String GRAPHQL_BATCH_QUERIES = "graphQLBatchQueries";
+    // Denotes that the error of a rejected request is sent in the errors object of a GraphQL response
+    public static final String GRAPHQL_ERROR_RESPONSE = "graphQLErrorResponse";
+    public static final List<String> INTROSPECTION_FIELDS = Collections.unmodifiableList(
+            Arrays.asList("__schema", "__type"));
+    // Denotes that the request is the websocket handshake of a GraphQL subscription
+    public static final String GRAPHQL_SUBSCRIPTION = "graphQLSubscription";
 
     /**
      * GraphQL Constants related to GraphQL Subscription operations
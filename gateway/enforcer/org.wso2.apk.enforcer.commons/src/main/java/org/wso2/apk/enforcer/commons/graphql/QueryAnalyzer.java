@@ -24,12 +24,28 @@ import graphql.GraphQLError;
 import graphql.analysis.FieldComplexityCalculator;
 import graphql.analysis.MaxQueryComplexityInstrumentation;
 import graphql.analysis.MaxQueryDepthInstrumentation;
+import graphql.language.Definition;
+import graphql.language.Document;
+import graphql.language.Field;
+import graphql.language.FragmentDefinition;
+import graphql.language.FragmentSpread;
+import graphql.language.InlineFragment;
+import graphql.language.OperationDefinition;
+import graphql.language.Selection;
+import graphql.language.SelectionSet;
+import graphql.parser.Parser;
 import graphql.schema.GraphQLSchema;
 import org.apache.commons.logging.Log;
 import org.apache.commons.logging.LogFactory;
 import org.json.simple.parser.ParseException;
+import org.wso2.apk.enforcer.commons.constants.GraphQLConstants;
 import org.wso2.apk.enforcer.commons.dto.QueryAnalyzerResponseDTO;
+
+import java.util.HashMap;
+import java.util.HashSet;
 import java.util.List;
+import java.util.Map;
+import java.util.Set;
 
 /**
  * This class contains methods using for Graphql query depth and complexity analysis.
@@ -151,6 +167,136 @@ public class QueryAnalyzer {
         return analyseQueryComplexity(maxQueryComplexity, payload, fieldComplexityCalculator);
     }
 
+    /**
+     * This method analyses the number of aliased fields of the operations of the query. The fields selected through
+     * a fragment spread are counted once for each spread.
+     *
+     * @param maxAliases maximum number of aliased fields
+     * @param payload    payload of the request
+     * @return true, if the aliases do not exceed the maximum or false, if the aliases exceed the maximum
+     */
+    public QueryAnalyzerResponseDTO analyseQueryAliases(int maxAliases, String payload) {
+
+        QueryAnalyzerResponseDTO queryAnalyzerResponseDTO = new QueryAnalyzerResponseDTO();
+        // If maxAliases is not a negative value, perform the alias limitation check. Otherwise, bypass the check.
+        if (maxAliases >= 0) {
+            Document document = new Parser().parseDocument(payload);
+            Map<String, FragmentDefinition> fragments = getFragments(document);
+            for (Definition<?> definition : document.getDefinitions()) {
+                if (!(definition instanceof OperationDefinition)) {
+                    continue;
+                }
+                long aliases = countAliases(((OperationDefinition) definition).getSelectionSet(), fragments,
+                        new HashMap<>(), new HashSet<>());
+                if (aliases > maxAliases) {
+                    queryAnalyzerResponseDTO.addErrorToList("maximum number of aliases " + maxAliases +
+                            " exceeded with " + aliases + " aliases");
+                    queryAnalyzerResponseDTO.setSuccess(false);
+                    return queryAnalyzerResponseDTO;
+                }
+            }
+        }
+        queryAnalyzerResponseDTO.setSuccess(true);
+        return queryAnalyzerResponseDTO;
+    }
+
+    /**
+     * This method analyses whether the query selects the introspection fields of the schema.
+     *
+     * @param payload payload of the request
+     * @return true, if the query does not select the introspection fields or false, if it does
+     */
+    public QueryAnalyzerResponseDTO analyseQueryIntrospection(String payload) {
+
+        QueryAnalyzerResponseDTO queryAnalyzerResponseDTO = new QueryAnalyzerResponseDTO();
+        Document document = new Parser().parseDocument(payload);
+        for (Definition<?> definition : document.getDefinitions()) {
+            SelectionSet selectionSet = null;
+            if (definition instanceof OperationDefinition) {
+                selectionSet = ((OperationDefinition) definition).getSelectionSet();
+            } else if (definition instanceof FragmentDefinition) {
+                selectionSet = ((FragmentDefinition) definition).getSelectionSet();
+            }
+            String introspectionField = findIntrospectionField(selectionSet);
+            if (introspectionField != null) {
+                queryAnalyzerResponseDTO.addErrorToList("introspection field " + introspectionField +
+                        " is not allowed");
+                queryAnalyzerResponseDTO.setSuccess(false);
+                return queryAnalyzerResponseDTO;
+            }
+        }
+        queryAnalyzerResponseDTO.setSuccess(true);
+        return queryAnalyzerResponseDTO;
+    }
+
+    private static Map<String, FragmentDefinition> getFragments(Document document) {
+
+        Map<String, FragmentDefinition> fragments = new HashMap<>();
+        for (FragmentDefinition fragment : document.getDefinitionsOfType(FragmentDefinition.class)) {
+            fragments.put(fragment.getName(), fragment);
+        }
+        return fragments;
+    }
+
+    private static long countAliases(SelectionSet selectionSet, Map<String, FragmentDefinition> fragments,
+                                     Map<String, Long> fragmentAliases, Set<String> visitedFragments) {
+
+        if (selectionSet == null) {
+            return 0;
+        }
+        long aliases = 0;
+        for (Selection<?> selection : selectionSet.getSelections()) {
+            if (selection instanceof Field) {
+                Field field = (Field) selection;
+                if (field.getAlias() != null) {
+                    aliases++;
+                }
+                aliases += countAliases(field.getSelectionSet(), fragments, fragmentAliases, visitedFragments);
+            } else if (selection instanceof InlineFragment) {
+                aliases += countAliases(((InlineFragment) selection).getSelectionSet(), fragments, fragmentAliases,
+                        visitedFragments);
+            } else if (selection instanceof FragmentSpread) {
+                String fragmentName = ((FragmentSpread) selection).getName();
+                FragmentDefinition fragment = fragments.get(fragmentName);
+                // the aliases of a fragment are counted once and reused for each spread of it, and the visited
+                // fragments guard against the fragment cycles, which are rejected by the validation
+                if (fragment == null || !visitedFragments.add(fragmentName)) {
+                    continue;
+                }
+                if (!fragmentAliases.containsKey(fragmentName)) {
+                    fragmentAliases.put(fragmentName, countAliases(fragment.getSelectionSet(), fragments,
+                            fragmentAliases, visitedFragments));
+                }
+                aliases += fragmentAliases.get(fragmentName);
+                visitedFragments.remove(fragmentName);
+            }
+        }
+        return aliases;
+    }
+
+    private static String findIntrospectionField(SelectionSet selectionSet) {
+
+        if (selectionSet == null) {
+            return null;
+        }
+        for (Selection<?> selection : selectionSet.getSelections()) {
+            String introspectionField = null;
+            if (selection instanceof Field) {
+                Field field = (Field) selection;
+                if (GraphQLConstants.INTROSPECTION_FIELDS.contains(field.getName())) {
+                    return field.getName();
+                }
+                introspectionField = findIntrospectionField(field.getSelectionSet());
+            } else if (selection instanceof InlineFragment) {
+                introspectionField = findIntrospectionField(((InlineFragment) selection).getSelectionSet());
+            }
+            if (introspectionField != null) {
+                return introspectionField;
+            }
+        }
+        return null;
+    }
+
     public GraphQLSchema getSchema() {
         return schema;
     }
@@ -25,6 +25,7 @@ public class AuthenticationConfig {
     private List<APIKeyAuthenticationConfig> apiKeyAuthenticationConfigs;
     private InternalKeyConfig internalKeyConfig;
     private Oauth2AuthenticationConfig oauth2AuthenticationConfig;
+    private HMACAuthenticationConfig hmacAuthenticationConfig;
     private boolean Disabled;
 
     public JWTAuthenticationConfig getJwtAuthenticationConfig() {
@@ -68,4 +69,14 @@ public class AuthenticationConfig {
 
         this.oauth2AuthenticationConfig = oauth2AuthenticationConfig;
     }
+
+    public HMACAuthenticationConfig getHmacAuthenticationConfig() {
+
+        return hmacAuthenticationConfig;
+    }
+
+    public void setHmacAuthenticationConfig(HMACAuthenticationConfig hmacAuthenticationConfig) {
+
+        this.hmacAuthenticationConfig = hmacAuthenticationConfig;
+    }
 }
@@ -17,7 +17,9 @@
  */
 package org.wso2.apk.enforcer.commons.model;
 
+import java.util.HashMap;
 import java.util.List;
+import java.util.Map;
 
 /**
  * AuthenticationContext contains the details populated after applying authentication filter.
@@ -44,6 +46,8 @@ public class AuthenticationContext {
     private String apiUUID;
     private String rawToken;
     private String tokenType;
+    private Map<String, Object> claims = new HashMap<>();
+    private List<String> scopes;
 
     public static final String UNKNOWN_VALUE = "__unknown__";
 
@@ -316,4 +320,31 @@ public class AuthenticationContext {
     public void setApplicationUUID(String applicationUUID) {
         this.applicationUUID = applicationUUID;
     }
+
+    /**
+     * Claims of the token used to authenticate the request, which are available to the
+     * authorization rules of the API.
+     *
+     * @return claims of the token
+     */
+    public Map<String, Object> getClaims() {
+        return claims;
+    }
+
+    public void setClaims(Map<String, Object> claims) {
+        this.claims = claims;
+    }
+
+    /**
+     * Scopes of the token used to authenticate the request.
+     *
+     * @return scopes of the token, or null if the scopes of the token are not validated
+     */
+    public List<String> getScopes() {
+        return scopes;
+    }
+
+    public void setScopes(List<String> scopes) {
+        this.scopes = scopes;
+    }
 }
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.commons.model;
+
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+/**
+ * Defines HMAC request signature authentication config structure.
+ */
+public class HMACAuthenticationConfig {
+    private String header;
+    private Map<String, String> keys = new HashMap<>();
+    private List<String> algorithms = new ArrayList<>();
+    private List<String> signedHeaders = new ArrayList<>();
+    private int clockSkew;
+    private boolean sendTokenToUpstream;
+
+    public String getHeader() {
+        return header;
+    }
+
+    public void setHeader(String header) {
+        this.header = header;
+    }
+
+    public Map<String, String> getKeys() {
+        return keys;
+    }
+
+    public void setKeys(Map<String, String> keys) {
+        this.keys = keys;
+    }
+
+    public List<String> getAlgorithms() {
+        return algorithms;
+    }
+
+    public void setAlgorithms(List<String> algorithms) {
+        this.algorithms = algorithms;
+    }
+
+    public List<String> getSignedHeaders() {
+        return signedHeaders;
+    }
+
+    public void setSignedHeaders(List<String> signedHeaders) {
+        this.signedHeaders = signedHeaders;
+    }
+
+    public int getClockSkew() {
+        return clockSkew;
+    }
+
+    public void setClockSkew(int clockSkew) {
+        this.clockSkew = clockSkew;
+    }
+
+    public boolean isSendTokenToUpstream() {
+        return sendTokenToUpstream;
+    }
+
+    public void setSendTokenToUpstream(boolean sendTokenToUpstream) {
+        this.sendTokenToUpstream = sendTokenToUpstream;
+    }
+}
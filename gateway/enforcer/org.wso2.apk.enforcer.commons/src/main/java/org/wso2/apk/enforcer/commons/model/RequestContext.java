@@ -47,6 +47,7 @@ public class RequestContext {
     private String requestID;
     private String clientIp;
     private String requestPayload;
+    private byte[] requestBody;
     private String clientCertificate;
     // Denotes the cluster header name for each environment. Both properties can be null if
     // the openAPI has production endpoints alone.
@@ -178,6 +179,16 @@ public class RequestContext {
         return requestPayload;
     }
 
+    /**
+     * Returns the body of the request as received from the router, before the payload is processed by any
+     * filter. This is null if the body is not buffered for the request.
+     *
+     * @return request body
+     */
+    public byte[] getRequestBody() {
+        return requestBody;
+    }
+
     /**
      * Returns the client certificate.
      *
@@ -382,6 +393,7 @@ public class RequestContext {
         private String requestID;
         private String clientIp;
         private String requestPayload;
+        private byte[] requestBody;
         private String clientCertificate;
         private WebSocketFrameContext webSocketFrameContext;
 
@@ -451,6 +463,11 @@ public class RequestContext {
             return this;
         }
 
+        public Builder requestBody(byte[] requestBody) {
+            this.requestBody = requestBody;
+            return this;
+        }
+
         public RequestContext build() {
             RequestContext requestContext = new RequestContext();
             requestContext.matchedResourcePaths = this.matchedResourceConfigs;
@@ -466,6 +483,7 @@ public class RequestContext {
             requestContext.requestID = this.requestID;
             requestContext.clientIp = this.clientIp;
             requestContext.requestPayload = this.requestPayload;
+            requestContext.requestBody = this.requestBody;
             requestContext.clientCertificate = this.clientCertificate;
             requestContext.addHeaders = new HashMap<>();
             requestContext.removeHeaders = new ArrayList<>();
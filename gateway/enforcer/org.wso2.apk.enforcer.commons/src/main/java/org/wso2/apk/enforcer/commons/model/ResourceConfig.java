@@ -17,6 +17,7 @@
  */
 package org.wso2.apk.enforcer.commons.model;
 
+import java.util.ArrayList;
 import java.util.HashMap;
 import java.util.List;
 import java.util.Map;
@@ -36,6 +37,7 @@ public class ResourceConfig {
     private MockedApiConfig mockedApiConfig;
     private AuthenticationConfig authenticationConfig;
     private String[] scopes;
+    private List<AuthorizationRuleConfig> authorizationRules = new ArrayList<>();
 
     /**
      * ENUM to hold http operations.
@@ -164,5 +166,17 @@ public class ResourceConfig {
     public void setEndpointSecurity(EndpointSecurity[] endpointSecurity) {
         this.endpointSecurity = endpointSecurity;
     }
-}
 
+    /**
+     * Get the authorization rules which should allow the request to access the resource.
+     *
+     * @return authorization rules of the resource
+     */
+    public List<AuthorizationRuleConfig> getAuthorizationRules() {
+        return authorizationRules;
+    }
+
+    public void setAuthorizationRules(List<AuthorizationRuleConfig> authorizationRules) {
+        this.authorizationRules = authorizationRules;
+    }
+}
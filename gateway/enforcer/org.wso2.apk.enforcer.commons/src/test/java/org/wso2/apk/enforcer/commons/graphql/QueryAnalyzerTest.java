@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.commons.graphql;
+
+import graphql.schema.GraphQLSchema;
+import graphql.schema.idl.RuntimeWiring;
+import graphql.schema.idl.SchemaGenerator;
+import graphql.schema.idl.SchemaParser;
+import org.junit.Assert;
+import org.junit.Test;
+import org.wso2.apk.enforcer.commons.dto.QueryAnalyzerResponseDTO;
+
+public class QueryAnalyzerTest {
+
+    private static final String SCHEMA = "type Query { books: [Book] book(id: ID): Book }\n"
+            + "type Book { id: ID title: String author: Author }\n"
+            + "type Author { name: String books: [Book] }";
+
+    private final QueryAnalyzer queryAnalyzer = new QueryAnalyzer(createSchema());
+
+    private static GraphQLSchema createSchema() {
+        return new SchemaGenerator().makeExecutableSchema(new SchemaParser().parse(SCHEMA),
+                RuntimeWiring.newRuntimeWiring().build());
+    }
+
+    private void assertAliases(String query, int maxAliases, boolean allowed) {
+        QueryAnalyzerResponseDTO response = queryAnalyzer.analyseQueryAliases(maxAliases, query);
+        Assert.assertEquals("Unexpected result for " + maxAliases + " aliases of " + query, allowed,
+                response.isSuccess());
+    }
+
+    private void assertIntrospection(String query, String introspectionField) {
+        QueryAnalyzerResponseDTO response = queryAnalyzer.analyseQueryIntrospection(query);
+        if (introspectionField == null) {
+            Assert.assertTrue("The query " + query + " should be allowed.", response.isSuccess());
+            return;
+        }
+        Assert.assertFalse("The query " + query + " should be blocked.", response.isSuccess());
+        Assert.assertTrue(response.getErrorList().get(0).contains(introspectionField));
+    }
+
+    @Test
+    public void testAliases() {
+        String query = "{ first: books { id } second: books { id name: title } }";
+        assertAliases(query, 2, false);
+        assertAliases(query, 3, true);
+        assertAliases(query, -1, true);
+        assertAliases("{ books { id } }", 0, true);
+    }
+
+    @Test
+    public void testAliasesOfEachOperation() {
+        String query = "query First { first: books { id } } query Second { second: books { id } }";
+        assertAliases(query, 1, true);
+        assertAliases(query, 0, false);
+    }
+
+    @Test
+    public void testAliasesThroughFragments() {
+        // the aliases of a fragment are counted for each of its spreads
+        String query = "{ books { ...titles } other: books { ...titles } } "
+                + "fragment titles on Book { first: title second: title }";
+        assertAliases(query, 4, false);
+        assertAliases(query, 5, true);
+
+        // the aliases of the nested fragments and the inline fragments are counted
+        query = "{ books { ...book } } fragment book on Book { ...author ... on Book { name: title } } "
+                + "fragment author on Book { writer: author { name } }";
+        assertAliases(query, 1, false);
+        assertAliases(query, 2, true);
+
+        // the fragments which are not spread by the operation are not counted
+        assertAliases("{ books { id } } fragment unused on Book { first: title }", 0, true);
+    }
+
+    @Test(timeout = 10000)
+    public void testAliasesThroughFragmentCycles() {
+        String query = "{ books { ...first } } fragment first on Book { one: title ...second } "
+                + "fragment second on Book { two: title ...first }";
+        assertAliases(query, 1, false);
+        assertAliases(query, 2, true);
+        assertAliases("{ books { ...self } } fragment self on Book { one: title ...self }", 1, true);
+    }
+
+    @Test(timeout = 10000)
+    public void testAliasesThroughRepeatedSpreads() {
+        // each fragment spreads the previous fragment twice, which doubles the aliases of each fragment
+        StringBuilder query = new StringBuilder("{ books { ...f20 } } fragment f0 on Book { one: title }");
+        for (int i = 1; i <= 20; i++) {
+            query.append(" fragment f").append(i).append(" on Book { ...f").append(i - 1).append(" ...f")
+                    .append(i - 1).append(" }");
+        }
+        QueryAnalyzerResponseDTO response = queryAnalyzer.analyseQueryAliases(1000, query.toString());
+        Assert.assertFalse(response.isSuccess());
+        Assert.assertTrue(response.getErrorList().get(0).contains("exceeded with 1048576 aliases"));
+    }
+
+    @Test
+    public void testIntrospection() {
+        assertIntrospection("{ __schema { queryType { name } } }", "__schema");
+        assertIntrospection("{ books { id } __type(name: \"Book\") { name } }", "__type");
+        assertIntrospection("{ books { __typename id } }", null);
+        assertIntrospection("{ books { title author { name } } }", null);
+    }
+
+    @Test
+    public void testIntrospectionInsideFragments() {
+        assertIntrospection("{ ...schema } fragment schema on Query { __schema { queryType { name } } }",
+                "__schema");
+        assertIntrospection("{ books { id } ...outer } fragment outer on Query { ...inner } "
+                + "fragment inner on Query { book(id: 1) { id } __type(name: \"Book\") { name } }", "__type");
+        assertIntrospection("{ ... on Query { __type(name: \"Book\") { name } } }", "__type");
+        assertIntrospection("{ ...books } fragment books on Query { ... on Query { books { ... on Book { "
+                + "__schema { types { name } } } } } }", "__schema");
+        assertIntrospection("{ ...books } fragment books on Query { books { __typename ...book } } "
+                + "fragment book on Book { title }", null);
+    }
+
+    @Test
+    public void testDepthThroughFragments() {
+        String query = "{ books { ...book } } fragment book on Book { author { books { id } } }";
+        Assert.assertFalse(queryAnalyzer.analyseQueryDepth(2, query).isSuccess());
+        Assert.assertTrue(queryAnalyzer.analyseQueryDepth(10, query).isSuccess());
+    }
+}
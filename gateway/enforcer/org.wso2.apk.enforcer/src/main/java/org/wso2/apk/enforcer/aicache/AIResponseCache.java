@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.aicache;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.core.JsonProcessingException;
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import com.fasterxml.jackson.databind.SerializationFeature;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+
+import java.nio.charset.StandardCharsets;
+import java.security.MessageDigest;
+import java.security.NoSuchAlgorithmException;
+import java.util.ArrayList;
+import java.util.Base64;
+import java.util.HexFormat;
+import java.util.List;
+import java.util.Map;
+import java.util.TreeMap;
+import java.util.concurrent.ConcurrentHashMap;
+
+/**
+ * AI response cache of an API. The responses are cached against the normalized model, messages and temperature
+ * of the request, scoped by the organization and the API. The configurations are received from the adapter as
+ * base64 encoded json in the route metadata.
+ */
+public class AIResponseCache {
+
+    private static final Logger logger = LogManager.getLogger(AIResponseCache.class);
+    private static final ObjectMapper mapper = new ObjectMapper();
+    // the canonical form of a request should not depend on the order of the fields sent by the client
+    private static final ObjectMapper canonicalMapper =
+            new ObjectMapper().configure(SerializationFeature.ORDER_MAP_ENTRIES_BY_KEYS, true);
+    private static final Map<String, AIResponseCache> caches = new ConcurrentHashMap<>();
+    private static final String CACHE_KEY_PREFIX = "wso2:apk:ai_response_cache:";
+
+    public static final String STATUS_HIT = "HIT";
+    public static final String STATUS_MISS = "MISS";
+    public static final String STATUS_BYPASS = "BYPASS";
+
+    private final String organization;
+    private final String apiUUID;
+    private final long ttlSeconds;
+    private final int maxEntrySize;
+    private final String bypassHeader;
+
+    private AIResponseCache(CacheConfig config) {
+        organization = config.organization;
+        apiUUID = config.apiUUID;
+        ttlSeconds = config.ttlSeconds;
+        maxEntrySize = config.maxEntrySize;
+        bypassHeader = config.bypassHeader;
+    }
+
+    /**
+     * Returns the cache of the given configurations.
+     *
+     * @param encodedConfig base64 encoded json of the cache configurations
+     * @return the cache, or null if the configurations could not be read
+     */
+    public static AIResponseCache fromEncodedConfig(String encodedConfig) {
+        if (encodedConfig == null || encodedConfig.isEmpty()) {
+            return null;
+        }
+        AIResponseCache cache = caches.get(encodedConfig);
+        if (cache != null) {
+            return cache;
+        }
+        try {
+            String configJson = new String(Base64.getDecoder().decode(encodedConfig), StandardCharsets.UTF_8);
+            cache = new AIResponseCache(mapper.readValue(configJson, CacheConfig.class));
+            caches.put(encodedConfig, cache);
+            return cache;
+        } catch (Exception e) {
+            logger.error("Error while reading the AI response cache configurations of the route. " + e);
+            return null;
+        }
+    }
+
+    /**
+     * Returns the cache key of the given request body. Only the model, the messages and the temperature of the
+     * request are considered, after normalizing the whitespaces of the message contents.
+     *
+     * @param body request body sent to the AI provider
+     * @return the cache key, or null if the request is not cacheable
+     */
+    public String getCacheKey(String body) {
+        JsonNode request;
+        try {
+            request = mapper.readTree(body);
+        } catch (Exception e) {
+            logger.debug("The AI request is not cacheable as the body is not a json.");
+            return null;
+        }
+        if (request == null || !request.isObject() || !request.path("messages").isArray()
+                || request.path("stream").asBoolean(false)) {
+            return null;
+        }
+        List<Map<String, Object>> messages = new ArrayList<>();
+        for (JsonNode message : request.get("messages")) {
+            Map<String, Object> normalizedMessage = new TreeMap<>();
+            normalizedMessage.put("role", message.path("role").asText());
+            JsonNode content = message.get("content");
+            if (content == null || content.isTextual()) {
+                normalizedMessage.put("content", normalize(content == null ? "" : content.asText()));
+            } else {
+                normalizedMessage.put("content", mapper.convertValue(content, Object.class));
+            }
+            messages.add(normalizedMessage);
+        }
+        Map<String, Object> normalizedRequest = new TreeMap<>();
+        normalizedRequest.put("model", request.path("model").asText().trim());
+        normalizedRequest.put("messages", messages);
+        JsonNode temperature = request.get("temperature");
+        normalizedRequest.put("temperature", temperature != null && temperature.isNumber()
+                ? temperature.decimalValue().stripTrailingZeros().toPlainString() : null);
+        try {
+            byte[] canonicalRequest = canonicalMapper.writeValueAsBytes(normalizedRequest);
+            byte[] digest = MessageDigest.getInstance("SHA-256").digest(canonicalRequest);
+            return CACHE_KEY_PREFIX + organization + ":" + apiUUID + ":" + HexFormat.of().formatHex(digest);
+        } catch (NoSuchAlgorithmException | JsonProcessingException e) {
+            logger.error("Error while generating the AI response cache key. " + e);
+            return null;
+        }
+    }
+
+    /**
+     * Checks whether the given response fits in a cache entry.
+     *
+     * @param response response body
+     * @return true if the response is not larger than the maximum entry size
+     */
+    public boolean isCacheable(String response) {
+        return response != null && response.getBytes(StandardCharsets.UTF_8).length <= maxEntrySize;
+    }
+
+    private static String normalize(String content) {
+        return content.trim().replaceAll("\\s+", " ");
+    }
+
+    public long getTtlSeconds() {
+        return ttlSeconds;
+    }
+
+    public String getBypassHeader() {
+        return bypassHeader;
+    }
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    private static class CacheConfig {
+        public String organization;
+        public String apiUUID;
+        public long ttlSeconds;
+        public int maxEntrySize;
+        public String bypassHeader;
+    }
+}
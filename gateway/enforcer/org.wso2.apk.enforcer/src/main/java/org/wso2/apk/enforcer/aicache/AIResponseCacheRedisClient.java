@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.aicache;
+
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+import org.wso2.apk.enforcer.server.RevokedTokenRedisClient;
+import redis.clients.jedis.Jedis;
+import redis.clients.jedis.JedisPool;
+
+/**
+ * Reads and writes the cached AI responses in the redis server shared with the revoked token store.
+ */
+public class AIResponseCacheRedisClient {
+
+    private static final Logger logger = LogManager.getLogger(AIResponseCacheRedisClient.class);
+    private static volatile AIResponseCacheRedisClient instance;
+    private final JedisPool jedisPool;
+
+    private AIResponseCacheRedisClient() throws EnforcerException {
+        this.jedisPool = RevokedTokenRedisClient.getJedisPool();
+    }
+
+    /**
+     * Returns the redis client of the AI response cache. The connection pool is created on the first use.
+     *
+     * @return the redis client, or null if the connection pool could not be created
+     */
+    public static AIResponseCacheRedisClient getInstance() {
+        if (instance == null) {
+            synchronized (AIResponseCacheRedisClient.class) {
+                if (instance == null) {
+                    try {
+                        instance = new AIResponseCacheRedisClient();
+                    } catch (EnforcerException e) {
+                        logger.error("Error while creating the redis connection pool of the AI response cache.", e);
+                        return null;
+                    }
+                }
+            }
+        }
+        return instance;
+    }
+
+    /**
+     * Returns the cached response of the given key.
+     *
+     * @param key cache key
+     * @return the cached response, or null if it is not cached or the cache is not reachable
+     */
+    public String get(String key) {
+        try (Jedis jedis = jedisPool.getResource()) {
+            return jedis.get(key);
+        } catch (Exception e) {
+            logger.warn("Error while reading the AI response cache. Treating the request as a cache miss.", e);
+            return null;
+        }
+    }
+
+    /**
+     * Caches the given response under the given key.
+     *
+     * @param key        cache key
+     * @param response   response to cache
+     * @param ttlSeconds seconds until the cached response expires
+     * @return true if the response is cached
+     */
+    public boolean put(String key, String response, long ttlSeconds) {
+        try (Jedis jedis = jedisPool.getResource()) {
+            jedis.setex(key, ttlSeconds, response);
+            return true;
+        } catch (Exception e) {
+            logger.warn("Error while writing to the AI response cache.", e);
+            return false;
+        }
+    }
+}
@@ -0,0 +1,216 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.aitransformation;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+
+import java.nio.charset.StandardCharsets;
+import java.time.Instant;
+import java.util.Base64;
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+
+/**
+ * Transformation of the AI provider of an API. The OpenAI chat completions requests of the clients are converted
+ * to the format of the provider and sent to the path of the provider, and the responses of the provider are
+ * converted back to the OpenAI format. The requests are signed when the backend is secured with AWS credentials.
+ * The configurations are received from the adapter as base64 encoded json in the route metadata.
+ */
+public class AITransformation {
+
+    private static final Logger logger = LogManager.getLogger(AITransformation.class);
+    private static final ObjectMapper mapper = new ObjectMapper();
+    private static final Map<String, AITransformation> transformations = new ConcurrentHashMap<>();
+    // These values are shared between the adapter and enforcer
+    private static final String FORMAT_ANTHROPIC = "Anthropic";
+    private static final String FORMAT_BEDROCK = "Bedrock";
+
+    private static final String PATH_HEADER = ":path";
+    private static final String CONTENT_TYPE_HEADER = "content-type";
+    private static final String JSON_CONTENT_TYPE = "application/json";
+    private static final String METHOD_POST = "POST";
+
+    private final ProviderConverter converter;
+    private final String basePath;
+    private final AWSSigner signer;
+
+    AITransformation(ProviderConverter converter, String basePath, AWSSigner signer) {
+        this.converter = converter;
+        this.basePath = basePath == null ? "" : basePath;
+        this.signer = signer;
+    }
+
+    /**
+     * Returns the transformation of the given configurations.
+     *
+     * @param encodedConfig base64 encoded json of the transformation configurations
+     * @return the transformation, or null if the configurations could not be read
+     */
+    public static AITransformation fromEncodedConfig(String encodedConfig) {
+        if (encodedConfig == null || encodedConfig.isEmpty()) {
+            return null;
+        }
+        AITransformation transformation = transformations.get(encodedConfig);
+        if (transformation != null) {
+            return transformation;
+        }
+        try {
+            String configJson = new String(Base64.getDecoder().decode(encodedConfig), StandardCharsets.UTF_8);
+            TransformationConfig config = mapper.readValue(configJson, TransformationConfig.class);
+            ProviderConverter converter;
+            if (FORMAT_ANTHROPIC.equals(config.format)) {
+                converter = new AnthropicConverter(config.defaultMaxTokens, config.apiVersion);
+            } else if (FORMAT_BEDROCK.equals(config.format)) {
+                converter = new BedrockConverter(config.defaultMaxTokens);
+            } else {
+                logger.error("Unsupported AI provider format: " + config.format);
+                return null;
+            }
+            AWSSigner signer = null;
+            if (config.aws != null) {
+                signer = new AWSSigner(config.aws.region, config.aws.service, config.aws.host,
+                        config.aws.accessKeyId, config.aws.secretAccessKey, config.aws.sessionToken);
+            }
+            transformation = new AITransformation(converter, config.basePath, signer);
+            transformations.put(encodedConfig, transformation);
+            return transformation;
+        } catch (Exception e) {
+            logger.error("Error while reading the AI provider transformation of the route. " + e);
+            return null;
+        }
+    }
+
+    /**
+     * Returns the model of the given OpenAI chat completions request.
+     *
+     * @param body request body sent by the client
+     * @return the model, or an empty string if the request does not specify it
+     */
+    public static String getModel(String body) {
+        try {
+            return mapper.readTree(body).path("model").asText("");
+        } catch (Exception e) {
+            return "";
+        }
+    }
+
+    /**
+     * Converts the given OpenAI chat completions request to the request of the provider. The path of the request is
+     * replaced with the path of the provider, keeping the query of the request, and the credentials are moved to the
+     * header of the provider or replaced with the signature of the request.
+     *
+     * @param body          request body sent by the client
+     * @param path          path of the request, or null if it is not known
+     * @param authorization authorization header of the request, or null if it is not set
+     * @return the request of the provider, or null if the request is not a chat completions request
+     */
+    public ProviderRequest transformRequest(String body, String path, String authorization) {
+        JsonNode request;
+        String providerBody;
+        try {
+            request = mapper.readTree(body);
+            if (request == null || !request.isObject() || !request.path("messages").isArray()) {
+                logger.debug("The AI request is not transformed as it is not a chat completions request.");
+                return null;
+            }
+            providerBody = mapper.writeValueAsString(converter.toProviderRequest(request));
+        } catch (Exception e) {
+            logger.debug("The AI request is not transformed as the body is not a json.");
+            return null;
+        }
+        ProviderRequest providerRequest = new ProviderRequest(providerBody);
+        String providerPath = basePath + converter.getRequestPath(request);
+        int querySeparator = path != null ? path.indexOf('?') : -1;
+        if (querySeparator >= 0) {
+            providerPath += path.substring(querySeparator);
+        }
+        providerRequest.setHeader(PATH_HEADER, providerPath);
+        providerRequest.setHeader(CONTENT_TYPE_HEADER, JSON_CONTENT_TYPE);
+        converter.addRequestHeaders(providerRequest, authorization);
+        if (signer != null) {
+            signer.sign(METHOD_POST, providerPath, providerBody, Instant.now()).forEach(providerRequest::setHeader);
+        }
+        return providerRequest;
+    }
+
+    /**
+     * Converts the given response of the provider to the OpenAI chat completions format. Unsuccessful responses
+     * are converted to the OpenAI error format.
+     *
+     * @param body    response body of the provider
+     * @param success whether the status of the response is successful
+     * @param model   model requested by the client
+     * @return the response body in the OpenAI format, or null if the response could not be converted
+     */
+    public String transformResponse(String body, boolean success, String model) {
+        try {
+            JsonNode response = mapper.readTree(body);
+            if (response == null || !response.isObject()) {
+                return null;
+            }
+            return mapper.writeValueAsString(success ? converter.toOpenAIResponse(response, model)
+                    : converter.toOpenAIError(response));
+        } catch (Exception e) {
+            logger.error("Error while transforming the AI provider response. " + e);
+            return null;
+        }
+    }
+
+    /**
+     * Checks whether the given content type is the content type of the streamed responses of the provider.
+     *
+     * @param contentType content type header value of the response
+     * @return true if the response is streamed
+     */
+    public boolean isStreamingResponse(String contentType) {
+        return contentType != null && contentType.trim().toLowerCase().startsWith(converter.getStreamContentType());
+    }
+
+    /**
+     * Returns a converter of a streamed response of the provider to OpenAI chat completion chunks.
+     *
+     * @param model model requested by the client
+     * @return the stream converter
+     */
+    public StreamConverter newStreamConverter(String model) {
+        return converter.newStreamConverter(model);
+    }
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    private static class TransformationConfig {
+        public String format;
+        public int defaultMaxTokens;
+        public String apiVersion;
+        public String basePath;
+        public AWSConfig aws;
+    }
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    private static class AWSConfig {
+        public String region;
+        public String service;
+        public String host;
+        public String accessKeyId;
+        public String secretAccessKey;
+        public String sessionToken;
+    }
+}
@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.aitransformation;
+
+import java.net.URLDecoder;
+import java.nio.charset.StandardCharsets;
+import java.security.GeneralSecurityException;
+import java.security.MessageDigest;
+import java.time.Instant;
+import java.time.ZoneOffset;
+import java.time.format.DateTimeFormatter;
+import java.util.ArrayList;
+import java.util.HexFormat;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import javax.crypto.Mac;
+import javax.crypto.spec.SecretKeySpec;
+
+/**
+ * Signs the requests to an AWS service with signature version 4. The host, the date and the session token of
+ * the temporary credentials are signed, along with the method, the path, the query and the body of the request.
+ */
+class AWSSigner {
+
+    private static final String ALGORITHM = "AWS4-HMAC-SHA256";
+    private static final String HMAC_ALGORITHM = "HmacSHA256";
+    private static final DateTimeFormatter DATE_TIME_FORMAT =
+            DateTimeFormatter.ofPattern("yyyyMMdd'T'HHmmss'Z'").withZone(ZoneOffset.UTC);
+    static final String DATE_HEADER = "x-amz-date";
+    static final String SECURITY_TOKEN_HEADER = "x-amz-security-token";
+    static final String AUTHORIZATION_HEADER = "authorization";
+
+    private final String region;
+    private final String service;
+    private final String host;
+    private final String accessKeyId;
+    private final String secretAccessKey;
+    private final String sessionToken;
+
+    AWSSigner(String region, String service, String host, String accessKeyId, String secretAccessKey,
+              String sessionToken) {
+        this.region = region;
+        this.service = service;
+        this.host = host;
+        this.accessKeyId = accessKeyId;
+        this.secretAccessKey = secretAccessKey;
+        this.sessionToken = sessionToken == null || sessionToken.isEmpty() ? null : sessionToken;
+    }
+
+    /**
+     * Signs the given request.
+     *
+     * @param method HTTP method of the request
+     * @param path   path of the request, including the query
+     * @param body   body of the request
+     * @param time   time at which the request is signed
+     * @return the headers to be set on the request
+     */
+    Map<String, String> sign(String method, String path, String body, Instant time) {
+        String dateTime = DATE_TIME_FORMAT.format(time);
+        String date = dateTime.substring(0, 8);
+        Map<String, String> signedHeaders = new LinkedHashMap<>();
+        // the headers are signed in the sorted order of their names
+        signedHeaders.put("host", host);
+        signedHeaders.put(DATE_HEADER, dateTime);
+        if (sessionToken != null) {
+            signedHeaders.put(SECURITY_TOKEN_HEADER, sessionToken);
+        }
+        String signedHeaderNames = String.join(";", signedHeaders.keySet());
+        StringBuilder canonicalHeaders = new StringBuilder();
+        signedHeaders.forEach((name, value) -> canonicalHeaders.append(name).append(':').append(value.trim())
+                .append('\n'));
+        int querySeparator = path.indexOf('?');
+        String canonicalRequest = method + '\n'
+                + getCanonicalURI(querySeparator < 0 ? path : path.substring(0, querySeparator)) + '\n'
+                + getCanonicalQuery(querySeparator < 0 ? "" : path.substring(querySeparator + 1)) + '\n'
+                + canonicalHeaders + '\n'
+                + signedHeaderNames + '\n'
+                + sha256Hex(body);
+        String scope = date + '/' + region + '/' + service + "/aws4_request";
+        String stringToSign = ALGORITHM + '\n' + dateTime + '\n' + scope + '\n' + sha256Hex(canonicalRequest);
+        byte[] signingKey = hmac(("AWS4" + secretAccessKey).getBytes(StandardCharsets.UTF_8), date);
+        signingKey = hmac(signingKey, region);
+        signingKey = hmac(signingKey, service);
+        signingKey = hmac(signingKey, "aws4_request");
+        String signature = HexFormat.of().formatHex(hmac(signingKey, stringToSign));
+
+        Map<String, String> headers = new LinkedHashMap<>();
+        headers.put(DATE_HEADER, dateTime);
+        if (sessionToken != null) {
+            headers.put(SECURITY_TOKEN_HEADER, sessionToken);
+        }
+        headers.put(AUTHORIZATION_HEADER, ALGORITHM + " Credential=" + accessKeyId + '/' + scope
+                + ", SignedHeaders=" + signedHeaderNames + ", Signature=" + signature);
+        return headers;
+    }
+
+    // getCanonicalURI encodes each segment of the path once more, as AWS expects for the services other than S3
+    private static String getCanonicalURI(String path) {
+        if (path.isEmpty()) {
+            return "/";
+        }
+        String[] segments = path.split("/", -1);
+        StringBuilder canonicalURI = new StringBuilder();
+        for (int i = 0; i < segments.length; i++) {
+            if (i > 0) {
+                canonicalURI.append('/');
+            }
+            canonicalURI.append(uriEncode(segments[i]));
+        }
+        return canonicalURI.toString();
+    }
+
+    // getCanonicalQuery returns the encoded query parameters sorted by their names and values
+    private static String getCanonicalQuery(String query) {
+        if (query.isEmpty()) {
+            return "";
+        }
+        List<String[]> parameters = new ArrayList<>();
+        for (String parameter : query.split("&")) {
+            if (parameter.isEmpty()) {
+                continue;
+            }
+            int separator = parameter.indexOf('=');
+            String name = separator < 0 ? parameter : parameter.substring(0, separator);
+            String value = separator < 0 ? "" : parameter.substring(separator + 1);
+            parameters.add(new String[]{uriEncode(URLDecoder.decode(name, StandardCharsets.UTF_8)),
+                    uriEncode(URLDecoder.decode(value, StandardCharsets.UTF_8))});
+        }
+        parameters.sort((first, second) -> first[0].equals(second[0]) ? first[1].compareTo(second[1])
+                : first[0].compareTo(second[0]));
+        StringBuilder canonicalQuery = new StringBuilder();
+        for (String[] parameter : parameters) {
+            if (canonicalQuery.length() > 0) {
+                canonicalQuery.append('&');
+            }
+            canonicalQuery.append(parameter[0]).append('=').append(parameter[1]);
+        }
+        return canonicalQuery.toString();
+    }
+
+    /**
+     * Encodes the given value as AWS expects, which keeps only the unreserved characters of RFC 3986.
+     *
+     * @param value value to be encoded
+     * @return the encoded value
+     */
+    static String uriEncode(String value) {
+        StringBuilder encoded = new StringBuilder();
+        for (byte b : value.getBytes(StandardCharsets.UTF_8)) {
+            char c = (char) (b & 0xff);
+            if ((c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+                    || c == '-' || c == '_' || c == '.' || c == '~') {
+                encoded.append(c);
+            } else {
+                encoded.append('%').append(HexFormat.of().withUpperCase().toHexDigits(b));
+            }
+        }
+        return encoded.toString();
+    }
+
+    private static String sha256Hex(String value) {
+        try {
+            return HexFormat.of().formatHex(MessageDigest.getInstance("SHA-256")
+                    .digest(value.getBytes(StandardCharsets.UTF_8)));
+        } catch (GeneralSecurityException e) {
+            throw new IllegalStateException("SHA-256 is not supported", e);
+        }
+    }
+
+    private static byte[] hmac(byte[] key, String data) {
+        try {
+            Mac mac = Mac.getInstance(HMAC_ALGORITHM);
+            mac.init(new SecretKeySpec(key, HMAC_ALGORITHM));
+            return mac.doFinal(data.getBytes(StandardCharsets.UTF_8));
+        } catch (GeneralSecurityException e) {
+            throw new IllegalStateException("HMAC-SHA256 is not supported", e);
+        }
+    }
+}
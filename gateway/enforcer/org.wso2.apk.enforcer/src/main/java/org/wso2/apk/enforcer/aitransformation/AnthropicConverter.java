@@ -0,0 +1,258 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.aitransformation;
+
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.node.ArrayNode;
+import com.fasterxml.jackson.databind.node.ObjectNode;
+
+
+/**
+ * Converts the OpenAI chat completions requests to the Anthropic messages format, and the Anthropic responses
+ * back to the OpenAI format.
+ */
+class AnthropicConverter extends ProviderConverter {
+
+    private static final String DEFAULT_API_VERSION = "2023-06-01";
+    private static final String API_VERSION_HEADER = "anthropic-version";
+    private static final String API_KEY_HEADER = "x-api-key";
+    private static final String AUTHORIZATION_HEADER = "authorization";
+    private static final String BEARER_PREFIX = "bearer ";
+    private static final String MESSAGES_PATH = "/v1/messages";
+    private static final String STREAM_CONTENT_TYPE = "text/event-stream";
+
+    private final int defaultMaxTokens;
+    private final String apiVersion;
+
+    AnthropicConverter(int defaultMaxTokens, String apiVersion) {
+        this.defaultMaxTokens = defaultMaxTokens;
+        this.apiVersion = apiVersion == null || apiVersion.isEmpty() ? DEFAULT_API_VERSION : apiVersion;
+    }
+
+    @Override
+    ObjectNode toProviderRequest(JsonNode request) {
+        ObjectNode converted = mapper.createObjectNode();
+        if (request.has("model")) {
+            converted.set("model", request.get("model"));
+        }
+        StringBuilder system = new StringBuilder();
+        ArrayNode messages = mapper.createArrayNode();
+        for (JsonNode message : request.get("messages")) {
+            switch (message.path("role").asText()) {
+                case "system":
+                case "developer":
+                    if (system.length() > 0) {
+                        system.append("\n\n");
+                    }
+                    system.append(getTextContent(message.get("content")));
+                    break;
+                case "assistant":
+                    addMessage(messages, "assistant", getAssistantContent(message));
+                    break;
+                case "tool":
+                    ArrayNode toolResult = mapper.createArrayNode();
+                    ObjectNode result = toolResult.addObject();
+                    result.put("type", "tool_result");
+                    result.put("tool_use_id", message.path("tool_call_id").asText());
+                    result.put("content", getTextContent(message.get("content")));
+                    addMessage(messages, "user", toolResult);
+                    break;
+                default:
+                    addMessage(messages, "user", getUserContent(message.get("content")));
+            }
+        }
+        if (system.length() > 0) {
+            converted.put("system", system.toString());
+        }
+        converted.set("messages", messages);
+        converted.put("max_tokens", getMaxTokens(request, defaultMaxTokens));
+        for (String field : new String[]{"temperature", "top_p", "stream"}) {
+            if (request.has(field) && !request.get(field).isNull()) {
+                converted.set(field, request.get(field));
+            }
+        }
+        if (request.has("stop") && !request.get("stop").isNull()) {
+            converted.set("stop_sequences", getStopSequences(request.get("stop")));
+        }
+        if (request.path("user").isTextual()) {
+            converted.putObject("metadata").put("user_id", request.get("user").asText());
+        }
+        if (request.path("tools").isArray() && request.get("tools").size() > 0) {
+            ArrayNode tools = converted.putArray("tools");
+            for (JsonNode tool : request.get("tools")) {
+                JsonNode function = tool.path("function");
+                ObjectNode convertedTool = tools.addObject();
+                convertedTool.put("name", function.path("name").asText());
+                if (function.has("description")) {
+                    convertedTool.put("description", function.get("description").asText());
+                }
+                if (function.path("parameters").isObject()) {
+                    convertedTool.set("input_schema", function.get("parameters"));
+                } else {
+                    convertedTool.putObject("input_schema").put("type", "object");
+                }
+            }
+            if (request.has("tool_choice")) {
+                converted.set("tool_choice", getToolChoice(request.get("tool_choice")));
+            }
+        }
+        return converted;
+    }
+
+    @Override
+    ObjectNode toOpenAIResponse(JsonNode response, String model) {
+        ObjectNode message = mapper.createObjectNode();
+        message.put("role", "assistant");
+        StringBuilder text = new StringBuilder();
+        ArrayNode toolCalls = mapper.createArrayNode();
+        for (JsonNode block : response.path("content")) {
+            String type = block.path("type").asText();
+            if ("text".equals(type)) {
+                text.append(block.path("text").asText());
+            } else if ("tool_use".equals(type)) {
+                toolCalls.add(newToolCall(block.path("id").asText(), block.path("name").asText(),
+                        block.path("input")));
+            }
+        }
+        if (text.length() == 0 && toolCalls.size() > 0) {
+            message.putNull("content");
+        } else {
+            message.put("content", text.toString());
+        }
+        if (toolCalls.size() > 0) {
+            message.set("tool_calls", toolCalls);
+        }
+        JsonNode usage = response.path("usage");
+        return newCompletion(response.path("id").asText(newCompletionID()), response.path("model").asText(model),
+                message, toFinishReason(response.path("stop_reason").asText()),
+                usage.path("input_tokens").asInt(), usage.path("output_tokens").asInt());
+    }
+
+    @Override
+    ObjectNode toOpenAIError(JsonNode response) {
+        JsonNode error = response.path("error");
+        return newError(error.path("message").asText(response.toString()), error.path("type").asText("api_error"));
+    }
+
+    @Override
+    String getStreamContentType() {
+        return STREAM_CONTENT_TYPE;
+    }
+
+    @Override
+    StreamConverter newStreamConverter(String model) {
+        return new AnthropicStreamConverter(model);
+    }
+
+    @Override
+    String getRequestPath(JsonNode request) {
+        return MESSAGES_PATH;
+    }
+
+    @Override
+    void addRequestHeaders(ProviderRequest providerRequest, String authorization) {
+        providerRequest.setHeader(API_VERSION_HEADER, apiVersion);
+        // Anthropic accepts the API keys only in its own header, not as the bearer tokens OpenAI expects
+        if (authorization != null && authorization.toLowerCase().startsWith(BEARER_PREFIX)) {
+            providerRequest.setHeader(API_KEY_HEADER, authorization.substring(BEARER_PREFIX.length()).trim());
+            providerRequest.removeHeader(AUTHORIZATION_HEADER);
+        }
+    }
+
+    // toFinishReason maps a stop reason of Anthropic to the finish reason of OpenAI
+    static String toFinishReason(String stopReason) {
+        switch (stopReason) {
+            case "max_tokens":
+                return "length";
+            case "tool_use":
+                return "tool_calls";
+            case "refusal":
+                return "content_filter";
+            default:
+                return "stop";
+        }
+    }
+
+    private static ArrayNode getUserContent(JsonNode content) {
+        ArrayNode blocks = mapper.createArrayNode();
+        if (content == null || content.isNull()) {
+            return blocks;
+        }
+        if (!content.isArray()) {
+            blocks.addObject().put("type", "text").put("text", content.asText());
+            return blocks;
+        }
+        for (JsonNode part : content) {
+            String type = part.path("type").asText();
+            if ("text".equals(type)) {
+                blocks.addObject().put("type", "text").put("text", part.path("text").asText());
+            } else if ("image_url".equals(type)) {
+                String url = part.path("image_url").path("url").asText();
+                ObjectNode image = blocks.addObject();
+                image.put("type", "image");
+                ObjectNode source = image.putObject("source");
+                String[] dataURL = parseDataURL(url);
+                if (dataURL != null) {
+                    source.put("type", "base64");
+                    source.put("media_type", dataURL[0]);
+                    source.put("data", dataURL[1]);
+                } else {
+                    source.put("type", "url");
+                    source.put("url", url);
+                }
+            }
+        }
+        return blocks;
+    }
+
+    private static ArrayNode getAssistantContent(JsonNode message) {
+        ArrayNode blocks = mapper.createArrayNode();
+        String text = getTextContent(message.get("content"));
+        if (!text.isEmpty()) {
+            blocks.addObject().put("type", "text").put("text", text);
+        }
+        for (JsonNode toolCall : message.path("tool_calls")) {
+            ObjectNode toolUse = blocks.addObject();
+            toolUse.put("type", "tool_use");
+            toolUse.put("id", toolCall.path("id").asText());
+            toolUse.put("name", toolCall.path("function").path("name").asText());
+            toolUse.set("input", parseArguments(toolCall.path("function").path("arguments").asText()));
+        }
+        return blocks;
+    }
+
+    private static ObjectNode getToolChoice(JsonNode toolChoice) {
+        ObjectNode converted = mapper.createObjectNode();
+        if (toolChoice.isObject()) {
+            converted.put("type", "tool");
+            converted.put("name", toolChoice.path("function").path("name").asText());
+            return converted;
+        }
+        switch (toolChoice.asText()) {
+            case "required":
+                converted.put("type", "any");
+                break;
+            case "none":
+                converted.put("type", "none");
+                break;
+            default:
+                converted.put("type", "auto");
+        }
+        return converted;
+    }
+}
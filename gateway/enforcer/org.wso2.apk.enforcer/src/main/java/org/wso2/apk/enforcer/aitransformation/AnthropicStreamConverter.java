@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.aitransformation;
+
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.node.ObjectNode;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+
+import java.nio.charset.StandardCharsets;
+import java.util.HashMap;
+import java.util.Map;
+
+/**
+ * Converts a server-sent event stream of the Anthropic messages API to OpenAI chat completion chunks.
+ */
+class AnthropicStreamConverter extends StreamConverter {
+
+    private static final Logger logger = LogManager.getLogger(AnthropicStreamConverter.class);
+    private static final String DATA_FIELD = "data:";
+
+    // the content blocks of Anthropic are indexed together, while the tool calls of OpenAI are indexed separately
+    private final Map<Integer, Integer> toolCallIndexes = new HashMap<>();
+
+    AnthropicStreamConverter(String model) {
+        super(ProviderConverter.newCompletionID(), model);
+    }
+
+    @Override
+    protected int convertEvents(byte[] data, StringBuilder events) {
+        int consumed = 0;
+        int eventEnd;
+        while ((eventEnd = findEventEnd(data, consumed)) >= 0) {
+            String event = new String(data, consumed, eventEnd - consumed, StandardCharsets.UTF_8);
+            consumed = eventEnd;
+            for (String line : event.split("\r?\n")) {
+                line = line.trim();
+                if (line.startsWith(DATA_FIELD)) {
+                    convertEvent(line.substring(DATA_FIELD.length()).trim(), events);
+                }
+            }
+        }
+        return consumed;
+    }
+
+    private void convertEvent(String data, StringBuilder events) {
+        JsonNode event;
+        try {
+            event = mapper.readTree(data);
+        } catch (Exception e) {
+            logger.debug("Skipping an event of the Anthropic stream which is not a json.");
+            return;
+        }
+        ObjectNode delta = mapper.createObjectNode();
+        switch (event.path("type").asText()) {
+            case "message_start":
+                JsonNode message = event.path("message");
+                id = message.path("id").asText(id);
+                model = message.path("model").asText(model);
+                promptTokens = message.path("usage").path("input_tokens").asInt();
+                completionTokens = message.path("usage").path("output_tokens").asInt();
+                delta.put("role", "assistant");
+                delta.put("content", "");
+                addChunk(events, delta, null);
+                break;
+            case "content_block_start":
+                JsonNode block = event.path("content_block");
+                if ("tool_use".equals(block.path("type").asText())) {
+                    int toolCallIndex = toolCallIndexes.size();
+                    toolCallIndexes.put(event.path("index").asInt(), toolCallIndex);
+                    ObjectNode toolCall = ProviderConverter.newToolCall(block.path("id").asText(),
+                            block.path("name").asText(), null);
+                    toolCall.put("index", toolCallIndex);
+                    ((ObjectNode) toolCall.get("function")).put("arguments", "");
+                    delta.putArray("tool_calls").add(toolCall);
+                    addChunk(events, delta, null);
+                }
+                break;
+            case "content_block_delta":
+                JsonNode blockDelta = event.path("delta");
+                String deltaType = blockDelta.path("type").asText();
+                if ("text_delta".equals(deltaType)) {
+                    delta.put("content", blockDelta.path("text").asText());
+                    addChunk(events, delta, null);
+                } else if ("input_json_delta".equals(deltaType)) {
+                    Integer toolCallIndex = toolCallIndexes.get(event.path("index").asInt());
+                    if (toolCallIndex != null) {
+                        ObjectNode toolCall = delta.putArray("tool_calls").addObject();
+                        toolCall.put("index", toolCallIndex);
+                        toolCall.putObject("function").put("arguments", blockDelta.path("partial_json").asText());
+                        addChunk(events, delta, null);
+                    }
+                }
+                break;
+            case "message_delta":
+                if (event.path("usage").has("output_tokens")) {
+                    completionTokens = event.path("usage").path("output_tokens").asInt();
+                }
+                addChunk(events, delta, AnthropicConverter.toFinishReason(
+                        event.path("delta").path("stop_reason").asText()));
+                break;
+            case "message_stop":
+                addUsageChunk(events);
+                break;
+            case "error":
+                JsonNode error = event.path("error");
+                addEvent(events, ProviderConverter.newError(error.path("message").asText(),
+                        error.path("type").asText("api_error")));
+                break;
+            default:
+                // ping and content_block_stop events do not have an OpenAI counterpart
+        }
+    }
+
+    // findEventEnd returns the end of the first complete event from the given offset, including the blank line
+    // terminating it, or -1 if the event is not complete yet
+    private static int findEventEnd(byte[] data, int offset) {
+        for (int i = offset; i < data.length - 1; i++) {
+            if (data[i] == '\n' && data[i + 1] == '\n') {
+                return i + 2;
+            }
+            if (data[i] == '\n' && data[i + 1] == '\r' && i + 2 < data.length && data[i + 2] == '\n') {
+                return i + 3;
+            }
+        }
+        return -1;
+    }
+}
@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.aitransformation;
+
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.node.ArrayNode;
+import com.fasterxml.jackson.databind.node.ObjectNode;
+
+/**
+ * Converts the OpenAI chat completions requests to the Amazon Bedrock Converse format, and the Converse responses
+ * back to the OpenAI format. Bedrock selects the model and whether the response is streamed by the request path,
+ * hence the requests are sent to the converse or the converse-stream path of the requested model.
+ */
+class BedrockConverter extends ProviderConverter {
+
+    private static final String STREAM_CONTENT_TYPE = "application/vnd.amazon.eventstream";
+    private static final String MODEL_PATH = "/model/";
+    private static final String CONVERSE_PATH = "/converse";
+    private static final String CONVERSE_STREAM_PATH = "/converse-stream";
+
+    private final int defaultMaxTokens;
+
+    BedrockConverter(int defaultMaxTokens) {
+        this.defaultMaxTokens = defaultMaxTokens;
+    }
+
+    @Override
+    ObjectNode toProviderRequest(JsonNode request) {
+        ObjectNode converted = mapper.createObjectNode();
+        ArrayNode system = mapper.createArrayNode();
+        ArrayNode messages = mapper.createArrayNode();
+        for (JsonNode message : request.get("messages")) {
+            switch (message.path("role").asText()) {
+                case "system":
+                case "developer":
+                    system.addObject().put("text", getTextContent(message.get("content")));
+                    break;
+                case "assistant":
+                    addMessage(messages, "assistant", getAssistantContent(message));
+                    break;
+                case "tool":
+                    ArrayNode toolResult = mapper.createArrayNode();
+                    ObjectNode result = toolResult.addObject().putObject("toolResult");
+                    result.put("toolUseId", message.path("tool_call_id").asText());
+                    result.putArray("content").addObject().put("text", getTextContent(message.get("content")));
+                    addMessage(messages, "user", toolResult);
+                    break;
+                default:
+                    addMessage(messages, "user", getUserContent(message.get("content")));
+            }
+        }
+        converted.set("messages", messages);
+        if (system.size() > 0) {
+            converted.set("system", system);
+        }
+        ObjectNode inferenceConfig = converted.putObject("inferenceConfig");
+        inferenceConfig.put("maxTokens", getMaxTokens(request, defaultMaxTokens));
+        if (request.path("temperature").isNumber()) {
+            inferenceConfig.set("temperature", request.get("temperature"));
+        }
+        if (request.path("top_p").isNumber()) {
+            inferenceConfig.set("topP", request.get("top_p"));
+        }
+        if (request.has("stop") && !request.get("stop").isNull()) {
+            inferenceConfig.set("stopSequences", getStopSequences(request.get("stop")));
+        }
+        // Converse does not have a tool choice to disable the tools, hence the tools are not sent instead
+        boolean toolsDisabled = "none".equals(request.path("tool_choice").asText());
+        if (request.path("tools").isArray() && request.get("tools").size() > 0 && !toolsDisabled) {
+            ObjectNode toolConfig = converted.putObject("toolConfig");
+            ArrayNode tools = toolConfig.putArray("tools");
+            for (JsonNode tool : request.get("tools")) {
+                JsonNode function = tool.path("function");
+                ObjectNode toolSpec = tools.addObject().putObject("toolSpec");
+                toolSpec.put("name", function.path("name").asText());
+                if (function.has("description")) {
+                    toolSpec.put("description", function.get("description").asText());
+                }
+                if (function.path("parameters").isObject()) {
+                    toolSpec.putObject("inputSchema").set("json", function.get("parameters"));
+                } else {
+                    toolSpec.putObject("inputSchema").putObject("json").put("type", "object");
+                }
+            }
+            if (request.has("tool_choice")) {
+                toolConfig.set("toolChoice", getToolChoice(request.get("tool_choice")));
+            }
+        }
+        return converted;
+    }
+
+    @Override
+    ObjectNode toOpenAIResponse(JsonNode response, String model) {
+        ObjectNode message = mapper.createObjectNode();
+        message.put("role", "assistant");
+        StringBuilder text = new StringBuilder();
+        ArrayNode toolCalls = mapper.createArrayNode();
+        for (JsonNode block : response.path("output").path("message").path("content")) {
+            if (block.has("text")) {
+                text.append(block.get("text").asText());
+            } else if (block.has("toolUse")) {
+                JsonNode toolUse = block.get("toolUse");
+                toolCalls.add(newToolCall(toolUse.path("toolUseId").asText(), toolUse.path("name").asText(),
+                        toolUse.path("input")));
+            }
+        }
+        if (text.length() == 0 && toolCalls.size() > 0) {
+            message.putNull("content");
+        } else {
+            message.put("content", text.toString());
+        }
+        if (toolCalls.size() > 0) {
+            message.set("tool_calls", toolCalls);
+        }
+        JsonNode usage = response.path("usage");
+        return newCompletion(newCompletionID(), model, message, toFinishReason(response.path("stopReason").asText()),
+                usage.path("inputTokens").asInt(), usage.path("outputTokens").asInt());
+    }
+
+    @Override
+    ObjectNode toOpenAIError(JsonNode response) {
+        return newError(response.path("message").asText(response.toString()), "api_error");
+    }
+
+    @Override
+    String getStreamContentType() {
+        return STREAM_CONTENT_TYPE;
+    }
+
+    @Override
+    StreamConverter newStreamConverter(String model) {
+        return new BedrockStreamConverter(model);
+    }
+
+    @Override
+    String getRequestPath(JsonNode request) {
+        return MODEL_PATH + AWSSigner.uriEncode(request.path("model").asText())
+                + (request.path("stream").asBoolean(false) ? CONVERSE_STREAM_PATH : CONVERSE_PATH);
+    }
+
+    // toFinishReason maps a stop reason of Bedrock to the finish reason of OpenAI
+    static String toFinishReason(String stopReason) {
+        switch (stopReason) {
+            case "max_tokens":
+                return "length";
+            case "tool_use":
+                return "tool_calls";
+            case "guardrail_intervened":
+            case "content_filtered":
+                return "content_filter";
+            default:
+                return "stop";
+        }
+    }
+
+    private static ArrayNode getUserContent(JsonNode content) {
+        ArrayNode blocks = mapper.createArrayNode();
+        if (content == null || content.isNull()) {
+            return blocks;
+        }
+        if (!content.isArray()) {
+            blocks.addObject().put("text", content.asText());
+            return blocks;
+        }
+        for (JsonNode part : content) {
+            String type = part.path("type").asText();
+            if ("text".equals(type)) {
+                blocks.addObject().put("text", part.path("text").asText());
+            } else if ("image_url".equals(type)) {
+                // Converse accepts only the bytes of the images
+                String[] dataURL = parseDataURL(part.path("image_url").path("url").asText());
+                if (dataURL != null) {
+                    ObjectNode image = blocks.addObject().putObject("image");
+                    image.put("format", dataURL[0].substring(dataURL[0].indexOf('/') + 1));
+                    image.putObject("source").put("bytes", dataURL[1]);
+                }
+            }
+        }
+        return blocks;
+    }
+
+    private static ArrayNode getAssistantContent(JsonNode message) {
+        ArrayNode blocks = mapper.createArrayNode();
+        String text = getTextContent(message.get("content"));
+        if (!text.isEmpty()) {
+            blocks.addObject().put("text", text);
+        }
+        for (JsonNode toolCall : message.path("tool_calls")) {
+            ObjectNode toolUse = blocks.addObject().putObject("toolUse");
+            toolUse.put("toolUseId", toolCall.path("id").asText());
+            toolUse.put("name", toolCall.path("function").path("name").asText());
+            toolUse.set("input", parseArguments(toolCall.path("function").path("arguments").asText()));
+        }
+        return blocks;
+    }
+
+    private static ObjectNode getToolChoice(JsonNode toolChoice) {
+        ObjectNode converted = mapper.createObjectNode();
+        if (toolChoice.isObject()) {
+            converted.putObject("tool").put("name", toolChoice.path("function").path("name").asText());
+        } else if ("required".equals(toolChoice.asText())) {
+            converted.putObject("any");
+        } else {
+            converted.putObject("auto");
+        }
+        return converted;
+    }
+}
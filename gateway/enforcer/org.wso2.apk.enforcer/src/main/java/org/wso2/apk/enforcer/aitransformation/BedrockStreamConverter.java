@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.aitransformation;
+
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.node.ObjectNode;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+
+import java.nio.ByteBuffer;
+import java.nio.charset.StandardCharsets;
+import java.util.HashMap;
+import java.util.Map;
+
+/**
+ * Converts an Amazon event stream of the Bedrock ConverseStream API to OpenAI chat completion chunks. Each message
+ * of the event stream is framed by a prelude holding its length and the length of its headers, and is followed by
+ * a checksum. The type of the event is given by the headers and the event itself by the json payload.
+ */
+class BedrockStreamConverter extends StreamConverter {
+
+    private static final Logger logger = LogManager.getLogger(BedrockStreamConverter.class);
+    // total length, headers length and the checksum of them
+    private static final int PRELUDE_LENGTH = 12;
+    private static final int CHECKSUM_LENGTH = 4;
+    private static final String MESSAGE_TYPE_HEADER = ":message-type";
+    private static final String EVENT_TYPE_HEADER = ":event-type";
+    private static final String EXCEPTION_TYPE_HEADER = ":exception-type";
+
+    // the content blocks of Bedrock are indexed together, while the tool calls of OpenAI are indexed separately
+    private final Map<Integer, Integer> toolCallIndexes = new HashMap<>();
+
+    BedrockStreamConverter(String model) {
+        super(ProviderConverter.newCompletionID(), model);
+    }
+
+    @Override
+    protected int convertEvents(byte[] data, StringBuilder events) {
+        int consumed = 0;
+        while (data.length - consumed >= PRELUDE_LENGTH) {
+            ByteBuffer prelude = ByteBuffer.wrap(data, consumed, PRELUDE_LENGTH);
+            int totalLength = prelude.getInt();
+            int headersLength = prelude.getInt();
+            if (totalLength < PRELUDE_LENGTH + CHECKSUM_LENGTH || headersLength < 0
+                    || headersLength > totalLength - PRELUDE_LENGTH - CHECKSUM_LENGTH) {
+                logger.error("Invalid message in the Bedrock event stream. Hence the rest of the chunk is dropped.");
+                return data.length;
+            }
+            if (data.length - consumed < totalLength) {
+                break;
+            }
+            Map<String, String> headers = readHeaders(data, consumed + PRELUDE_LENGTH, headersLength);
+            int payloadOffset = consumed + PRELUDE_LENGTH + headersLength;
+            int payloadLength = totalLength - PRELUDE_LENGTH - headersLength - CHECKSUM_LENGTH;
+            convertEvent(headers, new String(data, payloadOffset, payloadLength, StandardCharsets.UTF_8), events);
+            consumed += totalLength;
+        }
+        return consumed;
+    }
+
+    private void convertEvent(Map<String, String> headers, String payload, StringBuilder events) {
+        JsonNode event;
+        try {
+            event = mapper.readTree(payload);
+        } catch (Exception e) {
+            logger.debug("Skipping an event of the Bedrock stream which is not a json.");
+            return;
+        }
+        if (!"event".equals(headers.getOrDefault(MESSAGE_TYPE_HEADER, "event"))) {
+            addEvent(events, ProviderConverter.newError(event.path("message").asText(payload),
+                    headers.getOrDefault(EXCEPTION_TYPE_HEADER, "api_error")));
+            return;
+        }
+        ObjectNode delta = mapper.createObjectNode();
+        switch (headers.getOrDefault(EVENT_TYPE_HEADER, "")) {
+            case "messageStart":
+                delta.put("role", "assistant");
+                delta.put("content", "");
+                addChunk(events, delta, null);
+                break;
+            case "contentBlockStart":
+                JsonNode toolUse = event.path("start").path("toolUse");
+                if (!toolUse.isMissingNode()) {
+                    int toolCallIndex = toolCallIndexes.size();
+                    toolCallIndexes.put(event.path("contentBlockIndex").asInt(), toolCallIndex);
+                    ObjectNode toolCall = ProviderConverter.newToolCall(toolUse.path("toolUseId").asText(),
+                            toolUse.path("name").asText(), null);
+                    toolCall.put("index", toolCallIndex);
+                    ((ObjectNode) toolCall.get("function")).put("arguments", "");
+                    delta.putArray("tool_calls").add(toolCall);
+                    addChunk(events, delta, null);
+                }
+                break;
+            case "contentBlockDelta":
+                JsonNode blockDelta = event.path("delta");
+                if (blockDelta.has("text")) {
+                    delta.put("content", blockDelta.get("text").asText());
+                    addChunk(events, delta, null);
+                } else if (blockDelta.has("toolUse")) {
+                    Integer toolCallIndex = toolCallIndexes.get(event.path("contentBlockIndex").asInt());
+                    if (toolCallIndex != null) {
+                        ObjectNode toolCall = delta.putArray("tool_calls").addObject();
+                        toolCall.put("index", toolCallIndex);
+                        toolCall.putObject("function").put("arguments",
+                                blockDelta.get("toolUse").path("input").asText());
+                        addChunk(events, delta, null);
+                    }
+                }
+                break;
+            case "messageStop":
+                addChunk(events, delta, BedrockConverter.toFinishReason(event.path("stopReason").asText()));
+                break;
+            case "metadata":
+                promptTokens = event.path("usage").path("inputTokens").asInt();
+                completionTokens = event.path("usage").path("outputTokens").asInt();
+                addUsageChunk(events);
+                break;
+            default:
+                // contentBlockStop events do not have an OpenAI counterpart
+        }
+    }
+
+    // readHeaders returns the string headers of a message. The values of the other header types are skipped.
+    private static Map<String, String> readHeaders(byte[] data, int offset, int length) {
+        Map<String, String> headers = new HashMap<>();
+        int position = offset;
+        int end = offset + length;
+        while (position < end) {
+            int nameLength = data[position++] & 0xff;
+            String name = new String(data, position, nameLength, StandardCharsets.UTF_8);
+            position += nameLength;
+            byte type = data[position++];
+            switch (type) {
+                case 0:
+                case 1:
+                    // boolean values are given by the type itself
+                    break;
+                case 2:
+                    position += 1;
+                    break;
+                case 3:
+                    position += 2;
+                    break;
+                case 4:
+                    position += 4;
+                    break;
+                case 5:
+                case 8:
+                    position += 8;
+                    break;
+                case 6:
+                case 7:
+                    int valueLength = ((data[position] & 0xff) << 8) | (data[position + 1] & 0xff);
+                    position += 2;
+                    if (type == 7) {
+                        headers.put(name, new String(data, position, valueLength, StandardCharsets.UTF_8));
+                    }
+                    position += valueLength;
+                    break;
+                case 9:
+                    position += 16;
+                    break;
+                default:
+                    logger.debug("Unknown header type in the Bedrock event stream: " + type);
+                    return headers;
+            }
+        }
+        return headers;
+    }
+}
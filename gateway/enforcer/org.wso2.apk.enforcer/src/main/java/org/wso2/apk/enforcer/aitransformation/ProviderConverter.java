@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.aitransformation;
+
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import com.fasterxml.jackson.databind.node.ArrayNode;
+import com.fasterxml.jackson.databind.node.ObjectNode;
+
+import java.util.UUID;
+
+/**
+ * Converts the OpenAI chat completions requests to the format of an AI provider, and the responses of the
+ * provider back to the OpenAI format.
+ */
+abstract class ProviderConverter {
+
+    protected static final ObjectMapper mapper = new ObjectMapper();
+
+    /**
+     * Converts the given OpenAI chat completions request to the request of the provider.
+     *
+     * @param request OpenAI chat completions request
+     * @return the request of the provider
+     */
+    abstract ObjectNode toProviderRequest(JsonNode request);
+
+    /**
+     * Converts the given successful response of the provider to an OpenAI chat completion.
+     *
+     * @param response response of the provider
+     * @param model    model requested by the client
+     * @return the OpenAI chat completion
+     */
+    abstract ObjectNode toOpenAIResponse(JsonNode response, String model);
+
+    /**
+     * Converts the given error response of the provider to an OpenAI error.
+     *
+     * @param response error response of the provider
+     * @return the OpenAI error
+     */
+    abstract ObjectNode toOpenAIError(JsonNode response);
+
+    /**
+     * Returns the content type of the streamed responses of the provider.
+     *
+     * @return the content type
+     */
+    abstract String getStreamContentType();
+
+    /**
+     * Returns a converter of a streamed response of the provider.
+     *
+     * @param model model requested by the client
+     * @return the stream converter
+     */
+    abstract StreamConverter newStreamConverter(String model);
+
+    /**
+     * Returns the path of the provider to which the given OpenAI chat completions request is sent.
+     *
+     * @param request OpenAI chat completions request
+     * @return the path, relative to the base path of the backend
+     */
+    abstract String getRequestPath(JsonNode request);
+
+    /**
+     * Sets the headers of the provider on the converted request. The credentials sent by the client or set for the
+     * backend are moved to the header the provider expects them in.
+     *
+     * @param providerRequest converted request
+     * @param authorization   authorization header of the request, or null if it is not set
+     */
+    void addRequestHeaders(ProviderRequest providerRequest, String authorization) {
+    }
+
+    // getTextContent returns the text of a message content, which is either a string or an array of content parts
+    static String getTextContent(JsonNode content) {
+        if (content == null || content.isNull()) {
+            return "";
+        }
+        if (!content.isArray()) {
+            return content.asText();
+        }
+        StringBuilder text = new StringBuilder();
+        for (JsonNode part : content) {
+            if ("text".equals(part.path("type").asText())) {
+                if (text.length() > 0) {
+                    text.append('\n');
+                }
+                text.append(part.path("text").asText());
+            }
+        }
+        return text.toString();
+    }
+
+    // parseArguments returns the json object of the arguments of a tool call, which OpenAI sends as a string
+    static JsonNode parseArguments(String arguments) {
+        try {
+            JsonNode input = mapper.readTree(arguments);
+            if (input != null && input.isObject()) {
+                return input;
+            }
+        } catch (Exception e) {
+            // the arguments generated by a model are not always a valid json
+        }
+        return mapper.createObjectNode();
+    }
+
+    // getStopSequences returns the stop sequences of a request, which OpenAI accepts as a string or an array
+    static ArrayNode getStopSequences(JsonNode stop) {
+        ArrayNode stopSequences = mapper.createArrayNode();
+        if (stop.isTextual()) {
+            stopSequences.add(stop.asText());
+        } else if (stop.isArray()) {
+            stop.forEach(sequence -> stopSequences.add(sequence.asText()));
+        }
+        return stopSequences;
+    }
+
+    // getMaxTokens returns the maximum tokens of a request, or the default when the request does not specify it
+    static int getMaxTokens(JsonNode request, int defaultMaxTokens) {
+        if (request.path("max_completion_tokens").isNumber()) {
+            return request.get("max_completion_tokens").asInt();
+        }
+        if (request.path("max_tokens").isNumber()) {
+            return request.get("max_tokens").asInt();
+        }
+        return defaultMaxTokens;
+    }
+
+    // parseDataURL returns the media type and the base64 data of a data url, or null if it is not a base64 data url
+    static String[] parseDataURL(String url) {
+        if (url == null || !url.startsWith("data:")) {
+            return null;
+        }
+        int separator = url.indexOf(";base64,");
+        if (separator < 0) {
+            return null;
+        }
+        return new String[]{url.substring("data:".length(), separator), url.substring(separator + ";base64,".length())};
+    }
+
+    // addMessage adds the content blocks as a message, merging them into the previous message of the same role as
+    // the providers expect the roles to alternate
+    static void addMessage(ArrayNode messages, String role, ArrayNode content) {
+        if (content.size() == 0) {
+            return;
+        }
+        if (messages.size() > 0 && role.equals(messages.get(messages.size() - 1).path("role").asText())) {
+            ((ArrayNode) messages.get(messages.size() - 1).get("content")).addAll(content);
+            return;
+        }
+        ObjectNode message = messages.addObject();
+        message.put("role", role);
+        message.set("content", content);
+    }
+
+    static String newCompletionID() {
+        return "chatcmpl-" + UUID.randomUUID().toString().replace("-", "");
+    }
+
+    static ObjectNode newCompletion(String id, String model, ObjectNode message, String finishReason,
+                                    int promptTokens, int completionTokens) {
+        ObjectNode completion = mapper.createObjectNode();
+        completion.put("id", id);
+        completion.put("object", "chat.completion");
+        completion.put("created", System.currentTimeMillis() / 1000);
+        completion.put("model", model);
+        ObjectNode choice = completion.putArray("choices").addObject();
+        choice.put("index", 0);
+        choice.set("message", message);
+        choice.put("finish_reason", finishReason);
+        completion.set("usage", newUsage(promptTokens, completionTokens));
+        return completion;
+    }
+
+    static ObjectNode newUsage(int promptTokens, int completionTokens) {
+        ObjectNode usage = mapper.createObjectNode();
+        usage.put("prompt_tokens", promptTokens);
+        usage.put("completion_tokens", completionTokens);
+        usage.put("total_tokens", promptTokens + completionTokens);
+        return usage;
+    }
+
+    static ObjectNode newToolCall(String id, String name, JsonNode input) {
+        ObjectNode toolCall = mapper.createObjectNode();
+        toolCall.put("id", id);
+        toolCall.put("type", "function");
+        ObjectNode function = toolCall.putObject("function");
+        function.put("name", name);
+        function.put("arguments", input == null || input.isMissingNode() ? "{}" : input.toString());
+        return toolCall;
+    }
+
+    static ObjectNode newError(String message, String type) {
+        ObjectNode error = mapper.createObjectNode();
+        ObjectNode errorDetails = error.putObject("error");
+        errorDetails.put("message", message);
+        errorDetails.put("type", type);
+        errorDetails.putNull("param");
+        errorDetails.putNull("code");
+        return error;
+    }
+}
@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.aitransformation;
+
+import java.util.ArrayList;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+
+/**
+ * Request converted to the format of an AI provider, with the headers to be set and removed for the provider.
+ * The path of the provider is set as the :path header.
+ */
+public class ProviderRequest {
+
+    private final String body;
+    private final Map<String, String> headers = new LinkedHashMap<>();
+    private final List<String> removeHeaders = new ArrayList<>();
+
+    ProviderRequest(String body) {
+        this.body = body;
+    }
+
+    public String getBody() {
+        return body;
+    }
+
+    /**
+     * Returns the headers to be set on the request sent to the provider.
+     *
+     * @return header names and values
+     */
+    public Map<String, String> getHeaders() {
+        return headers;
+    }
+
+    /**
+     * Returns the headers to be removed from the request sent to the provider.
+     *
+     * @return header names
+     */
+    public List<String> getRemoveHeaders() {
+        return removeHeaders;
+    }
+
+    void setHeader(String name, String value) {
+        removeHeaders.remove(name);
+        headers.put(name, value);
+    }
+
+    void removeHeader(String name) {
+        headers.remove(name);
+        if (!removeHeaders.contains(name)) {
+            removeHeaders.add(name);
+        }
+    }
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.aitransformation;
+
+import com.fasterxml.jackson.databind.ObjectMapper;
+import com.fasterxml.jackson.databind.node.ObjectNode;
+
+import java.io.ByteArrayOutputStream;
+
+/**
+ * Converts a streamed response of an AI provider to a server-sent event stream of OpenAI chat completion chunks,
+ * chunk by chunk. An event split across chunks is kept until the rest of it arrives with the next chunk. The
+ * token usage of the stream is collected while converting it.
+ */
+public abstract class StreamConverter {
+
+    protected static final ObjectMapper mapper = new ObjectMapper();
+    private static final String DONE_EVENT = "data: [DONE]\n\n";
+
+    private final ByteArrayOutputStream pendingBytes = new ByteArrayOutputStream();
+    private final long created = System.currentTimeMillis() / 1000;
+    protected String id;
+    protected String model;
+    protected int promptTokens;
+    protected int completionTokens;
+
+    protected StreamConverter(String id, String model) {
+        this.id = id;
+        this.model = model == null ? "" : model;
+    }
+
+    /**
+     * Converts the complete events of the given chunk.
+     *
+     * @param chunk a chunk of the response body of the provider
+     * @return the OpenAI server-sent events converted from the chunk, which may be empty
+     */
+    public String convert(byte[] chunk) {
+        pendingBytes.write(chunk, 0, chunk.length);
+        byte[] data = pendingBytes.toByteArray();
+        StringBuilder events = new StringBuilder();
+        int consumed = convertEvents(data, events);
+        pendingBytes.reset();
+        pendingBytes.write(data, consumed, data.length - consumed);
+        return events.toString();
+    }
+
+    /**
+     * Returns the event terminating the OpenAI stream.
+     *
+     * @return the terminating event
+     */
+    public String finish() {
+        return DONE_EVENT;
+    }
+
+    /**
+     * Converts the complete events at the beginning of the given data.
+     *
+     * @param data   pending data of the stream
+     * @param events OpenAI server-sent events to which the converted events are appended
+     * @return the number of bytes of the converted events
+     */
+    protected abstract int convertEvents(byte[] data, StringBuilder events);
+
+    protected void addChunk(StringBuilder events, ObjectNode delta, String finishReason) {
+        ObjectNode chunk = newChunk();
+        ObjectNode choice = chunk.putArray("choices").addObject();
+        choice.put("index", 0);
+        choice.set("delta", delta);
+        if (finishReason == null) {
+            choice.putNull("finish_reason");
+        } else {
+            choice.put("finish_reason", finishReason);
+        }
+        addEvent(events, chunk);
+    }
+
+    protected void addUsageChunk(StringBuilder events) {
+        ObjectNode chunk = newChunk();
+        chunk.putArray("choices");
+        chunk.set("usage", ProviderConverter.newUsage(promptTokens, completionTokens));
+        addEvent(events, chunk);
+    }
+
+    protected void addEvent(StringBuilder events, ObjectNode event) {
+        events.append("data: ").append(event.toString()).append("\n\n");
+    }
+
+    private ObjectNode newChunk() {
+        ObjectNode chunk = mapper.createObjectNode();
+        chunk.put("id", id);
+        chunk.put("object", "chat.completion.chunk");
+        chunk.put("created", created);
+        chunk.put("model", model);
+        return chunk;
+    }
+
+    public int getPromptTokens() {
+        return promptTokens;
+    }
+
+    public int getCompletionTokens() {
+        return completionTokens;
+    }
+
+    public String getModel() {
+        return model;
+    }
+}
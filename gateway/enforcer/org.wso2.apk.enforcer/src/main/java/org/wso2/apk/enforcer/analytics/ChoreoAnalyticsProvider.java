@@ -287,6 +287,27 @@ public class ChoreoAnalyticsProvider implements AnalyticsDataProvider {
         if (aiMetadata.getModel() != null || aiMetadata.getVendorName() != null || aiMetadata.getVendorVersion() != null) {
             map.put("aiMetadata", aiMetadata);
         }
+
+        // AI Guardrails
+        Map<String, String> aiGuardrail = new HashMap<>();
+        String guardrailViolation = getValueAsString(fieldsMap, MetadataConstants.AI_GUARDRAIL_VIOLATION);
+        if (guardrailViolation != null) {
+            aiGuardrail.put("violation", guardrailViolation);
+            aiGuardrail.put("direction", getValueAsString(fieldsMap, MetadataConstants.AI_GUARDRAIL_DIRECTION));
+        }
+        String promptRedactions = getValueAsString(fieldsMap, MetadataConstants.AI_GUARDRAIL_PROMPT_REDACTIONS);
+        if (promptRedactions != null) aiGuardrail.put("promptRedactions", promptRedactions);
+        String responseRedactions = getValueAsString(fieldsMap, MetadataConstants.AI_GUARDRAIL_RESPONSE_REDACTIONS);
+        if (responseRedactions != null) aiGuardrail.put("responseRedactions", responseRedactions);
+        if (!aiGuardrail.isEmpty()) {
+            map.put("aiGuardrail", aiGuardrail);
+        }
+
+        // AI Response Cache
+        String aiCacheStatus = getValueAsString(fieldsMap, MetadataConstants.AI_CACHE_STATUS);
+        if (aiCacheStatus != null) {
+            map.put("aiCacheStatus", aiCacheStatus);
+        }
         map.put(AnalyticsConstants.GATEWAY_URL, gwURL);
         if (customDataProvider != null && customDataProvider.getCustomProperties(customProperties) != null) {
             Map<String, Object> customPropertiesFromProvider = customDataProvider.getCustomProperties(customProperties);
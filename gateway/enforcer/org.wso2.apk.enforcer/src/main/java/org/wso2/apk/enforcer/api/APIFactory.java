@@ -129,6 +129,17 @@ public class APIFactory {
         return apis.get(apiKey);
     }
 
+    /**
+     * Returns the deployed API with the given UUID.
+     *
+     * @param apiUUID UUID of the API
+     * @return the API, or null if the API is not deployed
+     */
+    public API getAPI(String apiUUID) {
+        return apis.values().stream().filter(api -> apiUUID.equals(api.getAPIConfig().getUuid())).findFirst()
+                .orElse(null);
+    }
+
     public byte[] getAPIDefinition(final String basePath, final String version, final String vHost) {
         String apiKey = getApiKey(vHost, basePath, version);
         API api = apis.get(apiKey);
@@ -140,8 +140,8 @@ public class GRPCAPI implements API {
                     APIConstants.NOT_FOUND_DESCRIPTION);
         }
 
-        if ((isExistsMatchedOperations || isOptionCall) && executeFilterChain(requestContext)) {
-            EndpointUtils.updateClusterHeaderAndCheckEnv(requestContext);
+        if ((isExistsMatchedOperations || isOptionCall) && executeFilterChain(requestContext)
+                && EndpointUtils.updateClusterHeaderAndCheckEnv(requestContext)) {
             responseObject.setOrganizationId(requestContext.getMatchedAPI().getOrganizationId());
             responseObject.setRemoveHeaderMap(requestContext.getRemoveHeaders());
             responseObject.setQueryParamsToRemove(requestContext.getQueryParamsToRemove());
@@ -36,6 +36,7 @@ import org.wso2.apk.enforcer.discovery.api.Operation;
 import org.wso2.apk.enforcer.discovery.api.Resource;
 import org.wso2.apk.enforcer.analytics.AnalyticsFilter;
 import org.wso2.apk.enforcer.commons.Filter;
+import org.wso2.apk.enforcer.commons.constants.GraphQLConstants;
 import org.wso2.apk.enforcer.commons.model.APIConfig;
 import org.wso2.apk.enforcer.commons.model.EndpointCluster;
 import org.wso2.apk.enforcer.commons.model.EndpointSecurity;
@@ -179,8 +180,8 @@ public class GraphQLAPI implements API {
                     APIConstants.NOT_FOUND_DESCRIPTION);
         }
 
-        if ((isExistsMatchedOperations || isOptionCall) && executeFilterChain(requestContext)) {
-            EndpointUtils.updateClusterHeaderAndCheckEnv(requestContext);
+        if ((isExistsMatchedOperations || isOptionCall) && executeFilterChain(requestContext)
+                && EndpointUtils.updateClusterHeaderAndCheckEnv(requestContext)) {
             responseObject.setOrganizationId(requestContext.getMatchedAPI().getOrganizationId());
             responseObject.setRemoveHeaderMap(requestContext.getRemoveHeaders());
             responseObject.setQueryParamsToRemove(requestContext.getQueryParamsToRemove());
@@ -213,6 +214,9 @@ public class GraphQLAPI implements API {
                 responseObject.setErrorDescription(requestContext.getProperties()
                         .get(APIConstants.MessageFormat.ERROR_DESCRIPTION).toString());
             }
+            // the queries blocked by the query analysis are answered with the errors object of a GraphQL response
+            responseObject.setGraphQLErrorResponse(
+                    requestContext.getProperties().containsKey(GraphQLConstants.GRAPHQL_ERROR_RESPONSE));
             if (requestContext.getAddHeaders() != null && requestContext.getAddHeaders().size() > 0) {
                 responseObject.setHeaderMap(requestContext.getAddHeaders());
             }
@@ -20,6 +20,7 @@ package org.wso2.apk.enforcer.api;
 
 import java.util.ArrayList;
 import java.util.HashMap;
+import java.util.List;
 import java.util.Map;
 
 /**
@@ -31,6 +32,8 @@ public class ResponseObject {
     private String errorCode;
     private String errorMessage;
     private String errorDescription;
+    private List<Map<String, String>> errorDetails;
+    private boolean graphQLErrorResponse = false;
     private Map<String, String> headerMap = new HashMap<>();
     private ArrayList<String> removeHeaderMap = new ArrayList<>();
     private Map<String, String> metaDataMap;
@@ -106,6 +109,22 @@ public class ResponseObject {
         this.errorDescription = errorDescription;
     }
 
+    public List<Map<String, String>> getErrorDetails() {
+        return errorDetails;
+    }
+
+    public void setErrorDetails(List<Map<String, String>> errorDetails) {
+        this.errorDetails = errorDetails;
+    }
+
+    public boolean isGraphQLErrorResponse() {
+        return graphQLErrorResponse;
+    }
+
+    public void setGraphQLErrorResponse(boolean graphQLErrorResponse) {
+        this.graphQLErrorResponse = graphQLErrorResponse;
+    }
+
     public boolean isDirectResponse() {
         return isDirectResponse;
     }
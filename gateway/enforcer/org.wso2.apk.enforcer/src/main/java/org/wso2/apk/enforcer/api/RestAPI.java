@@ -34,8 +34,11 @@ import org.wso2.apk.enforcer.constants.HttpConstants;
 import org.wso2.apk.enforcer.cors.CorsFilter;
 import org.wso2.apk.enforcer.discovery.api.*;
 import org.wso2.apk.enforcer.interceptor.MediationPolicyFilter;
+import org.wso2.apk.enforcer.schemavalidation.SchemaValidationFilter;
 import org.wso2.apk.enforcer.security.AuthFilter;
+import org.wso2.apk.enforcer.security.authorization.AuthorizationFilter;
 import org.wso2.apk.enforcer.security.mtls.MtlsUtils;
+import org.wso2.apk.enforcer.tokenbudget.TokenBudgetFilter;
 import org.wso2.apk.enforcer.util.EndpointUtils;
 import org.wso2.apk.enforcer.util.FilterUtils;
 import org.wso2.apk.enforcer.util.MockImplUtils;
@@ -167,8 +170,8 @@ public class RestAPI implements API {
             requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_DESCRIPTION,
                     APIConstants.NOT_FOUND_DESCRIPTION);
         }
-        if ((isExistsMatchedResourcePath || isOptionCall) && executeFilterChain(requestContext)) {
-            EndpointUtils.updateClusterHeaderAndCheckEnv(requestContext);
+        if ((isExistsMatchedResourcePath || isOptionCall) && executeFilterChain(requestContext)
+                && EndpointUtils.updateClusterHeaderAndCheckEnv(requestContext)) {
             responseObject.setOrganizationId(requestContext.getMatchedAPI().getOrganizationId());
             responseObject.setRemoveHeaderMap(requestContext.getRemoveHeaders());
             responseObject.setQueryParamsToRemove(requestContext.getQueryParamsToRemove());
@@ -205,6 +208,10 @@ public class RestAPI implements API {
                 responseObject.setErrorDescription(requestContext.getProperties()
                         .get(APIConstants.MessageFormat.ERROR_DESCRIPTION).toString());
             }
+            if (requestContext.getProperties().get(APIConstants.MessageFormat.ERROR_DETAILS) != null) {
+                responseObject.setErrorDetails((List<Map<String, String>>) requestContext.getProperties()
+                        .get(APIConstants.MessageFormat.ERROR_DETAILS));
+            }
             if (requestContext.getAddHeaders() != null && requestContext.getAddHeaders().size() > 0) {
                 responseObject.setHeaderMap(requestContext.getAddHeaders());
             }
@@ -228,6 +235,14 @@ public class RestAPI implements API {
         AuthFilter authFilter = new AuthFilter();
         authFilter.init(apiConfig, null);
         this.filters.add(authFilter);
+        this.filters.add(new AuthorizationFilter());
+
+        if (apiConfig.getAiProvider() != null && Boolean.TRUE.equals(apiConfig.getAiProvider().getEnabled())) {
+            this.filters.add(new TokenBudgetFilter());
+        }
+
+        // the requests are validated after the authentication, so the unauthenticated requests are not inspected
+        this.filters.add(new SchemaValidationFilter());
 
         if (!apiConfig.isSystemAPI()) {
             MediationPolicyFilter mediationPolicyFilter = new MediationPolicyFilter();
@@ -19,11 +19,15 @@ package org.wso2.apk.enforcer.api;
 
 import org.wso2.apk.enforcer.commons.model.APIKeyAuthenticationConfig;
 import org.wso2.apk.enforcer.commons.model.AuthenticationConfig;
+import org.wso2.apk.enforcer.commons.model.AuthorizationRuleConfig;
+import org.wso2.apk.enforcer.commons.model.HMACAuthenticationConfig;
 import org.wso2.apk.enforcer.commons.model.JWTAuthenticationConfig;
 import org.wso2.apk.enforcer.commons.model.Oauth2AuthenticationConfig;
 import org.wso2.apk.enforcer.config.EnforcerConfig;
 import org.wso2.apk.enforcer.discovery.api.APIKey;
+import org.wso2.apk.enforcer.discovery.api.AuthorizationRule;
 import org.wso2.apk.enforcer.discovery.api.EndpointClusterConfig;
+import org.wso2.apk.enforcer.discovery.api.HMAC;
 import org.wso2.apk.enforcer.discovery.api.Operation;
 import org.wso2.apk.enforcer.discovery.api.OperationPolicies;
 import org.wso2.apk.enforcer.commons.model.EndpointCluster;
@@ -38,6 +42,7 @@ import org.wso2.apk.enforcer.constants.APIConstants;
 import org.wso2.apk.enforcer.constants.AdapterConstants;
 
 import java.util.ArrayList;
+import java.util.HashMap;
 import java.util.List;
 
 /**
@@ -116,12 +121,29 @@ public class Utils {
             }
             List<APIKeyAuthenticationConfig> apiKeyAuthenticationConfigs = getApiKeyAuthenticationConfigs(operation);
             authenticationConfig.setApiKeyAuthenticationConfigs(apiKeyAuthenticationConfigs);
+            if (operation.getApiAuthentication().hasHmac()) {
+                authenticationConfig.setHmacAuthenticationConfig(getHMACAuthenticationConfig(operation));
+            }
         }
         resource.setAuthenticationConfig(authenticationConfig);
         resource.setScopes(operation.getScopesList().toArray(new String[0]));
+        resource.setAuthorizationRules(getAuthorizationRules(operation));
         return resource;
     }
 
+    private static List<AuthorizationRuleConfig> getAuthorizationRules(Operation operation) {
+        List<AuthorizationRuleConfig> authorizationRules = new ArrayList<>();
+        for (AuthorizationRule authorizationRule : operation.getAuthorizationRulesList()) {
+            AuthorizationRuleConfig authorizationRuleConfig = new AuthorizationRuleConfig();
+            authorizationRuleConfig.setName(authorizationRule.getName());
+            authorizationRuleConfig.setLanguage(authorizationRule.getLanguage());
+            authorizationRuleConfig.setExpression(authorizationRule.getExpression());
+            authorizationRuleConfig.setMessage(authorizationRule.getMessage());
+            authorizationRules.add(authorizationRuleConfig);
+        }
+        return authorizationRules;
+    }
+
     private static List<APIKeyAuthenticationConfig> getApiKeyAuthenticationConfigs(Operation operation) {
         List<APIKeyAuthenticationConfig> apiKeyAuthenticationConfigs = new ArrayList<>();
         for (APIKey apiKey : operation.getApiAuthentication().getApikeyList()) {
@@ -134,6 +156,18 @@ public class Utils {
         return apiKeyAuthenticationConfigs;
     }
 
+    private static HMACAuthenticationConfig getHMACAuthenticationConfig(Operation operation) {
+        HMAC hmac = operation.getApiAuthentication().getHmac();
+        HMACAuthenticationConfig hmacAuthenticationConfig = new HMACAuthenticationConfig();
+        hmacAuthenticationConfig.setHeader(hmac.getHeader());
+        hmacAuthenticationConfig.setKeys(new HashMap<>(hmac.getKeysMap()));
+        hmacAuthenticationConfig.setAlgorithms(new ArrayList<>(hmac.getAlgorithmsList()));
+        hmacAuthenticationConfig.setSignedHeaders(new ArrayList<>(hmac.getSignedHeadersList()));
+        hmacAuthenticationConfig.setClockSkew(hmac.getClockSkew());
+        hmacAuthenticationConfig.setSendTokenToUpstream(hmac.getSendTokenToUpstream());
+        return hmacAuthenticationConfig;
+    }
+
     private static JWTAuthenticationConfig getJwtAuthenticationConfig(Operation operation) {
         JWTAuthenticationConfig jwtAuthenticationConfig = new JWTAuthenticationConfig();
         jwtAuthenticationConfig.setHeader(operation.getApiAuthentication().getJwt().getHeader());
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.bodytransformation;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+
+import java.nio.charset.StandardCharsets;
+import java.util.Base64;
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+
+/**
+ * Body transformations of an operation of a REST API, which transform the payloads of the requests before they are
+ * sent to the backend and the payloads of the successful responses before they are sent to the client. The
+ * configurations are received from the adapter as base64 encoded json in the route metadata.
+ */
+public class BodyTransformation {
+
+    private static final Logger logger = LogManager.getLogger(BodyTransformation.class);
+    private static final ObjectMapper mapper = new ObjectMapper();
+    private static final Map<String, BodyTransformation> transformations = new ConcurrentHashMap<>();
+
+    private final BodyTransformer request;
+    private final BodyTransformer response;
+
+    private BodyTransformation(BodyTransformer request, BodyTransformer response) {
+        this.request = request;
+        this.response = response;
+    }
+
+    /**
+     * Returns the body transformations of the given configurations.
+     *
+     * @param encodedConfig base64 encoded json of the body transformations
+     * @return the body transformations, or null if the configurations could not be read
+     */
+    public static BodyTransformation fromEncodedConfig(String encodedConfig) {
+        if (encodedConfig == null || encodedConfig.isEmpty()) {
+            return null;
+        }
+        BodyTransformation transformation = transformations.get(encodedConfig);
+        if (transformation != null) {
+            return transformation;
+        }
+        try {
+            String configJson = new String(Base64.getDecoder().decode(encodedConfig), StandardCharsets.UTF_8);
+            TransformationConfig config = mapper.readValue(configJson, TransformationConfig.class);
+            transformation = new BodyTransformation(
+                    config.request != null ? new BodyTransformer(config.request) : null,
+                    config.response != null ? new BodyTransformer(config.response) : null);
+            transformations.put(encodedConfig, transformation);
+            return transformation;
+        } catch (Exception e) {
+            logger.error("Error while reading the body transformations of the route. " + e);
+            return null;
+        }
+    }
+
+    /**
+     * Returns the transformer of the payloads of the requests, or null if they are not transformed.
+     */
+    public BodyTransformer getRequest() {
+        return request;
+    }
+
+    /**
+     * Returns the transformer of the payloads of the responses, or null if they are not transformed.
+     */
+    public BodyTransformer getResponse() {
+        return response;
+    }
+
+    /**
+     * Configurations of the body transformations, received from the adapter.
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    static class TransformationConfig {
+        public BodyTransformer.TransformerConfig request;
+        public BodyTransformer.TransformerConfig response;
+    }
+}
@@ -0,0 +1,205 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.bodytransformation;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import org.json.JSONArray;
+import org.json.JSONException;
+import org.json.JSONObject;
+import org.json.JSONTokener;
+import org.json.XML;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+
+/**
+ * Transforms the payloads of a direction of an operation. The steps are applied in the order of, the conversion from
+ * XML, the removal, the renaming and the addition of the fields, the template and the conversion to XML.
+ */
+public class BodyTransformer {
+
+    static final String JSON_TO_XML = "JSONToXML";
+    static final String XML_TO_JSON = "XMLToJSON";
+
+    private final String conversion;
+    private final String xmlRootElement;
+    private final List<JsonPath> removeFields = new ArrayList<>();
+    private final List<JsonPath> renameFields = new ArrayList<>();
+    private final List<String> renameFieldNames = new ArrayList<>();
+    private final List<JsonPath> addFields = new ArrayList<>();
+    private final List<Object> addFieldValues = new ArrayList<>();
+    private final PayloadTemplate template;
+    private final Map<String, String> headers;
+
+    BodyTransformer(TransformerConfig config) throws BodyTransformationException {
+        conversion = config.conversion;
+        xmlRootElement = config.xmlRootElement != null ? config.xmlRootElement : "root";
+        if (JSON_TO_XML.equals(conversion)) {
+            XMLSerializer.validateElementName(xmlRootElement);
+        }
+        if (config.removeFields != null) {
+            for (String path : config.removeFields) {
+                removeFields.add(JsonPath.parse(path));
+            }
+        }
+        if (config.renameFields != null) {
+            for (FieldRenameConfig renameField : config.renameFields) {
+                JsonPath path = JsonPath.parse(renameField.from);
+                if (!path.endsWithField()) {
+                    throw new BodyTransformationException("Only the fields can be renamed. JSONPath: " + path);
+                }
+                renameFields.add(path);
+                renameFieldNames.add(renameField.to);
+            }
+        }
+        if (config.addFields != null) {
+            for (FieldConfig addField : config.addFields) {
+                addFields.add(JsonPath.parse(addField.path));
+                addFieldValues.add(parseValue(addField.value));
+            }
+        }
+        // the templates rendering XML payloads escape the values of the payload
+        boolean xmlTemplate = config.contentType != null && config.contentType.toLowerCase().contains("xml")
+                && !JSON_TO_XML.equals(conversion);
+        if (config.template == null || config.template.isEmpty()) {
+            template = null;
+        } else {
+            template = xmlTemplate ? PayloadTemplate.parseXml(config.template) : PayloadTemplate.parse(config.template);
+        }
+        headers = config.contentType != null && !config.contentType.isEmpty()
+                ? Map.of("content-type", config.contentType) : Map.of();
+    }
+
+    /**
+     * Transforms the given payload.
+     *
+     * @param body payload of the request or the response
+     * @return the transformed payload
+     * @throws BodyTransformationException if the payload is not in the expected format, or the template fails
+     */
+    public String transform(String body) throws BodyTransformationException {
+        Object payload;
+        try {
+            payload = XML_TO_JSON.equals(conversion) ? XML.toJSONObject(body) : parseJson(body);
+        } catch (JSONException e) {
+            throw new BodyTransformationException("The payload could not be parsed", e);
+        }
+        for (JsonPath path : removeFields) {
+            path.remove(payload);
+        }
+        for (int i = 0; i < renameFields.size(); i++) {
+            renameFields.get(i).rename(payload, renameFieldNames.get(i));
+        }
+        for (int i = 0; i < addFields.size(); i++) {
+            addFields.get(i).set(payload, copyValue(addFieldValues.get(i)));
+        }
+        if (template != null) {
+            String rewrittenBody = template.execute(payload);
+            if (!JSON_TO_XML.equals(conversion)) {
+                return rewrittenBody;
+            }
+            try {
+                payload = parseJson(rewrittenBody);
+            } catch (JSONException e) {
+                throw new BodyTransformationException("The payload rewritten by the template is not JSON", e);
+            }
+        }
+        if (JSON_TO_XML.equals(conversion)) {
+            return XMLSerializer.toXml(payload, xmlRootElement);
+        }
+        return payload instanceof JSONObject || payload instanceof JSONArray ? payload.toString()
+                : JSONObject.valueToString(payload);
+    }
+
+    /**
+     * Returns the headers to be set on the transformed payloads, which is the content type of the transformed
+     * payloads if it changes.
+     */
+    public Map<String, String> getHeaders() {
+        return headers;
+    }
+
+    /**
+     * Returns the content type of the transformed payloads, or null if it does not change.
+     */
+    public String getContentType() {
+        return headers.get("content-type");
+    }
+
+    static Object parseJson(String body) throws JSONException {
+        JSONTokener tokener = new JSONTokener(body);
+        Object payload = tokener.nextValue();
+        if (tokener.nextClean() != 0) {
+            throw new JSONException("Unexpected content after the JSON payload");
+        }
+        return payload;
+    }
+
+    // parseValue returns the JSON value of a field to be added, or the value as a string if it is not JSON
+    private static Object parseValue(String value) {
+        if (value == null) {
+            return JSONObject.NULL;
+        }
+        try {
+            return parseJson(value);
+        } catch (JSONException e) {
+            return value;
+        }
+    }
+
+    // copyValue returns a copy of the objects and the arrays, as an added value is shared by the payloads
+    private static Object copyValue(Object value) {
+        if (value instanceof JSONObject || value instanceof JSONArray) {
+            return parseJson(value.toString());
+        }
+        return value;
+    }
+
+    /**
+     * Configurations of the transformation of a direction, received from the adapter.
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    static class TransformerConfig {
+        public String conversion;
+        public String xmlRootElement;
+        public List<FieldConfig> addFields;
+        public List<String> removeFields;
+        public List<FieldRenameConfig> renameFields;
+        public String template;
+        public String contentType;
+    }
+
+    /**
+     * Value to be set at a JSONPath of a payload.
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    static class FieldConfig {
+        public String path;
+        public String value;
+    }
+
+    /**
+     * Field of a payload to be renamed.
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    static class FieldRenameConfig {
+        public String from;
+        public String to;
+    }
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.bodytransformation;
+
+import org.json.JSONArray;
+import org.json.JSONObject;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.regex.Matcher;
+import java.util.regex.Pattern;
+
+/**
+ * JSONPath of the fields of a payload parsed by org.json. The paths are made of the child fields, the array indices
+ * and the wildcards of the arrays, such as $.order.items[*].id, which is the subset validated by the APIPolicy
+ * webhook.
+ */
+class JsonPath {
+
+    private static final Object WILDCARD = new Object();
+    private static final Pattern SEGMENT_PATTERN =
+            Pattern.compile("\\.([A-Za-z_][A-Za-z0-9_-]*)|\\['([^']+)']|\\[([0-9]+)]|\\[(\\*)]");
+
+    private final String path;
+    // each segment is the name of a field, the index of an array element or the wildcard
+    private final List<Object> segments;
+
+    private JsonPath(String path, List<Object> segments) {
+        this.path = path;
+        this.segments = segments;
+    }
+
+    /**
+     * Parses the given JSONPath.
+     *
+     * @param path JSONPath starting from the root of the payload
+     * @return the parsed path
+     * @throws BodyTransformationException if the path is not supported
+     */
+    static JsonPath parse(String path) throws BodyTransformationException {
+        if (path == null || path.length() < 2 || path.charAt(0) != '$') {
+            throw new BodyTransformationException("Invalid JSONPath " + path);
+        }
+        List<Object> segments = new ArrayList<>();
+        Matcher matcher = SEGMENT_PATTERN.matcher(path);
+        int position = 1;
+        while (position < path.length()) {
+            matcher.region(position, path.length());
+            if (!matcher.lookingAt()) {
+                throw new BodyTransformationException("Invalid JSONPath " + path);
+            }
+            if (matcher.group(1) != null) {
+                segments.add(matcher.group(1));
+            } else if (matcher.group(2) != null) {
+                segments.add(matcher.group(2));
+            } else if (matcher.group(3) != null) {
+                segments.add(Integer.parseInt(matcher.group(3)));
+            } else {
+                segments.add(WILDCARD);
+            }
+            position = matcher.end();
+        }
+        return new JsonPath(path, segments);
+    }
+
+    /**
+     * Returns whether the path ends with the name of a field, which is required to rename the field.
+     */
+    boolean endsWithField() {
+        return segments.get(segments.size() - 1) instanceof String;
+    }
+
+    /**
+     * Removes the fields at the path from the given payload.
+     */
+    void remove(Object payload) {
+        Object lastSegment = segments.get(segments.size() - 1);
+        for (Object parent : getParents(payload, false)) {
+            if (lastSegment instanceof String field && parent instanceof JSONObject object) {
+                object.remove(field);
+            } else if (lastSegment instanceof Integer index && parent instanceof JSONArray array) {
+                if (index < array.length()) {
+                    array.remove(index);
+                }
+            } else if (lastSegment == WILDCARD && parent instanceof JSONArray array) {
+                array.clear();
+            } else if (lastSegment == WILDCARD && parent instanceof JSONObject object) {
+                object.clear();
+            }
+        }
+    }
+
+    /**
+     * Renames the fields at the path of the given payload to the given name. The renamed fields stay in the same
+     * object.
+     */
+    void rename(Object payload, String name) {
+        String field = (String) segments.get(segments.size() - 1);
+        for (Object parent : getParents(payload, false)) {
+            if (parent instanceof JSONObject object && object.has(field)) {
+                object.put(name, object.remove(field));
+            }
+        }
+    }
+
+    /**
+     * Sets the given value at the path of the given payload, creating the missing objects and arrays on the way.
+     */
+    void set(Object payload, Object value) {
+        Object lastSegment = segments.get(segments.size() - 1);
+        for (Object parent : getParents(payload, true)) {
+            if (lastSegment instanceof String field && parent instanceof JSONObject object) {
+                object.put(field, value);
+            } else if (lastSegment instanceof Integer index && parent instanceof JSONArray array) {
+                array.put(index, value);
+            } else if (lastSegment == WILDCARD && parent instanceof JSONArray array) {
+                for (int i = 0; i < array.length(); i++) {
+                    array.put(i, value);
+                }
+            } else if (lastSegment == WILDCARD && parent instanceof JSONObject object) {
+                for (String key : new ArrayList<>(object.keySet())) {
+                    object.put(key, value);
+                }
+            }
+        }
+    }
+
+    // getParents returns the objects and the arrays holding the fields at the path
+    private List<Object> getParents(Object payload, boolean create) {
+        List<Object> parents = new ArrayList<>();
+        collectParents(payload, 0, create, parents);
+        return parents;
+    }
+
+    private void collectParents(Object node, int index, boolean create, List<Object> parents) {
+        if (index == segments.size() - 1) {
+            parents.add(node);
+            return;
+        }
+        Object segment = segments.get(index);
+        if (segment == WILDCARD) {
+            if (node instanceof JSONArray array) {
+                for (int i = 0; i < array.length(); i++) {
+                    collectParents(array.get(i), index + 1, create, parents);
+                }
+            } else if (node instanceof JSONObject object) {
+                for (String key : object.keySet()) {
+                    collectParents(object.get(key), index + 1, create, parents);
+                }
+            }
+            return;
+        }
+        Object child = null;
+        if (segment instanceof String field && node instanceof JSONObject object) {
+            child = object.opt(field);
+            if (isMissing(child) && create) {
+                child = newContainer(segments.get(index + 1));
+                object.put(field, child);
+            }
+        } else if (segment instanceof Integer position && node instanceof JSONArray array) {
+            child = array.opt(position);
+            if (isMissing(child) && create) {
+                child = newContainer(segments.get(index + 1));
+                array.put(position.intValue(), child);
+            }
+        }
+        if (!isMissing(child)) {
+            collectParents(child, index + 1, create, parents);
+        }
+    }
+
+    private static boolean isMissing(Object value) {
+        return value == null || JSONObject.NULL.equals(value);
+    }
+
+    // newContainer returns the object or the array holding the given segment
+    private static Object newContainer(Object segment) {
+        return segment instanceof String ? new JSONObject() : new JSONArray();
+    }
+
+    @Override
+    public String toString() {
+        return path;
+    }
+}
@@ -0,0 +1,421 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.bodytransformation;
+
+import org.json.JSONArray;
+import org.json.JSONObject;
+import org.json.XML;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.TreeSet;
+
+/**
+ * Go template rewriting a JSON payload. The subset of the Go templates validated by the APIPolicy webhook is
+ * supported, which is the field and the dot actions, the if, else if, with and range actions, the json function,
+ * the comments and the trim markers. The fields of the payload are printed as JSON, except for the strings which are
+ * printed as they are, unless they are passed to the json function. The printed values are XML escaped in the XML
+ * templates, so a payload can not inject markup in the rendered XML.
+ */
+class PayloadTemplate {
+
+    private static final String LEFT_DELIMITER = "{{";
+    private static final String RIGHT_DELIMITER = "}}";
+    private static final String JSON_FUNCTION = "json";
+
+    private final List<Node> nodes;
+
+    private PayloadTemplate(List<Node> nodes) {
+        this.nodes = nodes;
+    }
+
+    /**
+     * Parses the given template.
+     *
+     * @param text Go template
+     * @return the parsed template
+     * @throws BodyTransformationException if the template is not supported
+     */
+    static PayloadTemplate parse(String text) throws BodyTransformationException {
+        return parse(text, false);
+    }
+
+    /**
+     * Parses the given template rendering XML, which escapes the values of the payload it prints.
+     *
+     * @param text Go template
+     * @return the parsed template
+     * @throws BodyTransformationException if the template is not supported
+     */
+    static PayloadTemplate parseXml(String text) throws BodyTransformationException {
+        return parse(text, true);
+    }
+
+    private static PayloadTemplate parse(String text, boolean xml) throws BodyTransformationException {
+        Parser parser = new Parser(tokenize(text), xml);
+        List<Node> nodes = parser.parseList();
+        if (parser.hasNext()) {
+            throw new BodyTransformationException("Unexpected {{" + parser.next().text + "}} in the template");
+        }
+        return new PayloadTemplate(nodes);
+    }
+
+    /**
+     * Executes the template on the given payload.
+     *
+     * @param payload JSON payload parsed by org.json
+     * @return the rewritten payload
+     * @throws BodyTransformationException if a range action can not iterate over its value
+     */
+    String execute(Object payload) throws BodyTransformationException {
+        StringBuilder output = new StringBuilder();
+        render(nodes, payload, payload, output);
+        return output.toString();
+    }
+
+    private static void render(List<Node> nodes, Object dot, Object root, StringBuilder output)
+            throws BodyTransformationException {
+        for (Node node : nodes) {
+            node.render(dot, root, output);
+        }
+    }
+
+    // tokenize splits the template into the texts and the actions, and applies the trim markers on the texts
+    private static List<Token> tokenize(String text) throws BodyTransformationException {
+        List<Token> tokens = new ArrayList<>();
+        int position = 0;
+        boolean trimLeft = false;
+        while (position < text.length()) {
+            int start = text.indexOf(LEFT_DELIMITER, position);
+            String textPart = start < 0 ? text.substring(position) : text.substring(position, start);
+            if (trimLeft) {
+                textPart = textPart.stripLeading();
+            }
+            if (start < 0) {
+                tokens.add(new Token(false, textPart));
+                break;
+            }
+            int end = text.indexOf(RIGHT_DELIMITER, start + LEFT_DELIMITER.length());
+            if (end < 0) {
+                throw new BodyTransformationException("Unclosed action in the template");
+            }
+            String action = text.substring(start + LEFT_DELIMITER.length(), end);
+            if (action.startsWith("- ")) {
+                textPart = textPart.stripTrailing();
+                action = action.substring(1);
+            }
+            trimLeft = action.endsWith(" -");
+            if (trimLeft) {
+                action = action.substring(0, action.length() - 1);
+            }
+            tokens.add(new Token(false, textPart));
+            action = action.strip();
+            if (!action.startsWith("/*")) {
+                tokens.add(new Token(true, action));
+            }
+            position = end + RIGHT_DELIMITER.length();
+        }
+        return tokens;
+    }
+
+    private static boolean isTrue(Object value) {
+        if (value == null || JSONObject.NULL.equals(value)) {
+            return false;
+        } else if (value instanceof Boolean booleanValue) {
+            return booleanValue;
+        } else if (value instanceof Number number) {
+            return number.doubleValue() != 0;
+        } else if (value instanceof String string) {
+            return !string.isEmpty();
+        } else if (value instanceof JSONObject object) {
+            return !object.isEmpty();
+        } else if (value instanceof JSONArray array) {
+            return !array.isEmpty();
+        }
+        return true;
+    }
+
+    private static final class Token {
+        private final boolean action;
+        private final String text;
+
+        private Token(boolean action, String text) {
+            this.action = action;
+            this.text = text;
+        }
+    }
+
+    private interface Node {
+        void render(Object dot, Object root, StringBuilder output) throws BodyTransformationException;
+    }
+
+    /**
+     * Reference to a value of the payload, which is the dot or the root followed by the names of the fields.
+     */
+    private static final class Expression {
+        private final boolean fromRoot;
+        private final String[] fields;
+
+        private Expression(boolean fromRoot, String[] fields) {
+            this.fromRoot = fromRoot;
+            this.fields = fields;
+        }
+
+        static Expression parse(String text) throws BodyTransformationException {
+            boolean fromRoot = text.startsWith("$");
+            String fieldsText = fromRoot ? text.substring(1) : text;
+            if (fieldsText.isEmpty() && fromRoot || ".".equals(fieldsText)) {
+                return new Expression(fromRoot, new String[0]);
+            }
+            if (!fieldsText.matches("(\\.[A-Za-z_][A-Za-z0-9_]*)+")) {
+                throw new BodyTransformationException("Unsupported template pipeline " + text);
+            }
+            return new Expression(fromRoot, fieldsText.substring(1).split("\\."));
+        }
+
+        Object evaluate(Object dot, Object root) {
+            Object value = fromRoot ? root : dot;
+            for (String field : fields) {
+                value = value instanceof JSONObject object ? object.opt(field) : null;
+            }
+            return value;
+        }
+    }
+
+    private static final class TextNode implements Node {
+        private final String text;
+
+        private TextNode(String text) {
+            this.text = text;
+        }
+
+        @Override
+        public void render(Object dot, Object root, StringBuilder output) {
+            output.append(text);
+        }
+    }
+
+    private static final class ActionNode implements Node {
+        private final Expression expression;
+        private final boolean json;
+        private final boolean xml;
+
+        private ActionNode(Expression expression, boolean json, boolean xml) {
+            this.expression = expression;
+            this.json = json;
+            this.xml = xml;
+        }
+
+        @Override
+        public void render(Object dot, Object root, StringBuilder output) {
+            Object value = expression.evaluate(dot, root);
+            String text;
+            if (json) {
+                text = value == null ? "null" : JSONObject.valueToString(value);
+            } else if (value != null && !JSONObject.NULL.equals(value)) {
+                text = value instanceof String string ? string : JSONObject.valueToString(value);
+            } else {
+                return;
+            }
+            output.append(xml ? XML.escape(text) : text);
+        }
+    }
+
+    private static final class IfNode implements Node {
+        private final Expression condition;
+        private final List<Node> nodes;
+        private final List<Node> elseNodes;
+
+        private IfNode(Expression condition, List<Node> nodes, List<Node> elseNodes) {
+            this.condition = condition;
+            this.nodes = nodes;
+            this.elseNodes = elseNodes;
+        }
+
+        @Override
+        public void render(Object dot, Object root, StringBuilder output) throws BodyTransformationException {
+            PayloadTemplate.render(isTrue(condition.evaluate(dot, root)) ? nodes : elseNodes, dot, root, output);
+        }
+    }
+
+    private static final class WithNode implements Node {
+        private final Expression value;
+        private final List<Node> nodes;
+        private final List<Node> elseNodes;
+
+        private WithNode(Expression value, List<Node> nodes, List<Node> elseNodes) {
+            this.value = value;
+            this.nodes = nodes;
+            this.elseNodes = elseNodes;
+        }
+
+        @Override
+        public void render(Object dot, Object root, StringBuilder output) throws BodyTransformationException {
+            Object newDot = value.evaluate(dot, root);
+            if (isTrue(newDot)) {
+                PayloadTemplate.render(nodes, newDot, root, output);
+            } else {
+                PayloadTemplate.render(elseNodes, dot, root, output);
+            }
+        }
+    }
+
+    private static final class RangeNode implements Node {
+        private final Expression value;
+        private final List<Node> nodes;
+        private final List<Node> elseNodes;
+
+        private RangeNode(Expression value, List<Node> nodes, List<Node> elseNodes) {
+            this.value = value;
+            this.nodes = nodes;
+            this.elseNodes = elseNodes;
+        }
+
+        @Override
+        public void render(Object dot, Object root, StringBuilder output) throws BodyTransformationException {
+            Object collection = value.evaluate(dot, root);
+            if (!isTrue(collection)) {
+                PayloadTemplate.render(elseNodes, dot, root, output);
+            } else if (collection instanceof JSONArray array) {
+                for (int i = 0; i < array.length(); i++) {
+                    PayloadTemplate.render(nodes, array.get(i), root, output);
+                }
+            } else if (collection instanceof JSONObject object) {
+                // the values of the objects are iterated in the sorted order of the keys, as in Go
+                for (String key : new TreeSet<>(object.keySet())) {
+                    PayloadTemplate.render(nodes, object.get(key), root, output);
+                }
+            } else {
+                throw new BodyTransformationException("Range can not iterate over " + collection);
+            }
+        }
+    }
+
+    private static final class Parser {
+        private final List<Token> tokens;
+        private final boolean xml;
+        private int position;
+
+        private Parser(List<Token> tokens, boolean xml) {
+            this.tokens = tokens;
+            this.xml = xml;
+        }
+
+        boolean hasNext() {
+            return position < tokens.size();
+        }
+
+        Token next() {
+            return tokens.get(position++);
+        }
+
+        // parseList parses the nodes until the end of the template or an else or end action
+        List<Node> parseList() throws BodyTransformationException {
+            List<Node> nodes = new ArrayList<>();
+            while (hasNext()) {
+                Token token = tokens.get(position);
+                if (!token.action) {
+                    position++;
+                    if (!token.text.isEmpty()) {
+                        nodes.add(new TextNode(token.text));
+                    }
+                    continue;
+                }
+                String keyword = getKeyword(token.text);
+                if ("end".equals(keyword) || "else".equals(keyword)) {
+                    break;
+                }
+                position++;
+                String argument = token.text.substring(keyword.length()).strip();
+                switch (keyword) {
+                    case "if":
+                        nodes.add(parseIf(argument));
+                        break;
+                    case "with":
+                        nodes.add(new WithNode(Expression.parse(argument), parseList(), parseElse()));
+                        break;
+                    case "range":
+                        nodes.add(new RangeNode(Expression.parse(argument), parseList(), parseElse()));
+                        break;
+                    case JSON_FUNCTION:
+                        nodes.add(new ActionNode(Expression.parse(argument), true, xml));
+                        break;
+                    default:
+                        nodes.add(new ActionNode(Expression.parse(token.text), false, xml));
+                }
+            }
+            return nodes;
+        }
+
+        // parseIf parses an if action, where an else if action shares the end action of the if action
+        private Node parseIf(String condition) throws BodyTransformationException {
+            Expression expression = Expression.parse(condition);
+            List<Node> nodes = parseList();
+            Token terminator = expectTerminator();
+            if ("end".equals(terminator.text)) {
+                return new IfNode(expression, nodes, List.of());
+            }
+            String elseArgument = terminator.text.substring("else".length()).strip();
+            if (elseArgument.isEmpty()) {
+                List<Node> elseNodes = parseList();
+                expectEnd();
+                return new IfNode(expression, nodes, elseNodes);
+            }
+            if (!"if".equals(getKeyword(elseArgument))) {
+                throw new BodyTransformationException("Unsupported template action " + terminator.text);
+            }
+            return new IfNode(expression, nodes,
+                    List.of(parseIf(elseArgument.substring("if".length()).strip())));
+        }
+
+        // parseElse parses the optional else list of a with or range action, and its end action
+        private List<Node> parseElse() throws BodyTransformationException {
+            Token terminator = expectTerminator();
+            if ("end".equals(terminator.text)) {
+                return List.of();
+            }
+            if (!"else".equals(terminator.text)) {
+                throw new BodyTransformationException("Unsupported template action " + terminator.text);
+            }
+            List<Node> elseNodes = parseList();
+            expectEnd();
+            return elseNodes;
+        }
+
+        private Token expectTerminator() throws BodyTransformationException {
+            if (!hasNext()) {
+                throw new BodyTransformationException("Missing {{end}} in the template");
+            }
+            return next();
+        }
+
+        private void expectEnd() throws BodyTransformationException {
+            if (!"end".equals(expectTerminator().text)) {
+                throw new BodyTransformationException("Missing {{end}} in the template");
+            }
+        }
+
+        private static String getKeyword(String action) {
+            int separator = 0;
+            while (separator < action.length() && !Character.isWhitespace(action.charAt(separator))) {
+                separator++;
+            }
+            return action.substring(0, separator);
+        }
+    }
+}
@@ -0,0 +1,317 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.bodytransformation;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.json.JSONArray;
+import org.json.JSONException;
+import org.json.JSONObject;
+import org.json.XML;
+
+import java.nio.charset.StandardCharsets;
+import java.util.Base64;
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+
+/**
+ * SOAP mediation of an operation of a REST API, which wraps the JSON payloads of the requests in the SOAP envelopes
+ * of an operation of the SOAP backend, and converts the SOAP responses and faults of the backend back to JSON. The
+ * configurations are received from the adapter as base64 encoded json in the route metadata.
+ */
+public class SOAPMediation {
+
+    static final String SOAP12 = "SOAP12";
+    private static final String SOAP11_ENVELOPE_NAMESPACE = "http://schemas.xmlsoap.org/soap/envelope/";
+    private static final String SOAP12_ENVELOPE_NAMESPACE = "http://www.w3.org/2003/05/soap-envelope";
+    private static final Map<String, String> RESPONSE_HEADERS = Map.of("content-type", "application/json");
+
+    private static final Logger logger = LogManager.getLogger(SOAPMediation.class);
+    private static final ObjectMapper mapper = new ObjectMapper();
+    private static final Map<String, SOAPMediation> mediations = new ConcurrentHashMap<>();
+
+    private final String envelopeNamespace;
+    private final String namespace;
+    private final String inputElement;
+    private final PayloadTemplate envelopeTemplate;
+    private final Map<String, String> requestHeaders;
+
+    private SOAPMediation(MediationConfig config) throws BodyTransformationException {
+        boolean soap12 = SOAP12.equals(config.soapVersion);
+        String soapAction = config.soapAction != null ? config.soapAction : "";
+        envelopeNamespace = soap12 ? SOAP12_ENVELOPE_NAMESPACE : SOAP11_ENVELOPE_NAMESPACE;
+        namespace = config.namespace;
+        inputElement = config.inputElement;
+        envelopeTemplate = config.envelopeTemplate != null && !config.envelopeTemplate.isEmpty()
+                ? PayloadTemplate.parseXml(config.envelopeTemplate) : null;
+        if (envelopeTemplate == null) {
+            XMLSerializer.validateElementName(inputElement);
+        }
+        if (soap12) {
+            // the SOAP 1.2 action is a parameter of the content type
+            requestHeaders = Map.of("content-type", soapAction.isEmpty() ? "application/soap+xml; charset=utf-8"
+                    : "application/soap+xml; charset=utf-8; action=\"" + soapAction + "\"");
+        } else {
+            requestHeaders = Map.of("content-type", "text/xml; charset=utf-8", "soapaction", "\"" + soapAction + "\"");
+        }
+    }
+
+    /**
+     * Returns the SOAP mediation of the given configurations.
+     *
+     * @param encodedConfig base64 encoded json of the SOAP mediation
+     * @return the SOAP mediation, or null if the configurations could not be read
+     */
+    public static SOAPMediation fromEncodedConfig(String encodedConfig) {
+        if (encodedConfig == null || encodedConfig.isEmpty()) {
+            return null;
+        }
+        SOAPMediation mediation = mediations.get(encodedConfig);
+        if (mediation != null) {
+            return mediation;
+        }
+        try {
+            String configJson = new String(Base64.getDecoder().decode(encodedConfig), StandardCharsets.UTF_8);
+            mediation = new SOAPMediation(mapper.readValue(configJson, MediationConfig.class));
+            mediations.put(encodedConfig, mediation);
+            return mediation;
+        } catch (Exception e) {
+            logger.error("Error while reading the SOAP mediation of the route. " + e);
+            return null;
+        }
+    }
+
+    /**
+     * Wraps the given JSON payload of a request in the SOAP envelope of the operation. The body of the envelope is
+     * rendered by the envelope template, or else converted from the payload under the input element of the operation.
+     * The values of the payload are escaped, and the payloads with fields which are not valid element names are
+     * rejected.
+     *
+     * @param body JSON payload of the request
+     * @return the SOAP envelope
+     * @throws BodyTransformationException if the payload is not JSON, or it can not be converted to XML
+     */
+    public String toEnvelope(String body) throws BodyTransformationException {
+        Object payload;
+        try {
+            payload = body == null || body.isBlank() ? new JSONObject() : BodyTransformer.parseJson(body);
+        } catch (JSONException e) {
+            throw new BodyTransformationException("The payload could not be parsed", e);
+        }
+        String content;
+        if (envelopeTemplate != null) {
+            content = envelopeTemplate.execute(payload);
+        } else {
+            // the fields of the payload are qualified with the namespace of the input element
+            String namespaceDeclaration = namespace != null && !namespace.isEmpty()
+                    ? " xmlns=\"" + XML.escape(namespace) + "\"" : "";
+            content = "<" + inputElement + namespaceDeclaration + ">" + XMLSerializer.toXml(payload, null) + "</"
+                    + inputElement + ">";
+        }
+        return "<?xml version=\"1.0\" encoding=\"UTF-8\"?><soapenv:Envelope xmlns:soapenv=\"" + envelopeNamespace
+                + "\"><soapenv:Body>" + content + "</soapenv:Body></soapenv:Envelope>";
+    }
+
+    /**
+     * Converts the given SOAP envelope of a response to JSON. The payload is unwrapped from the element of the output
+     * message of the operation, and the faults are returned with the status code of the response to the client.
+     *
+     * @param body SOAP envelope of the response
+     * @return the converted response
+     * @throws BodyTransformationException if the response is not a SOAP envelope
+     */
+    public Response fromEnvelope(String body) throws BodyTransformationException {
+        Object envelope;
+        try {
+            envelope = stripNamespaces(XML.toJSONObject(body));
+        } catch (JSONException e) {
+            throw new BodyTransformationException("The response could not be parsed", e);
+        }
+        Object soapEnvelope = getChild(envelope, "Envelope");
+        if (!(soapEnvelope instanceof JSONObject) || !((JSONObject) soapEnvelope).has("Body")) {
+            throw new BodyTransformationException("The response is not a SOAP envelope");
+        }
+        Object soapBody = ((JSONObject) soapEnvelope).get("Body");
+        if (!(soapBody instanceof JSONObject)) {
+            return new Response("{}", 0, null, null, null);
+        }
+        JSONObject soapBodyObject = (JSONObject) soapBody;
+        if (soapBodyObject.has("Fault")) {
+            return toFault(soapBodyObject.get("Fault"));
+        }
+        for (String key : soapBodyObject.keySet()) {
+            Object value = soapBodyObject.get(key);
+            String payload = value instanceof JSONObject || value instanceof JSONArray ? value.toString()
+                    : new JSONObject().put(key, value).toString();
+            return new Response(payload, 0, null, null, null);
+        }
+        return new Response("{}", 0, null, null, null);
+    }
+
+    /**
+     * Returns the headers to be set on the requests wrapped in SOAP envelopes, which are the content type and the
+     * SOAP action.
+     */
+    public Map<String, String> getRequestHeaders() {
+        return requestHeaders;
+    }
+
+    /**
+     * Returns the headers to be set on the responses converted to JSON.
+     */
+    public Map<String, String> getResponseHeaders() {
+        return RESPONSE_HEADERS;
+    }
+
+    // toFault returns the response of a SOAP 1.1 fault, with the faultcode, faultstring and detail elements, or a
+    // SOAP 1.2 fault, with the Code, Reason and Detail elements. The client faults are caused by the request and
+    // the other faults by the backend.
+    private static Response toFault(Object fault) {
+        JSONObject faultObject = fault instanceof JSONObject ? (JSONObject) fault : new JSONObject();
+        String code = getText(faultObject.has("faultcode") ? faultObject.get("faultcode")
+                : getChild(faultObject.opt("Code"), "Value"));
+        String reason = getText(faultObject.has("faultstring") ? faultObject.get("faultstring")
+                : getChild(faultObject.opt("Reason"), "Text"));
+        String detail = getText(faultObject.has("detail") ? faultObject.get("detail") : faultObject.opt("Detail"));
+        if (code != null && code.contains(":")) {
+            code = code.substring(code.indexOf(':') + 1);
+        }
+        boolean clientFault = code != null && (code.equals("Client") || code.startsWith("Client.")
+                || code.equals("Sender"));
+        return new Response(null, clientFault ? 400 : 500, code, reason, detail);
+    }
+
+    private static Object getChild(Object parent, String name) {
+        return parent instanceof JSONObject ? ((JSONObject) parent).opt(name) : null;
+    }
+
+    // getText returns the text of an element, which is under the content key when the element has attributes
+    private static String getText(Object value) {
+        if (value == null) {
+            return null;
+        }
+        if (value instanceof JSONArray) {
+            return ((JSONArray) value).isEmpty() ? null : getText(((JSONArray) value).get(0));
+        }
+        if (value instanceof JSONObject) {
+            JSONObject object = (JSONObject) value;
+            return object.has("content") ? String.valueOf(object.get("content")) : object.toString();
+        }
+        return String.valueOf(value);
+    }
+
+    // stripNamespaces removes the namespace declarations and the namespace prefixes of the elements converted to JSON
+    private static Object stripNamespaces(Object value) {
+        if (value instanceof JSONObject) {
+            JSONObject object = (JSONObject) value;
+            JSONObject stripped = new JSONObject();
+            for (String key : object.keySet()) {
+                if ("xmlns".equals(key) || key.startsWith("xmlns:")) {
+                    continue;
+                }
+                int separator = key.indexOf(':');
+                stripped.put(separator >= 0 ? key.substring(separator + 1) : key, stripNamespaces(object.get(key)));
+            }
+            return stripped;
+        }
+        if (value instanceof JSONArray) {
+            JSONArray stripped = new JSONArray();
+            for (Object item : (JSONArray) value) {
+                stripped.put(stripNamespaces(item));
+            }
+            return stripped;
+        }
+        return value;
+    }
+
+    /**
+     * Response of a SOAP backend converted to JSON, which is either the payload of the response or a fault.
+     */
+    public static class Response {
+
+        private final String payload;
+        private final int faultStatusCode;
+        private final String faultCode;
+        private final String faultReason;
+        private final String faultDetail;
+
+        Response(String payload, int faultStatusCode, String faultCode, String faultReason, String faultDetail) {
+            this.payload = payload;
+            this.faultStatusCode = faultStatusCode;
+            this.faultCode = faultCode;
+            this.faultReason = faultReason;
+            this.faultDetail = faultDetail;
+        }
+
+        /**
+         * Returns whether the backend responded with a fault.
+         */
+        public boolean isFault() {
+            return faultStatusCode > 0;
+        }
+
+        /**
+         * Returns the JSON payload of the response, or null if it is a fault.
+         */
+        public String getPayload() {
+            return payload;
+        }
+
+        /**
+         * Returns the status code of the fault, which is 400 for the client faults and 500 for the others.
+         */
+        public int getFaultStatusCode() {
+            return faultStatusCode;
+        }
+
+        /**
+         * Returns the fault code without its namespace prefix, such as Client or Receiver.
+         */
+        public String getFaultCode() {
+            return faultCode;
+        }
+
+        /**
+         * Returns the reason of the fault.
+         */
+        public String getFaultReason() {
+            return faultReason;
+        }
+
+        /**
+         * Returns the detail of the fault, or null if it has none.
+         */
+        public String getFaultDetail() {
+            return faultDetail;
+        }
+    }
+
+    /**
+     * Configurations of the SOAP mediation, received from the adapter.
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    static class MediationConfig {
+        public String soapVersion;
+        public String soapAction;
+        public String namespace;
+        public String inputElement;
+        public String envelopeTemplate;
+    }
+}
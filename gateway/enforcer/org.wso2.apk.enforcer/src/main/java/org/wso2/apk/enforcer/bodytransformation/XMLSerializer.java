@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.bodytransformation;
+
+import org.json.JSONArray;
+import org.json.JSONObject;
+import org.json.XML;
+
+import java.util.regex.Pattern;
+
+/**
+ * Converts JSON payloads parsed by org.json to XML. The fields of the objects are converted to elements and the
+ * items of the arrays to repeated elements, and the content field is converted to the text of its element, as in
+ * org.json. The texts are escaped, and the fields which are not valid element names are rejected, so a payload can
+ * not inject markup in the converted XML.
+ */
+final class XMLSerializer {
+
+    // element names without a namespace prefix, which is the pattern allowed by the APIPolicy for the root element
+    private static final Pattern ELEMENT_NAME_PATTERN = Pattern.compile("[A-Za-z_][A-Za-z0-9_.-]*");
+    private static final String CONTENT_FIELD = "content";
+    private static final String ARRAY_ELEMENT = "array";
+
+    private XMLSerializer() {
+    }
+
+    /**
+     * Converts the given JSON value to XML.
+     *
+     * @param value       JSON value parsed by org.json
+     * @param rootElement name of the element wrapping the value, or null to convert only the content of the value
+     * @return the XML
+     * @throws BodyTransformationException if a field of the value is not a valid element name
+     */
+    static String toXml(Object value, String rootElement) throws BodyTransformationException {
+        StringBuilder output = new StringBuilder();
+        if (rootElement != null) {
+            validateElementName(rootElement);
+        }
+        write(value, rootElement, output);
+        return output.toString();
+    }
+
+    /**
+     * Checks whether the given name is a valid XML element name.
+     *
+     * @param name element name
+     * @throws BodyTransformationException if the name is not a valid element name
+     */
+    static void validateElementName(String name) throws BodyTransformationException {
+        if (name == null || !ELEMENT_NAME_PATTERN.matcher(name).matches()) {
+            throw new BodyTransformationException("Invalid XML element name " + name);
+        }
+    }
+
+    private static void write(Object value, String element, StringBuilder output) throws BodyTransformationException {
+        if (value instanceof JSONArray array) {
+            for (Object item : array) {
+                write(item, element != null ? element : ARRAY_ELEMENT, output);
+            }
+            return;
+        }
+        if (element == null) {
+            writeContent(value, output);
+            return;
+        }
+        if (value == null || JSONObject.NULL.equals(value)) {
+            output.append('<').append(element).append("/>");
+            return;
+        }
+        output.append('<').append(element).append('>');
+        writeContent(value, output);
+        output.append("</").append(element).append('>');
+    }
+
+    private static void writeContent(Object value, StringBuilder output) throws BodyTransformationException {
+        if (value instanceof JSONObject object) {
+            for (String field : object.keySet()) {
+                Object fieldValue = object.get(field);
+                if (CONTENT_FIELD.equals(field)) {
+                    writeText(fieldValue, output);
+                } else {
+                    validateElementName(field);
+                    write(fieldValue, field, output);
+                }
+            }
+        } else if (value instanceof JSONArray) {
+            write(value, null, output);
+        } else if (value != null && !JSONObject.NULL.equals(value)) {
+            output.append(XML.escape(value instanceof String ? (String) value : JSONObject.valueToString(value)));
+        }
+    }
+
+    // writeText writes the text of an element, where the items of an array are separated by new lines as in org.json
+    private static void writeText(Object value, StringBuilder output) {
+        if (value instanceof JSONArray array) {
+            for (int i = 0; i < array.length(); i++) {
+                if (i > 0) {
+                    output.append('\n');
+                }
+                output.append(XML.escape(String.valueOf(array.get(i))));
+            }
+        } else if (value != null && !JSONObject.NULL.equals(value)) {
+            output.append(XML.escape(String.valueOf(value)));
+        }
+    }
+}
@@ -53,6 +53,9 @@ public class EnvVarConfig {
     public static final String REDIS_PORT = "REDIS_PORT";
     public static final String IS_REDIS_TLS_ENABLED = "IS_REDIS_TLS_ENABLED";
     public static final String REDIS_REVOKED_TOKENS_CHANNEL = "REDIS_REVOKED_TOKENS_CHANNEL";
+    public static final String REDIS_TOKEN_BUDGET_ALERTS_CHANNEL = "REDIS_TOKEN_BUDGET_ALERTS_CHANNEL";
+    public static final String TOKEN_BUDGET_FAILURE_MODE_DENY = "TOKEN_BUDGET_FAILURE_MODE_DENY";
+    public static final String REDIS_RESPONSE_CACHE_INVALIDATION_CHANNEL = "REDIS_RESPONSE_CACHE_INVALIDATION_CHANNEL";
     public static final String REDIS_KEY_FILE = "REDIS_KEY_FILE";
     public static final String REDIS_CERT_FILE = "REDIS_CERT_FILE";
     public static final String REDIS_CA_CERT_FILE = "REDIS_CA_CERT_FILE";
@@ -87,6 +90,10 @@ public class EnvVarConfig {
     public static final int DEFAULT_REDIS_PORT = 6379;
     public static final String DEFAULT_IS_REDIS_TLS_ENABLED = "false";
     public static final String DEFAULT_REDIS_REVOKED_TOKENS_CHANNEL = "wso2-apk-revoked-tokens-channel";
+    public static final String DEFAULT_REDIS_TOKEN_BUDGET_ALERTS_CHANNEL = "wso2-apk-token-budget-alerts-channel";
+    public static final String DEFAULT_TOKEN_BUDGET_FAILURE_MODE_DENY = "false";
+    public static final String DEFAULT_REDIS_RESPONSE_CACHE_INVALIDATION_CHANNEL =
+            "wso2-apk-response-cache-invalidation-channel";
     public static final String DEFAULT_REDIS_KEY_FILE = "/home/wso2/security/redis/redis.key";
     public static final String DEFAULT_REDIS_CERT_FILE = "/home/wso2/security/redis/redis.crt";
     public static final String DEFAULT_REDIS_CA_CERT_FILE = "/home/wso2/security/redis/ca.crt";
@@ -126,6 +133,10 @@ public class EnvVarConfig {
     private final int redisPort;
     private final boolean isRedisTlsEnabled;
     private final String revokedTokensRedisChannel;
+    private final String tokenBudgetAlertsRedisChannel;
+    // the requests are rejected when the token budgets could not be read from redis
+    private final boolean tokenBudgetFailureModeDeny;
+    private final String responseCacheInvalidationRedisChannel;
     private final String redisKeyFile;
     private final String redisCertFile;
     private final String redisCaCertFile;
@@ -175,6 +186,12 @@ public class EnvVarConfig {
         isRedisTlsEnabled = retrieveEnvVarOrDefault(IS_REDIS_TLS_ENABLED, DEFAULT_IS_REDIS_TLS_ENABLED).toLowerCase()
                 .equals(DEFAULT_IS_REDIS_TLS_ENABLED)? false:true;
         revokedTokensRedisChannel = retrieveEnvVarOrDefault(REDIS_REVOKED_TOKENS_CHANNEL, DEFAULT_REDIS_REVOKED_TOKENS_CHANNEL);
+        tokenBudgetAlertsRedisChannel = retrieveEnvVarOrDefault(REDIS_TOKEN_BUDGET_ALERTS_CHANNEL,
+                DEFAULT_REDIS_TOKEN_BUDGET_ALERTS_CHANNEL);
+        tokenBudgetFailureModeDeny = Boolean.parseBoolean(retrieveEnvVarOrDefault(TOKEN_BUDGET_FAILURE_MODE_DENY,
+                DEFAULT_TOKEN_BUDGET_FAILURE_MODE_DENY));
+        responseCacheInvalidationRedisChannel = retrieveEnvVarOrDefault(REDIS_RESPONSE_CACHE_INVALIDATION_CHANNEL,
+                DEFAULT_REDIS_RESPONSE_CACHE_INVALIDATION_CHANNEL);
         redisKeyFile = retrieveEnvVarOrDefault(REDIS_KEY_FILE, DEFAULT_REDIS_KEY_FILE);
         redisCertFile = retrieveEnvVarOrDefault(REDIS_CERT_FILE, DEFAULT_REDIS_CERT_FILE);
         redisCaCertFile = retrieveEnvVarOrDefault(REDIS_CA_CERT_FILE, DEFAULT_REDIS_CA_CERT_FILE);
@@ -346,6 +363,18 @@ public class EnvVarConfig {
         return revokedTokensRedisChannel;
     }
 
+    public String getTokenBudgetAlertsRedisChannel() {
+        return tokenBudgetAlertsRedisChannel;
+    }
+
+    public boolean isTokenBudgetFailureModeDeny() {
+        return tokenBudgetFailureModeDeny;
+    }
+
+    public String getResponseCacheInvalidationRedisChannel() {
+        return responseCacheInvalidationRedisChannel;
+    }
+
     public int getRevokedTokenCleanupInterval() {
         return revokedTokenCleanupInterval;
     }
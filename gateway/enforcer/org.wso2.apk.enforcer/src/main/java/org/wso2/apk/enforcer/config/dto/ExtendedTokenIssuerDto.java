@@ -30,6 +30,7 @@ public class ExtendedTokenIssuerDto extends TokenIssuerDto {
     private String name;
     private boolean validateSubscriptions;
     private String alias;
+    private IntrospectionConfigDto introspectionConfig;
 
 
     public ExtendedTokenIssuerDto(String issuer) {
@@ -59,5 +60,13 @@ public class ExtendedTokenIssuerDto extends TokenIssuerDto {
     public void setCertificateAlias(String alias) {
         this.alias = alias;
     }
+
+    public IntrospectionConfigDto getIntrospectionConfig() {
+        return introspectionConfig;
+    }
+
+    public void setIntrospectionConfig(IntrospectionConfigDto introspectionConfig) {
+        this.introspectionConfig = introspectionConfig;
+    }
 }
 
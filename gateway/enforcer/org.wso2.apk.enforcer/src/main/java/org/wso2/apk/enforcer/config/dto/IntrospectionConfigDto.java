@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.config.dto;
+
+import java.security.cert.Certificate;
+
+/**
+ * Holds the RFC 7662 token introspection configuration of a token issuer.
+ */
+public class IntrospectionConfigDto {
+
+    private String url;
+    private Certificate certificate;
+    private String clientId;
+    private String clientSecret;
+    private int positiveCacheTTL;
+    private int negativeCacheTTL;
+
+    public String getUrl() {
+
+        return url;
+    }
+
+    public void setUrl(String url) {
+
+        this.url = url;
+    }
+
+    public Certificate getCertificate() {
+
+        return certificate;
+    }
+
+    public void setCertificate(Certificate certificate) {
+
+        this.certificate = certificate;
+    }
+
+    public String getClientId() {
+
+        return clientId;
+    }
+
+    public void setClientId(String clientId) {
+
+        this.clientId = clientId;
+    }
+
+    public String getClientSecret() {
+
+        return clientSecret;
+    }
+
+    public void setClientSecret(String clientSecret) {
+
+        this.clientSecret = clientSecret;
+    }
+
+    public int getPositiveCacheTTL() {
+
+        return positiveCacheTTL;
+    }
+
+    public void setPositiveCacheTTL(int positiveCacheTTL) {
+
+        this.positiveCacheTTL = positiveCacheTTL;
+    }
+
+    public int getNegativeCacheTTL() {
+
+        return negativeCacheTTL;
+    }
+
+    public void setNegativeCacheTTL(int negativeCacheTTL) {
+
+        this.negativeCacheTTL = negativeCacheTTL;
+    }
+}
@@ -31,6 +31,8 @@ public class APIConstants {
     public static final String GW_RES_PATH_PARAM = "path";
     public static final String GW_VERSION_PARAM = "version";
     public static final String GW_API_NAME_PARAM = "name";
+    public static final String SCHEMA_VALIDATION_PARAM = "schemaValidation";
+    public static final String VALIDATE_RESPONSES_PARAM = "validateResponses";
     public static final String PROTOTYPED_LIFE_CYCLE_STATUS = "PROTOTYPED";
     public static final String UNLIMITED_TIER = "Unlimited";
     public static final String UNAUTHENTICATED_TIER = "Unauthenticated";
@@ -56,6 +58,7 @@ public class APIConstants {
     public static final String SWAGGER_API_KEY_IN_HEADER = "Header";
     public static final String SWAGGER_API_KEY_IN_QUERY = "Query";
     public static final String API_SECURITY_MUTUAL_SSL_NAME = "mtls";
+    public static final String API_SECURITY_HMAC = "HMAC";
     public static final String CLIENT_CERTIFICATE_HEADER_DEFAULT = "X-WSO2-CLIENT-CERTIFICATE";
     public static final String WWW_AUTHENTICATE = "WWW-Authenticate";
     public static final String TEST_CONSOLE_KEY_HEADER = "internal-key";
@@ -104,6 +107,7 @@ public class APIConstants {
         public static final String SOAP11 = "SOAP11";
         public static final String SOAP12 = "SOAP12";
         public static final String JSON = "JSON";
+        public static final String GRAPHQL = "GRAPHQL";
     }
 
     /**
@@ -228,6 +232,7 @@ public class APIConstants {
         public static final String ERROR_CODE = "code";
         public static final String ERROR_MESSAGE = "error_message";
         public static final String ERROR_DESCRIPTION = "error_description";
+        public static final String ERROR_DETAILS = "errors";
     }
 
     /**
@@ -30,6 +30,88 @@ public class GeneralErrorCodeConstants {
     // TODO: (renuka) check error codes with APIM
     public static final int MEDIATION_POLICY_ERROR_CODE = 901100;
 
+    /**
+     * Contains the errors of the validation of the requests and the responses against the API definition
+     */
+    public static class SchemaValidation {
+        public static final String INVALID_REQUEST_CODE = "900880";
+        public static final String INVALID_REQUEST_MESSAGE = "Schema validation failed";
+        public static final String INVALID_REQUEST_DESCRIPTION = "The request does not conform to the API definition.";
+        public static final String INVALID_RESPONSE_CODE = "900881";
+        public static final String INVALID_RESPONSE_MESSAGE = "Invalid response";
+        public static final String INVALID_RESPONSE_DESCRIPTION =
+                "The response of the backend does not conform to the API definition.";
+        public static final String INVALID_DEFINITION_CODE = "900882";
+        public static final String INVALID_DEFINITION_MESSAGE = "Schema validation failed";
+        public static final String INVALID_DEFINITION_DESCRIPTION =
+                "The API definition could not be read to validate the request.";
+    }
+
+    /**
+     * Contains the errors of the transformation of the payloads of the requests and the responses
+     */
+    public static class BodyTransformation {
+        public static final String REQUEST_FAILURE_CODE = "900885";
+        public static final String REQUEST_FAILURE_MESSAGE = "Payload transformation failed";
+        public static final String REQUEST_FAILURE_DESCRIPTION =
+                "The payload of the request could not be transformed.";
+        public static final String RESPONSE_FAILURE_CODE = "900886";
+        public static final String RESPONSE_FAILURE_MESSAGE = "Invalid response";
+        public static final String RESPONSE_FAILURE_DESCRIPTION =
+                "The payload of the response of the backend could not be transformed.";
+    }
+
+    /**
+     * Contains the errors of the mediation of the requests and the responses of the SOAP backends
+     */
+    public static class SOAPMediation {
+        public static final String REQUEST_FAILURE_CODE = "900887";
+        public static final String REQUEST_FAILURE_MESSAGE = "SOAP mediation failed";
+        public static final String REQUEST_FAILURE_DESCRIPTION =
+                "The payload of the request could not be wrapped in a SOAP envelope.";
+        public static final String RESPONSE_FAILURE_CODE = "900888";
+        public static final String RESPONSE_FAILURE_MESSAGE = "Invalid response";
+        public static final String RESPONSE_FAILURE_DESCRIPTION =
+                "The response of the SOAP backend could not be converted to JSON.";
+        public static final String FAULT_CODE = "900889";
+        public static final String FAULT_MESSAGE = "SOAP fault";
+    }
+
+    /**
+     * Contains the errors of obtaining the credentials of the secured backends
+     */
+    public static class EndpointSecurity {
+        public static final int TOKEN_FAILURE_CODE = 900890;
+        public static final String TOKEN_FAILURE_MESSAGE = "Backend authentication failed";
+        public static final String TOKEN_FAILURE_DESCRIPTION =
+                "An access token could not be obtained to invoke the backend.";
+    }
+
+    /**
+     * Contains the errors of checking the token budgets of the subscriptions to the AI APIs
+     */
+    public static class TokenBudget {
+        public static final int UNAVAILABLE_CODE = 900891;
+        public static final String UNAVAILABLE_MESSAGE = "Token budget unavailable";
+        public static final String UNAVAILABLE_DESCRIPTION = "The token budget of the application could not be checked.";
+    }
+
+    /**
+     * Contains the errors of authorizing the subscribe messages of the subscriptions to the GraphQL APIs
+     */
+    public static class GraphQLSubscription {
+        public static final String INVALID_MESSAGE_CODE = "900892";
+        public static final String INVALID_MESSAGE_MESSAGE = "Invalid subscription";
+        public static final String THROTTLED_CODE = "900893";
+        public static final String THROTTLED_MESSAGE = "Message throttled out";
+        public static final String THROTTLED_DESCRIPTION =
+                "You have exceeded your quota of the subscriptions to the field.";
+        public static final String UNAVAILABLE_CODE = "900894";
+        public static final String UNAVAILABLE_MESSAGE = "Subscription unavailable";
+        public static final String UNAVAILABLE_DESCRIPTION =
+                "The subscribe message could not be authorized as the API is not available.";
+    }
+
     /**
      * Contains mock impl endpoint apis related errors
      */
@@ -24,6 +24,7 @@ package org.wso2.apk.enforcer.constants;
 public class HttpConstants {
     public static final int NO_CONTENT_STATUS_CODE = 204;
     public static final String OPTIONS = "OPTIONS";
+    public static final String GET = "GET";
     public static final String ALLOW_HEADER = "allow";
     public static final String X_REQUEST_ID_HEADER = "x-request-id";
     public static final String APPLICATION_JSON = "application/json";
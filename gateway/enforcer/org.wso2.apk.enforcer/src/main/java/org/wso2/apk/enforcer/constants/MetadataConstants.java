@@ -70,5 +70,17 @@ public class MetadataConstants {
     public static final String MODEL = "aitoken:model";
     public static final String AI_PROVIDER_NAME = "ai:providername";
     public static final String AI_PROVIDER_API_VERSION = "ai:providerversion";
+    public static final String AI_GUARDRAIL_DIRECTION = "aiguardrail:direction";
+    public static final String AI_GUARDRAIL_VIOLATION = "aiguardrail:violation";
+    public static final String AI_GUARDRAIL_PROMPT_REDACTIONS = "aiguardrail:promptredactions";
+    public static final String AI_GUARDRAIL_RESPONSE_REDACTIONS = "aiguardrail:responseredactions";
+    public static final String AI_CACHE_STATUS = "aicache:status";
+    public static final String TOKEN_BUDGET_APPLICATION = "tokenbudget:application";
+    public static final String TOKEN_BUDGET_SUBSCRIPTION = "tokenbudget:subscription";
+    public static final String RESPONSE_CACHE_CONSUMER = "responsecache:consumer";
+    public static final String SCHEMA_VALIDATION_API = "schemavalidation:api";
+    public static final String SCHEMA_VALIDATION_OPERATION = "schemavalidation:operation";
+    public static final String GRAPHQL_SUBSCRIPTION_API = "graphqlsubscription:api";
+    public static final String GRAPHQL_SUBSCRIPTION_SCOPES = "graphqlsubscription:scopes";
 
 }
@@ -25,6 +25,7 @@ import io.envoyproxy.envoy.type.v3.HttpStatus;
 import org.wso2.apk.enforcer.api.ResponseObject;
 import org.wso2.apk.enforcer.config.ConfigHolder;
 import org.wso2.apk.enforcer.constants.APIConstants;
+import org.wso2.apk.enforcer.deniedresponse.types.GraphQLDeniedResponse;
 import org.wso2.apk.enforcer.deniedresponse.types.JsonDeniedResponse;
 import org.wso2.apk.enforcer.deniedresponse.types.Soap11DeniedResponse;
 import org.wso2.apk.enforcer.deniedresponse.types.Soap12DeniedResponse;
@@ -51,13 +52,16 @@ public class DeniedResponsePreparer {
 
     /**
      * This function will set the error message according to the format that needs to be sent.
-     * Currently, supported formats are JSON, SOAP1.1, SOAP1.2.
+     * Currently, supported formats are JSON, SOAP1.1, SOAP1.2 and GraphQL.
      *
      * @param request        CheckRequest object containing request details
      * @param responseObject ResponseObject containing the response details
      */
     public void setErrorMessage(CheckRequest request, ResponseObject responseObject) {
         findResponseType(request);
+        if (responseObject.isGraphQLErrorResponse()) {
+            this.responseType = APIConstants.ErrorResponseTypes.GRAPHQL;
+        }
         switch (responseType) {
             case APIConstants.ErrorResponseTypes.SOAP11:
                 deniedResponse = new Soap11DeniedResponse(denyResponseBuilder);
@@ -67,6 +71,10 @@ public class DeniedResponsePreparer {
                 deniedResponse = new Soap12DeniedResponse(denyResponseBuilder);
                 deniedResponse.setResponse(responseObject);
                 break;
+            case APIConstants.ErrorResponseTypes.GRAPHQL:
+                deniedResponse = new GraphQLDeniedResponse(denyResponseBuilder);
+                deniedResponse.setResponse(responseObject);
+                break;
             default:
                 deniedResponse = new JsonDeniedResponse(denyResponseBuilder);
                 deniedResponse.setResponse(responseObject);
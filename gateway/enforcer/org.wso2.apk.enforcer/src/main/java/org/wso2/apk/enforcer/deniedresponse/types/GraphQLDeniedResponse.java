@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.deniedresponse.types;
+
+import io.envoyproxy.envoy.config.core.v3.HeaderValue;
+import io.envoyproxy.envoy.config.core.v3.HeaderValueOption;
+import io.envoyproxy.envoy.service.auth.v3.DeniedHttpResponse;
+import org.json.JSONArray;
+import org.json.JSONObject;
+import org.wso2.apk.enforcer.api.ResponseObject;
+import org.wso2.apk.enforcer.constants.APIConstants;
+import org.wso2.apk.enforcer.deniedresponse.DeniedResponse;
+
+/**
+ * generates denied responses carrying the errors object of a GraphQL response, as in
+ * {"errors":[{"message":"QUERY TOO DEEP","extensions":{"code":"900820","description":"..."}}]}.
+ */
+public class GraphQLDeniedResponse extends DeniedResponse {
+
+    private static final String ERRORS = "errors";
+    private static final String MESSAGE = "message";
+    private static final String EXTENSIONS = "extensions";
+    private static final String DESCRIPTION = "description";
+
+    public GraphQLDeniedResponse(DeniedHttpResponse.Builder denyResponseBuilder) {
+        super(denyResponseBuilder);
+    }
+
+    @Override
+    public void setResponse(ResponseObject responseObject) {
+        JSONObject extensions = new JSONObject();
+        extensions.put(APIConstants.MessageFormat.ERROR_CODE, responseObject.getErrorCode());
+        extensions.put(DESCRIPTION, responseObject.getErrorDescription());
+        JSONObject error = new JSONObject();
+        error.put(MESSAGE, responseObject.getErrorMessage());
+        error.put(EXTENSIONS, extensions);
+        JSONObject responseJson = new JSONObject();
+        responseJson.put(ERRORS, new JSONArray().put(error));
+        denyResponseBuilder.setBody(responseJson.toString());
+        HeaderValueOption headerValueOption = HeaderValueOption.newBuilder().setHeader(HeaderValue.newBuilder()
+                .setKey(APIConstants.CONTENT_TYPE_HEADER)
+                .setValue(APIConstants.APPLICATION_JSON).build()).build();
+        denyResponseBuilder.addHeaders(headerValueOption);
+    }
+}
@@ -42,6 +42,9 @@ public class JsonDeniedResponse extends DeniedResponse {
         responseJson.put(APIConstants.MessageFormat.ERROR_MESSAGE, responseObject.getErrorMessage());
         responseJson.put(APIConstants.MessageFormat.ERROR_DESCRIPTION,
                 responseObject.getErrorDescription());
+        if (responseObject.getErrorDetails() != null) {
+            responseJson.put(APIConstants.MessageFormat.ERROR_DETAILS, responseObject.getErrorDetails());
+        }
         denyResponseBuilder.setBody(responseJson.toString());
         HeaderValueOption headerValueOption = HeaderValueOption.newBuilder().setHeader(HeaderValue.newBuilder()
                 .setKey(APIConstants.CONTENT_TYPE_HEADER)
@@ -90,6 +90,19 @@ private static final long serialVersionUID = 0L;
 
             break;
           }
+          case 42: {
+            org.wso2.apk.enforcer.discovery.api.HMAC.Builder subBuilder = null;
+            if (hmac_ != null) {
+              subBuilder = hmac_.toBuilder();
+            }
+            hmac_ = input.readMessage(org.wso2.apk.enforcer.discovery.api.HMAC.parser(), extensionRegistry);
+            if (subBuilder != null) {
+              subBuilder.mergeFrom(hmac_);
+              hmac_ = subBuilder.buildPartial();
+            }
+
+            break;
+          }
           default: {
             if (!parseUnknownField(
                 input, unknownFields, extensionRegistry, tag)) {
@@ -232,6 +245,32 @@ private static final long serialVersionUID = 0L;
     return getOauth2();
   }
 
+  public static final int HMAC_FIELD_NUMBER = 5;
+  private org.wso2.apk.enforcer.discovery.api.HMAC hmac_;
+  /**
+   * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+   * @return Whether the hmac field is set.
+   */
+  @java.lang.Override
+  public boolean hasHmac() {
+    return hmac_ != null;
+  }
+  /**
+   * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+   * @return The hmac.
+   */
+  @java.lang.Override
+  public org.wso2.apk.enforcer.discovery.api.HMAC getHmac() {
+    return hmac_ == null ? org.wso2.apk.enforcer.discovery.api.HMAC.getDefaultInstance() : hmac_;
+  }
+  /**
+   * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+   */
+  @java.lang.Override
+  public org.wso2.apk.enforcer.discovery.api.HMACOrBuilder getHmacOrBuilder() {
+    return getHmac();
+  }
+
   private byte memoizedIsInitialized = -1;
   @java.lang.Override
   public final boolean isInitialized() {
@@ -258,6 +297,9 @@ private static final long serialVersionUID = 0L;
     if (oauth2_ != null) {
       output.writeMessage(4, getOauth2());
     }
+    if (hmac_ != null) {
+      output.writeMessage(5, getHmac());
+    }
     unknownFields.writeTo(output);
   }
 
@@ -283,6 +325,10 @@ private static final long serialVersionUID = 0L;
       size += com.google.protobuf.CodedOutputStream
         .computeMessageSize(4, getOauth2());
     }
+    if (hmac_ != null) {
+      size += com.google.protobuf.CodedOutputStream
+        .computeMessageSize(5, getHmac());
+    }
     size += unknownFields.getSerializedSize();
     memoizedSize = size;
     return size;
@@ -312,6 +358,11 @@ private static final long serialVersionUID = 0L;
       if (!getOauth2()
           .equals(other.getOauth2())) return false;
     }
+    if (hasHmac() != other.hasHmac()) return false;
+    if (hasHmac()) {
+      if (!getHmac()
+          .equals(other.getHmac())) return false;
+    }
     if (!unknownFields.equals(other.unknownFields)) return false;
     return true;
   }
@@ -338,6 +389,10 @@ private static final long serialVersionUID = 0L;
       hash = (37 * hash) + OAUTH2_FIELD_NUMBER;
       hash = (53 * hash) + getOauth2().hashCode();
     }
+    if (hasHmac()) {
+      hash = (37 * hash) + HMAC_FIELD_NUMBER;
+      hash = (53 * hash) + getHmac().hashCode();
+    }
     hash = (29 * hash) + unknownFields.hashCode();
     memoizedHashCode = hash;
     return hash;
@@ -492,6 +547,12 @@ private static final long serialVersionUID = 0L;
         oauth2_ = null;
         oauth2Builder_ = null;
       }
+      if (hmacBuilder_ == null) {
+        hmac_ = null;
+      } else {
+        hmac_ = null;
+        hmacBuilder_ = null;
+      }
       return this;
     }
 
@@ -539,6 +600,11 @@ private static final long serialVersionUID = 0L;
       } else {
         result.oauth2_ = oauth2Builder_.build();
       }
+      if (hmacBuilder_ == null) {
+        result.hmac_ = hmac_;
+      } else {
+        result.hmac_ = hmacBuilder_.build();
+      }
       onBuilt();
       return result;
     }
@@ -622,6 +688,9 @@ private static final long serialVersionUID = 0L;
       if (other.hasOauth2()) {
         mergeOauth2(other.getOauth2());
       }
+      if (other.hasHmac()) {
+        mergeHmac(other.getHmac());
+      }
       this.mergeUnknownFields(other.unknownFields);
       onChanged();
       return this;
@@ -1172,6 +1241,125 @@ private static final long serialVersionUID = 0L;
       }
       return oauth2Builder_;
     }
+
+    private org.wso2.apk.enforcer.discovery.api.HMAC hmac_;
+    private com.google.protobuf.SingleFieldBuilderV3<
+        org.wso2.apk.enforcer.discovery.api.HMAC, org.wso2.apk.enforcer.discovery.api.HMAC.Builder, org.wso2.apk.enforcer.discovery.api.HMACOrBuilder> hmacBuilder_;
+    /**
+     * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+     * @return Whether the hmac field is set.
+     */
+    public boolean hasHmac() {
+      return hmacBuilder_ != null || hmac_ != null;
+    }
+    /**
+     * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+     * @return The hmac.
+     */
+    public org.wso2.apk.enforcer.discovery.api.HMAC getHmac() {
+      if (hmacBuilder_ == null) {
+        return hmac_ == null ? org.wso2.apk.enforcer.discovery.api.HMAC.getDefaultInstance() : hmac_;
+      } else {
+        return hmacBuilder_.getMessage();
+      }
+    }
+    /**
+     * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+     */
+    public Builder setHmac(org.wso2.apk.enforcer.discovery.api.HMAC value) {
+      if (hmacBuilder_ == null) {
+        if (value == null) {
+          throw new NullPointerException();
+        }
+        hmac_ = value;
+        onChanged();
+      } else {
+        hmacBuilder_.setMessage(value);
+      }
+
+      return this;
+    }
+    /**
+     * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+     */
+    public Builder setHmac(
+        org.wso2.apk.enforcer.discovery.api.HMAC.Builder builderForValue) {
+      if (hmacBuilder_ == null) {
+        hmac_ = builderForValue.build();
+        onChanged();
+      } else {
+        hmacBuilder_.setMessage(builderForValue.build());
+      }
+
+      return this;
+    }
+    /**
+     * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+     */
+    public Builder mergeHmac(org.wso2.apk.enforcer.discovery.api.HMAC value) {
+      if (hmacBuilder_ == null) {
+        if (hmac_ != null) {
+          hmac_ =
+            org.wso2.apk.enforcer.discovery.api.HMAC.newBuilder(hmac_).mergeFrom(value).buildPartial();
+        } else {
+          hmac_ = value;
+        }
+        onChanged();
+      } else {
+        hmacBuilder_.mergeFrom(value);
+      }
+
+      return this;
+    }
+    /**
+     * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+     */
+    public Builder clearHmac() {
+      if (hmacBuilder_ == null) {
+        hmac_ = null;
+        onChanged();
+      } else {
+        hmac_ = null;
+        hmacBuilder_ = null;
+      }
+
+      return this;
+    }
+    /**
+     * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+     */
+    public org.wso2.apk.enforcer.discovery.api.HMAC.Builder getHmacBuilder() {
+      
+      onChanged();
+      return getHmacFieldBuilder().getBuilder();
+    }
+    /**
+     * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+     */
+    public org.wso2.apk.enforcer.discovery.api.HMACOrBuilder getHmacOrBuilder() {
+      if (hmacBuilder_ != null) {
+        return hmacBuilder_.getMessageOrBuilder();
+      } else {
+        return hmac_ == null ?
+            org.wso2.apk.enforcer.discovery.api.HMAC.getDefaultInstance() : hmac_;
+      }
+    }
+    /**
+     * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+     */
+    private com.google.protobuf.SingleFieldBuilderV3<
+        org.wso2.apk.enforcer.discovery.api.HMAC, org.wso2.apk.enforcer.discovery.api.HMAC.Builder, org.wso2.apk.enforcer.discovery.api.HMACOrBuilder> 
+        getHmacFieldBuilder() {
+      if (hmacBuilder_ == null) {
+        hmacBuilder_ = new com.google.protobuf.SingleFieldBuilderV3<
+            org.wso2.apk.enforcer.discovery.api.HMAC, org.wso2.apk.enforcer.discovery.api.HMAC.Builder, org.wso2.apk.enforcer.discovery.api.HMACOrBuilder>(
+                getHmac(),
+                getParentForChildren(),
+                isClean());
+        hmac_ = null;
+      }
+      return hmacBuilder_;
+    }
     @java.lang.Override
     public final Builder setUnknownFields(
         final com.google.protobuf.UnknownFieldSet unknownFields) {
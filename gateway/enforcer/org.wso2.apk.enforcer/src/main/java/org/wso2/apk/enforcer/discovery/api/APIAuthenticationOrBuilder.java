@@ -70,4 +70,19 @@ public interface APIAuthenticationOrBuilder extends
    * <code>.wso2.discovery.api.Oauth2 Oauth2 = 4;</code>
    */
   org.wso2.apk.enforcer.discovery.api.Oauth2OrBuilder getOauth2OrBuilder();
+
+  /**
+   * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+   * @return Whether the hmac field is set.
+   */
+  boolean hasHmac();
+  /**
+   * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+   * @return The hmac.
+   */
+  org.wso2.apk.enforcer.discovery.api.HMAC getHmac();
+  /**
+   * <code>.wso2.discovery.api.HMAC hmac = 5;</code>
+   */
+  org.wso2.apk.enforcer.discovery.api.HMACOrBuilder getHmacOrBuilder();
 }
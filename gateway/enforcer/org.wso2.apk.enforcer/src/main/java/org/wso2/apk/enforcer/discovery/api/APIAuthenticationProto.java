@@ -29,6 +29,16 @@ public final class APIAuthenticationProto {
   static final 
     com.google.protobuf.GeneratedMessageV3.FieldAccessorTable
       internal_static_wso2_discovery_api_Oauth2_fieldAccessorTable;
+  static final com.google.protobuf.Descriptors.Descriptor
+    internal_static_wso2_discovery_api_HMAC_descriptor;
+  static final 
+    com.google.protobuf.GeneratedMessageV3.FieldAccessorTable
+      internal_static_wso2_discovery_api_HMAC_fieldAccessorTable;
+  static final com.google.protobuf.Descriptors.Descriptor
+    internal_static_wso2_discovery_api_HMAC_KeysEntry_descriptor;
+  static final 
+    com.google.protobuf.GeneratedMessageV3.FieldAccessorTable
+      internal_static_wso2_discovery_api_HMAC_KeysEntry_fieldAccessorTable;
   static final com.google.protobuf.Descriptors.Descriptor
     internal_static_wso2_discovery_api_APIAuthentication_descriptor;
   static final 
@@ -49,14 +59,21 @@ public final class APIAuthenticationProto {
       "ream\030\003 \001(\010\"D\n\003JWT\022\016\n\006header\030\001 \001(\t\022\033\n\023sen" +
       "dTokenToUpstream\030\002 \001(\010\022\020\n\010audience\030\003 \003(\t" +
       "\"5\n\006Oauth2\022\016\n\006header\030\001 \001(\t\022\033\n\023sendTokenT" +
-      "oUpstream\030\002 \001(\010\"\243\001\n\021APIAuthentication\022\020\n" +
-      "\010disabled\030\001 \001(\010\022$\n\003jwt\030\002 \001(\0132\027.wso2.disc" +
-      "overy.api.JWT\022*\n\006apikey\030\003 \003(\0132\032.wso2.dis" +
-      "covery.api.APIKey\022*\n\006Oauth2\030\004 \001(\0132\032.wso2" +
-      ".discovery.api.Oauth2B~\n#org.wso2.apk.en" +
-      "forcer.discovery.apiB\026APIAuthenticationP" +
-      "rotoP\001Z=github.com/envoyproxy/go-control" +
-      "-plane/wso2/discovery/api;apib\006proto3"
+      "oUpstream\030\002 \001(\010\"\320\001\n\004HMAC\022\016\n\006header\030\001 \001(\t" +
+      "\0220\n\004keys\030\002 \003(\0132\".wso2.discovery.api.HMAC" +
+      ".KeysEntry\022\022\n\nalgorithms\030\003 \003(\t\022\025\n\rsigned" +
+      "Headers\030\004 \003(\t\022\021\n\tclockSkew\030\005 \001(\r\022\033\n\023send" +
+      "TokenToUpstream\030\006 \001(\010\032+\n\tKeysEntry\022\013\n\003ke" +
+      "y\030\001 \001(\t\022\r\n\005value\030\002 \001(\t:\0028\001\"\313\001\n\021APIAuthen" +
+      "tication\022\020\n\010disabled\030\001 \001(\010\022$\n\003jwt\030\002 \001(\0132" +
+      "\027.wso2.discovery.api.JWT\022*\n\006apikey\030\003 \003(\013" +
+      "2\032.wso2.discovery.api.APIKey\022*\n\006Oauth2\030\004" +
+      " \001(\0132\032.wso2.discovery.api.Oauth2\022&\n\004hmac" +
+      "\030\005 \001(\0132\030.wso2.discovery.api.HMACB~\n#org." +
+      "wso2.apk.enforcer.discovery.apiB\026APIAuth" +
+      "enticationProtoP\001Z=github.com/envoyproxy" +
+      "/go-control-plane/wso2/discovery/api;api" +
+      "b\006proto3"
     };
     descriptor = com.google.protobuf.Descriptors.FileDescriptor
       .internalBuildGeneratedFileFrom(descriptorData,
@@ -80,12 +97,24 @@ public final class APIAuthenticationProto {
       com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
         internal_static_wso2_discovery_api_Oauth2_descriptor,
         new java.lang.String[] { "Header", "SendTokenToUpstream", });
-    internal_static_wso2_discovery_api_APIAuthentication_descriptor =
+    internal_static_wso2_discovery_api_HMAC_descriptor =
       getDescriptor().getMessageTypes().get(3);
+    internal_static_wso2_discovery_api_HMAC_fieldAccessorTable = new
+      com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
+        internal_static_wso2_discovery_api_HMAC_descriptor,
+        new java.lang.String[] { "Header", "Keys", "Algorithms", "SignedHeaders", "ClockSkew", "SendTokenToUpstream", });
+    internal_static_wso2_discovery_api_HMAC_KeysEntry_descriptor =
+      internal_static_wso2_discovery_api_HMAC_descriptor.getNestedTypes().get(0);
+    internal_static_wso2_discovery_api_HMAC_KeysEntry_fieldAccessorTable = new
+      com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
+        internal_static_wso2_discovery_api_HMAC_KeysEntry_descriptor,
+        new java.lang.String[] { "Key", "Value", });
+    internal_static_wso2_discovery_api_APIAuthentication_descriptor =
+      getDescriptor().getMessageTypes().get(4);
     internal_static_wso2_discovery_api_APIAuthentication_fieldAccessorTable = new
       com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
         internal_static_wso2_discovery_api_APIAuthentication_descriptor,
-        new java.lang.String[] { "Disabled", "Jwt", "Apikey", "Oauth2", });
+        new java.lang.String[] { "Disabled", "Jwt", "Apikey", "Oauth2", "Hmac", });
   }
 
   // @@protoc_insertion_point(outer_class_scope)
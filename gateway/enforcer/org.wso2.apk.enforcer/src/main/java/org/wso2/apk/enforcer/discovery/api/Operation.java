@@ -24,6 +24,7 @@ private static final long serialVersionUID = 0L;
     tier_ = "";
     scopes_ = com.google.protobuf.LazyStringArrayList.EMPTY;
     matchID_ = "";
+    authorizationRules_ = java.util.Collections.emptyList();
   }
 
   @java.lang.Override
@@ -110,6 +111,15 @@ private static final long serialVersionUID = 0L;
             matchID_ = s;
             break;
           }
+          case 58: {
+            if (!((mutable_bitField0_ & 0x00000002) != 0)) {
+              authorizationRules_ = new java.util.ArrayList<org.wso2.apk.enforcer.discovery.api.AuthorizationRule>();
+              mutable_bitField0_ |= 0x00000002;
+            }
+            authorizationRules_.add(
+                input.readMessage(org.wso2.apk.enforcer.discovery.api.AuthorizationRule.parser(), extensionRegistry));
+            break;
+          }
           default: {
             if (!parseUnknownField(
                 input, unknownFields, extensionRegistry, tag)) {
@@ -128,6 +138,9 @@ private static final long serialVersionUID = 0L;
       if (((mutable_bitField0_ & 0x00000001) != 0)) {
         scopes_ = scopes_.getUnmodifiableView();
       }
+      if (((mutable_bitField0_ & 0x00000002) != 0)) {
+        authorizationRules_ = java.util.Collections.unmodifiableList(authorizationRules_);
+      }
       this.unknownFields = unknownFields.build();
       makeExtensionsImmutable();
     }
@@ -311,10 +324,6 @@ private static final long serialVersionUID = 0L;
   public static final int MATCHID_FIELD_NUMBER = 6;
   private volatile java.lang.Object matchID_;
   /**
-   * <pre>
-   * MockedApiConfig mockedApiConfig = 6;
-   * </pre>
-   *
    * <code>string matchID = 6;</code>
    * @return The matchID.
    */
@@ -332,10 +341,6 @@ private static final long serialVersionUID = 0L;
     }
   }
   /**
-   * <pre>
-   * MockedApiConfig mockedApiConfig = 6;
-   * </pre>
-   *
    * <code>string matchID = 6;</code>
    * @return The bytes for matchID.
    */
@@ -354,6 +359,66 @@ private static final long serialVersionUID = 0L;
     }
   }
 
+  public static final int AUTHORIZATIONRULES_FIELD_NUMBER = 7;
+  private java.util.List<org.wso2.apk.enforcer.discovery.api.AuthorizationRule> authorizationRules_;
+  /**
+   * <pre>
+   * MockedApiConfig mockedApiConfig = 6;
+   * </pre>
+   *
+   * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+   */
+  @java.lang.Override
+  public java.util.List<org.wso2.apk.enforcer.discovery.api.AuthorizationRule> getAuthorizationRulesList() {
+    return authorizationRules_;
+  }
+  /**
+   * <pre>
+   * MockedApiConfig mockedApiConfig = 6;
+   * </pre>
+   *
+   * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+   */
+  @java.lang.Override
+  public java.util.List<? extends org.wso2.apk.enforcer.discovery.api.AuthorizationRuleOrBuilder> 
+      getAuthorizationRulesOrBuilderList() {
+    return authorizationRules_;
+  }
+  /**
+   * <pre>
+   * MockedApiConfig mockedApiConfig = 6;
+   * </pre>
+   *
+   * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+   */
+  @java.lang.Override
+  public int getAuthorizationRulesCount() {
+    return authorizationRules_.size();
+  }
+  /**
+   * <pre>
+   * MockedApiConfig mockedApiConfig = 6;
+   * </pre>
+   *
+   * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+   */
+  @java.lang.Override
+  public org.wso2.apk.enforcer.discovery.api.AuthorizationRule getAuthorizationRules(int index) {
+    return authorizationRules_.get(index);
+  }
+  /**
+   * <pre>
+   * MockedApiConfig mockedApiConfig = 6;
+   * </pre>
+   *
+   * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+   */
+  @java.lang.Override
+  public org.wso2.apk.enforcer.discovery.api.AuthorizationRuleOrBuilder getAuthorizationRulesOrBuilder(
+      int index) {
+    return authorizationRules_.get(index);
+  }
+
   private byte memoizedIsInitialized = -1;
   @java.lang.Override
   public final boolean isInitialized() {
@@ -386,6 +451,9 @@ private static final long serialVersionUID = 0L;
     if (!getMatchIDBytes().isEmpty()) {
       com.google.protobuf.GeneratedMessageV3.writeString(output, 6, matchID_);
     }
+    for (int i = 0; i < authorizationRules_.size(); i++) {
+      output.writeMessage(7, authorizationRules_.get(i));
+    }
     unknownFields.writeTo(output);
   }
 
@@ -420,6 +488,10 @@ private static final long serialVersionUID = 0L;
     if (!getMatchIDBytes().isEmpty()) {
       size += com.google.protobuf.GeneratedMessageV3.computeStringSize(6, matchID_);
     }
+    for (int i = 0; i < authorizationRules_.size(); i++) {
+      size += com.google.protobuf.CodedOutputStream
+        .computeMessageSize(7, authorizationRules_.get(i));
+    }
     size += unknownFields.getSerializedSize();
     memoizedSize = size;
     return size;
@@ -453,6 +525,8 @@ private static final long serialVersionUID = 0L;
         .equals(other.getScopesList())) return false;
     if (!getMatchID()
         .equals(other.getMatchID())) return false;
+    if (!getAuthorizationRulesList()
+        .equals(other.getAuthorizationRulesList())) return false;
     if (!unknownFields.equals(other.unknownFields)) return false;
     return true;
   }
@@ -482,6 +556,10 @@ private static final long serialVersionUID = 0L;
     }
     hash = (37 * hash) + MATCHID_FIELD_NUMBER;
     hash = (53 * hash) + getMatchID().hashCode();
+    if (getAuthorizationRulesCount() > 0) {
+      hash = (37 * hash) + AUTHORIZATIONRULES_FIELD_NUMBER;
+      hash = (53 * hash) + getAuthorizationRulesList().hashCode();
+    }
     hash = (29 * hash) + unknownFields.hashCode();
     memoizedHashCode = hash;
     return hash;
@@ -614,6 +692,7 @@ private static final long serialVersionUID = 0L;
     private void maybeForceBuilderInitialization() {
       if (com.google.protobuf.GeneratedMessageV3
               .alwaysUseFieldBuilders) {
+        getAuthorizationRulesFieldBuilder();
       }
     }
     @java.lang.Override
@@ -639,6 +718,12 @@ private static final long serialVersionUID = 0L;
       bitField0_ = (bitField0_ & ~0x00000001);
       matchID_ = "";
 
+      if (authorizationRulesBuilder_ == null) {
+        authorizationRules_ = java.util.Collections.emptyList();
+        bitField0_ = (bitField0_ & ~0x00000002);
+      } else {
+        authorizationRulesBuilder_.clear();
+      }
       return this;
     }
 
@@ -684,6 +769,15 @@ private static final long serialVersionUID = 0L;
       }
       result.scopes_ = scopes_;
       result.matchID_ = matchID_;
+      if (authorizationRulesBuilder_ == null) {
+        if (((bitField0_ & 0x00000002) != 0)) {
+          authorizationRules_ = java.util.Collections.unmodifiableList(authorizationRules_);
+          bitField0_ = (bitField0_ & ~0x00000002);
+        }
+        result.authorizationRules_ = authorizationRules_;
+      } else {
+        result.authorizationRules_ = authorizationRulesBuilder_.build();
+      }
       onBuilt();
       return result;
     }
@@ -760,6 +854,32 @@ private static final long serialVersionUID = 0L;
         matchID_ = other.matchID_;
         onChanged();
       }
+      if (authorizationRulesBuilder_ == null) {
+        if (!other.authorizationRules_.isEmpty()) {
+          if (authorizationRules_.isEmpty()) {
+            authorizationRules_ = other.authorizationRules_;
+            bitField0_ = (bitField0_ & ~0x00000002);
+          } else {
+            ensureAuthorizationRulesIsMutable();
+            authorizationRules_.addAll(other.authorizationRules_);
+          }
+          onChanged();
+        }
+      } else {
+        if (!other.authorizationRules_.isEmpty()) {
+          if (authorizationRulesBuilder_.isEmpty()) {
+            authorizationRulesBuilder_.dispose();
+            authorizationRulesBuilder_ = null;
+            authorizationRules_ = other.authorizationRules_;
+            bitField0_ = (bitField0_ & ~0x00000002);
+            authorizationRulesBuilder_ = 
+              com.google.protobuf.GeneratedMessageV3.alwaysUseFieldBuilders ?
+                 getAuthorizationRulesFieldBuilder() : null;
+          } else {
+            authorizationRulesBuilder_.addAllMessages(other.authorizationRules_);
+          }
+        }
+      }
       this.mergeUnknownFields(other.unknownFields);
       onChanged();
       return this;
@@ -1292,10 +1412,6 @@ private static final long serialVersionUID = 0L;
 
     private java.lang.Object matchID_ = "";
     /**
-     * <pre>
-     * MockedApiConfig mockedApiConfig = 6;
-     * </pre>
-     *
      * <code>string matchID = 6;</code>
      * @return The matchID.
      */
@@ -1312,10 +1428,6 @@ private static final long serialVersionUID = 0L;
       }
     }
     /**
-     * <pre>
-     * MockedApiConfig mockedApiConfig = 6;
-     * </pre>
-     *
      * <code>string matchID = 6;</code>
      * @return The bytes for matchID.
      */
@@ -1333,10 +1445,6 @@ private static final long serialVersionUID = 0L;
       }
     }
     /**
-     * <pre>
-     * MockedApiConfig mockedApiConfig = 6;
-     * </pre>
-     *
      * <code>string matchID = 6;</code>
      * @param value The matchID to set.
      * @return This builder for chaining.
@@ -1352,10 +1460,6 @@ private static final long serialVersionUID = 0L;
       return this;
     }
     /**
-     * <pre>
-     * MockedApiConfig mockedApiConfig = 6;
-     * </pre>
-     *
      * <code>string matchID = 6;</code>
      * @return This builder for chaining.
      */
@@ -1366,10 +1470,6 @@ private static final long serialVersionUID = 0L;
       return this;
     }
     /**
-     * <pre>
-     * MockedApiConfig mockedApiConfig = 6;
-     * </pre>
-     *
      * <code>string matchID = 6;</code>
      * @param value The bytes for matchID to set.
      * @return This builder for chaining.
@@ -1385,6 +1485,318 @@ private static final long serialVersionUID = 0L;
       onChanged();
       return this;
     }
+
+    private java.util.List<org.wso2.apk.enforcer.discovery.api.AuthorizationRule> authorizationRules_ =
+      java.util.Collections.emptyList();
+    private void ensureAuthorizationRulesIsMutable() {
+      if (!((bitField0_ & 0x00000002) != 0)) {
+        authorizationRules_ = new java.util.ArrayList<org.wso2.apk.enforcer.discovery.api.AuthorizationRule>(authorizationRules_);
+        bitField0_ |= 0x00000002;
+       }
+    }
+
+    private com.google.protobuf.RepeatedFieldBuilderV3<
+        org.wso2.apk.enforcer.discovery.api.AuthorizationRule, org.wso2.apk.enforcer.discovery.api.AuthorizationRule.Builder, org.wso2.apk.enforcer.discovery.api.AuthorizationRuleOrBuilder> authorizationRulesBuilder_;
+
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public java.util.List<org.wso2.apk.enforcer.discovery.api.AuthorizationRule> getAuthorizationRulesList() {
+      if (authorizationRulesBuilder_ == null) {
+        return java.util.Collections.unmodifiableList(authorizationRules_);
+      } else {
+        return authorizationRulesBuilder_.getMessageList();
+      }
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public int getAuthorizationRulesCount() {
+      if (authorizationRulesBuilder_ == null) {
+        return authorizationRules_.size();
+      } else {
+        return authorizationRulesBuilder_.getCount();
+      }
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public org.wso2.apk.enforcer.discovery.api.AuthorizationRule getAuthorizationRules(int index) {
+      if (authorizationRulesBuilder_ == null) {
+        return authorizationRules_.get(index);
+      } else {
+        return authorizationRulesBuilder_.getMessage(index);
+      }
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public Builder setAuthorizationRules(
+        int index, org.wso2.apk.enforcer.discovery.api.AuthorizationRule value) {
+      if (authorizationRulesBuilder_ == null) {
+        if (value == null) {
+          throw new NullPointerException();
+        }
+        ensureAuthorizationRulesIsMutable();
+        authorizationRules_.set(index, value);
+        onChanged();
+      } else {
+        authorizationRulesBuilder_.setMessage(index, value);
+      }
+      return this;
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public Builder setAuthorizationRules(
+        int index, org.wso2.apk.enforcer.discovery.api.AuthorizationRule.Builder builderForValue) {
+      if (authorizationRulesBuilder_ == null) {
+        ensureAuthorizationRulesIsMutable();
+        authorizationRules_.set(index, builderForValue.build());
+        onChanged();
+      } else {
+        authorizationRulesBuilder_.setMessage(index, builderForValue.build());
+      }
+      return this;
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public Builder addAuthorizationRules(org.wso2.apk.enforcer.discovery.api.AuthorizationRule value) {
+      if (authorizationRulesBuilder_ == null) {
+        if (value == null) {
+          throw new NullPointerException();
+        }
+        ensureAuthorizationRulesIsMutable();
+        authorizationRules_.add(value);
+        onChanged();
+      } else {
+        authorizationRulesBuilder_.addMessage(value);
+      }
+      return this;
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public Builder addAuthorizationRules(
+        int index, org.wso2.apk.enforcer.discovery.api.AuthorizationRule value) {
+      if (authorizationRulesBuilder_ == null) {
+        if (value == null) {
+          throw new NullPointerException();
+        }
+        ensureAuthorizationRulesIsMutable();
+        authorizationRules_.add(index, value);
+        onChanged();
+      } else {
+        authorizationRulesBuilder_.addMessage(index, value);
+      }
+      return this;
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public Builder addAuthorizationRules(
+        org.wso2.apk.enforcer.discovery.api.AuthorizationRule.Builder builderForValue) {
+      if (authorizationRulesBuilder_ == null) {
+        ensureAuthorizationRulesIsMutable();
+        authorizationRules_.add(builderForValue.build());
+        onChanged();
+      } else {
+        authorizationRulesBuilder_.addMessage(builderForValue.build());
+      }
+      return this;
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public Builder addAuthorizationRules(
+        int index, org.wso2.apk.enforcer.discovery.api.AuthorizationRule.Builder builderForValue) {
+      if (authorizationRulesBuilder_ == null) {
+        ensureAuthorizationRulesIsMutable();
+        authorizationRules_.add(index, builderForValue.build());
+        onChanged();
+      } else {
+        authorizationRulesBuilder_.addMessage(index, builderForValue.build());
+      }
+      return this;
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public Builder addAllAuthorizationRules(
+        java.lang.Iterable<? extends org.wso2.apk.enforcer.discovery.api.AuthorizationRule> values) {
+      if (authorizationRulesBuilder_ == null) {
+        ensureAuthorizationRulesIsMutable();
+        com.google.protobuf.AbstractMessageLite.Builder.addAll(
+            values, authorizationRules_);
+        onChanged();
+      } else {
+        authorizationRulesBuilder_.addAllMessages(values);
+      }
+      return this;
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public Builder clearAuthorizationRules() {
+      if (authorizationRulesBuilder_ == null) {
+        authorizationRules_ = java.util.Collections.emptyList();
+        bitField0_ = (bitField0_ & ~0x00000002);
+        onChanged();
+      } else {
+        authorizationRulesBuilder_.clear();
+      }
+      return this;
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public Builder removeAuthorizationRules(int index) {
+      if (authorizationRulesBuilder_ == null) {
+        ensureAuthorizationRulesIsMutable();
+        authorizationRules_.remove(index);
+        onChanged();
+      } else {
+        authorizationRulesBuilder_.remove(index);
+      }
+      return this;
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public org.wso2.apk.enforcer.discovery.api.AuthorizationRule.Builder getAuthorizationRulesBuilder(
+        int index) {
+      return getAuthorizationRulesFieldBuilder().getBuilder(index);
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public org.wso2.apk.enforcer.discovery.api.AuthorizationRuleOrBuilder getAuthorizationRulesOrBuilder(
+        int index) {
+      if (authorizationRulesBuilder_ == null) {
+        return authorizationRules_.get(index);  } else {
+        return authorizationRulesBuilder_.getMessageOrBuilder(index);
+      }
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public java.util.List<? extends org.wso2.apk.enforcer.discovery.api.AuthorizationRuleOrBuilder> 
+         getAuthorizationRulesOrBuilderList() {
+      if (authorizationRulesBuilder_ != null) {
+        return authorizationRulesBuilder_.getMessageOrBuilderList();
+      } else {
+        return java.util.Collections.unmodifiableList(authorizationRules_);
+      }
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public org.wso2.apk.enforcer.discovery.api.AuthorizationRule.Builder addAuthorizationRulesBuilder() {
+      return getAuthorizationRulesFieldBuilder().addBuilder(
+          org.wso2.apk.enforcer.discovery.api.AuthorizationRule.getDefaultInstance());
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public org.wso2.apk.enforcer.discovery.api.AuthorizationRule.Builder addAuthorizationRulesBuilder(
+        int index) {
+      return getAuthorizationRulesFieldBuilder().addBuilder(
+          index, org.wso2.apk.enforcer.discovery.api.AuthorizationRule.getDefaultInstance());
+    }
+    /**
+     * <pre>
+     * MockedApiConfig mockedApiConfig = 6;
+     * </pre>
+     *
+     * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+     */
+    public java.util.List<org.wso2.apk.enforcer.discovery.api.AuthorizationRule.Builder> 
+         getAuthorizationRulesBuilderList() {
+      return getAuthorizationRulesFieldBuilder().getBuilderList();
+    }
+    private com.google.protobuf.RepeatedFieldBuilderV3<
+        org.wso2.apk.enforcer.discovery.api.AuthorizationRule, org.wso2.apk.enforcer.discovery.api.AuthorizationRule.Builder, org.wso2.apk.enforcer.discovery.api.AuthorizationRuleOrBuilder> 
+        getAuthorizationRulesFieldBuilder() {
+      if (authorizationRulesBuilder_ == null) {
+        authorizationRulesBuilder_ = new com.google.protobuf.RepeatedFieldBuilderV3<
+            org.wso2.apk.enforcer.discovery.api.AuthorizationRule, org.wso2.apk.enforcer.discovery.api.AuthorizationRule.Builder, org.wso2.apk.enforcer.discovery.api.AuthorizationRuleOrBuilder>(
+                authorizationRules_,
+                ((bitField0_ & 0x00000002) != 0),
+                getParentForChildren(),
+                isClean());
+        authorizationRules_ = null;
+      }
+      return authorizationRulesBuilder_;
+    }
     @java.lang.Override
     public final Builder setUnknownFields(
         final com.google.protobuf.UnknownFieldSet unknownFields) {
@@ -86,23 +86,59 @@ public interface OperationOrBuilder extends
   com.google.protobuf.ByteString
       getScopesBytes(int index);
 
+  /**
+   * <code>string matchID = 6;</code>
+   * @return The matchID.
+   */
+  java.lang.String getMatchID();
+  /**
+   * <code>string matchID = 6;</code>
+   * @return The bytes for matchID.
+   */
+  com.google.protobuf.ByteString
+      getMatchIDBytes();
+
   /**
    * <pre>
    * MockedApiConfig mockedApiConfig = 6;
    * </pre>
    *
-   * <code>string matchID = 6;</code>
-   * @return The matchID.
+   * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
    */
-  java.lang.String getMatchID();
+  java.util.List<org.wso2.apk.enforcer.discovery.api.AuthorizationRule> 
+      getAuthorizationRulesList();
   /**
    * <pre>
    * MockedApiConfig mockedApiConfig = 6;
    * </pre>
    *
-   * <code>string matchID = 6;</code>
-   * @return The bytes for matchID.
+   * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
    */
-  com.google.protobuf.ByteString
-      getMatchIDBytes();
+  org.wso2.apk.enforcer.discovery.api.AuthorizationRule getAuthorizationRules(int index);
+  /**
+   * <pre>
+   * MockedApiConfig mockedApiConfig = 6;
+   * </pre>
+   *
+   * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+   */
+  int getAuthorizationRulesCount();
+  /**
+   * <pre>
+   * MockedApiConfig mockedApiConfig = 6;
+   * </pre>
+   *
+   * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+   */
+  java.util.List<? extends org.wso2.apk.enforcer.discovery.api.AuthorizationRuleOrBuilder> 
+      getAuthorizationRulesOrBuilderList();
+  /**
+   * <pre>
+   * MockedApiConfig mockedApiConfig = 6;
+   * </pre>
+   *
+   * <code>repeated .wso2.discovery.api.AuthorizationRule authorizationRules = 7;</code>
+   */
+  org.wso2.apk.enforcer.discovery.api.AuthorizationRuleOrBuilder getAuthorizationRulesOrBuilder(
+      int index);
 }
@@ -24,6 +24,11 @@ public final class ResourceProto {
   static final 
     com.google.protobuf.GeneratedMessageV3.FieldAccessorTable
       internal_static_wso2_discovery_api_Operation_fieldAccessorTable;
+  static final com.google.protobuf.Descriptors.Descriptor
+    internal_static_wso2_discovery_api_AuthorizationRule_descriptor;
+  static final 
+    com.google.protobuf.GeneratedMessageV3.FieldAccessorTable
+      internal_static_wso2_discovery_api_AuthorizationRule_fieldAccessorTable;
   static final com.google.protobuf.Descriptors.Descriptor
     internal_static_wso2_discovery_api_OperationPolicies_descriptor;
   static final 
@@ -57,23 +62,27 @@ public final class ResourceProto {
       "\035.wso2.discovery.api.Operation\0226\n\tendpoi" +
       "nts\030\004 \001(\0132#.wso2.discovery.api.EndpointC" +
       "luster\022:\n\020endpointSecurity\030\005 \003(\0132 .wso2." +
-      "discovery.api.SecurityInfo\"\305\001\n\tOperation" +
+      "discovery.api.SecurityInfo\"\210\002\n\tOperation" +
       "\022\016\n\006method\030\001 \001(\t\022@\n\021apiAuthentication\030\002 " +
       "\001(\0132%.wso2.discovery.api.APIAuthenticati" +
       "on\022\014\n\004tier\030\003 \001(\t\0227\n\010policies\030\004 \001(\0132%.wso" +
       "2.discovery.api.OperationPolicies\022\016\n\006sco" +
-      "pes\030\005 \003(\t\022\017\n\007matchID\030\006 \001(\t\"\231\001\n\021Operation" +
-      "Policies\022+\n\007request\030\001 \003(\0132\032.wso2.discove" +
-      "ry.api.Policy\022,\n\010response\030\002 \003(\0132\032.wso2.d" +
-      "iscovery.api.Policy\022)\n\005fault\030\003 \003(\0132\032.wso" +
-      "2.discovery.api.Policy\"\213\001\n\006Policy\022\016\n\006act" +
-      "ion\030\001 \001(\t\022>\n\nparameters\030\002 \003(\0132*.wso2.dis" +
-      "covery.api.Policy.ParametersEntry\0321\n\017Par" +
-      "ametersEntry\022\013\n\003key\030\001 \001(\t\022\r\n\005value\030\002 \001(\t" +
-      ":\0028\001Bu\n#org.wso2.apk.enforcer.discovery." +
-      "apiB\rResourceProtoP\001Z=github.com/envoypr" +
-      "oxy/go-control-plane/wso2/discovery/api;" +
-      "apib\006proto3"
+      "pes\030\005 \003(\t\022\017\n\007matchID\030\006 \001(\t\022A\n\022authorizat" +
+      "ionRules\030\007 \003(\0132%.wso2.discovery.api.Auth" +
+      "orizationRule\"X\n\021AuthorizationRule\022\014\n\004na" +
+      "me\030\001 \001(\t\022\020\n\010language\030\002 \001(\t\022\022\n\nexpression" +
+      "\030\003 \001(\t\022\017\n\007message\030\004 \001(\t\"\231\001\n\021OperationPol" +
+      "icies\022+\n\007request\030\001 \003(\0132\032.wso2.discovery." +
+      "api.Policy\022,\n\010response\030\002 \003(\0132\032.wso2.disc" +
+      "overy.api.Policy\022)\n\005fault\030\003 \003(\0132\032.wso2.d" +
+      "iscovery.api.Policy\"\213\001\n\006Policy\022\016\n\006action" +
+      "\030\001 \001(\t\022>\n\nparameters\030\002 \003(\0132*.wso2.discov" +
+      "ery.api.Policy.ParametersEntry\0321\n\017Parame" +
+      "tersEntry\022\013\n\003key\030\001 \001(\t\022\r\n\005value\030\002 \001(\t:\0028" +
+      "\001Bu\n#org.wso2.apk.enforcer.discovery.api" +
+      "B\rResourceProtoP\001Z=github.com/envoyproxy" +
+      "/go-control-plane/wso2/discovery/api;api" +
+      "b\006proto3"
     };
     descriptor = com.google.protobuf.Descriptors.FileDescriptor
       .internalBuildGeneratedFileFrom(descriptorData,
@@ -93,15 +102,21 @@ public final class ResourceProto {
     internal_static_wso2_discovery_api_Operation_fieldAccessorTable = new
       com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
         internal_static_wso2_discovery_api_Operation_descriptor,
-        new java.lang.String[] { "Method", "ApiAuthentication", "Tier", "Policies", "Scopes", "MatchID", });
-    internal_static_wso2_discovery_api_OperationPolicies_descriptor =
+        new java.lang.String[] { "Method", "ApiAuthentication", "Tier", "Policies", "Scopes", "MatchID", "AuthorizationRules", });
+    internal_static_wso2_discovery_api_AuthorizationRule_descriptor =
       getDescriptor().getMessageTypes().get(2);
+    internal_static_wso2_discovery_api_AuthorizationRule_fieldAccessorTable = new
+      com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
+        internal_static_wso2_discovery_api_AuthorizationRule_descriptor,
+        new java.lang.String[] { "Name", "Language", "Expression", "Message", });
+    internal_static_wso2_discovery_api_OperationPolicies_descriptor =
+      getDescriptor().getMessageTypes().get(3);
     internal_static_wso2_discovery_api_OperationPolicies_fieldAccessorTable = new
       com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
         internal_static_wso2_discovery_api_OperationPolicies_descriptor,
         new java.lang.String[] { "Request", "Response", "Fault", });
     internal_static_wso2_discovery_api_Policy_descriptor =
-      getDescriptor().getMessageTypes().get(3);
+      getDescriptor().getMessageTypes().get(4);
     internal_static_wso2_discovery_api_Policy_fieldAccessorTable = new
       com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
         internal_static_wso2_discovery_api_Policy_descriptor,
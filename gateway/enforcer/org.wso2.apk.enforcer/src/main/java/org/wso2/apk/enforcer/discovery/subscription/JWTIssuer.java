@@ -131,6 +131,19 @@ private static final long serialVersionUID = 0L;
             environments_.add(s);
             break;
           }
+          case 82: {
+            org.wso2.apk.enforcer.discovery.subscription.Introspection.Builder subBuilder = null;
+            if (introspection_ != null) {
+              subBuilder = introspection_.toBuilder();
+            }
+            introspection_ = input.readMessage(org.wso2.apk.enforcer.discovery.subscription.Introspection.parser(), extensionRegistry);
+            if (subBuilder != null) {
+              subBuilder.mergeFrom(introspection_);
+              introspection_ = subBuilder.buildPartial();
+            }
+
+            break;
+          }
           default: {
             if (!parseUnknownField(
                 input, unknownFields, extensionRegistry, tag)) {
@@ -548,6 +561,32 @@ private static final long serialVersionUID = 0L;
     return environments_.getByteString(index);
   }
 
+  public static final int INTROSPECTION_FIELD_NUMBER = 10;
+  private org.wso2.apk.enforcer.discovery.subscription.Introspection introspection_;
+  /**
+   * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+   * @return Whether the introspection field is set.
+   */
+  @java.lang.Override
+  public boolean hasIntrospection() {
+    return introspection_ != null;
+  }
+  /**
+   * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+   * @return The introspection.
+   */
+  @java.lang.Override
+  public org.wso2.apk.enforcer.discovery.subscription.Introspection getIntrospection() {
+    return introspection_ == null ? org.wso2.apk.enforcer.discovery.subscription.Introspection.getDefaultInstance() : introspection_;
+  }
+  /**
+   * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+   */
+  @java.lang.Override
+  public org.wso2.apk.enforcer.discovery.subscription.IntrospectionOrBuilder getIntrospectionOrBuilder() {
+    return getIntrospection();
+  }
+
   private byte memoizedIsInitialized = -1;
   @java.lang.Override
   public final boolean isInitialized() {
@@ -592,6 +631,9 @@ private static final long serialVersionUID = 0L;
     for (int i = 0; i < environments_.size(); i++) {
       com.google.protobuf.GeneratedMessageV3.writeString(output, 9, environments_.getRaw(i));
     }
+    if (introspection_ != null) {
+      output.writeMessage(10, getIntrospection());
+    }
     unknownFields.writeTo(output);
   }
 
@@ -641,6 +683,10 @@ private static final long serialVersionUID = 0L;
       size += dataSize;
       size += 1 * getEnvironmentsList().size();
     }
+    if (introspection_ != null) {
+      size += com.google.protobuf.CodedOutputStream
+        .computeMessageSize(10, getIntrospection());
+    }
     size += unknownFields.getSerializedSize();
     memoizedSize = size;
     return size;
@@ -677,6 +723,11 @@ private static final long serialVersionUID = 0L;
         other.internalGetClaimMapping())) return false;
     if (!getEnvironmentsList()
         .equals(other.getEnvironmentsList())) return false;
+    if (hasIntrospection() != other.hasIntrospection()) return false;
+    if (hasIntrospection()) {
+      if (!getIntrospection()
+          .equals(other.getIntrospection())) return false;
+    }
     if (!unknownFields.equals(other.unknownFields)) return false;
     return true;
   }
@@ -712,6 +763,10 @@ private static final long serialVersionUID = 0L;
       hash = (37 * hash) + ENVIRONMENTS_FIELD_NUMBER;
       hash = (53 * hash) + getEnvironmentsList().hashCode();
     }
+    if (hasIntrospection()) {
+      hash = (37 * hash) + INTROSPECTION_FIELD_NUMBER;
+      hash = (53 * hash) + getIntrospection().hashCode();
+    }
     hash = (29 * hash) + unknownFields.hashCode();
     memoizedHashCode = hash;
     return hash;
@@ -892,6 +947,12 @@ private static final long serialVersionUID = 0L;
       internalGetMutableClaimMapping().clear();
       environments_ = com.google.protobuf.LazyStringArrayList.EMPTY;
       bitField0_ = (bitField0_ & ~0x00000002);
+      if (introspectionBuilder_ == null) {
+        introspection_ = null;
+      } else {
+        introspection_ = null;
+        introspectionBuilder_ = null;
+      }
       return this;
     }
 
@@ -937,6 +998,11 @@ private static final long serialVersionUID = 0L;
         bitField0_ = (bitField0_ & ~0x00000002);
       }
       result.environments_ = environments_;
+      if (introspectionBuilder_ == null) {
+        result.introspection_ = introspection_;
+      } else {
+        result.introspection_ = introspectionBuilder_.build();
+      }
       onBuilt();
       return result;
     }
@@ -1024,6 +1090,9 @@ private static final long serialVersionUID = 0L;
         }
         onChanged();
       }
+      if (other.hasIntrospection()) {
+        mergeIntrospection(other.getIntrospection());
+      }
       this.mergeUnknownFields(other.unknownFields);
       onChanged();
       return this;
@@ -1866,6 +1935,125 @@ private static final long serialVersionUID = 0L;
       onChanged();
       return this;
     }
+
+    private org.wso2.apk.enforcer.discovery.subscription.Introspection introspection_;
+    private com.google.protobuf.SingleFieldBuilderV3<
+        org.wso2.apk.enforcer.discovery.subscription.Introspection, org.wso2.apk.enforcer.discovery.subscription.Introspection.Builder, org.wso2.apk.enforcer.discovery.subscription.IntrospectionOrBuilder> introspectionBuilder_;
+    /**
+     * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+     * @return Whether the introspection field is set.
+     */
+    public boolean hasIntrospection() {
+      return introspectionBuilder_ != null || introspection_ != null;
+    }
+    /**
+     * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+     * @return The introspection.
+     */
+    public org.wso2.apk.enforcer.discovery.subscription.Introspection getIntrospection() {
+      if (introspectionBuilder_ == null) {
+        return introspection_ == null ? org.wso2.apk.enforcer.discovery.subscription.Introspection.getDefaultInstance() : introspection_;
+      } else {
+        return introspectionBuilder_.getMessage();
+      }
+    }
+    /**
+     * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+     */
+    public Builder setIntrospection(org.wso2.apk.enforcer.discovery.subscription.Introspection value) {
+      if (introspectionBuilder_ == null) {
+        if (value == null) {
+          throw new NullPointerException();
+        }
+        introspection_ = value;
+        onChanged();
+      } else {
+        introspectionBuilder_.setMessage(value);
+      }
+
+      return this;
+    }
+    /**
+     * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+     */
+    public Builder setIntrospection(
+        org.wso2.apk.enforcer.discovery.subscription.Introspection.Builder builderForValue) {
+      if (introspectionBuilder_ == null) {
+        introspection_ = builderForValue.build();
+        onChanged();
+      } else {
+        introspectionBuilder_.setMessage(builderForValue.build());
+      }
+
+      return this;
+    }
+    /**
+     * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+     */
+    public Builder mergeIntrospection(org.wso2.apk.enforcer.discovery.subscription.Introspection value) {
+      if (introspectionBuilder_ == null) {
+        if (introspection_ != null) {
+          introspection_ =
+            org.wso2.apk.enforcer.discovery.subscription.Introspection.newBuilder(introspection_).mergeFrom(value).buildPartial();
+        } else {
+          introspection_ = value;
+        }
+        onChanged();
+      } else {
+        introspectionBuilder_.mergeFrom(value);
+      }
+
+      return this;
+    }
+    /**
+     * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+     */
+    public Builder clearIntrospection() {
+      if (introspectionBuilder_ == null) {
+        introspection_ = null;
+        onChanged();
+      } else {
+        introspection_ = null;
+        introspectionBuilder_ = null;
+      }
+
+      return this;
+    }
+    /**
+     * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+     */
+    public org.wso2.apk.enforcer.discovery.subscription.Introspection.Builder getIntrospectionBuilder() {
+      
+      onChanged();
+      return getIntrospectionFieldBuilder().getBuilder();
+    }
+    /**
+     * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+     */
+    public org.wso2.apk.enforcer.discovery.subscription.IntrospectionOrBuilder getIntrospectionOrBuilder() {
+      if (introspectionBuilder_ != null) {
+        return introspectionBuilder_.getMessageOrBuilder();
+      } else {
+        return introspection_ == null ?
+            org.wso2.apk.enforcer.discovery.subscription.Introspection.getDefaultInstance() : introspection_;
+      }
+    }
+    /**
+     * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+     */
+    private com.google.protobuf.SingleFieldBuilderV3<
+        org.wso2.apk.enforcer.discovery.subscription.Introspection, org.wso2.apk.enforcer.discovery.subscription.Introspection.Builder, org.wso2.apk.enforcer.discovery.subscription.IntrospectionOrBuilder> 
+        getIntrospectionFieldBuilder() {
+      if (introspectionBuilder_ == null) {
+        introspectionBuilder_ = new com.google.protobuf.SingleFieldBuilderV3<
+            org.wso2.apk.enforcer.discovery.subscription.Introspection, org.wso2.apk.enforcer.discovery.subscription.Introspection.Builder, org.wso2.apk.enforcer.discovery.subscription.IntrospectionOrBuilder>(
+                getIntrospection(),
+                getParentForChildren(),
+                isClean());
+        introspection_ = null;
+      }
+      return introspectionBuilder_;
+    }
     @java.lang.Override
     public final Builder setUnknownFields(
         final com.google.protobuf.UnknownFieldSet unknownFields) {
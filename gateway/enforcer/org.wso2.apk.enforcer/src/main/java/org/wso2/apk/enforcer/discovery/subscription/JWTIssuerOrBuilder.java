@@ -152,4 +152,19 @@ public interface JWTIssuerOrBuilder extends
    */
   com.google.protobuf.ByteString
       getEnvironmentsBytes(int index);
+
+  /**
+   * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+   * @return Whether the introspection field is set.
+   */
+  boolean hasIntrospection();
+  /**
+   * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+   * @return The introspection.
+   */
+  org.wso2.apk.enforcer.discovery.subscription.Introspection getIntrospection();
+  /**
+   * <code>.wso2.discovery.subscription.Introspection introspection = 10;</code>
+   */
+  org.wso2.apk.enforcer.discovery.subscription.IntrospectionOrBuilder getIntrospectionOrBuilder();
 }
@@ -34,6 +34,11 @@ public final class JWTIssuerProto {
   static final 
     com.google.protobuf.GeneratedMessageV3.FieldAccessorTable
       internal_static_wso2_discovery_subscription_JWKS_fieldAccessorTable;
+  static final com.google.protobuf.Descriptors.Descriptor
+    internal_static_wso2_discovery_subscription_Introspection_descriptor;
+  static final 
+    com.google.protobuf.GeneratedMessageV3.FieldAccessorTable
+      internal_static_wso2_discovery_subscription_Introspection_fieldAccessorTable;
 
   public static com.google.protobuf.Descriptors.FileDescriptor
       getDescriptor() {
@@ -44,7 +49,7 @@ public final class JWTIssuerProto {
   static {
     java.lang.String[] descriptorData = {
       "\n+wso2/discovery/subscription/jwtIssuer." +
-      "proto\022\033wso2.discovery.subscription\"\331\002\n\tJ" +
+      "proto\022\033wso2.discovery.subscription\"\234\003\n\tJ" +
       "WTIssuer\022\017\n\007eventId\030\001 \001(\t\022\014\n\004name\030\002 \001(\t\022" +
       "\024\n\014organization\030\003 \001(\t\022\016\n\006issuer\030\004 \001(\t\022=\n" +
       "\013certificate\030\005 \001(\0132(.wso2.discovery.subs" +
@@ -52,15 +57,20 @@ public final class JWTIssuerProto {
       "\030\006 \001(\t\022\023\n\013scopesClaim\030\007 \001(\t\022N\n\014claimMapp" +
       "ing\030\010 \003(\01328.wso2.discovery.subscription." +
       "JWTIssuer.ClaimMappingEntry\022\024\n\014environme" +
-      "nts\030\t \003(\t\0323\n\021ClaimMappingEntry\022\013\n\003key\030\001 " +
-      "\001(\t\022\r\n\005value\030\002 \001(\t:\0028\001\"S\n\013Certificate\022\023\n" +
-      "\013certificate\030\001 \001(\t\022/\n\004jwks\030\002 \001(\0132!.wso2." +
-      "discovery.subscription.JWKS\" \n\004JWKS\022\013\n\003u" +
-      "rl\030\001 \001(\t\022\013\n\003tls\030\002 \001(\tB\221\001\n,org.wso2.apk.e" +
-      "nforcer.discovery.subscriptionB\016JWTIssue" +
-      "rProtoP\001ZOgithub.com/envoyproxy/go-contr" +
-      "ol-plane/wso2/discovery/subscription;sub" +
-      "scriptionb\006proto3"
+      "nts\030\t \003(\t\022A\n\rintrospection\030\n \001(\0132*.wso2." +
+      "discovery.subscription.Introspection\0323\n\021" +
+      "ClaimMappingEntry\022\013\n\003key\030\001 \001(\t\022\r\n\005value\030" +
+      "\002 \001(\t:\0028\001\"S\n\013Certificate\022\023\n\013certificate\030" +
+      "\001 \001(\t\022/\n\004jwks\030\002 \001(\0132!.wso2.discovery.sub" +
+      "scription.JWKS\" \n\004JWKS\022\013\n\003url\030\001 \001(\t\022\013\n\003t" +
+      "ls\030\002 \001(\t\"\205\001\n\rIntrospection\022\013\n\003url\030\001 \001(\t\022" +
+      "\013\n\003tls\030\002 \001(\t\022\020\n\010clientId\030\003 \001(\t\022\024\n\014client" +
+      "Secret\030\004 \001(\t\022\030\n\020positiveCacheTTL\030\005 \001(\r\022\030" +
+      "\n\020negativeCacheTTL\030\006 \001(\rB\221\001\n,org.wso2.ap" +
+      "k.enforcer.discovery.subscriptionB\016JWTIs" +
+      "suerProtoP\001ZOgithub.com/envoyproxy/go-co" +
+      "ntrol-plane/wso2/discovery/subscription;" +
+      "subscriptionb\006proto3"
     };
     descriptor = com.google.protobuf.Descriptors.FileDescriptor
       .internalBuildGeneratedFileFrom(descriptorData,
@@ -71,7 +81,7 @@ public final class JWTIssuerProto {
     internal_static_wso2_discovery_subscription_JWTIssuer_fieldAccessorTable = new
       com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
         internal_static_wso2_discovery_subscription_JWTIssuer_descriptor,
-        new java.lang.String[] { "EventId", "Name", "Organization", "Issuer", "Certificate", "ConsumerKeyClaim", "ScopesClaim", "ClaimMapping", "Environments", });
+        new java.lang.String[] { "EventId", "Name", "Organization", "Issuer", "Certificate", "ConsumerKeyClaim", "ScopesClaim", "ClaimMapping", "Environments", "Introspection", });
     internal_static_wso2_discovery_subscription_JWTIssuer_ClaimMappingEntry_descriptor =
       internal_static_wso2_discovery_subscription_JWTIssuer_descriptor.getNestedTypes().get(0);
     internal_static_wso2_discovery_subscription_JWTIssuer_ClaimMappingEntry_fieldAccessorTable = new
@@ -90,6 +100,12 @@ public final class JWTIssuerProto {
       com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
         internal_static_wso2_discovery_subscription_JWKS_descriptor,
         new java.lang.String[] { "Url", "Tls", });
+    internal_static_wso2_discovery_subscription_Introspection_descriptor =
+      getDescriptor().getMessageTypes().get(3);
+    internal_static_wso2_discovery_subscription_Introspection_fieldAccessorTable = new
+      com.google.protobuf.GeneratedMessageV3.FieldAccessorTable(
+        internal_static_wso2_discovery_subscription_Introspection_descriptor,
+        new java.lang.String[] { "Url", "Tls", "ClientId", "ClientSecret", "PositiveCacheTTL", "NegativeCacheTTL", });
   }
 
   // @@protoc_insertion_point(outer_class_scope)
@@ -19,14 +19,23 @@ package org.wso2.apk.enforcer.graphql;
 
 import graphql.language.Definition;
 import graphql.language.Document;
+import graphql.language.Field;
+import graphql.language.FragmentDefinition;
+import graphql.language.FragmentSpread;
+import graphql.language.InlineFragment;
 import graphql.language.OperationDefinition;
+import graphql.language.Selection;
+import graphql.language.SelectionSet;
 import graphql.parser.InvalidSyntaxException;
 import graphql.parser.Parser;
 import graphql.schema.GraphQLSchema;
 import graphql.validation.Validator;
 import org.apache.commons.lang3.StringUtils;
+import org.apache.http.NameValuePair;
+import org.apache.http.client.utils.URLEncodedUtils;
 import org.apache.logging.log4j.LogManager;
 import org.apache.logging.log4j.Logger;
+import org.json.JSONArray;
 import org.json.JSONException;
 import org.json.JSONObject;
 import org.wso2.apk.enforcer.commons.constants.GraphQLConstants;
@@ -42,13 +51,19 @@ import org.wso2.apk.enforcer.commons.logging.ErrorDetails;
 import org.wso2.apk.enforcer.commons.logging.LoggingConstants;
 import org.wso2.apk.enforcer.commons.model.GraphQLCustomComplexityInfoDTO;
 import org.wso2.apk.enforcer.commons.model.GraphQLSchemaDTO;
+import org.wso2.apk.enforcer.commons.model.RequestContext;
 import org.wso2.apk.enforcer.commons.model.ResourceConfig;
 import org.wso2.apk.enforcer.constants.APIConstants;
 
+import java.nio.charset.StandardCharsets;
 import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.HashSet;
+import java.util.LinkedHashSet;
 import java.util.List;
 import java.util.Locale;
 import java.util.Map;
+import java.util.Set;
 
 /**
  * utils for GraphQL Request payload processing.
@@ -118,6 +133,143 @@ public class GraphQLPayloadUtils {
         }
     }
 
+    /**
+     * This method resolves the operations of the queries of a batched request.
+     *
+     * @param api          matched api
+     * @param batchQueries graphQL queries of the batch
+     * @param routeName    name of the matched route
+     * @return matching resource configs for the queries, or an empty list if an operation is not matched
+     * @throws EnforcerException use for error response handling
+     */
+    public static ArrayList<ResourceConfig> buildGQLBatchRequestContext(API api, List<String> batchQueries,
+                                                                        String routeName) throws EnforcerException {
+        ArrayList<ResourceConfig> resourceConfigs = new ArrayList<>();
+        for (String query : batchQueries) {
+            ArrayList<ResourceConfig> queryResourceConfigs = buildGQLRequestContext(api, query, routeName);
+            if (queryResourceConfigs.isEmpty()) {
+                return queryResourceConfigs;
+            }
+            for (ResourceConfig resourceConfig : queryResourceConfigs) {
+                if (!resourceConfigs.contains(resourceConfig)) {
+                    resourceConfigs.add(resourceConfig);
+                }
+            }
+        }
+        return resourceConfigs;
+    }
+
+    /**
+     * This method resolves the subscription fields of the websocket handshake of a GraphQL subscription. All the
+     * subscription fields of the API are resolved when the handshake does not carry the subscription document.
+     *
+     * @param api       matched api
+     * @param queryBody graphQL subscription, or null if the handshake does not carry it
+     * @param routeName name of the matched route
+     * @return matching resource configs for the handshake
+     * @throws EnforcerException use for error response handling
+     */
+    public static ArrayList<ResourceConfig> buildGQLSubscriptionRequestContext(API api, String queryBody,
+                                                                               String routeName)
+            throws EnforcerException {
+        ArrayList<ResourceConfig> resourceConfigs;
+        if (StringUtils.isBlank(queryBody)) {
+            resourceConfigs = new ArrayList<>();
+            for (ResourceConfig resourceConfig : api.getAPIConfig().getResources()) {
+                if (resourceConfig.getMethod() == ResourceConfig.HttpMethods.SUBSCRIPTION) {
+                    resourceConfigs.add(resourceConfig);
+                }
+            }
+            return resourceConfigs;
+        }
+        resourceConfigs = buildGQLRequestContext(api, queryBody, routeName);
+        for (ResourceConfig resourceConfig : resourceConfigs) {
+            if (resourceConfig.getMethod() != ResourceConfig.HttpMethods.SUBSCRIPTION) {
+                throw new EnforcerException("Only subscription operations are allowed over a websocket connection");
+            }
+        }
+        return resourceConfigs;
+    }
+
+    /**
+     * This method resolves the subscription fields selected by the subscription document of a subscribe message. The
+     * client chooses the operation of the document to execute, hence every operation of the document must be a
+     * subscription, and the fields of all the operations are resolved.
+     *
+     * @param schema    graphQL schema of the API
+     * @param queryBody graphQL subscription document
+     * @return names of the selected subscription fields
+     * @throws EnforcerException if the document is invalid or it is not a subscription
+     */
+    public static Set<String> getGQLSubscriptionFields(GraphQLSchema schema, String queryBody)
+            throws EnforcerException {
+        Document document;
+        try {
+            document = new Parser().parseDocument(queryBody);
+        } catch (InvalidSyntaxException exception) {
+            throw new EnforcerException("Invalid syntax", exception);
+        }
+        String validationErrors = validatePayloadWithSchema(schema, document);
+        if (validationErrors != null) {
+            throw new EnforcerException("Payload is invalid", new Exception(validationErrors));
+        }
+        Map<String, FragmentDefinition> fragments = new HashMap<>();
+        for (FragmentDefinition fragment : document.getDefinitionsOfType(FragmentDefinition.class)) {
+            fragments.put(fragment.getName(), fragment);
+        }
+        Set<String> fields = new LinkedHashSet<>();
+        for (OperationDefinition operation : document.getDefinitionsOfType(OperationDefinition.class)) {
+            if (operation.getOperation() != OperationDefinition.Operation.SUBSCRIPTION) {
+                throw new EnforcerException("Only subscription operations are allowed over a websocket connection");
+            }
+            collectRootFields(operation.getSelectionSet(), fragments, new HashSet<>(), fields);
+        }
+        if (fields.isEmpty()) {
+            throw new EnforcerException("Subscription does not select a subscription field");
+        }
+        return fields;
+    }
+
+    private static void collectRootFields(SelectionSet selectionSet, Map<String, FragmentDefinition> fragments,
+                                          Set<String> visitedFragments, Set<String> fields) {
+        if (selectionSet == null) {
+            return;
+        }
+        for (Selection<?> selection : selectionSet.getSelections()) {
+            if (selection instanceof Field) {
+                fields.add(((Field) selection).getName());
+            } else if (selection instanceof InlineFragment) {
+                collectRootFields(((InlineFragment) selection).getSelectionSet(), fragments, visitedFragments,
+                        fields);
+            } else if (selection instanceof FragmentSpread) {
+                String fragmentName = ((FragmentSpread) selection).getName();
+                FragmentDefinition fragment = fragments.get(fragmentName);
+                if (fragment != null && visitedFragments.add(fragmentName)) {
+                    collectRootFields(fragment.getSelectionSet(), fragments, visitedFragments, fields);
+                }
+            }
+        }
+    }
+
+    /**
+     * This method returns the graphQL subscription carried in the query parameter of a websocket handshake.
+     *
+     * @param requestPath request path of the handshake
+     * @return graphQL subscription, or null if the handshake does not carry it
+     */
+    public static String getGQLSubscriptionPayload(String requestPath) {
+        String[] pathParts = requestPath.split("\\?", 2);
+        if (pathParts.length < 2) {
+            return null;
+        }
+        for (NameValuePair queryParam : URLEncodedUtils.parse(pathParts[1], StandardCharsets.UTF_8)) {
+            if (GraphQLConstants.SubscriptionConstants.PAYLOAD_FIELD_NAME_QUERY.equals(queryParam.getName())) {
+                return queryParam.getValue();
+            }
+        }
+        return null;
+    }
+
     /**
      * @param requestPayload request payload
      * @param requestHeaders request headers
@@ -149,6 +301,63 @@ public class GraphQLPayloadUtils {
         throw new EnforcerException("Query cannot be empty");
     }
 
+    /**
+     * This method returns the queries of a batched request, which carries a JSON array of graphQL requests.
+     *
+     * @param requestPayload request payload
+     * @param requestHeaders request headers
+     * @return queries of the batch, or null if the request is not batched
+     * @throws EnforcerException invalid payloads
+     */
+    public static List<String> getGQLBatchRequestPayloads(String requestPayload, Map<String, String> requestHeaders)
+            throws EnforcerException {
+        if (requestPayload == null || !requestPayload.trim().startsWith("[") ||
+                (requestHeaders.containsKey(APIConstants.CONTENT_TYPE_HEADER) && !APIConstants.APPLICATION_JSON
+                        .equalsIgnoreCase(requestHeaders.get(APIConstants.CONTENT_TYPE_HEADER)))) {
+            return null;
+        }
+        List<String> batchQueries = new ArrayList<>();
+        try {
+            JSONArray jsonArray = new JSONArray(requestPayload);
+            for (int i = 0; i < jsonArray.length(); i++) {
+                String queryBody = jsonArray.getJSONObject(i)
+                        .getString(GraphQLConstants.GRAPHQL_QUERY.toLowerCase(Locale.ROOT));
+                if (StringUtils.isBlank(queryBody)) {
+                    throw new EnforcerException("Query cannot be empty");
+                }
+                batchQueries.add(queryBody);
+            }
+        } catch (JSONException e) {
+            throw new EnforcerException("Invalid GraphQL batch query body structure");
+        }
+        if (batchQueries.isEmpty()) {
+            throw new EnforcerException("Batch cannot be empty");
+        }
+        return batchQueries;
+    }
+
+    /**
+     * This method adds the limits of the GraphQL protection policy of the matched route to the properties of the
+     * request context, from which the query analysis filter applies them.
+     *
+     * @param requestContext    request context
+     * @param contextExtensions context extensions of the matched route
+     */
+    public static void addGQLProtectionProperties(RequestContext requestContext,
+                                                  Map<String, String> contextExtensions) {
+        for (String limit : new String[]{GraphQLConstants.GRAPHQL_MAX_DEPTH, GraphQLConstants.GRAPHQL_MAX_COMPLEXITY,
+                GraphQLConstants.GRAPHQL_MAX_ALIASES, GraphQLConstants.GRAPHQL_MAX_BATCH_SIZE}) {
+            if (contextExtensions.containsKey(limit)) {
+                // the limits are unsigned 32 bit values, which are capped to the range of an integer
+                long value = Long.parseLong(contextExtensions.get(limit));
+                requestContext.getProperties().put(limit, (int) Math.min(value, Integer.MAX_VALUE));
+            }
+        }
+        if (Boolean.parseBoolean(contextExtensions.get(GraphQLConstants.GRAPHQL_DISABLE_INTROSPECTION))) {
+            requestContext.getProperties().put(GraphQLConstants.GRAPHQL_DISABLE_INTROSPECTION, true);
+        }
+    }
+
     /**
      * This method validate the payload.
      *
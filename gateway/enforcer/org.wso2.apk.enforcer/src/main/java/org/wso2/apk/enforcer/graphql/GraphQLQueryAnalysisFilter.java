@@ -29,11 +29,14 @@ import org.wso2.apk.enforcer.commons.Filter;
 import org.wso2.apk.enforcer.commons.logging.ErrorDetails;
 import org.wso2.apk.enforcer.commons.logging.LoggingConstants;
 import org.wso2.apk.enforcer.commons.model.APIConfig;
+import org.wso2.apk.enforcer.commons.model.AuthenticationContext;
 import org.wso2.apk.enforcer.commons.model.GraphQLCustomComplexityInfoDTO;
 import org.wso2.apk.enforcer.commons.model.RequestContext;
 import org.wso2.apk.enforcer.constants.APIConstants;
 import org.wso2.apk.enforcer.constants.APISecurityConstants;
+import org.wso2.apk.enforcer.constants.MetadataConstants;
 
+import java.util.Collections;
 import java.util.HashMap;
 import java.util.LinkedHashMap;
 import java.util.List;
@@ -41,7 +44,9 @@ import java.util.Map;
 
 /**
  * This Handler can be used to analyse GraphQL Query. This implementation uses previously set
- * complexity and depth limitation to block the complex queries before it reaches the backend.
+ * complexity and depth limitation to block the complex queries before it reaches the backend. The limits of the
+ * GraphQL protection policy of the API also block the queries with too many aliases or introspection fields, and the
+ * batches with too many queries.
  */
 public class GraphQLQueryAnalysisFilter implements Filter {
 
@@ -56,14 +61,47 @@ public class GraphQLQueryAnalysisFilter implements Filter {
 
     @Override
     public boolean handleRequest(RequestContext requestContext) {
+        if (Boolean.TRUE.equals(requestContext.getProperties().get(GraphQLConstants.GRAPHQL_SUBSCRIPTION))) {
+            addSubscriptionMetadata(requestContext);
+        }
         String payload = requestContext.getRequestPayload();
-        if (!isDepthAndComplexityValid(requestContext, payload)) {
-            logger.debug("Query was blocked by the static query analyser");
-            return false;
+        List<String> queries;
+        if (requestContext.getProperties().containsKey(GraphQLConstants.GRAPHQL_BATCH_QUERIES)) {
+            queries = (List<String>) requestContext.getProperties().get(GraphQLConstants.GRAPHQL_BATCH_QUERIES);
+            if (!isBatchSizeValid(requestContext, queries.size())) {
+                return false;
+            }
+        } else if (payload == null) {
+            // the websocket handshake of a subscription may not carry the subscription document.
+            return true;
+        } else {
+            queries = Collections.singletonList(payload);
+        }
+        for (String query : queries) {
+            if (!isQueryAllowed(requestContext, query)) {
+                logger.debug("Query was blocked by the static query analyser");
+                return false;
+            }
         }
         return true;
     }
 
+    /**
+     * This method passes the API and the scopes of the token of the websocket handshake of a subscription to the
+     * external processor, which authorizes the subscribe messages of the connection.
+     *
+     * @param requestContext message context of the request
+     */
+    private void addSubscriptionMetadata(RequestContext requestContext) {
+        requestContext.addMetadataToMap(MetadataConstants.GRAPHQL_SUBSCRIPTION_API,
+                requestContext.getMatchedAPI().getUuid());
+        AuthenticationContext authenticationContext = requestContext.getAuthenticationContext();
+        if (authenticationContext != null && authenticationContext.getScopes() != null) {
+            requestContext.addMetadataToMap(MetadataConstants.GRAPHQL_SUBSCRIPTION_SCOPES,
+                    String.join(" ", authenticationContext.getScopes()));
+        }
+    }
+
     /**
      * This method analyses the query.
      *
@@ -71,9 +109,10 @@ public class GraphQLQueryAnalysisFilter implements Filter {
      * @param payload        payload of the request
      * @return true, if the query is not blocked or false, if the query is blocked
      */
-    private boolean isDepthAndComplexityValid(RequestContext requestContext, String payload) {
+    private boolean isQueryAllowed(RequestContext requestContext, String payload) {
         try {
-            return isDepthValid(requestContext, payload) && isComplexityValid(requestContext, payload);
+            return isIntrospectionAllowed(requestContext, payload) && isAliasCountValid(requestContext, payload) &&
+                    isDepthValid(requestContext, payload) && isComplexityValid(requestContext, payload);
         } catch (Exception e) {
             logger.error("Policy definition parsing failed for API UUID : {} API : {} version : {}",
                     requestContext.getMatchedAPI().getUuid(), requestContext.getMatchedAPI().getName(),
@@ -84,11 +123,58 @@ public class GraphQLQueryAnalysisFilter implements Filter {
         }
     }
 
-    private boolean isDepthValid(RequestContext requestContext, String payload) {
-        int maxQueryDepth = -1;
-        if (requestContext.getProperties().containsKey(GraphQLConstants.MAXIMUM_QUERY_DEPTH)) {
-            maxQueryDepth = (Integer) requestContext.getProperties().get(GraphQLConstants.MAXIMUM_QUERY_DEPTH);
+    private boolean isBatchSizeValid(RequestContext requestContext, int batchSize) {
+        int maxBatchSize = getLimit(requestContext, GraphQLConstants.GRAPHQL_MAX_BATCH_SIZE);
+        if (maxBatchSize > 0 && batchSize > maxBatchSize) {
+            String errorDescription = "maximum batch size " + maxBatchSize + " exceeded with " + batchSize +
+                    " queries";
+            handleFailure(requestContext, GraphQLConstants.GRAPHQL_BATCH_TOO_LARGE,
+                    GraphQLConstants.GRAPHQL_BATCH_TOO_LARGE_MESSAGE, errorDescription);
+            logger.debug("Requested batch's size has exceeded. API : {}, version : {}, Error : {}",
+                    requestContext.getMatchedAPI().getName(), requestContext.getMatchedAPI().getVersion(),
+                    errorDescription);
+            return false;
+        }
+        return true;
+    }
+
+    private boolean isIntrospectionAllowed(RequestContext requestContext, String payload) {
+        if (!Boolean.TRUE.equals(requestContext.getProperties()
+                .get(GraphQLConstants.GRAPHQL_DISABLE_INTROSPECTION))) {
+            return true;
+        }
+        QueryAnalyzerResponseDTO responseDTO = queryAnalyzer.analyseQueryIntrospection(payload);
+        if (!responseDTO.isSuccess()) {
+            handleFailure(requestContext, GraphQLConstants.GRAPHQL_INTROSPECTION_DISABLED,
+                    GraphQLConstants.GRAPHQL_INTROSPECTION_DISABLED_MESSAGE, responseDTO.getErrorList().toString());
+            logger.debug("Requested query's introspection is disabled. API : {}, version : {}, Error : {}",
+                    requestContext.getMatchedAPI().getName(), requestContext.getMatchedAPI().getVersion(),
+                    responseDTO.getErrorList().toString());
+            return false;
+        }
+        return true;
+    }
+
+    private boolean isAliasCountValid(RequestContext requestContext, String payload) {
+        int maxAliases = -1;
+        if (requestContext.getProperties().containsKey(GraphQLConstants.GRAPHQL_MAX_ALIASES)) {
+            maxAliases = (Integer) requestContext.getProperties().get(GraphQLConstants.GRAPHQL_MAX_ALIASES);
         }
+        QueryAnalyzerResponseDTO responseDTO = queryAnalyzer.analyseQueryAliases(maxAliases, payload);
+        if (!responseDTO.isSuccess()) {
+            handleFailure(requestContext, GraphQLConstants.GRAPHQL_TOO_MANY_ALIASES,
+                    GraphQLConstants.GRAPHQL_TOO_MANY_ALIASES_MESSAGE, responseDTO.getErrorList().toString());
+            logger.debug("Requested query's aliases have exceeded. API : {}, version : {}, Error : {}",
+                    requestContext.getMatchedAPI().getName(), requestContext.getMatchedAPI().getVersion(),
+                    responseDTO.getErrorList().toString());
+            return false;
+        }
+        return true;
+    }
+
+    private boolean isDepthValid(RequestContext requestContext, String payload) {
+        int maxQueryDepth = getLimit(requestContext, GraphQLConstants.MAXIMUM_QUERY_DEPTH,
+                GraphQLConstants.GRAPHQL_MAX_DEPTH);
         QueryAnalyzerResponseDTO responseDTO = queryAnalyzer.analyseQueryDepth(maxQueryDepth, payload);
         if (!responseDTO.isSuccess() && !responseDTO.getErrorList().isEmpty()) {
             handleFailure(requestContext, GraphQLConstants.GRAPHQL_QUERY_TOO_DEEP,
@@ -103,11 +189,8 @@ public class GraphQLQueryAnalysisFilter implements Filter {
     }
 
     private boolean isComplexityValid(RequestContext requestContext, String payload) {
-        int queryComplexity = -1;
-        if (requestContext.getProperties().containsKey(GraphQLConstants.MAXIMUM_QUERY_COMPLEXITY)) {
-            queryComplexity = (Integer) requestContext.getProperties()
-                    .get(GraphQLConstants.MAXIMUM_QUERY_COMPLEXITY);
-        }
+        int queryComplexity = getLimit(requestContext, GraphQLConstants.MAXIMUM_QUERY_COMPLEXITY,
+                GraphQLConstants.GRAPHQL_MAX_COMPLEXITY);
         QueryAnalyzerResponseDTO responseDTO = null;
         try {
             responseDTO = queryAnalyzer.analyseQueryMutationComplexity(payload, queryComplexity,
@@ -163,6 +246,27 @@ public class GraphQLQueryAnalysisFilter implements Filter {
                 errorMessage);
         requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_DESCRIPTION,
                 errorDescription);
+        requestContext.getProperties().put(GraphQLConstants.GRAPHQL_ERROR_RESPONSE, true);
+    }
+
+    /**
+     * This method returns the strictest of the given positive limits set in the request context, which are set by
+     * the subscription policy of the application and the GraphQL protection policy of the API.
+     *
+     * @param requestContext message context of the request
+     * @param limits         names of the limits
+     * @return the strictest limit, or -1 if none of the limits is set
+     */
+    private int getLimit(RequestContext requestContext, String... limits) {
+        int strictestLimit = -1;
+        for (String limit : limits) {
+            Object value = requestContext.getProperties().get(limit);
+            if (value instanceof Integer && (Integer) value > 0 &&
+                    (strictestLimit < 0 || (Integer) value < strictestLimit)) {
+                strictestLimit = (Integer) value;
+            }
+        }
+        return strictestLimit;
     }
 
 
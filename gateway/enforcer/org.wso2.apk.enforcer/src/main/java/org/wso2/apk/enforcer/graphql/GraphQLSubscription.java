@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.graphql;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import io.envoyproxy.envoy.extensions.common.ratelimit.v3.RateLimitDescriptor;
+import io.envoyproxy.envoy.type.v3.StatusCode;
+import org.apache.commons.lang3.StringUtils;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.json.JSONException;
+import org.json.JSONObject;
+import org.wso2.apk.enforcer.commons.constants.GraphQLConstants;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+import org.wso2.apk.enforcer.commons.model.APIConfig;
+import org.wso2.apk.enforcer.commons.model.ResourceConfig;
+import org.wso2.apk.enforcer.constants.APISecurityConstants;
+import org.wso2.apk.enforcer.constants.GeneralErrorCodeConstants;
+
+import java.nio.charset.StandardCharsets;
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.Base64;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.function.Predicate;
+
+/**
+ * Subscriptions of a GraphQL API, whose subscribe messages are authorized against the scopes and the rate limits of
+ * the subscribed fields. The websocket handshake of a connection does not carry the subscriptions of the connection,
+ * hence they are authorized on each subscribe message of the graphql-ws and graphql-transport-ws protocols. The
+ * configurations are received from the adapter as base64 encoded json in the route metadata.
+ */
+public class GraphQLSubscription {
+
+    private static final Logger logger = LogManager.getLogger(GraphQLSubscription.class);
+    private static final ObjectMapper mapper = new ObjectMapper();
+    private static final Map<String, GraphQLSubscription> subscriptions = new ConcurrentHashMap<>();
+
+    // descriptors of the rate limits of the subscription fields
+    private final Map<String, RateLimitDescriptor> rateLimits = new HashMap<>();
+
+    private GraphQLSubscription(SubscriptionConfig config) {
+        if (config.rateLimits == null) {
+            return;
+        }
+        for (Map.Entry<String, List<DescriptorEntry>> rateLimit : config.rateLimits.entrySet()) {
+            RateLimitDescriptor.Builder descriptor = RateLimitDescriptor.newBuilder();
+            for (DescriptorEntry entry : rateLimit.getValue()) {
+                descriptor.addEntries(RateLimitDescriptor.Entry.newBuilder().setKey(entry.key)
+                        .setValue(entry.value).build());
+            }
+            rateLimits.put(rateLimit.getKey(), descriptor.build());
+        }
+    }
+
+    /**
+     * Returns the subscriptions of the given configurations.
+     *
+     * @param encodedConfig base64 encoded json of the subscriptions
+     * @return the subscriptions, or null if the configurations could not be read
+     */
+    public static GraphQLSubscription fromEncodedConfig(String encodedConfig) {
+        if (encodedConfig == null || encodedConfig.isEmpty()) {
+            return null;
+        }
+        GraphQLSubscription subscription = subscriptions.get(encodedConfig);
+        if (subscription != null) {
+            return subscription;
+        }
+        try {
+            String configJson = new String(Base64.getDecoder().decode(encodedConfig), StandardCharsets.UTF_8);
+            subscription = new GraphQLSubscription(mapper.readValue(configJson, SubscriptionConfig.class));
+            subscriptions.put(encodedConfig, subscription);
+            return subscription;
+        } catch (Exception e) {
+            logger.error("Error while reading the GraphQL subscriptions of the route. " + e);
+            return null;
+        }
+    }
+
+    /**
+     * Authorizes a message sent by the client of a subscription. The messages other than the subscribe messages are
+     * allowed. A subscribe message is allowed when the token allows the subscribed fields, and the fields have not
+     * exceeded their rate limits.
+     *
+     * @param api         GraphQL API
+     * @param message     message sent by the client
+     * @param scopes      scopes of the token of the connection, or null if the scopes are not validated
+     * @param isOverLimit sends a hit of a descriptor to the rate limit service, and returns whether the descriptor
+     *                    has exceeded its limit
+     * @return the reason to reject the message, or null if the message is allowed
+     */
+    public Rejection authorize(APIConfig api, String message, List<String> scopes,
+                               Predicate<RateLimitDescriptor> isOverLimit) {
+        String query;
+        try {
+            JSONObject messageJson = new JSONObject(message);
+            String type = messageJson.optString(GraphQLConstants.SubscriptionConstants.PAYLOAD_FIELD_NAME_TYPE);
+            if (!GraphQLConstants.SubscriptionConstants.PAYLOAD_FIELD_NAME_ARRAY_FOR_SUBSCRIBE.contains(type)) {
+                return null;
+            }
+            JSONObject payload = messageJson.optJSONObject(
+                    GraphQLConstants.SubscriptionConstants.PAYLOAD_FIELD_NAME_PAYLOAD);
+            query = payload != null ? payload.optString(GraphQLConstants.SubscriptionConstants.PAYLOAD_FIELD_NAME_QUERY)
+                    : null;
+        } catch (JSONException e) {
+            return Rejection.invalidMessage("Invalid GraphQL subscription message structure");
+        }
+        if (StringUtils.isBlank(query)) {
+            return Rejection.invalidMessage("Query cannot be empty");
+        }
+        Set<String> fields;
+        try {
+            fields = GraphQLPayloadUtils.getGQLSubscriptionFields(api.getGraphQLSchemaDTO().getGraphQLSchema(),
+                    query);
+        } catch (EnforcerException e) {
+            return Rejection.invalidMessage(e.getMessage());
+        }
+        List<ResourceConfig> resources = new ArrayList<>();
+        for (String field : fields) {
+            ResourceConfig resource = getSubscriptionResource(api, field);
+            if (resource == null) {
+                return Rejection.invalidMessage("No matching subscription found for " + field);
+            }
+            // the token is required to have one of the scopes of each field, as validated for the queries
+            if (scopes != null && resource.getScopes() != null && resource.getScopes().length > 0
+                    && scopes.stream().noneMatch(Arrays.asList(resource.getScopes())::contains)) {
+                return new Rejection(StatusCode.Forbidden, String.valueOf(APISecurityConstants.INVALID_SCOPE),
+                        APISecurityConstants.INVALID_SCOPE_MESSAGE,
+                        "User is NOT authorized to access the Resource: " + field + ". Scope validation failed.",
+                        "graphql_subscription_invalid_scope");
+            }
+            resources.add(resource);
+        }
+        for (ResourceConfig resource : resources) {
+            RateLimitDescriptor descriptor = rateLimits.get(resource.getPath());
+            if (descriptor != null && isOverLimit.test(descriptor)) {
+                return new Rejection(StatusCode.TooManyRequests,
+                        GeneralErrorCodeConstants.GraphQLSubscription.THROTTLED_CODE,
+                        GeneralErrorCodeConstants.GraphQLSubscription.THROTTLED_MESSAGE,
+                        GeneralErrorCodeConstants.GraphQLSubscription.THROTTLED_DESCRIPTION,
+                        "graphql_subscription_throttled");
+            }
+        }
+        return null;
+    }
+
+    private static ResourceConfig getSubscriptionResource(APIConfig api, String field) {
+        for (ResourceConfig resource : api.getResources()) {
+            if (resource.getMethod() == ResourceConfig.HttpMethods.SUBSCRIPTION && field.equals(resource.getPath())) {
+                return resource;
+            }
+        }
+        return null;
+    }
+
+    /**
+     * Reason to reject a message of a subscription.
+     */
+    public static class Rejection {
+        private final StatusCode statusCode;
+        private final String code;
+        private final String message;
+        private final String description;
+        private final String details;
+
+        Rejection(StatusCode statusCode, String code, String message, String description, String details) {
+            this.statusCode = statusCode;
+            this.code = code;
+            this.message = message;
+            this.description = description;
+            this.details = details;
+        }
+
+        static Rejection invalidMessage(String description) {
+            return new Rejection(StatusCode.BadRequest,
+                    GeneralErrorCodeConstants.GraphQLSubscription.INVALID_MESSAGE_CODE,
+                    GeneralErrorCodeConstants.GraphQLSubscription.INVALID_MESSAGE_MESSAGE, description,
+                    "graphql_subscription_invalid_message");
+        }
+
+        public StatusCode getStatusCode() {
+            return statusCode;
+        }
+
+        public String getCode() {
+            return code;
+        }
+
+        public String getMessage() {
+            return message;
+        }
+
+        public String getDescription() {
+            return description;
+        }
+
+        public String getDetails() {
+            return details;
+        }
+    }
+
+    /**
+     * Configurations of the subscriptions, received from the adapter.
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    static class SubscriptionConfig {
+        public Map<String, List<DescriptorEntry>> rateLimits;
+    }
+
+    /**
+     * Entry of the descriptor of a rate limit.
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    static class DescriptorEntry {
+        public String key;
+        public String value;
+    }
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.graphql;
+
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+
+import java.io.ByteArrayOutputStream;
+import java.nio.charset.StandardCharsets;
+import java.util.ArrayList;
+import java.util.List;
+
+/**
+ * Decodes the messages sent by the client of a websocket connection, which are streamed to the enforcer in chunks of
+ * arbitrary size. The frames of a message are reassembled, and the control frames are skipped. The extensions of the
+ * frames are not supported, as the router does not negotiate them.
+ */
+public class WebSocketMessageDecoder {
+
+    static final int MAX_MESSAGE_SIZE = 1024 * 1024;
+    private static final int OPCODE_CONTINUATION = 0x0;
+    private static final int OPCODE_TEXT = 0x1;
+    private static final int OPCODE_BINARY = 0x2;
+    private static final int OPCODE_CLOSE = 0x8;
+
+    // bytes of the frame which is not received completely yet, which are bounded by the maximum message size
+    private final ByteArrayOutputStream pending = new ByteArrayOutputStream();
+    // payload of the frames received so far of a fragmented message
+    private final ByteArrayOutputStream message = new ByteArrayOutputStream();
+    private boolean fragmented;
+
+    /**
+     * Decodes the given chunk of the stream.
+     *
+     * @param chunk chunk of the stream
+     * @return the messages completed by the chunk
+     * @throws EnforcerException if the frames are invalid, or a message exceeds the maximum size
+     */
+    public List<String> decode(byte[] chunk) throws EnforcerException {
+        pending.write(chunk, 0, chunk.length);
+        byte[] data = pending.toByteArray();
+        List<String> messages = new ArrayList<>();
+        int offset = 0;
+        int frameLength;
+        while ((frameLength = decodeFrame(data, offset, messages)) > 0) {
+            offset += frameLength;
+        }
+        pending.reset();
+        pending.write(data, offset, data.length - offset);
+        return messages;
+    }
+
+    // decodeFrame decodes the frame at the given offset, and returns its length or 0 if the frame is not received
+    // completely yet
+    private int decodeFrame(byte[] data, int offset, List<String> messages) throws EnforcerException {
+        int available = data.length - offset;
+        if (available < 2) {
+            return 0;
+        }
+        int first = data[offset] & 0xff;
+        int second = data[offset + 1] & 0xff;
+        if ((first & 0x70) != 0) {
+            throw new EnforcerException("Extensions of the websocket frames are not supported");
+        }
+        boolean fin = (first & 0x80) != 0;
+        int opcode = first & 0x0f;
+        boolean masked = (second & 0x80) != 0;
+        long payloadLength = second & 0x7f;
+        int headerLength = 2;
+        if (payloadLength == 126) {
+            headerLength = 4;
+        } else if (payloadLength == 127) {
+            headerLength = 10;
+        }
+        if (available < headerLength) {
+            return 0;
+        }
+        if (headerLength > 2) {
+            payloadLength = 0;
+            for (int i = offset + 2; i < offset + headerLength; i++) {
+                payloadLength = (payloadLength << 8) | (data[i] & 0xff);
+            }
+        }
+        if (payloadLength < 0 || payloadLength > MAX_MESSAGE_SIZE) {
+            throw new EnforcerException("The websocket frame exceeds the maximum message size");
+        }
+        int maskOffset = offset + headerLength;
+        if (masked) {
+            headerLength += 4;
+        }
+        if (available < headerLength + payloadLength) {
+            return 0;
+        }
+        int frameLength = headerLength + (int) payloadLength;
+        if (opcode >= OPCODE_CLOSE) {
+            // the control frames, which may be interleaved with the frames of a fragmented message, are not
+            // authorized
+            return frameLength;
+        }
+        if (opcode == OPCODE_CONTINUATION) {
+            if (!fragmented) {
+                throw new EnforcerException("Unexpected continuation frame");
+            }
+        } else if (opcode == OPCODE_TEXT || opcode == OPCODE_BINARY) {
+            if (fragmented) {
+                throw new EnforcerException("Unexpected data frame in a fragmented message");
+            }
+        } else {
+            throw new EnforcerException("Unknown websocket opcode " + opcode);
+        }
+        if (message.size() + payloadLength > MAX_MESSAGE_SIZE) {
+            throw new EnforcerException("The websocket message exceeds the maximum message size");
+        }
+        int payloadOffset = offset + headerLength;
+        for (int i = 0; i < payloadLength; i++) {
+            int value = data[payloadOffset + i];
+            if (masked) {
+                value ^= data[maskOffset + (i % 4)];
+            }
+            message.write(value);
+        }
+        fragmented = !fin;
+        if (fin) {
+            messages.add(message.toString(StandardCharsets.UTF_8));
+            message.reset();
+        }
+        return frameLength;
+    }
+}
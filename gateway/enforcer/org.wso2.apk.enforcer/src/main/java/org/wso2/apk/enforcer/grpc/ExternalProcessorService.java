@@ -20,9 +20,12 @@ package org.wso2.apk.enforcer.grpc;
 
 import com.fasterxml.jackson.databind.JsonNode;
 import com.fasterxml.jackson.databind.ObjectMapper;
+import com.google.protobuf.ByteString;
 import com.google.protobuf.Struct;
 import com.google.protobuf.Value;
 import io.envoyproxy.envoy.config.core.v3.HeaderValue;
+import io.envoyproxy.envoy.config.core.v3.HeaderValueOption;
+import io.envoyproxy.envoy.extensions.filters.http.ext_proc.v3.ProcessingMode;
 import io.envoyproxy.envoy.service.ext_proc.v3.BodyMutation;
 import io.envoyproxy.envoy.service.ext_proc.v3.BodyResponse;
 import io.envoyproxy.envoy.service.ext_proc.v3.CommonResponse;
@@ -30,14 +33,42 @@ import io.envoyproxy.envoy.service.ext_proc.v3.ExternalProcessorGrpc;
 import io.envoyproxy.envoy.service.ext_proc.v3.HeaderMutation;
 import io.envoyproxy.envoy.service.ext_proc.v3.HeadersResponse;
 import io.envoyproxy.envoy.service.ext_proc.v3.HttpHeaders;
+import io.envoyproxy.envoy.service.ext_proc.v3.ImmediateResponse;
 import io.envoyproxy.envoy.service.ext_proc.v3.ProcessingRequest;
 import io.envoyproxy.envoy.service.ext_proc.v3.ProcessingResponse;
+import io.envoyproxy.envoy.type.v3.HttpStatus;
+import io.envoyproxy.envoy.type.v3.StatusCode;
 import io.grpc.stub.StreamObserver;
 import org.apache.commons.compress.compressors.CompressorStreamFactory;
 import org.apache.logging.log4j.LogManager;
 import org.apache.logging.log4j.Logger;
+import org.json.JSONObject;
+import org.wso2.apk.enforcer.aicache.AIResponseCache;
+import org.wso2.apk.enforcer.aicache.AIResponseCacheRedisClient;
+import org.wso2.apk.enforcer.aitransformation.AITransformation;
+import org.wso2.apk.enforcer.aitransformation.ProviderRequest;
+import org.wso2.apk.enforcer.aitransformation.StreamConverter;
+import org.wso2.apk.enforcer.api.API;
+import org.wso2.apk.enforcer.api.APIFactory;
+import org.wso2.apk.enforcer.bodytransformation.BodyTransformation;
+import org.wso2.apk.enforcer.bodytransformation.BodyTransformationException;
+import org.wso2.apk.enforcer.bodytransformation.SOAPMediation;
+import org.wso2.apk.enforcer.bodytransformation.BodyTransformer;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+import org.wso2.apk.enforcer.constants.APIConstants;
+import org.wso2.apk.enforcer.constants.GeneralErrorCodeConstants;
 import org.wso2.apk.enforcer.constants.MetadataConstants;
+import org.wso2.apk.enforcer.graphql.GraphQLSubscription;
+import org.wso2.apk.enforcer.graphql.WebSocketMessageDecoder;
 import org.wso2.apk.enforcer.grpc.client.RatelimitClient;
+import org.wso2.apk.enforcer.guardrails.AIGuardrailsValidator;
+import org.wso2.apk.enforcer.metrics.jmx.impl.AIResponseCacheMetrics;
+import org.wso2.apk.enforcer.responsecache.CachedResponse;
+import org.wso2.apk.enforcer.responsecache.ResponseCache;
+import org.wso2.apk.enforcer.responsecache.ResponseCacheStore;
+import org.wso2.apk.enforcer.schemavalidation.OpenAPISchemaValidator;
+import org.wso2.apk.enforcer.tokenbudget.TokenBudget;
+import org.wso2.apk.enforcer.tokenbudget.TokenBudgetManager;
 
 import java.io.BufferedReader;
 import java.io.ByteArrayInputStream;
@@ -46,7 +77,9 @@ import java.io.InputStreamReader;
 import java.nio.charset.StandardCharsets;
 import java.util.ArrayList;
 import java.util.Arrays;
+import java.util.HashMap;
 import java.util.List;
+import java.util.Map;
 import java.util.concurrent.ExecutorService;
 import java.util.concurrent.Executors;
 import java.util.regex.Matcher;
@@ -65,6 +98,10 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
     private static final String DESCRIPTOR_KEY_FOR_SUBSCRIPTION_BASED_AI_RESPONSE_TOKEN_COUNT = "airesponsetokencountsubs";
     private static final String DESCRIPTOR_KEY_FOR_SUBSCRIPTION_BASED_AI_TOTAL_TOKEN_COUNT    = "aitotaltokencountsubs";
     private static final String DESCRIPTOR_KEY_FOR_AI_SUBSCRIPTION = "subscription";
+    // These values are shared between the adapter and enforcer
+    private static final String GUARDRAIL_REQUEST_DIRECTION = "request";
+    private static final String GUARDRAIL_RESPONSE_DIRECTION = "response";
+    private static final String RESPONSE_CACHE_STATUS_HEADER = "x-cache";
     private final ExecutorService executorService = Executors.newFixedThreadPool(10);;
     RatelimitClient ratelimitClient = new RatelimitClient();
     @Override
@@ -72,20 +109,309 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
             final StreamObserver<ProcessingResponse> responseObserver) {
         FilterMetadata filterMetadata = new FilterMetadata();
         return new StreamObserver<ProcessingRequest>() {
+            // collects the usage of a server-sent event response, which is processed chunk by chunk
+            private ServerSentEventUsageCollector eventStreamUsageCollector;
+            // status code of the response, as the guardrails are applied only on the successful responses
+            private String responseStatus;
+            // whether the client asked to bypass the AI response cache
+            private boolean aiCacheBypassed;
+            // key under which the response is cached when the request missed the AI response cache
+            private String aiCacheKey;
+            // model requested by the client, as the responses of some providers do not include it
+            private String aiRequestModel;
+            // prompt tokens estimated from the request, for the event streams which do not report the usage
+            private int aiPromptTokenEstimate;
+            // converts a streamed response of the provider to OpenAI chat completion chunks
+            private StreamConverter aiStreamConverter;
+            // path and authorization header of the request, which are rewritten for the AI provider
+            private String aiRequestPath;
+            private String aiRequestAuthorization;
+            // key under which the response of a REST API is cached, when the request missed the response cache
+            private String restCacheKey;
+            // Cache-Control header of the request, which decides whether the response is cached
+            private String requestCacheControl;
+            // seconds to cache the response, and the headers served with the cached response
+            private long restCacheTtlSeconds;
+            private Map<String, String> restCacheHeaders;
+            // validator of the response, and the operation of the API definition the response is validated against
+            private OpenAPISchemaValidator schemaValidator;
+            private String schemaValidationOperation;
+            private String responseContentType;
+            // transformer of the payload of the response, when the response is transformed
+            private BodyTransformer responseBodyTransformer;
+            // SOAP mediation converting the response of the SOAP backend to JSON, when the response is mediated
+            private SOAPMediation responseSOAPMediation;
+            // decoder of the messages of the websocket connection of a GraphQL subscription, and the API and the
+            // scopes of the token of the connection authorized by the enforcer
+            private WebSocketMessageDecoder gqlSubscriptionDecoder;
+            private String gqlSubscriptionAPI;
+            private List<String> gqlSubscriptionScopes;
 
             @Override
             public void onNext(ProcessingRequest request) {
                 ProcessingRequest.RequestCase r = request.getRequestCase();
                 logger.info("Starting to serve external processing request");
                 switch (r) {
+                    case REQUEST_HEADERS:
+                        // the request headers are sent to the enforcer to detect the AI response cache bypass header,
+                        // and to serve the requests of the REST APIs from the response cache
+                        updateFilterMetadata(request, filterMetadata);
+                        if (filterMetadata.responseCache != null) {
+                            String bypassHeaderValue = getHeaderValue(request.getRequestHeaders(),
+                                    filterMetadata.responseCache.getBypassHeader());
+                            aiCacheBypassed = bypassHeaderValue != null && !"false".equalsIgnoreCase(bypassHeaderValue);
+                        }
+                        if (filterMetadata.transformation != null) {
+                            aiRequestPath = getHeaderValue(request.getRequestHeaders(), ":path");
+                            aiRequestAuthorization = getHeaderValue(request.getRequestHeaders(), "authorization");
+                        }
+                        if (filterMetadata.restResponseCache != null) {
+                            HttpHeaders requestHeaders = request.getRequestHeaders();
+                            requestCacheControl = getHeaderValue(requestHeaders, "cache-control");
+                            restCacheKey = filterMetadata.restResponseCache.getCacheKey(
+                                    getHeaderValue(requestHeaders, ":path"),
+                                    header -> getHeaderValue(requestHeaders, header), getConsumer(request));
+                            if (restCacheKey != null && filterMetadata.restResponseCache.isLookupAllowed(requestCacheControl)) {
+                                ResponseCacheStore store = filterMetadata.restResponseCache.getStore();
+                                CachedResponse cachedResponse = store != null ? store.get(restCacheKey) : null;
+                                if (cachedResponse != null) {
+                                    responseObserver.onNext(prepareCachedResponse(cachedResponse));
+                                    responseObserver.onCompleted();
+                                    break;
+                                }
+                            }
+                        }
+                        if (filterMetadata.gqlSubscription != null) {
+                            Struct filterMetadataFromAuthZ = request.getMetadataContext()
+                                    .getFilterMetadataOrDefault(MetadataConstants.EXT_AUTH_METADATA_CONTEXT_KEY, null);
+                            gqlSubscriptionDecoder = new WebSocketMessageDecoder();
+                            gqlSubscriptionAPI = getMetadataValue(filterMetadataFromAuthZ,
+                                    MetadataConstants.GRAPHQL_SUBSCRIPTION_API);
+                            String scopes = getMetadataValue(filterMetadataFromAuthZ,
+                                    MetadataConstants.GRAPHQL_SUBSCRIPTION_SCOPES);
+                            if (scopes != null) {
+                                gqlSubscriptionScopes = scopes.isEmpty() ? List.of() : Arrays.asList(scopes.split(" "));
+                            }
+                        }
+                        responseObserver.onNext(ProcessingResponse.newBuilder().setRequestHeaders(prepareHeadersResponse()).build());
+                        break;
+                    case REQUEST_BODY:
+                        updateFilterMetadata(request, filterMetadata);
+                        if (filterMetadata.gqlSubscription != null) {
+                            // the messages of the websocket connection of a GraphQL subscription are streamed, and the
+                            // connection is closed when a subscribe message is rejected, as the handshake is already
+                            // responded
+                            ProcessingResponse rejection = authorizeGQLSubscriptionMessages(
+                                    filterMetadata.gqlSubscription, request.getRequestBody().getBody().toByteArray());
+                            if (rejection != null) {
+                                responseObserver.onNext(rejection);
+                                responseObserver.onCompleted();
+                                break;
+                            }
+                            responseObserver.onNext(ProcessingResponse.newBuilder()
+                                    .setRequestBody(prepareBodyResponse()).build());
+                            break;
+                        }
+                        if ((filterMetadata.bodyTransformation != null
+                                && filterMetadata.bodyTransformation.getRequest() != null)
+                                || filterMetadata.soapMediation != null) {
+                            // the payloads of the requests of the REST APIs are transformed, and then wrapped in SOAP
+                            // envelopes for the SOAP backends, before they are sent to the backend
+                            String transformedBody = request.getRequestBody().getBody().toStringUtf8();
+                            Map<String, String> transformedHeaders = new HashMap<>();
+                            if (filterMetadata.bodyTransformation != null
+                                    && filterMetadata.bodyTransformation.getRequest() != null) {
+                                BodyTransformer requestBodyTransformer = filterMetadata.bodyTransformation.getRequest();
+                                try {
+                                    transformedBody = requestBodyTransformer.transform(transformedBody);
+                                    transformedHeaders.putAll(requestBodyTransformer.getHeaders());
+                                } catch (BodyTransformationException e) {
+                                    logger.debug("Error while transforming the payload of the request. "
+                                            + e.getMessage());
+                                    responseObserver.onNext(prepareErrorResponse(StatusCode.BadRequest,
+                                            GeneralErrorCodeConstants.BodyTransformation.REQUEST_FAILURE_CODE,
+                                            GeneralErrorCodeConstants.BodyTransformation.REQUEST_FAILURE_MESSAGE,
+                                            GeneralErrorCodeConstants.BodyTransformation.REQUEST_FAILURE_DESCRIPTION,
+                                            null, "body_transformation_failure"));
+                                    responseObserver.onCompleted();
+                                    break;
+                                }
+                            }
+                            if (filterMetadata.soapMediation != null) {
+                                try {
+                                    transformedBody = filterMetadata.soapMediation.toEnvelope(transformedBody);
+                                    transformedHeaders.putAll(filterMetadata.soapMediation.getRequestHeaders());
+                                } catch (BodyTransformationException e) {
+                                    logger.debug("Error while wrapping the payload of the request in a SOAP envelope. "
+                                            + e.getMessage());
+                                    responseObserver.onNext(prepareErrorResponse(StatusCode.BadRequest,
+                                            GeneralErrorCodeConstants.SOAPMediation.REQUEST_FAILURE_CODE,
+                                            GeneralErrorCodeConstants.SOAPMediation.REQUEST_FAILURE_MESSAGE,
+                                            GeneralErrorCodeConstants.SOAPMediation.REQUEST_FAILURE_DESCRIPTION,
+                                            null, "soap_mediation_failure"));
+                                    responseObserver.onCompleted();
+                                    break;
+                                }
+                            }
+                            responseObserver.onNext(ProcessingResponse.newBuilder().setRequestBody(
+                                    prepareBodyResponse(transformedBody, transformedHeaders)).build());
+                            break;
+                        }
+                        String prompt = request.getRequestBody().getBody().toStringUtf8();
+                        aiPromptTokenEstimate = ServerSentEventUsageCollector.estimatePromptTokens(prompt);
+                        BodyResponse requestBodyResponse = prepareBodyResponse();
+                        Struct.Builder requestStructBuilder = Struct.newBuilder();
+                        if (filterMetadata.guardrails != null) {
+                            AIGuardrailsValidator.Result result = filterMetadata.guardrails.validatePrompt(prompt);
+                            if (result.isViolated()) {
+                                responseObserver.onNext(prepareGuardrailViolationResponse(result, StatusCode.BadRequest,
+                                        GUARDRAIL_REQUEST_DIRECTION));
+                                responseObserver.onCompleted();
+                                break;
+                            }
+                            if (result.getRedactedPayload() != null) {
+                                prompt = result.getRedactedPayload();
+                                requestBodyResponse = prepareBodyResponse(prompt);
+                                addMetadata(requestStructBuilder, MetadataConstants.AI_GUARDRAIL_PROMPT_REDACTIONS,
+                                        String.join(",", result.getRedactedEntities()));
+                            }
+                        }
+                        if (filterMetadata.responseCache != null) {
+                            if (aiCacheBypassed) {
+                                AIResponseCacheMetrics.getInstance().recordBypass();
+                                addMetadata(requestStructBuilder, MetadataConstants.AI_CACHE_STATUS, AIResponseCache.STATUS_BYPASS);
+                            } else {
+                                aiCacheKey = filterMetadata.responseCache.getCacheKey(prompt);
+                            }
+                        }
+                        if (aiCacheKey != null) {
+                            AIResponseCacheRedisClient cacheClient = AIResponseCacheRedisClient.getInstance();
+                            String cachedResponse = cacheClient != null ? cacheClient.get(aiCacheKey) : null;
+                            if (cachedResponse != null) {
+                                AIResponseCacheMetrics.getInstance().recordHit();
+                                addMetadata(requestStructBuilder, MetadataConstants.AI_CACHE_STATUS, AIResponseCache.STATUS_HIT);
+                                responseObserver.onNext(prepareCachedResponse(cachedResponse, requestStructBuilder));
+                                responseObserver.onCompleted();
+                                break;
+                            }
+                            AIResponseCacheMetrics.getInstance().recordMiss();
+                            addMetadata(requestStructBuilder, MetadataConstants.AI_CACHE_STATUS, AIResponseCache.STATUS_MISS);
+                        }
+                        if (filterMetadata.transformation != null) {
+                            aiRequestModel = AITransformation.getModel(prompt);
+                            ProviderRequest providerRequest = filterMetadata.transformation.transformRequest(prompt,
+                                    aiRequestPath, aiRequestAuthorization);
+                            if (providerRequest != null) {
+                                requestBodyResponse = prepareBodyResponse(providerRequest);
+                            }
+                        }
+                        ProcessingResponse.Builder requestBodyResponseBuilder = ProcessingResponse.newBuilder().setRequestBody(requestBodyResponse);
+                        if (requestStructBuilder.getFieldsCount() > 0) {
+                            requestBodyResponseBuilder.setDynamicMetadata(Struct.newBuilder().putFields(
+                                    MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY,
+                                    Value.newBuilder().setStructValue(requestStructBuilder.build()).build()).build());
+                        }
+                        responseObserver.onNext(requestBodyResponseBuilder.build());
+                        break;
                     case RESPONSE_HEADERS:
-                        if (!request.getAttributesMap().isEmpty() && request.getAttributesMap().get(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY) != null && request.getAttributesMap().get(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY).getFieldsMap().get("xds.route_metadata") != null){
-                            Value value = request.getAttributesMap().get(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY).getFieldsMap().get("xds.route_metadata");
-                            FilterMetadata metadata = convertStringToFilterMetadata(value.getStringValue());
-                            filterMetadata.backendBasedAIRatelimitDescriptorValue = metadata.backendBasedAIRatelimitDescriptorValue;
-                            filterMetadata.enableBackendBasedAIRatelimit = metadata.enableBackendBasedAIRatelimit;
+                        updateFilterMetadata(request, filterMetadata);
+                        responseStatus = getHeaderValue(request.getResponseHeaders(), ":status");
+                        if (filterMetadata.transformation != null && isSuccessStatus(responseStatus)
+                                && filterMetadata.transformation.isStreamingResponse(
+                                        getHeaderValue(request.getResponseHeaders(), "content-type"))) {
+                            // the streamed responses of the provider are converted chunk by chunk, and their tokens
+                            // are counted by the converter
+                            aiStreamConverter = filterMetadata.transformation.newStreamConverter(aiRequestModel);
+                            responseObserver.onNext(ProcessingResponse.newBuilder()
+                                    .setResponseHeaders(prepareEventStreamHeadersResponse())
+                                    .setModeOverride(ProcessingMode.newBuilder()
+                                            .setResponseBodyMode(ProcessingMode.BodySendMode.STREAMED).build())
+                                    .build());
+                            break;
+                        }
+                        // the responses are validated when the schema validation filter asks for it. The responses
+                        // encoded by the backend are not validated.
+                        Struct filterMetadataFromAuthZForValidation = request.getMetadataContext()
+                                .getFilterMetadataOrDefault(MetadataConstants.EXT_AUTH_METADATA_CONTEXT_KEY, null);
+                        String responseContentEncoding = getHeaderValue(request.getResponseHeaders(), "content-encoding");
+                        if (filterMetadataFromAuthZForValidation != null && filterMetadataFromAuthZForValidation
+                                .getFieldsMap().get(MetadataConstants.SCHEMA_VALIDATION_API) != null
+                                && (responseContentEncoding == null || "identity".equalsIgnoreCase(responseContentEncoding))) {
+                            schemaValidator = OpenAPISchemaValidator.getValidator(filterMetadataFromAuthZForValidation
+                                    .getFieldsMap().get(MetadataConstants.SCHEMA_VALIDATION_API).getStringValue());
+                            schemaValidationOperation = filterMetadataFromAuthZForValidation.getFieldsMap()
+                                    .get(MetadataConstants.SCHEMA_VALIDATION_OPERATION).getStringValue();
+                            responseContentType = getHeaderValue(request.getResponseHeaders(), "content-type");
                         }
+                        if (filterMetadata.bodyTransformation != null
+                                && filterMetadata.bodyTransformation.getResponse() != null
+                                && isSuccessStatus(responseStatus)
+                                && (responseContentEncoding == null || "identity".equalsIgnoreCase(responseContentEncoding))) {
+                            // only the successful responses are transformed, as the error responses of the backend
+                            // may not be in the expected format
+                            responseBodyTransformer = filterMetadata.bodyTransformation.getResponse();
+                        }
+                        if (filterMetadata.soapMediation != null
+                                && (responseContentEncoding == null || "identity".equalsIgnoreCase(responseContentEncoding))) {
+                            // all the responses of the SOAP backends are mediated, as the faults are returned with
+                            // error status codes
+                            responseSOAPMediation = filterMetadata.soapMediation;
+                        }
+                        boolean schemaValidationEnabled = filterMetadataFromAuthZForValidation != null
+                                && filterMetadataFromAuthZForValidation.getFieldsMap()
+                                        .get(MetadataConstants.SCHEMA_VALIDATION_API) != null;
+                        if (restCacheKey != null || schemaValidationEnabled || filterMetadata.bodyTransformation != null
+                                || filterMetadata.soapMediation != null) {
+                            // the response body is sent to the enforcer only when the response is cached, validated,
+                            // transformed or mediated
+                            HttpHeaders responseHeaders = request.getResponseHeaders();
+                            if (restCacheKey != null) {
+                                restCacheTtlSeconds = isCacheableResponse(responseStatus, responseContentEncoding)
+                                        ? filterMetadata.restResponseCache.getTtlSeconds(requestCacheControl,
+                                                getHeaderValue(responseHeaders, "cache-control")) : 0;
+                                if (restCacheTtlSeconds > 0) {
+                                    restCacheHeaders = filterMetadata.restResponseCache.getCachedHeaders(
+                                            header -> getHeaderValue(responseHeaders, header));
+                                }
+                            }
+                            if (restCacheTtlSeconds > 0 || schemaValidator != null || responseBodyTransformer != null
+                                    || responseSOAPMediation != null) {
+                                responseObserver.onNext(ProcessingResponse.newBuilder()
+                                        .setResponseHeaders(prepareHeadersResponse()).build());
+                            } else {
+                                responseObserver.onNext(ProcessingResponse.newBuilder()
+                                        .setResponseHeaders(prepareHeadersResponse())
+                                        .setModeOverride(ProcessingMode.newBuilder()
+                                                .setResponseBodyMode(ProcessingMode.BodySendMode.NONE).build())
+                                        .build());
+                                responseObserver.onCompleted();
+                            }
+                            break;
+                        }
+                        // the response body is sent to the enforcer after the headers when the guardrails inspect it,
+                        // when it is cached or when it is converted to the OpenAI format
+                        boolean responseBodyExpected = (filterMetadata.guardrails != null && filterMetadata.guardrails.hasResponseChecks())
+                                || filterMetadata.responseCache != null || filterMetadata.transformation != null;
                         Struct filterMetadataFromAuthZForHeader = request.getMetadataContext().getFilterMetadataOrDefault("envoy.filters.http.ext_authz", null);
+                        if (filterMetadataFromAuthZForHeader != null && filterMetadataFromAuthZForHeader.getFieldsMap().get(MetadataConstants.EXTRACT_TOKEN_FROM) != null
+                                && !"header".equalsIgnoreCase(filterMetadataFromAuthZForHeader.getFieldsMap().get(MetadataConstants.EXTRACT_TOKEN_FROM).getStringValue())) {
+                            // The tokens are extracted from the body. Server-sent event streams are switched to the streamed
+                            // mode and their chunks are processed as they arrive. Other responses are sent buffered.
+                            ProcessingResponse.Builder headersResponseBuilder = ProcessingResponse.newBuilder().setResponseHeaders(prepareHeadersResponse());
+                            if (ServerSentEventUsageCollector.isEventStream(getHeaderValue(request.getResponseHeaders(), "content-type"))) {
+                                eventStreamUsageCollector = new ServerSentEventUsageCollector(
+                                        filterMetadataFromAuthZForHeader.getFieldsMap().get(MetadataConstants.COMPLETION_TOKEN_ID).getStringValue(),
+                                        filterMetadataFromAuthZForHeader.getFieldsMap().get(MetadataConstants.PROMPT_TOKEN_ID).getStringValue(),
+                                        filterMetadataFromAuthZForHeader.getFieldsMap().get(MetadataConstants.TOTAL_TOKEN_ID).getStringValue(),
+                                        filterMetadataFromAuthZForHeader.getFieldsMap().get(MetadataConstants.MODEL_ID).getStringValue(),
+                                        getHeaderValue(request.getResponseHeaders(), "content-encoding"),
+                                        aiPromptTokenEstimate);
+                                headersResponseBuilder.setModeOverride(ProcessingMode.newBuilder()
+                                        .setResponseBodyMode(ProcessingMode.BodySendMode.STREAMED).build());
+                            }
+                            responseObserver.onNext(headersResponseBuilder.build());
+                            break;
+                        }
                         if (filterMetadataFromAuthZForHeader != null) {
                             String extractTokenFrom = filterMetadataFromAuthZForHeader.getFieldsMap().get(MetadataConstants.EXTRACT_TOKEN_FROM).getStringValue();
                             String promptTokenID = filterMetadataFromAuthZForHeader.getFieldsMap().get(MetadataConstants.PROMPT_TOKEN_ID).getStringValue();
@@ -100,7 +426,6 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
                             executorService.submit(() -> {
                                 if (usage == null) {
                                     logger.error("Usage details not found..");
-                                    responseObserver.onCompleted();
                                     return;
                                 }
                                 List<RatelimitClient.KeyValueHitsAddend> configs = new ArrayList<>();
@@ -121,6 +446,7 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
                                     }
                                 }
                                 ratelimitClient.shouldRatelimit(configs);
+                                consumeTokenBudget(filterMetadataFromAuthZForHeader, usage);
                             });
                             if (usage != null) {
                                 Struct.Builder structBuilder = Struct.newBuilder();
@@ -133,28 +459,164 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
                                 Struct.Builder rootStructBuilder = Struct.newBuilder();
                                 rootStructBuilder.putFields(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY, Value.newBuilder().setStructValue(structBuilder.build()).build());
                                 responseObserver.onNext(ProcessingResponse.newBuilder().setDynamicMetadata(rootStructBuilder.build()).setResponseHeaders(prepareHeadersResponse()).build());
+                            } else if (responseBodyExpected) {
+                                responseObserver.onNext(ProcessingResponse.newBuilder().setResponseHeaders(prepareHeadersResponse()).build());
                             }
+                        } else if (responseBodyExpected) {
+                            responseObserver.onNext(ProcessingResponse.newBuilder().setResponseHeaders(prepareHeadersResponse()).build());
+                        }
+                        if (!responseBodyExpected) {
+                            responseObserver.onCompleted();
                         }
-                        responseObserver.onCompleted();
+                        break;
                     case RESPONSE_BODY:
 
-                        if (!request.getAttributesMap().isEmpty() && request.getAttributesMap().get(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY) != null && request.getAttributesMap().get(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY).getFieldsMap().get("xds.route_metadata") != null){
-                            Value value = request.getAttributesMap().get(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY).getFieldsMap().get("xds.route_metadata");
-                            FilterMetadata metadata = convertStringToFilterMetadata(value.getStringValue());
-                            filterMetadata.backendBasedAIRatelimitDescriptorValue = metadata.backendBasedAIRatelimitDescriptorValue;
-                            filterMetadata.enableBackendBasedAIRatelimit = metadata.enableBackendBasedAIRatelimit;
+                        updateFilterMetadata(request, filterMetadata);
+                        if (restCacheKey != null || schemaValidator != null || responseBodyTransformer != null
+                                || responseSOAPMediation != null) {
+                            // the response is mediated and transformed before it is validated and cached, as the API
+                            // definition and the cached responses are of the transformed responses
+                            String responseBody = request.hasResponseBody()
+                                    ? request.getResponseBody().getBody().toStringUtf8() : null;
+                            BodyResponse restBodyResponse = prepareBodyResponse();
+                            Map<String, String> transformedHeaders = null;
+                            if (responseSOAPMediation != null && responseBody != null) {
+                                SOAPMediation.Response soapResponse = null;
+                                try {
+                                    soapResponse = responseSOAPMediation.fromEnvelope(responseBody);
+                                } catch (BodyTransformationException e) {
+                                    logger.debug("Error while converting the SOAP response. " + e.getMessage());
+                                    if (isSuccessStatus(responseStatus)) {
+                                        responseObserver.onNext(prepareErrorResponse(StatusCode.BadGateway,
+                                                GeneralErrorCodeConstants.SOAPMediation.RESPONSE_FAILURE_CODE,
+                                                GeneralErrorCodeConstants.SOAPMediation.RESPONSE_FAILURE_MESSAGE,
+                                                GeneralErrorCodeConstants.SOAPMediation.RESPONSE_FAILURE_DESCRIPTION,
+                                                null, "soap_mediation_failure"));
+                                        responseObserver.onCompleted();
+                                        break;
+                                    }
+                                    // the error responses which are not SOAP envelopes, such as the ones of a proxy,
+                                    // are sent as they are
+                                }
+                                if (soapResponse != null && soapResponse.isFault()) {
+                                    responseObserver.onNext(prepareSOAPFaultResponse(soapResponse));
+                                    responseObserver.onCompleted();
+                                    break;
+                                }
+                                if (soapResponse != null) {
+                                    responseBody = soapResponse.getPayload();
+                                    transformedHeaders = new HashMap<>(responseSOAPMediation.getResponseHeaders());
+                                }
+                            }
+                            if (responseBodyTransformer != null && responseBody != null) {
+                                try {
+                                    responseBody = responseBodyTransformer.transform(responseBody);
+                                } catch (BodyTransformationException e) {
+                                    logger.debug("Error while transforming the payload of the response. "
+                                            + e.getMessage());
+                                    responseObserver.onNext(prepareErrorResponse(StatusCode.BadGateway,
+                                            GeneralErrorCodeConstants.BodyTransformation.RESPONSE_FAILURE_CODE,
+                                            GeneralErrorCodeConstants.BodyTransformation.RESPONSE_FAILURE_MESSAGE,
+                                            GeneralErrorCodeConstants.BodyTransformation.RESPONSE_FAILURE_DESCRIPTION,
+                                            null, "body_transformation_failure"));
+                                    responseObserver.onCompleted();
+                                    break;
+                                }
+                                if (transformedHeaders == null) {
+                                    transformedHeaders = new HashMap<>();
+                                }
+                                transformedHeaders.putAll(responseBodyTransformer.getHeaders());
+                            }
+                            if (transformedHeaders != null) {
+                                restBodyResponse = prepareBodyResponse(responseBody, transformedHeaders);
+                                if (transformedHeaders.get("content-type") != null) {
+                                    responseContentType = transformedHeaders.get("content-type");
+                                    if (restCacheHeaders != null) {
+                                        restCacheHeaders.put("content-type", responseContentType);
+                                    }
+                                }
+                            }
+                            if (schemaValidator != null) {
+                                List<Map<String, String>> errors = schemaValidator.validateResponse(
+                                        schemaValidationOperation, responseStatus, responseContentType, responseBody);
+                                if (!errors.isEmpty()) {
+                                    responseObserver.onNext(prepareInvalidResponse(errors));
+                                    responseObserver.onCompleted();
+                                    break;
+                                }
+                            }
+                            if (restCacheKey != null && responseBody != null && restCacheTtlSeconds > 0) {
+                                storeInResponseCache(filterMetadata.restResponseCache, restCacheKey, new CachedResponse(
+                                        StatusCode.OK_VALUE, restCacheHeaders, responseBody), restCacheTtlSeconds);
+                            }
+                            responseObserver.onNext(ProcessingResponse.newBuilder().setResponseBody(restBodyResponse).build());
+                            responseObserver.onCompleted();
+                            break;
                         }
                         if (request.hasResponseBody()) {
-                            final byte[] bodyFromResponse = request.getResponseBody().getBody().toByteArray();
-                            String body;
-                            try {
-                                body = decompress(bodyFromResponse);
-                            } catch (Exception e) {
-                                throw new RuntimeException(e);
+                            String body = null;
+                            BodyResponse responseBodyResponse = prepareBodyResponse();
+                            if (aiStreamConverter != null) {
+                                String events = aiStreamConverter.convert(request.getResponseBody().getBody().toByteArray());
+                                if (request.getResponseBody().getEndOfStream()) {
+                                    events += aiStreamConverter.finish();
+                                }
+                                responseBodyResponse = prepareStreamedBodyResponse(events);
+                                if (!request.getResponseBody().getEndOfStream()) {
+                                    responseObserver.onNext(ProcessingResponse.newBuilder().setResponseBody(responseBodyResponse).build());
+                                    break;
+                                }
+                            } else if (eventStreamUsageCollector != null) {
+                                eventStreamUsageCollector.addChunk(request.getResponseBody().getBody().toByteArray());
+                                if (!request.getResponseBody().getEndOfStream()) {
+                                    responseObserver.onNext(ProcessingResponse.newBuilder().setResponseBody(prepareBodyResponse()).build());
+                                    break;
+                                }
+                            } else {
+                                final byte[] bodyFromResponse = request.getResponseBody().getBody().toByteArray();
+                                try {
+                                    body = decompress(bodyFromResponse);
+                                } catch (Exception e) {
+                                    throw new RuntimeException(e);
+                                }
+                            }
+                            // the tokens are extracted from the response of the provider before it is converted
+                            String providerBody = body;
+                            if (body != null && filterMetadata.transformation != null) {
+                                String openAIBody = filterMetadata.transformation.transformResponse(body,
+                                        isSuccessStatus(responseStatus), aiRequestModel);
+                                if (openAIBody != null) {
+                                    body = openAIBody;
+                                    responseBodyResponse = prepareBodyResponse(openAIBody);
+                                }
+                            }
+                            Struct.Builder guardrailStructBuilder = Struct.newBuilder();
+                            if (body != null && filterMetadata.guardrails != null && isSuccessStatus(responseStatus)) {
+                                AIGuardrailsValidator.Result result = filterMetadata.guardrails.validateResponse(body);
+                                if (result.isViolated()) {
+                                    responseObserver.onNext(prepareGuardrailViolationResponse(result, StatusCode.BadGateway,
+                                            GUARDRAIL_RESPONSE_DIRECTION));
+                                    responseObserver.onCompleted();
+                                    break;
+                                }
+                                if (result.getRedactedPayload() != null) {
+                                    responseBodyResponse = prepareBodyResponse(result.getRedactedPayload());
+                                    addMetadata(guardrailStructBuilder, MetadataConstants.AI_GUARDRAIL_RESPONSE_REDACTIONS,
+                                            String.join(",", result.getRedactedEntities()));
+                                }
+                            }
+                            if (body != null && aiCacheKey != null && isSuccessStatus(responseStatus)) {
+                                storeInCache(filterMetadata.responseCache, aiCacheKey,
+                                        responseBodyResponse.getResponse().hasBodyMutation()
+                                                ? responseBodyResponse.getResponse().getBodyMutation().getBody().toStringUtf8() : body);
                             }
 
                             Struct filterMetadataFromAuthZForBody = request.getMetadataContext().getFilterMetadataOrDefault("envoy.filters.http.ext_authz", null);
-                            if (filterMetadataFromAuthZForBody != null) {
+                            // the tokens of the responses of the routes extracting them from the headers are already counted
+                            boolean tokensInHeaders = filterMetadataFromAuthZForBody != null
+                                    && filterMetadataFromAuthZForBody.getFieldsMap().get(MetadataConstants.EXTRACT_TOKEN_FROM) != null
+                                    && "header".equalsIgnoreCase(filterMetadataFromAuthZForBody.getFieldsMap().get(MetadataConstants.EXTRACT_TOKEN_FROM).getStringValue());
+                            if (filterMetadataFromAuthZForBody != null && !tokensInHeaders) {
                                 String extractTokenFrom = filterMetadataFromAuthZForBody.getFieldsMap().get(MetadataConstants.EXTRACT_TOKEN_FROM).getStringValue();
                                 String promptTokenID = filterMetadataFromAuthZForBody.getFieldsMap().get(MetadataConstants.PROMPT_TOKEN_ID).getStringValue();
                                 String completionTokenID = filterMetadataFromAuthZForBody.getFieldsMap().get(MetadataConstants.COMPLETION_TOKEN_ID).getStringValue();
@@ -163,12 +625,13 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
                                 String providerName = filterMetadataFromAuthZForBody.getFieldsMap().get(MetadataConstants.AI_PROVIDER_NAME).getStringValue();
                                 String providerAPIVersion = filterMetadataFromAuthZForBody.getFieldsMap().get(MetadataConstants.AI_PROVIDER_API_VERSION).getStringValue();
 
-                                Usage usage = extractUsageFromBody(body, completionTokenID, promptTokenID, totalTokenID, modelID);
+                                Usage usage = aiStreamConverter != null ? getUsage(aiStreamConverter)
+                                        : eventStreamUsageCollector != null ? eventStreamUsageCollector.getUsage()
+                                        : extractUsageFromBody(providerBody, completionTokenID, promptTokenID, totalTokenID, modelID);
 
                                 executorService.submit(() -> {
                                     if (usage == null) {
                                         logger.error("Usage details not found..");
-                                        responseObserver.onCompleted();
                                         return;
                                     }
                                     List<RatelimitClient.KeyValueHitsAddend> configs = new ArrayList<>();
@@ -189,9 +652,10 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
                                         }
                                     }
                                     ratelimitClient.shouldRatelimit(configs);
+                                    consumeTokenBudget(filterMetadataFromAuthZForBody, usage);
                                 });
                                 if (usage != null) {
-                                    Struct.Builder structBuilder = Struct.newBuilder();
+                                    Struct.Builder structBuilder = guardrailStructBuilder;
                                     addMetadata(structBuilder, MetadataConstants.AI_PROVIDER_API_VERSION, providerAPIVersion);
                                     addMetadata(structBuilder, MetadataConstants.AI_PROVIDER_NAME, providerName);
                                     addMetadata(structBuilder, MetadataConstants.MODEL, usage.model);
@@ -200,8 +664,12 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
                                     addMetadata(structBuilder, MetadataConstants.PROMPT_TOKEN_COUNT, usage.prompt_tokens);
                                     Struct.Builder rootStructBuilder = Struct.newBuilder();
                                     rootStructBuilder.putFields(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY, Value.newBuilder().setStructValue(structBuilder.build()).build());
-                                    responseObserver.onNext(ProcessingResponse.newBuilder().setDynamicMetadata(rootStructBuilder.build()).setResponseBody(prepareBodyResponse()).build());
+                                    responseObserver.onNext(ProcessingResponse.newBuilder().setDynamicMetadata(rootStructBuilder.build()).setResponseBody(responseBodyResponse).build());
+                                } else {
+                                    responseObserver.onNext(prepareBodyResponse(responseBodyResponse, guardrailStructBuilder));
                                 }
+                            } else {
+                                responseObserver.onNext(prepareBodyResponse(responseBodyResponse, guardrailStructBuilder));
                             }
                             responseObserver.onCompleted();
                         } else {
@@ -212,6 +680,42 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
                 }
             }
 
+            // authorizeGQLSubscriptionMessages authorizes the subscribe messages completed by the given chunk of a
+            // websocket connection, and returns the response rejecting the connection, or null if they are allowed
+            private ProcessingResponse authorizeGQLSubscriptionMessages(GraphQLSubscription subscription,
+                                                                        byte[] chunk) {
+                API api = gqlSubscriptionAPI != null ? APIFactory.getInstance().getAPI(gqlSubscriptionAPI) : null;
+                if (gqlSubscriptionDecoder == null || api == null) {
+                    logger.debug("The API of the GraphQL subscription is not available to authorize the messages.");
+                    return prepareErrorResponse(StatusCode.ServiceUnavailable,
+                            GeneralErrorCodeConstants.GraphQLSubscription.UNAVAILABLE_CODE,
+                            GeneralErrorCodeConstants.GraphQLSubscription.UNAVAILABLE_MESSAGE,
+                            GeneralErrorCodeConstants.GraphQLSubscription.UNAVAILABLE_DESCRIPTION, null,
+                            "graphql_subscription_unavailable");
+                }
+                List<String> messages;
+                try {
+                    messages = gqlSubscriptionDecoder.decode(chunk);
+                } catch (EnforcerException e) {
+                    logger.debug("Error while decoding the messages of the GraphQL subscription. " + e.getMessage());
+                    return prepareErrorResponse(StatusCode.BadRequest,
+                            GeneralErrorCodeConstants.GraphQLSubscription.INVALID_MESSAGE_CODE,
+                            GeneralErrorCodeConstants.GraphQLSubscription.INVALID_MESSAGE_MESSAGE, e.getMessage(),
+                            null, "graphql_subscription_invalid_message");
+                }
+                for (String message : messages) {
+                    GraphQLSubscription.Rejection rejection = subscription.authorize(api.getAPIConfig(), message,
+                            gqlSubscriptionScopes, ratelimitClient::isOverLimit);
+                    if (rejection != null) {
+                        logger.debug("The subscribe message of the GraphQL subscription is rejected. "
+                                + rejection.getDescription());
+                        return prepareErrorResponse(rejection.getStatusCode(), rejection.getCode(),
+                                rejection.getMessage(), rejection.getDescription(), null, rejection.getDetails());
+                    }
+                }
+                return null;
+            }
+
             @Override
             public void onError(Throwable err) {
                 logger.error("Error initiated from envoy in the external processing session. Error: " + err);
@@ -236,6 +740,294 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
                 .build();
     }
 
+    // prepareBodyResponse returns a body response which replaces the body with the given body. The body is sent
+    // decoded, hence the content encoding and the length of the original body are removed.
+    protected BodyResponse prepareBodyResponse(String body) {
+        return BodyResponse.newBuilder()
+                .setResponse(
+                        CommonResponse.newBuilder()
+                                .setStatus(CommonResponse.ResponseStatus.CONTINUE)
+                                .setHeaderMutation(HeaderMutation.newBuilder()
+                                        .addRemoveHeaders("content-length")
+                                        .addRemoveHeaders("content-encoding")
+                                        .build())
+                                .setBodyMutation(BodyMutation.newBuilder().setBody(ByteString.copyFromUtf8(body)).build())
+                                .build())
+                .build();
+    }
+
+    // prepareBodyResponse returns a body response which replaces the body with the given body, such as a transformed
+    // payload, and sets the given headers
+    private BodyResponse prepareBodyResponse(String body, Map<String, String> headers) {
+        HeaderMutation.Builder headerMutation = HeaderMutation.newBuilder().addRemoveHeaders("content-length");
+        headers.forEach((name, value) -> headerMutation.addSetHeaders(HeaderValueOption.newBuilder()
+                .setHeader(HeaderValue.newBuilder().setKey(name).setRawValue(ByteString.copyFromUtf8(value)).build())
+                .build()));
+        return BodyResponse.newBuilder()
+                .setResponse(CommonResponse.newBuilder()
+                        .setStatus(CommonResponse.ResponseStatus.CONTINUE)
+                        .setHeaderMutation(headerMutation.build())
+                        .setBodyMutation(BodyMutation.newBuilder().setBody(ByteString.copyFromUtf8(body)).build())
+                        .build())
+                .build();
+    }
+
+    // prepareBodyResponse returns a body response which replaces the body with the given request converted to the
+    // format of the AI provider, and sets and removes the headers of the provider
+    private BodyResponse prepareBodyResponse(ProviderRequest providerRequest) {
+        HeaderMutation.Builder headerMutation = HeaderMutation.newBuilder()
+                .addRemoveHeaders("content-length")
+                .addRemoveHeaders("content-encoding")
+                .addAllRemoveHeaders(providerRequest.getRemoveHeaders());
+        providerRequest.getHeaders().forEach((name, value) -> headerMutation.addSetHeaders(HeaderValueOption.newBuilder()
+                .setHeader(HeaderValue.newBuilder().setKey(name).setRawValue(ByteString.copyFromUtf8(value)).build())
+                .build()));
+        return BodyResponse.newBuilder()
+                .setResponse(CommonResponse.newBuilder()
+                        .setStatus(CommonResponse.ResponseStatus.CONTINUE)
+                        .setHeaderMutation(headerMutation.build())
+                        .setBodyMutation(BodyMutation.newBuilder()
+                                .setBody(ByteString.copyFromUtf8(providerRequest.getBody())).build())
+                        .build())
+                .build();
+    }
+
+    // prepareStreamedBodyResponse returns a body response which replaces a chunk of a streamed response with the
+    // given chunk. The headers can not be mutated once the chunks are streamed.
+    private BodyResponse prepareStreamedBodyResponse(String chunk) {
+        return BodyResponse.newBuilder()
+                .setResponse(CommonResponse.newBuilder()
+                        .setStatus(CommonResponse.ResponseStatus.CONTINUE)
+                        .setBodyMutation(BodyMutation.newBuilder().setBody(ByteString.copyFromUtf8(chunk)).build())
+                        .build())
+                .build();
+    }
+
+    // prepareEventStreamHeadersResponse returns the headers response of a streamed response converted to a
+    // server-sent event stream of OpenAI chat completion chunks
+    private HeadersResponse prepareEventStreamHeadersResponse() {
+        return HeadersResponse.newBuilder()
+                .setResponse(CommonResponse.newBuilder()
+                        .setStatus(CommonResponse.ResponseStatus.CONTINUE)
+                        .setHeaderMutation(HeaderMutation.newBuilder()
+                                .addRemoveHeaders("content-length")
+                                .addSetHeaders(HeaderValueOption.newBuilder()
+                                        .setHeader(HeaderValue.newBuilder()
+                                                .setKey("content-type")
+                                                .setRawValue(ByteString.copyFromUtf8("text/event-stream"))
+                                                .build())
+                                        .build())
+                                .build())
+                        .build())
+                .build();
+    }
+
+    // getUsage returns the token usage collected while converting a streamed response
+    private static Usage getUsage(StreamConverter streamConverter) {
+        Usage usage = new Usage();
+        usage.setPrompt_tokens(streamConverter.getPromptTokens());
+        usage.setCompletion_tokens(streamConverter.getCompletionTokens());
+        usage.setTotal_tokens(streamConverter.getPromptTokens() + streamConverter.getCompletionTokens());
+        usage.setModel(streamConverter.getModel());
+        return usage;
+    }
+
+    private ProcessingResponse prepareBodyResponse(BodyResponse bodyResponse, Struct.Builder structBuilder) {
+        ProcessingResponse.Builder responseBuilder = ProcessingResponse.newBuilder().setResponseBody(bodyResponse);
+        if (structBuilder.getFieldsCount() > 0) {
+            responseBuilder.setDynamicMetadata(Struct.newBuilder().putFields(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY,
+                    Value.newBuilder().setStructValue(structBuilder.build()).build()).build());
+        }
+        return responseBuilder.build();
+    }
+
+    // prepareGuardrailViolationResponse returns the local reply rejecting a payload violating the AI guardrails.
+    // The router maps the reply to the standard error response using the direction set in the metadata. The payload
+    // is rejected as a server error when the guardrails of the route could not be read.
+    private ProcessingResponse prepareGuardrailViolationResponse(AIGuardrailsValidator.Result result,
+                                                                 StatusCode statusCode, String direction) {
+        Struct.Builder structBuilder = Struct.newBuilder();
+        if (AIGuardrailsValidator.VIOLATION_CONFIGURATION.equals(result.getViolation())) {
+            statusCode = StatusCode.InternalServerError;
+        } else {
+            addMetadata(structBuilder, MetadataConstants.AI_GUARDRAIL_DIRECTION, direction);
+        }
+        addMetadata(structBuilder, MetadataConstants.AI_GUARDRAIL_VIOLATION, result.getViolation());
+        Struct.Builder rootStructBuilder = Struct.newBuilder();
+        rootStructBuilder.putFields(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY,
+                Value.newBuilder().setStructValue(structBuilder.build()).build());
+        return ProcessingResponse.newBuilder()
+                .setDynamicMetadata(rootStructBuilder.build())
+                .setImmediateResponse(ImmediateResponse.newBuilder()
+                        .setStatus(HttpStatus.newBuilder().setCode(statusCode).build())
+                        .setBody(result.getDescription())
+                        .setDetails("ai_guardrail_violation")
+                        .build())
+                .build();
+    }
+
+    // prepareCachedResponse returns the local reply serving a response of the AI response cache
+    private ProcessingResponse prepareCachedResponse(String cachedResponse, Struct.Builder structBuilder) {
+        return ProcessingResponse.newBuilder()
+                .setDynamicMetadata(Struct.newBuilder().putFields(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY,
+                        Value.newBuilder().setStructValue(structBuilder.build()).build()).build())
+                .setImmediateResponse(ImmediateResponse.newBuilder()
+                        .setStatus(HttpStatus.newBuilder().setCode(StatusCode.OK).build())
+                        .setHeaders(HeaderMutation.newBuilder()
+                                .addSetHeaders(HeaderValueOption.newBuilder()
+                                        .setHeader(HeaderValue.newBuilder()
+                                                .setKey("content-type")
+                                                .setRawValue(ByteString.copyFromUtf8("application/json"))
+                                                .build())
+                                        .build())
+                                .build())
+                        .setBody(cachedResponse)
+                        .setDetails("ai_response_cache_hit")
+                        .build())
+                .build();
+    }
+
+    // storeInCache caches the response asynchronously, so the response is not delayed by the cache
+    private void storeInCache(AIResponseCache responseCache, String cacheKey, String response) {
+        if (!responseCache.isCacheable(response)) {
+            logger.debug("The AI response exceeds the maximum entry size of the cache. Hence not cached.");
+            return;
+        }
+        executorService.submit(() -> {
+            AIResponseCacheRedisClient cacheClient = AIResponseCacheRedisClient.getInstance();
+            if (cacheClient != null && cacheClient.put(cacheKey, response, responseCache.getTtlSeconds())) {
+                AIResponseCacheMetrics.getInstance().recordStore();
+            }
+        });
+    }
+
+    // prepareCachedResponse returns the local reply serving a response of the REST API response cache
+    private ProcessingResponse prepareCachedResponse(CachedResponse cachedResponse) {
+        HeaderMutation.Builder headerMutation = HeaderMutation.newBuilder();
+        if (cachedResponse.getHeaders() != null) {
+            cachedResponse.getHeaders().forEach((name, value) -> headerMutation.addSetHeaders(HeaderValueOption
+                    .newBuilder()
+                    .setHeader(HeaderValue.newBuilder().setKey(name).setRawValue(ByteString.copyFromUtf8(value)).build())
+                    .build()));
+        }
+        headerMutation.addSetHeaders(HeaderValueOption.newBuilder()
+                .setHeader(HeaderValue.newBuilder()
+                        .setKey(RESPONSE_CACHE_STATUS_HEADER)
+                        .setRawValue(ByteString.copyFromUtf8(ResponseCache.STATUS_HIT))
+                        .build())
+                .build());
+        return ProcessingResponse.newBuilder()
+                .setImmediateResponse(ImmediateResponse.newBuilder()
+                        .setStatus(HttpStatus.newBuilder().setCodeValue(cachedResponse.getStatus()).build())
+                        .setHeaders(headerMutation.build())
+                        .setBody(cachedResponse.getBody())
+                        .setDetails("response_cache_hit")
+                        .build())
+                .build();
+    }
+
+    // prepareInvalidResponse returns the local reply replacing a response of the backend which does not conform to the
+    // API definition
+    private ProcessingResponse prepareInvalidResponse(List<Map<String, String>> errors) {
+        return prepareErrorResponse(StatusCode.BadGateway,
+                GeneralErrorCodeConstants.SchemaValidation.INVALID_RESPONSE_CODE,
+                GeneralErrorCodeConstants.SchemaValidation.INVALID_RESPONSE_MESSAGE,
+                GeneralErrorCodeConstants.SchemaValidation.INVALID_RESPONSE_DESCRIPTION, errors,
+                "schema_validation_failure");
+    }
+
+    // prepareSOAPFaultResponse returns the local reply replacing a fault of a SOAP backend, with the status code of
+    // the fault and the fault code in the details of the standard error response
+    private ProcessingResponse prepareSOAPFaultResponse(SOAPMediation.Response soapResponse) {
+        Map<String, String> fault = new HashMap<>();
+        if (soapResponse.getFaultCode() != null) {
+            fault.put("faultCode", soapResponse.getFaultCode());
+        }
+        if (soapResponse.getFaultDetail() != null) {
+            fault.put("detail", soapResponse.getFaultDetail());
+        }
+        String reason = soapResponse.getFaultReason() != null ? soapResponse.getFaultReason()
+                : GeneralErrorCodeConstants.SOAPMediation.FAULT_MESSAGE;
+        return prepareErrorResponse(soapResponse.getFaultStatusCode() == StatusCode.BadRequest_VALUE
+                        ? StatusCode.BadRequest : StatusCode.InternalServerError,
+                GeneralErrorCodeConstants.SOAPMediation.FAULT_CODE,
+                GeneralErrorCodeConstants.SOAPMediation.FAULT_MESSAGE, reason, List.of(fault), "soap_fault");
+    }
+
+    // prepareErrorResponse returns the local reply with the standard error response of the given error
+    private ProcessingResponse prepareErrorResponse(StatusCode statusCode, String code, String message,
+                                                    String description, List<Map<String, String>> errors,
+                                                    String details) {
+        JSONObject responseJson = new JSONObject();
+        responseJson.put(APIConstants.MessageFormat.ERROR_CODE, code);
+        responseJson.put(APIConstants.MessageFormat.ERROR_MESSAGE, message);
+        responseJson.put(APIConstants.MessageFormat.ERROR_DESCRIPTION, description);
+        if (errors != null) {
+            responseJson.put(APIConstants.MessageFormat.ERROR_DETAILS, errors);
+        }
+        return ProcessingResponse.newBuilder()
+                .setImmediateResponse(ImmediateResponse.newBuilder()
+                        .setStatus(HttpStatus.newBuilder().setCode(statusCode).build())
+                        .setHeaders(HeaderMutation.newBuilder()
+                                .addSetHeaders(HeaderValueOption.newBuilder()
+                                        .setHeader(HeaderValue.newBuilder()
+                                                .setKey("content-type")
+                                                .setRawValue(ByteString.copyFromUtf8("application/json"))
+                                                .build())
+                                        .build())
+                                .build())
+                        .setBody(responseJson.toString())
+                        .setDetails(details)
+                        .build())
+                .build();
+    }
+
+    // storeInResponseCache caches the response of a REST API asynchronously, so the response is not delayed by the
+    // cache
+    private void storeInResponseCache(ResponseCache responseCache, String cacheKey, CachedResponse cachedResponse,
+                                      long ttlSeconds) {
+        if (!responseCache.isCacheable(cachedResponse.getBody())) {
+            logger.debug("The response exceeds the maximum entry size of the cache. Hence not cached.");
+            return;
+        }
+        executorService.submit(() -> {
+            ResponseCacheStore store = responseCache.getStore();
+            if (store != null) {
+                store.put(cacheKey, cachedResponse, ttlSeconds);
+            }
+        });
+    }
+
+    // Only the successful responses of the backend are cached. The responses encoded by the backend are not cached,
+    // as the cached responses are served as text.
+    private static boolean isCacheableResponse(String status, String contentEncoding) {
+        return String.valueOf(StatusCode.OK_VALUE).equals(status)
+                && (contentEncoding == null || "identity".equalsIgnoreCase(contentEncoding));
+    }
+
+    // getConsumer returns the end user or the application of the request authenticated by the enforcer
+    private static String getConsumer(ProcessingRequest request) {
+        Struct filterMetadataFromAuthZ = request.getMetadataContext()
+                .getFilterMetadataOrDefault(MetadataConstants.EXT_AUTH_METADATA_CONTEXT_KEY, null);
+        if (filterMetadataFromAuthZ == null
+                || filterMetadataFromAuthZ.getFieldsMap().get(MetadataConstants.RESPONSE_CACHE_CONSUMER) == null) {
+            return null;
+        }
+        return filterMetadataFromAuthZ.getFieldsMap().get(MetadataConstants.RESPONSE_CACHE_CONSUMER).getStringValue();
+    }
+
+    // getMetadataValue returns the value of the given key of the metadata, or null if the key is not set
+    private static String getMetadataValue(Struct metadata, String key) {
+        if (metadata == null || metadata.getFieldsMap().get(key) == null) {
+            return null;
+        }
+        return metadata.getFieldsMap().get(key).getStringValue();
+    }
+
+    private static boolean isSuccessStatus(String status) {
+        return status == null || status.startsWith("2");
+    }
+
     protected HeadersResponse prepareHeadersResponse() {
         return HeadersResponse.newBuilder()
                 .setResponse(
@@ -253,6 +1045,13 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
     private static class FilterMetadata {
         boolean enableBackendBasedAIRatelimit;
         String backendBasedAIRatelimitDescriptorValue;
+        AIGuardrailsValidator guardrails;
+        AIResponseCache responseCache;
+        AITransformation transformation;
+        ResponseCache restResponseCache;
+        BodyTransformation bodyTransformation;
+        SOAPMediation soapMediation;
+        GraphQLSubscription gqlSubscription;
         @Override
         public String toString() {
             return "FilterMetadata{" +
@@ -262,16 +1061,47 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
         }
     }
 
+    // Updates the filter metadata of the stream from the route metadata sent with the first message of a direction
+    private static void updateFilterMetadata(ProcessingRequest request, FilterMetadata filterMetadata) {
+        if (!request.getAttributesMap().isEmpty() && request.getAttributesMap().get(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY) != null && request.getAttributesMap().get(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY).getFieldsMap().get("xds.route_metadata") != null){
+            Value value = request.getAttributesMap().get(MetadataConstants.EXT_PROC_METADATA_CONTEXT_KEY).getFieldsMap().get("xds.route_metadata");
+            FilterMetadata metadata = convertStringToFilterMetadata(value.getStringValue());
+            filterMetadata.backendBasedAIRatelimitDescriptorValue = metadata.backendBasedAIRatelimitDescriptorValue;
+            filterMetadata.enableBackendBasedAIRatelimit = metadata.enableBackendBasedAIRatelimit;
+            filterMetadata.guardrails = metadata.guardrails;
+            filterMetadata.responseCache = metadata.responseCache;
+            filterMetadata.transformation = metadata.transformation;
+            filterMetadata.restResponseCache = metadata.restResponseCache;
+            filterMetadata.bodyTransformation = metadata.bodyTransformation;
+            filterMetadata.soapMediation = metadata.soapMediation;
+            filterMetadata.gqlSubscription = metadata.gqlSubscription;
+        }
+    }
+
     // Method to parse the string and create FilterMetadata object
     public static FilterMetadata convertStringToFilterMetadata(String input) {
         FilterMetadata metadata = new FilterMetadata();
         // Regex patterns to extract specific fields
         String backendValuePattern = "key: \"BackendBasedAIRatelimitDescriptorValue\".*?string_value: \"(.*?)\"";
         String enableBackendPattern = "key: \"EnableBackendBasedAIRatelimit\".*?string_value: \"(.*?)\"";
+        String guardrailsPattern = "key: \"AIGuardrails\".*?string_value: \"(.*?)\"";
+        String responseCachePattern = "key: \"AIResponseCache\".*?string_value: \"(.*?)\"";
+        String transformationPattern = "key: \"AITransformation\".*?string_value: \"(.*?)\"";
+        String restResponseCachePattern = "key: \"ResponseCache\".*?string_value: \"(.*?)\"";
+        String bodyTransformationPattern = "key: \"BodyTransformation\".*?string_value: \"(.*?)\"";
+        String soapMediationPattern = "key: \"SOAPMediation\".*?string_value: \"(.*?)\"";
+        String gqlSubscriptionPattern = "key: \"GraphQLSubscription\".*?string_value: \"(.*?)\"";
 
         // Extract and assign to the FilterMetadata object
         metadata.backendBasedAIRatelimitDescriptorValue = extractValue(input, backendValuePattern);
         metadata.enableBackendBasedAIRatelimit = Boolean.parseBoolean(extractValue(input, enableBackendPattern));
+        metadata.guardrails = AIGuardrailsValidator.fromEncodedConfig(extractValue(input, guardrailsPattern));
+        metadata.responseCache = AIResponseCache.fromEncodedConfig(extractValue(input, responseCachePattern));
+        metadata.transformation = AITransformation.fromEncodedConfig(extractValue(input, transformationPattern));
+        metadata.restResponseCache = ResponseCache.fromEncodedConfig(extractValue(input, restResponseCachePattern));
+        metadata.bodyTransformation = BodyTransformation.fromEncodedConfig(extractValue(input, bodyTransformationPattern));
+        metadata.soapMediation = SOAPMediation.fromEncodedConfig(extractValue(input, soapMediationPattern));
+        metadata.gqlSubscription = GraphQLSubscription.fromEncodedConfig(extractValue(input, gqlSubscriptionPattern));
 
         return metadata;
     }
@@ -291,6 +1121,38 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
         structBuilder.putFields(key, Value.newBuilder().setNumberValue(value).build());
     }
 
+    /**
+     * Records the tokens of the response against the token budget of the subscription, if the subscription
+     * based AI ratelimit policy of the request has a token budget.
+     *
+     * @param filterMetadataFromAuthZ metadata set by the ext_authz filter
+     * @param usage                   token usage of the response
+     */
+    private static void consumeTokenBudget(Struct filterMetadataFromAuthZ, Usage usage) {
+        if (filterMetadataFromAuthZ == null) {
+            return;
+        }
+        Value policyKey = filterMetadataFromAuthZ.getFieldsMap().get(MetadataConstants.ORGANIZATION_AND_AIRL_POLICY);
+        Value application = filterMetadataFromAuthZ.getFieldsMap().get(MetadataConstants.TOKEN_BUDGET_APPLICATION);
+        Value subscription = filterMetadataFromAuthZ.getFieldsMap().get(MetadataConstants.TOKEN_BUDGET_SUBSCRIPTION);
+        if (policyKey == null || application == null || subscription == null) {
+            return;
+        }
+        TokenBudgetManager tokenBudgetManager = TokenBudgetManager.getInstance();
+        if (tokenBudgetManager == null) {
+            return;
+        }
+        try {
+            TokenBudget budget = tokenBudgetManager.getBudget(policyKey.getStringValue());
+            if (budget != null) {
+                tokenBudgetManager.consume(policyKey.getStringValue(), budget, application.getStringValue(),
+                        subscription.getStringValue(), usage.getTotal_tokens());
+            }
+        } catch (EnforcerException e) {
+            logger.error("Error while recording the consumed tokens of the token budget.", e);
+        }
+    }
+
     // Helper method to extract value based on a regex pattern
     private static String extractValue(String input, String pattern) {
         Pattern p = Pattern.compile(pattern);
@@ -306,6 +1168,19 @@ public class ExternalProcessorService extends ExternalProcessorGrpc.ExternalProc
         return input.replaceAll("[\\t\\n\\r]+", " ").trim();
     }
 
+    private static String getHeaderValue(HttpHeaders headers, String headerName) {
+        for (HeaderValue headerValue : headers.getHeaders().getHeadersList()) {
+            if (headerValue.getKey().equalsIgnoreCase(headerName)) {
+                String value = headerValue.getValue();
+                if (value.isEmpty()) {
+                    value = headerValue.getRawValue().toString(StandardCharsets.UTF_8);
+                }
+                return value;
+            }
+        }
+        return null;
+    }
+
     private static Usage extractUsageFromHeaders(HttpHeaders headers, String completionTokenPath, String promptTokenPath, String totalTokenPath, String modelPath) {
         try {
             Usage usage = new Usage();
@@ -0,0 +1,330 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.grpc;
+
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+
+import java.io.ByteArrayOutputStream;
+import java.nio.charset.StandardCharsets;
+import java.util.Arrays;
+import java.util.zip.DataFormatException;
+import java.util.zip.Inflater;
+
+/**
+ * Collects the token usage of a server-sent event stream of an AI provider response, chunk by chunk, without
+ * holding the whole stream. The chunks are decoded by the content encoding of the response, and a line split across
+ * chunks is kept until the rest of it arrives. The usage is taken from the last event carrying the usage fields.
+ * When the provider does not send the usage, the prompt tokens are estimated from the request and the completion
+ * tokens by the number of data events of the stream.
+ */
+public class ServerSentEventUsageCollector {
+
+    private static final Logger logger = LogManager.getLogger(ServerSentEventUsageCollector.class);
+    private static final String EVENT_STREAM_CONTENT_TYPE = "text/event-stream";
+    private static final String DATA_FIELD = "data:";
+    private static final String DONE_EVENT = "[DONE]";
+    private static final int CHARACTERS_PER_TOKEN = 4;
+    private static final int GZIP_HEADER_LENGTH = 10;
+    private static final int GZIP_DEFLATE_METHOD = 8;
+    private static final int GZIP_FLAG_HEADER_CRC = 2;
+    private static final int GZIP_FLAG_EXTRA = 4;
+    private static final int GZIP_FLAG_NAME = 8;
+    private static final int GZIP_FLAG_COMMENT = 16;
+    private static final ObjectMapper mapper = new ObjectMapper();
+
+    private final String completionTokenPath;
+    private final String promptTokenPath;
+    private final String totalTokenPath;
+    private final String modelPath;
+    private final int estimatedPromptTokens;
+    private final ByteArrayOutputStream partialLine = new ByteArrayOutputStream();
+    // inflates the gzip and deflate encoded streams, and is null for the streams which are not encoded
+    private final Inflater inflater;
+    // holds the beginning of a gzip stream until its header is complete, and is null once the header is skipped
+    private ByteArrayOutputStream gzipHeader;
+    // set when the stream can not be decoded, in which case the usage is not collected
+    private boolean undecodable;
+    private ExternalProcessorService.Usage usage;
+    private String model = "";
+    private int dataEvents;
+
+    public ServerSentEventUsageCollector(String completionTokenPath, String promptTokenPath, String totalTokenPath,
+                                         String modelPath, String contentEncoding, int estimatedPromptTokens) {
+        this.completionTokenPath = completionTokenPath;
+        this.promptTokenPath = promptTokenPath;
+        this.totalTokenPath = totalTokenPath;
+        this.modelPath = modelPath;
+        this.estimatedPromptTokens = estimatedPromptTokens;
+        String encoding = contentEncoding == null ? "" : contentEncoding.trim().toLowerCase();
+        switch (encoding) {
+            case "":
+            case "identity":
+                inflater = null;
+                break;
+            case "gzip":
+            case "x-gzip":
+                inflater = new Inflater(true);
+                gzipHeader = new ByteArrayOutputStream();
+                break;
+            case "deflate":
+                inflater = new Inflater();
+                break;
+            default:
+                logger.debug("Usage of the event stream encoded with {} is not collected", encoding);
+                inflater = null;
+                undecodable = true;
+        }
+    }
+
+    /**
+     * Checks whether the given content type is the content type of a server-sent event stream.
+     *
+     * @param contentType content type header value of the response
+     * @return true if the response is a server-sent event stream
+     */
+    public static boolean isEventStream(String contentType) {
+        return contentType != null && contentType.trim().toLowerCase().startsWith(EVENT_STREAM_CONTENT_TYPE);
+    }
+
+    /**
+     * Estimates the prompt tokens of the given request by the characters of the text of its messages or prompt.
+     * All the characters of the request are counted when it does not carry messages or a prompt.
+     *
+     * @param requestBody body of the request sent to the provider
+     * @return the estimated prompt tokens
+     */
+    public static int estimatePromptTokens(String requestBody) {
+        if (requestBody == null || requestBody.isEmpty()) {
+            return 0;
+        }
+        int characters = requestBody.length();
+        try {
+            JsonNode request = mapper.readTree(requestBody);
+            StringBuilder text = new StringBuilder();
+            appendText(request.get("messages"), text);
+            appendText(request.get("prompt"), text);
+            if (text.length() > 0) {
+                characters = text.length();
+            }
+        } catch (Exception e) {
+            logger.debug("Estimating the prompt tokens from the request which is not a json");
+        }
+        return (characters + CHARACTERS_PER_TOKEN - 1) / CHARACTERS_PER_TOKEN;
+    }
+
+    /**
+     * Processes the complete lines of the given chunk.
+     *
+     * @param chunk a chunk of the response body, in the content encoding of the response
+     */
+    public void addChunk(byte[] chunk) {
+        if (undecodable) {
+            return;
+        }
+        byte[] decoded;
+        try {
+            decoded = decode(chunk);
+        } catch (DataFormatException e) {
+            logger.debug("Error while decoding the event stream. Usage of the stream is not collected", e);
+            undecodable = true;
+            return;
+        }
+        // lines are split at the new line bytes, which are never a part of a multi byte character
+        int lineStart = 0;
+        for (int i = 0; i < decoded.length; i++) {
+            if (decoded[i] == '\n') {
+                partialLine.write(decoded, lineStart, i - lineStart);
+                processLine(new String(partialLine.toByteArray(), StandardCharsets.UTF_8).trim());
+                partialLine.reset();
+                lineStart = i + 1;
+            }
+        }
+        partialLine.write(decoded, lineStart, decoded.length - lineStart);
+    }
+
+    /**
+     * Returns the usage of the stream. This is called once the end of the stream is reached.
+     *
+     * @return the usage reported by the provider, the estimated usage if the provider did not report it, or null if
+     * the stream could not be decoded
+     */
+    public ExternalProcessorService.Usage getUsage() {
+        if (inflater != null) {
+            inflater.end();
+        }
+        if (undecodable) {
+            return null;
+        }
+        if (partialLine.size() > 0) {
+            processLine(new String(partialLine.toByteArray(), StandardCharsets.UTF_8).trim());
+            partialLine.reset();
+        }
+        if (usage != null) {
+            return usage;
+        }
+        logger.debug("Usage not found in the event stream. Estimating the usage from {} data events", dataEvents);
+        ExternalProcessorService.Usage estimatedUsage = new ExternalProcessorService.Usage();
+        estimatedUsage.setPrompt_tokens(estimatedPromptTokens);
+        estimatedUsage.setCompletion_tokens(dataEvents);
+        estimatedUsage.setTotal_tokens(estimatedPromptTokens + dataEvents);
+        estimatedUsage.setModel(model);
+        return estimatedUsage;
+    }
+
+    private byte[] decode(byte[] chunk) throws DataFormatException {
+        if (inflater == null) {
+            return chunk;
+        }
+        if (gzipHeader != null) {
+            gzipHeader.write(chunk, 0, chunk.length);
+            byte[] data = gzipHeader.toByteArray();
+            int headerLength = getGzipHeaderLength(data);
+            if (headerLength < 0) {
+                return new byte[0];
+            }
+            gzipHeader = null;
+            chunk = Arrays.copyOfRange(data, headerLength, data.length);
+        }
+        inflater.setInput(chunk);
+        ByteArrayOutputStream decoded = new ByteArrayOutputStream();
+        byte[] buffer = new byte[8192];
+        while (!inflater.finished()) {
+            int length = inflater.inflate(buffer);
+            if (length == 0) {
+                break;
+            }
+            decoded.write(buffer, 0, length);
+        }
+        return decoded.toByteArray();
+    }
+
+    /**
+     * Returns the length of the gzip header at the beginning of the given data.
+     *
+     * @param data beginning of a gzip stream
+     * @return the length of the header, or -1 if the header is not complete
+     * @throws DataFormatException if the data is not in the gzip format
+     */
+    private static int getGzipHeaderLength(byte[] data) throws DataFormatException {
+        if (data.length < GZIP_HEADER_LENGTH) {
+            return -1;
+        }
+        if ((data[0] & 0xff) != 0x1f || (data[1] & 0xff) != 0x8b || data[2] != GZIP_DEFLATE_METHOD) {
+            throw new DataFormatException("Not in the gzip format");
+        }
+        int flags = data[3] & 0xff;
+        int position = GZIP_HEADER_LENGTH;
+        if ((flags & GZIP_FLAG_EXTRA) != 0) {
+            if (data.length < position + 2) {
+                return -1;
+            }
+            position += 2 + ((data[position] & 0xff) | ((data[position + 1] & 0xff) << 8));
+        }
+        if ((flags & GZIP_FLAG_NAME) != 0) {
+            position = skipZeroTerminated(data, position);
+        }
+        if (position >= 0 && (flags & GZIP_FLAG_COMMENT) != 0) {
+            position = skipZeroTerminated(data, position);
+        }
+        if (position >= 0 && (flags & GZIP_FLAG_HEADER_CRC) != 0) {
+            position += 2;
+        }
+        return position <= data.length ? position : -1;
+    }
+
+    private static int skipZeroTerminated(byte[] data, int position) {
+        for (int i = position; i < data.length; i++) {
+            if (data[i] == 0) {
+                return i + 1;
+            }
+        }
+        return -1;
+    }
+
+    private static void appendText(JsonNode node, StringBuilder text) {
+        if (node == null) {
+            return;
+        }
+        if (node.isTextual()) {
+            text.append(node.asText());
+        } else if (node.isArray()) {
+            for (JsonNode element : node) {
+                appendText(element, text);
+            }
+        } else if (node.isObject()) {
+            appendText(node.get("content"), text);
+            appendText(node.get("text"), text);
+        }
+    }
+
+    private void processLine(String line) {
+        if (!line.startsWith(DATA_FIELD)) {
+            return;
+        }
+        String data = line.substring(DATA_FIELD.length()).trim();
+        if (data.isEmpty() || DONE_EVENT.equals(data)) {
+            return;
+        }
+        dataEvents++;
+        JsonNode event;
+        try {
+            event = mapper.readTree(data);
+        } catch (Exception e) {
+            logger.debug("Skipping the event which is not a json in the event stream: {}", data);
+            return;
+        }
+        JsonNode modelNode = getNode(event, modelPath);
+        if (modelNode != null && !modelNode.asText().isEmpty()) {
+            model = modelNode.asText();
+        }
+        JsonNode promptTokens = getNode(event, promptTokenPath);
+        JsonNode completionTokens = getNode(event, completionTokenPath);
+        JsonNode totalTokens = getNode(event, totalTokenPath);
+        if (promptTokens == null || completionTokens == null || totalTokens == null || totalTokens.isNull()) {
+            return;
+        }
+        ExternalProcessorService.Usage eventUsage = new ExternalProcessorService.Usage();
+        eventUsage.setPrompt_tokens(promptTokens.asInt());
+        eventUsage.setCompletion_tokens(completionTokens.asInt());
+        eventUsage.setTotal_tokens(totalTokens.asInt());
+        eventUsage.setModel(model);
+        usage = eventUsage;
+    }
+
+    private static JsonNode getNode(JsonNode rootNode, String path) {
+        if (path == null || path.isEmpty()) {
+            return null;
+        }
+        String[] keys = path.split("\\.");
+        if (keys.length > 0 && "$".equals(keys[0])) {
+            keys = Arrays.copyOfRange(keys, 1, keys.length);
+        }
+        JsonNode currentNode = rootNode;
+        for (String key : keys) {
+            if (currentNode == null || !currentNode.has(key)) {
+                return null;
+            }
+            currentNode = currentNode.get(key);
+        }
+        return currentNode;
+    }
+}
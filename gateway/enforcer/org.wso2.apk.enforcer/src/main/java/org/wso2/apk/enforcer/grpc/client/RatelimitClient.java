@@ -5,6 +5,7 @@ import io.envoyproxy.envoy.service.ratelimit.v3.RateLimitRequest;
 import io.envoyproxy.envoy.service.ratelimit.v3.RateLimitServiceGrpc;
 import io.envoyproxy.envoy.service.ratelimit.v3.RateLimitResponse;
 import io.grpc.ManagedChannel;
+import io.grpc.StatusRuntimeException;
 import io.grpc.netty.shaded.io.grpc.netty.GrpcSslContexts;
 import io.grpc.netty.shaded.io.grpc.netty.NettyChannelBuilder;
 import io.grpc.netty.shaded.io.netty.handler.ssl.SslContext;
@@ -65,6 +66,28 @@ public class RatelimitClient {
         }
     }
 
+    /**
+     * Sends a hit of the given descriptor to the rate limit service. The hit is allowed when the rate limit service
+     * is unreachable, as the router does by default.
+     *
+     * @param descriptor descriptor of the rate limit
+     * @return true, if the descriptor has exceeded its limit
+     */
+    public boolean isOverLimit(RateLimitDescriptor descriptor) {
+        RateLimitRequest rateLimitRequest = RateLimitRequest.newBuilder()
+                .addDescriptors(descriptor)
+                .setDomain("Default")
+                .setHitsAddend(1)
+                .build();
+        try {
+            RateLimitResponse rateLimitResponse = stub.shouldRateLimit(rateLimitRequest);
+            return rateLimitResponse.getOverallCode() == RateLimitResponse.Code.OVER_LIMIT;
+        } catch (StatusRuntimeException e) {
+            logger.error("Error while checking the rate limit of the descriptor. " + e.getMessage());
+            return false;
+        }
+    }
+
     public static class KeyValueHitsAddend {
         private String key;
         private String value;
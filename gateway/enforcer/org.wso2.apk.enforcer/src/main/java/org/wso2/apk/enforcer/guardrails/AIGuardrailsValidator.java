@@ -0,0 +1,606 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.guardrails;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import com.fasterxml.jackson.databind.node.ArrayNode;
+import com.fasterxml.jackson.databind.node.ObjectNode;
+import com.fasterxml.jackson.databind.node.TextNode;
+import com.google.common.cache.CacheBuilder;
+import com.google.common.cache.CacheLoader;
+import com.google.common.cache.LoadingCache;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.everit.json.schema.Schema;
+import org.everit.json.schema.ValidationException;
+import org.everit.json.schema.loader.SchemaLoader;
+import org.json.JSONArray;
+import org.json.JSONObject;
+import org.json.JSONTokener;
+
+import java.nio.charset.StandardCharsets;
+import java.util.ArrayList;
+import java.util.Base64;
+import java.util.Iterator;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.regex.Matcher;
+import java.util.regex.Pattern;
+
+/**
+ * Applies the AI guardrails of an API on the prompts sent to the AI provider and on the responses returned by it.
+ * The guardrails are received from the adapter as base64 encoded json in the route metadata. The guardrails are
+ * applied on the text of the json payloads, such as the message contents, rather than on the raw json, and the
+ * payloads are rejected when the guardrails of the route could not be read.
+ */
+public class AIGuardrailsValidator {
+
+    private static final Logger logger = LogManager.getLogger(AIGuardrailsValidator.class);
+    private static final ObjectMapper mapper = new ObjectMapper();
+    private static final int MAX_CACHED_VALIDATORS = 1000;
+    // validators are shared by the routes with the same guardrails, as compiling the patterns is costly
+    private static final LoadingCache<String, AIGuardrailsValidator> validators = CacheBuilder.newBuilder()
+            .maximumSize(MAX_CACHED_VALIDATORS).build(new CacheLoader<String, AIGuardrailsValidator>() {
+                @Override
+                public AIGuardrailsValidator load(String encodedConfig) {
+                    try {
+                        String configJson = new String(Base64.getDecoder().decode(encodedConfig),
+                                StandardCharsets.UTF_8);
+                        return new AIGuardrailsValidator(mapper.readValue(configJson, GuardrailsConfig.class));
+                    } catch (Exception e) {
+                        logger.error("Error while reading the AI guardrails of the route. " + e);
+                        return new AIGuardrailsValidator();
+                    }
+                }
+            });
+
+    public static final String VIOLATION_PROMPT_LENGTH = "PROMPT_LENGTH";
+    public static final String VIOLATION_DENY_PATTERN = "DENY_PATTERN";
+    public static final String VIOLATION_DENY_KEYWORD = "DENY_KEYWORD";
+    public static final String VIOLATION_PII = "PII";
+    public static final String VIOLATION_RESPONSE_SCHEMA = "RESPONSE_SCHEMA";
+    public static final String VIOLATION_CONFIGURATION = "CONFIGURATION";
+
+    private static final String PII_EMAIL = "Email";
+    private static final String PII_CARD_NUMBER = "CardNumber";
+    private static final String PII_NATIONAL_ID = "NationalID";
+    private static final Map<String, Pattern> PII_PATTERNS = Map.of(
+            PII_EMAIL, Pattern.compile("[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\\.[A-Za-z]{2,}"),
+            PII_CARD_NUMBER, Pattern.compile("\\b(?:\\d[ -]?){12,18}\\d\\b"),
+            PII_NATIONAL_ID, Pattern.compile("\\b\\d{3}-\\d{2}-\\d{4}\\b"));
+    private static final Map<String, String> PII_PLACEHOLDERS = Map.of(
+            PII_EMAIL, "[REDACTED_EMAIL]",
+            PII_CARD_NUMBER, "[REDACTED_CARD_NUMBER]",
+            PII_NATIONAL_ID, "[REDACTED_NATIONAL_ID]");
+
+    private final List<Pattern> denyPatterns = new ArrayList<>();
+    private final List<Pattern> denyKeywords = new ArrayList<>();
+    private final Map<String, Pattern> piiPatterns = new LinkedHashMap<>();
+    private final boolean rejectPII;
+    private final int maxPromptLength;
+    private final Schema responseSchema;
+    private final String responseContentPath;
+    // invalid is set when the guardrails could not be read, in which case every payload is rejected
+    private final boolean invalid;
+
+    private AIGuardrailsValidator() {
+        rejectPII = false;
+        maxPromptLength = 0;
+        responseSchema = null;
+        responseContentPath = null;
+        invalid = true;
+    }
+
+    private AIGuardrailsValidator(GuardrailsConfig config) throws Exception {
+        if (config.denyPatterns != null) {
+            for (String denyPattern : config.denyPatterns) {
+                denyPatterns.add(Pattern.compile(denyPattern));
+            }
+        }
+        if (config.denyKeywords != null) {
+            for (String denyKeyword : config.denyKeywords) {
+                denyKeywords.add(Pattern.compile("\\b" + Pattern.quote(denyKeyword) + "\\b",
+                        Pattern.CASE_INSENSITIVE | Pattern.UNICODE_CASE));
+            }
+        }
+        if (config.piiEntities != null) {
+            for (String piiEntity : config.piiEntities) {
+                if (PII_PATTERNS.containsKey(piiEntity)) {
+                    piiPatterns.put(piiEntity, PII_PATTERNS.get(piiEntity));
+                }
+            }
+        }
+        rejectPII = "Reject".equals(config.piiAction);
+        maxPromptLength = config.maxPromptLength;
+        responseSchema = config.responseSchema == null || config.responseSchema.isEmpty() ? null
+                : loadSchema(config.responseSchema);
+        responseContentPath = config.responseContentPath;
+        invalid = false;
+    }
+
+    /**
+     * Returns the validator of the given guardrails. If the guardrails could not be read, the returned validator
+     * rejects every payload.
+     *
+     * @param encodedConfig base64 encoded json of the guardrails
+     * @return the validator, or null if the route has no guardrails
+     */
+    public static AIGuardrailsValidator fromEncodedConfig(String encodedConfig) {
+        if (encodedConfig == null || encodedConfig.isEmpty()) {
+            return null;
+        }
+        return validators.getUnchecked(encodedConfig);
+    }
+
+    /**
+     * Checks whether the responses are inspected by the guardrails.
+     *
+     * @return true if any of the guardrails applies to the responses
+     */
+    public boolean hasResponseChecks() {
+        return invalid || !denyPatterns.isEmpty() || !denyKeywords.isEmpty() || !piiPatterns.isEmpty()
+                || responseSchema != null;
+    }
+
+    /**
+     * Checks whether the guardrails could not be read, in which case every payload is rejected.
+     *
+     * @return true if the guardrails are invalid
+     */
+    public boolean isInvalid() {
+        return invalid;
+    }
+
+    /**
+     * Applies the guardrails on the request body sent to the AI provider.
+     *
+     * @param body request body
+     * @return the result of the guardrails
+     */
+    public Result validatePrompt(String body) {
+        if (invalid) {
+            return configurationViolation();
+        }
+        JsonNode rootNode = readJson(body);
+        List<TextField> textFields = rootNode == null ? null : getPromptFields(rootNode);
+        if (maxPromptLength > 0) {
+            int promptLength = 0;
+            if (textFields == null) {
+                promptLength = body.codePointCount(0, body.length());
+            } else {
+                for (TextField textField : textFields) {
+                    promptLength += textField.text.codePointCount(0, textField.text.length());
+                }
+            }
+            if (promptLength > maxPromptLength) {
+                return Result.violation(VIOLATION_PROMPT_LENGTH,
+                        String.format("The prompt exceeds the maximum length of %d characters.", maxPromptLength));
+            }
+        }
+        return validateContent(body, rootNode, textFields, "prompt");
+    }
+
+    /**
+     * Applies the guardrails on the response body returned by the AI provider.
+     *
+     * @param body response body
+     * @return the result of the guardrails
+     */
+    public Result validateResponse(String body) {
+        if (invalid) {
+            return configurationViolation();
+        }
+        JsonNode rootNode = readJson(body);
+        List<TextField> outputFields = rootNode == null ? null : getNodes(rootNode, responseContentPath);
+        List<TextField> textFields = outputFields == null ? null : getTextFields(outputFields);
+        if (rootNode != null && textFields.isEmpty()) {
+            // the output is not found at the configured path, so every text of the response is inspected
+            textFields = new ArrayList<>();
+            collectTextFields(rootNode, textFields);
+        }
+        Result result = validateContent(body, rootNode, textFields, "response");
+        if (result.isViolated() || responseSchema == null) {
+            return result;
+        }
+        String violation;
+        if (rootNode == null) {
+            violation = "The model output is not a valid json.";
+        } else if (outputFields == null || outputFields.isEmpty()) {
+            violation = "The model output is not found in the response.";
+        } else {
+            try {
+                JsonNode output = outputFields.get(0).node;
+                if (output.isTextual()) {
+                    output = mapper.readTree(output.asText());
+                }
+                responseSchema.validate(new JSONTokener(mapper.writeValueAsString(output)).nextValue());
+                violation = null;
+            } catch (ValidationException e) {
+                violation = "The model output does not conform to the response schema. " + e.getMessage();
+            } catch (Exception e) {
+                violation = "The model output is not a valid json.";
+            }
+        }
+        if (violation != null) {
+            return Result.violation(VIOLATION_RESPONSE_SCHEMA, violation);
+        }
+        return result;
+    }
+
+    // loadSchema compiles the given json schema. The schemas referred by URLs are not fetched, as the schema is
+    // provided by the API developer, so such a schema is taken as invalid.
+    private static Schema loadSchema(String schema) {
+        JSONObject schemaJson = new JSONObject(schema);
+        checkReferences(schemaJson);
+        return SchemaLoader.load(schemaJson);
+    }
+
+    private static void checkReferences(Object schema) {
+        if (schema instanceof JSONArray) {
+            for (Object item : (JSONArray) schema) {
+                checkReferences(item);
+            }
+            return;
+        }
+        if (!(schema instanceof JSONObject)) {
+            return;
+        }
+        JSONObject schemaJson = (JSONObject) schema;
+        Object ref = schemaJson.opt("$ref");
+        if (ref instanceof String && !((String) ref).startsWith("#")) {
+            throw new IllegalArgumentException("Response schema refers to an external schema: " + ref);
+        }
+        for (String key : schemaJson.keySet()) {
+            checkReferences(schemaJson.get(key));
+        }
+    }
+
+    // validateContent applies the deny patterns, the deny keywords and the PII checks on the given text fields of
+    // the json payload, or on the raw payload if it is not json.
+    private Result validateContent(String body, JsonNode rootNode, List<TextField> textFields, String contentName) {
+        if (textFields == null) {
+            textFields = new ArrayList<>();
+            textFields.add(new TextField(null, null, -1, new TextNode(body)));
+        }
+        for (TextField textField : textFields) {
+            for (Pattern denyPattern : denyPatterns) {
+                if (denyPattern.matcher(textField.text).find()) {
+                    return Result.violation(VIOLATION_DENY_PATTERN,
+                            String.format("The %s contains content which is not allowed.", contentName));
+                }
+            }
+            for (Pattern denyKeyword : denyKeywords) {
+                if (denyKeyword.matcher(textField.text).find()) {
+                    return Result.violation(VIOLATION_DENY_KEYWORD,
+                            String.format("The %s contains a keyword which is not allowed.", contentName));
+                }
+            }
+        }
+        Result result = new Result();
+        String redactedBody = null;
+        for (TextField textField : textFields) {
+            String redactedContent = textField.text;
+            for (Map.Entry<String, Pattern> piiPattern : piiPatterns.entrySet()) {
+                Matcher matcher = piiPattern.getValue().matcher(redactedContent);
+                StringBuilder redacted = new StringBuilder();
+                boolean detected = false;
+                while (matcher.find()) {
+                    if (PII_CARD_NUMBER.equals(piiPattern.getKey()) && !passesLuhnCheck(matcher.group())) {
+                        continue;
+                    }
+                    if (rejectPII) {
+                        return Result.violation(VIOLATION_PII, String.format(
+                                "The %s contains personally identifiable information of type %s.", contentName,
+                                piiPattern.getKey()));
+                    }
+                    detected = true;
+                    matcher.appendReplacement(redacted,
+                            Matcher.quoteReplacement(PII_PLACEHOLDERS.get(piiPattern.getKey())));
+                }
+                if (detected) {
+                    matcher.appendTail(redacted);
+                    redactedContent = redacted.toString();
+                    if (!result.redactedEntities.contains(piiPattern.getKey())) {
+                        result.redactedEntities.add(piiPattern.getKey());
+                    }
+                }
+            }
+            if (!redactedContent.equals(textField.text)) {
+                if (textField.parent == null) {
+                    redactedBody = redactedContent;
+                } else {
+                    textField.replace(redactedContent);
+                }
+            }
+        }
+        if (!result.redactedEntities.isEmpty()) {
+            if (redactedBody == null) {
+                try {
+                    redactedBody = mapper.writeValueAsString(rootNode);
+                } catch (Exception e) {
+                    logger.error("Error while writing the redacted payload. " + e);
+                    return configurationViolation();
+                }
+            }
+            result.redactedPayload = redactedBody;
+        }
+        return result;
+    }
+
+    private static Result configurationViolation() {
+        return Result.violation(VIOLATION_CONFIGURATION, "The AI guardrails of the API could not be applied.");
+    }
+
+    // readJson returns the json object or array of the payload, or null if the payload is not json.
+    private static JsonNode readJson(String body) {
+        try {
+            JsonNode rootNode = mapper.readTree(body);
+            if (rootNode != null && (rootNode.isObject() || rootNode.isArray())) {
+                return rootNode;
+            }
+        } catch (Exception e) {
+            // the payload is inspected as text
+        }
+        return null;
+    }
+
+    // getPromptFields returns the texts of the prompt, the system prompt, the input and the message contents of the
+    // request, or every text of the request if none of them are present.
+    private static List<TextField> getPromptFields(JsonNode rootNode) {
+        List<TextField> textFields = new ArrayList<>();
+        if (rootNode.isObject()) {
+            for (String fieldName : new String[]{"prompt", "system", "input"}) {
+                addTextFields(textFields, rootNode, fieldName, -1, rootNode.get(fieldName));
+            }
+            JsonNode messages = rootNode.get("messages");
+            if (messages != null && messages.isArray()) {
+                for (JsonNode message : messages) {
+                    if (message.isObject()) {
+                        addTextFields(textFields, message, "content", -1, message.get("content"));
+                    }
+                }
+            }
+        }
+        if (textFields.isEmpty()) {
+            collectTextFields(rootNode, textFields);
+        }
+        return textFields;
+    }
+
+    // addTextFields adds the given node if it is a text, or the texts of its parts if it is an array of texts or
+    // of content parts such as {"type": "text", "text": "..."}.
+    private static void addTextFields(List<TextField> textFields, JsonNode parent, String fieldName, int index,
+                                      JsonNode node) {
+        if (node == null) {
+            return;
+        }
+        if (node.isTextual()) {
+            textFields.add(new TextField(parent, fieldName, index, node));
+        } else if (node.isArray()) {
+            for (int i = 0; i < node.size(); i++) {
+                JsonNode part = node.get(i);
+                if (part.isObject()) {
+                    addTextFields(textFields, part, "text", -1, part.get("text"));
+                } else {
+                    addTextFields(textFields, node, null, i, part);
+                }
+            }
+        }
+    }
+
+    // collectTextFields adds every text value of the given node, ignoring the field names.
+    private static void collectTextFields(JsonNode node, List<TextField> textFields) {
+        if (node.isObject()) {
+            Iterator<Map.Entry<String, JsonNode>> fields = node.fields();
+            while (fields.hasNext()) {
+                Map.Entry<String, JsonNode> field = fields.next();
+                if (field.getValue().isTextual()) {
+                    textFields.add(new TextField(node, field.getKey(), -1, field.getValue()));
+                } else {
+                    collectTextFields(field.getValue(), textFields);
+                }
+            }
+        } else if (node.isArray()) {
+            for (int i = 0; i < node.size(); i++) {
+                if (node.get(i).isTextual()) {
+                    textFields.add(new TextField(node, null, i, node.get(i)));
+                } else {
+                    collectTextFields(node.get(i), textFields);
+                }
+            }
+        }
+    }
+
+    // getTextFields returns the texts of the given nodes, which are either texts, arrays of content parts or json
+    // objects.
+    private static List<TextField> getTextFields(List<TextField> fields) {
+        List<TextField> textFields = new ArrayList<>();
+        for (TextField field : fields) {
+            if (field.node.isArray()) {
+                addTextFields(textFields, field.parent, field.fieldName, field.index, field.node);
+            } else if (field.node.isObject()) {
+                collectTextFields(field.node, textFields);
+            } else if (field.node.isTextual()) {
+                textFields.add(field);
+            }
+        }
+        return textFields;
+    }
+
+    // getNodes returns the nodes at the given path, such as $.choices[0].message.content or
+    // $.choices[*].message.content.
+    private static List<TextField> getNodes(JsonNode rootNode, String path) {
+        List<TextField> nodes = new ArrayList<>();
+        if (path == null || path.isEmpty()) {
+            return nodes;
+        }
+        List<TextField> currentFields = new ArrayList<>();
+        currentFields.add(new TextField(null, null, -1, rootNode));
+        for (String segment : path.split("\\.")) {
+            if ("$".equals(segment) || segment.isEmpty()) {
+                continue;
+            }
+            String fieldName = segment;
+            String index = null;
+            int bracket = segment.indexOf('[');
+            if (bracket >= 0 && segment.endsWith("]")) {
+                fieldName = segment.substring(0, bracket);
+                index = segment.substring(bracket + 1, segment.length() - 1);
+            }
+            List<TextField> nextFields = new ArrayList<>();
+            for (TextField currentField : currentFields) {
+                JsonNode node = currentField.node;
+                TextField field = currentField;
+                if (!fieldName.isEmpty()) {
+                    JsonNode child = node.isObject() ? node.get(fieldName) : null;
+                    if (child == null) {
+                        continue;
+                    }
+                    field = new TextField(node, fieldName, -1, child);
+                    node = child;
+                }
+                if (index == null) {
+                    nextFields.add(field);
+                } else if (node.isArray()) {
+                    if ("*".equals(index)) {
+                        for (int i = 0; i < node.size(); i++) {
+                            nextFields.add(new TextField(node, null, i, node.get(i)));
+                        }
+                    } else {
+                        int i;
+                        try {
+                            i = Integer.parseInt(index);
+                        } catch (NumberFormatException e) {
+                            return nodes;
+                        }
+                        if (i >= 0 && i < node.size()) {
+                            nextFields.add(new TextField(node, null, i, node.get(i)));
+                        }
+                    }
+                }
+            }
+            currentFields = nextFields;
+        }
+        for (TextField currentField : currentFields) {
+            if (!currentField.node.isNull()) {
+                nodes.add(currentField);
+            }
+        }
+        return nodes;
+    }
+
+    private static boolean passesLuhnCheck(String cardNumber) {
+        String digits = cardNumber.replaceAll("[ -]", "");
+        int sum = 0;
+        boolean doubleDigit = false;
+        for (int i = digits.length() - 1; i >= 0; i--) {
+            int digit = digits.charAt(i) - '0';
+            if (doubleDigit) {
+                digit *= 2;
+                if (digit > 9) {
+                    digit -= 9;
+                }
+            }
+            sum += digit;
+            doubleDigit = !doubleDigit;
+        }
+        return sum % 10 == 0;
+    }
+
+    // TextField is a node of a json payload with its location, so that the redacted text can be set in place.
+    private static class TextField {
+        private final JsonNode parent;
+        private final String fieldName;
+        private final int index;
+        private final JsonNode node;
+        private final String text;
+
+        private TextField(JsonNode parent, String fieldName, int index, JsonNode node) {
+            this.parent = parent;
+            this.fieldName = fieldName;
+            this.index = index;
+            this.node = node;
+            this.text = node.isTextual() ? node.asText() : "";
+        }
+
+        private void replace(String value) {
+            if (parent instanceof ObjectNode) {
+                ((ObjectNode) parent).put(fieldName, value);
+            } else if (parent instanceof ArrayNode) {
+                ((ArrayNode) parent).set(index, new TextNode(value));
+            }
+        }
+    }
+
+    /**
+     * Result of applying the guardrails on a payload.
+     */
+    public static class Result {
+        private String violation;
+        private String description;
+        private String redactedPayload;
+        private final List<String> redactedEntities = new ArrayList<>();
+
+        private static Result violation(String violation, String description) {
+            Result result = new Result();
+            result.violation = violation;
+            result.description = description;
+            return result;
+        }
+
+        public boolean isViolated() {
+            return violation != null;
+        }
+
+        public String getViolation() {
+            return violation;
+        }
+
+        public String getDescription() {
+            return description;
+        }
+
+        /**
+         * Returns the payload with the detected PII replaced, or null if nothing was redacted.
+         *
+         * @return redacted payload
+         */
+        public String getRedactedPayload() {
+            return redactedPayload;
+        }
+
+        public List<String> getRedactedEntities() {
+            return redactedEntities;
+        }
+    }
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    private static class GuardrailsConfig {
+        public List<String> denyPatterns;
+        public List<String> denyKeywords;
+        public List<String> piiEntities;
+        public String piiAction;
+        public int maxPromptLength;
+        public String responseSchema;
+        public String responseContentPath;
+    }
+}
@@ -0,0 +1,57 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package org.wso2.apk.enforcer.metrics.jmx.api;
+
+/**
+ * MBean API for AI response cache metrics.
+ */
+public interface AIResponseCacheMetricsMXBean {
+
+    /**
+     * Get the number of AI requests served from the cache.
+     *
+     * @return long
+     */
+    public long getHitCount();
+
+    /**
+     * Get the number of cacheable AI requests not found in the cache.
+     *
+     * @return long
+     */
+    public long getMissCount();
+
+    /**
+     * Get the number of AI requests which bypassed the cache.
+     *
+     * @return long
+     */
+    public long getBypassCount();
+
+    /**
+     * Get the number of AI responses stored in the cache.
+     *
+     * @return long
+     */
+    public long getStoreCount();
+
+    /**
+     * Resets all the metrics to their initial values.
+     */
+    public void resetAIResponseCacheMetrics();
+
+}
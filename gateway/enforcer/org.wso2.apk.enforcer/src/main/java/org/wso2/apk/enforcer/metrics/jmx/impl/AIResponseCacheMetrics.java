@@ -0,0 +1,98 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package org.wso2.apk.enforcer.metrics.jmx.impl;
+
+import org.wso2.apk.enforcer.jmx.MBeanRegistrator;
+import org.wso2.apk.enforcer.metrics.jmx.api.AIResponseCacheMetricsMXBean;
+
+import java.util.concurrent.atomic.AtomicLong;
+
+/**
+ * Singleton MBean for AI response cache metrics.
+ */
+public class AIResponseCacheMetrics implements AIResponseCacheMetricsMXBean {
+
+    private static volatile AIResponseCacheMetrics aiResponseCacheMetricsMBean = null;
+    private final AtomicLong hitCount = new AtomicLong();
+    private final AtomicLong missCount = new AtomicLong();
+    private final AtomicLong bypassCount = new AtomicLong();
+    private final AtomicLong storeCount = new AtomicLong();
+
+    private AIResponseCacheMetrics() {
+        MBeanRegistrator.registerMBean(this);
+    }
+
+    /**
+     * Getter for the Singleton AIResponseCacheMetrics instance.
+     *
+     * @return AIResponseCacheMetrics
+     */
+    public static AIResponseCacheMetrics getInstance() {
+        if (aiResponseCacheMetricsMBean == null) {
+            synchronized (AIResponseCacheMetrics.class) {
+                if (aiResponseCacheMetricsMBean == null) {
+                    aiResponseCacheMetricsMBean = new AIResponseCacheMetrics();
+                }
+            }
+        }
+        return aiResponseCacheMetricsMBean;
+    }
+
+    public void recordHit() {
+        hitCount.incrementAndGet();
+    }
+
+    public void recordMiss() {
+        missCount.incrementAndGet();
+    }
+
+    public void recordBypass() {
+        bypassCount.incrementAndGet();
+    }
+
+    public void recordStore() {
+        storeCount.incrementAndGet();
+    }
+
+    @Override
+    public long getHitCount() {
+        return hitCount.get();
+    }
+
+    @Override
+    public long getMissCount() {
+        return missCount.get();
+    }
+
+    @Override
+    public long getBypassCount() {
+        return bypassCount.get();
+    }
+
+    @Override
+    public long getStoreCount() {
+        return storeCount.get();
+    }
+
+    @Override
+    public void resetAIResponseCacheMetrics() {
+        hitCount.set(0);
+        missCount.set(0);
+        bypassCount.set(0);
+        storeCount.set(0);
+    }
+}
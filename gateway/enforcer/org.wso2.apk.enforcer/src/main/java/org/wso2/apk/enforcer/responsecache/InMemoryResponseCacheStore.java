@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.responsecache;
+
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+
+/**
+ * Keeps the cached responses in the memory of the enforcer, hence each router has its own cache. The entries are
+ * removed when they expire or when the cache of the API is invalidated through the common controller.
+ */
+public class InMemoryResponseCacheStore implements ResponseCacheStore {
+
+    private static final Logger logger = LogManager.getLogger(InMemoryResponseCacheStore.class);
+    private static final InMemoryResponseCacheStore instance = new InMemoryResponseCacheStore();
+    private static final int MAX_ENTRIES = 10000;
+    private final Map<String, Entry> entries = new ConcurrentHashMap<>();
+
+    private InMemoryResponseCacheStore() {
+    }
+
+    public static InMemoryResponseCacheStore getInstance() {
+        return instance;
+    }
+
+    @Override
+    public CachedResponse get(String key) {
+        Entry entry = entries.get(key);
+        if (entry == null) {
+            return null;
+        }
+        if (entry.isExpired(System.currentTimeMillis())) {
+            entries.remove(key, entry);
+            return null;
+        }
+        return entry.response;
+    }
+
+    @Override
+    public boolean put(String key, CachedResponse response, long ttlSeconds) {
+        long now = System.currentTimeMillis();
+        if (entries.size() >= MAX_ENTRIES && !entries.containsKey(key)) {
+            entries.values().removeIf(entry -> entry.isExpired(now));
+            if (entries.size() >= MAX_ENTRIES) {
+                logger.debug("The in-memory response cache is full. Hence the response is not cached.");
+                return false;
+            }
+        }
+        entries.put(key, new Entry(now + ttlSeconds * 1000, response));
+        return true;
+    }
+
+    /**
+     * Removes the cached responses of which the keys start with the given prefix.
+     *
+     * @param keyPrefix prefix of the keys of an organization or an API
+     */
+    public void invalidate(String keyPrefix) {
+        entries.keySet().removeIf(key -> key.startsWith(keyPrefix));
+    }
+
+    private static class Entry {
+        private final long expiresAt;
+        private final CachedResponse response;
+
+        Entry(long expiresAt, CachedResponse response) {
+            this.expiresAt = expiresAt;
+            this.response = response;
+        }
+
+        boolean isExpired(long now) {
+            return expiresAt <= now;
+        }
+    }
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.responsecache;
+
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+import org.wso2.apk.enforcer.server.RevokedTokenRedisClient;
+import redis.clients.jedis.Jedis;
+import redis.clients.jedis.JedisPool;
+
+/**
+ * Keeps the cached responses in the redis server shared with the revoked token store, hence the cache is shared by
+ * all the routers.
+ */
+public class RedisResponseCacheStore implements ResponseCacheStore {
+
+    private static final Logger logger = LogManager.getLogger(RedisResponseCacheStore.class);
+    private static final ObjectMapper mapper = new ObjectMapper();
+    private static volatile RedisResponseCacheStore instance;
+    private final JedisPool jedisPool;
+
+    private RedisResponseCacheStore() throws EnforcerException {
+        this.jedisPool = RevokedTokenRedisClient.getJedisPool();
+    }
+
+    /**
+     * Returns the redis store of the response cache. The connection pool is created on the first use.
+     *
+     * @return the redis store, or null if the connection pool could not be created
+     */
+    public static RedisResponseCacheStore getInstance() {
+        if (instance == null) {
+            synchronized (RedisResponseCacheStore.class) {
+                if (instance == null) {
+                    try {
+                        instance = new RedisResponseCacheStore();
+                    } catch (EnforcerException e) {
+                        logger.error("Error while creating the redis connection pool of the response cache.", e);
+                        return null;
+                    }
+                }
+            }
+        }
+        return instance;
+    }
+
+    @Override
+    public CachedResponse get(String key) {
+        try (Jedis jedis = jedisPool.getResource()) {
+            String cachedResponse = jedis.get(key);
+            return cachedResponse != null ? mapper.readValue(cachedResponse, CachedResponse.class) : null;
+        } catch (Exception e) {
+            logger.warn("Error while reading the response cache. Treating the request as a cache miss.", e);
+            return null;
+        }
+    }
+
+    @Override
+    public boolean put(String key, CachedResponse response, long ttlSeconds) {
+        try (Jedis jedis = jedisPool.getResource()) {
+            jedis.setex(key, ttlSeconds, mapper.writeValueAsString(response));
+            return true;
+        } catch (Exception e) {
+            logger.warn("Error while writing to the response cache.", e);
+            return false;
+        }
+    }
+}
@@ -0,0 +1,285 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.responsecache;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+
+import java.net.URLDecoder;
+import java.nio.charset.StandardCharsets;
+import java.security.MessageDigest;
+import java.security.NoSuchAlgorithmException;
+import java.util.ArrayList;
+import java.util.Base64;
+import java.util.HexFormat;
+import java.util.List;
+import java.util.Locale;
+import java.util.Map;
+import java.util.TreeMap;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.function.Function;
+import java.util.regex.Matcher;
+import java.util.regex.Pattern;
+
+/**
+ * Response cache of an operation of a REST API. The responses are cached against the path, the query parameters,
+ * the headers and optionally the consumer of the request, scoped by the organization and the API. The
+ * configurations are received from the adapter as base64 encoded json in the route metadata.
+ */
+public class ResponseCache {
+
+    private static final Logger logger = LogManager.getLogger(ResponseCache.class);
+    private static final ObjectMapper mapper = new ObjectMapper();
+    private static final Map<String, ResponseCache> caches = new ConcurrentHashMap<>();
+    // This value is shared between the common controller and the enforcer
+    public static final String CACHE_KEY_PREFIX = "wso2:apk:response_cache:";
+    public static final String STATUS_HIT = "HIT";
+    private static final String STORE_REDIS = "Redis";
+    private static final String AUTHORIZATION_HEADER = "authorization";
+    private static final Pattern MAX_AGE_PATTERN =
+            Pattern.compile("(?:^|,)\\s*(s-maxage|max-age)\\s*=\\s*\"?(\\d+)\"?");
+    // headers of the backend response which are served with the cached response
+    private static final List<String> CACHED_HEADERS =
+            List.of("content-type", "content-language", "etag", "last-modified");
+
+    private final String organization;
+    private final String apiUUID;
+    private final long ttlSeconds;
+    private final List<String> varyByHeaders;
+    private final List<String> varyByQueryParams;
+    private final boolean varyByConsumer;
+    private final boolean respectCacheControl;
+    private final String store;
+    private final int maxEntrySize;
+
+    private ResponseCache(CacheConfig config) {
+        organization = config.organization;
+        apiUUID = config.apiUUID;
+        ttlSeconds = config.ttlSeconds;
+        varyByHeaders = config.varyByHeaders != null ? config.varyByHeaders : List.of();
+        varyByQueryParams = config.varyByQueryParams != null ? config.varyByQueryParams : List.of();
+        varyByConsumer = config.varyByConsumer;
+        respectCacheControl = config.respectCacheControl;
+        store = config.store;
+        maxEntrySize = config.maxEntrySize;
+    }
+
+    /**
+     * Returns the cache of the given configurations.
+     *
+     * @param encodedConfig base64 encoded json of the cache configurations
+     * @return the cache, or null if the configurations could not be read
+     */
+    public static ResponseCache fromEncodedConfig(String encodedConfig) {
+        if (encodedConfig == null || encodedConfig.isEmpty()) {
+            return null;
+        }
+        ResponseCache cache = caches.get(encodedConfig);
+        if (cache != null) {
+            return cache;
+        }
+        try {
+            String configJson = new String(Base64.getDecoder().decode(encodedConfig), StandardCharsets.UTF_8);
+            cache = new ResponseCache(mapper.readValue(configJson, CacheConfig.class));
+            caches.put(encodedConfig, cache);
+            return cache;
+        } catch (Exception e) {
+            logger.error("Error while reading the response cache configurations of the route. " + e);
+            return null;
+        }
+    }
+
+    /**
+     * Returns the cache key of the given request. The query parameters are considered in the sorted order, hence
+     * the key does not depend on the order of the parameters sent by the client. All the query parameters are
+     * considered when the cache does not vary by specific parameters. The responses are never shared between
+     * the consumers, hence a request without a known consumer is not cached when the cache varies by the consumer,
+     * and a request with credentials is not cached when it does not.
+     *
+     * @param path         path of the request including the query
+     * @param headerLookup returns the value of a request header
+     * @param consumer     consumer of the request, which is the end user or the application
+     * @return the cache key, or null if the request is not cacheable
+     */
+    public String getCacheKey(String path, Function<String, String> headerLookup, String consumer) {
+        if (path == null) {
+            return null;
+        }
+        boolean consumerUnknown = varyByConsumer ? consumer == null || consumer.isEmpty()
+                : headerLookup.apply(AUTHORIZATION_HEADER) != null;
+        if (consumerUnknown) {
+            logger.debug("The response of the request is not cached as the consumer of the request is not known.");
+            return null;
+        }
+        int queryIndex = path.indexOf('?');
+        Map<String, List<String>> queryParams = new TreeMap<>();
+        if (queryIndex >= 0) {
+            for (String param : path.substring(queryIndex + 1).split("&")) {
+                if (param.isEmpty()) {
+                    continue;
+                }
+                int valueIndex = param.indexOf('=');
+                String name = decode(valueIndex >= 0 ? param.substring(0, valueIndex) : param);
+                String value = valueIndex >= 0 ? decode(param.substring(valueIndex + 1)) : "";
+                if (varyByQueryParams.isEmpty() || varyByQueryParams.contains(name)) {
+                    queryParams.computeIfAbsent(name, key -> new ArrayList<>()).add(value);
+                }
+            }
+            path = path.substring(0, queryIndex);
+        }
+        StringBuilder canonicalRequest = new StringBuilder(path);
+        queryParams.forEach((name, values) -> values.stream().sorted()
+                .forEach(value -> canonicalRequest.append('\n').append("q:").append(name).append('=').append(value)));
+        for (String header : varyByHeaders) {
+            String value = headerLookup.apply(header);
+            canonicalRequest.append('\n').append("h:").append(header).append('=').append(value != null ? value : "");
+        }
+        if (varyByConsumer) {
+            canonicalRequest.append('\n').append("c:").append(consumer != null ? consumer : "");
+        }
+        try {
+            byte[] digest = MessageDigest.getInstance("SHA-256")
+                    .digest(canonicalRequest.toString().getBytes(StandardCharsets.UTF_8));
+            return CACHE_KEY_PREFIX + organization + ":" + apiUUID + ":" + HexFormat.of().formatHex(digest);
+        } catch (NoSuchAlgorithmException e) {
+            logger.error("Error while generating the response cache key. " + e);
+            return null;
+        }
+    }
+
+    /**
+     * Checks whether the cached response can be served for a request with the given Cache-Control header. The
+     * clients can ask for a fresh response with the no-cache and no-store directives.
+     *
+     * @param requestCacheControl Cache-Control header of the request
+     * @return true if the cached response can be served
+     */
+    public boolean isLookupAllowed(String requestCacheControl) {
+        return !respectCacheControl || !hasDirective(requestCacheControl, "no-cache", "no-store");
+    }
+
+    /**
+     * Returns the seconds for which a response should be cached. The TTL of the policy is shortened by the max-age
+     * of the response, and the responses are not cached when the request or the response forbids it.
+     *
+     * @param requestCacheControl  Cache-Control header of the request
+     * @param responseCacheControl Cache-Control header of the response
+     * @return seconds to cache the response, or 0 if the response should not be cached
+     */
+    public long getTtlSeconds(String requestCacheControl, String responseCacheControl) {
+        if (!respectCacheControl) {
+            return ttlSeconds;
+        }
+        if (hasDirective(requestCacheControl, "no-store")
+                || hasDirective(responseCacheControl, "no-store", "no-cache", "private")) {
+            return 0;
+        }
+        if (responseCacheControl != null) {
+            long maxAge = -1;
+            Matcher matcher = MAX_AGE_PATTERN.matcher(responseCacheControl.toLowerCase(Locale.ROOT));
+            while (matcher.find()) {
+                // the shared caches prefer s-maxage over max-age
+                if ("s-maxage".equals(matcher.group(1)) || maxAge < 0) {
+                    maxAge = Long.parseLong(matcher.group(2));
+                }
+            }
+            if (maxAge >= 0) {
+                return Math.min(ttlSeconds, maxAge);
+            }
+        }
+        return ttlSeconds;
+    }
+
+    /**
+     * Returns the headers of the backend response which are served with the cached response.
+     *
+     * @param headerLookup returns the value of a response header
+     * @return cached headers by the lower case header name
+     */
+    public Map<String, String> getCachedHeaders(Function<String, String> headerLookup) {
+        Map<String, String> headers = new TreeMap<>();
+        for (String header : CACHED_HEADERS) {
+            String value = headerLookup.apply(header);
+            if (value != null) {
+                headers.put(header, value);
+            }
+        }
+        return headers;
+    }
+
+    /**
+     * Checks whether the given response fits in a cache entry.
+     *
+     * @param response response body
+     * @return true if the response is not larger than the maximum entry size
+     */
+    public boolean isCacheable(String response) {
+        return response != null && response.getBytes(StandardCharsets.UTF_8).length <= maxEntrySize;
+    }
+
+    /**
+     * Returns the store of the cached responses. The responses are kept in the memory of the enforcer unless the
+     * policy shares them through redis.
+     *
+     * @return the store, or null if the store is not reachable
+     */
+    public ResponseCacheStore getStore() {
+        if (STORE_REDIS.equals(store)) {
+            return RedisResponseCacheStore.getInstance();
+        }
+        return InMemoryResponseCacheStore.getInstance();
+    }
+
+    private static boolean hasDirective(String cacheControl, String... directives) {
+        if (cacheControl == null) {
+            return false;
+        }
+        for (String directive : cacheControl.toLowerCase(Locale.ROOT).split(",")) {
+            String name = directive.split("=", 2)[0].trim();
+            for (String expected : directives) {
+                if (expected.equals(name)) {
+                    return true;
+                }
+            }
+        }
+        return false;
+    }
+
+    private static String decode(String value) {
+        try {
+            return URLDecoder.decode(value, StandardCharsets.UTF_8);
+        } catch (IllegalArgumentException e) {
+            return value;
+        }
+    }
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    private static class CacheConfig {
+        public String organization;
+        public String apiUUID;
+        public long ttlSeconds;
+        public List<String> varyByHeaders;
+        public List<String> varyByQueryParams;
+        public boolean varyByConsumer;
+        public boolean respectCacheControl;
+        public String store;
+        public int maxEntrySize;
+    }
+}
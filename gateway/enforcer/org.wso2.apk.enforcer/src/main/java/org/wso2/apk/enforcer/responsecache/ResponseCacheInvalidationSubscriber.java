@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.responsecache;
+
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+import org.wso2.apk.enforcer.config.ConfigHolder;
+import org.wso2.apk.enforcer.server.RevokedTokenRedisClient;
+import redis.clients.jedis.Jedis;
+import redis.clients.jedis.JedisPool;
+import redis.clients.jedis.JedisPubSub;
+
+/**
+ * Removes the invalidated responses from the in-memory response cache. The common controller publishes the key
+ * prefix of the invalidated organization or API to the redis channel, after removing the responses cached in redis.
+ */
+public class ResponseCacheInvalidationSubscriber implements Runnable {
+
+    private static final Logger logger = LogManager.getLogger(ResponseCacheInvalidationSubscriber.class);
+    private final JedisPool jedisPool;
+    private final String channel;
+
+    private ResponseCacheInvalidationSubscriber(JedisPool jedisPool, String channel) {
+        this.jedisPool = jedisPool;
+        this.channel = channel;
+    }
+
+    /**
+     * Starts listening to the response cache invalidations.
+     */
+    public static void subscribe() {
+        try {
+            String channel = ConfigHolder.getInstance().getEnvVarConfig().getResponseCacheInvalidationRedisChannel();
+            Thread subscriberThread = new Thread(new ResponseCacheInvalidationSubscriber(
+                    RevokedTokenRedisClient.getJedisPool(), channel));
+            subscriberThread.setDaemon(true);
+            subscriberThread.start();
+        } catch (EnforcerException e) {
+            logger.error("Error while creating the redis connection pool of the response cache invalidations.", e);
+        }
+    }
+
+    @Override
+    public void run() {
+        while (true) {
+            try (Jedis jedis = jedisPool.getResource()) {
+                jedis.subscribe(new JedisPubSub() {
+                    @Override
+                    public void onMessage(String channel, String keyPrefix) {
+                        if (keyPrefix == null || !keyPrefix.startsWith(ResponseCache.CACHE_KEY_PREFIX)) {
+                            logger.warn("Ignoring the invalid response cache invalidation: " + keyPrefix);
+                            return;
+                        }
+                        logger.debug("Invalidating the responses cached under: " + keyPrefix);
+                        InMemoryResponseCacheStore.getInstance().invalidate(keyPrefix);
+                    }
+
+                    @Override
+                    public void onSubscribe(String channel, int subscribedChannels) {
+                        logger.info("Subscribed Channel: {} subscribed channels: {}", channel, subscribedChannels);
+                    }
+                }, channel);
+            } catch (Exception e) {
+                logger.error("Error occurred in the response cache invalidation subscription. Trying to connect again. "
+                        + e);
+            }
+            try {
+                Thread.sleep(1000);
+            } catch (InterruptedException e) {
+                Thread.currentThread().interrupt();
+                return;
+            }
+        }
+    }
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.responsecache;
+
+/**
+ * Store of the cached responses of the REST APIs.
+ */
+public interface ResponseCacheStore {
+
+    /**
+     * Returns the cached response of the given key.
+     *
+     * @param key cache key
+     * @return the cached response, or null if it is not cached or the store is not reachable
+     */
+    CachedResponse get(String key);
+
+    /**
+     * Caches the given response under the given key.
+     *
+     * @param key        cache key
+     * @param response   response to cache
+     * @param ttlSeconds seconds until the cached response expires
+     * @return true if the response is cached
+     */
+    boolean put(String key, CachedResponse response, long ttlSeconds);
+}
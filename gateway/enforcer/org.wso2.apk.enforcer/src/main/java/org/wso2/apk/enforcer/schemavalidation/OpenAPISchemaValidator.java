@@ -0,0 +1,584 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.schemavalidation;
+
+import io.swagger.v3.core.util.Json;
+import io.swagger.v3.oas.models.OpenAPI;
+import io.swagger.v3.oas.models.Operation;
+import io.swagger.v3.oas.models.PathItem;
+import io.swagger.v3.oas.models.media.Content;
+import io.swagger.v3.oas.models.media.MediaType;
+import io.swagger.v3.oas.models.parameters.Parameter;
+import io.swagger.v3.oas.models.responses.ApiResponse;
+import io.swagger.v3.parser.OpenAPIV3Parser;
+import io.swagger.v3.parser.core.models.ParseOptions;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.everit.json.schema.Schema;
+import org.everit.json.schema.ValidationException;
+import org.everit.json.schema.loader.SchemaLoader;
+import org.json.JSONArray;
+import org.json.JSONException;
+import org.json.JSONObject;
+import org.json.JSONTokener;
+import org.json.XML;
+import org.wso2.apk.enforcer.commons.model.APIConfig;
+import org.wso2.apk.enforcer.server.swagger.APIDefinitionUtils;
+
+import java.io.IOException;
+import java.net.URLDecoder;
+import java.nio.charset.StandardCharsets;
+import java.util.ArrayList;
+import java.util.Comparator;
+import java.util.HashMap;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+import java.util.concurrent.ConcurrentHashMap;
+import java.util.regex.Matcher;
+import java.util.regex.Pattern;
+
+/**
+ * Validates the requests and the responses of a REST API against the OpenAPI 3 definition of the API. The schemas
+ * of the definition are compiled once per API, and the validator is rebuilt when the API is updated. JSON and XML
+ * bodies are validated. The bodies of the other media types are not inspected.
+ */
+public class OpenAPISchemaValidator {
+
+    private static final Logger logger = LogManager.getLogger(OpenAPISchemaValidator.class);
+    // validators of the APIs keyed by the API UUID
+    private static final Map<String, OpenAPISchemaValidator> validators = new ConcurrentHashMap<>();
+    private static final Pattern PATH_PARAM_PATTERN = Pattern.compile("\\{([^}/]+)}");
+    // formats validated by the json schema validator. The other formats of OpenAPI are not validated.
+    private static final Set<String> SUPPORTED_FORMATS = Set.of("date-time", "email", "hostname", "ipv4", "ipv6",
+            "uri");
+    // set by the router when only a part of the request body is sent to the enforcer
+    private static final String PARTIAL_BODY_HEADER = "x-envoy-auth-partial-body";
+
+    public static final String ERROR_POINTER = "pointer";
+    public static final String ERROR_MESSAGE = "message";
+
+    private final byte[] apiDefinition;
+    private final List<OperationSchemas> operations = new ArrayList<>();
+    private final Map<String, OperationSchemas> operationsByKey = new HashMap<>();
+
+    private OpenAPISchemaValidator(byte[] apiDefinition) {
+        this.apiDefinition = apiDefinition;
+        ParseOptions options = new ParseOptions();
+        options.setResolve(true);
+        options.setResolveFully(true);
+        OpenAPI openAPI = new OpenAPIV3Parser().readContents(readDefinition(apiDefinition), null, options)
+                .getOpenAPI();
+        if (openAPI == null || openAPI.getOpenapi() == null || !openAPI.getOpenapi().startsWith("3.")
+                || openAPI.getPaths() == null) {
+            throw new IllegalArgumentException("The API definition is not a valid OpenAPI 3 definition.");
+        }
+        for (Map.Entry<String, PathItem> path : openAPI.getPaths().entrySet()) {
+            for (Map.Entry<PathItem.HttpMethod, Operation> operation : path.getValue().readOperationsMap()
+                    .entrySet()) {
+                List<Parameter> parameters = new ArrayList<>();
+                if (path.getValue().getParameters() != null) {
+                    parameters.addAll(path.getValue().getParameters());
+                }
+                if (operation.getValue().getParameters() != null) {
+                    parameters.addAll(operation.getValue().getParameters());
+                }
+                OperationSchemas operationSchemas = new OperationSchemas(operation.getKey().name(), path.getKey(),
+                        parameters, operation.getValue());
+                operations.add(operationSchemas);
+                operationsByKey.put(operationSchemas.getKey(), operationSchemas);
+            }
+        }
+        // the paths without parameters are matched before the templated paths, as required by OpenAPI
+        operations.sort(Comparator.comparingInt(operation -> operation.pathParams.size()));
+    }
+
+    /**
+     * Returns the validator of the given API.
+     *
+     * @param apiConfig API
+     * @return the validator, or null if the API does not have a valid OpenAPI 3 definition
+     */
+    public static OpenAPISchemaValidator getValidator(APIConfig apiConfig) {
+        byte[] apiDefinition = apiConfig.getApiDefinition();
+        if (apiDefinition == null || apiDefinition.length == 0) {
+            return null;
+        }
+        OpenAPISchemaValidator validator = validators.get(apiConfig.getUuid());
+        // the definition is replaced when the API is updated
+        if (validator != null && validator.apiDefinition == apiDefinition) {
+            return validator;
+        }
+        try {
+            validator = new OpenAPISchemaValidator(apiDefinition);
+            validators.put(apiConfig.getUuid(), validator);
+            return validator;
+        } catch (Exception e) {
+            logger.error("Error while reading the API definition of the API {} for the schema validation. {}",
+                    apiConfig.getUuid(), e.getMessage());
+            return null;
+        }
+    }
+
+    /**
+     * Returns the validator of the API with the given UUID, which was built while validating a request.
+     *
+     * @param apiUUID UUID of the API
+     * @return the validator, or null if the requests of the API are not validated
+     */
+    public static OpenAPISchemaValidator getValidator(String apiUUID) {
+        return validators.get(apiUUID);
+    }
+
+    /**
+     * Validates a request against the operation of the API definition matching the request. The requests which do
+     * not match an operation of the API definition and the requests whose body was truncated by the router are
+     * invalid, as they cannot be validated.
+     *
+     * @param method          http method of the request
+     * @param path            path of the request relative to the base path of the API, without the query
+     * @param queryParameters query parameters of the request
+     * @param headers         headers of the request with lower case names
+     * @param body            body of the request
+     * @return the result of the validation
+     */
+    public Result validateRequest(String method, String path, Map<String, String> queryParameters,
+                                  Map<String, String> headers, String body) {
+        List<Map<String, String>> errors = new ArrayList<>();
+        for (OperationSchemas operation : operations) {
+            if (!operation.method.equalsIgnoreCase(method)) {
+                continue;
+            }
+            Matcher matcher = operation.pathPattern.matcher(path);
+            if (!matcher.matches()) {
+                continue;
+            }
+            Map<String, String> pathParams = new HashMap<>();
+            for (int i = 0; i < operation.pathParams.size(); i++) {
+                pathParams.put(operation.pathParams.get(i), decode(matcher.group(i + 1)));
+            }
+            for (ParameterSchema parameter : operation.parameters) {
+                String value;
+                switch (parameter.in) {
+                    case "path":
+                        value = pathParams.get(parameter.name);
+                        break;
+                    case "query":
+                        value = queryParameters != null ? queryParameters.get(parameter.name) : null;
+                        break;
+                    case "header":
+                        value = headers.get(parameter.name.toLowerCase());
+                        break;
+                    case "cookie":
+                        value = getCookie(headers.get("cookie"), parameter.name);
+                        break;
+                    default:
+                        continue;
+                }
+                String pointer = "/" + parameter.in + "/" + escapePointer(parameter.name);
+                if (value == null) {
+                    if (parameter.required) {
+                        addError(errors, pointer, "The required parameter is missing.");
+                    }
+                    continue;
+                }
+                validate(parameter.schema, coerce(value, parameter.type, parameter.itemType), pointer, errors);
+            }
+            if ("true".equalsIgnoreCase(headers.get(PARTIAL_BODY_HEADER))) {
+                addError(errors, "/body", "The body is too large to be validated.");
+            } else {
+                validateBody(operation.requestBodySchemas, operation.requestBodyRequired,
+                        headers.get("content-type"), body, errors);
+            }
+            return new Result(operation.getKey(), errors);
+        }
+        logger.debug("No operation of the API definition matches the request {} {}", method, path);
+        addError(errors, "", "The request does not match any operation of the API definition.");
+        return new Result(null, errors);
+    }
+
+    /**
+     * Validates a response against the definition of the given status code of the given operation.
+     *
+     * @param operationKey key of the operation returned when validating the request
+     * @param status       status code of the response
+     * @param contentType  content type of the response
+     * @param body         body of the response
+     * @return the errors found, which is empty if the response conforms to the API definition
+     */
+    public List<Map<String, String>> validateResponse(String operationKey, String status, String contentType,
+                                                      String body) {
+        List<Map<String, String>> errors = new ArrayList<>();
+        OperationSchemas operation = operationsByKey.get(operationKey);
+        if (operation == null || status == null || status.isEmpty()) {
+            return errors;
+        }
+        Map<String, Schema> schemas = operation.responseSchemas.get(status);
+        if (schemas == null) {
+            schemas = operation.responseSchemas.get(status.charAt(0) + "XX");
+        }
+        if (schemas == null) {
+            schemas = operation.responseSchemas.get("default");
+        }
+        // the responses with status codes which are not documented are not validated
+        if (schemas != null) {
+            validateBody(schemas, false, contentType, body, errors);
+        }
+        return errors;
+    }
+
+    private static void validateBody(Map<String, Schema> schemas, boolean required, String contentType, String body,
+                                     List<Map<String, String>> errors) {
+        if (schemas.isEmpty()) {
+            return;
+        }
+        if (body == null || body.isEmpty()) {
+            if (required) {
+                addError(errors, "/body", "The required body is missing.");
+            }
+            return;
+        }
+        String mediaType = matchMediaType(schemas, contentType);
+        if (mediaType == null) {
+            addError(errors, "/body", String.format("The content type %s is not defined in the API definition.",
+                    contentType));
+            return;
+        }
+        Schema schema = schemas.get(mediaType);
+        if (schema == null) {
+            return;
+        }
+        Object value;
+        if (isXml(contentType)) {
+            try {
+                JSONObject xml = XML.toJSONObject(body);
+                // the schema describes the content of the root element
+                value = xml.length() == 1 ? xml.get(xml.keys().next()) : xml;
+            } catch (JSONException e) {
+                addError(errors, "/body", "The body is not a valid XML document.");
+                return;
+            }
+        } else if (isJson(contentType)) {
+            try {
+                JSONTokener tokener = new JSONTokener(body);
+                value = tokener.nextValue();
+                if (tokener.more()) {
+                    addError(errors, "/body", "The body is not a valid JSON document.");
+                    return;
+                }
+            } catch (JSONException e) {
+                addError(errors, "/body", "The body is not a valid JSON document.");
+                return;
+            }
+        } else {
+            return;
+        }
+        validate(schema, value, "/body", errors);
+    }
+
+    private static void validate(Schema schema, Object value, String pointer, List<Map<String, String>> errors) {
+        if (schema == null) {
+            return;
+        }
+        try {
+            schema.validate(value);
+        } catch (ValidationException e) {
+            addErrors(e, pointer, errors);
+        }
+    }
+
+    // addErrors adds the violations causing the given exception, as the exception of a schema with several
+    // violations only summarizes them
+    private static void addErrors(ValidationException exception, String pointer, List<Map<String, String>> errors) {
+        if (exception.getCausingExceptions() == null || exception.getCausingExceptions().isEmpty()) {
+            String violationPointer = exception.getPointerToViolation();
+            if (violationPointer != null && violationPointer.startsWith("#")) {
+                violationPointer = violationPointer.substring(1);
+            }
+            addError(errors, pointer + (violationPointer != null ? violationPointer : ""),
+                    exception.getErrorMessage());
+            return;
+        }
+        for (ValidationException causingException : exception.getCausingExceptions()) {
+            addErrors(causingException, pointer, errors);
+        }
+    }
+
+    private static void addError(List<Map<String, String>> errors, String pointer, String message) {
+        Map<String, String> error = new LinkedHashMap<>();
+        error.put(ERROR_POINTER, pointer);
+        error.put(ERROR_MESSAGE, message);
+        errors.add(error);
+    }
+
+    // coerce converts the value of a parameter to the type of its schema, as the parameters are received as text
+    private static Object coerce(String value, String type, String itemType) {
+        if (type == null) {
+            return value;
+        }
+        try {
+            switch (type) {
+                case "integer":
+                    return Long.parseLong(value);
+                case "number":
+                    return Double.parseDouble(value);
+                case "boolean":
+                    if ("true".equals(value) || "false".equals(value)) {
+                        return Boolean.parseBoolean(value);
+                    }
+                    return value;
+                case "array":
+                    JSONArray items = new JSONArray();
+                    for (String item : value.split(",", -1)) {
+                        items.put(coerce(item, itemType, null));
+                    }
+                    return items;
+                case "object":
+                    return new JSONObject(value);
+                default:
+                    return value;
+            }
+        } catch (NumberFormatException | JSONException e) {
+            // the value is validated as text, so the type mismatch is reported by the schema
+            return value;
+        }
+    }
+
+    private static String matchMediaType(Map<String, Schema> schemas, String contentType) {
+        if (contentType == null) {
+            return schemas.containsKey("*/*") ? "*/*" : null;
+        }
+        String mediaType = contentType.split(";")[0].trim().toLowerCase();
+        if (schemas.containsKey(mediaType)) {
+            return mediaType;
+        }
+        String wildcard = mediaType.split("/")[0] + "/*";
+        if (schemas.containsKey(wildcard)) {
+            return wildcard;
+        }
+        return schemas.containsKey("*/*") ? "*/*" : null;
+    }
+
+    private static boolean isJson(String contentType) {
+        return contentType == null || contentType.toLowerCase().contains("json");
+    }
+
+    private static boolean isXml(String contentType) {
+        return contentType != null && contentType.toLowerCase().contains("xml");
+    }
+
+    private static String getCookie(String cookieHeader, String name) {
+        if (cookieHeader == null) {
+            return null;
+        }
+        for (String cookie : cookieHeader.split(";")) {
+            String[] nameAndValue = cookie.trim().split("=", 2);
+            if (nameAndValue.length == 2 && nameAndValue[0].equals(name)) {
+                return nameAndValue[1];
+            }
+        }
+        return null;
+    }
+
+    private static String decode(String value) {
+        try {
+            return URLDecoder.decode(value.replace("+", "%2B"), StandardCharsets.UTF_8);
+        } catch (IllegalArgumentException e) {
+            return value;
+        }
+    }
+
+    private static String escapePointer(String name) {
+        return name.replace("~", "~0").replace("/", "~1");
+    }
+
+    private static String readDefinition(byte[] apiDefinition) {
+        try {
+            return APIDefinitionUtils.ReadGzip(apiDefinition);
+        } catch (IOException e) {
+            // the definition is not compressed
+            return new String(apiDefinition, StandardCharsets.UTF_8);
+        }
+    }
+
+    // loadSchema compiles the given OpenAPI schema to a json schema
+    private static Schema loadSchema(io.swagger.v3.oas.models.media.Schema<?> schema) {
+        if (schema == null) {
+            return null;
+        }
+        try {
+            JSONObject schemaJson = new JSONObject(Json.mapper().writeValueAsString(schema));
+            normalizeSchema(schemaJson);
+            return SchemaLoader.load(schemaJson);
+        } catch (Exception e) {
+            logger.error("Error while loading a schema of the API definition. {}", e.getMessage());
+            return null;
+        }
+    }
+
+    // normalizeSchema converts the keywords of OpenAPI which differ from json schema
+    private static void normalizeSchema(Object schema) {
+        if (schema instanceof JSONArray) {
+            for (Object item : (JSONArray) schema) {
+                normalizeSchema(item);
+            }
+            return;
+        }
+        if (!(schema instanceof JSONObject)) {
+            return;
+        }
+        JSONObject schemaJson = (JSONObject) schema;
+        if (schemaJson.optBoolean("nullable") && schemaJson.opt("type") instanceof String) {
+            schemaJson.put("type", new JSONArray().put(schemaJson.get("type")).put("null"));
+        }
+        schemaJson.remove("nullable");
+        if (schemaJson.opt("format") instanceof String && !SUPPORTED_FORMATS.contains(schemaJson.getString("format"))) {
+            schemaJson.remove("format");
+        }
+        for (String key : schemaJson.keySet()) {
+            normalizeSchema(schemaJson.get(key));
+        }
+    }
+
+    private static String getType(io.swagger.v3.oas.models.media.Schema<?> schema) {
+        if (schema == null) {
+            return null;
+        }
+        if (schema.getType() != null) {
+            return schema.getType();
+        }
+        return schema.getTypes() != null && schema.getTypes().size() == 1 ? schema.getTypes().iterator().next() : null;
+    }
+
+    private static Map<String, Schema> loadContentSchemas(Content content) {
+        Map<String, Schema> schemas = new HashMap<>();
+        if (content == null) {
+            return schemas;
+        }
+        for (Map.Entry<String, MediaType> mediaType : content.entrySet()) {
+            schemas.put(mediaType.getKey().toLowerCase(),
+                    mediaType.getValue() != null ? loadSchema(mediaType.getValue().getSchema()) : null);
+        }
+        return schemas;
+    }
+
+    /**
+     * The result of the validation of a request.
+     */
+    public static class Result {
+
+        private final String operationKey;
+        private final List<Map<String, String>> errors;
+
+        private Result(String operationKey, List<Map<String, String>> errors) {
+            this.operationKey = operationKey;
+            this.errors = errors;
+        }
+
+        /**
+         * Returns the key of the operation matching the request, which is used to validate the response.
+         *
+         * @return the key, or null if no operation of the API definition matches the request
+         */
+        public String getOperationKey() {
+            return operationKey;
+        }
+
+        public List<Map<String, String>> getErrors() {
+            return errors;
+        }
+
+        public boolean isValid() {
+            return errors.isEmpty();
+        }
+    }
+
+    private static class ParameterSchema {
+
+        private final String name;
+        private final String in;
+        private final boolean required;
+        private final String type;
+        private final String itemType;
+        private final Schema schema;
+
+        private ParameterSchema(Parameter parameter) {
+            this.name = parameter.getName();
+            this.in = parameter.getIn();
+            this.required = Boolean.TRUE.equals(parameter.getRequired());
+            this.type = getType(parameter.getSchema());
+            this.itemType = parameter.getSchema() != null ? getType(parameter.getSchema().getItems()) : null;
+            this.schema = loadSchema(parameter.getSchema());
+        }
+    }
+
+    private static class OperationSchemas {
+
+        private final String method;
+        private final String path;
+        private final Pattern pathPattern;
+        private final List<String> pathParams = new ArrayList<>();
+        private final List<ParameterSchema> parameters = new ArrayList<>();
+        private final boolean requestBodyRequired;
+        private final Map<String, Schema> requestBodySchemas;
+        private final Map<String, Map<String, Schema>> responseSchemas = new HashMap<>();
+
+        private OperationSchemas(String method, String path, List<Parameter> parameters, Operation operation) {
+            this.method = method;
+            this.path = path;
+            StringBuilder pathRegex = new StringBuilder();
+            Matcher matcher = PATH_PARAM_PATTERN.matcher(path);
+            int end = 0;
+            while (matcher.find()) {
+                pathRegex.append(Pattern.quote(path.substring(end, matcher.start()))).append("([^/]+)");
+                pathParams.add(matcher.group(1));
+                end = matcher.end();
+            }
+            pathRegex.append(Pattern.quote(path.substring(end))).append("/?");
+            this.pathPattern = Pattern.compile(pathRegex.toString());
+            // the parameters of the operation override the parameters of the path with the same name
+            Map<String, Parameter> parametersByName = new LinkedHashMap<>();
+            for (Parameter parameter : parameters) {
+                if (parameter != null && parameter.getName() != null && parameter.getIn() != null) {
+                    parametersByName.put(parameter.getIn() + ":" + parameter.getName(), parameter);
+                }
+            }
+            for (Parameter parameter : parametersByName.values()) {
+                this.parameters.add(new ParameterSchema(parameter));
+            }
+            this.requestBodyRequired = operation.getRequestBody() != null
+                    && Boolean.TRUE.equals(operation.getRequestBody().getRequired());
+            this.requestBodySchemas = loadContentSchemas(operation.getRequestBody() != null
+                    ? operation.getRequestBody().getContent() : null);
+            if (operation.getResponses() != null) {
+                for (Map.Entry<String, ApiResponse> response : operation.getResponses().entrySet()) {
+                    // the status code ranges are matched in upper case, as in 2XX
+                    responseSchemas.put("default".equals(response.getKey()) ? response.getKey()
+                                    : response.getKey().toUpperCase(),
+                            loadContentSchemas(response.getValue().getContent()));
+                }
+            }
+        }
+
+        private String getKey() {
+            return method + " " + path;
+        }
+    }
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.schemavalidation;
+
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.apk.enforcer.commons.Filter;
+import org.wso2.apk.enforcer.commons.model.APIConfig;
+import org.wso2.apk.enforcer.commons.model.RequestContext;
+import org.wso2.apk.enforcer.constants.APIConstants;
+import org.wso2.apk.enforcer.constants.GeneralErrorCodeConstants;
+import org.wso2.apk.enforcer.constants.MetadataConstants;
+
+import java.util.regex.Pattern;
+
+/**
+ * Rejects the requests of the operations with the schema validation policy which do not conform to the OpenAPI
+ * definition of the API. The rejected requests are responded with the JSON pointers of the failing values. The
+ * requests which do not match an operation of the API definition and the requests whose body was truncated by the
+ * router are rejected as well, as they cannot be validated.
+ */
+public class SchemaValidationFilter implements Filter {
+
+    private static final Logger logger = LogManager.getLogger(SchemaValidationFilter.class);
+
+    @Override
+    public boolean handleRequest(RequestContext requestContext) {
+        if (!Boolean.TRUE.equals(requestContext.getProperties().get(APIConstants.SCHEMA_VALIDATION_PARAM))) {
+            return true;
+        }
+        APIConfig api = requestContext.getMatchedAPI();
+        OpenAPISchemaValidator validator = OpenAPISchemaValidator.getValidator(api);
+        if (validator == null) {
+            // the adapter does not deploy the APIs without a valid OpenAPI 3 definition, hence the validation fails
+            // closed if the definition could not be read
+            logger.error("The requests of the API {} are rejected as its definition could not be read for the "
+                    + "schema validation", api.getUuid());
+            requestContext.getProperties().put(APIConstants.MessageFormat.STATUS_CODE,
+                    APIConstants.StatusCodes.INTERNAL_SERVER_ERROR.getCode());
+            requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_CODE,
+                    GeneralErrorCodeConstants.SchemaValidation.INVALID_DEFINITION_CODE);
+            requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_MESSAGE,
+                    GeneralErrorCodeConstants.SchemaValidation.INVALID_DEFINITION_MESSAGE);
+            requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_DESCRIPTION,
+                    GeneralErrorCodeConstants.SchemaValidation.INVALID_DEFINITION_DESCRIPTION);
+            return false;
+        }
+        OpenAPISchemaValidator.Result result = validator.validateRequest(requestContext.getRequestMethod(),
+                getRelativePath(api, requestContext.getRequestPath()), requestContext.getQueryParameters(),
+                requestContext.getHeaders(), requestContext.getRequestPayload());
+        if (result.isValid()) {
+            // the operation is passed to the external processor, which validates the response
+            if (result.getOperationKey() != null
+                    && Boolean.TRUE.equals(requestContext.getProperties().get(APIConstants.VALIDATE_RESPONSES_PARAM))) {
+                requestContext.addMetadataToMap(MetadataConstants.SCHEMA_VALIDATION_API, api.getUuid());
+                requestContext.addMetadataToMap(MetadataConstants.SCHEMA_VALIDATION_OPERATION,
+                        result.getOperationKey());
+            }
+            return true;
+        }
+        logger.debug("The request to the operation {} does not conform to the API definition",
+                result.getOperationKey());
+        requestContext.getProperties().put(APIConstants.MessageFormat.STATUS_CODE,
+                APIConstants.StatusCodes.BAD_REQUEST_ERROR.getCode());
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_CODE,
+                GeneralErrorCodeConstants.SchemaValidation.INVALID_REQUEST_CODE);
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_MESSAGE,
+                GeneralErrorCodeConstants.SchemaValidation.INVALID_REQUEST_MESSAGE);
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_DESCRIPTION,
+                GeneralErrorCodeConstants.SchemaValidation.INVALID_REQUEST_DESCRIPTION);
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_DETAILS, result.getErrors());
+        return false;
+    }
+
+    // getRelativePath returns the path of the request relative to the base path of the API, as the paths of the
+    // API definition are relative to it
+    private static String getRelativePath(APIConfig api, String requestPath) {
+        String path = requestPath.split("\\?")[0];
+        String basePath = api.getBasePath() != null ? api.getBasePath().replaceAll("/$", "") : "";
+        if (path.startsWith(basePath)) {
+            return path.substring(basePath.length());
+        }
+        // the requests to the default version of the API do not have the version in the path
+        if (api.getVersion() != null) {
+            String defaultBasePath = basePath.replaceAll("[/.]" + Pattern.quote(api.getVersion()) + "$", "");
+            if (path.startsWith(defaultBasePath)) {
+                return path.substring(defaultBasePath.length());
+            }
+        }
+        return path;
+    }
+}
@@ -29,6 +29,7 @@ import org.wso2.apk.enforcer.commons.logging.LoggingConstants;
 import org.wso2.apk.enforcer.commons.model.APIConfig;
 import org.wso2.apk.enforcer.commons.model.APIKeyAuthenticationConfig;
 import org.wso2.apk.enforcer.commons.model.AuthenticationContext;
+import org.wso2.apk.enforcer.commons.model.HMACAuthenticationConfig;
 import org.wso2.apk.enforcer.commons.model.JWTAuthenticationConfig;
 import org.wso2.apk.enforcer.commons.model.Oauth2AuthenticationConfig;
 import org.wso2.apk.enforcer.commons.model.RequestContext;
@@ -39,6 +40,7 @@ import org.wso2.apk.enforcer.constants.APIConstants;
 import org.wso2.apk.enforcer.constants.InterceptorConstants;
 import org.wso2.apk.enforcer.constants.MetadataConstants;
 import org.wso2.apk.enforcer.grpc.ExternalProcessorService;
+import org.wso2.apk.enforcer.security.hmac.HMACAuthenticator;
 import org.wso2.apk.enforcer.security.jwt.APIKeyAuthenticator;
 import org.wso2.apk.enforcer.security.jwt.JWTAuthenticator;
 import org.wso2.apk.enforcer.security.jwt.Oauth2Authenticator;
@@ -62,6 +64,7 @@ public class AuthFilter implements Filter {
     private boolean isMutualSSLMandatory;
     private boolean isOAuth2Mandatory;
     private boolean isAPIKeyMandatory;
+    private boolean isHMACMandatory;
 
     @Override
     public void init(APIConfig apiConfig, Map<String, String> configProperties) {
@@ -72,6 +75,7 @@ public class AuthFilter implements Filter {
         // TODO: Check security schema and add relevant authenticators.
         boolean isMutualSSLProtected = false;
         boolean isAPIKeyProtected = false;
+        boolean isHMACProtected = false;
         isMutualSSLMandatory = false;
 
         // Set security conditions
@@ -81,6 +85,10 @@ public class AuthFilter implements Filter {
         if (isAPIKeyProtected) {
             isAPIKeyMandatory = securityMaps.getOrDefault("APIKey", false);
         }
+        isHMACProtected = securityMaps.containsKey(APIConstants.API_SECURITY_HMAC);
+        if (isHMACProtected) {
+            isHMACMandatory = securityMaps.getOrDefault(APIConstants.API_SECURITY_HMAC, false);
+        }
 
         if (!Objects.isNull(apiConfig.getMutualSSL())) {
             if (apiConfig.isTransportSecurity()) {
@@ -113,6 +121,11 @@ public class AuthFilter implements Filter {
             authenticators.add(apiKeyAuthenticator);
         }
 
+        if (isHMACProtected) {
+            Authenticator hmacAuthenticator = new HMACAuthenticator();
+            authenticators.add(hmacAuthenticator);
+        }
+
         Authenticator jwtAuthenticator = new JWTAuthenticator(jwtConfigurationDto, isGatewayTokenCacheEnabled);
         authenticators.add(jwtAuthenticator);
 
@@ -187,13 +200,15 @@ public class AuthFilter implements Filter {
                 }
                 // Check if the failed authentication is a mandatory application level security
                 if (!authenticator.getName()
-                        .contains(APIConstants.API_SECURITY_MUTUAL_SSL_NAME) && (isAPIKeyMandatory || isOAuth2Mandatory)) {
+                        .contains(APIConstants.API_SECURITY_MUTUAL_SSL_NAME) && (isAPIKeyMandatory || isOAuth2Mandatory
+                        || isHMACMandatory)) {
                     authenticated = false;
                 }
 
             }
         }
         if (authenticated) {
+            setResponseCacheConsumerMetadata(requestContext);
             return true;
         }
         if (!canAuthenticated) {
@@ -226,7 +241,8 @@ public class AuthFilter implements Filter {
                     // proceed to the next authenticator only if application security is enabled and
                     // is mandatory
                     return new AuthenticationResponse(true, isMutualSSLMandatory,
-                            !isApplicationSecurityDisabled && (isOAuth2Mandatory || isAPIKeyMandatory));
+                            !isApplicationSecurityDisabled && (isOAuth2Mandatory || isAPIKeyMandatory
+                                    || isHMACMandatory));
                 } else {
                     if (isMutualSSLMandatory) {
                         log.debug("Mandatory mTLS authentication was failed for the request: {} , API: {}:{}, " +
@@ -285,6 +301,30 @@ public class AuthFilter implements Filter {
                 Objects.toString(requestContext.getMatchedAPI().getEnvType(), ""));
     }
 
+    // the consumer is passed to the external processor, which varies the cached responses by the consumer. The
+    // end user is the consumer, as the users of an application must not be served the responses of each other.
+    // The application is the consumer of the tokens which are not issued to a user.
+    static String getResponseCacheConsumer(AuthenticationContext authContext) {
+        if (authContext == null) {
+            return "";
+        }
+        String username = authContext.getUsername();
+        if (username != null && !username.isEmpty() && !AuthenticationContext.UNKNOWN_VALUE.equals(username)) {
+            return "user:" + username;
+        }
+        String applicationUUID = authContext.getApplicationUUID();
+        if (applicationUUID != null && !applicationUUID.isEmpty()
+                && !AuthenticationContext.UNKNOWN_VALUE.equals(applicationUUID)) {
+            return "application:" + applicationUUID;
+        }
+        return "";
+    }
+
+    private void setResponseCacheConsumerMetadata(RequestContext requestContext) {
+        requestContext.addMetadataToMap(MetadataConstants.RESPONSE_CACHE_CONSUMER,
+                getResponseCacheConsumer(requestContext.getAuthenticationContext()));
+    }
+
     private void setInterceptorAPIMetadata(RequestContext requestContext) {
         requestContext.addMetadataToMap(InterceptorConstants.APIMetadataFields.API_BASE_PATH,
                 Objects.toString(requestContext.getMatchedAPI().getBasePath(), ""));
@@ -308,12 +348,17 @@ public class AuthFilter implements Filter {
                     .getJwtAuthenticationConfig();
             List<APIKeyAuthenticationConfig> apiKeyAuthenticationConfig = resourcePath.getAuthenticationConfig()
                     .getApiKeyAuthenticationConfigs();
+            HMACAuthenticationConfig hmacAuthenticationConfig = resourcePath.getAuthenticationConfig()
+                    .getHmacAuthenticationConfig();
             if (oauth2AuthenticationConfig != null && !oauth2AuthenticationConfig.isSendTokenToUpstream()) {
                 requestContext.getRemoveHeaders().add(oauth2AuthenticationConfig.getHeader());
             }
             if (jwtAuthenticationConfig != null && !jwtAuthenticationConfig.isSendTokenToUpstream()) {
                 requestContext.getRemoveHeaders().add(jwtAuthenticationConfig.getHeader());
             }
+            if (hmacAuthenticationConfig != null && !hmacAuthenticationConfig.isSendTokenToUpstream()) {
+                requestContext.getRemoveHeaders().add(hmacAuthenticationConfig.getHeader());
+            }
             if (apiKeyAuthenticationConfig != null && !apiKeyAuthenticationConfig.isEmpty()) {
                 requestContext.getQueryParamsToRemove().addAll(apiKeyAuthenticationConfig.stream()
                         .filter(apiKeyAuthenticationConfig1 -> !apiKeyAuthenticationConfig1.isSendTokenToUpstream()
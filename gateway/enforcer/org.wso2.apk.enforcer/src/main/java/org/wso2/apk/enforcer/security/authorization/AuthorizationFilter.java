@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.authorization;
+
+import org.apache.commons.lang3.StringUtils;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.apk.enforcer.commons.Filter;
+import org.wso2.apk.enforcer.commons.exception.APISecurityException;
+import org.wso2.apk.enforcer.commons.model.AuthenticationContext;
+import org.wso2.apk.enforcer.commons.model.AuthorizationRuleConfig;
+import org.wso2.apk.enforcer.commons.model.RequestContext;
+import org.wso2.apk.enforcer.commons.model.ResourceConfig;
+import org.wso2.apk.enforcer.constants.APIConstants;
+import org.wso2.apk.enforcer.constants.APISecurityConstants;
+import org.wso2.apk.enforcer.util.FilterUtils;
+
+import java.util.HashMap;
+import java.util.Map;
+
+/**
+ * Evaluates the authorization rules of the matched resources after the request is authenticated. The request is
+ * allowed only if all the rules allow it, and a rule which fails to evaluate denies the request.
+ */
+public class AuthorizationFilter implements Filter {
+
+    private static final Logger log = LogManager.getLogger(AuthorizationFilter.class);
+
+    @Override
+    public boolean handleRequest(RequestContext requestContext) {
+        if (requestContext.getMatchedResourcePaths() == null) {
+            return true;
+        }
+        Map<String, Object> input = null;
+        for (ResourceConfig resource : requestContext.getMatchedResourcePaths()) {
+            if (resource.getAuthorizationRules() == null) {
+                continue;
+            }
+            for (AuthorizationRuleConfig rule : resource.getAuthorizationRules()) {
+                if (input == null) {
+                    input = buildInput(requestContext);
+                }
+                if (!AuthorizationRuleCompiler.getCompiledRule(rule).allows(input)) {
+                    log.debug("Authorization rule {} denied the request to {}", rule.getName(),
+                            resource.getPath());
+                    String message = StringUtils.isNotEmpty(rule.getMessage()) ? rule.getMessage()
+                            : APISecurityConstants.API_AUTH_FORBIDDEN_MESSAGE;
+                    FilterUtils.setErrorToContext(requestContext, new APISecurityException(
+                            APIConstants.StatusCodes.UNAUTHORIZED.getCode(), APISecurityConstants.API_AUTH_FORBIDDEN,
+                            message));
+                    return false;
+                }
+            }
+        }
+        return true;
+    }
+
+    // buildInput returns the variables available to the authorization rules
+    static Map<String, Object> buildInput(RequestContext requestContext) {
+        Map<String, Object> input = new HashMap<>();
+        AuthenticationContext authenticationContext = requestContext.getAuthenticationContext();
+        Object claims = authenticationContext != null ? authenticationContext.getClaims() : null;
+        input.put("claims", claims != null ? RuleEvaluator.normalize(claims) : new HashMap<>());
+        input.put("headers", requestContext.getHeaders() != null ? new HashMap<>(requestContext.getHeaders())
+                : new HashMap<>());
+        input.put("pathParams", requestContext.getPathParameters() != null
+                ? new HashMap<>(requestContext.getPathParameters()) : new HashMap<>());
+        input.put("path", StringUtils.substringBefore(requestContext.getRequestPath(), "?"));
+        input.put("method", requestContext.getRequestMethod());
+        return input;
+    }
+}
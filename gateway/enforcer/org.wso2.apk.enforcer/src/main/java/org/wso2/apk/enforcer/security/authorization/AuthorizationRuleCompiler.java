@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.authorization;
+
+import com.google.common.cache.CacheBuilder;
+import com.google.common.cache.CacheLoader;
+import com.google.common.cache.LoadingCache;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.apk.enforcer.commons.model.AuthorizationRuleConfig;
+
+/**
+ * Compiles the authorization rules of the resources. The compiled rules are cached by their expressions, as the
+ * same rules are evaluated for every request to a resource.
+ */
+public class AuthorizationRuleCompiler {
+
+    private static final Logger log = LogManager.getLogger(AuthorizationRuleCompiler.class);
+    private static final String LANGUAGE_APK_EXPRESSION = "APKExpression";
+    private static final int MAX_CACHED_RULES = 10000;
+
+    private static final LoadingCache<AuthorizationRuleConfig, CompiledRule> rules = CacheBuilder.newBuilder()
+            .maximumSize(MAX_CACHED_RULES).weakKeys().build(new CacheLoader<AuthorizationRuleConfig, CompiledRule>() {
+                @Override
+                public CompiledRule load(AuthorizationRuleConfig rule) {
+                    try {
+                        return compile(rule.getLanguage(), rule.getExpression());
+                    } catch (AuthorizationRuleException e) {
+                        // the adapter rejects the invalid rules, hence this should not happen. The rule denies all
+                        // the requests so that the resource is not left unprotected.
+                        log.error("Error while compiling the authorization rule {}: {}", rule.getName(),
+                                e.getMessage());
+                        return input -> false;
+                    }
+                }
+            });
+
+    private AuthorizationRuleCompiler() {
+    }
+
+    /**
+     * Returns the compiled rule of the given authorization rule. A rule which can not be compiled denies all the
+     * requests.
+     *
+     * @param rule authorization rule of a resource
+     * @return the compiled rule
+     */
+    public static CompiledRule getCompiledRule(AuthorizationRuleConfig rule) {
+        return rules.getUnchecked(rule);
+    }
+
+    /**
+     * Compiles the expression of an authorization rule written in the given language. An empty language is
+     * treated as the APK expression language.
+     *
+     * @param language   language of the expression, which is APKExpression
+     * @param expression expression of the rule
+     * @return the compiled rule
+     * @throws AuthorizationRuleException if the expression is invalid or the language is not supported
+     */
+    public static CompiledRule compile(String language, String expression) throws AuthorizationRuleException {
+        if (language == null || language.isEmpty() || LANGUAGE_APK_EXPRESSION.equals(language)) {
+            return new ExpressionRule(expression);
+        }
+        throw new AuthorizationRuleException("unsupported language " + language);
+    }
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.authorization;
+
+import java.util.Map;
+
+/**
+ * Authorization rule compiled from its expression, which decides whether a request is allowed.
+ */
+public interface CompiledRule {
+
+    /**
+     * Evaluates the rule for a request. Any error during the evaluation denies the request.
+     *
+     * @param input claims, headers, pathParams, path and method of the request
+     * @return true if the rule allows the request
+     */
+    boolean allows(Map<String, Object> input);
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.authorization;
+
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+
+import java.util.Map;
+
+/**
+ * Authorization rule written in the APK expression language, which allows the request only if its expression
+ * evaluates to true.
+ */
+class ExpressionRule implements CompiledRule {
+
+    private static final Logger log = LogManager.getLogger(ExpressionRule.class);
+
+    private final RuleNode expression;
+    private final RuleEvaluator evaluator = new RuleEvaluator();
+
+    ExpressionRule(String expression) throws AuthorizationRuleException {
+        this.expression = RuleParser.parse(expression);
+        RuleChecker.check(this.expression);
+    }
+
+    @Override
+    public boolean allows(Map<String, Object> input) {
+        try {
+            return Boolean.TRUE.equals(evaluator.evaluate(expression, input));
+        } catch (AuthorizationRuleException e) {
+            log.debug("Error while evaluating the expression: {}", e.getMessage());
+            return false;
+        }
+    }
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.authorization;
+
+import com.google.re2j.Pattern;
+import com.google.re2j.PatternSyntaxException;
+
+import java.util.Arrays;
+import java.util.HashMap;
+import java.util.HashSet;
+import java.util.List;
+import java.util.Map;
+import java.util.Set;
+
+/**
+ * Checks that the expressions of the authorization rules only refer to the supported variables, functions and
+ * methods, with the same rules as the adapter. The adapter rejects the rules which fail these checks, hence the
+ * enforcer compiles exactly the rules the adapter accepts.
+ */
+final class RuleChecker {
+
+    // variables available to the expressions of an authorization rule
+    private static final Set<String> VARIABLES = new HashSet<>(Arrays.asList("claims", "headers", "pathParams",
+            "path", "method"));
+    // supported global functions and their number of arguments
+    private static final Map<String, Integer> FUNCTIONS = new HashMap<>();
+    // supported methods and their number of arguments
+    private static final Map<String, Integer> METHODS = new HashMap<>();
+
+    static {
+        FUNCTIONS.put("has", 1);
+        FUNCTIONS.put("size", 1);
+        FUNCTIONS.put("int", 1);
+        FUNCTIONS.put("string", 1);
+        METHODS.put("startsWith", 1);
+        METHODS.put("endsWith", 1);
+        METHODS.put("contains", 1);
+        METHODS.put("matches", 1);
+        METHODS.put("size", 0);
+        METHODS.put("lowerAscii", 0);
+        METHODS.put("upperAscii", 0);
+        METHODS.put("exists", 2);
+        METHODS.put("all", 2);
+    }
+
+    private RuleChecker() {
+    }
+
+    static void check(RuleNode root) throws AuthorizationRuleException {
+        check(root, new HashSet<>());
+    }
+
+    private static void check(RuleNode node, Set<String> locals) throws AuthorizationRuleException {
+        switch (node.type) {
+            case IDENT:
+                if (!VARIABLES.contains(node.name) && !locals.contains(node.name)) {
+                    throw new AuthorizationRuleException("undeclared reference to \"" + node.name + "\"");
+                }
+                return;
+            case CALL:
+                checkCall(node, locals);
+                return;
+            default:
+                checkNodes(node.children, locals);
+        }
+    }
+
+    private static void checkCall(RuleNode node, Set<String> locals) throws AuthorizationRuleException {
+        List<RuleNode> args = node.children;
+        if (node.target == null) {
+            Integer arity = FUNCTIONS.get(node.name);
+            if (arity == null) {
+                throw new AuthorizationRuleException("unsupported function \"" + node.name + "\"");
+            }
+            if (args.size() != arity) {
+                throw new AuthorizationRuleException("function \"" + node.name + "\" expects " + arity
+                        + " argument(s)");
+            }
+            if ("has".equals(node.name) && args.get(0).type != RuleNode.Type.SELECT) {
+                throw new AuthorizationRuleException("argument of has() must be a field selection");
+            }
+            checkNodes(args, locals);
+            return;
+        }
+        Integer arity = METHODS.get(node.name);
+        if (arity == null) {
+            throw new AuthorizationRuleException("unsupported method \"" + node.name + "\"");
+        }
+        if (args.size() != arity) {
+            throw new AuthorizationRuleException("method \"" + node.name + "\" expects " + arity + " argument(s)");
+        }
+        check(node.target, locals);
+        switch (node.name) {
+            case "exists":
+            case "all":
+                if (args.get(0).type != RuleNode.Type.IDENT) {
+                    throw new AuthorizationRuleException("first argument of \"" + node.name
+                            + "\" must be a variable name");
+                }
+                Set<String> scoped = new HashSet<>(locals);
+                scoped.add(args.get(0).name);
+                check(args.get(1), scoped);
+                return;
+            case "matches":
+                RuleNode pattern = args.get(0);
+                if (pattern.type == RuleNode.Type.LITERAL && pattern.value instanceof String) {
+                    try {
+                        Pattern.compile((String) pattern.value);
+                    } catch (PatternSyntaxException e) {
+                        throw new AuthorizationRuleException("invalid regular expression: " + e.getMessage());
+                    }
+                }
+                break;
+            default:
+                break;
+        }
+        checkNodes(args, locals);
+    }
+
+    private static void checkNodes(List<RuleNode> nodes, Set<String> locals) throws AuthorizationRuleException {
+        for (RuleNode node : nodes) {
+            check(node, locals);
+        }
+    }
+}
@@ -0,0 +1,450 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.authorization;
+
+import com.google.common.cache.CacheBuilder;
+import com.google.common.cache.CacheLoader;
+import com.google.common.cache.LoadingCache;
+import com.google.common.util.concurrent.UncheckedExecutionException;
+import com.google.re2j.Pattern;
+import com.google.re2j.PatternSyntaxException;
+
+import java.util.ArrayList;
+import java.util.Collection;
+import java.util.Date;
+import java.util.HashMap;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.Objects;
+import java.util.concurrent.ExecutionException;
+
+/**
+ * Evaluates the syntax trees of the authorization rules. A reference to a missing field, a type mismatch or an
+ * unsupported function is reported as an {@link AuthorizationRuleException}, which the rules treat as a denial.
+ * The regular expressions are evaluated with RE2, as they are validated by the adapter.
+ */
+class RuleEvaluator {
+
+    private static final LoadingCache<String, Pattern> patterns = CacheBuilder.newBuilder().maximumSize(1000)
+            .build(new CacheLoader<String, Pattern>() {
+                @Override
+                public Pattern load(String regex) {
+                    return Pattern.compile(regex);
+                }
+            });
+
+    /**
+     * Converts the given value into the types handled by the evaluator. Integers are converted to longs, floats to
+     * doubles, dates to epoch seconds and collections to lists, recursively.
+     *
+     * @param value value of a variable, such as the claims of a token
+     * @return the normalized value
+     */
+    static Object normalize(Object value) {
+        if (value instanceof Integer || value instanceof Short || value instanceof Byte) {
+            return ((Number) value).longValue();
+        } else if (value instanceof Float) {
+            return ((Float) value).doubleValue();
+        } else if (value instanceof Date) {
+            return ((Date) value).getTime() / 1000;
+        } else if (value instanceof Collection) {
+            List<Object> list = new ArrayList<>();
+            for (Object element : (Collection<?>) value) {
+                list.add(normalize(element));
+            }
+            return list;
+        } else if (value instanceof Object[]) {
+            List<Object> list = new ArrayList<>();
+            for (Object element : (Object[]) value) {
+                list.add(normalize(element));
+            }
+            return list;
+        } else if (value instanceof Map) {
+            Map<String, Object> map = new HashMap<>();
+            for (Map.Entry<?, ?> entry : ((Map<?, ?>) value).entrySet()) {
+                map.put(String.valueOf(entry.getKey()), normalize(entry.getValue()));
+            }
+            return map;
+        }
+        return value;
+    }
+
+    Object evaluate(RuleNode node, Map<String, Object> variables) throws AuthorizationRuleException {
+        switch (node.type) {
+            case LITERAL:
+                return node.value;
+            case IDENT:
+                if (!variables.containsKey(node.name)) {
+                    throw new AuthorizationRuleException("undeclared reference to " + node.name);
+                }
+                return variables.get(node.name);
+            case SELECT:
+                return select(evaluate(node.children.get(0), variables), node.name);
+            case INDEX:
+                return index(evaluate(node.children.get(0), variables), evaluate(node.children.get(1), variables));
+            case CALL:
+                return node.target == null ? callFunction(node, variables) : callMethod(node, variables);
+            case LIST:
+                List<Object> list = new ArrayList<>();
+                for (RuleNode element : node.children) {
+                    list.add(evaluate(element, variables));
+                }
+                return list;
+            case MAP:
+                Map<String, Object> map = new LinkedHashMap<>();
+                for (int i = 0; i < node.children.size(); i += 2) {
+                    map.put(asString(evaluate(node.children.get(i), variables)),
+                            evaluate(node.children.get(i + 1), variables));
+                }
+                return map;
+            case UNARY:
+                Object operand = evaluate(node.children.get(0), variables);
+                if ("!".equals(node.name)) {
+                    return !asBoolean(operand);
+                }
+                if (operand instanceof Double) {
+                    return -(Double) operand;
+                }
+                return -asLong(operand);
+            case BINARY:
+                return evaluateBinary(node, variables);
+            case CONDITIONAL:
+                return asBoolean(evaluate(node.children.get(0), variables)) ? evaluate(node.children.get(1),
+                        variables) : evaluate(node.children.get(2), variables);
+            default:
+                throw new AuthorizationRuleException("unsupported expression");
+        }
+    }
+
+    private Object evaluateBinary(RuleNode node, Map<String, Object> variables) throws AuthorizationRuleException {
+        String operator = node.name;
+        if ("&&".equals(operator) || "||".equals(operator)) {
+            // as in CEL, an error on one side is absorbed when the other side decides the result
+            boolean shortCircuit = "||".equals(operator);
+            AuthorizationRuleException error = null;
+            for (RuleNode child : node.children) {
+                try {
+                    if (asBoolean(evaluate(child, variables)) == shortCircuit) {
+                        return shortCircuit;
+                    }
+                } catch (AuthorizationRuleException e) {
+                    error = e;
+                }
+            }
+            if (error != null) {
+                throw error;
+            }
+            return !shortCircuit;
+        }
+        Object left = evaluate(node.children.get(0), variables);
+        Object right = evaluate(node.children.get(1), variables);
+        switch (operator) {
+            case "==":
+                return valuesEqual(left, right);
+            case "!=":
+                return !valuesEqual(left, right);
+            case "<":
+                return compare(left, right) < 0;
+            case "<=":
+                return compare(left, right) <= 0;
+            case ">":
+                return compare(left, right) > 0;
+            case ">=":
+                return compare(left, right) >= 0;
+            case "in":
+                if (right instanceof Map) {
+                    return ((Map<?, ?>) right).containsKey(asString(left));
+                }
+                for (Object element : asList(right)) {
+                    if (valuesEqual(left, element)) {
+                        return true;
+                    }
+                }
+                return false;
+            case "+":
+                if (left instanceof String) {
+                    return (String) left + asString(right);
+                } else if (left instanceof List) {
+                    List<Object> list = new ArrayList<>(asList(left));
+                    list.addAll(asList(right));
+                    return list;
+                }
+                return arithmetic(operator, left, right);
+            default:
+                return arithmetic(operator, left, right);
+        }
+    }
+
+    private static Object arithmetic(String operator, Object left, Object right) throws AuthorizationRuleException {
+        if (left instanceof Double || right instanceof Double) {
+            double l = asNumber(left).doubleValue();
+            double r = asNumber(right).doubleValue();
+            switch (operator) {
+                case "+":
+                    return l + r;
+                case "-":
+                    return l - r;
+                case "*":
+                    return l * r;
+                case "/":
+                    return l / r;
+                default:
+                    return l % r;
+            }
+        }
+        long l = asLong(left);
+        long r = asLong(right);
+        switch (operator) {
+            case "+":
+                return l + r;
+            case "-":
+                return l - r;
+            case "*":
+                return l * r;
+            default:
+                if (r == 0) {
+                    throw new AuthorizationRuleException("division by zero");
+                }
+                return "/".equals(operator) ? l / r : l % r;
+        }
+    }
+
+    private Object callFunction(RuleNode node, Map<String, Object> variables) throws AuthorizationRuleException {
+        List<RuleNode> args = node.children;
+        if ("has".equals(node.name) && args.size() == 1 && args.get(0).type == RuleNode.Type.SELECT) {
+            Object operand = evaluate(args.get(0).children.get(0), variables);
+            if (!(operand instanceof Map)) {
+                throw new AuthorizationRuleException("has() can only test the fields of a map");
+            }
+            return ((Map<?, ?>) operand).containsKey(args.get(0).name);
+        }
+        List<Object> values = new ArrayList<>();
+        for (RuleNode arg : args) {
+            values.add(evaluate(arg, variables));
+        }
+        String function = node.name;
+        switch (function) {
+            case "size":
+                checkArguments(function, values, 1);
+                return size(values.get(0));
+            case "int":
+                checkArguments(function, values, 1);
+                if (values.get(0) instanceof String) {
+                    try {
+                        return Long.parseLong((String) values.get(0));
+                    } catch (NumberFormatException e) {
+                        throw new AuthorizationRuleException("can not convert " + values.get(0) + " to int");
+                    }
+                }
+                return asNumber(values.get(0)).longValue();
+            case "string":
+                checkArguments(function, values, 1);
+                return String.valueOf(values.get(0));
+            default:
+                throw new AuthorizationRuleException("unsupported function " + function);
+        }
+    }
+
+    private Object callMethod(RuleNode node, Map<String, Object> variables) throws AuthorizationRuleException {
+        String method = node.name;
+        List<RuleNode> args = node.children;
+        Object target = evaluate(node.target, variables);
+        if ("exists".equals(method) || "all".equals(method)) {
+            if (args.size() != 2 || args.get(0).type != RuleNode.Type.IDENT) {
+                throw new AuthorizationRuleException(method + "() expects a variable and a predicate");
+            }
+            Collection<?> elements = target instanceof Map ? ((Map<?, ?>) target).keySet() : asList(target);
+            boolean exists = "exists".equals(method);
+            Map<String, Object> scope = new HashMap<>(variables);
+            for (Object element : elements) {
+                scope.put(args.get(0).name, element);
+                if (asBoolean(evaluate(args.get(1), scope)) == exists) {
+                    return exists;
+                }
+            }
+            return !exists;
+        }
+        List<Object> values = new ArrayList<>();
+        for (RuleNode arg : args) {
+            values.add(evaluate(arg, variables));
+        }
+        switch (method) {
+            case "startsWith":
+                checkArguments(method, values, 1);
+                return asString(target).startsWith(asString(values.get(0)));
+            case "endsWith":
+                checkArguments(method, values, 1);
+                return asString(target).endsWith(asString(values.get(0)));
+            case "contains":
+                checkArguments(method, values, 1);
+                return asString(target).contains(asString(values.get(0)));
+            case "matches":
+                checkArguments(method, values, 1);
+                return matches(asString(target), asString(values.get(0)));
+            case "size":
+                checkArguments(method, values, 0);
+                return size(target);
+            case "lowerAscii":
+                checkArguments(method, values, 0);
+                return convertAscii(asString(target), false);
+            case "upperAscii":
+                checkArguments(method, values, 0);
+                return convertAscii(asString(target), true);
+            default:
+                throw new AuthorizationRuleException("unsupported method " + method);
+        }
+    }
+
+    private static Object select(Object operand, String field) throws AuthorizationRuleException {
+        if (!(operand instanceof Map)) {
+            throw new AuthorizationRuleException("can not select field " + field + " of a non map value");
+        }
+        Map<?, ?> map = (Map<?, ?>) operand;
+        if (!map.containsKey(field)) {
+            throw new AuthorizationRuleException("no such key: " + field);
+        }
+        return map.get(field);
+    }
+
+    private static Object index(Object operand, Object index) throws AuthorizationRuleException {
+        if (operand instanceof Map) {
+            return select(operand, asString(index));
+        }
+        List<?> list = asList(operand);
+        long position = asLong(index);
+        if (position < 0 || position >= list.size()) {
+            throw new AuthorizationRuleException("index out of bounds: " + position);
+        }
+        return list.get((int) position);
+    }
+
+    private static boolean matches(String value, String regex) throws AuthorizationRuleException {
+        try {
+            return patterns.get(regex).matcher(value).find();
+        } catch (ExecutionException | UncheckedExecutionException e) {
+            if (e.getCause() instanceof PatternSyntaxException) {
+                throw new AuthorizationRuleException("invalid regular expression " + regex);
+            }
+            throw new AuthorizationRuleException("error while matching the regular expression " + regex);
+        }
+    }
+
+    // convertAscii converts the case of the ASCII letters of the value, leaving the other characters as they are
+    private static String convertAscii(String value, boolean upper) {
+        StringBuilder converted = new StringBuilder(value.length());
+        for (int i = 0; i < value.length(); i++) {
+            char c = value.charAt(i);
+            if (upper && c >= 'a' && c <= 'z') {
+                c = (char) (c - 'a' + 'A');
+            } else if (!upper && c >= 'A' && c <= 'Z') {
+                c = (char) (c - 'A' + 'a');
+            }
+            converted.append(c);
+        }
+        return converted.toString();
+    }
+
+    private static long size(Object value) throws AuthorizationRuleException {
+        if (value instanceof String) {
+            return ((String) value).codePointCount(0, ((String) value).length());
+        } else if (value instanceof Map) {
+            return ((Map<?, ?>) value).size();
+        }
+        return asList(value).size();
+    }
+
+    private static boolean valuesEqual(Object left, Object right) {
+        if (left instanceof Number && right instanceof Number) {
+            if (left instanceof Double || right instanceof Double) {
+                return ((Number) left).doubleValue() == ((Number) right).doubleValue();
+            }
+            return ((Number) left).longValue() == ((Number) right).longValue();
+        }
+        if (left instanceof List && right instanceof List) {
+            List<?> leftList = (List<?>) left;
+            List<?> rightList = (List<?>) right;
+            if (leftList.size() != rightList.size()) {
+                return false;
+            }
+            for (int i = 0; i < leftList.size(); i++) {
+                if (!valuesEqual(leftList.get(i), rightList.get(i))) {
+                    return false;
+                }
+            }
+            return true;
+        }
+        return Objects.equals(left, right);
+    }
+
+    private static int compare(Object left, Object right) throws AuthorizationRuleException {
+        if (left instanceof Number && right instanceof Number) {
+            return Double.compare(((Number) left).doubleValue(), ((Number) right).doubleValue());
+        } else if (left instanceof String && right instanceof String) {
+            return ((String) left).compareTo((String) right);
+        }
+        throw new AuthorizationRuleException("can not compare " + typeName(left) + " with " + typeName(right));
+    }
+
+    private static void checkArguments(String function, List<Object> values, int count)
+            throws AuthorizationRuleException {
+        if (values.size() != count) {
+            throw new AuthorizationRuleException(function + " expects " + count + " argument(s)");
+        }
+    }
+
+    static boolean asBoolean(Object value) throws AuthorizationRuleException {
+        if (value instanceof Boolean) {
+            return (Boolean) value;
+        }
+        throw new AuthorizationRuleException("expected a bool but found " + typeName(value));
+    }
+
+    private static String asString(Object value) throws AuthorizationRuleException {
+        if (value instanceof String) {
+            return (String) value;
+        }
+        throw new AuthorizationRuleException("expected a string but found " + typeName(value));
+    }
+
+    private static Number asNumber(Object value) throws AuthorizationRuleException {
+        if (value instanceof Number) {
+            return (Number) value;
+        }
+        throw new AuthorizationRuleException("expected a number but found " + typeName(value));
+    }
+
+    private static long asLong(Object value) throws AuthorizationRuleException {
+        if (value instanceof Long) {
+            return (Long) value;
+        }
+        throw new AuthorizationRuleException("expected an int but found " + typeName(value));
+    }
+
+    private static List<?> asList(Object value) throws AuthorizationRuleException {
+        if (value instanceof List) {
+            return (List<?>) value;
+        }
+        throw new AuthorizationRuleException("expected a list but found " + typeName(value));
+    }
+
+    private static String typeName(Object value) {
+        return value == null ? "null" : value.getClass().getSimpleName();
+    }
+}
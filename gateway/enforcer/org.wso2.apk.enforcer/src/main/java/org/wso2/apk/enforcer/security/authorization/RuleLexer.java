@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.authorization;
+
+import java.util.ArrayList;
+import java.util.List;
+
+/**
+ * Splits the expressions of the authorization rules into tokens, with the same rules as the lexer of the adapter.
+ */
+class RuleLexer {
+
+    // operators are ordered so that the longer operators are matched first
+    private static final String[] OPERATORS = {"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "+", "-", "*",
+            "/", "%", "?", ":", ".", ",", "(", ")", "[", "]", "{", "}"};
+
+    enum Kind {
+        EOF, IDENT, INT, FLOAT, STRING, OPERATOR
+    }
+
+    static class Token {
+        final Kind kind;
+        final String value;
+        final int pos;
+
+        Token(Kind kind, String value, int pos) {
+            this.kind = kind;
+            this.value = value;
+            this.pos = pos;
+        }
+
+        boolean is(Kind kind, String value) {
+            return this.kind == kind && this.value.equals(value);
+        }
+    }
+
+    private RuleLexer() {
+    }
+
+    static List<Token> tokenize(String input) throws AuthorizationRuleException {
+        List<Token> tokens = new ArrayList<>();
+        int pos = 0;
+        while (pos < input.length()) {
+            char c = input.charAt(pos);
+            if (c == ' ' || c == '\t' || c == '\r' || c == '\n') {
+                pos++;
+            } else if (input.startsWith("//", pos)) {
+                while (pos < input.length() && input.charAt(pos) != '\n') {
+                    pos++;
+                }
+            } else if (isLetter(c)) {
+                int start = pos;
+                while (pos < input.length() && (isLetter(input.charAt(pos)) || isDigit(input.charAt(pos)))) {
+                    pos++;
+                }
+                tokens.add(new Token(Kind.IDENT, input.substring(start, pos), start));
+            } else if (isDigit(c)) {
+                int start = pos;
+                Kind kind = Kind.INT;
+                while (pos < input.length() && isDigit(input.charAt(pos))) {
+                    pos++;
+                }
+                if (pos + 1 < input.length() && input.charAt(pos) == '.' && isDigit(input.charAt(pos + 1))) {
+                    kind = Kind.FLOAT;
+                    pos++;
+                    while (pos < input.length() && isDigit(input.charAt(pos))) {
+                        pos++;
+                    }
+                }
+                tokens.add(new Token(kind, input.substring(start, pos), start));
+            } else if (c == '"' || c == '\'') {
+                StringBuilder value = new StringBuilder();
+                int start = pos;
+                pos = readString(input, pos, value);
+                tokens.add(new Token(Kind.STRING, value.toString(), start));
+            } else {
+                String operator = null;
+                for (String candidate : OPERATORS) {
+                    if (input.startsWith(candidate, pos)) {
+                        operator = candidate;
+                        break;
+                    }
+                }
+                if (operator == null) {
+                    throw new AuthorizationRuleException("unexpected character '" + c + "' at position " + pos);
+                }
+                tokens.add(new Token(Kind.OPERATOR, operator, pos));
+                pos += operator.length();
+            }
+        }
+        tokens.add(new Token(Kind.EOF, "", input.length()));
+        return tokens;
+    }
+
+    // readString reads the quoted string starting at start into value and returns the position after the closing
+    // quote
+    private static int readString(String input, int start, StringBuilder value) throws AuthorizationRuleException {
+        char quote = input.charAt(start);
+        for (int pos = start + 1; pos < input.length(); pos++) {
+            char c = input.charAt(pos);
+            if (c == quote) {
+                return pos + 1;
+            } else if (c == '\n') {
+                break;
+            } else if (c == '\\') {
+                pos++;
+                if (pos == input.length()) {
+                    break;
+                }
+                switch (input.charAt(pos)) {
+                    case '\\':
+                    case '"':
+                    case '\'':
+                        value.append(input.charAt(pos));
+                        break;
+                    case 'n':
+                        value.append('\n');
+                        break;
+                    case 'r':
+                        value.append('\r');
+                        break;
+                    case 't':
+                        value.append('\t');
+                        break;
+                    default:
+                        throw new AuthorizationRuleException("invalid escape sequence at position " + (pos - 1));
+                }
+            } else {
+                value.append(c);
+            }
+        }
+        throw new AuthorizationRuleException("unterminated string at position " + start);
+    }
+
+    private static boolean isLetter(char c) {
+        return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z');
+    }
+
+    private static boolean isDigit(char c) {
+        return c >= '0' && c <= '9';
+    }
+}
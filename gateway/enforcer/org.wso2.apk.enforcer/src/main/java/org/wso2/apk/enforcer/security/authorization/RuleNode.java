@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.authorization;
+
+import java.util.Arrays;
+import java.util.Collections;
+import java.util.List;
+
+/**
+ * Node of the syntax tree of an authorization rule expression.
+ */
+final class RuleNode {
+
+    enum Type {
+        LITERAL, IDENT, SELECT, INDEX, CALL, LIST, MAP, UNARY, BINARY, CONDITIONAL
+    }
+
+    final Type type;
+    // name of the variable, field, function or operator of the node
+    final String name;
+    // value of a literal, which is a String, Long, Double, Boolean or null
+    final Object value;
+    // target of a method call, which is null for the global functions
+    final RuleNode target;
+    // operands of the node. The keys and values of a map are interleaved.
+    final List<RuleNode> children;
+
+    private RuleNode(Type type, String name, Object value, RuleNode target, List<RuleNode> children) {
+        this.type = type;
+        this.name = name;
+        this.value = value;
+        this.target = target;
+        this.children = children;
+    }
+
+    static RuleNode literal(Object value) {
+        return new RuleNode(Type.LITERAL, null, value, null, Collections.emptyList());
+    }
+
+    static RuleNode ident(String name) {
+        return new RuleNode(Type.IDENT, name, null, null, Collections.emptyList());
+    }
+
+    static RuleNode select(RuleNode operand, String field) {
+        return new RuleNode(Type.SELECT, field, null, null, Collections.singletonList(operand));
+    }
+
+    static RuleNode index(RuleNode operand, RuleNode index) {
+        return new RuleNode(Type.INDEX, null, null, null, Arrays.asList(operand, index));
+    }
+
+    static RuleNode call(RuleNode target, String function, List<RuleNode> args) {
+        return new RuleNode(Type.CALL, function, null, target, args);
+    }
+
+    static RuleNode list(List<RuleNode> elements) {
+        return new RuleNode(Type.LIST, null, null, null, elements);
+    }
+
+    static RuleNode map(List<RuleNode> entries) {
+        return new RuleNode(Type.MAP, null, null, null, entries);
+    }
+
+    static RuleNode unary(String operator, RuleNode operand) {
+        return new RuleNode(Type.UNARY, operator, null, null, Collections.singletonList(operand));
+    }
+
+    static RuleNode binary(String operator, RuleNode left, RuleNode right) {
+        return new RuleNode(Type.BINARY, operator, null, null, Arrays.asList(left, right));
+    }
+
+    static RuleNode conditional(RuleNode condition, RuleNode ifTrue, RuleNode ifFalse) {
+        return new RuleNode(Type.CONDITIONAL, null, null, null, Arrays.asList(condition, ifTrue, ifFalse));
+    }
+}
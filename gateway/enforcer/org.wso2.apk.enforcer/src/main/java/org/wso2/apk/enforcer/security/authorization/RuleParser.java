@@ -0,0 +1,230 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.authorization;
+
+import java.util.ArrayList;
+import java.util.List;
+
+/**
+ * Recursive descent parser of the authorization rule expressions, with the same grammar as the parser of the
+ * adapter. The relations do not associate, hence a == b == c is rejected.
+ */
+class RuleParser {
+
+    // operators of the binary expressions, ordered by their precedence
+    private static final String[][] BINARY_OPERATORS = {{"||"}, {"&&"}, {"==", "!=", "<=", ">=", "<", ">", "in"},
+            {"+", "-"}, {"*", "/", "%"}};
+    private static final int RELATION_PRECEDENCE = 2;
+
+    private final List<RuleLexer.Token> tokens;
+    private int pos;
+
+    private RuleParser(List<RuleLexer.Token> tokens) {
+        this.tokens = tokens;
+    }
+
+    static RuleNode parse(String expression) throws AuthorizationRuleException {
+        RuleParser parser = new RuleParser(RuleLexer.tokenize(expression));
+        RuleNode root = parser.parseExpression();
+        if (parser.peek().kind != RuleLexer.Kind.EOF) {
+            throw unexpectedToken(parser.peek(), "end of expression");
+        }
+        return root;
+    }
+
+    private RuleNode parseExpression() throws AuthorizationRuleException {
+        RuleNode condition = parseBinary(0);
+        if (!isOperator("?")) {
+            return condition;
+        }
+        next();
+        RuleNode ifTrue = parseExpression();
+        expectOperator(":");
+        RuleNode ifFalse = parseExpression();
+        return RuleNode.conditional(condition, ifTrue, ifFalse);
+    }
+
+    private RuleNode parseBinary(int precedence) throws AuthorizationRuleException {
+        if (precedence == BINARY_OPERATORS.length) {
+            return parseUnary();
+        }
+        RuleNode left = parseBinary(precedence + 1);
+        while (true) {
+            String operator = null;
+            for (String candidate : BINARY_OPERATORS[precedence]) {
+                if (isOperator(candidate) || ("in".equals(candidate) && isKeyword("in"))) {
+                    operator = candidate;
+                }
+            }
+            if (operator == null) {
+                return left;
+            }
+            next();
+            RuleNode right = parseBinary(precedence + 1);
+            left = RuleNode.binary(operator, left, right);
+            if (precedence == RELATION_PRECEDENCE) {
+                return left;
+            }
+        }
+    }
+
+    private RuleNode parseUnary() throws AuthorizationRuleException {
+        if (isOperator("!") || isOperator("-")) {
+            String operator = next().value;
+            return RuleNode.unary(operator, parseUnary());
+        }
+        return parseMember();
+    }
+
+    private RuleNode parseMember() throws AuthorizationRuleException {
+        RuleNode operand = parsePrimary();
+        while (true) {
+            if (isOperator(".")) {
+                next();
+                RuleLexer.Token field = next();
+                if (field.kind != RuleLexer.Kind.IDENT) {
+                    throw unexpectedToken(field, "a field name");
+                }
+                if (isOperator("(")) {
+                    operand = RuleNode.call(operand, field.value, parseArguments("(", ")"));
+                } else {
+                    operand = RuleNode.select(operand, field.value);
+                }
+            } else if (isOperator("[")) {
+                next();
+                RuleNode index = parseExpression();
+                expectOperator("]");
+                operand = RuleNode.index(operand, index);
+            } else {
+                return operand;
+            }
+        }
+    }
+
+    private RuleNode parsePrimary() throws AuthorizationRuleException {
+        RuleLexer.Token token = peek();
+        switch (token.kind) {
+            case INT:
+                next();
+                try {
+                    return RuleNode.literal(Long.parseLong(token.value));
+                } catch (NumberFormatException e) {
+                    throw new AuthorizationRuleException("integer " + token.value + " at position " + token.pos
+                            + " is out of range");
+                }
+            case FLOAT:
+                next();
+                return RuleNode.literal(Double.parseDouble(token.value));
+            case STRING:
+                next();
+                return RuleNode.literal(token.value);
+            case IDENT:
+                next();
+                switch (token.value) {
+                    case "true":
+                    case "false":
+                        return RuleNode.literal(Boolean.parseBoolean(token.value));
+                    case "null":
+                        return RuleNode.literal(null);
+                    default:
+                        if (isOperator("(")) {
+                            return RuleNode.call(null, token.value, parseArguments("(", ")"));
+                        }
+                        return RuleNode.ident(token.value);
+                }
+            default:
+                break;
+        }
+        if (isOperator("(")) {
+            next();
+            RuleNode expression = parseExpression();
+            expectOperator(")");
+            return expression;
+        } else if (isOperator("[")) {
+            return RuleNode.list(parseArguments("[", "]"));
+        } else if (isOperator("{")) {
+            return parseMap();
+        }
+        throw unexpectedToken(token, "an expression");
+    }
+
+    // parseArguments parses a comma separated list of expressions enclosed by open and close
+    private List<RuleNode> parseArguments(String open, String close) throws AuthorizationRuleException {
+        expectOperator(open);
+        List<RuleNode> args = new ArrayList<>();
+        while (!isOperator(close)) {
+            if (!args.isEmpty()) {
+                expectOperator(",");
+            }
+            args.add(parseExpression());
+        }
+        next();
+        return args;
+    }
+
+    private RuleNode parseMap() throws AuthorizationRuleException {
+        expectOperator("{");
+        List<RuleNode> entries = new ArrayList<>();
+        while (!isOperator("}")) {
+            if (!entries.isEmpty()) {
+                expectOperator(",");
+            }
+            entries.add(parseExpression());
+            expectOperator(":");
+            entries.add(parseExpression());
+        }
+        next();
+        return RuleNode.map(entries);
+    }
+
+    private RuleLexer.Token peek() {
+        return tokens.get(pos);
+    }
+
+    private RuleLexer.Token next() {
+        RuleLexer.Token token = peek();
+        if (token.kind != RuleLexer.Kind.EOF) {
+            pos++;
+        }
+        return token;
+    }
+
+    private boolean isOperator(String operator) {
+        return peek().is(RuleLexer.Kind.OPERATOR, operator);
+    }
+
+    private boolean isKeyword(String keyword) {
+        return peek().is(RuleLexer.Kind.IDENT, keyword);
+    }
+
+    private void expectOperator(String operator) throws AuthorizationRuleException {
+        RuleLexer.Token token = next();
+        if (!token.is(RuleLexer.Kind.OPERATOR, operator)) {
+            throw unexpectedToken(token, "\"" + operator + "\"");
+        }
+    }
+
+    private static AuthorizationRuleException unexpectedToken(RuleLexer.Token token, String expected) {
+        if (token.kind == RuleLexer.Kind.EOF) {
+            return new AuthorizationRuleException("unexpected end of expression, expected " + expected);
+        }
+        return new AuthorizationRuleException("unexpected \"" + token.value + "\" at position " + token.pos
+                + ", expected " + expected);
+    }
+}
@@ -0,0 +1,393 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.hmac;
+
+import io.opentelemetry.context.Scope;
+import org.apache.commons.lang3.StringUtils;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.apache.logging.log4j.ThreadContext;
+import org.wso2.apk.enforcer.commons.exception.APISecurityException;
+import org.wso2.apk.enforcer.commons.model.AuthenticationContext;
+import org.wso2.apk.enforcer.commons.model.HMACAuthenticationConfig;
+import org.wso2.apk.enforcer.commons.model.RequestContext;
+import org.wso2.apk.enforcer.commons.model.ResourceConfig;
+import org.wso2.apk.enforcer.constants.APIConstants;
+import org.wso2.apk.enforcer.constants.APISecurityConstants;
+import org.wso2.apk.enforcer.security.Authenticator;
+import org.wso2.apk.enforcer.tracing.TracingConstants;
+import org.wso2.apk.enforcer.tracing.TracingSpan;
+import org.wso2.apk.enforcer.tracing.TracingTracer;
+import org.wso2.apk.enforcer.tracing.Utils;
+
+import java.nio.charset.StandardCharsets;
+import java.security.InvalidKeyException;
+import java.security.MessageDigest;
+import java.security.NoSuchAlgorithmException;
+import java.time.Instant;
+import java.time.ZonedDateTime;
+import java.time.format.DateTimeFormatter;
+import java.time.format.DateTimeParseException;
+import java.util.ArrayList;
+import java.util.Base64;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Locale;
+import java.util.Map;
+import java.util.regex.Matcher;
+import java.util.regex.Pattern;
+import javax.crypto.Mac;
+import javax.crypto.spec.SecretKeySpec;
+
+/**
+ * Implements the authenticator interface to authenticate requests signed with a shared key. The signature is
+ * passed as defined in draft-cavage-http-signatures, e.g.
+ * {@code keyId="client1",algorithm="hmac-sha256",headers="(request-target) date",signature="base64"}.
+ */
+public class HMACAuthenticator implements Authenticator {
+
+    private static final Logger log = LogManager.getLogger(HMACAuthenticator.class);
+    private static final Pattern SIGNATURE_PARAM_PATTERN = Pattern.compile("([a-zA-Z]+)=\"([^\"]*)\"");
+    private static final String SIGNATURE_SCHEME = "Signature ";
+    private static final String KEY_ID = "keyId";
+    private static final String ALGORITHM = "algorithm";
+    private static final String HEADERS = "headers";
+    private static final String SIGNATURE = "signature";
+    private static final String CREATED = "created";
+    private static final String EXPIRES = "expires";
+    private static final String REQUEST_TARGET = "(request-target)";
+    private static final String CREATED_PSEUDO_HEADER = "(created)";
+    private static final String EXPIRES_PSEUDO_HEADER = "(expires)";
+    private static final String DATE_HEADER = "date";
+    private static final String DIGEST_HEADER = "digest";
+    private static final String CONTENT_LENGTH_HEADER = "content-length";
+    private static final String PARTIAL_BODY_HEADER = "x-envoy-auth-partial-body";
+    private static final Map<String, String> MAC_ALGORITHMS = Map.of(
+            "hmac-sha256", "HmacSHA256",
+            "hmac-sha384", "HmacSHA384",
+            "hmac-sha512", "HmacSHA512");
+    private static final Map<String, String> DIGEST_ALGORITHMS = Map.of(
+            "sha-256", "SHA-256",
+            "sha-512", "SHA-512");
+
+    @Override
+    public boolean canAuthenticate(RequestContext requestContext) {
+        HMACAuthenticationConfig hmacAuthenticationConfig = getHMACAuthenticationConfig(requestContext);
+        return hmacAuthenticationConfig != null && StringUtils.isNotBlank(
+                getSignatureHeaderValue(requestContext, hmacAuthenticationConfig));
+    }
+
+    @Override
+    public AuthenticationContext authenticate(RequestContext requestContext) throws APISecurityException {
+        TracingSpan hmacAuthenticatorSpan = null;
+        Scope hmacAuthenticatorSpanScope = null;
+        try {
+            if (Utils.tracingEnabled()) {
+                TracingTracer tracer = Utils.getGlobalTracer();
+                hmacAuthenticatorSpan = Utils.startSpan(TracingConstants.HMAC_AUTHENTICATOR_SPAN, tracer);
+                hmacAuthenticatorSpanScope = hmacAuthenticatorSpan.getSpan().makeCurrent();
+                Utils.setTag(hmacAuthenticatorSpan, APIConstants.LOG_TRACE_ID,
+                        ThreadContext.get(APIConstants.LOG_TRACE_ID));
+            }
+            HMACAuthenticationConfig hmacAuthenticationConfig = getHMACAuthenticationConfig(requestContext);
+            if (hmacAuthenticationConfig == null) {
+                throw new APISecurityException(APIConstants.StatusCodes.UNAUTHENTICATED.getCode(),
+                        APISecurityConstants.API_AUTH_MISSING_CREDENTIALS,
+                        APISecurityConstants.API_AUTH_MISSING_CREDENTIALS_MESSAGE);
+            }
+            String signatureHeaderValue = getSignatureHeaderValue(requestContext, hmacAuthenticationConfig);
+            String keyId = verifySignature(requestContext, hmacAuthenticationConfig, signatureHeaderValue,
+                    Instant.now());
+
+            AuthenticationContext authenticationContext = new AuthenticationContext();
+            authenticationContext.setAuthenticated(true);
+            authenticationContext.setUsername(keyId);
+            authenticationContext.setApiKey(keyId);
+            authenticationContext.setApplicationUUID(keyId);
+            authenticationContext.setTier(APIConstants.UNLIMITED_TIER);
+            authenticationContext.setApplicationTier(APIConstants.UNLIMITED_TIER);
+            authenticationContext.setStopOnQuotaReach(true);
+            authenticationContext.setRawToken(signatureHeaderValue);
+            authenticationContext.setApiUUID(requestContext.getMatchedAPI().getUuid());
+            return authenticationContext;
+        } finally {
+            if (Utils.tracingEnabled() && hmacAuthenticatorSpan != null) {
+                hmacAuthenticatorSpanScope.close();
+                Utils.finishSpan(hmacAuthenticatorSpan);
+            }
+        }
+    }
+
+    /**
+     * Verify the signature of the request.
+     *
+     * @param requestContext           request context
+     * @param hmacAuthenticationConfig HMAC configuration of the matched resource
+     * @param signatureHeaderValue     value of the signature header
+     * @param now                      time the signature is verified at
+     * @return the key ID the request is signed with
+     * @throws APISecurityException if the signature is not valid
+     */
+    static String verifySignature(RequestContext requestContext, HMACAuthenticationConfig hmacAuthenticationConfig,
+                                  String signatureHeaderValue, Instant now) throws APISecurityException {
+        Map<String, String> signatureParams = parseSignatureParams(signatureHeaderValue);
+        String keyId = signatureParams.get(KEY_ID);
+        String signature = signatureParams.get(SIGNATURE);
+        if (StringUtils.isEmpty(keyId) || StringUtils.isEmpty(signature)) {
+            throw invalidSignature("Signature does not contain the keyId and the signature");
+        }
+        String key = hmacAuthenticationConfig.getKeys().get(keyId);
+        if (key == null) {
+            throw invalidSignature("Unknown key ID: " + keyId);
+        }
+
+        // the algorithm is optional in the signature, in which case the first accepted algorithm is used
+        String algorithm = signatureParams.get(ALGORITHM);
+        if (algorithm == null && !hmacAuthenticationConfig.getAlgorithms().isEmpty()) {
+            algorithm = hmacAuthenticationConfig.getAlgorithms().get(0);
+        }
+        algorithm = StringUtils.lowerCase(algorithm);
+        if (!hmacAuthenticationConfig.getAlgorithms().contains(algorithm) || !MAC_ALGORITHMS.containsKey(algorithm)) {
+            throw invalidSignature("Signature algorithm is not accepted: " + algorithm);
+        }
+
+        List<String> signedHeaders = new ArrayList<>();
+        for (String header : StringUtils.split(signatureParams.getOrDefault(HEADERS, DATE_HEADER), ' ')) {
+            signedHeaders.add(header.toLowerCase(Locale.ROOT));
+        }
+        for (String requiredHeader : hmacAuthenticationConfig.getSignedHeaders()) {
+            if (!signedHeaders.contains(requiredHeader.toLowerCase(Locale.ROOT))) {
+                throw invalidSignature("Signature does not cover the header: " + requiredHeader);
+            }
+        }
+        validateSignatureTime(requestContext, signatureParams, signedHeaders, hmacAuthenticationConfig.getClockSkew(),
+                now);
+
+        String signingString = getSigningString(requestContext, signatureParams, signedHeaders);
+        byte[] expectedSignature = sign(MAC_ALGORITHMS.get(algorithm), key, signingString);
+        byte[] providedSignature;
+        try {
+            providedSignature = Base64.getDecoder().decode(signature);
+        } catch (IllegalArgumentException e) {
+            throw invalidSignature("Signature is not base64 encoded");
+        }
+        if (!MessageDigest.isEqual(expectedSignature, providedSignature)) {
+            throw invalidSignature("Signature mismatch for key ID: " + keyId);
+        }
+        if (signedHeaders.contains(DIGEST_HEADER)) {
+            validateDigest(requestContext);
+        }
+        return keyId;
+    }
+
+    /**
+     * The signature is only accepted within the clock skew of the time it is created at, which is taken from the
+     * signed (created) parameter or the signed Date header, so that a captured request can not be replayed later.
+     */
+    private static void validateSignatureTime(RequestContext requestContext, Map<String, String> signatureParams,
+                                              List<String> signedHeaders, int clockSkew, Instant now)
+            throws APISecurityException {
+        Instant createdAt;
+        if (signedHeaders.contains(CREATED_PSEUDO_HEADER)) {
+            createdAt = parseEpochSeconds(signatureParams.get(CREATED));
+        } else if (signedHeaders.contains(DATE_HEADER)) {
+            String date = requestContext.getHeaders().get(DATE_HEADER);
+            if (date == null) {
+                throw invalidSignature("Signed header is missing in the request: " + DATE_HEADER);
+            }
+            try {
+                createdAt = ZonedDateTime.parse(date, DateTimeFormatter.RFC_1123_DATE_TIME).toInstant();
+            } catch (DateTimeParseException e) {
+                throw invalidSignature("Invalid date header: " + date);
+            }
+        } else {
+            throw invalidSignature("Signature does not cover the (created) parameter or the date header");
+        }
+        if (createdAt.isBefore(now.minusSeconds(clockSkew)) || createdAt.isAfter(now.plusSeconds(clockSkew))) {
+            throw invalidSignature("Signature is not created within the accepted clock skew");
+        }
+        if (signedHeaders.contains(EXPIRES_PSEUDO_HEADER)
+                && parseEpochSeconds(signatureParams.get(EXPIRES)).isBefore(now.minusSeconds(clockSkew))) {
+            throw invalidSignature("Signature is expired");
+        }
+    }
+
+    /**
+     * A signed digest header, e.g. {@code SHA-256=base64}, only protects the body if it is the digest of the body
+     * which is sent to the backend. Hence each digest with a supported algorithm is compared with the digest of the
+     * request body, and the request is rejected if the body is not available to compute it.
+     */
+    private static void validateDigest(RequestContext requestContext) throws APISecurityException {
+        String digestHeader = requestContext.getHeaders().get(DIGEST_HEADER);
+        byte[] requestBody = getRequestBody(requestContext);
+        boolean validated = false;
+        for (String digest : StringUtils.split(StringUtils.defaultString(digestHeader), ',')) {
+            String[] digestParts = digest.trim().split("=", 2);
+            String digestAlgorithm = DIGEST_ALGORITHMS.get(digestParts[0].toLowerCase(Locale.ROOT));
+            if (digestAlgorithm == null || digestParts.length != 2) {
+                continue;
+            }
+            byte[] providedDigest;
+            try {
+                providedDigest = Base64.getDecoder().decode(digestParts[1].trim());
+            } catch (IllegalArgumentException e) {
+                throw invalidSignature("Digest is not base64 encoded");
+            }
+            try {
+                byte[] expectedDigest = MessageDigest.getInstance(digestAlgorithm).digest(requestBody);
+                if (!MessageDigest.isEqual(expectedDigest, providedDigest)) {
+                    throw invalidSignature("Digest does not match the request body");
+                }
+            } catch (NoSuchAlgorithmException e) {
+                log.error("Error while computing the request body digest", e);
+                throw new APISecurityException(APIConstants.StatusCodes.UNAUTHENTICATED.getCode(),
+                        APISecurityConstants.API_AUTH_GENERAL_ERROR,
+                        APISecurityConstants.API_AUTH_GENERAL_ERROR_MESSAGE, e);
+            }
+            validated = true;
+        }
+        if (!validated) {
+            throw invalidSignature("Digest header does not contain a supported digest: " + digestHeader);
+        }
+    }
+
+    /**
+     * The router only passes the request body when it is buffered for the API, and a body larger than the buffer is
+     * truncated. A missing body is only taken as empty if the request declares that it has no content.
+     */
+    private static byte[] getRequestBody(RequestContext requestContext) throws APISecurityException {
+        Map<String, String> headers = requestContext.getHeaders();
+        if (Boolean.parseBoolean(headers.get(PARTIAL_BODY_HEADER))) {
+            throw invalidSignature("Request body is not buffered completely to validate the digest");
+        }
+        String contentLength = StringUtils.trim(headers.get(CONTENT_LENGTH_HEADER));
+        byte[] requestBody = requestContext.getRequestBody();
+        if (requestBody == null) {
+            if ("0".equals(contentLength)) {
+                return new byte[0];
+            }
+            throw invalidSignature("Request body is not available to validate the digest");
+        }
+        if (contentLength != null && !contentLength.equals(String.valueOf(requestBody.length))) {
+            throw invalidSignature("Request body is not buffered completely to validate the digest");
+        }
+        return requestBody;
+    }
+
+    private static String getSigningString(RequestContext requestContext, Map<String, String> signatureParams,
+                                           List<String> signedHeaders) throws APISecurityException {
+        StringBuilder signingString = new StringBuilder();
+        for (String header : signedHeaders) {
+            String value;
+            switch (header) {
+                case REQUEST_TARGET:
+                    value = requestContext.getRequestMethod().toLowerCase(Locale.ROOT) + " "
+                            + requestContext.getRequestPath();
+                    break;
+                case CREATED_PSEUDO_HEADER:
+                    value = signatureParams.get(CREATED);
+                    break;
+                case EXPIRES_PSEUDO_HEADER:
+                    value = signatureParams.get(EXPIRES);
+                    break;
+                default:
+                    value = requestContext.getHeaders().get(header);
+            }
+            if (value == null) {
+                throw invalidSignature("Signed header is missing in the request: " + header);
+            }
+            if (signingString.length() > 0) {
+                signingString.append('\n');
+            }
+            signingString.append(header).append(": ").append(value.trim());
+        }
+        return signingString.toString();
+    }
+
+    private static byte[] sign(String macAlgorithm, String key, String signingString) throws APISecurityException {
+        try {
+            Mac mac = Mac.getInstance(macAlgorithm);
+            mac.init(new SecretKeySpec(key.getBytes(StandardCharsets.UTF_8), macAlgorithm));
+            return mac.doFinal(signingString.getBytes(StandardCharsets.UTF_8));
+        } catch (NoSuchAlgorithmException | InvalidKeyException e) {
+            log.error("Error while computing the request signature", e);
+            throw new APISecurityException(APIConstants.StatusCodes.UNAUTHENTICATED.getCode(),
+                    APISecurityConstants.API_AUTH_GENERAL_ERROR,
+                    APISecurityConstants.API_AUTH_GENERAL_ERROR_MESSAGE, e);
+        }
+    }
+
+    private static Map<String, String> parseSignatureParams(String signatureHeaderValue) {
+        String value = signatureHeaderValue.trim();
+        if (StringUtils.startsWithIgnoreCase(value, SIGNATURE_SCHEME)) {
+            value = value.substring(SIGNATURE_SCHEME.length());
+        }
+        Map<String, String> signatureParams = new HashMap<>();
+        Matcher matcher = SIGNATURE_PARAM_PATTERN.matcher(value);
+        while (matcher.find()) {
+            signatureParams.put(matcher.group(1), matcher.group(2));
+        }
+        return signatureParams;
+    }
+
+    private static Instant parseEpochSeconds(String value) throws APISecurityException {
+        try {
+            return Instant.ofEpochSecond(Long.parseLong(StringUtils.defaultString(value)));
+        } catch (NumberFormatException e) {
+            throw invalidSignature("Invalid signature timestamp: " + value);
+        }
+    }
+
+    private static APISecurityException invalidSignature(String reason) {
+        log.debug("HMAC signature validation failed. {}", reason);
+        return new APISecurityException(APIConstants.StatusCodes.UNAUTHENTICATED.getCode(),
+                APISecurityConstants.API_AUTH_INVALID_CREDENTIALS,
+                APISecurityConstants.API_AUTH_INVALID_CREDENTIALS_MESSAGE);
+    }
+
+    private static HMACAuthenticationConfig getHMACAuthenticationConfig(RequestContext requestContext) {
+        for (ResourceConfig resourceConfig : requestContext.getMatchedResourcePaths()) {
+            if (resourceConfig.getAuthenticationConfig() != null
+                    && resourceConfig.getAuthenticationConfig().getHmacAuthenticationConfig() != null) {
+                return resourceConfig.getAuthenticationConfig().getHmacAuthenticationConfig();
+            }
+        }
+        return null;
+    }
+
+    private static String getSignatureHeaderValue(RequestContext requestContext,
+                                                  HMACAuthenticationConfig hmacAuthenticationConfig) {
+        return requestContext.getHeaders().get(StringUtils.lowerCase(hmacAuthenticationConfig.getHeader()));
+    }
+
+    @Override
+    public String getChallengeString() {
+        return "Signature realm=\"APK\"";
+    }
+
+    @Override
+    public String getName() {
+        return APIConstants.API_SECURITY_HMAC;
+    }
+
+    @Override
+    public int getPriority() {
+        return 20;
+    }
+}
@@ -47,6 +47,7 @@ import org.wso2.apk.enforcer.config.dto.APIKeyIssuerDto;
 import org.wso2.apk.enforcer.constants.APIConstants;
 import org.wso2.apk.enforcer.constants.APISecurityConstants;
 import org.wso2.apk.enforcer.constants.GeneralErrorCodeConstants;
+import org.wso2.apk.enforcer.constants.MetadataConstants;
 import org.wso2.apk.enforcer.dto.APIKeyValidationInfoDTO;
 import org.wso2.apk.enforcer.dto.JWTTokenPayloadInfo;
 import org.wso2.apk.enforcer.models.Application;
@@ -313,6 +314,8 @@ public class APIKeyAuthenticator extends APIKeyHandler {
                                 requestContext.addMetadataToMap("ratelimit:usage-policy", subscription.getRatelimitTier());
                                 requestContext.addMetadataToMap("ratelimit:organization", subscription.getOrganization());
                                 requestContext.addMetadataToMap("ratelimit:organization-and-rlpolicy", String.format("%s-%s", subscription.getOrganization(), subscription.getRatelimitTier()));
+                                requestContext.addMetadataToMap(MetadataConstants.TOKEN_BUDGET_APPLICATION, app.getUUID());
+                                requestContext.addMetadataToMap(MetadataConstants.TOKEN_BUDGET_SUBSCRIPTION, subscription.getSubscriptionId());
                             }
                             break;
                         }
@@ -188,8 +188,14 @@ public class JWTAuthenticator implements Authenticator {
                                 endUserToken);
                     }
 
-                    return FilterUtils.generateAuthenticationContext(requestContext, validationInfo.getIdentifier(),
-                            validationInfo, null, endUserToken, jwtToken, true);
+                    AuthenticationContext authenticationContext = FilterUtils.generateAuthenticationContext(
+                            requestContext, validationInfo.getIdentifier(), validationInfo, null, endUserToken,
+                            jwtToken, true);
+                    // the scopes are kept to validate the scopes of the subscribe messages of GraphQL subscriptions
+                    if (!isInternalKey(tokenType)) {
+                        authenticationContext.setScopes(validationInfo.getScopes());
+                    }
+                    return authenticationContext;
                 } else {
                     throw new APISecurityException(APIConstants.StatusCodes.UNAUTHENTICATED.getCode(),
                             validationInfo.getValidationCode(),
@@ -40,6 +40,7 @@ import org.wso2.apk.enforcer.commons.model.ResourceConfig;
 import org.wso2.apk.enforcer.config.ConfigHolder;
 import org.wso2.apk.enforcer.constants.APIConstants;
 import org.wso2.apk.enforcer.constants.APISecurityConstants;
+import org.wso2.apk.enforcer.constants.MetadataConstants;
 import org.wso2.apk.enforcer.dto.APIKeyValidationInfoDTO;
 import org.wso2.apk.enforcer.models.ApplicationKeyMapping;
 import org.wso2.apk.enforcer.models.ApplicationMapping;
@@ -103,11 +104,27 @@ public class Oauth2Authenticator implements Authenticator {
             // StringUtils.startsWithIgnoreCase(null, "bearer")         = false
             // StringUtils.startsWithIgnoreCase("abc", "bearer")        = false
             // StringUtils.startsWithIgnoreCase("Bearer abc", "bearer") = true
-            return StringUtils.startsWithIgnoreCase(authHeaderValue, JWTConstants.BEARER) && authHeaderValue.trim().split("\\s+").length == 2 && authHeaderValue.split("\\.").length == 3;
+            if (!StringUtils.startsWithIgnoreCase(authHeaderValue, JWTConstants.BEARER) ||
+                    authHeaderValue.trim().split("\\s+").length != 2) {
+                return false;
+            }
+            if (authHeaderValue.split("\\.").length == 3) {
+                return true;
+            }
+            // Opaque tokens can only be validated by the token issuers with an introspection endpoint
+            return isIntrospectionEnabled(requestContext);
         }
         return false;
     }
 
+    private boolean isIntrospectionEnabled(RequestContext requestContext) {
+
+        SubscriptionDataStore subscriptionDataStore = SubscriptionDataHolder.getInstance()
+                .getSubscriptionDataStore(requestContext.getMatchedAPI().getOrganizationId());
+        return subscriptionDataStore != null && !subscriptionDataStore
+                .getIntrospectionValidators(requestContext.getMatchedAPI().getEnvironment()).isEmpty();
+    }
+
     @Override
     public AuthenticationContext authenticate(RequestContext requestContext) throws APISecurityException {
 
@@ -139,7 +156,12 @@ public class Oauth2Authenticator implements Authenticator {
             String organization = requestContext.getMatchedAPI().getOrganizationId();
             String environment = requestContext.getMatchedAPI().getEnvironment();
 
-            JWTValidationInfo validationInfo = getJwtValidationInfo(jwtToken, organization, environment);
+            JWTValidationInfo validationInfo;
+            if (jwtToken.split("\\.").length == 3) {
+                validationInfo = getJwtValidationInfo(jwtToken, organization, environment);
+            } else {
+                validationInfo = getIntrospectedTokenValidationInfo(jwtToken, organization, environment);
+            }
             if (RevokedTokenRedisClient.getRevokedTokens().contains(validationInfo.getIdentifier())) {
                 log.info("Revoked JWT token. ", validationInfo.getIdentifier());
                 throw new APISecurityException(APIConstants.StatusCodes.UNAUTHENTICATED.getCode(),
@@ -251,6 +273,8 @@ public class Oauth2Authenticator implements Authenticator {
 
                     AuthenticationContext authenticationContext = FilterUtils.generateAuthenticationContext(requestContext, validationInfo.getIdentifier(),
                             validationInfo, apiKeyValidationInfoDTO, endUserToken, jwtToken, true);
+                    // the scopes are kept to validate the scopes of the subscribe messages of GraphQL subscriptions
+                    authenticationContext.setScopes(validationInfo.getScopes());
 
                     // For subscription rate limiting, it is required to populate dynamic metadata
                     SubscriptionDataStore datastore = SubscriptionDataHolder.getInstance().
@@ -277,6 +301,8 @@ public class Oauth2Authenticator implements Authenticator {
                                         requestContext.addMetadataToMap("ratelimit:usage-policy", subscription.getRatelimitTier());
                                         requestContext.addMetadataToMap("ratelimit:organization", subscription.getOrganization());
                                         requestContext.addMetadataToMap("ratelimit:organization-and-rlpolicy", String.format("%s-%s", subscription.getOrganization(), subscription.getRatelimitTier()));
+                                        requestContext.addMetadataToMap(MetadataConstants.TOKEN_BUDGET_APPLICATION, applicationId);
+                                        requestContext.addMetadataToMap(MetadataConstants.TOKEN_BUDGET_SUBSCRIPTION, subscription.getSubscriptionId());
                                     }
                                     break;
                                 }
@@ -534,7 +560,8 @@ public class Oauth2Authenticator implements Authenticator {
             }
 
             JWTValidationInfo jwtValidationInfo = jwtValidator.validateToken(jwtToken, signedJWTInfo);
-            if (isGatewayTokenCacheEnabled) {
+            // Introspected tokens are cached by the introspector for the cache TTLs of the issuer
+            if (isGatewayTokenCacheEnabled && !jwtValidator.isIntrospectionOnly()) {
                 // Add token to tenant token cache
                 if (jwtValidationInfo.isValid()) {
                     CacheProviderUtil.getOrganizationCache(organization).getGatewayKeyCache().put(signature,
@@ -552,6 +579,54 @@ public class Oauth2Authenticator implements Authenticator {
         }
     }
 
+    /**
+     * Validate an opaque token through the introspection endpoints of the token issuers of the organization. The
+     * token is rejected if no issuer reports it as active. A failure to call an introspection endpoint is only
+     * reported when no other issuer accepts the token.
+     *
+     * @param token        The opaque token
+     * @param organization organization of the API
+     * @param environment  environment of the API
+     * @return validation information of the token
+     * @throws APISecurityException if the token could not be introspected
+     */
+    private JWTValidationInfo getIntrospectedTokenValidationInfo(String token, String organization,
+                                                                 String environment) throws APISecurityException {
+
+        SubscriptionDataStore subscriptionDataStore = SubscriptionDataHolder.getInstance()
+                .getSubscriptionDataStore(organization);
+        if (subscriptionDataStore == null) {
+            throw new APISecurityException(APIConstants.StatusCodes.UNAUTHENTICATED.getCode(),
+                    APISecurityConstants.API_AUTH_INVALID_CREDENTIALS,
+                    APISecurityConstants.API_AUTH_INVALID_CREDENTIALS_MESSAGE);
+        }
+        JWTValidationInfo validationInfo = null;
+        EnforcerException introspectionError = null;
+        for (JWTValidator jwtValidator : subscriptionDataStore.getIntrospectionValidators(environment)) {
+            try {
+                validationInfo = jwtValidator.validateOpaqueToken(token);
+            } catch (EnforcerException e) {
+                introspectionError = e;
+                continue;
+            }
+            if (validationInfo.isValid()) {
+                return validationInfo;
+            }
+        }
+        if (introspectionError != null) {
+            log.error("Token introspection failed", introspectionError);
+            throw new APISecurityException(APIConstants.StatusCodes.UNAUTHENTICATED.getCode(),
+                    APISecurityConstants.API_AUTH_GENERAL_ERROR,
+                    APISecurityConstants.API_AUTH_GENERAL_ERROR_MESSAGE);
+        }
+        if (validationInfo == null) {
+            throw new APISecurityException(APIConstants.StatusCodes.UNAUTHENTICATED.getCode(),
+                    APISecurityConstants.API_AUTH_INVALID_CREDENTIALS,
+                    APISecurityConstants.API_AUTH_INVALID_CREDENTIALS_MESSAGE);
+        }
+        return validationInfo;
+    }
+
     /**
      * Check whether the jwt token is expired or not.
      *
@@ -35,9 +35,11 @@ import org.wso2.apk.enforcer.commons.jwttransformer.JWTTransformer;
 import org.wso2.apk.enforcer.commons.exception.EnforcerException;
 import org.wso2.apk.enforcer.config.ConfigHolder;
 import org.wso2.apk.enforcer.config.dto.ExtendedTokenIssuerDto;
+import org.wso2.apk.enforcer.config.dto.IntrospectionConfigDto;
 import org.wso2.apk.enforcer.constants.APIConstants;
 import org.wso2.apk.enforcer.constants.APISecurityConstants;
 import org.wso2.apk.enforcer.security.jwt.SignedJWTInfo;
+import org.wso2.apk.enforcer.security.oauth.TokenIntrospector;
 import org.wso2.apk.enforcer.util.JWKSClient;
 import org.wso2.apk.enforcer.util.JWTUtils;
 
@@ -61,6 +63,7 @@ public class JWTValidator {
     JWTTransformer jwtTransformer;
     ExtendedTokenIssuerDto tokenIssuer;
     JWKSClient jwksClient;
+    TokenIntrospector tokenIntrospector;
 
     public JWTValidator(ExtendedTokenIssuerDto tokenIssuer) throws EnforcerException {
         jwtTransformer = ConfigHolder.getInstance().getConfig().getJwtTransformer(tokenIssuer.getIssuer());
@@ -74,9 +77,38 @@ public class JWTValidator {
                 jwksClient = new JWKSClient(tokenIssuer.getJwksConfigurationDTO().getUrl(), Collections.emptyList());
             }
         }
+        IntrospectionConfigDto introspectionConfig = tokenIssuer.getIntrospectionConfig();
+        if (introspectionConfig != null && StringUtils.isNotEmpty(introspectionConfig.getUrl())) {
+            tokenIntrospector = new TokenIntrospector(introspectionConfig,
+                    ConfigHolder.getInstance().getConfig().getCacheDto().getMaximumSize());
+        }
+    }
+
+    /**
+     * Returns whether the tokens of the issuer can be validated through token introspection.
+     *
+     * @return true if an introspection endpoint is configured for the issuer
+     */
+    public boolean isIntrospectionEnabled() {
+
+        return tokenIntrospector != null;
+    }
+
+    /**
+     * Returns whether the tokens of the issuer are validated only through token introspection, as no JWKS endpoint
+     * or certificate is configured to validate the signature of the JWTs of the issuer.
+     *
+     * @return true if the signature of the tokens cannot be validated locally
+     */
+    public boolean isIntrospectionOnly() {
+
+        return tokenIntrospector != null && jwksClient == null && tokenIssuer.getCertificate() == null;
     }
 
     public JWTValidationInfo validateToken(String token, SignedJWTInfo signedJWTInfo) throws EnforcerException {
+        if (isIntrospectionOnly()) {
+            return introspectToken(token, JWTUtils.getJWTTokenIdentifier(signedJWTInfo));
+        }
         JWTValidationInfo jwtValidationInfo = new JWTValidationInfo();
         boolean state;
         try {
@@ -109,6 +141,57 @@ public class JWTValidator {
         return jwtValidationInfo;
     }
 
+    /**
+     * Validates an opaque token through the introspection endpoint of the issuer. The claims of the introspection
+     * response are transformed with the claim mappings of the issuer in the same way as the claims of a JWT.
+     *
+     * @param token opaque access token
+     * @return validation information of the token
+     * @throws EnforcerException if the introspection endpoint could not be called
+     */
+    public JWTValidationInfo validateOpaqueToken(String token) throws EnforcerException {
+
+        return introspectToken(token, null);
+    }
+
+    private JWTValidationInfo introspectToken(String token, String identifier) throws EnforcerException {
+        JWTValidationInfo jwtValidationInfo = new JWTValidationInfo();
+        if (tokenIntrospector == null) {
+            throw new EnforcerException("Token introspection is not configured for issuer " + tokenIssuer.getIssuer());
+        }
+        JWTClaimsSet jwtClaimsSet = tokenIntrospector.introspect(token);
+        if (jwtClaimsSet == null) {
+            logger.debug("Token is not active.");
+            jwtValidationInfo.setValid(false);
+            jwtValidationInfo.setValidationCode(APIConstants.KeyValidationStatus.API_AUTH_INVALID_CREDENTIALS);
+            return jwtValidationInfo;
+        }
+        if (jwtClaimsSet.getIssuer() != null && !jwtClaimsSet.getIssuer().equals(tokenIssuer.getIssuer())) {
+            logger.debug("Issuer of the introspected token does not match the token issuer.");
+            jwtValidationInfo.setValid(false);
+            jwtValidationInfo.setValidationCode(APIConstants.KeyValidationStatus.API_AUTH_INVALID_CREDENTIALS);
+            return jwtValidationInfo;
+        }
+        try {
+            jwtValidationInfo.setConsumerKey(jwtTransformer.getTransformedConsumerKey(jwtClaimsSet));
+            jwtValidationInfo.setScopes(jwtTransformer.getTransformedScopes(jwtClaimsSet));
+            JWTClaimsSet transformedJWTClaimSet = jwtTransformer.transform(jwtClaimsSet);
+            createJWTValidationInfoFromJWT(jwtValidationInfo, transformedJWTClaimSet);
+        } catch (ParseException | JWTGeneratorException e) {
+            throw new EnforcerException("Error while reading the introspection response", e);
+        }
+        jwtValidationInfo.setKeyManager(tokenIssuer.getName());
+        if (identifier == null) {
+            identifier = StringUtils.isNotEmpty(jwtClaimsSet.getJWTID()) ? jwtClaimsSet.getJWTID() :
+                    TokenIntrospector.getTokenIdentifier(token);
+        }
+        jwtValidationInfo.setIdentifier(identifier);
+        jwtValidationInfo.setJwtClaimsSet(jwtClaimsSet);
+        jwtValidationInfo.setToken(token);
+        jwtValidationInfo.setAudience(jwtClaimsSet.getAudience());
+        return jwtValidationInfo;
+    }
+
     protected boolean validateSignature(SignedJWT signedJWT) throws EnforcerException {
         try {
             String keyID = signedJWT.getHeader().getKeyID();
@@ -162,7 +245,9 @@ public class JWTValidator {
             throws ParseException {
         jwtValidationInfo.setValid(true);
         jwtValidationInfo.setClaims(jwtClaimsSet.getClaims());
-        jwtValidationInfo.setExpiryTime(jwtClaimsSet.getExpirationTime().getTime());
+        if (jwtClaimsSet.getExpirationTime() != null) {
+            jwtValidationInfo.setExpiryTime(jwtClaimsSet.getExpirationTime().getTime());
+        }
         jwtValidationInfo.setUser(jwtClaimsSet.getSubject());
         if (jwtClaimsSet.getClaim(APIConstants.JwtTokenConstants.SCOPE) != null) {
             if (jwtClaimsSet.getClaim(APIConstants.JwtTokenConstants.SCOPE) instanceof List) {
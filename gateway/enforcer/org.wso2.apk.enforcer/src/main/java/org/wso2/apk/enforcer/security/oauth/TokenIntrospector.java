@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.oauth;
+
+import com.google.common.cache.Cache;
+import com.google.common.cache.CacheBuilder;
+import com.nimbusds.jwt.JWTClaimsSet;
+import org.apache.commons.io.IOUtils;
+import org.apache.commons.lang3.StringUtils;
+import org.apache.http.HttpHeaders;
+import org.apache.http.NameValuePair;
+import org.apache.http.client.HttpClient;
+import org.apache.http.client.entity.UrlEncodedFormEntity;
+import org.apache.http.client.methods.CloseableHttpResponse;
+import org.apache.http.client.methods.HttpPost;
+import org.apache.http.message.BasicNameValuePair;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+import org.wso2.apk.enforcer.config.ConfigHolder;
+import org.wso2.apk.enforcer.config.dto.IntrospectionConfigDto;
+import org.wso2.apk.enforcer.util.FilterUtils;
+import org.wso2.apk.enforcer.util.TLSUtils;
+
+import java.io.IOException;
+import java.io.InputStream;
+import java.nio.charset.StandardCharsets;
+import java.security.KeyStore;
+import java.security.MessageDigest;
+import java.security.NoSuchAlgorithmException;
+import java.text.ParseException;
+import java.util.ArrayList;
+import java.util.Base64;
+import java.util.Date;
+import java.util.HexFormat;
+import java.util.List;
+import java.util.concurrent.TimeUnit;
+
+/**
+ * Validates opaque access tokens against the RFC 7662 introspection endpoint of a token issuer. Active tokens are
+ * cached for the positive cache TTL, but never beyond their expiry, and inactive tokens are cached for the negative
+ * cache TTL. Failures to reach the endpoint are not cached.
+ */
+public class TokenIntrospector {
+
+    private static final Logger log = LogManager.getLogger(TokenIntrospector.class);
+    private static final String ACTIVE_CLAIM = "active";
+
+    private final HttpClient httpClient;
+    private final IntrospectionConfigDto introspectionConfig;
+    private final Cache<String, JWTClaimsSet> activeTokenCache;
+    private final Cache<String, Boolean> inactiveTokenCache;
+
+    public TokenIntrospector(IntrospectionConfigDto introspectionConfig, int maxCacheSize) throws EnforcerException {
+
+        this(introspectionConfig, createHttpClient(introspectionConfig), maxCacheSize);
+    }
+
+    TokenIntrospector(IntrospectionConfigDto introspectionConfig, HttpClient httpClient, int maxCacheSize) {
+
+        this.introspectionConfig = introspectionConfig;
+        this.httpClient = httpClient;
+        this.activeTokenCache = CacheBuilder.newBuilder().maximumSize(maxCacheSize)
+                .expireAfterWrite(introspectionConfig.getPositiveCacheTTL(), TimeUnit.SECONDS).build();
+        this.inactiveTokenCache = CacheBuilder.newBuilder().maximumSize(maxCacheSize)
+                .expireAfterWrite(introspectionConfig.getNegativeCacheTTL(), TimeUnit.SECONDS).build();
+    }
+
+    private static HttpClient createHttpClient(IntrospectionConfigDto introspectionConfig) throws EnforcerException {
+
+        KeyStore trustStore = ConfigHolder.getInstance().getTrustStore();
+        if (introspectionConfig.getCertificate() != null) {
+            trustStore = TLSUtils.getDefaultCertTrustStore();
+            TLSUtils.convertAndAddCertificatesToTrustStore(trustStore, List.of(introspectionConfig.getCertificate()));
+        }
+        return FilterUtils.getHttpClient(null, trustStore, null);
+    }
+
+    /**
+     * Introspects the given token.
+     *
+     * @param token opaque access token
+     * @return claims of the token if it is active, null if it is not
+     * @throws EnforcerException if the introspection endpoint could not be called or returned an invalid response
+     */
+    public JWTClaimsSet introspect(String token) throws EnforcerException {
+
+        String cacheKey = getTokenIdentifier(token);
+        if (inactiveTokenCache.getIfPresent(cacheKey) != null) {
+            return null;
+        }
+        JWTClaimsSet claimsSet = activeTokenCache.getIfPresent(cacheKey);
+        if (claimsSet != null) {
+            if (!isExpired(claimsSet)) {
+                return claimsSet;
+            }
+            activeTokenCache.invalidate(cacheKey);
+            inactiveTokenCache.put(cacheKey, true);
+            return null;
+        }
+        claimsSet = callIntrospectionEndpoint(token);
+        if (claimsSet == null || !Boolean.TRUE.equals(claimsSet.getClaim(ACTIVE_CLAIM)) || isExpired(claimsSet)) {
+            if (introspectionConfig.getNegativeCacheTTL() > 0) {
+                inactiveTokenCache.put(cacheKey, true);
+            }
+            return null;
+        }
+        if (introspectionConfig.getPositiveCacheTTL() > 0) {
+            activeTokenCache.put(cacheKey, claimsSet);
+        }
+        return claimsSet;
+    }
+
+    private JWTClaimsSet callIntrospectionEndpoint(String token) throws EnforcerException {
+
+        HttpPost introspectRequest = new HttpPost(introspectionConfig.getUrl());
+        List<NameValuePair> params = new ArrayList<>();
+        params.add(new BasicNameValuePair("token", token));
+        params.add(new BasicNameValuePair("token_type_hint", "access_token"));
+        introspectRequest.setEntity(new UrlEncodedFormEntity(params, StandardCharsets.UTF_8));
+        introspectRequest.setHeader(HttpHeaders.ACCEPT, "application/json");
+        if (StringUtils.isNotEmpty(introspectionConfig.getClientId())) {
+            String credentials = introspectionConfig.getClientId() + ":" + introspectionConfig.getClientSecret();
+            introspectRequest.setHeader(HttpHeaders.AUTHORIZATION, "Basic " +
+                    Base64.getEncoder().encodeToString(credentials.getBytes(StandardCharsets.UTF_8)));
+        }
+        try (CloseableHttpResponse response = (CloseableHttpResponse) httpClient.execute(introspectRequest)) {
+            int statusCode = response.getStatusLine().getStatusCode();
+            if (statusCode != 200) {
+                throw new EnforcerException("Introspection endpoint " + introspectionConfig.getUrl() +
+                        " responded with status code " + statusCode);
+            }
+            try (InputStream content = response.getEntity().getContent()) {
+                return JWTClaimsSet.parse(IOUtils.toString(content, StandardCharsets.UTF_8));
+            }
+        } catch (IOException | ParseException e) {
+            throw new EnforcerException("Error occurred when calling the introspection endpoint " +
+                    introspectionConfig.getUrl(), e);
+        }
+    }
+
+    private static boolean isExpired(JWTClaimsSet claimsSet) {
+
+        Date expiry = claimsSet.getExpirationTime();
+        return expiry != null && !expiry.after(new Date());
+    }
+
+    /**
+     * Returns an identifier of the token which can be kept in caches and logs instead of the token.
+     *
+     * @param token opaque access token
+     * @return SHA-256 digest of the token
+     */
+    public static String getTokenIdentifier(String token) {
+
+        try {
+            byte[] digest = MessageDigest.getInstance("SHA-256").digest(token.getBytes(StandardCharsets.UTF_8));
+            return HexFormat.of().formatHex(digest);
+        } catch (NoSuchAlgorithmException e) {
+            log.error("Error while generating the token identifier. " + e);
+            throw new IllegalStateException(e);
+        }
+    }
+}
@@ -42,6 +42,7 @@ import org.wso2.apk.enforcer.grpc.interceptors.AccessLogInterceptor;
 import org.wso2.apk.enforcer.grpc.interceptors.OpenTelemetryInterceptor;
 import org.wso2.apk.enforcer.jmx.JMXAgent;
 import org.wso2.apk.enforcer.metrics.MetricsManager;
+import org.wso2.apk.enforcer.responsecache.ResponseCacheInvalidationSubscriber;
 import org.wso2.apk.enforcer.security.jwt.validator.RevokedJWTDataHolder;
 import org.wso2.apk.enforcer.subscription.SubscriptionDataStoreUtil;
 import org.wso2.apk.enforcer.tracing.TracerFactory;
@@ -119,6 +120,8 @@ public class AuthServer {
             }
             // Start receiving revoked tokens from redis cache
             RevokedTokenRedisClient.retrieveAndSubscribe();
+            // Start receiving the invalidations of the in-memory response cache
+            ResponseCacheInvalidationSubscriber.subscribe();
 
             // Start the server
             server.start();
@@ -41,6 +41,7 @@ import org.wso2.apk.enforcer.graphql.GraphQLPayloadUtils;
 import org.wso2.apk.enforcer.util.FilterUtils;
 
 import java.util.ArrayList;
+import java.util.List;
 import java.util.Map;
 
 /**
@@ -104,27 +105,51 @@ public class HttpRequestHandler implements RequestHandler<CheckRequest, Response
         }
         address = FilterUtils.getClientIp(headers, address);
         String requestPayload = null;
+        byte[] requestBody = null;
         if (!request.getAttributes().getRequest().getHttp().getRawBody().isEmpty()) {
             ByteString byteString = request.getAttributes().getRequest().getHttp().getRawBody();
+            requestBody = byteString.toByteArray();
             if (byteString.isValidUtf8()) {
                 requestPayload = byteString.toStringUtf8();
             }
         }
         if (!request.getAttributes().getRequest().getHttp().getBody().isEmpty()) {
             ByteString byteString = request.getAttributes().getRequest().getHttp().getBodyBytes();
+            requestBody = byteString.toByteArray();
             if (byteString.isValidUtf8()) {
                 requestPayload = byteString.toStringUtf8();
             }
         }
+        Map<String, String> contextExtensions = request.getAttributes().getContextExtensionsMap();
         ResourceConfig resourceConfig = null;
         ArrayList<ResourceConfig> resourceConfigs = null;
+        List<String> batchQueries = null;
         boolean isGraphQLAPI = api.getAPIConfig().getApiType().equals(APIConstants.ApiType.GRAPHQL);
+        // GraphQL subscriptions are served over a websocket connection upgraded from a GET request.
+        boolean isGraphQLSubscription = isGraphQLAPI && HttpConstants.GET.equals(method) &&
+                APIConstants.WEBSOCKET.equalsIgnoreCase(headers.get(APIConstants.UPGRADE_HEADER));
         EnforcerConfig enforcerConfig = ConfigHolder.getInstance().getConfig();
         if (isGraphQLAPI && !HttpConstants.OPTIONS.equals(method)) {
             // need to decode the payload if request is graphql and a non option call.
             try {
-                requestPayload = GraphQLPayloadUtils.getGQLRequestPayload(requestPayload, headers);
-                resourceConfigs = GraphQLPayloadUtils.buildGQLRequestContext(api, requestPayload, routeName);
+                if (isGraphQLSubscription) {
+                    requestPayload = GraphQLPayloadUtils.getGQLSubscriptionPayload(requestPath);
+                    resourceConfigs = GraphQLPayloadUtils.buildGQLSubscriptionRequestContext(api, requestPayload,
+                            routeName);
+                } else {
+                    // batched requests are only accepted when the GraphQL protection policy limits their size
+                    if (contextExtensions.containsKey(GraphQLConstants.GRAPHQL_MAX_BATCH_SIZE)) {
+                        batchQueries = GraphQLPayloadUtils.getGQLBatchRequestPayloads(requestPayload, headers);
+                    }
+                    if (batchQueries != null) {
+                        resourceConfigs = GraphQLPayloadUtils.buildGQLBatchRequestContext(api, batchQueries,
+                                routeName);
+                    } else {
+                        requestPayload = GraphQLPayloadUtils.getGQLRequestPayload(requestPayload, headers);
+                        resourceConfigs = GraphQLPayloadUtils.buildGQLRequestContext(api, requestPayload,
+                                routeName);
+                    }
+                }
             } catch (EnforcerException exception) {
                 logger.error("Error while processing the graphql api request for {}",
                         api.getAPIConfig().getName(),
@@ -157,11 +182,30 @@ public class HttpRequestHandler implements RequestHandler<CheckRequest, Response
                 resourceConfigs.add(resourceConfig);
             }
         }
-        return new RequestContext.Builder(requestPath).matchedResourceConfigs(resourceConfigs).requestMethod(method)
-                .certificate(certificate).matchedAPI(api.getAPIConfig()).headers(headers).requestID(requestID)
-                .address(address).clusterHeader(cluster)
+        RequestContext requestContext = new RequestContext.Builder(requestPath).matchedResourceConfigs(resourceConfigs)
+                .requestMethod(method).certificate(certificate).matchedAPI(api.getAPIConfig()).headers(headers)
+                .requestID(requestID).address(address).clusterHeader(cluster)
                 .requestTimeStamp(requestTimeInMillis).pathTemplate(pathTemplate).requestPayload(requestPayload)
-                .build();
+                .requestBody(requestBody).build();
+        if (isGraphQLSubscription) {
+            // the scopes and the rate limits of the subscription fields are applied on the subscribe messages of the
+            // connection by the external processor
+            requestContext.getProperties().put(GraphQLConstants.GRAPHQL_SUBSCRIPTION, true);
+        }
+        if (isGraphQLAPI) {
+            // the limits of the GraphQL protection policy are applied by the query analysis filter
+            GraphQLPayloadUtils.addGQLProtectionProperties(requestContext, contextExtensions);
+            if (batchQueries != null) {
+                requestContext.getProperties().put(GraphQLConstants.GRAPHQL_BATCH_QUERIES, batchQueries);
+            }
+        }
+        // the operations with the schema validation policy are validated by the schema validation filter
+        if (Boolean.parseBoolean(contextExtensions.get(APIConstants.SCHEMA_VALIDATION_PARAM))) {
+            requestContext.getProperties().put(APIConstants.SCHEMA_VALIDATION_PARAM, true);
+            requestContext.getProperties().put(APIConstants.VALIDATE_RESPONSES_PARAM,
+                    Boolean.parseBoolean(contextExtensions.get(APIConstants.VALIDATE_RESPONSES_PARAM)));
+        }
+        return requestContext;
     }
 
     /**
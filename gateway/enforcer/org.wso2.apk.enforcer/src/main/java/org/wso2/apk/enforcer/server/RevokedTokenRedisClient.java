@@ -29,6 +29,7 @@ import redis.clients.jedis.HostAndPort;
 import redis.clients.jedis.Jedis;
 import redis.clients.jedis.JedisClientConfig;
 import redis.clients.jedis.JedisPool;
+import redis.clients.jedis.JedisPoolConfig;
 import redis.clients.jedis.JedisPubSub;
 import redis.clients.jedis.params.ScanParams;
 import redis.clients.jedis.resps.ScanResult;
@@ -41,6 +42,7 @@ import java.security.GeneralSecurityException;
 import java.security.Key;
 import java.security.KeyStore;
 import java.security.cert.Certificate;
+import java.time.Duration;
 import java.util.Collections;
 import java.util.HashSet;
 import java.util.Map;
@@ -69,17 +71,42 @@ public class RevokedTokenRedisClient {
     private static final Logger logger = LogManager.getLogger(RevokedTokenRedisClient.class);
     private static final String TOKEN_EXPIRY_DIVIDER = "_##_";
     private static final String REVOKED_TOKEN_REDIS_KEY_PATTERN = "wso2:apk:revoked_token:*";
+    // the pool is shared by the subscribers, which hold a connection each, and the request flows
+    private static final int REDIS_POOL_MAX_CONNECTIONS = 32;
+    private static final Duration REDIS_POOL_MAX_WAIT = Duration.ofSeconds(1);
+    private static volatile JedisPool jedisPoolStatic;
     private RevokedTokenRedisClient(Set<String> revokedTokens, Queue<Map.Entry<Long, String>> expiryQueue) throws EnforcerException {
         this.revokedTokens = revokedTokens;
         this.expiryQueue = expiryQueue;
+        this.redisRevokedTokensChannel = ConfigHolder.getInstance().getEnvVarConfig().getRevokedTokensRedisChannel();
+        this.revokedTokenCleanupInterval = ConfigHolder.getInstance().getEnvVarConfig().getRevokedTokenCleanupInterval();
+        this.jedisPool = getJedisPool();
+    }
 
+    /**
+     * Returns the pool of connections to the redis server configured for the enforcer. The pool is created on the
+     * first use and is shared by all the redis clients of the enforcer.
+     *
+     * @return redis connection pool
+     * @throws EnforcerException if the SSL socket factory could not be created
+     */
+    public static JedisPool getJedisPool() throws EnforcerException {
+        if (jedisPoolStatic == null) {
+            synchronized (RevokedTokenRedisClient.class) {
+                if (jedisPoolStatic == null) {
+                    jedisPoolStatic = createJedisPool();
+                }
+            }
+        }
+        return jedisPoolStatic;
+    }
+
+    private static JedisPool createJedisPool() throws EnforcerException {
         String userName = ConfigHolder.getInstance().getEnvVarConfig().getRedisUsername();
         String password = ConfigHolder.getInstance().getEnvVarConfig().getRedisPassword();
         String host = ConfigHolder.getInstance().getEnvVarConfig().getRedisHost();
         int port = ConfigHolder.getInstance().getEnvVarConfig().getRedisPort();
         boolean isSSLEnabled = ConfigHolder.getInstance().getEnvVarConfig().isRedisTlsEnabled();
-        this.redisRevokedTokensChannel = ConfigHolder.getInstance().getEnvVarConfig().getRevokedTokensRedisChannel();
-        this.revokedTokenCleanupInterval = ConfigHolder.getInstance().getEnvVarConfig().getRevokedTokenCleanupInterval();
         String caCert = ConfigHolder.getInstance().getEnvVarConfig().getRedisCaCertFile();
         DefaultJedisClientConfig.Builder builder = DefaultJedisClientConfig.builder()
                 .password(password);
@@ -95,8 +122,10 @@ public class RevokedTokenRedisClient {
         JedisClientConfig config = builder.build();
 
         HostAndPort hostAndPort = new HostAndPort(host, port);
-        this.jedisPool = new JedisPool(hostAndPort, config);
-
+        JedisPoolConfig poolConfig = new JedisPoolConfig();
+        poolConfig.setMaxTotal(REDIS_POOL_MAX_CONNECTIONS);
+        poolConfig.setMaxWait(REDIS_POOL_MAX_WAIT);
+        return new JedisPool(poolConfig, hostAndPort, config);
     }
 
     public static void retrieveAndSubscribe() throws EnforcerException {
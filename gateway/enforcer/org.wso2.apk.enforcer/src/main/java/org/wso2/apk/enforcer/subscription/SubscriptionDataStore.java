@@ -101,6 +101,15 @@ public interface SubscriptionDataStore {
      */
     JWTValidator getJWTValidatorByIssuer(String issuer, String environment);
 
+    /**
+     * Returns the JWTValidators of the issuers which validate tokens through token introspection. Opaque tokens do
+     * not carry an issuer, so these are tried in turn.
+     *
+     * @param environment environment of the API
+     * @return JWTValidators with an introspection endpoint
+     */
+    List<JWTValidator> getIntrospectionValidators(String environment);
+
     void addApplication(org.wso2.apk.enforcer.discovery.subscription.Application application);
 
     void addSubscription(org.wso2.apk.enforcer.discovery.subscription.Subscription subscription);
@@ -25,8 +25,10 @@ import org.wso2.apk.enforcer.commons.dto.ClaimMappingDto;
 import org.wso2.apk.enforcer.commons.dto.JWKSConfigurationDTO;
 import org.wso2.apk.enforcer.commons.exception.EnforcerException;
 import org.wso2.apk.enforcer.config.dto.ExtendedTokenIssuerDto;
+import org.wso2.apk.enforcer.config.dto.IntrospectionConfigDto;
 import org.wso2.apk.enforcer.constants.Constants;
 import org.wso2.apk.enforcer.discovery.subscription.Certificate;
+import org.wso2.apk.enforcer.discovery.subscription.Introspection;
 import org.wso2.apk.enforcer.discovery.subscription.JWTIssuer;
 import org.wso2.apk.enforcer.models.Application;
 import org.wso2.apk.enforcer.models.ApplicationKeyMapping;
@@ -39,6 +41,7 @@ import org.wso2.apk.enforcer.util.TLSUtils;
 import java.io.IOException;
 import java.security.cert.CertificateException;
 import java.util.ArrayList;
+import java.util.Collections;
 import java.util.HashMap;
 import java.util.HashSet;
 import java.util.Iterator;
@@ -63,6 +66,7 @@ public class SubscriptionDataStoreImpl implements SubscriptionDataStore {
     private Map<String, Application> applicationMap = new ConcurrentHashMap<>();
     private Map<String, Subscription> subscriptionMap = new ConcurrentHashMap<>();
     private Map<String, JWTValidator> jwtValidatorMap = new ConcurrentHashMap<>();
+    private Map<String, List<JWTValidator>> introspectionValidatorMap = new ConcurrentHashMap<>();
 
     SubscriptionDataStoreImpl() {
 
@@ -214,6 +218,7 @@ public class SubscriptionDataStoreImpl implements SubscriptionDataStore {
     public void addJWTIssuers(List<JWTIssuer> jwtIssuers) {
 
         Map<String, JWTValidator> jwtValidatorMap = new ConcurrentHashMap<>();
+        Map<String, List<JWTValidator>> introspectionValidatorMap = new ConcurrentHashMap<>();
         for (JWTIssuer jwtIssuer : jwtIssuers) {
             try {
                 ExtendedTokenIssuerDto tokenIssuerDto = new ExtendedTokenIssuerDto(jwtIssuer.getIssuer());
@@ -237,6 +242,20 @@ public class SubscriptionDataStoreImpl implements SubscriptionDataStore {
                             TLSUtils.getCertificateFromContent(certificate.getCertificate());
                     tokenIssuerDto.setCertificate(signingCertificate);
                 }
+                if (jwtIssuer.hasIntrospection() && StringUtils.isNotEmpty(jwtIssuer.getIntrospection().getUrl())) {
+                    Introspection introspection = jwtIssuer.getIntrospection();
+                    IntrospectionConfigDto introspectionConfigDto = new IntrospectionConfigDto();
+                    introspectionConfigDto.setUrl(introspection.getUrl());
+                    if (StringUtils.isNotEmpty(introspection.getTls())) {
+                        introspectionConfigDto.setCertificate(
+                                TLSUtils.getCertificateFromContent(introspection.getTls()));
+                    }
+                    introspectionConfigDto.setClientId(introspection.getClientId());
+                    introspectionConfigDto.setClientSecret(introspection.getClientSecret());
+                    introspectionConfigDto.setPositiveCacheTTL(introspection.getPositiveCacheTTL());
+                    introspectionConfigDto.setNegativeCacheTTL(introspection.getNegativeCacheTTL());
+                    tokenIssuerDto.setIntrospectionConfig(introspectionConfigDto);
+                }
                 Map<String, String> claimMappingMap = jwtIssuer.getClaimMappingMap();
                 Map<String, ClaimMappingDto> claimMappingDtos = new HashMap<>();
                 claimMappingMap.forEach((remoteClaim, localClaim) -> claimMappingDtos.put(remoteClaim,
@@ -247,12 +266,17 @@ public class SubscriptionDataStoreImpl implements SubscriptionDataStore {
                 for (String environment : environments) {
                     String mapKey = getMapKey(environment, jwtIssuer.getIssuer());
                     jwtValidatorMap.put(mapKey, jwtValidator);
+                    if (jwtValidator.isIntrospectionEnabled()) {
+                        introspectionValidatorMap.computeIfAbsent(environment, k -> new ArrayList<>())
+                                .add(jwtValidator);
+                    }
                 }
                 this.jwtValidatorMap = jwtValidatorMap;
             } catch (EnforcerException | CertificateException | IOException e) {
                 log.error("Error occurred while configuring JWT Validator for issuer " + jwtIssuer.getIssuer(), e);
             }
         }
+        this.introspectionValidatorMap = introspectionValidatorMap;
     }
 
     @Override
@@ -267,6 +291,18 @@ public class SubscriptionDataStoreImpl implements SubscriptionDataStore {
         return jwtValidatorMap.get(mapKey);
     }
 
+    @Override
+    public List<JWTValidator> getIntrospectionValidators(String environment) {
+
+        List<JWTValidator> introspectionValidators = new ArrayList<>(introspectionValidatorMap
+                .getOrDefault(Constants.DEFAULT_ALL_ENVIRONMENTS_TOKEN_ISSUER, Collections.emptyList()));
+        if (!Constants.DEFAULT_ALL_ENVIRONMENTS_TOKEN_ISSUER.equals(environment)) {
+            introspectionValidators.addAll(introspectionValidatorMap.getOrDefault(environment,
+                    Collections.emptyList()));
+        }
+        return introspectionValidators;
+    }
+
     @Override
     public void addApplication(org.wso2.apk.enforcer.discovery.subscription.Application application) {
 
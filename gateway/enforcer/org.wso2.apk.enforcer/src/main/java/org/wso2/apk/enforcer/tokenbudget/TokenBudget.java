@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.tokenbudget;
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+
+import java.util.ArrayList;
+import java.util.List;
+
+/**
+ * Token budget of a subscription based AI ratelimit policy. The budgets are received from the common controller
+ * through redis.
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+public class TokenBudget {
+
+    private static final String SCOPE_SUBSCRIPTION = "Subscription";
+
+    private String period;
+    private String scope;
+    private long totalTokenCount;
+    private List<Integer> alertThresholds = new ArrayList<>();
+
+    public String getPeriod() {
+        return period;
+    }
+
+    public void setPeriod(String period) {
+        this.period = period;
+    }
+
+    public String getScope() {
+        return scope;
+    }
+
+    public void setScope(String scope) {
+        this.scope = scope;
+    }
+
+    public long getTotalTokenCount() {
+        return totalTokenCount;
+    }
+
+    public void setTotalTokenCount(long totalTokenCount) {
+        this.totalTokenCount = totalTokenCount;
+    }
+
+    public List<Integer> getAlertThresholds() {
+        return alertThresholds;
+    }
+
+    public void setAlertThresholds(List<Integer> alertThresholds) {
+        this.alertThresholds = alertThresholds == null ? new ArrayList<>() : alertThresholds;
+    }
+
+    /**
+     * Checks whether each subscription of an application has its own budget.
+     *
+     * @return true if the budget applies to each subscription, false if it is shared by the application
+     */
+    public boolean isSubscriptionScoped() {
+        return SCOPE_SUBSCRIPTION.equals(scope);
+    }
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.tokenbudget;
+
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.apk.enforcer.commons.Filter;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+import org.wso2.apk.enforcer.commons.model.RequestContext;
+import org.wso2.apk.enforcer.config.ConfigHolder;
+import org.wso2.apk.enforcer.constants.APIConstants;
+import org.wso2.apk.enforcer.constants.AnalyticsConstants;
+import org.wso2.apk.enforcer.constants.GeneralErrorCodeConstants;
+import org.wso2.apk.enforcer.constants.MetadataConstants;
+
+import java.util.Map;
+
+/**
+ * Rejects the requests of the applications which have exhausted the token budget of their subscription to an
+ * AI API for the current period.
+ */
+public class TokenBudgetFilter implements Filter {
+
+    private static final Logger logger = LogManager.getLogger(TokenBudgetFilter.class);
+
+    @Override
+    public boolean handleRequest(RequestContext requestContext) {
+        Map<String, String> metadata = requestContext.getMetadataMap();
+        String policyKey = metadata.get(MetadataConstants.ORGANIZATION_AND_AIRL_POLICY);
+        String application = metadata.get(MetadataConstants.TOKEN_BUDGET_APPLICATION);
+        String subscription = metadata.get(MetadataConstants.TOKEN_BUDGET_SUBSCRIPTION);
+        if (policyKey == null || application == null || subscription == null) {
+            return true;
+        }
+        TokenBudgetManager tokenBudgetManager = TokenBudgetManager.getInstance();
+        if (tokenBudgetManager == null) {
+            return handleFailure(requestContext,
+                    ConfigHolder.getInstance().getEnvVarConfig().isTokenBudgetFailureModeDeny());
+        }
+        long consumed;
+        TokenBudget budget;
+        try {
+            budget = tokenBudgetManager.getBudget(policyKey);
+            if (budget == null) {
+                return true;
+            }
+            consumed = tokenBudgetManager.getConsumedTokens(policyKey, budget, application, subscription);
+        } catch (EnforcerException e) {
+            logger.warn("Error while checking the token budget of the policy " + policyKey, e);
+            return handleFailure(requestContext, tokenBudgetManager.isFailureModeDeny());
+        }
+        if (consumed < budget.getTotalTokenCount()) {
+            return true;
+        }
+        logger.debug("Token budget of the policy {} is exhausted for the application {}", policyKey, application);
+        requestContext.getProperties().put(APIConstants.MessageFormat.STATUS_CODE,
+                APIConstants.StatusCodes.THROTTLED.getCode());
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_CODE,
+                AnalyticsConstants.HARD_LIMIT_EXCEEDED_ERROR_CODE);
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_MESSAGE, "Token budget exceeded");
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_DESCRIPTION,
+                "The token budget of the application for the current period is exhausted.");
+        return false;
+    }
+
+    // handleFailure rejects the request if the token budgets are configured to deny the requests when they could
+    // not be read from redis, and lets the request through otherwise.
+    private static boolean handleFailure(RequestContext requestContext, boolean failureModeDeny) {
+        if (!failureModeDeny) {
+            return true;
+        }
+        requestContext.getProperties().put(APIConstants.MessageFormat.STATUS_CODE,
+                APIConstants.StatusCodes.SERVICE_UNAVAILABLE.getCode());
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_CODE,
+                GeneralErrorCodeConstants.TokenBudget.UNAVAILABLE_CODE);
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_MESSAGE,
+                GeneralErrorCodeConstants.TokenBudget.UNAVAILABLE_MESSAGE);
+        requestContext.getProperties().put(APIConstants.MessageFormat.ERROR_DESCRIPTION,
+                GeneralErrorCodeConstants.TokenBudget.UNAVAILABLE_DESCRIPTION);
+        return false;
+    }
+}
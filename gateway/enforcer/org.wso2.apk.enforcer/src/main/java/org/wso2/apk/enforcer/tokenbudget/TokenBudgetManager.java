@@ -0,0 +1,254 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.tokenbudget;
+
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+import org.wso2.apk.enforcer.config.ConfigHolder;
+import org.wso2.apk.enforcer.server.RevokedTokenRedisClient;
+import redis.clients.jedis.Jedis;
+import redis.clients.jedis.JedisPool;
+
+import java.time.Instant;
+import java.time.ZoneOffset;
+import java.time.ZonedDateTime;
+import java.util.Collections;
+import java.util.HashMap;
+import java.util.LinkedHashMap;
+import java.util.Map;
+
+/**
+ * Tracks the tokens consumed against the token budgets of the subscription based AI ratelimit policies. The
+ * consumed tokens of each period are kept in redis, so they are shared by the enforcers and are not lost on
+ * restarts. Soft limit alerts are published to a redis channel when the consumption crosses an alert threshold.
+ * When redis is not reachable, the budgets are not enforced unless the enforcer is configured to deny the requests
+ * in that case (TOKEN_BUDGET_FAILURE_MODE_DENY).
+ */
+public class TokenBudgetManager {
+
+    private static final Logger logger = LogManager.getLogger(TokenBudgetManager.class);
+    private static final ObjectMapper mapper = new ObjectMapper();
+    // These values are shared between the common controller and the enforcer
+    private static final String POLICIES_KEY = "wso2:apk:token_budget:policies";
+    private static final String USAGE_KEY_PREFIX = "wso2:apk:token_budget:usage";
+    private static final String PERIOD_QUARTER = "Quarter";
+    private static final long POLICY_REFRESH_INTERVAL_MILLIS = 30 * 1000;
+    // the usage of a period is kept for a while after the period ends to be reported
+    private static final long USAGE_RETENTION_SECONDS = 90L * 24 * 60 * 60;
+    private static volatile TokenBudgetManager instance;
+
+    private final JedisPool jedisPool;
+    private final String alertsChannel;
+    private final boolean failureModeDeny;
+    private volatile Map<String, TokenBudget> budgets = Collections.emptyMap();
+    private volatile long budgetsLoadedAt;
+    private volatile boolean budgetsLoaded;
+
+    private TokenBudgetManager() throws EnforcerException {
+        this(RevokedTokenRedisClient.getJedisPool(),
+                ConfigHolder.getInstance().getEnvVarConfig().getTokenBudgetAlertsRedisChannel(),
+                ConfigHolder.getInstance().getEnvVarConfig().isTokenBudgetFailureModeDeny());
+    }
+
+    TokenBudgetManager(JedisPool jedisPool, String alertsChannel, boolean failureModeDeny) {
+        this.jedisPool = jedisPool;
+        this.alertsChannel = alertsChannel;
+        this.failureModeDeny = failureModeDeny;
+    }
+
+    /**
+     * Returns the token budget manager. The redis connection pool is created on the first use.
+     *
+     * @return the token budget manager, or null if the connection pool could not be created
+     */
+    public static TokenBudgetManager getInstance() {
+        if (instance == null) {
+            synchronized (TokenBudgetManager.class) {
+                if (instance == null) {
+                    try {
+                        instance = new TokenBudgetManager();
+                    } catch (EnforcerException e) {
+                        logger.error("Error while creating the redis connection pool of the token budgets.", e);
+                        return null;
+                    }
+                }
+            }
+        }
+        return instance;
+    }
+
+    /**
+     * Checks whether the requests are rejected when the token budgets could not be read from redis.
+     *
+     * @return true if the requests are rejected, false if the budgets are not enforced in that case
+     */
+    public boolean isFailureModeDeny() {
+        return failureModeDeny;
+    }
+
+    /**
+     * Returns the token budget of the given policy. The budgets loaded last are used while redis is not reachable.
+     *
+     * @param policyKey organization and the name of the AI ratelimit policy
+     * @return the token budget, or null if the policy does not have a token budget
+     * @throws EnforcerException if the budgets have never been loaded from redis
+     */
+    public TokenBudget getBudget(String policyKey) throws EnforcerException {
+        refreshBudgets();
+        if (!budgetsLoaded) {
+            throw new EnforcerException("Token budgets are not loaded from redis");
+        }
+        return budgets.get(policyKey);
+    }
+
+    /**
+     * Returns the tokens consumed against the budget in the current period.
+     *
+     * @param policyKey    organization and the name of the AI ratelimit policy
+     * @param budget       token budget of the policy
+     * @param application  UUID of the application
+     * @param subscription UUID of the subscription
+     * @return the consumed tokens
+     * @throws EnforcerException if the consumed tokens could not be read from redis
+     */
+    public long getConsumedTokens(String policyKey, TokenBudget budget, String application, String subscription)
+            throws EnforcerException {
+        String usageKey = getUsageKey(policyKey, budget, application, subscription, getPeriod(budget.getPeriod()));
+        try (Jedis jedis = jedisPool.getResource()) {
+            String consumed = jedis.get(usageKey);
+            return consumed == null ? 0 : Long.parseLong(consumed);
+        } catch (Exception e) {
+            throw new EnforcerException("Error while reading the consumed tokens of the token budget", e);
+        }
+    }
+
+    /**
+     * Records the given tokens against the budget in the current period, and raises the alerts of the thresholds
+     * crossed by them.
+     *
+     * @param policyKey    organization and the name of the AI ratelimit policy
+     * @param budget       token budget of the policy
+     * @param application  UUID of the application
+     * @param subscription UUID of the subscription
+     * @param tokens       consumed tokens
+     */
+    public void consume(String policyKey, TokenBudget budget, String application, String subscription, long tokens) {
+        if (tokens <= 0) {
+            return;
+        }
+        BudgetPeriod period = getPeriod(budget.getPeriod());
+        String usageKey = getUsageKey(policyKey, budget, application, subscription, period);
+        try (Jedis jedis = jedisPool.getResource()) {
+            long consumed = jedis.incrBy(usageKey, tokens);
+            jedis.expireAt(usageKey, period.end.getEpochSecond() + USAGE_RETENTION_SECONDS);
+            for (Integer threshold : budget.getAlertThresholds()) {
+                long thresholdTokens = (long) Math.ceil(budget.getTotalTokenCount() * threshold / 100.0);
+                if (consumed - tokens < thresholdTokens && consumed >= thresholdTokens) {
+                    raiseAlert(jedis, policyKey, budget, application, subscription, period, threshold, consumed);
+                }
+            }
+        } catch (Exception e) {
+            logger.error("Error while recording the consumed tokens of the token budget.", e);
+        }
+    }
+
+    private void raiseAlert(Jedis jedis, String policyKey, TokenBudget budget, String application,
+                            String subscription, BudgetPeriod period, int threshold, long consumed) {
+        logger.warn("Token budget of the policy {} for the application {} reached {}% in the period {}. " +
+                "Consumed tokens: {}, budget: {}", policyKey, application, threshold, period.id, consumed,
+                budget.getTotalTokenCount());
+        Map<String, Object> alert = new LinkedHashMap<>();
+        alert.put("policy", policyKey);
+        alert.put("application", application);
+        if (budget.isSubscriptionScoped()) {
+            alert.put("subscription", subscription);
+        }
+        alert.put("period", period.id);
+        alert.put("threshold", threshold);
+        alert.put("consumedTokenCount", consumed);
+        alert.put("totalTokenCount", budget.getTotalTokenCount());
+        alert.put("timestamp", Instant.now().toString());
+        try {
+            jedis.publish(alertsChannel, mapper.writeValueAsString(alert));
+        } catch (Exception e) {
+            logger.error("Error while publishing the token budget alert.", e);
+        }
+    }
+
+    private void refreshBudgets() {
+        long now = System.currentTimeMillis();
+        if (now - budgetsLoadedAt < POLICY_REFRESH_INTERVAL_MILLIS) {
+            return;
+        }
+        synchronized (this) {
+            if (now - budgetsLoadedAt < POLICY_REFRESH_INTERVAL_MILLIS) {
+                return;
+            }
+            budgetsLoadedAt = now;
+            try (Jedis jedis = jedisPool.getResource()) {
+                Map<String, TokenBudget> loadedBudgets = new HashMap<>();
+                for (Map.Entry<String, String> entry : jedis.hgetAll(POLICIES_KEY).entrySet()) {
+                    try {
+                        loadedBudgets.put(entry.getKey(), mapper.readValue(entry.getValue(), TokenBudget.class));
+                    } catch (Exception e) {
+                        logger.error("Error while reading the token budget of the policy " + entry.getKey(), e);
+                    }
+                }
+                budgets = loadedBudgets;
+                budgetsLoaded = true;
+            } catch (Exception e) {
+                logger.warn("Error while loading the token budgets from redis.", e);
+            }
+        }
+    }
+
+    private static String getUsageKey(String policyKey, TokenBudget budget, String application, String subscription,
+                                      BudgetPeriod period) {
+        StringBuilder usageKey = new StringBuilder(USAGE_KEY_PREFIX).append(':').append(policyKey).append(':')
+                .append(application).append(':');
+        if (budget.isSubscriptionScoped()) {
+            usageKey.append(subscription).append(':');
+        }
+        return usageKey.append(period.id).toString();
+    }
+
+    private static BudgetPeriod getPeriod(String period) {
+        ZonedDateTime now = ZonedDateTime.now(ZoneOffset.UTC);
+        if (PERIOD_QUARTER.equals(period)) {
+            int quarter = (now.getMonthValue() - 1) / 3;
+            ZonedDateTime start = ZonedDateTime.of(now.getYear(), quarter * 3 + 1, 1, 0, 0, 0, 0, ZoneOffset.UTC);
+            return new BudgetPeriod(String.format("%d-Q%d", now.getYear(), quarter + 1),
+                    start.plusMonths(3).toInstant());
+        }
+        ZonedDateTime start = ZonedDateTime.of(now.getYear(), now.getMonthValue(), 1, 0, 0, 0, 0, ZoneOffset.UTC);
+        return new BudgetPeriod(String.format("%d-%02d", now.getYear(), now.getMonthValue()),
+                start.plusMonths(1).toInstant());
+    }
+
+    private static class BudgetPeriod {
+        private final String id;
+        private final Instant end;
+
+        BudgetPeriod(String id, Instant end) {
+            this.id = id;
+            this.end = end;
+        }
+    }
+}
@@ -56,6 +56,8 @@ public class TracingConstants {
             "Authenticate request using Unsecured Api Authenticator.";
     public static final String MTLS_API_AUTHENTICATOR_SPAN = "MTLSAPIAuthenticator:authenticate():" +
             "Authenticate request using MTLS Api Authenticator.";
+    public static final String HMAC_AUTHENTICATOR_SPAN = "HMACAuthenticator:authenticate():" +
+            "Authenticate request using HMAC Authenticator.";
 //    public static final String WS_HANDLER_SPAN = "WebSocketHandler:process():Handle request coming through" +
 //            " websocket frame service";
 //    public static final String WS_THROTTLE_SPAN = "WebSocketThrottleFilter:handleRequest():WebSocket throttling filter";
@@ -19,6 +19,9 @@
 package org.wso2.apk.enforcer.util;
 
 import org.apache.commons.lang3.StringUtils;
+import org.apache.commons.logging.Log;
+import org.apache.commons.logging.LogFactory;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
 import org.wso2.apk.enforcer.commons.model.EndpointCluster;
 import org.wso2.apk.enforcer.commons.model.EndpointSecurity;
 import org.wso2.apk.enforcer.commons.model.RequestContext;
@@ -27,6 +30,7 @@ import org.wso2.apk.enforcer.commons.model.RetryConfig;
 import org.wso2.apk.enforcer.config.ConfigHolder;
 import org.wso2.apk.enforcer.config.EnforcerConfig;
 import org.wso2.apk.enforcer.constants.APIConstants;
+import org.wso2.apk.enforcer.constants.GeneralErrorCodeConstants;
 
 import java.util.Base64;
 import java.util.Map;
@@ -37,13 +41,15 @@ import org.wso2.apk.enforcer.constants.AdapterConstants;
  * Util methods related to backend endpoint security.
  */
 public class EndpointUtils {
+    private static final Log log = LogFactory.getLog(EndpointUtils.class);
 
     /**
      * Adds the backend endpoint security header to the given requestContext.
      *
      * @param requestContext requestContext instance to add the backend endpoint security header
+     * @return false if the credentials of the backend could not be obtained
      */
-    public static void addEndpointSecurity(RequestContext requestContext) {
+    public static boolean addEndpointSecurity(RequestContext requestContext) {
         if (requestContext.getMatchedResourcePaths() != null) {
             // getting only first element as there could be only one resourcepaths for APIs except for graphQL APIs. 
             // For GQL APIs too would only have one endpoint for all resources.
@@ -74,9 +80,31 @@ public class EndpointUtils {
                                     securityInfo.getCustomParameters().get("value"));
                         }
                     }
+                    // Add the bearer token obtained with the client credentials grant if the security type is OAuth2
+                    if (securityInfo != null && securityInfo.isEnabled() &&
+                            "OAuth2".equalsIgnoreCase(securityInfo.getSecurityType())) {
+                        try {
+                            String accessToken = OAuth2TokenClient.getInstance()
+                                    .getAccessToken(securityInfo.getCustomParameters());
+                            requestContext.getRemoveHeaders().remove(APIConstants.AUTHORIZATION_HEADER_DEFAULT
+                                    .toLowerCase());
+                            requestContext.addOrModifyHeaders(APIConstants.AUTHORIZATION_HEADER_DEFAULT,
+                                    "Bearer " + accessToken);
+                        } catch (EnforcerException e) {
+                            // The request is not forwarded without the credentials the backend expects
+                            log.error("Error while obtaining the access token for the backend", e);
+                            FilterUtils.setErrorToContext(requestContext,
+                                    GeneralErrorCodeConstants.EndpointSecurity.TOKEN_FAILURE_CODE,
+                                    APIConstants.StatusCodes.SERVICE_UNAVAILABLE.getCode(),
+                                    GeneralErrorCodeConstants.EndpointSecurity.TOKEN_FAILURE_MESSAGE,
+                                    GeneralErrorCodeConstants.EndpointSecurity.TOKEN_FAILURE_DESCRIPTION);
+                            return false;
+                        }
+                    }
                 }
             }
         }
+        return true;
     }
 
     /**
@@ -84,15 +112,16 @@ public class EndpointUtils {
      * environment.
      *
      * @param requestContext request Context
+     * @return false if the request should not be forwarded to the backend, with the error set to the request context
      */
-    public static void updateClusterHeaderAndCheckEnv(RequestContext requestContext) {
+    public static boolean updateClusterHeaderAndCheckEnv(RequestContext requestContext) {
         EnforcerConfig enforcerConfig = ConfigHolder.getInstance().getConfig();
         if (!enforcerConfig.getEnableGatewayClassController()) {
             requestContext.addOrModifyHeaders(AdapterConstants.CLUSTER_HEADER, requestContext.getClusterHeader());
         }
         requestContext.getRemoveHeaders().remove(AdapterConstants.CLUSTER_HEADER);
         addRouterHttpHeaders(requestContext);
-        addEndpointSecurity(requestContext);
+        return addEndpointSecurity(requestContext);
     }
 
     private static void addRouterHttpHeaders(RequestContext requestContext) {
@@ -299,6 +299,9 @@ public class FilterUtils {
         authContext.setAuthenticated(true);
         authContext.setApiKey(jti);
         authContext.setUsername(jwtValidationInfo.getUser());
+        if (jwtValidationInfo.getClaims() != null) {
+            authContext.setClaims(jwtValidationInfo.getClaims());
+        }
 
         if (apiKeyValidationInfoDTO != null) {
             authContext.setApplicationUUID(apiKeyValidationInfoDTO.getApplicationUUID());
@@ -382,6 +385,7 @@ public class FilterUtils {
         authContext.setApiKey(tokenIdentifier);
         authContext.setRawToken(rawToken);
         authContext.setUsername(payload.getSubject());
+        authContext.setClaims(payload.getClaims());
 
         if (api != null) {
             authContext.setTier(APIConstants.UNLIMITED_TIER);
@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package org.wso2.apk.enforcer.util;
+
+import org.apache.commons.io.IOUtils;
+import org.apache.commons.lang3.StringUtils;
+import org.apache.commons.logging.Log;
+import org.apache.commons.logging.LogFactory;
+import org.apache.http.NameValuePair;
+import org.apache.http.client.HttpClient;
+import org.apache.http.client.entity.UrlEncodedFormEntity;
+import org.apache.http.client.methods.CloseableHttpResponse;
+import org.apache.http.client.methods.HttpPost;
+import org.apache.http.message.BasicNameValuePair;
+import org.json.JSONException;
+import org.json.JSONObject;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+import org.wso2.apk.enforcer.constants.APIConstants;
+
+import java.io.IOException;
+import java.io.InputStream;
+import java.nio.charset.StandardCharsets;
+import java.util.ArrayList;
+import java.util.Base64;
+import java.util.List;
+import java.util.Map;
+import java.util.concurrent.ConcurrentHashMap;
+
+/**
+ * This class obtains access tokens for backends secured with the OAuth2 client credentials grant and caches
+ * them until they are about to expire.
+ */
+public class OAuth2TokenClient {
+    private static final Log log = LogFactory.getLog(OAuth2TokenClient.class.getName());
+    private static final OAuth2TokenClient instance = new OAuth2TokenClient();
+    // expiry used when the token endpoint does not return the expires_in attribute
+    private static final long DEFAULT_EXPIRY_SECONDS = 3600;
+
+    private final Map<String, CachedToken> tokenCache = new ConcurrentHashMap<>();
+    // a lock per cache key, so that a slow token endpoint only holds up the requests of its own backends
+    private final Map<String, Object> tokenLocks = new ConcurrentHashMap<>();
+    private HttpClient httpClient;
+
+    private OAuth2TokenClient() {
+    }
+
+    OAuth2TokenClient(HttpClient httpClient) {
+        this.httpClient = httpClient;
+    }
+
+    public static OAuth2TokenClient getInstance() {
+        return instance;
+    }
+
+    /**
+     * Returns a cached access token or obtains a new one from the token endpoint if the cached token is
+     * expired or about to expire.
+     *
+     * @param parameters OAuth2 endpoint security parameters of the backend
+     * @return access token
+     * @throws EnforcerException if the token could not be obtained
+     */
+    public String getAccessToken(Map<String, String> parameters) throws EnforcerException {
+        String tokenEndpoint = parameters.get("tokenEndpoint");
+        String clientId = parameters.get("clientId");
+        String scopes = parameters.get("scopes");
+        String audience = parameters.get("audience");
+        String cacheKey = String.join("|", tokenEndpoint, clientId, StringUtils.defaultString(scopes),
+                StringUtils.defaultString(audience));
+        long refreshSkewMillis = parseSeconds(parameters.get("refreshSkew")) * 1000;
+
+        CachedToken cachedToken = tokenCache.get(cacheKey);
+        if (cachedToken != null && cachedToken.isValid(refreshSkewMillis)) {
+            return cachedToken.accessToken;
+        }
+        synchronized (tokenLocks.computeIfAbsent(cacheKey, key -> new Object())) {
+            cachedToken = tokenCache.get(cacheKey);
+            if (cachedToken != null && cachedToken.isValid(refreshSkewMillis)) {
+                return cachedToken.accessToken;
+            }
+            cachedToken = requestToken(tokenEndpoint, clientId, parameters.get("clientSecret"), scopes, audience,
+                    parseSeconds(parameters.get("tokenCacheTTL")));
+            tokenCache.put(cacheKey, cachedToken);
+            return cachedToken.accessToken;
+        }
+    }
+
+    private synchronized HttpClient getHttpClient() {
+        if (httpClient == null) {
+            httpClient = FilterUtils.getHttpClient(null);
+        }
+        return httpClient;
+    }
+
+    private CachedToken requestToken(String tokenEndpoint, String clientId, String clientSecret, String scopes,
+                                     String audience, long tokenCacheTTL) throws EnforcerException {
+        List<NameValuePair> formParams = new ArrayList<>();
+        formParams.add(new BasicNameValuePair("grant_type", "client_credentials"));
+        if (StringUtils.isNotEmpty(scopes)) {
+            formParams.add(new BasicNameValuePair("scope", scopes));
+        }
+        if (StringUtils.isNotEmpty(audience)) {
+            formParams.add(new BasicNameValuePair("audience", audience));
+        }
+        HttpPost httpPost = new HttpPost(tokenEndpoint);
+        httpPost.setHeader(APIConstants.AUTHORIZATION_HEADER_DEFAULT, APIConstants.AUTHORIZATION_HEADER_BASIC + ' ' +
+                Base64.getEncoder().encodeToString((clientId + ':' + clientSecret).getBytes(StandardCharsets.UTF_8)));
+        httpPost.setEntity(new UrlEncodedFormEntity(formParams, StandardCharsets.UTF_8));
+        try (CloseableHttpResponse response = (CloseableHttpResponse) getHttpClient().execute(httpPost)) {
+            if (response.getStatusLine().getStatusCode() != 200) {
+                throw new EnforcerException("Error occurred when calling the token endpoint " + tokenEndpoint +
+                        ", status code: " + response.getStatusLine().getStatusCode());
+            }
+            try (InputStream content = response.getEntity().getContent()) {
+                JSONObject tokenResponse = new JSONObject(IOUtils.toString(content, StandardCharsets.UTF_8));
+                long expiresIn = tokenResponse.optLong("expires_in", DEFAULT_EXPIRY_SECONDS);
+                if (tokenCacheTTL > 0 && tokenCacheTTL < expiresIn) {
+                    expiresIn = tokenCacheTTL;
+                }
+                log.debug("Obtained an access token from the token endpoint " + tokenEndpoint);
+                long obtainedAt = System.currentTimeMillis();
+                return new CachedToken(tokenResponse.getString("access_token"), obtainedAt,
+                        obtainedAt + expiresIn * 1000);
+            }
+        } catch (IOException | JSONException e) {
+            throw new EnforcerException("Error occurred when calling the token endpoint " + tokenEndpoint, e);
+        }
+    }
+
+    private static long parseSeconds(String value) {
+        if (StringUtils.isEmpty(value)) {
+            return 0;
+        }
+        try {
+            return Long.parseLong(value);
+        } catch (NumberFormatException e) {
+            return 0;
+        }
+    }
+
+    private static class CachedToken {
+        private final String accessToken;
+        private final long obtainedAt;
+        private final long expiresAt;
+
+        CachedToken(String accessToken, long obtainedAt, long expiresAt) {
+            this.accessToken = accessToken;
+            this.obtainedAt = obtainedAt;
+            this.expiresAt = expiresAt;
+        }
+
+        /**
+         * Checks whether the token can still be used. The refresh skew is clamped to half of the lifetime of the
+         * token, so that a skew longer than the lifetime does not make every request obtain a new token.
+         */
+        boolean isValid(long refreshSkewMillis) {
+            long skew = Math.min(refreshSkewMillis, (expiresAt - obtainedAt) / 2);
+            return System.currentTimeMillis() + skew < expiresAt;
+        }
+    }
+}
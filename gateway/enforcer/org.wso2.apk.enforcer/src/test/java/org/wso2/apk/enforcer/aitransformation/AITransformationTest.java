@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.aitransformation;
+
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.junit.Assert;
+import org.junit.Test;
+
+import java.nio.charset.StandardCharsets;
+import java.util.Base64;
+
+public class AITransformationTest {
+
+    private static final ObjectMapper mapper = new ObjectMapper();
+    private static final String CHAT_REQUEST = "{\"model\":\"anthropic.claude-3-haiku-20240307-v1:0\","
+            + "\"messages\":[{\"role\":\"user\",\"content\":\"Hi\"}],\"stream\":true}";
+
+    private static AITransformation transformation(String configJson) {
+        return AITransformation.fromEncodedConfig(
+                Base64.getEncoder().encodeToString(configJson.getBytes(StandardCharsets.UTF_8)));
+    }
+
+    @Test
+    public void testInvalidConfigs() {
+        Assert.assertNull(AITransformation.fromEncodedConfig(null));
+        Assert.assertNull(AITransformation.fromEncodedConfig(""));
+        Assert.assertNull(transformation("{\"format\":\"Unknown\"}"));
+    }
+
+    @Test
+    public void testRequestsNotTransformed() {
+        AITransformation transformation = transformation("{\"format\":\"Anthropic\",\"defaultMaxTokens\":1024}");
+        Assert.assertNull(transformation.transformRequest("not json", "/ai/v1/chat/completions", null));
+        Assert.assertNull(transformation.transformRequest("{\"prompt\":\"Hi\"}", "/ai/v1/completions", null));
+    }
+
+    @Test
+    public void testAnthropicRequest() throws Exception {
+        AITransformation transformation = transformation("{\"format\":\"Anthropic\",\"defaultMaxTokens\":1024,"
+                + "\"basePath\":\"/anthropic\"}");
+        ProviderRequest providerRequest = transformation.transformRequest(CHAT_REQUEST,
+                "/ai/v1/chat/completions?trace=true", "Bearer sk-ant-key");
+
+        Assert.assertEquals("The path of the provider should be under the base path of the backend, with the query.",
+                "/anthropic/v1/messages?trace=true", providerRequest.getHeaders().get(":path"));
+        Assert.assertEquals("application/json", providerRequest.getHeaders().get("content-type"));
+        Assert.assertEquals("2023-06-01", providerRequest.getHeaders().get("anthropic-version"));
+        Assert.assertEquals("sk-ant-key", providerRequest.getHeaders().get("x-api-key"));
+        Assert.assertTrue(providerRequest.getRemoveHeaders().contains("authorization"));
+        Assert.assertEquals(1024, mapper.readTree(providerRequest.getBody()).path("max_tokens").asInt());
+    }
+
+    @Test
+    public void testBedrockRequestSigned() throws Exception {
+        AITransformation transformation = transformation("{\"format\":\"Bedrock\",\"defaultMaxTokens\":1024,"
+                + "\"aws\":{\"region\":\"us-east-1\",\"service\":\"bedrock\","
+                + "\"host\":\"bedrock-runtime.us-east-1.amazonaws.com\",\"accessKeyId\":\"AKIDEXAMPLE\","
+                + "\"secretAccessKey\":\"secret\"}}");
+        ProviderRequest providerRequest = transformation.transformRequest(CHAT_REQUEST, "/ai/v1/chat/completions",
+                "Bearer gateway-token");
+
+        Assert.assertEquals("/model/anthropic.claude-3-haiku-20240307-v1%3A0/converse-stream",
+                providerRequest.getHeaders().get(":path"));
+        Assert.assertNotNull(providerRequest.getHeaders().get("x-amz-date"));
+        String authorization = providerRequest.getHeaders().get("authorization");
+        Assert.assertTrue("The request should be signed instead of sending the credentials of the client.",
+                authorization.startsWith("AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"));
+        Assert.assertTrue(authorization.contains("/us-east-1/bedrock/aws4_request, SignedHeaders=host;x-amz-date, "
+                + "Signature="));
+        Assert.assertFalse(providerRequest.getRemoveHeaders().contains("authorization"));
+        Assert.assertTrue(mapper.readTree(providerRequest.getBody()).has("inferenceConfig"));
+    }
+
+    @Test
+    public void testBedrockRequestWithoutSigning() {
+        AITransformation transformation = transformation("{\"format\":\"Bedrock\",\"defaultMaxTokens\":1024}");
+        ProviderRequest providerRequest = transformation.transformRequest(CHAT_REQUEST, null, "Bearer bedrock-key");
+
+        Assert.assertEquals("/model/anthropic.claude-3-haiku-20240307-v1%3A0/converse-stream",
+                providerRequest.getHeaders().get(":path"));
+        Assert.assertFalse("The credentials should be sent unchanged when the backend is not secured with AWS.",
+                providerRequest.getHeaders().containsKey("authorization"));
+        Assert.assertFalse(providerRequest.getRemoveHeaders().contains("authorization"));
+    }
+
+    @Test
+    public void testResponseConversion() throws Exception {
+        AITransformation transformation = transformation("{\"format\":\"Anthropic\",\"defaultMaxTokens\":1024}");
+        String response = transformation.transformResponse("{\"id\":\"msg_1\",\"content\":[{\"type\":\"text\","
+                + "\"text\":\"Hello\"}],\"stop_reason\":\"end_turn\"}", true, "claude-3-haiku");
+        Assert.assertEquals("Hello", mapper.readTree(response).path("choices").get(0).path("message")
+                .path("content").asText());
+        Assert.assertTrue(transformation.isStreamingResponse("text/event-stream; charset=utf-8"));
+        Assert.assertFalse(transformation.isStreamingResponse("application/json"));
+    }
+}
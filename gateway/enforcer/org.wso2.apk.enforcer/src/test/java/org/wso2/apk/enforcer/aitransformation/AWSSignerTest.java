@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.aitransformation;
+
+import org.junit.Assert;
+import org.junit.Test;
+
+import java.time.Instant;
+import java.util.Map;
+
+public class AWSSignerTest {
+
+    // credentials of the signature version 4 test suite of AWS
+    private static final String ACCESS_KEY_ID = "AKIDEXAMPLE";
+    private static final String SECRET_ACCESS_KEY = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY";
+    private static final Instant TEST_SUITE_TIME = Instant.parse("2015-08-30T12:36:00Z");
+
+    @Test
+    public void testSignatureOfTestSuite() {
+        AWSSigner signer = new AWSSigner("us-east-1", "service", "example.amazonaws.com", ACCESS_KEY_ID,
+                SECRET_ACCESS_KEY, null);
+
+        Map<String, String> headers = signer.sign("GET", "/", "", TEST_SUITE_TIME);
+        Assert.assertEquals("20150830T123600Z", headers.get("x-amz-date"));
+        Assert.assertFalse(headers.containsKey("x-amz-security-token"));
+        Assert.assertEquals("AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "
+                        + "SignedHeaders=host;x-amz-date, "
+                        + "Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+                headers.get("authorization"));
+
+        headers = signer.sign("POST", "/", "", TEST_SUITE_TIME);
+        Assert.assertEquals("AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "
+                        + "SignedHeaders=host;x-amz-date, "
+                        + "Signature=5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b",
+                headers.get("authorization"));
+
+        headers = signer.sign("GET", "/?Param1=value1", "", TEST_SUITE_TIME);
+        Assert.assertEquals("AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "
+                        + "SignedHeaders=host;x-amz-date, "
+                        + "Signature=a67d582fa61cc504c4bae71f336f98b97f1ea3c7a6bfe1b6e45aec72011b9aeb",
+                headers.get("authorization"));
+    }
+
+    @Test
+    public void testSignatureWithSessionTokenAndEncodedPath() {
+        AWSSigner signer = new AWSSigner("us-east-1", "bedrock", "bedrock-runtime.us-east-1.amazonaws.com",
+                ACCESS_KEY_ID, SECRET_ACCESS_KEY, "session-token");
+
+        Map<String, String> headers = signer.sign("POST",
+                "/model/anthropic.claude-3-haiku-20240307-v1%3A0/converse", "{\"messages\":[]}",
+                Instant.parse("2024-01-01T00:00:00Z"));
+        Assert.assertEquals("20240101T000000Z", headers.get("x-amz-date"));
+        Assert.assertEquals("session-token", headers.get("x-amz-security-token"));
+        // the encoded path is encoded once more in the canonical request
+        Assert.assertEquals("AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240101/us-east-1/bedrock/aws4_request, "
+                        + "SignedHeaders=host;x-amz-date;x-amz-security-token, "
+                        + "Signature=a94625269be8574314b58d4e42b444a9989029d1b854fe1e75f4143e07586356",
+                headers.get("authorization"));
+    }
+
+    @Test
+    public void testUriEncode() {
+        Assert.assertEquals("anthropic.claude-3-haiku-20240307-v1%3A0",
+                AWSSigner.uriEncode("anthropic.claude-3-haiku-20240307-v1:0"));
+        Assert.assertEquals("a%20b%2Fc~_-.%C3%A9", AWSSigner.uriEncode("a b/c~_-.\u00e9"));
+    }
+}
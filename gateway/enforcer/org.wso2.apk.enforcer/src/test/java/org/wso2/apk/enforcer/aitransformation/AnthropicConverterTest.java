@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.aitransformation;
+
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.junit.Assert;
+import org.junit.Test;
+
+import java.nio.charset.StandardCharsets;
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.List;
+
+public class AnthropicConverterTest {
+
+    private static final ObjectMapper mapper = new ObjectMapper();
+    private final AnthropicConverter converter = new AnthropicConverter(1024, null);
+
+    @Test
+    public void testRequestConversion() throws Exception {
+        JsonNode request = mapper.readTree("{\"model\":\"claude-3-haiku\",\"messages\":["
+                + "{\"role\":\"system\",\"content\":\"Be brief.\"},"
+                + "{\"role\":\"user\",\"content\":\"Hi\"},"
+                + "{\"role\":\"assistant\",\"content\":null,\"tool_calls\":[{\"id\":\"call_1\",\"type\":\"function\","
+                + "\"function\":{\"name\":\"get_weather\",\"arguments\":\"{\\\"city\\\":\\\"Colombo\\\"}\"}}]},"
+                + "{\"role\":\"tool\",\"tool_call_id\":\"call_1\",\"content\":\"30C\"},"
+                + "{\"role\":\"user\",\"content\":[{\"type\":\"text\",\"text\":\"And tomorrow?\"},"
+                + "{\"type\":\"image_url\",\"image_url\":{\"url\":\"data:image/png;base64,AAAA\"}}]}],"
+                + "\"max_tokens\":100,\"stop\":\"END\",\"stream\":true,\"user\":\"user-1\","
+                + "\"tools\":[{\"type\":\"function\",\"function\":{\"name\":\"get_weather\","
+                + "\"parameters\":{\"type\":\"object\"}}}],\"tool_choice\":\"required\"}");
+
+        JsonNode expected = mapper.readTree("{\"model\":\"claude-3-haiku\",\"system\":\"Be brief.\",\"messages\":["
+                + "{\"role\":\"user\",\"content\":[{\"type\":\"text\",\"text\":\"Hi\"}]},"
+                + "{\"role\":\"assistant\",\"content\":[{\"type\":\"tool_use\",\"id\":\"call_1\","
+                + "\"name\":\"get_weather\",\"input\":{\"city\":\"Colombo\"}}]},"
+                + "{\"role\":\"user\",\"content\":[{\"type\":\"tool_result\",\"tool_use_id\":\"call_1\","
+                + "\"content\":\"30C\"},{\"type\":\"text\",\"text\":\"And tomorrow?\"},{\"type\":\"image\","
+                + "\"source\":{\"type\":\"base64\",\"media_type\":\"image/png\",\"data\":\"AAAA\"}}]}],"
+                + "\"max_tokens\":100,\"stream\":true,\"stop_sequences\":[\"END\"],"
+                + "\"metadata\":{\"user_id\":\"user-1\"},"
+                + "\"tools\":[{\"name\":\"get_weather\",\"input_schema\":{\"type\":\"object\"}}],"
+                + "\"tool_choice\":{\"type\":\"any\"}}");
+        Assert.assertEquals(expected, converter.toProviderRequest(request));
+    }
+
+    @Test
+    public void testRequestWithoutMaxTokens() throws Exception {
+        JsonNode request = mapper.readTree("{\"model\":\"claude-3-haiku\",\"messages\":"
+                + "[{\"role\":\"user\",\"content\":\"Hi\"}]}");
+        Assert.assertEquals(1024, converter.toProviderRequest(request).path("max_tokens").asInt());
+
+        request = mapper.readTree("{\"messages\":[{\"role\":\"user\",\"content\":\"Hi\"}],"
+                + "\"max_completion_tokens\":50,\"max_tokens\":100}");
+        Assert.assertEquals(50, converter.toProviderRequest(request).path("max_tokens").asInt());
+    }
+
+    @Test
+    public void testResponseConversion() throws Exception {
+        JsonNode response = mapper.readTree("{\"id\":\"msg_1\",\"model\":\"claude-3-haiku\",\"content\":["
+                + "{\"type\":\"text\",\"text\":\"Hello\"},{\"type\":\"tool_use\",\"id\":\"toolu_1\","
+                + "\"name\":\"get_weather\",\"input\":{\"city\":\"Colombo\"}}],\"stop_reason\":\"tool_use\","
+                + "\"usage\":{\"input_tokens\":10,\"output_tokens\":5}}");
+
+        JsonNode completion = converter.toOpenAIResponse(response, "requested-model");
+        Assert.assertEquals("msg_1", completion.path("id").asText());
+        Assert.assertEquals("chat.completion", completion.path("object").asText());
+        Assert.assertEquals("claude-3-haiku", completion.path("model").asText());
+        JsonNode choice = completion.path("choices").get(0);
+        Assert.assertEquals("tool_calls", choice.path("finish_reason").asText());
+        Assert.assertEquals("assistant", choice.path("message").path("role").asText());
+        Assert.assertEquals("Hello", choice.path("message").path("content").asText());
+        JsonNode toolCall = choice.path("message").path("tool_calls").get(0);
+        Assert.assertEquals("toolu_1", toolCall.path("id").asText());
+        Assert.assertEquals("get_weather", toolCall.path("function").path("name").asText());
+        Assert.assertEquals(mapper.readTree("{\"city\":\"Colombo\"}"),
+                mapper.readTree(toolCall.path("function").path("arguments").asText()));
+        Assert.assertEquals(mapper.readTree("{\"prompt_tokens\":10,\"completion_tokens\":5,\"total_tokens\":15}"),
+                completion.path("usage"));
+    }
+
+    @Test
+    public void testErrorConversion() throws Exception {
+        JsonNode error = converter.toOpenAIError(mapper.readTree("{\"type\":\"error\",\"error\":"
+                + "{\"type\":\"invalid_request_error\",\"message\":\"max_tokens is too large\"}}"));
+        Assert.assertEquals("max_tokens is too large", error.path("error").path("message").asText());
+        Assert.assertEquals("invalid_request_error", error.path("error").path("type").asText());
+    }
+
+    @Test
+    public void testRequestPathAndHeaders() throws Exception {
+        Assert.assertEquals("/v1/messages", converter.getRequestPath(mapper.readTree("{\"messages\":[]}")));
+
+        ProviderRequest providerRequest = new ProviderRequest("{}");
+        converter.addRequestHeaders(providerRequest, "Bearer sk-ant-key");
+        Assert.assertEquals("2023-06-01", providerRequest.getHeaders().get("anthropic-version"));
+        Assert.assertEquals("The bearer token should be sent as the API key of Anthropic.",
+                "sk-ant-key", providerRequest.getHeaders().get("x-api-key"));
+        Assert.assertTrue(providerRequest.getRemoveHeaders().contains("authorization"));
+
+        providerRequest = new ProviderRequest("{}");
+        new AnthropicConverter(1024, "2024-01-01").addRequestHeaders(providerRequest, null);
+        Assert.assertEquals("2024-01-01", providerRequest.getHeaders().get("anthropic-version"));
+        Assert.assertFalse(providerRequest.getHeaders().containsKey("x-api-key"));
+        Assert.assertTrue(providerRequest.getRemoveHeaders().isEmpty());
+    }
+
+    @Test
+    public void testStreamConversion() throws Exception {
+        byte[] stream = ("event: message_start\n"
+                + "data: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"model\":\"claude-3-haiku\","
+                + "\"usage\":{\"input_tokens\":10,\"output_tokens\":1}}}\n\n"
+                + "event: ping\ndata: {\"type\":\"ping\"}\n\n"
+                + "event: content_block_delta\n"
+                + "data: {\"type\":\"content_block_delta\",\"index\":0,"
+                + "\"delta\":{\"type\":\"text_delta\",\"text\":\"Hi\"}}\n\n"
+                + "event: message_delta\n"
+                + "data: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"end_turn\"},"
+                + "\"usage\":{\"output_tokens\":5}}\n\n"
+                + "event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n").getBytes(StandardCharsets.UTF_8);
+        StreamConverter streamConverter = converter.newStreamConverter("requested-model");
+        // the events are split across the chunks
+        String converted = streamConverter.convert(Arrays.copyOfRange(stream, 0, 40))
+                + streamConverter.convert(Arrays.copyOfRange(stream, 40, stream.length))
+                + streamConverter.finish();
+
+        List<JsonNode> chunks = parseEvents(converted);
+        Assert.assertEquals(4, chunks.size());
+        Assert.assertEquals("msg_1", chunks.get(0).path("id").asText());
+        Assert.assertEquals("assistant", chunks.get(0).path("choices").get(0).path("delta").path("role").asText());
+        Assert.assertEquals("Hi", chunks.get(1).path("choices").get(0).path("delta").path("content").asText());
+        Assert.assertEquals("stop", chunks.get(2).path("choices").get(0).path("finish_reason").asText());
+        Assert.assertEquals(15, chunks.get(3).path("usage").path("total_tokens").asInt());
+        Assert.assertTrue(converted.endsWith("data: [DONE]\n\n"));
+        Assert.assertEquals(10, streamConverter.getPromptTokens());
+        Assert.assertEquals(5, streamConverter.getCompletionTokens());
+        Assert.assertEquals("claude-3-haiku", streamConverter.getModel());
+    }
+
+    // parseEvents returns the json chunks of the given OpenAI server-sent events
+    static List<JsonNode> parseEvents(String events) throws Exception {
+        List<JsonNode> chunks = new ArrayList<>();
+        for (String event : events.split("\n\n")) {
+            String data = event.substring("data: ".length());
+            if (!"[DONE]".equals(data)) {
+                chunks.add(mapper.readTree(data));
+            }
+        }
+        return chunks;
+    }
+}
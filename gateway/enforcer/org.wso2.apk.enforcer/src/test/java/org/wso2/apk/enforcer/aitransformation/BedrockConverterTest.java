@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.aitransformation;
+
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.junit.Assert;
+import org.junit.Test;
+
+import java.io.ByteArrayOutputStream;
+import java.nio.ByteBuffer;
+import java.nio.charset.StandardCharsets;
+import java.util.Arrays;
+import java.util.List;
+
+public class BedrockConverterTest {
+
+    private static final ObjectMapper mapper = new ObjectMapper();
+    private final BedrockConverter converter = new BedrockConverter(1024);
+
+    @Test
+    public void testRequestConversion() throws Exception {
+        JsonNode request = mapper.readTree("{\"model\":\"anthropic.claude-3-haiku-20240307-v1:0\",\"messages\":["
+                + "{\"role\":\"developer\",\"content\":\"Be brief.\"},"
+                + "{\"role\":\"user\",\"content\":\"Hi\"},"
+                + "{\"role\":\"assistant\",\"content\":\"\",\"tool_calls\":[{\"id\":\"call_1\",\"type\":\"function\","
+                + "\"function\":{\"name\":\"get_weather\",\"arguments\":\"not json\"}}]},"
+                + "{\"role\":\"tool\",\"tool_call_id\":\"call_1\",\"content\":\"30C\"}],"
+                + "\"temperature\":0.5,\"top_p\":0.9,\"stop\":[\"END\"],"
+                + "\"tools\":[{\"type\":\"function\",\"function\":{\"name\":\"get_weather\"}}],"
+                + "\"tool_choice\":{\"type\":\"function\",\"function\":{\"name\":\"get_weather\"}}}");
+
+        JsonNode expected = mapper.readTree("{\"messages\":["
+                + "{\"role\":\"user\",\"content\":[{\"text\":\"Hi\"}]},"
+                + "{\"role\":\"assistant\",\"content\":[{\"toolUse\":{\"toolUseId\":\"call_1\","
+                + "\"name\":\"get_weather\",\"input\":{}}}]},"
+                + "{\"role\":\"user\",\"content\":[{\"toolResult\":{\"toolUseId\":\"call_1\","
+                + "\"content\":[{\"text\":\"30C\"}]}}]}],"
+                + "\"system\":[{\"text\":\"Be brief.\"}],"
+                + "\"inferenceConfig\":{\"maxTokens\":1024,\"temperature\":0.5,\"topP\":0.9,"
+                + "\"stopSequences\":[\"END\"]},"
+                + "\"toolConfig\":{\"tools\":[{\"toolSpec\":{\"name\":\"get_weather\","
+                + "\"inputSchema\":{\"json\":{\"type\":\"object\"}}}}],"
+                + "\"toolChoice\":{\"tool\":{\"name\":\"get_weather\"}}}}");
+        Assert.assertEquals("The model is given by the path, hence it should not be in the body.",
+                expected, converter.toProviderRequest(request));
+    }
+
+    @Test
+    public void testRequestWithToolsDisabled() throws Exception {
+        JsonNode request = mapper.readTree("{\"messages\":[{\"role\":\"user\",\"content\":\"Hi\"}],"
+                + "\"tools\":[{\"type\":\"function\",\"function\":{\"name\":\"get_weather\"}}],"
+                + "\"tool_choice\":\"none\"}");
+        Assert.assertFalse(converter.toProviderRequest(request).has("toolConfig"));
+    }
+
+    @Test
+    public void testRequestPath() throws Exception {
+        Assert.assertEquals("/model/anthropic.claude-3-haiku-20240307-v1%3A0/converse",
+                converter.getRequestPath(mapper.readTree(
+                        "{\"model\":\"anthropic.claude-3-haiku-20240307-v1:0\",\"messages\":[]}")));
+        Assert.assertEquals("/model/anthropic.claude-3-haiku-20240307-v1%3A0/converse-stream",
+                converter.getRequestPath(mapper.readTree(
+                        "{\"model\":\"anthropic.claude-3-haiku-20240307-v1:0\",\"messages\":[],\"stream\":true}")));
+        Assert.assertEquals("The model should not escape its path segment.", "/model/..%2Fadmin/converse",
+                converter.getRequestPath(mapper.readTree("{\"model\":\"../admin\",\"messages\":[]}")));
+    }
+
+    @Test
+    public void testResponseConversion() throws Exception {
+        JsonNode response = mapper.readTree("{\"output\":{\"message\":{\"role\":\"assistant\",\"content\":["
+                + "{\"text\":\"Hello\"}]}},\"stopReason\":\"max_tokens\","
+                + "\"usage\":{\"inputTokens\":10,\"outputTokens\":5,\"totalTokens\":15}}");
+
+        JsonNode completion = converter.toOpenAIResponse(response, "anthropic.claude-3-haiku-20240307-v1:0");
+        Assert.assertTrue(completion.path("id").asText().startsWith("chatcmpl-"));
+        Assert.assertEquals("anthropic.claude-3-haiku-20240307-v1:0", completion.path("model").asText());
+        JsonNode choice = completion.path("choices").get(0);
+        Assert.assertEquals("length", choice.path("finish_reason").asText());
+        Assert.assertEquals("Hello", choice.path("message").path("content").asText());
+        Assert.assertFalse(choice.path("message").has("tool_calls"));
+        Assert.assertEquals(mapper.readTree("{\"prompt_tokens\":10,\"completion_tokens\":5,\"total_tokens\":15}"),
+                completion.path("usage"));
+
+        response = mapper.readTree("{\"output\":{\"message\":{\"role\":\"assistant\",\"content\":["
+                + "{\"toolUse\":{\"toolUseId\":\"tooluse_1\",\"name\":\"get_weather\","
+                + "\"input\":{\"city\":\"Colombo\"}}}]}},\"stopReason\":\"tool_use\"}");
+        choice = converter.toOpenAIResponse(response, "model").path("choices").get(0);
+        Assert.assertEquals("tool_calls", choice.path("finish_reason").asText());
+        Assert.assertTrue(choice.path("message").path("content").isNull());
+        Assert.assertEquals("tooluse_1", choice.path("message").path("tool_calls").get(0).path("id").asText());
+    }
+
+    @Test
+    public void testErrorConversion() throws Exception {
+        JsonNode error = converter.toOpenAIError(mapper.readTree("{\"message\":\"The model is not supported.\"}"));
+        Assert.assertEquals("The model is not supported.", error.path("error").path("message").asText());
+        Assert.assertEquals("api_error", error.path("error").path("type").asText());
+    }
+
+    @Test
+    public void testStreamConversion() throws Exception {
+        ByteArrayOutputStream stream = new ByteArrayOutputStream();
+        stream.write(eventMessage("messageStart", "{\"role\":\"assistant\"}"));
+        stream.write(eventMessage("contentBlockDelta", "{\"contentBlockIndex\":0,\"delta\":{\"text\":\"Hi\"}}"));
+        stream.write(eventMessage("contentBlockStop", "{\"contentBlockIndex\":0}"));
+        stream.write(eventMessage("messageStop", "{\"stopReason\":\"end_turn\"}"));
+        stream.write(eventMessage("metadata", "{\"usage\":{\"inputTokens\":10,\"outputTokens\":5}}"));
+        byte[] bytes = stream.toByteArray();
+        StreamConverter streamConverter = converter.newStreamConverter("model");
+        // the messages are split across the chunks
+        String converted = streamConverter.convert(Arrays.copyOfRange(bytes, 0, 70))
+                + streamConverter.convert(Arrays.copyOfRange(bytes, 70, bytes.length))
+                + streamConverter.finish();
+
+        List<JsonNode> chunks = AnthropicConverterTest.parseEvents(converted);
+        Assert.assertEquals(4, chunks.size());
+        Assert.assertEquals("assistant", chunks.get(0).path("choices").get(0).path("delta").path("role").asText());
+        Assert.assertEquals("Hi", chunks.get(1).path("choices").get(0).path("delta").path("content").asText());
+        Assert.assertEquals("stop", chunks.get(2).path("choices").get(0).path("finish_reason").asText());
+        Assert.assertEquals(15, chunks.get(3).path("usage").path("total_tokens").asInt());
+        Assert.assertEquals(10, streamConverter.getPromptTokens());
+        Assert.assertEquals(5, streamConverter.getCompletionTokens());
+    }
+
+    // eventMessage returns a message of the Amazon event stream encoding. The checksums are not validated by the
+    // converter, hence they are left empty.
+    private static byte[] eventMessage(String eventType, String payload) {
+        ByteArrayOutputStream headers = new ByteArrayOutputStream();
+        writeHeader(headers, ":message-type", "event");
+        writeHeader(headers, ":event-type", eventType);
+        writeHeader(headers, ":content-type", "application/json");
+        byte[] headerBytes = headers.toByteArray();
+        byte[] payloadBytes = payload.getBytes(StandardCharsets.UTF_8);
+        int totalLength = 12 + headerBytes.length + payloadBytes.length + 4;
+        ByteBuffer message = ByteBuffer.allocate(totalLength);
+        message.putInt(totalLength);
+        message.putInt(headerBytes.length);
+        message.putInt(0);
+        message.put(headerBytes);
+        message.put(payloadBytes);
+        message.putInt(0);
+        return message.array();
+    }
+
+    private static void writeHeader(ByteArrayOutputStream headers, String name, String value) {
+        byte[] nameBytes = name.getBytes(StandardCharsets.UTF_8);
+        byte[] valueBytes = value.getBytes(StandardCharsets.UTF_8);
+        headers.write(nameBytes.length);
+        headers.write(nameBytes, 0, nameBytes.length);
+        headers.write(7);
+        headers.write(valueBytes.length >> 8);
+        headers.write(valueBytes.length & 0xff);
+        headers.write(valueBytes, 0, valueBytes.length);
+    }
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.bodytransformation;
+
+import org.junit.Assert;
+import org.junit.Test;
+
+import java.nio.charset.StandardCharsets;
+import java.util.Base64;
+
+public class SOAPMediationTest {
+
+    private static final String ENVELOPE_PREFIX = "<?xml version=\"1.0\" encoding=\"UTF-8\"?><soapenv:Envelope "
+            + "xmlns:soapenv=\"http://schemas.xmlsoap.org/soap/envelope/\"><soapenv:Body>";
+    private static final String ENVELOPE_SUFFIX = "</soapenv:Body></soapenv:Envelope>";
+
+    private static SOAPMediation mediation(String configJson) {
+        return SOAPMediation.fromEncodedConfig(
+                Base64.getEncoder().encodeToString(configJson.getBytes(StandardCharsets.UTF_8)));
+    }
+
+    @Test
+    public void testToEnvelope() throws Exception {
+        SOAPMediation mediation = mediation("{\"soapAction\":\"urn:Add\",\"namespace\":\"http://calc.example.com\","
+                + "\"inputElement\":\"Add\"}");
+        Assert.assertEquals(ENVELOPE_PREFIX + "<Add xmlns=\"http://calc.example.com\"><a>1</a></Add>"
+                + ENVELOPE_SUFFIX, mediation.toEnvelope("{\"a\":1}"));
+        Assert.assertEquals("\"urn:Add\"", mediation.getRequestHeaders().get("soapaction"));
+    }
+
+    @Test
+    public void testHostileValuesEscaped() throws Exception {
+        SOAPMediation mediation = mediation("{\"inputElement\":\"Add\"}");
+        String envelope = mediation.toEnvelope("{\"a\":\"1</a><b>2</b><a>\"}");
+        Assert.assertEquals("The values of the payload should not inject elements.", ENVELOPE_PREFIX
+                + "<Add><a>1&lt;/a&gt;&lt;b&gt;2&lt;/b&gt;&lt;a&gt;</a></Add>" + ENVELOPE_SUFFIX, envelope);
+
+        envelope = mediation.toEnvelope("{\"content\":\"]]><!ENTITY x SYSTEM 'file:///etc/passwd'>&\"}");
+        Assert.assertFalse(envelope.contains("<!ENTITY"));
+        Assert.assertTrue(envelope.contains("&amp;"));
+    }
+
+    @Test
+    public void testHostileFieldNamesRejected() {
+        SOAPMediation mediation = mediation("{\"inputElement\":\"Add\"}");
+        for (String field : new String[]{"a><b", "a b", "a/", "1a", "a=\\\"1\\\"", "soapenv:Body", ""}) {
+            Assert.assertThrows("The field " + field + " should be rejected.", BodyTransformationException.class,
+                    () -> mediation.toEnvelope("{\"" + field + "\":1}"));
+        }
+        Assert.assertThrows(BodyTransformationException.class,
+                () -> mediation.toEnvelope("{\"a\":{\"b\":[{\"c></b><x\":1}]}}"));
+    }
+
+    @Test
+    public void testInvalidInputElementRejected() {
+        Assert.assertNull(mediation("{\"inputElement\":\"Add><x\"}"));
+        Assert.assertNull(mediation("{}"));
+    }
+
+    @Test
+    public void testEnvelopeTemplateEscaped() throws Exception {
+        SOAPMediation mediation = mediation("{\"envelopeTemplate\":\"<Add><a>{{ .a }}</a><b>{{ json .b }}</b>"
+                + "</Add>\"}");
+        Assert.assertEquals(ENVELOPE_PREFIX + "<Add><a>&lt;x/&gt;</a><b>&quot;&amp;&quot;</b></Add>"
+                + ENVELOPE_SUFFIX, mediation.toEnvelope("{\"a\":\"<x/>\",\"b\":\"&\"}"));
+    }
+
+    @Test
+    public void testFromEnvelope() throws Exception {
+        SOAPMediation mediation = mediation("{\"soapVersion\":\"SOAP12\",\"inputElement\":\"Add\"}");
+        SOAPMediation.Response response = mediation.fromEnvelope("<s:Envelope xmlns:s=\"http://www.w3.org/2003/"
+                + "05/soap-envelope\"><s:Body><AddResponse><result>3</result></AddResponse></s:Body></s:Envelope>");
+        Assert.assertFalse(response.isFault());
+        Assert.assertEquals("{\"result\":3}", response.getPayload());
+
+        response = mediation.fromEnvelope("<s:Envelope xmlns:s=\"http://www.w3.org/2003/05/soap-envelope\"><s:Body>"
+                + "<s:Fault><s:Code><s:Value>s:Sender</s:Value></s:Code><s:Reason><s:Text xml:lang=\"en\">Bad"
+                + "</s:Text></s:Reason></s:Fault></s:Body></s:Envelope>");
+        Assert.assertTrue(response.isFault());
+        Assert.assertEquals(400, response.getFaultStatusCode());
+        Assert.assertEquals("Sender", response.getFaultCode());
+        Assert.assertEquals("Bad", response.getFaultReason());
+        Assert.assertThrows(BodyTransformationException.class, () -> mediation.fromEnvelope("<a>1</a>"));
+    }
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.bodytransformation;
+
+import org.json.JSONObject;
+import org.junit.Assert;
+import org.junit.Test;
+
+public class XMLSerializerTest {
+
+    @Test
+    public void testToXml() throws Exception {
+        Assert.assertEquals("<root><a>1</a><a>2</a></root>",
+                XMLSerializer.toXml(BodyTransformer.parseJson("{\"a\":[1,2]}"), "root"));
+        Assert.assertEquals("<root><b/></root>", XMLSerializer.toXml(BodyTransformer.parseJson("{\"b\":null}"),
+                "root"));
+        Assert.assertEquals("<root>text &amp; more</root>",
+                XMLSerializer.toXml(BodyTransformer.parseJson("{\"content\":\"text & more\"}"), "root"));
+        Assert.assertEquals("<array>1</array><array>x</array>",
+                XMLSerializer.toXml(BodyTransformer.parseJson("[1,\"x\"]"), null));
+    }
+
+    @Test
+    public void testHostilePayloads() throws Exception {
+        Assert.assertEquals("<root><a>&lt;/a&gt;&lt;admin&gt;true&lt;/admin&gt;&lt;a&gt;</a></root>",
+                XMLSerializer.toXml(new JSONObject().put("a", "</a><admin>true</admin><a>"), "root"));
+        Assert.assertEquals("<root><a>&quot;&apos;</a></root>",
+                XMLSerializer.toXml(new JSONObject().put("a", "\"'"), "root"));
+        Assert.assertThrows(BodyTransformationException.class,
+                () -> XMLSerializer.toXml(new JSONObject().put("a><admin>true</admin><a", 1), "root"));
+        Assert.assertThrows(BodyTransformationException.class,
+                () -> XMLSerializer.toXml(new JSONObject().put("!--", 1), "root"));
+        Assert.assertThrows(BodyTransformationException.class, () -> XMLSerializer.toXml(1, "root><x"));
+    }
+
+    @Test
+    public void testTransformerToXml() throws Exception {
+        BodyTransformer.TransformerConfig config = new BodyTransformer.TransformerConfig();
+        config.conversion = BodyTransformer.JSON_TO_XML;
+        config.xmlRootElement = "pet";
+        BodyTransformer transformer = new BodyTransformer(config);
+        Assert.assertEquals("<pet><name>&lt;x/&gt;</name></pet>", transformer.transform("{\"name\":\"<x/>\"}"));
+        Assert.assertThrows(BodyTransformationException.class, () -> transformer.transform("{\"<x/>\":1}"));
+
+        config.xmlRootElement = "pet><x";
+        Assert.assertThrows(BodyTransformationException.class, () -> new BodyTransformer(config));
+    }
+
+    @Test
+    public void testXmlTemplate() throws Exception {
+        BodyTransformer.TransformerConfig config = new BodyTransformer.TransformerConfig();
+        config.contentType = "application/xml";
+        config.template = "<pet><name>{{ .name }}</name></pet>";
+        Assert.assertEquals("<pet><name>&lt;admin/&gt;</name></pet>",
+                new BodyTransformer(config).transform("{\"name\":\"<admin/>\"}"));
+
+        config.contentType = "application/json";
+        config.template = "{\"name\": {{ json .name }}}";
+        Assert.assertEquals("The JSON templates should not be XML escaped.", "{\"name\": \"<b>\"}",
+                new BodyTransformer(config).transform("{\"name\":\"<b>\"}"));
+    }
+}
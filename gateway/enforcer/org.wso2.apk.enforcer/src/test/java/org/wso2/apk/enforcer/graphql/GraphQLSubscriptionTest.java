@@ -0,0 +1,184 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.graphql;
+
+import graphql.schema.GraphQLSchema;
+import graphql.schema.idl.RuntimeWiring;
+import graphql.schema.idl.SchemaGenerator;
+import graphql.schema.idl.SchemaParser;
+import graphql.schema.idl.TypeDefinitionRegistry;
+import io.envoyproxy.envoy.extensions.common.ratelimit.v3.RateLimitDescriptor;
+import io.envoyproxy.envoy.type.v3.StatusCode;
+import org.junit.Assert;
+import org.junit.Test;
+import org.wso2.apk.enforcer.commons.model.APIConfig;
+import org.wso2.apk.enforcer.commons.model.GraphQLSchemaDTO;
+import org.wso2.apk.enforcer.commons.model.ResourceConfig;
+
+import java.nio.charset.StandardCharsets;
+import java.util.ArrayList;
+import java.util.Base64;
+import java.util.Collections;
+import java.util.List;
+
+public class GraphQLSubscriptionTest {
+
+    private static final String SCHEMA = "type Query { books: [Book] }\n"
+            + "type Book { id: ID title: String }\n"
+            + "type Subscription { bookAdded: Book bookRemoved: Book bookUpdated: Book }";
+    private static final String RATE_LIMITS = "{\"rateLimits\":{\"bookAdded\":[{\"key\":\"org\",\"value\":\"org1\"},"
+            + "{\"key\":\"path\",\"value\":\"/books/1.0bookAdded\"},{\"key\":\"method\",\"value\":\"SUBSCRIPTION\"}]}}";
+
+    private static final APIConfig API = createAPI();
+    private static final List<String> READ_SCOPES = List.of("read:books");
+
+    private static APIConfig createAPI() {
+        TypeDefinitionRegistry registry = new SchemaParser().parse(SCHEMA);
+        GraphQLSchema schema = new SchemaGenerator().makeExecutableSchema(registry,
+                RuntimeWiring.newRuntimeWiring().build());
+        List<ResourceConfig> resources = new ArrayList<>();
+        resources.add(createResource("books", ResourceConfig.HttpMethods.QUERY));
+        resources.add(createResource("bookAdded", ResourceConfig.HttpMethods.SUBSCRIPTION, "read:books"));
+        resources.add(createResource("bookRemoved", ResourceConfig.HttpMethods.SUBSCRIPTION, "admin"));
+        return new APIConfig.Builder("books").uuid("books-uuid").resources(resources)
+                .graphQLSchemaDTO(new GraphQLSchemaDTO(schema, registry, Collections.emptyList())).build();
+    }
+
+    private static ResourceConfig createResource(String field, ResourceConfig.HttpMethods method, String... scopes) {
+        ResourceConfig resource = new ResourceConfig();
+        resource.setPath(field);
+        resource.setMethod(method);
+        resource.setScopes(scopes);
+        return resource;
+    }
+
+    private static GraphQLSubscription subscription(String configJson) {
+        return GraphQLSubscription.fromEncodedConfig(
+                Base64.getEncoder().encodeToString(configJson.getBytes(StandardCharsets.UTF_8)));
+    }
+
+    private static String subscribe(String type, String query) {
+        return "{\"id\":\"1\",\"type\":\"" + type + "\",\"payload\":{\"query\":\"" + query + "\"}}";
+    }
+
+    private static GraphQLSubscription.Rejection authorize(String message, List<String> scopes) {
+        return subscription(RATE_LIMITS).authorize(API, message, scopes, descriptor -> false);
+    }
+
+    @Test
+    public void testOtherMessagesAllowed() {
+        Assert.assertNull(authorize("{\"type\":\"connection_init\",\"payload\":{}}", READ_SCOPES));
+        Assert.assertNull(authorize("{\"type\":\"ping\"}", READ_SCOPES));
+        Assert.assertNull(authorize("{\"id\":\"1\",\"type\":\"complete\"}", READ_SCOPES));
+    }
+
+    @Test
+    public void testScopesOfSubscribeMessages() {
+        Assert.assertNull(authorize(subscribe("subscribe", "subscription { bookAdded { id } }"), READ_SCOPES));
+        Assert.assertNull(authorize(subscribe("start", "subscription { bookAdded { id } }"), READ_SCOPES));
+
+        GraphQLSubscription.Rejection rejection = authorize(
+                subscribe("subscribe", "subscription { bookRemoved { id } }"), READ_SCOPES);
+        Assert.assertNotNull("The field without the scope of the token should be rejected.", rejection);
+        Assert.assertEquals(StatusCode.Forbidden, rejection.getStatusCode());
+        Assert.assertEquals("900910", rejection.getCode());
+        rejection = authorize(subscribe("start", "subscription { bookRemoved { id } }"), List.of());
+        Assert.assertNotNull("The graphql-ws subscribe messages should be authorized.", rejection);
+
+        Assert.assertNull("The scopes should not be validated when the token scopes are not validated.",
+                authorize(subscribe("subscribe", "subscription { bookRemoved { id } }"), null));
+    }
+
+    @Test
+    public void testFieldsOfFragmentsAuthorized() {
+        Assert.assertNotNull("The fields selected through a fragment spread should be authorized.", authorize(
+                subscribe("subscribe", "subscription { ...removed } fragment removed on Subscription "
+                        + "{ bookRemoved { id } }"), READ_SCOPES));
+        Assert.assertNotNull("The fields selected through an inline fragment should be authorized.", authorize(
+                subscribe("subscribe", "subscription { ... on Subscription { bookRemoved { id } } }"),
+                READ_SCOPES));
+        Assert.assertNull(authorize(subscribe("subscribe", "subscription { ...added } fragment added on "
+                + "Subscription { bookAdded { __typename id } }"), READ_SCOPES));
+    }
+
+    @Test
+    public void testAllOperationsAuthorized() {
+        GraphQLSubscription.Rejection rejection = authorize(subscribe("subscribe",
+                "subscription Added { bookAdded { id } } subscription Removed { bookRemoved { id } }"), READ_SCOPES);
+        Assert.assertNotNull("Every operation of the document should be authorized.", rejection);
+        Assert.assertEquals(StatusCode.Forbidden, rejection.getStatusCode());
+    }
+
+    @Test
+    public void testInvalidSubscribeMessagesRejected() {
+        String[] messages = {
+                subscribe("subscribe", "{ books { id } }"),
+                subscribe("subscribe", "subscription Added { bookAdded { id } } query Books { books { id } }"),
+                subscribe("subscribe", "subscription { bookAdded { id }"),
+                subscribe("subscribe", "subscription { bookUpdated { id } }"),
+                subscribe("subscribe", "subscription { unknown { id } }"),
+                subscribe("subscribe", ""),
+                "{\"id\":\"1\",\"type\":\"subscribe\",\"payload\":{}}",
+                "{\"type\":\"subscribe\"",
+        };
+        for (String message : messages) {
+            GraphQLSubscription.Rejection rejection = authorize(message, null);
+            Assert.assertNotNull("The message " + message + " should be rejected.", rejection);
+            Assert.assertEquals(StatusCode.BadRequest, rejection.getStatusCode());
+            Assert.assertEquals("900892", rejection.getCode());
+        }
+    }
+
+    @Test
+    public void testRateLimitsOfSubscribeMessages() {
+        List<RateLimitDescriptor> hits = new ArrayList<>();
+        GraphQLSubscription subscription = subscription(RATE_LIMITS);
+        Assert.assertNull(subscription.authorize(API, subscribe("subscribe", "subscription { bookAdded { id } }"),
+                READ_SCOPES, descriptor -> {
+                    hits.add(descriptor);
+                    return false;
+                }));
+        Assert.assertEquals(1, hits.size());
+        Assert.assertEquals("/books/1.0bookAdded", hits.get(0).getEntries(1).getValue());
+        Assert.assertEquals("SUBSCRIPTION", hits.get(0).getEntries(2).getValue());
+
+        Assert.assertNull("The fields without a rate limit should not be throttled.", subscription.authorize(API,
+                subscribe("subscribe", "subscription { bookRemoved { id } }"), null, descriptor -> true));
+        Assert.assertNull("The messages other than the subscribe messages should not be throttled.",
+                subscription.authorize(API, "{\"type\":\"ping\"}", null, descriptor -> true));
+
+        GraphQLSubscription.Rejection rejection = subscription.authorize(API,
+                subscribe("subscribe", "subscription { bookAdded { id } }"), READ_SCOPES, descriptor -> true);
+        Assert.assertNotNull(rejection);
+        Assert.assertEquals(StatusCode.TooManyRequests, rejection.getStatusCode());
+        Assert.assertEquals("900893", rejection.getCode());
+
+        hits.clear();
+        Assert.assertNotNull(subscription.authorize(API, subscribe("subscribe", "subscription { bookRemoved { id } }"),
+                READ_SCOPES, descriptor -> hits.add(descriptor)));
+        Assert.assertTrue("The rejected messages should not be counted against the rate limits.", hits.isEmpty());
+    }
+
+    @Test
+    public void testInvalidConfig() {
+        Assert.assertNull(GraphQLSubscription.fromEncodedConfig(null));
+        Assert.assertNull(GraphQLSubscription.fromEncodedConfig("not base64"));
+        Assert.assertNotNull(subscription("{}"));
+    }
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.graphql;
+
+import org.junit.Assert;
+import org.junit.Test;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+
+import java.io.ByteArrayOutputStream;
+import java.nio.charset.StandardCharsets;
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.Collections;
+import java.util.List;
+
+public class WebSocketMessageDecoderTest {
+
+    private static final byte[] MASK = {0x12, 0x34, 0x56, 0x78};
+
+    // frame returns a masked client frame with the given first byte and payload
+    private static byte[] frame(int first, byte[] payload) {
+        ByteArrayOutputStream frame = new ByteArrayOutputStream();
+        frame.write(first);
+        if (payload.length < 126) {
+            frame.write(0x80 | payload.length);
+        } else if (payload.length <= 0xffff) {
+            frame.write(0x80 | 126);
+            frame.write(payload.length >> 8);
+            frame.write(payload.length);
+        } else {
+            frame.write(0x80 | 127);
+            for (int i = 7; i >= 0; i--) {
+                frame.write((int) ((long) payload.length >> (8 * i)));
+            }
+        }
+        frame.write(MASK, 0, MASK.length);
+        for (int i = 0; i < payload.length; i++) {
+            frame.write(payload[i] ^ MASK[i % 4]);
+        }
+        return frame.toByteArray();
+    }
+
+    private static byte[] text(String message) {
+        return frame(0x81, message.getBytes(StandardCharsets.UTF_8));
+    }
+
+    private static byte[] concat(byte[]... chunks) {
+        ByteArrayOutputStream stream = new ByteArrayOutputStream();
+        for (byte[] chunk : chunks) {
+            stream.write(chunk, 0, chunk.length);
+        }
+        return stream.toByteArray();
+    }
+
+    @Test
+    public void testDecodeMessages() throws Exception {
+        WebSocketMessageDecoder decoder = new WebSocketMessageDecoder();
+        String longMessage = "{\"type\":\"subscribe\",\"payload\":{\"query\":\"" + "a".repeat(70000) + "\"}}";
+        List<String> messages = decoder.decode(concat(text("{\"type\":\"connection_init\"}"), text("{}"),
+                text(longMessage)));
+        Assert.assertEquals(Arrays.asList("{\"type\":\"connection_init\"}", "{}", longMessage), messages);
+
+        // the frames are not required to be masked by the decoder
+        Assert.assertEquals(Collections.singletonList("ab"), decoder.decode(new byte[]{(byte) 0x81, 2, 'a', 'b'}));
+    }
+
+    @Test
+    public void testDecodeSplitFrames() throws Exception {
+        WebSocketMessageDecoder decoder = new WebSocketMessageDecoder();
+        String message = "{\"type\":\"subscribe\",\"payload\":{\"query\":\"" + "b".repeat(300) + "\"}}";
+        byte[] data = concat(text(message), text("{\"type\":\"ping\"}"));
+        List<String> messages = new ArrayList<>();
+        for (byte value : data) {
+            messages.addAll(decoder.decode(new byte[]{value}));
+        }
+        Assert.assertEquals(Arrays.asList(message, "{\"type\":\"ping\"}"), messages);
+    }
+
+    @Test
+    public void testDecodeFragmentedMessage() throws Exception {
+        WebSocketMessageDecoder decoder = new WebSocketMessageDecoder();
+        byte[] ping = frame(0x89, "ping".getBytes(StandardCharsets.UTF_8));
+        Assert.assertTrue(decoder.decode(concat(frame(0x01, "{\"type\":".getBytes(StandardCharsets.UTF_8)),
+                ping)).isEmpty());
+        Assert.assertEquals(Collections.singletonList("{\"type\":\"subscribe\"}"), decoder.decode(concat(
+                frame(0x00, "\"subscribe".getBytes(StandardCharsets.UTF_8)),
+                frame(0x80, "\"}".getBytes(StandardCharsets.UTF_8)))));
+    }
+
+    @Test
+    public void testControlFramesSkipped() throws Exception {
+        WebSocketMessageDecoder decoder = new WebSocketMessageDecoder();
+        Assert.assertTrue(decoder.decode(concat(frame(0x89, new byte[0]), frame(0x8a, new byte[0]),
+                frame(0x88, new byte[]{0x03, (byte) 0xe8}))).isEmpty());
+    }
+
+    @Test
+    public void testInvalidFramesRejected() {
+        Assert.assertThrows("The compressed frames should be rejected.", EnforcerException.class,
+                () -> new WebSocketMessageDecoder().decode(frame(0xc1, new byte[]{1})));
+        Assert.assertThrows("The continuation frame without a message should be rejected.", EnforcerException.class,
+                () -> new WebSocketMessageDecoder().decode(frame(0x80, new byte[]{1})));
+        Assert.assertThrows("A new message in a fragmented message should be rejected.", EnforcerException.class,
+                () -> new WebSocketMessageDecoder().decode(concat(frame(0x01, new byte[]{1}),
+                        frame(0x81, new byte[]{1}))));
+        Assert.assertThrows("The unknown opcodes should be rejected.", EnforcerException.class,
+                () -> new WebSocketMessageDecoder().decode(frame(0x83, new byte[]{1})));
+    }
+
+    @Test
+    public void testOversizedMessagesRejected() throws Exception {
+        // the frame is rejected as soon as its header is received
+        byte[] header = Arrays.copyOf(frame(0x81, new byte[WebSocketMessageDecoder.MAX_MESSAGE_SIZE + 1]), 10);
+        Assert.assertThrows(EnforcerException.class, () -> new WebSocketMessageDecoder().decode(header));
+
+        WebSocketMessageDecoder decoder = new WebSocketMessageDecoder();
+        byte[] half = new byte[WebSocketMessageDecoder.MAX_MESSAGE_SIZE / 2 + 1];
+        Assert.assertTrue(decoder.decode(frame(0x01, half)).isEmpty());
+        Assert.assertThrows("The fragmented messages should not exceed the maximum size.", EnforcerException.class,
+                () -> decoder.decode(frame(0x80, half)));
+    }
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.grpc;
+
+import org.junit.Assert;
+import org.junit.Test;
+
+import java.io.ByteArrayOutputStream;
+import java.io.IOException;
+import java.nio.charset.StandardCharsets;
+import java.util.Arrays;
+import java.util.zip.DeflaterOutputStream;
+import java.util.zip.GZIPOutputStream;
+
+public class ServerSentEventUsageCollectorTest {
+
+    private static final String STREAM = "data: {\"model\":\"gpt-4o\",\"choices\":[{\"delta\":{\"content\":\"Héllo\"}}]}\n\n"
+            + "data: {\"model\":\"gpt-4o\",\"choices\":[{\"delta\":{\"content\":\" wörld\"}}]}\n\n"
+            + "data: {\"model\":\"gpt-4o\",\"choices\":[],\"usage\":{\"prompt_tokens\":12,"
+            + "\"completion_tokens\":2,\"total_tokens\":14}}\n\n"
+            + "data: [DONE]\n\n";
+
+    private static final String STREAM_WITHOUT_USAGE = "data: {\"model\":\"gpt-4o\",\"choices\":[{\"delta\":"
+            + "{\"content\":\"Héllo\"}}]}\n\n"
+            + "data: {\"model\":\"gpt-4o\",\"choices\":[{\"delta\":{\"content\":\" wörld\"}}]}\n\n"
+            + "data: [DONE]\n\n";
+
+    @Test
+    public void testUsageOfChunkedStream() {
+        ServerSentEventUsageCollector collector = newCollector(null, 0);
+        // the chunks split the lines and the multi byte characters
+        addChunks(collector, STREAM.getBytes(StandardCharsets.UTF_8), 5);
+        ExternalProcessorService.Usage usage = collector.getUsage();
+        Assert.assertEquals(12, usage.getPrompt_tokens());
+        Assert.assertEquals(2, usage.getCompletion_tokens());
+        Assert.assertEquals(14, usage.getTotal_tokens());
+        Assert.assertEquals("gpt-4o", usage.getModel());
+    }
+
+    @Test
+    public void testUsageOfGzipStream() throws IOException {
+        ByteArrayOutputStream compressed = new ByteArrayOutputStream();
+        try (GZIPOutputStream gzip = new GZIPOutputStream(compressed)) {
+            gzip.write(STREAM.getBytes(StandardCharsets.UTF_8));
+        }
+        ServerSentEventUsageCollector collector = newCollector("gzip", 0);
+        // the first chunks split the gzip header
+        addChunks(collector, compressed.toByteArray(), 3);
+        ExternalProcessorService.Usage usage = collector.getUsage();
+        Assert.assertEquals(12, usage.getPrompt_tokens());
+        Assert.assertEquals(14, usage.getTotal_tokens());
+    }
+
+    @Test
+    public void testUsageOfDeflateStream() throws IOException {
+        ByteArrayOutputStream compressed = new ByteArrayOutputStream();
+        try (DeflaterOutputStream deflate = new DeflaterOutputStream(compressed)) {
+            deflate.write(STREAM.getBytes(StandardCharsets.UTF_8));
+        }
+        ServerSentEventUsageCollector collector = newCollector("deflate", 0);
+        addChunks(collector, compressed.toByteArray(), 16);
+        Assert.assertEquals(14, collector.getUsage().getTotal_tokens());
+    }
+
+    @Test
+    public void testEstimatedUsage() {
+        ServerSentEventUsageCollector collector = newCollector(null, 7);
+        addChunks(collector, STREAM_WITHOUT_USAGE.getBytes(StandardCharsets.UTF_8), 64);
+        ExternalProcessorService.Usage usage = collector.getUsage();
+        Assert.assertEquals(7, usage.getPrompt_tokens());
+        Assert.assertEquals(2, usage.getCompletion_tokens());
+        Assert.assertEquals(9, usage.getTotal_tokens());
+        Assert.assertEquals("gpt-4o", usage.getModel());
+    }
+
+    @Test
+    public void testUndecodableStream() {
+        ServerSentEventUsageCollector collector = newCollector("br", 7);
+        collector.addChunk(STREAM.getBytes(StandardCharsets.UTF_8));
+        Assert.assertNull(collector.getUsage());
+
+        collector = newCollector("gzip", 7);
+        collector.addChunk(STREAM.getBytes(StandardCharsets.UTF_8));
+        Assert.assertNull(collector.getUsage());
+    }
+
+    @Test
+    public void testEstimatePromptTokens() {
+        Assert.assertEquals(3, ServerSentEventUsageCollector.estimatePromptTokens(
+                "{\"model\":\"gpt-4o\",\"messages\":[{\"role\":\"system\",\"content\":\"Be brief\"},"
+                        + "{\"role\":\"user\",\"content\":[{\"type\":\"text\",\"text\":\"Hi\"}]}]}"));
+        Assert.assertEquals(3, ServerSentEventUsageCollector.estimatePromptTokens(
+                "{\"model\":\"gpt-3.5-turbo-instruct\",\"prompt\":\"Say hello\"}"));
+        Assert.assertEquals(3, ServerSentEventUsageCollector.estimatePromptTokens("not a json"));
+        Assert.assertEquals(0, ServerSentEventUsageCollector.estimatePromptTokens(""));
+    }
+
+    private static ServerSentEventUsageCollector newCollector(String contentEncoding, int estimatedPromptTokens) {
+        return new ServerSentEventUsageCollector("$.usage.completion_tokens", "$.usage.prompt_tokens",
+                "$.usage.total_tokens", "$.model", contentEncoding, estimatedPromptTokens);
+    }
+
+    private static void addChunks(ServerSentEventUsageCollector collector, byte[] stream, int chunkSize) {
+        for (int start = 0; start < stream.length; start += chunkSize) {
+            collector.addChunk(Arrays.copyOfRange(stream, start, Math.min(start + chunkSize, stream.length)));
+        }
+    }
+}
@@ -56,6 +56,7 @@ import org.wso2.apk.enforcer.subscription.SubscriptionDataStore;
 import java.util.ArrayList;
 import java.util.HashMap;
 import java.util.HashSet;
+import java.util.List;
 import java.util.Map;
 import java.util.UUID;
 
@@ -674,4 +675,92 @@ public class Oauth2ValidatorTest {
             }
         }
     }
+
+    @Test
+    public void testOpaqueTokenIntrospection() throws APISecurityException, EnforcerException {
+
+        String organization = "org1";
+        String environment = "development";
+        String opaqueToken = "b8938768-23fd-4dec-8b70-bed45eb7c33d";
+        JWTValidationInfo jwtValidationInfo = new JWTValidationInfo();
+        jwtValidationInfo.setValid(true);
+        jwtValidationInfo.setExpiryTime(System.currentTimeMillis() + 5000L);
+        jwtValidationInfo.setConsumerKey(UUID.randomUUID().toString());
+        jwtValidationInfo.setUser("user1");
+        jwtValidationInfo.setKeyManager("Default");
+        jwtValidationInfo.setIdentifier("opaque-token-identifier");
+
+        Oauth2Authenticator oauth2Authenticator = new Oauth2Authenticator(new JWTConfigurationDto(), true);
+        RequestContext requestContext = Mockito.mock(RequestContext.class);
+        ArrayList<ResourceConfig> resourceConfigs = new ArrayList<>();
+        ResourceConfig resourceConfig = Mockito.mock(ResourceConfig.class);
+        AuthenticationConfig authenticationConfig = new AuthenticationConfig();
+        Oauth2AuthenticationConfig oauth2AuthenticationConfig = new Oauth2AuthenticationConfig();
+        oauth2AuthenticationConfig.setHeader("Authorization");
+        authenticationConfig.setOauth2AuthenticationConfig(oauth2AuthenticationConfig);
+        Mockito.when(resourceConfig.getAuthenticationConfig()).thenReturn(authenticationConfig);
+        Mockito.when(resourceConfig.getMethod()).thenReturn(ResourceConfig.HttpMethods.GET);
+        resourceConfigs.add(resourceConfig);
+        Mockito.when(requestContext.getMatchedResourcePaths()).thenReturn(resourceConfigs);
+        Map<String, String> headers = new HashMap<>();
+        headers.put("Authorization", "Bearer " + opaqueToken);
+        Mockito.when(requestContext.getHeaders()).thenReturn(headers);
+        Mockito.when(requestContext.getAuthenticationContext()).thenReturn(new AuthenticationContext());
+        APIConfig apiConfig = Mockito.mock(APIConfig.class);
+        Mockito.when(apiConfig.getName()).thenReturn("api1");
+        Mockito.when(apiConfig.getEnvironment()).thenReturn(environment);
+        Mockito.when(apiConfig.getOrganizationId()).thenReturn(organization);
+        Mockito.when(requestContext.getMatchedAPI()).thenReturn(apiConfig);
+        try (MockedStatic<LogManager> logManagerDummy = Mockito.mockStatic(LogManager.class);
+             MockedStatic<ConfigHolder> configHolderDummy = Mockito.mockStatic(ConfigHolder.class);
+             MockedStatic<SubscriptionDataHolder> subscriptionDataHolderMockedStatic =
+                     Mockito.mockStatic(SubscriptionDataHolder.class);
+             MockedStatic<KeyValidator> keyValidaterDummy = Mockito.mockStatic(KeyValidator.class)) {
+            SubscriptionDataStore subscriptionDataStore = Mockito.mock(SubscriptionDataStore.class);
+            SubscriptionDataHolder subscriptionDataHolder = Mockito.mock(SubscriptionDataHolder.class);
+            subscriptionDataHolderMockedStatic.when(SubscriptionDataHolder::getInstance).thenReturn(subscriptionDataHolder);
+            Mockito.when(subscriptionDataHolder.getSubscriptionDataStore(organization)).thenReturn(subscriptionDataStore);
+            Logger logger = Mockito.mock(Logger.class);
+            logManagerDummy.when(() -> LogManager.getLogger(Oauth2Authenticator.class)).thenReturn(logger);
+            EnforcerConfig enforcerConfig = Mockito.mock(EnforcerConfig.class);
+            ConfigHolder configHolder = Mockito.mock(ConfigHolder.class);
+            configHolderDummy.when(ConfigHolder::getInstance).thenReturn(configHolder);
+            Mockito.when(configHolder.getConfig()).thenReturn(enforcerConfig);
+            keyValidaterDummy.when(() -> KeyValidator.validateScopes(Mockito.any())).thenReturn(true);
+
+            // opaque tokens are not accepted when no issuer has an introspection endpoint
+            Mockito.when(subscriptionDataStore.getIntrospectionValidators(environment)).thenReturn(List.of());
+            Assert.assertFalse(oauth2Authenticator.canAuthenticate(requestContext));
+
+            JWTValidationInfo inactiveTokenInfo = new JWTValidationInfo();
+            inactiveTokenInfo.setValid(false);
+            inactiveTokenInfo.setValidationCode(APISecurityConstants.API_AUTH_INVALID_CREDENTIALS);
+            JWTValidator otherIssuerValidator = Mockito.mock(JWTValidator.class);
+            Mockito.when(otherIssuerValidator.validateOpaqueToken(opaqueToken)).thenReturn(inactiveTokenInfo);
+            JWTValidator jwtValidator = Mockito.mock(JWTValidator.class);
+            Mockito.when(jwtValidator.validateOpaqueToken(opaqueToken)).thenReturn(jwtValidationInfo);
+            Mockito.when(subscriptionDataStore.getIntrospectionValidators(environment))
+                    .thenReturn(List.of(otherIssuerValidator, jwtValidator));
+            Assert.assertTrue(oauth2Authenticator.canAuthenticate(requestContext));
+            AuthenticationContext authenticate = oauth2Authenticator.authenticate(requestContext);
+            Assert.assertNotNull(authenticate);
+            Mockito.verify(subscriptionDataStore, Mockito.never()).getJWTValidatorByIssuer(Mockito.any(),
+                    Mockito.any());
+
+            // the token is rejected when it is not active at any issuer
+            Mockito.when(subscriptionDataStore.getIntrospectionValidators(environment))
+                    .thenReturn(List.of(otherIssuerValidator));
+            Assert.assertThrows(APISecurityException.class, () -> oauth2Authenticator.authenticate(requestContext));
+
+            // a failure to call the introspection endpoint rejects the token
+            JWTValidator unreachableValidator = Mockito.mock(JWTValidator.class);
+            Mockito.when(unreachableValidator.validateOpaqueToken(opaqueToken))
+                    .thenThrow(new EnforcerException("Introspection endpoint is unreachable"));
+            Mockito.when(subscriptionDataStore.getIntrospectionValidators(environment))
+                    .thenReturn(List.of(unreachableValidator));
+            APISecurityException exception = Assert.assertThrows(APISecurityException.class,
+                    () -> oauth2Authenticator.authenticate(requestContext));
+            Assert.assertEquals(APISecurityConstants.API_AUTH_GENERAL_ERROR, exception.getErrorCode());
+        }
+    }
 }
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.responsecache;
+
+import org.junit.Assert;
+import org.junit.Test;
+
+import java.nio.charset.StandardCharsets;
+import java.util.Base64;
+import java.util.Map;
+
+public class ResponseCacheTest {
+
+    private static ResponseCache cache(boolean varyByConsumer) {
+        String config = "{\"organization\":\"org1\",\"apiUUID\":\"api1\",\"ttlSeconds\":60,"
+                + "\"varyByHeaders\":[\"accept-language\"],\"varyByConsumer\":" + varyByConsumer
+                + ",\"respectCacheControl\":true,\"store\":\"InMemory\",\"maxEntrySize\":1024}";
+        return ResponseCache.fromEncodedConfig(
+                Base64.getEncoder().encodeToString(config.getBytes(StandardCharsets.UTF_8)));
+    }
+
+    @Test
+    public void testCacheKeyOfQuery() {
+        ResponseCache cache = cache(false);
+        String key = cache.getCacheKey("/pets?b=2&a=1", header -> null, null);
+        Assert.assertTrue(key.startsWith(ResponseCache.CACHE_KEY_PREFIX + "org1:api1:"));
+        Assert.assertEquals("The order of the query parameters should not change the key.",
+                key, cache.getCacheKey("/pets?a=1&b=2", header -> null, null));
+        Assert.assertNotEquals(key, cache.getCacheKey("/pets?a=1&b=3", header -> null, null));
+        Assert.assertNotEquals(key, cache.getCacheKey("/pets?a=1&b=2",
+                Map.of("accept-language", "fr")::get, null));
+    }
+
+    @Test
+    public void testCacheKeyOfConsumers() {
+        ResponseCache cache = cache(true);
+        String userKey = cache.getCacheKey("/pets", header -> null, "user:alice");
+        Assert.assertNotNull(userKey);
+        Assert.assertNotEquals("The responses should not be shared between the users of an application.",
+                userKey, cache.getCacheKey("/pets", header -> null, "user:bob"));
+        Assert.assertNull("The requests without a known consumer should not be cached.",
+                cache.getCacheKey("/pets", header -> null, ""));
+        Assert.assertNull(cache.getCacheKey("/pets", header -> null, null));
+    }
+
+    @Test
+    public void testRequestWithCredentialsNotCached() {
+        ResponseCache cache = cache(false);
+        Assert.assertNull("The requests with credentials should not be cached when the cache is shared.",
+                cache.getCacheKey("/pets", Map.of("authorization", "Bearer token")::get, null));
+        Assert.assertNotNull(cache(true).getCacheKey("/pets", Map.of("authorization", "Bearer token")::get,
+                "user:alice"));
+    }
+
+    @Test
+    public void testTtlSeconds() {
+        ResponseCache cache = cache(false);
+        Assert.assertEquals(60, cache.getTtlSeconds(null, null));
+        Assert.assertEquals(10, cache.getTtlSeconds(null, "public, max-age=30, s-maxage=10"));
+        Assert.assertEquals(0, cache.getTtlSeconds(null, "private"));
+        Assert.assertEquals(0, cache.getTtlSeconds("no-store", null));
+        Assert.assertFalse(cache.isLookupAllowed("no-cache"));
+        Assert.assertTrue(cache.isLookupAllowed("max-age=0"));
+    }
+}
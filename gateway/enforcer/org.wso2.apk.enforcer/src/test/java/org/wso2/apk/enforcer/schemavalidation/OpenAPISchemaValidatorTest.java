@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.schemavalidation;
+
+import org.junit.Assert;
+import org.junit.Test;
+import org.wso2.apk.enforcer.commons.model.APIConfig;
+
+import java.nio.charset.StandardCharsets;
+import java.util.List;
+import java.util.Map;
+import java.util.stream.Collectors;
+
+public class OpenAPISchemaValidatorTest {
+
+    private static final String DEFINITION = "openapi: 3.0.1\n"
+            + "info:\n"
+            + "  title: Pets\n"
+            + "  version: 1.0.0\n"
+            + "paths:\n"
+            + "  /pets:\n"
+            + "    get:\n"
+            + "      parameters:\n"
+            + "        - name: limit\n"
+            + "          in: query\n"
+            + "          schema:\n"
+            + "            type: integer\n"
+            + "            maximum: 100\n"
+            + "      responses:\n"
+            + "        '200':\n"
+            + "          description: pets\n"
+            + "          content:\n"
+            + "            application/json:\n"
+            + "              schema:\n"
+            + "                type: array\n"
+            + "                items:\n"
+            + "                  $ref: '#/components/schemas/Pet'\n"
+            + "    post:\n"
+            + "      requestBody:\n"
+            + "        required: true\n"
+            + "        content:\n"
+            + "          application/json:\n"
+            + "            schema:\n"
+            + "              $ref: '#/components/schemas/Pet'\n"
+            + "      responses:\n"
+            + "        '201':\n"
+            + "          description: created\n"
+            + "  /pets/{petId}:\n"
+            + "    get:\n"
+            + "      parameters:\n"
+            + "        - name: petId\n"
+            + "          in: path\n"
+            + "          required: true\n"
+            + "          schema:\n"
+            + "            type: integer\n"
+            + "        - name: x-tenant\n"
+            + "          in: header\n"
+            + "          required: true\n"
+            + "          schema:\n"
+            + "            type: string\n"
+            + "      responses:\n"
+            + "        '200':\n"
+            + "          description: pet\n"
+            + "components:\n"
+            + "  schemas:\n"
+            + "    Pet:\n"
+            + "      type: object\n"
+            + "      required: [name]\n"
+            + "      properties:\n"
+            + "        name:\n"
+            + "          type: string\n"
+            + "        tag:\n"
+            + "          type: string\n"
+            + "          nullable: true\n";
+
+    private static OpenAPISchemaValidator validator(String uuid, String definition) {
+        return OpenAPISchemaValidator.getValidator(new APIConfig.Builder("Pets").uuid(uuid)
+                .apiDefinition(definition.getBytes(StandardCharsets.UTF_8)).build());
+    }
+
+    private static List<String> pointers(OpenAPISchemaValidator.Result result) {
+        return result.getErrors().stream().map(error -> error.get(OpenAPISchemaValidator.ERROR_POINTER))
+                .collect(Collectors.toList());
+    }
+
+    @Test
+    public void testValidRequests() {
+        OpenAPISchemaValidator validator = validator("valid-requests", DEFINITION);
+        OpenAPISchemaValidator.Result result = validator.validateRequest("GET", "/pets", Map.of("limit", "10"),
+                Map.of(), null);
+        Assert.assertTrue(result.isValid());
+        Assert.assertEquals("GET /pets", result.getOperationKey());
+
+        result = validator.validateRequest("POST", "/pets", Map.of(),
+                Map.of("content-type", "application/json; charset=utf-8"), "{\"name\":\"Tom\",\"tag\":null}");
+        Assert.assertTrue(result.getErrors().toString(), result.isValid());
+
+        result = validator.validateRequest("GET", "/pets/7/", Map.of(), Map.of("x-tenant", "t1"), null);
+        Assert.assertTrue(result.getErrors().toString(), result.isValid());
+        Assert.assertEquals("GET /pets/{petId}", result.getOperationKey());
+    }
+
+    @Test
+    public void testInvalidRequests() {
+        OpenAPISchemaValidator validator = validator("invalid-requests", DEFINITION);
+        Assert.assertEquals(List.of("/query/limit"), pointers(validator.validateRequest("GET", "/pets",
+                Map.of("limit", "1000"), Map.of(), null)));
+        Assert.assertEquals(List.of("/query/limit"), pointers(validator.validateRequest("GET", "/pets",
+                Map.of("limit", "ten"), Map.of(), null)));
+        Assert.assertEquals(List.of("/path/petId", "/header/x-tenant"), pointers(validator.validateRequest("GET",
+                "/pets/tom", Map.of(), Map.of(), null)));
+        Assert.assertEquals(List.of("/body"), pointers(validator.validateRequest("POST", "/pets", Map.of(),
+                Map.of("content-type", "application/json"), null)));
+        Assert.assertEquals(List.of("/body"), pointers(validator.validateRequest("POST", "/pets", Map.of(),
+                Map.of("content-type", "application/json"), "{\"name\":")));
+        Assert.assertEquals(List.of("/body"), pointers(validator.validateRequest("POST", "/pets", Map.of(),
+                Map.of("content-type", "text/plain"), "Tom")));
+        Assert.assertEquals(List.of("/body/name"), pointers(validator.validateRequest("POST", "/pets", Map.of(),
+                Map.of("content-type", "application/json"), "{\"name\":1}")));
+    }
+
+    @Test
+    public void testUnmatchedRequests() {
+        OpenAPISchemaValidator validator = validator("unmatched-requests", DEFINITION);
+        OpenAPISchemaValidator.Result result = validator.validateRequest("DELETE", "/pets", Map.of(), Map.of(),
+                null);
+        Assert.assertFalse("The requests which do not match an operation should be rejected.", result.isValid());
+        Assert.assertNull(result.getOperationKey());
+        Assert.assertFalse(validator.validateRequest("GET", "/owners", Map.of(), Map.of(), null).isValid());
+    }
+
+    @Test
+    public void testTruncatedBody() {
+        OpenAPISchemaValidator validator = validator("truncated-body", DEFINITION);
+        OpenAPISchemaValidator.Result result = validator.validateRequest("POST", "/pets", Map.of(),
+                Map.of("content-type", "application/json", "x-envoy-auth-partial-body", "true"),
+                "{\"name\":\"Tom\"}");
+        Assert.assertEquals("A truncated body should not be validated partially.", List.of("/body"),
+                pointers(result));
+    }
+
+    @Test
+    public void testValidateResponse() {
+        OpenAPISchemaValidator validator = validator("responses", DEFINITION);
+        Assert.assertTrue(validator.validateResponse("GET /pets", "200", "application/json",
+                "[{\"name\":\"Tom\"}]").isEmpty());
+        Assert.assertFalse(validator.validateResponse("GET /pets", "200", "application/json",
+                "[{\"tag\":\"cat\"}]").isEmpty());
+        Assert.assertTrue("The undocumented status codes should not be validated.",
+                validator.validateResponse("GET /pets", "500", "application/json", "oops").isEmpty());
+    }
+
+    @Test
+    public void testInvalidDefinitions() {
+        Assert.assertNull(validator("swagger", "swagger: \"2.0\"\ninfo:\n  title: Pets\n  version: 1.0.0\n"
+                + "paths: {}\n"));
+        Assert.assertNull(validator("not-a-definition", "not a definition"));
+        Assert.assertNull(OpenAPISchemaValidator.getValidator(new APIConfig.Builder("Pets").uuid("missing").build()));
+        OpenAPISchemaValidator validator = validator("reused", DEFINITION);
+        Assert.assertSame(validator, OpenAPISchemaValidator.getValidator("reused"));
+    }
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security;
+
+import org.junit.Assert;
+import org.junit.Test;
+import org.wso2.apk.enforcer.commons.model.AuthenticationContext;
+
+public class AuthFilterTest {
+
+    @Test
+    public void testResponseCacheConsumer() {
+        AuthenticationContext authContext = new AuthenticationContext();
+        authContext.setApplicationUUID("app1");
+        authContext.setUsername("alice");
+        Assert.assertEquals("The end user should be the consumer of the cached responses.",
+                "user:alice", AuthFilter.getResponseCacheConsumer(authContext));
+
+        authContext.setUsername(null);
+        Assert.assertEquals("application:app1", AuthFilter.getResponseCacheConsumer(authContext));
+
+        Assert.assertEquals("The consumer should not be known without a user or an application.",
+                "", AuthFilter.getResponseCacheConsumer(new AuthenticationContext()));
+        Assert.assertEquals("", AuthFilter.getResponseCacheConsumer(null));
+    }
+}
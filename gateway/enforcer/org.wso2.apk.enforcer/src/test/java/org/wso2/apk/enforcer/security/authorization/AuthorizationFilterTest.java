@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.authorization;
+
+import org.junit.Assert;
+import org.junit.Test;
+import org.mockito.Mockito;
+import org.wso2.apk.enforcer.commons.model.AuthenticationContext;
+import org.wso2.apk.enforcer.commons.model.AuthorizationRuleConfig;
+import org.wso2.apk.enforcer.commons.model.RequestContext;
+import org.wso2.apk.enforcer.commons.model.ResourceConfig;
+import org.wso2.apk.enforcer.constants.APIConstants;
+import org.wso2.apk.enforcer.constants.APISecurityConstants;
+
+import java.util.ArrayList;
+import java.util.Collections;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+public class AuthorizationFilterTest {
+
+    @Test
+    public void testRequestWithoutRulesIsAllowed() {
+        RequestContext requestContext = getRequestContext(new ArrayList<>(), new HashMap<>());
+        Assert.assertTrue(new AuthorizationFilter().handleRequest(requestContext));
+    }
+
+    @Test
+    public void testAllRulesShouldAllow() {
+        List<AuthorizationRuleConfig> rules = new ArrayList<>();
+        rules.add(getRule("APKExpression", "claims.sub == \"alice\"", null));
+        rules.add(getRule("APKExpression", "\"admin\" in claims.groups", "Only the admins can access the orders"));
+        Map<String, Object> claims = new HashMap<>();
+        claims.put("sub", "alice");
+        claims.put("groups", Collections.singletonList("admin"));
+        Assert.assertTrue(new AuthorizationFilter().handleRequest(getRequestContext(rules, claims)));
+
+        claims.put("groups", Collections.singletonList("dev"));
+        RequestContext requestContext = getRequestContext(rules, claims);
+        Assert.assertFalse(new AuthorizationFilter().handleRequest(requestContext));
+        Assert.assertEquals(APIConstants.StatusCodes.UNAUTHORIZED.getCode(),
+                requestContext.getProperties().get(APIConstants.MessageFormat.STATUS_CODE));
+        Assert.assertEquals(APISecurityConstants.API_AUTH_FORBIDDEN,
+                requestContext.getProperties().get(APIConstants.MessageFormat.ERROR_CODE));
+        Assert.assertEquals("Only the admins can access the orders",
+                requestContext.getProperties().get(APIConstants.MessageFormat.ERROR_DESCRIPTION));
+    }
+
+    @Test
+    public void testInvalidRuleDenies() {
+        List<AuthorizationRuleConfig> rules = Collections.singletonList(getRule("APKExpression", "claims.sub ==",
+                null));
+        Map<String, Object> claims = Collections.singletonMap("sub", "alice");
+        Assert.assertFalse(new AuthorizationFilter().handleRequest(getRequestContext(rules, claims)));
+    }
+
+    @Test
+    public void testUnauthenticatedRequestHasNoClaims() {
+        List<AuthorizationRuleConfig> rules = Collections.singletonList(getRule("APKExpression",
+                "claims.sub == \"alice\"", null));
+        Assert.assertFalse(new AuthorizationFilter().handleRequest(getRequestContext(rules, null)));
+    }
+
+    private static AuthorizationRuleConfig getRule(String language, String expression, String message) {
+        AuthorizationRuleConfig rule = new AuthorizationRuleConfig();
+        rule.setName("rule");
+        rule.setLanguage(language);
+        rule.setExpression(expression);
+        rule.setMessage(message);
+        return rule;
+    }
+
+    private static RequestContext getRequestContext(List<AuthorizationRuleConfig> rules, Map<String, Object> claims) {
+        ResourceConfig resourceConfig = new ResourceConfig();
+        resourceConfig.setPath("/orders/{orderId}");
+        resourceConfig.setAuthorizationRules(rules);
+        ArrayList<ResourceConfig> resourceConfigs = new ArrayList<>();
+        resourceConfigs.add(resourceConfig);
+        AuthenticationContext authenticationContext = new AuthenticationContext();
+        authenticationContext.setClaims(claims);
+        RequestContext requestContext = Mockito.mock(RequestContext.class);
+        Mockito.when(requestContext.getMatchedResourcePaths()).thenReturn(resourceConfigs);
+        Mockito.when(requestContext.getAuthenticationContext()).thenReturn(authenticationContext);
+        Mockito.when(requestContext.getHeaders()).thenReturn(new HashMap<>());
+        Mockito.when(requestContext.getPathParameters()).thenReturn(Collections.singletonMap("orderId", "42"));
+        Mockito.when(requestContext.getRequestMethod()).thenReturn("GET");
+        Mockito.when(requestContext.getRequestPath()).thenReturn("/orders/42?expand=items");
+        Mockito.when(requestContext.getProperties()).thenReturn(new HashMap<>());
+        return requestContext;
+    }
+}
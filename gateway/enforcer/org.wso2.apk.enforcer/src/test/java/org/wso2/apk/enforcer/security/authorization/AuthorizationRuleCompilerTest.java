@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.authorization;
+
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.junit.Assert;
+import org.junit.Test;
+
+import java.io.InputStream;
+import java.util.Collections;
+import java.util.Date;
+import java.util.HashMap;
+import java.util.Map;
+
+public class AuthorizationRuleCompilerTest {
+
+    /**
+     * Tests the expressions which the adapter is tested against, so that the enforcer compiles exactly the
+     * expressions accepted by the adapter.
+     */
+    @Test
+    public void testExpressions() throws Exception {
+        ObjectMapper mapper = new ObjectMapper();
+        JsonNode expressions;
+        try (InputStream stream = getClass().getResourceAsStream("/expressions.json")) {
+            Assert.assertNotNull("expressions.json of the adapter is not a test resource", stream);
+            expressions = mapper.readTree(stream);
+        }
+        Map<String, Object> input = (Map<String, Object>) RuleEvaluator.normalize(
+                mapper.convertValue(expressions.get("input"), Map.class));
+        Assert.assertTrue(expressions.get("tests").size() > 0);
+        for (JsonNode test : expressions.get("tests")) {
+            String name = test.get("name").asText();
+            CompiledRule rule;
+            try {
+                rule = AuthorizationRuleCompiler.compile("APKExpression", test.get("expression").asText());
+            } catch (AuthorizationRuleException e) {
+                Assert.assertFalse(name + ": " + e.getMessage(), test.get("valid").asBoolean());
+                continue;
+            }
+            Assert.assertTrue(name + " should be invalid", test.get("valid").asBoolean());
+            Assert.assertEquals(name, test.get("allowed").asBoolean(), rule.allows(input));
+        }
+    }
+
+    @Test
+    public void testDateClaims() throws Exception {
+        Map<String, Object> claims = new HashMap<>();
+        claims.put("exp", new Date(1800000000000L));
+        Map<String, Object> input = new HashMap<>();
+        input.put("claims", RuleEvaluator.normalize(claims));
+        Assert.assertTrue(AuthorizationRuleCompiler.compile("", "claims.exp == 1800000000").allows(input));
+    }
+
+    @Test
+    public void testDefaultLanguage() throws Exception {
+        Map<String, Object> input = Collections.singletonMap("method", "GET");
+        Assert.assertTrue(AuthorizationRuleCompiler.compile("", "method == \"GET\"").allows(input));
+        Assert.assertTrue(AuthorizationRuleCompiler.compile(null, "method == \"GET\"").allows(input));
+    }
+
+    @Test(expected = AuthorizationRuleException.class)
+    public void testUnsupportedLanguage() throws Exception {
+        AuthorizationRuleCompiler.compile("Rego", "package apk\nallow if input.method == \"GET\"");
+    }
+}
@@ -0,0 +1,245 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.hmac;
+
+import org.junit.Assert;
+import org.junit.Test;
+import org.mockito.Mockito;
+import org.wso2.apk.enforcer.commons.exception.APISecurityException;
+import org.wso2.apk.enforcer.commons.model.APIConfig;
+import org.wso2.apk.enforcer.commons.model.AuthenticationConfig;
+import org.wso2.apk.enforcer.commons.model.AuthenticationContext;
+import org.wso2.apk.enforcer.commons.model.HMACAuthenticationConfig;
+import org.wso2.apk.enforcer.commons.model.RequestContext;
+import org.wso2.apk.enforcer.commons.model.ResourceConfig;
+import org.wso2.apk.enforcer.constants.APISecurityConstants;
+
+import java.nio.charset.StandardCharsets;
+import java.time.Instant;
+import java.time.ZoneOffset;
+import java.time.format.DateTimeFormatter;
+import java.util.ArrayList;
+import java.util.Base64;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+import javax.crypto.Mac;
+import javax.crypto.spec.SecretKeySpec;
+
+public class HMACAuthenticatorTest {
+
+    private static final String KEY_ID = "client1";
+    private static final String KEY = "shared-secret";
+    private static final Instant NOW = Instant.parse("2024-06-01T10:00:00Z");
+    private static final String BODY = "{\"hello\": \"world\"}";
+    private static final String BODY_DIGEST = "SHA-256=X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE=";
+
+    @Test
+    public void testValidSignature() throws Exception {
+        Map<String, String> headers = new HashMap<>();
+        headers.put("date", formatDate(Instant.now()));
+        headers.put("digest", BODY_DIGEST);
+        headers.put("signature", getDigestSignature(headers.get("date"), headers.get("digest")));
+        RequestContext requestContext = getRequestContext(headers, getConfig());
+        Mockito.when(requestContext.getRequestBody()).thenReturn(BODY.getBytes(StandardCharsets.UTF_8));
+
+        HMACAuthenticator hmacAuthenticator = new HMACAuthenticator();
+        Assert.assertTrue(hmacAuthenticator.canAuthenticate(requestContext));
+        AuthenticationContext authenticationContext = hmacAuthenticator.authenticate(requestContext);
+        Assert.assertTrue(authenticationContext.isAuthenticated());
+        Assert.assertEquals(KEY_ID, authenticationContext.getUsername());
+    }
+
+    @Test
+    public void testMissingSignature() {
+        RequestContext requestContext = getRequestContext(new HashMap<>(), getConfig());
+        Assert.assertFalse(new HMACAuthenticator().canAuthenticate(requestContext));
+    }
+
+    @Test
+    public void testCreatedParameterAndAlgorithms() throws Exception {
+        HMACAuthenticationConfig config = getConfig();
+        config.setAlgorithms(List.of("hmac-sha256", "hmac-sha512"));
+        config.setSignedHeaders(List.of("(request-target)", "(created)"));
+        long created = NOW.getEpochSecond();
+        String signingString = "(request-target): post /orders?status=new\n(created): " + created;
+        String signature = "Signature keyId=\"" + KEY_ID + "\",algorithm=\"hmac-sha512\",created=\"" + created
+                + "\",headers=\"(request-target) (created)\",signature=\"" + sign("HmacSHA512", KEY, signingString)
+                + "\"";
+        RequestContext requestContext = getRequestContext(new HashMap<>(), config);
+        Assert.assertEquals(KEY_ID, HMACAuthenticator.verifySignature(requestContext, config, signature, NOW));
+
+        // an algorithm which is not accepted for the API is rejected even if the signature matches
+        config.setAlgorithms(List.of("hmac-sha256"));
+        assertInvalid(requestContext, config, signature, NOW);
+    }
+
+    @Test
+    public void testTamperedRequest() throws Exception {
+        HMACAuthenticationConfig config = getConfig();
+        Map<String, String> headers = new HashMap<>();
+        headers.put("date", formatDate(NOW));
+        String signature = getDateSignature("post /orders?status=new", headers.get("date"), KEY);
+        RequestContext requestContext = getRequestContext(headers, config);
+        Assert.assertEquals(KEY_ID, HMACAuthenticator.verifySignature(requestContext, config, signature, NOW));
+
+        // the signature of another path is rejected
+        assertInvalid(requestContext, config, getDateSignature("post /orders/1", headers.get("date"), KEY), NOW);
+        // the signature created with another key is rejected
+        assertInvalid(requestContext, config, getDateSignature("post /orders?status=new", headers.get("date"),
+                "other-secret"), NOW);
+        // the signature of an unknown key ID is rejected
+        assertInvalid(requestContext, config, signature.replace(KEY_ID, "client2"), NOW);
+        // the signature which is not base64 encoded is rejected
+        assertInvalid(requestContext, config, "keyId=\"" + KEY_ID + "\",headers=\"(request-target) date\","
+                + "signature=\"not base64!\"", NOW);
+    }
+
+    @Test
+    public void testRequiredSignedHeaders() throws Exception {
+        HMACAuthenticationConfig config = getConfig();
+        Map<String, String> headers = new HashMap<>();
+        headers.put("date", formatDate(NOW));
+        String signature = "keyId=\"" + KEY_ID + "\",headers=\"date\",signature=\""
+                + sign("HmacSHA256", KEY, "date: " + headers.get("date")) + "\"";
+        RequestContext requestContext = getRequestContext(headers, config);
+
+        // the signature has to cover the (request-target) which is required for the API
+        assertInvalid(requestContext, config, signature, NOW);
+        config.setSignedHeaders(List.of("date"));
+        Assert.assertEquals(KEY_ID, HMACAuthenticator.verifySignature(requestContext, config, signature, NOW));
+    }
+
+    @Test
+    public void testClockSkew() throws Exception {
+        HMACAuthenticationConfig config = getConfig();
+        Map<String, String> headers = new HashMap<>();
+        headers.put("date", formatDate(NOW));
+        String signature = getDateSignature("post /orders?status=new", headers.get("date"), KEY);
+        RequestContext requestContext = getRequestContext(headers, config);
+
+        Assert.assertEquals(KEY_ID, HMACAuthenticator.verifySignature(requestContext, config, signature,
+                NOW.plusSeconds(300)));
+        Assert.assertEquals(KEY_ID, HMACAuthenticator.verifySignature(requestContext, config, signature,
+                NOW.minusSeconds(300)));
+        assertInvalid(requestContext, config, signature, NOW.plusSeconds(301));
+        assertInvalid(requestContext, config, signature, NOW.minusSeconds(301));
+
+        // a signature which does not cover a timestamp can be replayed, hence rejected
+        config.setSignedHeaders(List.of("(request-target)"));
+        String noTimestampSignature = "keyId=\"" + KEY_ID + "\",headers=\"(request-target)\",signature=\""
+                + sign("HmacSHA256", KEY, "(request-target): post /orders?status=new") + "\"";
+        assertInvalid(requestContext, config, noTimestampSignature, NOW);
+    }
+
+    @Test
+    public void testDigest() throws Exception {
+        HMACAuthenticationConfig config = getConfig();
+        Map<String, String> headers = new HashMap<>();
+        headers.put("date", formatDate(NOW));
+        headers.put("digest", BODY_DIGEST);
+        String signature = getDigestSignature(headers.get("date"), headers.get("digest"));
+        RequestContext requestContext = getRequestContext(headers, config);
+        Mockito.when(requestContext.getRequestBody()).thenReturn(BODY.getBytes(StandardCharsets.UTF_8));
+        Assert.assertEquals(KEY_ID, HMACAuthenticator.verifySignature(requestContext, config, signature, NOW));
+
+        // the body which does not match the signed digest is rejected
+        Mockito.when(requestContext.getRequestBody()).thenReturn("{\"hello\": \"there\"}"
+                .getBytes(StandardCharsets.UTF_8));
+        assertInvalid(requestContext, config, signature, NOW);
+
+        // the digest can not be validated if the body is truncated or not passed to the enforcer
+        Mockito.when(requestContext.getRequestBody()).thenReturn("{\"hello\"".getBytes(StandardCharsets.UTF_8));
+        headers.put("content-length", "18");
+        assertInvalid(requestContext, config, signature, NOW);
+        headers.remove("content-length");
+        headers.put("x-envoy-auth-partial-body", "true");
+        assertInvalid(requestContext, config, signature, NOW);
+        headers.remove("x-envoy-auth-partial-body");
+        Mockito.when(requestContext.getRequestBody()).thenReturn(null);
+        assertInvalid(requestContext, config, signature, NOW);
+
+        // a request without content has the digest of the empty body
+        headers.put("content-length", "0");
+        assertInvalid(requestContext, config, signature, NOW);
+        headers.put("digest", "SHA-256=47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=");
+        Assert.assertEquals(KEY_ID, HMACAuthenticator.verifySignature(requestContext, config,
+                getDigestSignature(headers.get("date"), headers.get("digest")), NOW));
+
+        // a digest without a supported algorithm can not be validated
+        headers.put("digest", "MD5=1B2M2Y8AsgTpgAmY7PhCfg==");
+        assertInvalid(requestContext, config, getDigestSignature(headers.get("date"), headers.get("digest")), NOW);
+    }
+
+    private static void assertInvalid(RequestContext requestContext, HMACAuthenticationConfig config,
+                                      String signature, Instant now) {
+        APISecurityException exception = Assert.assertThrows(APISecurityException.class,
+                () -> HMACAuthenticator.verifySignature(requestContext, config, signature, now));
+        Assert.assertEquals(APISecurityConstants.API_AUTH_INVALID_CREDENTIALS, exception.getErrorCode());
+    }
+
+    private static String getDateSignature(String requestTarget, String date, String key) throws Exception {
+        return "keyId=\"" + KEY_ID + "\",algorithm=\"hmac-sha256\",headers=\"(request-target) date\",signature=\""
+                + sign("HmacSHA256", key, "(request-target): " + requestTarget + "\ndate: " + date) + "\"";
+    }
+
+    private static String getDigestSignature(String date, String digest) throws Exception {
+        String signingString = "(request-target): post /orders?status=new\ndate: " + date + "\ndigest: " + digest;
+        return "keyId=\"" + KEY_ID + "\",algorithm=\"hmac-sha256\",headers=\"(request-target) date digest\","
+                + "signature=\"" + sign("HmacSHA256", KEY, signingString) + "\"";
+    }
+
+    private static HMACAuthenticationConfig getConfig() {
+        HMACAuthenticationConfig config = new HMACAuthenticationConfig();
+        config.setHeader("Signature");
+        config.setKeys(Map.of(KEY_ID, KEY));
+        config.setAlgorithms(List.of("hmac-sha256"));
+        config.setSignedHeaders(List.of("(request-target)", "date"));
+        config.setClockSkew(300);
+        return config;
+    }
+
+    private static RequestContext getRequestContext(Map<String, String> headers, HMACAuthenticationConfig config) {
+        AuthenticationConfig authenticationConfig = new AuthenticationConfig();
+        authenticationConfig.setHmacAuthenticationConfig(config);
+        ResourceConfig resourceConfig = new ResourceConfig();
+        resourceConfig.setAuthenticationConfig(authenticationConfig);
+        ArrayList<ResourceConfig> resourceConfigs = new ArrayList<>();
+        resourceConfigs.add(resourceConfig);
+        APIConfig apiConfig = Mockito.mock(APIConfig.class);
+        Mockito.when(apiConfig.getUuid()).thenReturn("api-uuid");
+        RequestContext requestContext = Mockito.mock(RequestContext.class);
+        Mockito.when(requestContext.getMatchedResourcePaths()).thenReturn(resourceConfigs);
+        Mockito.when(requestContext.getMatchedAPI()).thenReturn(apiConfig);
+        Mockito.when(requestContext.getHeaders()).thenReturn(headers);
+        Mockito.when(requestContext.getRequestMethod()).thenReturn("POST");
+        Mockito.when(requestContext.getRequestPath()).thenReturn("/orders?status=new");
+        return requestContext;
+    }
+
+    private static String formatDate(Instant instant) {
+        return DateTimeFormatter.RFC_1123_DATE_TIME.format(instant.atZone(ZoneOffset.UTC));
+    }
+
+    private static String sign(String algorithm, String key, String signingString) throws Exception {
+        Mac mac = Mac.getInstance(algorithm);
+        mac.init(new SecretKeySpec(key.getBytes(StandardCharsets.UTF_8), algorithm));
+        return Base64.getEncoder().encodeToString(mac.doFinal(signingString.getBytes(StandardCharsets.UTF_8)));
+    }
+}
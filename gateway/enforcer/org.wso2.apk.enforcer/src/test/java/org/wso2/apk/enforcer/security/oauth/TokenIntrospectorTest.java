@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.security.oauth;
+
+import com.nimbusds.jwt.JWTClaimsSet;
+import org.apache.commons.io.IOUtils;
+import org.apache.http.HttpEntity;
+import org.apache.http.HttpHeaders;
+import org.apache.http.StatusLine;
+import org.apache.http.client.HttpClient;
+import org.apache.http.client.methods.CloseableHttpResponse;
+import org.apache.http.client.methods.HttpPost;
+import org.apache.http.client.methods.HttpUriRequest;
+import org.junit.Assert;
+import org.junit.Test;
+import org.mockito.Mockito;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+import org.wso2.apk.enforcer.config.dto.IntrospectionConfigDto;
+
+import java.io.ByteArrayInputStream;
+import java.nio.charset.StandardCharsets;
+import java.util.ArrayList;
+import java.util.Base64;
+import java.util.List;
+import java.util.concurrent.atomic.AtomicInteger;
+
+public class TokenIntrospectorTest {
+
+    @Test
+    public void testActiveTokenIsCached() throws Exception {
+        List<HttpPost> requests = new ArrayList<>();
+        HttpClient httpClient = Mockito.mock(HttpClient.class);
+        long expiry = System.currentTimeMillis() / 1000 + 3600;
+        Mockito.when(httpClient.execute(Mockito.any(HttpUriRequest.class))).thenAnswer(invocation -> {
+            requests.add(invocation.getArgument(0));
+            return mockResponse(200, "{\"active\":true,\"client_id\":\"client\",\"scope\":\"read write\"," +
+                    "\"exp\":" + expiry + "}");
+        });
+        TokenIntrospector introspector = new TokenIntrospector(getConfig(300, 30), httpClient, 100);
+
+        JWTClaimsSet claimsSet = introspector.introspect("opaque-token");
+        Assert.assertNotNull(claimsSet);
+        Assert.assertEquals("client", claimsSet.getStringClaim("client_id"));
+        Assert.assertNotNull(introspector.introspect("opaque-token"));
+        Assert.assertEquals(1, requests.size());
+
+        HttpPost request = requests.get(0);
+        Assert.assertEquals("https://idp.example.com/introspect", request.getURI().toString());
+        Assert.assertEquals("Basic " + Base64.getEncoder().encodeToString("client:secret".getBytes(
+                StandardCharsets.UTF_8)), request.getFirstHeader(HttpHeaders.AUTHORIZATION).getValue());
+        String body = IOUtils.toString(request.getEntity().getContent(), StandardCharsets.UTF_8);
+        Assert.assertTrue(body.contains("token=opaque-token"));
+    }
+
+    @Test
+    public void testInactiveTokenIsCached() throws Exception {
+        AtomicInteger requestCount = new AtomicInteger();
+        HttpClient httpClient = Mockito.mock(HttpClient.class);
+        Mockito.when(httpClient.execute(Mockito.any(HttpUriRequest.class))).thenAnswer(invocation -> {
+            requestCount.incrementAndGet();
+            return mockResponse(200, "{\"active\":false}");
+        });
+        TokenIntrospector introspector = new TokenIntrospector(getConfig(300, 30), httpClient, 100);
+
+        Assert.assertNull(introspector.introspect("revoked-token"));
+        Assert.assertNull(introspector.introspect("revoked-token"));
+        Assert.assertEquals(1, requestCount.get());
+    }
+
+    @Test
+    public void testExpiredTokenIsInactive() throws Exception {
+        HttpClient httpClient = Mockito.mock(HttpClient.class);
+        long expiry = System.currentTimeMillis() / 1000 - 60;
+        Mockito.when(httpClient.execute(Mockito.any(HttpUriRequest.class))).thenAnswer(invocation ->
+                mockResponse(200, "{\"active\":true,\"exp\":" + expiry + "}"));
+        TokenIntrospector introspector = new TokenIntrospector(getConfig(300, 30), httpClient, 100);
+
+        Assert.assertNull(introspector.introspect("expired-token"));
+    }
+
+    @Test
+    public void testTokensAreNotCachedWithoutTTL() throws Exception {
+        AtomicInteger requestCount = new AtomicInteger();
+        HttpClient httpClient = Mockito.mock(HttpClient.class);
+        Mockito.when(httpClient.execute(Mockito.any(HttpUriRequest.class))).thenAnswer(invocation -> {
+            requestCount.incrementAndGet();
+            return mockResponse(200, "{\"active\":true}");
+        });
+        TokenIntrospector introspector = new TokenIntrospector(getConfig(0, 0), httpClient, 100);
+
+        Assert.assertNotNull(introspector.introspect("opaque-token"));
+        Assert.assertNotNull(introspector.introspect("opaque-token"));
+        Assert.assertEquals(2, requestCount.get());
+    }
+
+    @Test
+    public void testEndpointFailureIsNotCached() throws Exception {
+        AtomicInteger requestCount = new AtomicInteger();
+        HttpClient httpClient = Mockito.mock(HttpClient.class);
+        Mockito.when(httpClient.execute(Mockito.any(HttpUriRequest.class))).thenAnswer(invocation ->
+                requestCount.incrementAndGet() == 1 ? mockResponse(503, "") :
+                        mockResponse(200, "{\"active\":true}"));
+        TokenIntrospector introspector = new TokenIntrospector(getConfig(300, 30), httpClient, 100);
+
+        Assert.assertThrows(EnforcerException.class, () -> introspector.introspect("opaque-token"));
+        Assert.assertNotNull(introspector.introspect("opaque-token"));
+    }
+
+    @Test
+    public void testInvalidResponse() throws Exception {
+        HttpClient httpClient = Mockito.mock(HttpClient.class);
+        Mockito.when(httpClient.execute(Mockito.any(HttpUriRequest.class))).thenAnswer(invocation ->
+                mockResponse(200, "<html></html>"));
+        TokenIntrospector introspector = new TokenIntrospector(getConfig(300, 30), httpClient, 100);
+
+        Assert.assertThrows(EnforcerException.class, () -> introspector.introspect("opaque-token"));
+    }
+
+    private static IntrospectionConfigDto getConfig(int positiveCacheTTL, int negativeCacheTTL) {
+        IntrospectionConfigDto introspectionConfig = new IntrospectionConfigDto();
+        introspectionConfig.setUrl("https://idp.example.com/introspect");
+        introspectionConfig.setClientId("client");
+        introspectionConfig.setClientSecret("secret");
+        introspectionConfig.setPositiveCacheTTL(positiveCacheTTL);
+        introspectionConfig.setNegativeCacheTTL(negativeCacheTTL);
+        return introspectionConfig;
+    }
+
+    private static CloseableHttpResponse mockResponse(int statusCode, String body) throws Exception {
+        CloseableHttpResponse response = Mockito.mock(CloseableHttpResponse.class);
+        StatusLine statusLine = Mockito.mock(StatusLine.class);
+        Mockito.when(statusLine.getStatusCode()).thenReturn(statusCode);
+        Mockito.when(response.getStatusLine()).thenReturn(statusLine);
+        HttpEntity entity = Mockito.mock(HttpEntity.class);
+        Mockito.when(entity.getContent()).thenReturn(new ByteArrayInputStream(body.getBytes(StandardCharsets.UTF_8)));
+        Mockito.when(response.getEntity()).thenReturn(entity);
+        return response;
+    }
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+
+package org.wso2.apk.enforcer.tokenbudget;
+
+import org.junit.Assert;
+import org.junit.Before;
+import org.junit.Test;
+import org.mockito.MockedStatic;
+import org.mockito.Mockito;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+import org.wso2.apk.enforcer.commons.model.RequestContext;
+import org.wso2.apk.enforcer.constants.APIConstants;
+import org.wso2.apk.enforcer.constants.MetadataConstants;
+
+import java.util.HashMap;
+import java.util.Map;
+
+public class TokenBudgetFilterTest {
+
+    private TokenBudgetManager tokenBudgetManager;
+    private TokenBudget budget;
+    private RequestContext requestContext;
+    private Map<String, Object> properties;
+
+    @Before
+    public void setUp() throws EnforcerException {
+        tokenBudgetManager = Mockito.mock(TokenBudgetManager.class);
+        budget = new TokenBudget();
+        budget.setPeriod("Month");
+        budget.setTotalTokenCount(1000);
+        Mockito.when(tokenBudgetManager.getBudget("org1-gold")).thenReturn(budget);
+
+        Map<String, String> metadata = new HashMap<>();
+        metadata.put(MetadataConstants.ORGANIZATION_AND_AIRL_POLICY, "org1-gold");
+        metadata.put(MetadataConstants.TOKEN_BUDGET_APPLICATION, "app1");
+        metadata.put(MetadataConstants.TOKEN_BUDGET_SUBSCRIPTION, "sub1");
+        properties = new HashMap<>();
+        requestContext = Mockito.mock(RequestContext.class);
+        Mockito.when(requestContext.getMetadataMap()).thenReturn(metadata);
+        Mockito.when(requestContext.getProperties()).thenReturn(properties);
+    }
+
+    private boolean handleRequest() {
+        try (MockedStatic<TokenBudgetManager> mockedManager = Mockito.mockStatic(TokenBudgetManager.class)) {
+            mockedManager.when(TokenBudgetManager::getInstance).thenReturn(tokenBudgetManager);
+            return new TokenBudgetFilter().handleRequest(requestContext);
+        }
+    }
+
+    @Test
+    public void testRequestWithinBudget() throws EnforcerException {
+        Mockito.when(tokenBudgetManager.getConsumedTokens("org1-gold", budget, "app1", "sub1")).thenReturn(999L);
+        Assert.assertTrue(handleRequest());
+        Assert.assertTrue(properties.isEmpty());
+    }
+
+    @Test
+    public void testRequestOverBudget() throws EnforcerException {
+        Mockito.when(tokenBudgetManager.getConsumedTokens("org1-gold", budget, "app1", "sub1")).thenReturn(1000L);
+        Assert.assertFalse(handleRequest());
+        Assert.assertEquals(APIConstants.StatusCodes.THROTTLED.getCode(),
+                properties.get(APIConstants.MessageFormat.STATUS_CODE));
+    }
+
+    @Test
+    public void testPolicyWithoutBudget() throws EnforcerException {
+        requestContext.getMetadataMap().put(MetadataConstants.ORGANIZATION_AND_AIRL_POLICY, "org1-silver");
+        Assert.assertTrue(handleRequest());
+        Mockito.verify(tokenBudgetManager, Mockito.never()).getConsumedTokens(Mockito.anyString(), Mockito.any(),
+                Mockito.anyString(), Mockito.anyString());
+    }
+
+    @Test
+    public void testRequestWithoutSubscription() throws EnforcerException {
+        requestContext.getMetadataMap().remove(MetadataConstants.TOKEN_BUDGET_SUBSCRIPTION);
+        Assert.assertTrue(handleRequest());
+        Mockito.verify(tokenBudgetManager, Mockito.never()).getBudget(Mockito.anyString());
+    }
+
+    @Test
+    public void testRedisFailureAllowsRequestByDefault() throws EnforcerException {
+        Mockito.when(tokenBudgetManager.getConsumedTokens("org1-gold", budget, "app1", "sub1"))
+                .thenThrow(new EnforcerException("connection refused"));
+        Assert.assertTrue(handleRequest());
+        Assert.assertTrue(properties.isEmpty());
+    }
+
+    @Test
+    public void testRedisFailureRejectsRequestInFailureModeDeny() throws EnforcerException {
+        Mockito.when(tokenBudgetManager.isFailureModeDeny()).thenReturn(true);
+        Mockito.when(tokenBudgetManager.getBudget("org1-gold")).thenThrow(new EnforcerException("not loaded"));
+        Assert.assertFalse(handleRequest());
+        Assert.assertEquals(APIConstants.StatusCodes.SERVICE_UNAVAILABLE.getCode(),
+                properties.get(APIConstants.MessageFormat.STATUS_CODE));
+    }
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+
+package org.wso2.apk.enforcer.tokenbudget;
+
+import org.junit.Assert;
+import org.junit.Before;
+import org.junit.Test;
+import org.mockito.Mockito;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+import redis.clients.jedis.Jedis;
+import redis.clients.jedis.JedisPool;
+import redis.clients.jedis.exceptions.JedisConnectionException;
+
+import java.time.ZoneOffset;
+import java.time.ZonedDateTime;
+import java.util.Map;
+
+public class TokenBudgetManagerTest {
+
+    private static final String POLICIES_KEY = "wso2:apk:token_budget:policies";
+    private static final String USAGE_KEY_PREFIX = "wso2:apk:token_budget:usage:";
+    private static final String ALERTS_CHANNEL = "token-budget-alerts";
+
+    private Jedis jedis;
+    private TokenBudgetManager tokenBudgetManager;
+
+    @Before
+    public void setUp() {
+        jedis = Mockito.mock(Jedis.class);
+        JedisPool jedisPool = Mockito.mock(JedisPool.class);
+        Mockito.when(jedisPool.getResource()).thenReturn(jedis);
+        Mockito.when(jedis.hgetAll(POLICIES_KEY)).thenReturn(Map.of(
+                "org1-gold", "{\"period\":\"Month\",\"scope\":\"Application\",\"totalTokenCount\":1000,"
+                        + "\"alertThresholds\":[80,100]}",
+                "org1-silver", "{\"period\":\"Quarter\",\"scope\":\"Subscription\",\"totalTokenCount\":500}",
+                "org1-invalid", "not json"));
+        tokenBudgetManager = new TokenBudgetManager(jedisPool, ALERTS_CHANNEL, false);
+    }
+
+    private static String currentMonth() {
+        ZonedDateTime now = ZonedDateTime.now(ZoneOffset.UTC);
+        return String.format("%d-%02d", now.getYear(), now.getMonthValue());
+    }
+
+    private static String currentQuarter() {
+        ZonedDateTime now = ZonedDateTime.now(ZoneOffset.UTC);
+        return String.format("%d-Q%d", now.getYear(), (now.getMonthValue() - 1) / 3 + 1);
+    }
+
+    @Test
+    public void testGetBudget() throws EnforcerException {
+        TokenBudget gold = tokenBudgetManager.getBudget("org1-gold");
+        Assert.assertNotNull(gold);
+        Assert.assertEquals("Month", gold.getPeriod());
+        Assert.assertFalse(gold.isSubscriptionScoped());
+        Assert.assertEquals(1000, gold.getTotalTokenCount());
+        Assert.assertEquals(2, gold.getAlertThresholds().size());
+
+        TokenBudget silver = tokenBudgetManager.getBudget("org1-silver");
+        Assert.assertNotNull(silver);
+        Assert.assertTrue(silver.isSubscriptionScoped());
+        Assert.assertTrue(silver.getAlertThresholds().isEmpty());
+
+        Assert.assertNull(tokenBudgetManager.getBudget("org1-invalid"));
+        Assert.assertNull(tokenBudgetManager.getBudget("org1-bronze"));
+        // the budgets are loaded once within the refresh interval
+        Mockito.verify(jedis, Mockito.times(1)).hgetAll(POLICIES_KEY);
+    }
+
+    @Test
+    public void testGetBudgetWhenRedisIsNotReachable() {
+        Mockito.when(jedis.hgetAll(POLICIES_KEY)).thenThrow(new JedisConnectionException("connection refused"));
+        Assert.assertThrows(EnforcerException.class, () -> tokenBudgetManager.getBudget("org1-gold"));
+    }
+
+    @Test
+    public void testGetConsumedTokens() throws EnforcerException {
+        TokenBudget gold = tokenBudgetManager.getBudget("org1-gold");
+        TokenBudget silver = tokenBudgetManager.getBudget("org1-silver");
+        Mockito.when(jedis.get(USAGE_KEY_PREFIX + "org1-gold:app1:" + currentMonth())).thenReturn("120");
+        Mockito.when(jedis.get(USAGE_KEY_PREFIX + "org1-silver:app1:sub1:" + currentQuarter())).thenReturn("30");
+
+        Assert.assertEquals(120, tokenBudgetManager.getConsumedTokens("org1-gold", gold, "app1", "sub1"));
+        Assert.assertEquals(120, tokenBudgetManager.getConsumedTokens("org1-gold", gold, "app1", "sub2"));
+        Assert.assertEquals(30, tokenBudgetManager.getConsumedTokens("org1-silver", silver, "app1", "sub1"));
+        Assert.assertEquals(0, tokenBudgetManager.getConsumedTokens("org1-silver", silver, "app1", "sub2"));
+    }
+
+    @Test
+    public void testGetConsumedTokensWhenRedisIsNotReachable() throws EnforcerException {
+        TokenBudget gold = tokenBudgetManager.getBudget("org1-gold");
+        Mockito.when(jedis.get(Mockito.anyString())).thenThrow(new JedisConnectionException("connection refused"));
+        Assert.assertThrows(EnforcerException.class,
+                () -> tokenBudgetManager.getConsumedTokens("org1-gold", gold, "app1", "sub1"));
+    }
+
+    @Test
+    public void testConsumeRaisesAlertsOnce() throws EnforcerException {
+        TokenBudget gold = tokenBudgetManager.getBudget("org1-gold");
+        String usageKey = USAGE_KEY_PREFIX + "org1-gold:app1:" + currentMonth();
+
+        Mockito.when(jedis.incrBy(usageKey, 100L)).thenReturn(850L);
+        tokenBudgetManager.consume("org1-gold", gold, "app1", "sub1", 100);
+        Mockito.verify(jedis).expireAt(Mockito.eq(usageKey), Mockito.anyLong());
+        Mockito.verify(jedis, Mockito.times(1)).publish(Mockito.eq(ALERTS_CHANNEL),
+                Mockito.contains("\"threshold\":80"));
+
+        // the threshold already crossed is not alerted again
+        Mockito.when(jedis.incrBy(usageKey, 50L)).thenReturn(900L);
+        tokenBudgetManager.consume("org1-gold", gold, "app1", "sub1", 50);
+        Mockito.verify(jedis, Mockito.times(1)).publish(Mockito.eq(ALERTS_CHANNEL), Mockito.anyString());
+
+        Mockito.when(jedis.incrBy(usageKey, 200L)).thenReturn(1100L);
+        tokenBudgetManager.consume("org1-gold", gold, "app1", "sub1", 200);
+        Mockito.verify(jedis, Mockito.times(1)).publish(Mockito.eq(ALERTS_CHANNEL),
+                Mockito.contains("\"threshold\":100"));
+    }
+
+    @Test
+    public void testConsumeIgnoresEmptyUsage() throws EnforcerException {
+        TokenBudget gold = tokenBudgetManager.getBudget("org1-gold");
+        tokenBudgetManager.consume("org1-gold", gold, "app1", "sub1", 0);
+        Mockito.verify(jedis, Mockito.never()).incrBy(Mockito.anyString(), Mockito.anyLong());
+    }
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied. See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package org.wso2.apk.enforcer.util;
+
+import org.apache.http.HttpEntity;
+import org.apache.http.StatusLine;
+import org.apache.http.client.HttpClient;
+import org.apache.http.client.methods.CloseableHttpResponse;
+import org.apache.http.client.methods.HttpUriRequest;
+import org.junit.Assert;
+import org.junit.Test;
+import org.mockito.Mockito;
+import org.wso2.apk.enforcer.commons.exception.EnforcerException;
+
+import java.io.ByteArrayInputStream;
+import java.nio.charset.StandardCharsets;
+import java.util.HashMap;
+import java.util.Map;
+import java.util.concurrent.CompletableFuture;
+import java.util.concurrent.CountDownLatch;
+import java.util.concurrent.TimeUnit;
+import java.util.concurrent.atomic.AtomicInteger;
+
+public class OAuth2TokenClientTest {
+
+    @Test
+    public void testTokenIsCached() throws Exception {
+        AtomicInteger tokenCount = new AtomicInteger();
+        HttpClient httpClient = Mockito.mock(HttpClient.class);
+        Mockito.when(httpClient.execute(Mockito.any(HttpUriRequest.class))).thenAnswer(invocation ->
+                mockResponse(200, "{\"access_token\":\"token-" + tokenCount.incrementAndGet() +
+                        "\",\"expires_in\":3600}"));
+        OAuth2TokenClient tokenClient = new OAuth2TokenClient(httpClient);
+
+        Map<String, String> parameters = getParameters("https://idp.example.com/token", "30");
+        Assert.assertEquals("token-1", tokenClient.getAccessToken(parameters));
+        Assert.assertEquals("token-1", tokenClient.getAccessToken(parameters));
+        Assert.assertEquals(1, tokenCount.get());
+
+        // tokens of different scopes are cached separately
+        parameters.put("scopes", "orders:read");
+        Assert.assertEquals("token-2", tokenClient.getAccessToken(parameters));
+    }
+
+    @Test
+    public void testRefreshSkewIsClamped() throws Exception {
+        AtomicInteger tokenCount = new AtomicInteger();
+        HttpClient httpClient = Mockito.mock(HttpClient.class);
+        Mockito.when(httpClient.execute(Mockito.any(HttpUriRequest.class))).thenAnswer(invocation ->
+                mockResponse(200, "{\"access_token\":\"token-" + tokenCount.incrementAndGet() +
+                        "\",\"expires_in\":60}"));
+        OAuth2TokenClient tokenClient = new OAuth2TokenClient(httpClient);
+
+        // a refresh skew longer than the lifetime of the token should not make every request obtain a token
+        Map<String, String> parameters = getParameters("https://idp.example.com/token", "120");
+        Assert.assertEquals("token-1", tokenClient.getAccessToken(parameters));
+        Assert.assertEquals("token-1", tokenClient.getAccessToken(parameters));
+        Assert.assertEquals(1, tokenCount.get());
+    }
+
+    @Test
+    public void testTokenEndpointFailure() throws Exception {
+        HttpClient httpClient = Mockito.mock(HttpClient.class);
+        Mockito.when(httpClient.execute(Mockito.any(HttpUriRequest.class))).thenAnswer(invocation ->
+                mockResponse(500, "{}"));
+        OAuth2TokenClient tokenClient = new OAuth2TokenClient(httpClient);
+        Assert.assertThrows(EnforcerException.class, () ->
+                tokenClient.getAccessToken(getParameters("https://idp.example.com/token", "0")));
+    }
+
+    @Test
+    public void testSlowTokenEndpointDoesNotBlockOtherBackends() throws Exception {
+        CountDownLatch slowRequestStarted = new CountDownLatch(1);
+        CountDownLatch releaseSlowRequest = new CountDownLatch(1);
+        HttpClient httpClient = Mockito.mock(HttpClient.class);
+        Mockito.when(httpClient.execute(Mockito.any(HttpUriRequest.class))).thenAnswer(invocation -> {
+            HttpUriRequest request = invocation.getArgument(0);
+            if (request.getURI().getHost().startsWith("slow")) {
+                slowRequestStarted.countDown();
+                releaseSlowRequest.await(10, TimeUnit.SECONDS);
+                return mockResponse(200, "{\"access_token\":\"slow-token\"}");
+            }
+            return mockResponse(200, "{\"access_token\":\"fast-token\"}");
+        });
+        OAuth2TokenClient tokenClient = new OAuth2TokenClient(httpClient);
+
+        CompletableFuture<String> slowToken = CompletableFuture.supplyAsync(() -> {
+            try {
+                return tokenClient.getAccessToken(getParameters("https://slow.example.com/token", "0"));
+            } catch (EnforcerException e) {
+                throw new IllegalStateException(e);
+            }
+        });
+        Assert.assertTrue(slowRequestStarted.await(10, TimeUnit.SECONDS));
+        CompletableFuture<String> fastToken = CompletableFuture.supplyAsync(() -> {
+            try {
+                return tokenClient.getAccessToken(getParameters("https://fast.example.com/token", "0"));
+            } catch (EnforcerException e) {
+                throw new IllegalStateException(e);
+            }
+        });
+        Assert.assertEquals("fast-token", fastToken.get(5, TimeUnit.SECONDS));
+        Assert.assertFalse(slowToken.isDone());
+        releaseSlowRequest.countDown();
+        Assert.assertEquals("slow-token", slowToken.get(5, TimeUnit.SECONDS));
+    }
+
+    private static Map<String, String> getParameters(String tokenEndpoint, String refreshSkew) {
+        Map<String, String> parameters = new HashMap<>();
+        parameters.put("tokenEndpoint", tokenEndpoint);
+        parameters.put("clientId", "client");
+        parameters.put("clientSecret", "secret");
+        parameters.put("refreshSkew", refreshSkew);
+        return parameters;
+    }
+
+    private static CloseableHttpResponse mockResponse(int statusCode, String body) throws Exception {
+        CloseableHttpResponse response = Mockito.mock(CloseableHttpResponse.class);
+        StatusLine statusLine = Mockito.mock(StatusLine.class);
+        Mockito.when(statusLine.getStatusCode()).thenReturn(statusCode);
+        Mockito.when(response.getStatusLine()).thenReturn(statusLine);
+        HttpEntity entity = Mockito.mock(HttpEntity.class);
+        Mockito.when(entity.getContent()).thenReturn(new ByteArrayInputStream(body.getBytes(StandardCharsets.UTF_8)));
+        Mockito.when(response.getEntity()).thenReturn(entity);
+        return response;
+    }
+}
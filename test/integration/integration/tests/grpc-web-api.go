@@ -0,0 +1,90 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/wso2/apk/test/integration/integration/utils/grpc-code/student"
+	"github.com/wso2/apk/test/integration/integration/utils/grpcutils"
+	"github.com/wso2/apk/test/integration/integration/utils/http"
+	"github.com/wso2/apk/test/integration/integration/utils/suite"
+)
+
+func init() {
+	IntegrationTests = append(IntegrationTests, GRPCWebAPI)
+}
+
+// GRPCWebAPI tests gRPC API invoked by gRPC-Web clients
+var GRPCWebAPI = suite.IntegrationTest{
+	ShortName:   "GRPCWebAPI",
+	Description: "Tests gRPC API with gRPC-Web enabled",
+	Manifests:   []string{"tests/grpc-web-api.yaml"},
+	Test: func(t *testing.T, suite *suite.IntegrationTestSuite) {
+		gwAddr := "grpc-web.test.gw.wso2.com:9095"
+		methodPath := "/org.apk.web.v1.student_service.StudentService/GetStudent"
+
+		t.Run("Preflight request of gRPC-Web client", func(t *testing.T) {
+			http.MakeRequestAndExpectEventuallyConsistentResponse(t, suite.RoundTripper, suite.TimeoutConfig, gwAddr,
+				http.ExpectedResponse{
+					Request: http.Request{
+						Host: "grpc-web.test.gw.wso2.com",
+						Path: methodPath,
+						Headers: map[string]string{
+							"origin":                         "apk.wso2.com",
+							"access-control-request-method":  "POST",
+							"access-control-request-headers": "content-type,x-grpc-web,x-user-agent",
+						},
+						Method: "OPTIONS",
+					},
+					ExpectedRequest: &http.ExpectedRequest{
+						Request: http.Request{
+							Host:   "",
+							Method: "OPTIONS",
+						},
+					},
+					Response: http.Response{
+						Headers: map[string]string{
+							"access-control-allow-origin":   "apk.wso2.com",
+							"access-control-allow-methods":  "POST",
+							"access-control-allow-headers":  "authorization, content-type, x-grpc-web, x-user-agent, grpc-timeout",
+							"access-control-expose-headers": "x-request-id, grpc-status, grpc-message, grpc-status-details-bin",
+						},
+						StatusCode: 200,
+					},
+				})
+		})
+
+		t.Run("Invoke gRPC API with gRPC-Web client", func(t *testing.T) {
+			tc := grpcutils.GRPCTestCase{
+				ExpectedResponse: grpcutils.ExpectedResponse{
+					Out: &student.StudentResponse{
+						Name: "Student",
+						Age:  10,
+					},
+					Err: nil,
+				},
+				ActualResponse: &student.StudentResponse{},
+				Name:           "Get Student Details over gRPC-Web",
+				Satisfier:      student.StudentResponseSatisfier{},
+			}
+			grpcutils.InvokeGRPCWebClientUntilSatisfied(gwAddr, methodPath, &student.StudentRequest{Id: 1234}, t, tc,
+				tc.Satisfier)
+		})
+	},
+}
@@ -0,0 +1,138 @@
+/*
+ *  Copyright (c) 2024, WSO2 LLC. (http://www.wso2.org) All Rights Reserved.
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package grpcutils
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	grpcWebContentType = "application/grpc-web+proto"
+	// grpcWebTrailerFlag marks the frame of a gRPC-Web response which carries the trailers
+	grpcWebTrailerFlag = 0x80
+)
+
+// InvokeGRPCWebClientUntilSatisfied invokes the given gRPC method of the gateway as a gRPC-Web client, until the
+// response satisfies the test case.
+func InvokeGRPCWebClientUntilSatisfied(gwAddr, path string, in proto.Message, t *testing.T, testCase GRPCTestCase,
+	satisfier ResponseSatisfier) {
+	//(delay to allow CRs to be applied)
+	time.Sleep(5 * time.Second)
+
+	attempt := 0
+	maxAttempts := 4
+	timeoutDuration := 50 * time.Second
+	for attempt < maxAttempts {
+		t.Logf("Attempt %d to invoke gRPC-Web client...", attempt+1)
+		out := proto.Clone(testCase.ActualResponse.(proto.Message))
+		proto.Reset(out)
+		err := InvokeGRPCWebClient(gwAddr, path, in, out)
+		if err != nil {
+			t.Logf("Error on attempt %d: %v", attempt+1, err)
+		} else if satisfier.IsSatisfactory(out, testCase.ExpectedResponse) {
+			return
+		}
+
+		if attempt < maxAttempts-1 {
+			t.Logf("Waiting %s seconds before next attempt...", timeoutDuration)
+			time.Sleep(timeoutDuration)
+		}
+		attempt++
+	}
+
+	t.Logf("Failed to receive a satisfactory response after %d attempts", maxAttempts)
+	t.Fail()
+}
+
+// InvokeGRPCWebClient sends the given message to the given gRPC method of the gateway over gRPC-Web, as a browser
+// client does, and reads the response message into out.
+func InvokeGRPCWebClient(gwAddr, path string, in proto.Message, out proto.Message) error {
+	payload, err := proto.Marshal(in)
+	if err != nil {
+		return err
+	}
+	var body bytes.Buffer
+	body.WriteByte(0)
+	if err := binary.Write(&body, binary.BigEndian, uint32(len(payload))); err != nil {
+		return err
+	}
+	body.Write(payload)
+
+	request, err := http.NewRequest(http.MethodPost, "https://"+gwAddr+path, &body)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("content-type", grpcWebContentType)
+	request.Header.Set("x-grpc-web", "1")
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", response.StatusCode)
+	}
+	if !strings.HasPrefix(response.Header.Get("content-type"), "application/grpc-web") {
+		return fmt.Errorf("unexpected content type: %s", response.Header.Get("content-type"))
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	// A response without a message carries the status in the headers
+	grpcStatus := response.Header.Get("grpc-status")
+	for len(responseBody) >= 5 {
+		flag := responseBody[0]
+		length := binary.BigEndian.Uint32(responseBody[1:5])
+		if uint32(len(responseBody)-5) < length {
+			return fmt.Errorf("incomplete gRPC-Web frame")
+		}
+		frame := responseBody[5 : 5+length]
+		responseBody = responseBody[5+length:]
+		if flag&grpcWebTrailerFlag == 0 {
+			if err := proto.Unmarshal(frame, out); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, trailer := range strings.Split(string(frame), "\r\n") {
+			if name, value, found := strings.Cut(trailer, ":"); found && strings.EqualFold(name, "grpc-status") {
+				grpcStatus = strings.TrimSpace(value)
+			}
+		}
+	}
+	if grpcStatus != "0" {
+		return fmt.Errorf("unexpected grpc status: %s", grpcStatus)
+	}
+	return nil
+}
@@ -40,6 +40,7 @@ sudo echo "$IP backend-api-key-security.test.gw.wso2.com" | sudo tee -a /etc/hos
 sudo echo "$IP custom-auth-header.test.gw.wso2.com" | sudo tee -a /etc/hosts
 sudo echo "$IP gql.test.gw.wso2.com" | sudo tee -a /etc/hosts
 sudo echo "$IP grpc.test.gw.wso2.com" | sudo tee -a /etc/hosts
+sudo echo "$IP grpc-web.test.gw.wso2.com" | sudo tee -a /etc/hosts
 sudo echo "$IP api-level-jwt.test.gw.wso2.com" | sudo tee -a /etc/hosts
 sudo echo "$IP resource-level-jwt.test.gw.wso2.com" | sudo tee -a /etc/hosts
 sudo echo "255.255.255.255 broadcasthost" | sudo tee -a /etc/hosts